@@ -0,0 +1,56 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func listRecordsEndpoint(svc audit.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRecordsReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListRecords(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := recordsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Records: []recordRes{},
+		}
+
+		for _, r := range page.Records {
+			res.Records = append(res.Records, buildRecordRes(r))
+		}
+
+		return res, nil
+	}
+}
+
+func buildRecordRes(r audit.Record) recordRes {
+	return recordRes{
+		ID:         r.ID,
+		Actor:      r.Actor,
+		Action:     r.Action,
+		EntityType: r.EntityType,
+		EntityID:   r.EntityID,
+		OrgID:      r.OrgID,
+		Before:     r.Before,
+		After:      r.After,
+		SourceIP:   r.SourceIP,
+		CreatedAt:  r.CreatedAt,
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	httpapi "github.com/MainfluxLabs/mainflux/audit/api/http"
+	"github.com/MainfluxLabs/mainflux/audit/mocks"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminToken = "admin-token"
+	ownerToken = "owner-token"
+	userToken  = "user-token"
+	wrongValue = "wrong_value"
+	adminID    = "0ee2aa1a-2ddf-4bb5-91d5-9a1b3c1b4f3e"
+	ownerID    = "574106f7-030e-4881-8ab0-151195c29f94"
+	userID     = "ecf9e48b-ba3b-41c4-82a9-72e063b17868"
+	orgID      = "5384fb1c-d0ae-4cbe-be52-c54223150fe0"
+	thingID    = "b4c7cd8a-8de0-4c79-9c57-0e66d6b1f4ab"
+	n          = 10
+)
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type recordRes struct {
+	ID         string                 `json:"id"`
+	Actor      string                 `json:"actor"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   string                 `json:"entity_id"`
+	OrgID      string                 `json:"org_id"`
+	Before     map[string]interface{} `json:"before"`
+	After      map[string]interface{} `json:"after"`
+	SourceIP   string                 `json:"source_ip"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+type recordsPageRes struct {
+	Total   uint64      `json:"total"`
+	Offset  uint64      `json:"offset"`
+	Limit   uint64      `json:"limit"`
+	Records []recordRes `json:"records"`
+}
+
+func newService() audit.Service {
+	auth := mocks.NewAuthService(adminID,
+		map[string]string{adminToken: adminID, ownerToken: ownerID, userToken: userID},
+		map[string]string{orgID: ownerID},
+	)
+
+	return audit.New(auth, mocks.NewRecordRepository(), uuid.NewMock())
+}
+
+func newServer(svc audit.Service) *httptest.Server {
+	logger := logger.NewMock()
+	mux := httpapi.MakeHandler(mocktracer.New(), svc, logger)
+	return httptest.NewServer(mux)
+}
+
+func TestListRecords(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	start := time.Unix(1700000000, 0)
+	for i := 0; i < n; i++ {
+		r := audit.Record{
+			Actor:      userID,
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityThing,
+			EntityID:   thingID,
+			Before:     map[string]interface{}{"name": "thing"},
+			After:      map[string]interface{}{"name": "renamed"},
+			SourceIP:   "10.0.0.1",
+			CreatedAt:  start.Add(time.Duration(i) * time.Minute),
+		}
+		if i%2 == 0 {
+			r.Actor = ownerID
+			r.EntityType = audit.EntityOrg
+			r.EntityID = orgID
+			r.OrgID = orgID
+		}
+		err := svc.SaveRecord(context.Background(), r)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		auth   string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list records as root admin",
+			url:    fmt.Sprintf("%s/audit?offset=%d&limit=%d", ts.URL, 0, n),
+			auth:   adminToken,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list records with default pagination",
+			url:    fmt.Sprintf("%s/audit", ts.URL),
+			auth:   adminToken,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list half of the records",
+			url:    fmt.Sprintf("%s/audit?offset=%d&limit=%d", ts.URL, n/2, n),
+			auth:   adminToken,
+			status: http.StatusOK,
+			size:   n / 2,
+		},
+		{
+			desc:   "list records filtered by actor and action",
+			url:    fmt.Sprintf("%s/audit?actor=%s&action=%s", ts.URL, userID, audit.ActionUpdate),
+			auth:   adminToken,
+			status: http.StatusOK,
+			size:   n / 2,
+		},
+		{
+			desc:   "list records filtered by entity",
+			url:    fmt.Sprintf("%s/audit?entity_type=%s&entity_id=%s", ts.URL, audit.EntityThing, thingID),
+			auth:   adminToken,
+			status: http.StatusOK,
+			size:   n / 2,
+		},
+		{
+			desc:   "list records filtered by time",
+			url:    fmt.Sprintf("%s/audit?from=%d&to=%d", ts.URL, start.Unix(), start.Add(2*time.Minute).Unix()),
+			auth:   adminToken,
+			status: http.StatusOK,
+			size:   3,
+		},
+		{
+			desc:   "list org records as org owner",
+			url:    fmt.Sprintf("%s/audit?org_id=%s", ts.URL, orgID),
+			auth:   ownerToken,
+			status: http.StatusOK,
+			size:   n / 2,
+		},
+		{
+			desc:   "list all records as org owner",
+			url:    fmt.Sprintf("%s/audit", ts.URL),
+			auth:   ownerToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "list org records as user",
+			url:    fmt.Sprintf("%s/audit?org_id=%s", ts.URL, orgID),
+			auth:   userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "list records with limit greater than max",
+			url:    fmt.Sprintf("%s/audit?limit=%d", ts.URL, 110),
+			auth:   adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list records with invalid offset",
+			url:    fmt.Sprintf("%s/audit?offset=%s", ts.URL, wrongValue),
+			auth:   adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list records with invalid time",
+			url:    fmt.Sprintf("%s/audit?from=%s", ts.URL, wrongValue),
+			auth:   adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list records with inverted time range",
+			url:    fmt.Sprintf("%s/audit?from=%d&to=%d", ts.URL, start.Add(time.Hour).Unix(), start.Unix()),
+			auth:   adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list records with invalid auth token",
+			url:    fmt.Sprintf("%s/audit", ts.URL),
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list records with empty auth token",
+			url:    fmt.Sprintf("%s/audit", ts.URL),
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body recordsPageRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(body.Records), fmt.Sprintf("%s: expected %d records got %d", tc.desc, tc.size, len(body.Records)))
+	}
+}
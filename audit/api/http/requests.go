@@ -0,0 +1,33 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
+
+const maxLimitSize = 100
+
+type listRecordsReq struct {
+	token        string
+	pageMetadata audit.PageMetadata
+}
+
+func (req listRecordsReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	pm := req.pageMetadata
+	if !pm.From.IsZero() && !pm.To.IsZero() && pm.From.After(pm.To) {
+		return apiutil.ErrInvalidQueryParams
+	}
+
+	return nil
+}
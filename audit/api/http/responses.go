@@ -0,0 +1,49 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+)
+
+var _ mainflux.Response = (*recordsPageRes)(nil)
+
+type recordRes struct {
+	ID         string                 `json:"id"`
+	Actor      string                 `json:"actor"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   string                 `json:"entity_id"`
+	OrgID      string                 `json:"org_id,omitempty"`
+	Before     map[string]interface{} `json:"before,omitempty"`
+	After      map[string]interface{} `json:"after,omitempty"`
+	SourceIP   string                 `json:"source_ip,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type recordsPageRes struct {
+	pageRes
+	Records []recordRes `json:"records"`
+}
+
+func (res recordsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res recordsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res recordsPageRes) Empty() bool {
+	return false
+}
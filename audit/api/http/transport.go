@@ -0,0 +1,158 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kitot "github.com/go-kit/kit/tracing/opentracing"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/go-zoo/bone"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	contentType   = "application/json"
+	offsetKey     = "offset"
+	limitKey      = "limit"
+	actorKey      = "actor"
+	actionKey     = "action"
+	entityTypeKey = "entity_type"
+	entityIDKey   = "entity_id"
+	orgIDKey      = "org_id"
+	fromKey       = "from"
+	toKey         = "to"
+	defOffset     = 0
+	defLimit      = 10
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(tracer opentracing.Tracer, svc audit.Service, logger log.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
+	r := bone.New()
+
+	r.Get("/audit", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_records")(listRecordsEndpoint(svc)),
+		decodeListRecords,
+		encodeResponse,
+		opts...,
+	))
+
+	r.GetFunc("/health", mainflux.Health("audit"))
+	r.Handle("/metrics", promhttp.Handler())
+
+	return r
+}
+
+func decodeListRecords(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	pm := audit.PageMetadata{
+		Offset: o,
+		Limit:  l,
+	}
+
+	filters := map[string]*string{
+		actorKey:      &pm.Actor,
+		actionKey:     &pm.Action,
+		entityTypeKey: &pm.EntityType,
+		entityIDKey:   &pm.EntityID,
+		orgIDKey:      &pm.OrgID,
+	}
+	for key, val := range filters {
+		if *val, err = apiutil.ReadStringQuery(r, key, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	if pm.From, err = readTimeQuery(r, fromKey); err != nil {
+		return nil, err
+	}
+
+	if pm.To, err = readTimeQuery(r, toKey); err != nil {
+		return nil, err
+	}
+
+	req := listRecordsReq{
+		token:        apiutil.ExtractBearerToken(r),
+		pageMetadata: pm,
+	}
+
+	return req, nil
+}
+
+// readTimeQuery reads the time given in Unix seconds. Missing value is
+// returned as the zero time.
+func readTimeQuery(r *http.Request, key string) (time.Time, error) {
+	val, err := apiutil.ReadFloatQuery(r, key, 0)
+	if err != nil || val == 0 {
+		return time.Time{}, err
+	}
+
+	sec, frac := math.Modf(val)
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))), nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		err == apiutil.ErrLimitSize:
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrRetrieveEntity):
+		w.WriteHeader(http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
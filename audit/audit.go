@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// Actions of the audited operations.
+const (
+	ActionCreate     = "create"
+	ActionUpdate     = "update"
+	ActionUpdateKey  = "update_key"
+	ActionRemove     = "remove"
+	ActionConnect    = "connect"
+	ActionDisconnect = "disconnect"
+	ActionAssign     = "assign"
+	ActionUnassign   = "unassign"
+	ActionEnable     = "enable"
+	ActionDisable    = "disable"
+	ActionPassword   = "change_password"
+	ActionLock       = "lock"
+	ActionUnlock     = "unlock"
+)
+
+// Types of the audited entities.
+const (
+	EntityThing   = "thing"
+	EntityChannel = "channel"
+	EntityGroup   = "group"
+	EntityOrg     = "org"
+	EntityMember  = "member"
+	EntityKey     = "key"
+	EntityUser    = "user"
+	EntityConfig  = "config"
+	EntityPolicy  = "group_policy"
+)
+
+// Record represents an operation performed on an entity. Before and After
+// contain only the entity fields changed by the operation, so Before is
+// empty for the created entities and After is empty for the removed ones.
+type Record struct {
+	ID         string
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	OrgID      string
+	Before     map[string]interface{}
+	After      map[string]interface{}
+	SourceIP   string
+	CreatedAt  time.Time
+}
+
+// PageMetadata contains page metadata that helps navigation, and the
+// filters of the retrieved records. Zero values are not used as filters.
+type PageMetadata struct {
+	Total      uint64
+	Offset     uint64
+	Limit      uint64
+	Actor      string
+	Action     string
+	EntityType string
+	EntityID   string
+	OrgID      string
+	From       time.Time
+	To         time.Time
+}
+
+// RecordsPage contains page related metadata as well as a list of records
+// that belong to this page.
+type RecordsPage struct {
+	PageMetadata
+	Records []Record
+}
+
+// RecordRepository specifies an audit record persistence API.
+type RecordRepository interface {
+	// Save persists the record.
+	Save(ctx context.Context, record Record) error
+
+	// RetrieveAll retrieves the subset of records matching the page
+	// metadata filters, the most recent first.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (RecordsPage, error)
+}
+
+// Publisher specifies the API used by the audited services to hand the
+// records over to the audit service.
+type Publisher interface {
+	// Publish publishes the record of the performed operation.
+	Publish(ctx context.Context, record Record) error
+}
+
+// NewRecord returns the record of the action performed by the actor on the
+// entity. The entity states are reduced to the changed fields, and the
+// source IP is taken from the context.
+func NewRecord(ctx context.Context, actor, action, entityType, entityID string, before, after map[string]interface{}) Record {
+	b, a := Diff(before, after)
+
+	return Record{
+		Actor:      actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     b,
+		After:      a,
+		SourceIP:   SourceIP(ctx),
+		CreatedAt:  time.Now(),
+	}
+}
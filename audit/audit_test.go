@@ -0,0 +1,120 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		desc   string
+		before map[string]interface{}
+		after  map[string]interface{}
+		diffB  map[string]interface{}
+		diffA  map[string]interface{}
+	}{
+		{
+			desc:   "diff created entity",
+			before: nil,
+			after:  map[string]interface{}{"name": "thing"},
+			diffB:  map[string]interface{}{},
+			diffA:  map[string]interface{}{"name": "thing"},
+		},
+		{
+			desc:   "diff removed entity",
+			before: map[string]interface{}{"name": "thing"},
+			after:  nil,
+			diffB:  map[string]interface{}{"name": "thing"},
+			diffA:  map[string]interface{}{},
+		},
+		{
+			desc:   "diff updated entity",
+			before: map[string]interface{}{"name": "thing", "metadata": map[string]interface{}{"a": 1}},
+			after:  map[string]interface{}{"name": "renamed", "metadata": map[string]interface{}{"a": 1}},
+			diffB:  map[string]interface{}{"name": "thing"},
+			diffA:  map[string]interface{}{"name": "renamed"},
+		},
+		{
+			desc:   "diff unchanged entity",
+			before: map[string]interface{}{"name": "thing"},
+			after:  map[string]interface{}{"name": "thing"},
+			diffB:  map[string]interface{}{},
+			diffA:  map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		b, a := audit.Diff(tc.before, tc.after)
+		assert.Equal(t, tc.diffB, b, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.diffB, b))
+		assert.Equal(t, tc.diffA, a, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.diffA, a))
+	}
+}
+
+func TestPopulateSourceIP(t *testing.T) {
+	cases := []struct {
+		desc       string
+		remoteAddr string
+		headers    map[string]string
+		ip         string
+	}{
+		{
+			desc:       "populate connection peer address",
+			remoteAddr: "10.0.0.1:51234",
+			ip:         "10.0.0.1",
+		},
+		{
+			desc:       "populate real IP header address",
+			remoteAddr: "10.0.0.1:51234",
+			headers:    map[string]string{"X-Real-IP": "192.168.1.10"},
+			ip:         "192.168.1.10",
+		},
+		{
+			desc:       "populate forwarded header address",
+			remoteAddr: "10.0.0.1:51234",
+			headers:    map[string]string{"X-Forwarded-For": "203.0.113.5", "X-Real-IP": "192.168.1.10"},
+			ip:         "203.0.113.5",
+		},
+		{
+			desc:       "populate forwarded header address appended by proxy",
+			remoteAddr: "10.0.0.1:51234",
+			headers:    map[string]string{"X-Forwarded-For": "198.51.100.7, 203.0.113.5", "X-Real-IP": "203.0.113.5"},
+			ip:         "203.0.113.5",
+		},
+	}
+
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tc.remoteAddr
+		for k, v := range tc.headers {
+			req.Header.Set(k, v)
+		}
+
+		ip := audit.SourceIP(audit.PopulateSourceIP(context.Background(), req))
+		assert.Equal(t, tc.ip, ip, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.ip, ip))
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	ctx := audit.WithSourceIP(context.Background(), "10.0.0.1")
+	before := map[string]interface{}{"name": "thing", "metadata": map[string]interface{}{"type": "sensor"}}
+	after := map[string]interface{}{"name": "renamed", "metadata": map[string]interface{}{"type": "sensor"}}
+
+	record := audit.NewRecord(ctx, "actor", audit.ActionUpdate, audit.EntityThing, "id", before, after)
+	assert.Equal(t, "actor", record.Actor, fmt.Sprintf("expected actor %s got %s\n", "actor", record.Actor))
+	assert.Equal(t, audit.ActionUpdate, record.Action, fmt.Sprintf("expected action %s got %s\n", audit.ActionUpdate, record.Action))
+	assert.Equal(t, audit.EntityThing, record.EntityType, fmt.Sprintf("expected entity type %s got %s\n", audit.EntityThing, record.EntityType))
+	assert.Equal(t, "id", record.EntityID, fmt.Sprintf("expected entity ID %s got %s\n", "id", record.EntityID))
+	assert.Equal(t, map[string]interface{}{"name": "thing"}, record.Before, fmt.Sprintf("expected only changed fields before got %v\n", record.Before))
+	assert.Equal(t, map[string]interface{}{"name": "renamed"}, record.After, fmt.Sprintf("expected only changed fields after got %v\n", record.After))
+	assert.Equal(t, "10.0.0.1", record.SourceIP, fmt.Sprintf("expected source IP %s got %s\n", "10.0.0.1", record.SourceIP))
+	assert.False(t, record.CreatedAt.IsZero(), "expected creation time to be set")
+}
@@ -0,0 +1,27 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import "reflect"
+
+// Diff returns the fields of the entity state before and after the
+// operation which differ. The fields missing in one of the states are
+// considered changed.
+func Diff(before, after map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	b := map[string]interface{}{}
+	a := map[string]interface{}{}
+
+	for k, v := range before {
+		if av, ok := after[k]; !ok || !reflect.DeepEqual(v, av) {
+			b[k] = v
+		}
+	}
+	for k, v := range after {
+		if bv, ok := before[k]; !ok || !reflect.DeepEqual(v, bv) {
+			a[k] = v
+		}
+	}
+
+	return b, a
+}
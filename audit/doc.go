@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit contains the domain concept definitions needed to support
+// Mainflux audit service functionality, together with the helpers used by
+// the audited services to describe the performed operations.
+package audit
@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+)
+
+var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
+
+type authServiceMock struct {
+	adminID string
+	users   map[string]string
+	owners  map[string]string
+}
+
+// NewAuthService creates mock of auth service. Users are identified by the
+// tokens mapped to their IDs, and the orgs are mapped to their owner IDs.
+func NewAuthService(adminID string, users, owners map[string]string) mainflux.AuthServiceClient {
+	return &authServiceMock{
+		adminID: adminID,
+		users:   users,
+		owners:  owners,
+	}
+}
+
+func (svc authServiceMock) Identify(_ context.Context, in *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	if id, ok := svc.users[in.GetValue()]; ok {
+		return &mainflux.UserIdentity{Id: id}, nil
+	}
+	return nil, errors.ErrAuthentication
+}
+
+func (svc authServiceMock) IdentifyVerification(_ context.Context, _ *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Issue(_ context.Context, _ *mainflux.IssueReq, _ ...grpc.CallOption) (*mainflux.Token, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Authorize(_ context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	id, ok := svc.users[req.GetToken()]
+	if !ok {
+		return &empty.Empty{}, errors.ErrAuthentication
+	}
+
+	switch {
+	case id == svc.adminID:
+		return &empty.Empty{}, nil
+	case req.GetSubject() == auth.OrgSubject && svc.owners[req.GetObject()] == id:
+		return &empty.Empty{}, nil
+	default:
+		return &empty.Empty{}, errors.ErrAuthorization
+	}
+}
+
+func (svc authServiceMock) Members(_ context.Context, _ *mainflux.MembersReq, _ ...grpc.CallOption) (*mainflux.MembersRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) Assign(_ context.Context, _ *mainflux.Assignment, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AddPolicy(_ context.Context, _ *mainflux.PolicyReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) AssignRole(_ context.Context, _ *mainflux.AssignRoleReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RetrieveRole(_ context.Context, _ *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (*mainflux.RetrieveRoleRes, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) JoinOrg(_ context.Context, _ *mainflux.JoinOrgReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RetrieveGroupOrg(_ context.Context, _ *mainflux.GroupOrgReq, _ ...grpc.CallOption) (*mainflux.OrgID, error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RetrieveMemberGroups(_ context.Context, _ *mainflux.Token, _ ...grpc.CallOption) (*mainflux.MemberGroupsRes, error) {
+	panic("not implemented")
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+)
+
+// Publisher represents audit record publisher mock which keeps the
+// published records.
+type Publisher interface {
+	audit.Publisher
+
+	// Records returns the records published so far.
+	Records() []audit.Record
+}
+
+type publisherMock struct {
+	mu      sync.Mutex
+	records []audit.Record
+}
+
+// NewPublisher returns mock audit record publisher.
+func NewPublisher() Publisher {
+	return &publisherMock{}
+}
+
+func (pub *publisherMock) Publish(_ context.Context, record audit.Record) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.records = append(pub.records, record)
+	return nil
+}
+
+func (pub *publisherMock) Records() []audit.Record {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	return append([]audit.Record{}, pub.records...)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ audit.RecordRepository = (*recordRepositoryMock)(nil)
+
+type recordRepositoryMock struct {
+	mu      sync.Mutex
+	records map[string]audit.Record
+}
+
+// NewRecordRepository creates in-memory audit record repository.
+func NewRecordRepository() audit.RecordRepository {
+	return &recordRepositoryMock{
+		records: make(map[string]audit.Record),
+	}
+}
+
+func (rrm *recordRepositoryMock) Save(_ context.Context, record audit.Record) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	if _, ok := rrm.records[record.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	rrm.records[record.ID] = record
+	return nil
+}
+
+func (rrm *recordRepositoryMock) RetrieveAll(_ context.Context, pm audit.PageMetadata) (audit.RecordsPage, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rs := []audit.Record{}
+	for _, r := range rrm.records {
+		if match(r, pm) {
+			rs = append(rs, r)
+		}
+	}
+
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].CreatedAt.After(rs[j].CreatedAt)
+	})
+
+	total := uint64(len(rs))
+	first := pm.Offset
+	last := first + pm.Limit
+	if last > total || pm.Limit == 0 {
+		last = total
+	}
+	if first > last {
+		first = last
+	}
+
+	pm.Total = total
+	return audit.RecordsPage{
+		PageMetadata: pm,
+		Records:      rs[first:last],
+	}, nil
+}
+
+func match(r audit.Record, pm audit.PageMetadata) bool {
+	switch {
+	case pm.Actor != "" && r.Actor != pm.Actor,
+		pm.Action != "" && r.Action != pm.Action,
+		pm.EntityType != "" && r.EntityType != pm.EntityType,
+		pm.EntityID != "" && r.EntityID != pm.EntityID,
+		pm.OrgID != "" && r.OrgID != pm.OrgID,
+		!pm.From.IsZero() && r.CreatedAt.Before(pm.From),
+		!pm.To.IsZero() && r.CreatedAt.After(pm.To):
+		return false
+	default:
+		return true
+	}
+}
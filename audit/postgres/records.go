@@ -0,0 +1,234 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ audit.RecordRepository = (*recordRepository)(nil)
+
+type recordRepository struct {
+	db Database
+}
+
+// NewRecordRepository instantiates a PostgreSQL implementation of audit
+// record repository.
+func NewRecordRepository(db Database) audit.RecordRepository {
+	return &recordRepository{
+		db: db,
+	}
+}
+
+func (rr recordRepository) Save(ctx context.Context, record audit.Record) error {
+	q := `INSERT INTO records (id, actor, action, entity_type, entity_id, org_id, before, after, source_ip, created_at)
+		  VALUES (:id, :actor, :action, :entity_type, :entity_id, :org_id, :before, :after, :source_ip, :created_at);`
+
+	dbr, err := toDBRecord(record)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	if _, err := rr.db.NamedExecContext(ctx, q, dbr); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.UniqueViolation:
+				return errors.Wrap(errors.ErrConflict, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (rr recordRepository) RetrieveAll(ctx context.Context, pm audit.PageMetadata) (audit.RecordsPage, error) {
+	wq, params := filterQuery(pm)
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, actor, action, entity_type, entity_id, org_id, before, after, source_ip, created_at
+		FROM records %s ORDER BY created_at DESC, id %s;`, wq, olq)
+	qc := fmt.Sprintf(`SELECT COUNT(*) FROM records %s;`, wq)
+
+	params["limit"] = pm.Limit
+	params["offset"] = pm.Offset
+
+	rows, err := rr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return audit.RecordsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items := []audit.Record{}
+	for rows.Next() {
+		var dbr dbRecord
+		if err := rows.StructScan(&dbr); err != nil {
+			return audit.RecordsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		record, err := toRecord(dbr)
+		if err != nil {
+			return audit.RecordsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, record)
+	}
+
+	total, err := total(ctx, rr.db, qc, params)
+	if err != nil {
+		return audit.RecordsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	pm.Total = total
+	return audit.RecordsPage{
+		PageMetadata: pm,
+		Records:      items,
+	}, nil
+}
+
+func filterQuery(pm audit.PageMetadata) (string, map[string]interface{}) {
+	var conds []string
+	params := map[string]interface{}{}
+
+	filters := []struct {
+		column string
+		value  string
+	}{
+		{"actor", pm.Actor},
+		{"action", pm.Action},
+		{"entity_type", pm.EntityType},
+		{"entity_id", pm.EntityID},
+		{"org_id", pm.OrgID},
+	}
+	for _, f := range filters {
+		if f.value != "" {
+			conds = append(conds, fmt.Sprintf("%s = :%s", f.column, f.column))
+			params[f.column] = f.value
+		}
+	}
+
+	if !pm.From.IsZero() {
+		conds = append(conds, "created_at >= :from")
+		params["from"] = pm.From
+	}
+	if !pm.To.IsZero() {
+		conds = append(conds, "created_at <= :to")
+		params["to"] = pm.To
+	}
+
+	if len(conds) == 0 {
+		return "", params
+	}
+
+	return fmt.Sprintf("WHERE %s", strings.Join(conds, " AND ")), params
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+type dbRecord struct {
+	ID         string    `db:"id"`
+	Actor      string    `db:"actor"`
+	Action     string    `db:"action"`
+	EntityType string    `db:"entity_type"`
+	EntityID   string    `db:"entity_id"`
+	OrgID      string    `db:"org_id"`
+	Before     []byte    `db:"before"`
+	After      []byte    `db:"after"`
+	SourceIP   string    `db:"source_ip"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func toDBRecord(record audit.Record) (dbRecord, error) {
+	before, err := toJSON(record.Before)
+	if err != nil {
+		return dbRecord{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	after, err := toJSON(record.After)
+	if err != nil {
+		return dbRecord{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return dbRecord{
+		ID:         record.ID,
+		Actor:      record.Actor,
+		Action:     record.Action,
+		EntityType: record.EntityType,
+		EntityID:   record.EntityID,
+		OrgID:      record.OrgID,
+		Before:     before,
+		After:      after,
+		SourceIP:   record.SourceIP,
+		CreatedAt:  record.CreatedAt,
+	}, nil
+}
+
+func toRecord(dbr dbRecord) (audit.Record, error) {
+	record := audit.Record{
+		ID:         dbr.ID,
+		Actor:      dbr.Actor,
+		Action:     dbr.Action,
+		EntityType: dbr.EntityType,
+		EntityID:   dbr.EntityID,
+		OrgID:      dbr.OrgID,
+		SourceIP:   dbr.SourceIP,
+		CreatedAt:  dbr.CreatedAt,
+	}
+
+	if len(dbr.Before) > 0 {
+		if err := json.Unmarshal(dbr.Before, &record.Before); err != nil {
+			return audit.Record{}, err
+		}
+	}
+
+	if len(dbr.After) > 0 {
+		if err := json.Unmarshal(dbr.After, &record.After); err != nil {
+			return audit.Record{}, err
+		}
+	}
+
+	return record, nil
+}
+
+func toJSON(fields map[string]interface{}) ([]byte, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(fields)
+}
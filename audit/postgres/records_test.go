@@ -0,0 +1,148 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/audit/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idProvider = uuid.New()
+
+func newRecord(t *testing.T, actor string, createdAt time.Time) audit.Record {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	entityID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return audit.Record{
+		ID:         id,
+		Actor:      actor,
+		Action:     audit.ActionUpdate,
+		EntityType: audit.EntityThing,
+		EntityID:   entityID,
+		Before:     map[string]interface{}{"name": "thing"},
+		After:      map[string]interface{}{"name": "renamed"},
+		SourceIP:   "10.0.0.1",
+		CreatedAt:  createdAt,
+	}
+}
+
+func TestRecordSave(t *testing.T) {
+	repo := postgres.NewRecordRepository(postgres.NewDatabase(db))
+
+	record := newRecord(t, "records-save@example.com", time.Now())
+
+	cases := []struct {
+		desc   string
+		record audit.Record
+		err    error
+	}{
+		{
+			desc:   "save new record",
+			record: record,
+			err:    nil,
+		},
+		{
+			desc:   "save record that already exists",
+			record: record,
+			err:    errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.record)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRecordRetrieveAll(t *testing.T) {
+	repo := postgres.NewRecordRepository(postgres.NewDatabase(db))
+
+	actor, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	n := uint64(10)
+	start := time.Now().Add(-time.Hour).Round(time.Millisecond)
+	saved := []audit.Record{}
+	for i := uint64(0); i < n; i++ {
+		r := newRecord(t, actor, start.Add(time.Duration(i)*time.Minute))
+		if i%2 == 0 {
+			r.OrgID = orgID
+		}
+		err := repo.Save(context.Background(), r)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		saved = append(saved, r)
+	}
+
+	cases := []struct {
+		desc  string
+		pm    audit.PageMetadata
+		size  uint64
+		total uint64
+	}{
+		{
+			desc:  "retrieve records by actor",
+			pm:    audit.PageMetadata{Limit: n, Actor: actor},
+			size:  n,
+			total: n,
+		},
+		{
+			desc:  "retrieve records page by actor",
+			pm:    audit.PageMetadata{Offset: 2, Limit: 3, Actor: actor},
+			size:  3,
+			total: n,
+		},
+		{
+			desc:  "retrieve records by org",
+			pm:    audit.PageMetadata{Limit: n, OrgID: orgID},
+			size:  n / 2,
+			total: n / 2,
+		},
+		{
+			desc:  "retrieve records by entity",
+			pm:    audit.PageMetadata{Limit: n, EntityType: audit.EntityThing, EntityID: saved[0].EntityID},
+			size:  1,
+			total: 1,
+		},
+		{
+			desc:  "retrieve records by time",
+			pm:    audit.PageMetadata{Limit: n, Actor: actor, From: start.Add(2 * time.Minute), To: start.Add(5 * time.Minute)},
+			size:  4,
+			total: 4,
+		},
+		{
+			desc:  "retrieve records by unknown action",
+			pm:    audit.PageMetadata{Limit: n, Actor: actor, Action: audit.ActionRemove},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveAll(context.Background(), tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, uint64(len(page.Records)), fmt.Sprintf("%s: expected %d records got %d\n", tc.desc, tc.size, len(page.Records)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+
+	page, err := repo.RetrieveAll(context.Background(), audit.PageMetadata{Limit: 1, Actor: actor})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	latest := saved[n-1]
+	assert.Equal(t, latest.ID, page.Records[0].ID, fmt.Sprintf("expected the latest record %s got %s\n", latest.ID, page.Records[0].ID))
+	assert.Equal(t, latest.Before, page.Records[0].Before, fmt.Sprintf("expected before %v got %v\n", latest.Before, page.Records[0].Before))
+	assert.Equal(t, latest.After, page.Records[0].After, fmt.Sprintf("expected after %v got %v\n", latest.After, page.Records[0].After))
+}
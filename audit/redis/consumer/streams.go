@@ -0,0 +1,118 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	stream = "mainflux.audit"
+	group  = "mainflux.audit"
+
+	exists = "BUSYGROUP Consumer Group name already exists"
+)
+
+// Subscriber represents the source of the audit records.
+type Subscriber interface {
+	// Subscribe receives the audit records until the context is canceled.
+	Subscribe(context.Context) error
+}
+
+type eventStore struct {
+	svc      audit.Service
+	client   *redis.Client
+	consumer string
+	logger   logger.Logger
+}
+
+// NewEventStore returns new event store instance.
+func NewEventStore(svc audit.Service, client *redis.Client, consumer string, log logger.Logger) Subscriber {
+	return eventStore{
+		svc:      svc,
+		client:   client,
+		consumer: consumer,
+		logger:   log,
+	}
+}
+
+func (es eventStore) Subscribe(ctx context.Context) error {
+	err := es.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && err.Error() != exists {
+		return err
+	}
+
+	for {
+		streams, err := es.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: es.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    100,
+		}).Result()
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil || len(streams) == 0 {
+			continue
+		}
+
+		for _, msg := range streams[0].Messages {
+			if err := es.svc.SaveRecord(ctx, decode(msg.Values)); err != nil {
+				es.logger.Warn(fmt.Sprintf("Failed to save audit record: %s", err.Error()))
+				break
+			}
+			es.client.XAck(ctx, stream, group, msg.ID)
+		}
+	}
+}
+
+func decode(event map[string]interface{}) audit.Record {
+	record := audit.Record{
+		Actor:      read(event, "actor", ""),
+		Action:     read(event, "action", ""),
+		EntityType: read(event, "entity_type", ""),
+		EntityID:   read(event, "entity_id", ""),
+		OrgID:      read(event, "org_id", ""),
+		SourceIP:   read(event, "source_ip", ""),
+		Before:     readFields(event, "before"),
+		After:      readFields(event, "after"),
+	}
+
+	if ns, err := strconv.ParseInt(read(event, "created_at", ""), 10, 64); err == nil {
+		record.CreatedAt = time.Unix(0, ns)
+	}
+
+	return record
+}
+
+func readFields(event map[string]interface{}, key string) map[string]interface{} {
+	val := read(event, key, "")
+	if val == "" {
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(val), &fields); err != nil {
+		return nil
+	}
+
+	return fields
+}
+
+func read(event map[string]interface{}, key, def string) string {
+	val, ok := event[key].(string)
+	if !ok {
+		return def
+	}
+
+	return val
+}
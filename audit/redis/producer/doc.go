@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package producer contains the audit records publisher used by the audited
+// services to hand the records over to the audit service.
+package producer
@@ -0,0 +1,87 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	streamID  = "mainflux.audit"
+	streamLen = 10000
+)
+
+var _ audit.Publisher = (*publisher)(nil)
+
+type publisher struct {
+	client *redis.Client
+}
+
+// NewPublisher returns audit records publisher which adds the records to
+// the audit stream.
+func NewPublisher(client *redis.Client) audit.Publisher {
+	return publisher{
+		client: client,
+	}
+}
+
+func (pub publisher) Publish(ctx context.Context, record audit.Record) error {
+	values, err := encode(record)
+	if err != nil {
+		return err
+	}
+
+	args := &redis.XAddArgs{
+		Stream:       streamID,
+		MaxLenApprox: streamLen,
+		Values:       values,
+	}
+
+	return pub.client.XAdd(ctx, args).Err()
+}
+
+func encode(record audit.Record) (map[string]interface{}, error) {
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	val := map[string]interface{}{
+		"actor":       record.Actor,
+		"action":      record.Action,
+		"entity_type": record.EntityType,
+		"entity_id":   record.EntityID,
+		"created_at":  record.CreatedAt.UnixNano(),
+	}
+
+	if record.OrgID != "" {
+		val["org_id"] = record.OrgID
+	}
+
+	if record.SourceIP != "" {
+		val["source_ip"] = record.SourceIP
+	}
+
+	if len(record.Before) > 0 {
+		before, err := json.Marshal(record.Before)
+		if err != nil {
+			return nil, err
+		}
+		val["before"] = string(before)
+	}
+
+	if len(record.After) > 0 {
+		after, err := json.Marshal(record.After)
+		if err != nil {
+			return nil, err
+		}
+		val["after"] = string(after)
+	}
+
+	return val, nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package producer_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/audit/redis/producer"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const streamID = "mainflux.audit"
+
+func TestPublish(t *testing.T) {
+	redisClient.FlushAll(context.Background()).Err()
+
+	pub := producer.NewPublisher(redisClient)
+
+	createdAt := time.Now()
+	cases := []struct {
+		desc   string
+		record audit.Record
+		event  map[string]interface{}
+	}{
+		{
+			desc: "publish create record",
+			record: audit.Record{
+				Actor:      "actor",
+				Action:     audit.ActionCreate,
+				EntityType: audit.EntityThing,
+				EntityID:   "thing",
+				After:      map[string]interface{}{"name": "thing"},
+				SourceIP:   "10.0.0.1",
+				CreatedAt:  createdAt,
+			},
+			event: map[string]interface{}{
+				"actor":       "actor",
+				"action":      audit.ActionCreate,
+				"entity_type": audit.EntityThing,
+				"entity_id":   "thing",
+				"after":       `{"name":"thing"}`,
+				"source_ip":   "10.0.0.1",
+				"created_at":  strconv.FormatInt(createdAt.UnixNano(), 10),
+			},
+		},
+		{
+			desc: "publish org record",
+			record: audit.Record{
+				Actor:      "actor",
+				Action:     audit.ActionRemove,
+				EntityType: audit.EntityOrg,
+				EntityID:   "org",
+				OrgID:      "org",
+				Before:     map[string]interface{}{"name": "org"},
+				CreatedAt:  createdAt,
+			},
+			event: map[string]interface{}{
+				"actor":       "actor",
+				"action":      audit.ActionRemove,
+				"entity_type": audit.EntityOrg,
+				"entity_id":   "org",
+				"org_id":      "org",
+				"before":      `{"name":"org"}`,
+				"created_at":  strconv.FormatInt(createdAt.UnixNano(), 10),
+			},
+		},
+	}
+
+	lastID := "0"
+	for _, tc := range cases {
+		err := pub.Publish(context.Background(), tc.record)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+		streams := redisClient.XRead(context.Background(), &redis.XReadArgs{
+			Streams: []string{streamID, lastID},
+			Count:   1,
+			Block:   time.Second,
+		}).Val()
+		require.Len(t, streams, 1, fmt.Sprintf("%s: expected the record to be published", tc.desc))
+
+		msg := streams[0].Messages[0]
+		lastID = msg.ID
+		assert.Equal(t, tc.event, msg.Values, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.event, msg.Values))
+	}
+}
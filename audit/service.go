@@ -0,0 +1,88 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// SaveRecord stores the record of the performed operation.
+	SaveRecord(ctx context.Context, record Record) error
+
+	// ListRecords retrieves the records matching the page metadata filters.
+	// Root admins may list all the records, while org owners may list the
+	// records of their orgs only, so the org filter is required for them.
+	ListRecords(ctx context.Context, token string, pm PageMetadata) (RecordsPage, error)
+}
+
+var _ Service = (*auditService)(nil)
+
+type auditService struct {
+	auth       mainflux.AuthServiceClient
+	records    RecordRepository
+	idProvider mainflux.IDProvider
+}
+
+// New instantiates the audit service implementation.
+func New(auth mainflux.AuthServiceClient, records RecordRepository, idp mainflux.IDProvider) Service {
+	return &auditService{
+		auth:       auth,
+		records:    records,
+		idProvider: idp,
+	}
+}
+
+func (as *auditService) SaveRecord(ctx context.Context, record Record) error {
+	id, err := as.idProvider.ID()
+	if err != nil {
+		return err
+	}
+
+	record.ID = id
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	return as.records.Save(ctx, record)
+}
+
+func (as *auditService) ListRecords(ctx context.Context, token string, pm PageMetadata) (RecordsPage, error) {
+	if _, err := as.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+		return RecordsPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := as.authorize(ctx, token, auth.RootSubject, "", ""); err != nil {
+		if pm.OrgID == "" {
+			return RecordsPage{}, err
+		}
+		if err := as.authorize(ctx, token, auth.OrgSubject, pm.OrgID, auth.OwnerRole); err != nil {
+			return RecordsPage{}, err
+		}
+	}
+
+	return as.records.RetrieveAll(ctx, pm)
+}
+
+func (as *auditService) authorize(ctx context.Context, token, subject, object, action string) error {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: subject,
+		Object:  object,
+		Action:  action,
+	}
+
+	if _, err := as.auth.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
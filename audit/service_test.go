@@ -0,0 +1,189 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/audit/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	adminToken = "admin-token"
+	ownerToken = "owner-token"
+	userToken  = "user-token"
+	wrongValue = "wrong-value"
+	adminID    = "0ee2aa1a-2ddf-4bb5-91d5-9a1b3c1b4f3e"
+	ownerID    = "574106f7-030e-4881-8ab0-151195c29f94"
+	userID     = "ecf9e48b-ba3b-41c4-82a9-72e063b17868"
+	orgID      = "5384fb1c-d0ae-4cbe-be52-c54223150fe0"
+	otherOrgID = "d2ebb3f1-ee6f-4b2a-8a4a-3e8f2ec6d1b1"
+	thingID    = "b4c7cd8a-8de0-4c79-9c57-0e66d6b1f4ab"
+	n          = 10
+)
+
+func newService() audit.Service {
+	auth := mocks.NewAuthService(adminID,
+		map[string]string{adminToken: adminID, ownerToken: ownerID, userToken: userID},
+		map[string]string{orgID: ownerID, otherOrgID: adminID},
+	)
+
+	return audit.New(auth, mocks.NewRecordRepository(), uuid.NewMock())
+}
+
+func TestSaveRecord(t *testing.T) {
+	svc := newService()
+
+	now := time.Now().Round(time.Second)
+	cases := []struct {
+		desc   string
+		record audit.Record
+		err    error
+	}{
+		{
+			desc:   "save record",
+			record: audit.Record{Actor: userID, Action: audit.ActionCreate, EntityType: audit.EntityThing, EntityID: thingID, CreatedAt: now},
+			err:    nil,
+		},
+		{
+			desc:   "save record without creation time",
+			record: audit.Record{Actor: userID, Action: audit.ActionRemove, EntityType: audit.EntityThing, EntityID: thingID},
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.SaveRecord(context.Background(), tc.record)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	page, err := svc.ListRecords(context.Background(), adminToken, audit.PageMetadata{Limit: n})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.Records, len(cases))
+	for _, r := range page.Records {
+		assert.NotEmpty(t, r.ID, "expected record ID to be set")
+		assert.False(t, r.CreatedAt.IsZero(), "expected record creation time to be set")
+	}
+}
+
+func TestListRecords(t *testing.T) {
+	svc := newService()
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < n; i++ {
+		r := audit.Record{
+			Actor:      userID,
+			Action:     audit.ActionUpdate,
+			EntityType: audit.EntityThing,
+			EntityID:   thingID,
+			CreatedAt:  start.Add(time.Duration(i) * time.Minute),
+		}
+		if i%2 == 0 {
+			r.Actor = ownerID
+			r.Action = audit.ActionAssign
+			r.EntityType = audit.EntityMember
+			r.EntityID = userID
+			r.OrgID = orgID
+		}
+		err := svc.SaveRecord(context.Background(), r)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    audit.PageMetadata
+		size  uint64
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all records as root admin",
+			token: adminToken,
+			pm:    audit.PageMetadata{Limit: n},
+			size:  n,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list records page as root admin",
+			token: adminToken,
+			pm:    audit.PageMetadata{Offset: n - 2, Limit: n},
+			size:  2,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list records filtered by actor",
+			token: adminToken,
+			pm:    audit.PageMetadata{Limit: n, Actor: userID},
+			size:  n / 2,
+			total: n / 2,
+			err:   nil,
+		},
+		{
+			desc:  "list records filtered by entity",
+			token: adminToken,
+			pm:    audit.PageMetadata{Limit: n, EntityType: audit.EntityThing, EntityID: thingID},
+			size:  n / 2,
+			total: n / 2,
+			err:   nil,
+		},
+		{
+			desc:  "list records filtered by time",
+			token: adminToken,
+			pm:    audit.PageMetadata{Limit: n, From: start.Add(2 * time.Minute), To: start.Add(5 * time.Minute)},
+			size:  4,
+			total: 4,
+			err:   nil,
+		},
+		{
+			desc:  "list org records as org owner",
+			token: ownerToken,
+			pm:    audit.PageMetadata{Limit: n, OrgID: orgID},
+			size:  n / 2,
+			total: n / 2,
+			err:   nil,
+		},
+		{
+			desc:  "list records of other org as org owner",
+			token: ownerToken,
+			pm:    audit.PageMetadata{Limit: n, OrgID: otherOrgID},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "list all records as org owner",
+			token: ownerToken,
+			pm:    audit.PageMetadata{Limit: n},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "list org records as user",
+			token: userToken,
+			pm:    audit.PageMetadata{Limit: n, OrgID: orgID},
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "list records with invalid token",
+			token: wrongValue,
+			pm:    audit.PageMetadata{Limit: n},
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListRecords(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, uint64(len(page.Records)), fmt.Sprintf("%s: expected %d records got %d\n", tc.desc, tc.size, len(page.Records)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type sourceIPKey struct{}
+
+// WithSourceIP returns the context carrying the IP address of the client
+// which requested the operation.
+func WithSourceIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, sourceIPKey{}, ip)
+}
+
+// SourceIP returns the client IP address carried by the context.
+func SourceIP(ctx context.Context) string {
+	ip, _ := ctx.Value(sourceIPKey{}).(string)
+	return ip
+}
+
+// PopulateSourceIP stores the IP address of the client which sent the HTTP
+// request into the context. Addresses forwarded by the reverse proxy take
+// precedence over the address of the connection peer. Only the last address
+// of the X-Forwarded-For header is used, since it's appended by the proxy
+// itself, while the preceding ones are supplied by the client. It is meant
+// to be used as the go-kit server before function.
+func PopulateSourceIP(ctx context.Context, r *http.Request) context.Context {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		hops := strings.Split(fwd, ",")
+		return WithSourceIP(ctx, strings.TrimSpace(hops[len(hops)-1]))
+	}
+	if ip := r.Header.Get("X-Real-IP"); ip != "" {
+		return WithSourceIP(ctx, ip)
+	}
+
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	return WithSourceIP(ctx, ip)
+}
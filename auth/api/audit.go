@@ -0,0 +1,409 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/auth"
+	log "github.com/MainfluxLabs/mainflux/logger"
+)
+
+var _ auth.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	svc       auth.Service
+	publisher audit.Publisher
+	logger    log.Logger
+}
+
+// AuditMiddleware publishes the audit records of the successful operations
+// which change API keys, orgs, org members and group policies.
+func AuditMiddleware(svc auth.Service, publisher audit.Publisher, logger log.Logger) auth.Service {
+	return &auditMiddleware{
+		svc:       svc,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (am *auditMiddleware) Issue(ctx context.Context, token string, newKey auth.Key) (auth.Key, string, error) {
+	key, secret, err := am.svc.Issue(ctx, token, newKey)
+	if err != nil || key.Type != auth.APIKey {
+		return key, secret, err
+	}
+
+	am.publish(ctx, audit.NewRecord(ctx, key.IssuerID, audit.ActionCreate, audit.EntityKey, key.ID, nil, keyFields(key)))
+
+	return key, secret, nil
+}
+
+func (am *auditMiddleware) Revoke(ctx context.Context, token, id string) error {
+	actor, ok := am.identify(ctx, token)
+	before, _ := am.svc.RetrieveKey(ctx, token, id)
+
+	if err := am.svc.Revoke(ctx, token, id); err != nil {
+		return err
+	}
+
+	if ok {
+		am.publish(ctx, audit.NewRecord(ctx, actor, audit.ActionRemove, audit.EntityKey, id, keyFields(before), nil))
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) RevokeKeys(ctx context.Context, token string, ids ...string) error {
+	actor, ok := am.identify(ctx, token)
+
+	if err := am.svc.RevokeKeys(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	if ok {
+		for _, id := range ids {
+			am.publish(ctx, audit.NewRecord(ctx, actor, audit.ActionRemove, audit.EntityKey, id, nil, nil))
+		}
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) RetrieveKey(ctx context.Context, token, id string) (auth.Key, error) {
+	return am.svc.RetrieveKey(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListKeys(ctx context.Context, token string, pm auth.KeyPageMetadata) (auth.KeysPage, error) {
+	return am.svc.ListKeys(ctx, token, pm)
+}
+
+func (am *auditMiddleware) Identify(ctx context.Context, token string) (auth.Identity, error) {
+	return am.svc.Identify(ctx, token)
+}
+
+func (am *auditMiddleware) IdentifyVerification(ctx context.Context, token string) (auth.Identity, error) {
+	return am.svc.IdentifyVerification(ctx, token)
+}
+
+func (am *auditMiddleware) RetrievePublicKeys(ctx context.Context) ([]auth.PublicKey, error) {
+	return am.svc.RetrievePublicKeys(ctx)
+}
+
+func (am *auditMiddleware) Authorize(ctx context.Context, ar auth.AuthzReq) error {
+	return am.svc.Authorize(ctx, ar)
+}
+
+func (am *auditMiddleware) AddPolicy(ctx context.Context, token, groupID, policy string) error {
+	return am.svc.AddPolicy(ctx, token, groupID, policy)
+}
+
+func (am *auditMiddleware) AssignRole(ctx context.Context, id, role string) error {
+	return am.svc.AssignRole(ctx, id, role)
+}
+
+func (am *auditMiddleware) RetrieveRole(ctx context.Context, id string) (string, error) {
+	return am.svc.RetrieveRole(ctx, id)
+}
+
+func (am *auditMiddleware) CreateOrg(ctx context.Context, token string, org auth.Org) (auth.Org, error) {
+	o, err := am.svc.CreateOrg(ctx, token, org)
+	if err != nil {
+		return o, err
+	}
+
+	am.publishOrg(ctx, o.OwnerID, audit.ActionCreate, audit.EntityOrg, o.ID, o.ID, nil, orgFields(o))
+
+	return o, nil
+}
+
+func (am *auditMiddleware) UpdateOrg(ctx context.Context, token string, org auth.Org) (auth.Org, error) {
+	before, _ := am.svc.ViewOrg(ctx, token, org.ID)
+
+	o, err := am.svc.UpdateOrg(ctx, token, org)
+	if err != nil {
+		return o, err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		am.publishOrg(ctx, actor, audit.ActionUpdate, audit.EntityOrg, org.ID, org.ID, orgFields(before), orgFields(o))
+	}
+
+	return o, nil
+}
+
+func (am *auditMiddleware) ViewOrg(ctx context.Context, token, id string) (auth.Org, error) {
+	return am.svc.ViewOrg(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListOrgs(ctx context.Context, token string, admin bool, pm auth.PageMetadata) (auth.OrgsPage, error) {
+	return am.svc.ListOrgs(ctx, token, admin, pm)
+}
+
+func (am *auditMiddleware) ListOrgMemberships(ctx context.Context, token, memberID string, pm auth.PageMetadata) (auth.OrgsPage, error) {
+	return am.svc.ListOrgMemberships(ctx, token, memberID, pm)
+}
+
+func (am *auditMiddleware) RemoveOrg(ctx context.Context, token, id string) error {
+	actor, ok := am.identify(ctx, token)
+	before, _ := am.svc.ViewOrg(ctx, token, id)
+
+	if err := am.svc.RemoveOrg(ctx, token, id); err != nil {
+		return err
+	}
+
+	if ok {
+		am.publishOrg(ctx, actor, audit.ActionRemove, audit.EntityOrg, id, id, orgFields(before), nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) AssignMembers(ctx context.Context, token, orgID string, oms ...auth.OrgMember) error {
+	if err := am.svc.AssignMembers(ctx, token, orgID, oms...); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		for _, om := range oms {
+			am.publishOrg(ctx, actor, audit.ActionAssign, audit.EntityMember, om.MemberID, orgID, nil, memberFields(om))
+		}
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) JoinOrg(ctx context.Context, orgID, memberID string) error {
+	if err := am.svc.JoinOrg(ctx, orgID, memberID); err != nil {
+		return err
+	}
+
+	// The member joins the org on the single sign-on, so it's the actor as well.
+	after := map[string]interface{}{"role": auth.ViewerRole}
+	am.publishOrg(ctx, memberID, audit.ActionAssign, audit.EntityMember, memberID, orgID, nil, after)
+
+	return nil
+}
+
+func (am *auditMiddleware) UnassignMembers(ctx context.Context, token string, orgID string, memberIDs ...string) error {
+	if err := am.svc.UnassignMembers(ctx, token, orgID, memberIDs...); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		for _, id := range memberIDs {
+			am.publishOrg(ctx, actor, audit.ActionUnassign, audit.EntityMember, id, orgID, nil, nil)
+		}
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) UpdateMembers(ctx context.Context, token, orgID string, oms ...auth.OrgMember) error {
+	before := map[string]auth.OrgMember{}
+	for _, om := range oms {
+		if m, err := am.svc.ViewMember(ctx, token, orgID, om.MemberID); err == nil {
+			before[om.MemberID] = m
+		}
+	}
+
+	if err := am.svc.UpdateMembers(ctx, token, orgID, oms...); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		for _, om := range oms {
+			am.publishOrg(ctx, actor, audit.ActionUpdate, audit.EntityMember, om.MemberID, orgID, memberFields(before[om.MemberID]), memberFields(om))
+		}
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) ListOrgMembers(ctx context.Context, token, orgID string, pm auth.PageMetadata) (auth.OrgMembersPage, error) {
+	return am.svc.ListOrgMembers(ctx, token, orgID, pm)
+}
+
+func (am *auditMiddleware) ViewMember(ctx context.Context, token, orgID, memberID string) (auth.OrgMember, error) {
+	return am.svc.ViewMember(ctx, token, orgID, memberID)
+}
+
+func (am *auditMiddleware) AssignGroups(ctx context.Context, token, orgID string, groupIDs ...string) error {
+	if err := am.svc.AssignGroups(ctx, token, orgID, groupIDs...); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		for _, id := range groupIDs {
+			am.publishOrg(ctx, actor, audit.ActionAssign, audit.EntityGroup, id, orgID, nil, nil)
+		}
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) UnassignGroups(ctx context.Context, token string, orgID string, groupIDs ...string) error {
+	if err := am.svc.UnassignGroups(ctx, token, orgID, groupIDs...); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		for _, id := range groupIDs {
+			am.publishOrg(ctx, actor, audit.ActionUnassign, audit.EntityGroup, id, orgID, nil, nil)
+		}
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) ListOrgGroups(ctx context.Context, token, orgID string, pm auth.PageMetadata) (auth.GroupsPage, error) {
+	return am.svc.ListOrgGroups(ctx, token, orgID, pm)
+}
+
+func (am *auditMiddleware) RetrieveGroupOrg(ctx context.Context, groupID string) (string, error) {
+	return am.svc.RetrieveGroupOrg(ctx, groupID)
+}
+
+func (am *auditMiddleware) RetrieveMemberGroups(ctx context.Context, token string) ([]string, error) {
+	return am.svc.RetrieveMemberGroups(ctx, token)
+}
+
+func (am *auditMiddleware) ViewGroupMembership(ctx context.Context, token, groupID string) (auth.Org, error) {
+	return am.svc.ViewGroupMembership(ctx, token, groupID)
+}
+
+func (am *auditMiddleware) Backup(ctx context.Context, token string) (auth.Backup, error) {
+	return am.svc.Backup(ctx, token)
+}
+
+func (am *auditMiddleware) Restore(ctx context.Context, token string, backup auth.Backup) error {
+	return am.svc.Restore(ctx, token, backup)
+}
+
+func (am *auditMiddleware) CreateGroupPolicies(ctx context.Context, token, groupID string, gps ...auth.GroupPolicyByID) error {
+	if err := am.svc.CreateGroupPolicies(ctx, token, groupID, gps...); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		am.publish(ctx, audit.NewRecord(ctx, actor, audit.ActionCreate, audit.EntityPolicy, groupID, nil, policyFields(gps)))
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) ListGroupPolicies(ctx context.Context, token, groupID string, pm auth.PageMetadata) (auth.GroupPoliciesPage, error) {
+	return am.svc.ListGroupPolicies(ctx, token, groupID, pm)
+}
+
+func (am *auditMiddleware) UpdateGroupPolicies(ctx context.Context, token, groupID string, gps ...auth.GroupPolicyByID) error {
+	if err := am.svc.UpdateGroupPolicies(ctx, token, groupID, gps...); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		am.publish(ctx, audit.NewRecord(ctx, actor, audit.ActionUpdate, audit.EntityPolicy, groupID, nil, policyFields(gps)))
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) RemoveGroupPolicies(ctx context.Context, token, groupID string, memberIDs ...string) error {
+	if err := am.svc.RemoveGroupPolicies(ctx, token, groupID, memberIDs...); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		before := map[string]interface{}{}
+		for _, id := range memberIDs {
+			before[id] = ""
+		}
+		am.publish(ctx, audit.NewRecord(ctx, actor, audit.ActionRemove, audit.EntityPolicy, groupID, before, nil))
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) identify(ctx context.Context, token string) (string, bool) {
+	id, err := am.svc.Identify(ctx, token)
+	if err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to identify actor of auth operation: %s", err))
+		return "", false
+	}
+
+	return id.ID, true
+}
+
+// publishOrg publishes the record of the operation performed within the org,
+// which makes the record visible to the org owner.
+func (am *auditMiddleware) publishOrg(ctx context.Context, actor, action, entityType, entityID, orgID string, before, after map[string]interface{}) {
+	record := audit.NewRecord(ctx, actor, action, entityType, entityID, before, after)
+	record.OrgID = orgID
+	am.publish(ctx, record)
+}
+
+// publish publishes the record of the operation. Failures are logged,
+// since the operation itself has already succeeded.
+func (am *auditMiddleware) publish(ctx context.Context, record audit.Record) {
+	if err := am.publisher.Publish(ctx, record); err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to publish audit record of %s %s: %s", record.EntityType, record.Action, err))
+	}
+}
+
+// Key secrets are never recorded.
+func keyFields(k auth.Key) map[string]interface{} {
+	if k.ID == "" {
+		return nil
+	}
+
+	scopes := []string{}
+	for _, s := range k.Scopes {
+		scopes = append(scopes, s.String())
+	}
+
+	fields := map[string]interface{}{
+		"name":        k.Name,
+		"description": k.Description,
+		"scopes":      scopes,
+	}
+	if !k.ExpiresAt.IsZero() {
+		fields["expires_at"] = k.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return fields
+}
+
+func orgFields(o auth.Org) map[string]interface{} {
+	if o.ID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"name":        o.Name,
+		"description": o.Description,
+		"metadata":    map[string]interface{}(o.Metadata),
+	}
+}
+
+func memberFields(om auth.OrgMember) map[string]interface{} {
+	if om.MemberID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{"role": om.Role}
+}
+
+// policyFields maps the members to their policies in the group.
+func policyFields(gps []auth.GroupPolicyByID) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, gp := range gps {
+		fields[gp.MemberID] = gp.Policy
+	}
+
+	return fields
+}
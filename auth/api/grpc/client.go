@@ -25,12 +25,16 @@ var _ mainflux.AuthServiceClient = (*grpcClient)(nil)
 type grpcClient struct {
 	issue        endpoint.Endpoint
 	identify     endpoint.Endpoint
+	identifyVer  endpoint.Endpoint
 	authorize    endpoint.Endpoint
 	addPolicy    endpoint.Endpoint
 	assign       endpoint.Endpoint
 	members      endpoint.Endpoint
 	retrieveRole endpoint.Endpoint
 	assignRole   endpoint.Endpoint
+	joinOrg      endpoint.Endpoint
+	groupOrg     endpoint.Endpoint
+	memberGroups endpoint.Endpoint
 	timeout      time.Duration
 }
 
@@ -53,6 +57,14 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeIdentifyResponse,
 			mainflux.UserIdentity{},
 		).Endpoint()),
+		identifyVer: kitot.TraceClient(tracer, "identify_verification")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"IdentifyVerification",
+			encodeIdentifyRequest,
+			decodeIdentifyResponse,
+			mainflux.UserIdentity{},
+		).Endpoint()),
 		authorize: kitot.TraceClient(tracer, "authorize")(kitgrpc.NewClient(
 			conn,
 			svcName,
@@ -101,6 +113,30 @@ func NewClient(tracer opentracing.Tracer, conn *grpc.ClientConn, timeout time.Du
 			decodeEmptyResponse,
 			empty.Empty{},
 		).Endpoint()),
+		joinOrg: kitot.TraceClient(tracer, "join_org")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"JoinOrg",
+			encodeJoinOrgRequest,
+			decodeEmptyResponse,
+			empty.Empty{},
+		).Endpoint()),
+		groupOrg: kitot.TraceClient(tracer, "retrieve_group_org")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveGroupOrg",
+			encodeGroupOrgRequest,
+			decodeGroupOrgResponse,
+			mainflux.OrgID{},
+		).Endpoint()),
+		memberGroups: kitot.TraceClient(tracer, "retrieve_member_groups")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveMemberGroups",
+			encodeIdentifyRequest,
+			decodeMemberGroupsResponse,
+			mainflux.MemberGroupsRes{},
+		).Endpoint()),
 
 		timeout: timeout,
 	}
@@ -142,6 +178,19 @@ func (client grpcClient) Identify(ctx context.Context, token *mainflux.Token, _
 	return &mainflux.UserIdentity{Id: ir.id, Email: ir.email}, nil
 }
 
+func (client grpcClient) IdentifyVerification(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.identifyVer(ctx, identityReq{token: token.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	ir := res.(identityRes)
+	return &mainflux.UserIdentity{Id: ir.id, Email: ir.email}, nil
+}
+
 func encodeIdentifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(identityReq)
 	return &mainflux.Token{Value: req.token}, nil
@@ -243,6 +292,70 @@ func encodeAssignRoleRequest(_ context.Context, grpcReq interface{}) (interface{
 	}, nil
 }
 
+func (client grpcClient) JoinOrg(ctx context.Context, req *mainflux.JoinOrgReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.joinOrg(ctx, joinOrgReq{orgID: req.GetOrgID(), memberID: req.GetMemberID()})
+	if err != nil {
+		return &empty.Empty{}, err
+	}
+
+	er := res.(emptyRes)
+	return &empty.Empty{}, er.err
+}
+
+func encodeJoinOrgRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(joinOrgReq)
+	return &mainflux.JoinOrgReq{
+		OrgID:    req.orgID,
+		MemberID: req.memberID,
+	}, nil
+}
+
+func (client grpcClient) RetrieveGroupOrg(ctx context.Context, req *mainflux.GroupOrgReq, _ ...grpc.CallOption) (*mainflux.OrgID, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.groupOrg(ctx, groupOrgReq{groupID: req.GetGroupID()})
+	if err != nil {
+		return &mainflux.OrgID{}, err
+	}
+
+	gor := res.(groupOrgRes)
+	return &mainflux.OrgID{Value: gor.orgID}, nil
+}
+
+func encodeGroupOrgRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(groupOrgReq)
+	return &mainflux.GroupOrgReq{
+		GroupID: req.groupID,
+	}, nil
+}
+
+func decodeGroupOrgResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.OrgID)
+	return groupOrgRes{orgID: res.GetValue()}, nil
+}
+
+func (client grpcClient) RetrieveMemberGroups(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.MemberGroupsRes, error) {
+	ctx, close := context.WithTimeout(ctx, client.timeout)
+	defer close()
+
+	res, err := client.memberGroups(ctx, identityReq{token: token.GetValue()})
+	if err != nil {
+		return &mainflux.MemberGroupsRes{}, err
+	}
+
+	mgr := res.(memberGroupsRes)
+	return &mainflux.MemberGroupsRes{GroupIDs: mgr.groupIDs}, nil
+}
+
+func decodeMemberGroupsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.MemberGroupsRes)
+	return memberGroupsRes{groupIDs: res.GetGroupIDs()}, nil
+}
+
 func (client grpcClient) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
 	ctx, close := context.WithTimeout(ctx, client.timeout)
 	defer close()
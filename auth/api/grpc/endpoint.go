@@ -55,6 +55,27 @@ func identifyEndpoint(svc auth.Service) endpoint.Endpoint {
 	}
 }
 
+func identifyVerificationEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(identityReq)
+		if err := req.validate(); err != nil {
+			return identityRes{}, err
+		}
+
+		id, err := svc.IdentifyVerification(ctx, req.token)
+		if err != nil {
+			return identityRes{}, err
+		}
+
+		ret := identityRes{
+			id:    id.ID,
+			email: id.Email,
+		}
+
+		return ret, nil
+	}
+}
+
 func authorizeEndpoint(svc auth.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(authReq)
@@ -177,3 +198,53 @@ func membersEndpoint(svc auth.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func joinOrgEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(joinOrgReq)
+
+		if err := req.validate(); err != nil {
+			return emptyRes{}, err
+		}
+
+		if err := svc.JoinOrg(ctx, req.orgID, req.memberID); err != nil {
+			return emptyRes{}, err
+		}
+
+		return emptyRes{}, nil
+	}
+}
+
+func retrieveGroupOrgEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(groupOrgReq)
+
+		if err := req.validate(); err != nil {
+			return groupOrgRes{}, err
+		}
+
+		orgID, err := svc.RetrieveGroupOrg(ctx, req.groupID)
+		if err != nil {
+			return groupOrgRes{}, err
+		}
+
+		return groupOrgRes{orgID: orgID}, nil
+	}
+}
+
+func retrieveMemberGroupsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(identityReq)
+
+		if err := req.validate(); err != nil {
+			return memberGroupsRes{}, err
+		}
+
+		groupIDs, err := svc.RetrieveMemberGroups(ctx, req.token)
+		if err != nil {
+			return memberGroupsRes{}, err
+		}
+
+		return memberGroupsRes{groupIDs: groupIDs}, nil
+	}
+}
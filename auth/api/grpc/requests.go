@@ -19,7 +19,8 @@ func (req identityReq) validate() error {
 	}
 	if req.kind != auth.LoginKey &&
 		req.kind != auth.APIKey &&
-		req.kind != auth.RecoveryKey {
+		req.kind != auth.RecoveryKey &&
+		req.kind != auth.VerificationKey {
 		return apiutil.ErrInvalidAuthKey
 	}
 
@@ -38,7 +39,8 @@ func (req issueReq) validate() error {
 	}
 	if req.keyType != auth.LoginKey &&
 		req.keyType != auth.APIKey &&
-		req.keyType != auth.RecoveryKey {
+		req.keyType != auth.RecoveryKey &&
+		req.keyType != auth.VerificationKey {
 		return apiutil.ErrInvalidAuthKey
 	}
 
@@ -94,7 +96,10 @@ func (req authReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
-	if req.Subject != auth.RootSubject && req.Subject != auth.GroupSubject {
+	switch req.Subject {
+	case auth.RootSubject, auth.GroupSubject, auth.OrgSubject,
+		auth.ThingsSubject, auth.ChannelsSubject, auth.MessagesSubject, auth.GroupsSubject:
+	default:
 		return apiutil.ErrInvalidSubject
 	}
 
@@ -156,3 +161,28 @@ func (req retrieveRoleReq) validate() error {
 
 	return nil
 }
+
+type groupOrgReq struct {
+	groupID string
+}
+
+func (req groupOrgReq) validate() error {
+	if req.groupID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type joinOrgReq struct {
+	orgID    string
+	memberID string
+}
+
+func (req joinOrgReq) validate() error {
+	if req.orgID == "" || req.memberID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -26,3 +26,11 @@ type emptyRes struct {
 type retrieveRoleRes struct {
 	role string
 }
+
+type groupOrgRes struct {
+	orgID string
+}
+
+type memberGroupsRes struct {
+	groupIDs []string
+}
@@ -23,12 +23,16 @@ var _ mainflux.AuthServiceServer = (*grpcServer)(nil)
 type grpcServer struct {
 	issue        kitgrpc.Handler
 	identify     kitgrpc.Handler
+	identifyVer  kitgrpc.Handler
 	authorize    kitgrpc.Handler
 	addPolicy    kitgrpc.Handler
 	assign       kitgrpc.Handler
 	members      kitgrpc.Handler
 	assignRole   kitgrpc.Handler
 	retrieveRole kitgrpc.Handler
+	joinOrg      kitgrpc.Handler
+	groupOrg     kitgrpc.Handler
+	memberGroups kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -44,6 +48,11 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeIdentifyRequest,
 			encodeIdentifyResponse,
 		),
+		identifyVer: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "identify_verification")(identifyVerificationEndpoint(svc)),
+			decodeIdentifyRequest,
+			encodeIdentifyResponse,
+		),
 		authorize: kitgrpc.NewServer(
 			kitot.TraceServer(tracer, "authorize")(authorizeEndpoint(svc)),
 			decodeAuthorizeRequest,
@@ -74,6 +83,21 @@ func NewServer(tracer opentracing.Tracer, svc auth.Service) mainflux.AuthService
 			decodeRetrieveRoleRequest,
 			encodeRetrieveRoleResponse,
 		),
+		joinOrg: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "join_org")(joinOrgEndpoint(svc)),
+			decodeJoinOrgRequest,
+			encodeEmptyResponse,
+		),
+		groupOrg: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_group_org")(retrieveGroupOrgEndpoint(svc)),
+			decodeGroupOrgRequest,
+			encodeGroupOrgResponse,
+		),
+		memberGroups: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "retrieve_member_groups")(retrieveMemberGroupsEndpoint(svc)),
+			decodeIdentifyRequest,
+			encodeMemberGroupsResponse,
+		),
 	}
 }
 
@@ -93,6 +117,14 @@ func (s *grpcServer) Identify(ctx context.Context, token *mainflux.Token) (*main
 	return res.(*mainflux.UserIdentity), nil
 }
 
+func (s *grpcServer) IdentifyVerification(ctx context.Context, token *mainflux.Token) (*mainflux.UserIdentity, error) {
+	_, res, err := s.identifyVer.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.UserIdentity), nil
+}
+
 func (s *grpcServer) Authorize(ctx context.Context, req *mainflux.AuthorizeReq) (*empty.Empty, error) {
 	_, res, err := s.authorize.ServeGRPC(ctx, req)
 	if err != nil {
@@ -141,6 +173,30 @@ func (s *grpcServer) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRol
 	return res.(*mainflux.RetrieveRoleRes), nil
 }
 
+func (s *grpcServer) JoinOrg(ctx context.Context, req *mainflux.JoinOrgReq) (*empty.Empty, error) {
+	_, res, err := s.joinOrg.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*empty.Empty), nil
+}
+
+func (s *grpcServer) RetrieveGroupOrg(ctx context.Context, req *mainflux.GroupOrgReq) (*mainflux.OrgID, error) {
+	_, res, err := s.groupOrg.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.OrgID), nil
+}
+
+func (s *grpcServer) RetrieveMemberGroups(ctx context.Context, token *mainflux.Token) (*mainflux.MemberGroupsRes, error) {
+	_, res, err := s.memberGroups.ServeGRPC(ctx, token)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*mainflux.MemberGroupsRes), nil
+}
+
 func decodeAssignRoleRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.AssignRoleReq)
 	return assignRoleReq{ID: req.GetId(), Role: req.GetRole()}, nil
@@ -151,6 +207,26 @@ func decodeRetrieveRoleRequest(_ context.Context, grpcReq interface{}) (interfac
 	return retrieveRoleReq{id: req.GetId()}, nil
 }
 
+func decodeJoinOrgRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.JoinOrgReq)
+	return joinOrgReq{orgID: req.GetOrgID(), memberID: req.GetMemberID()}, nil
+}
+
+func decodeGroupOrgRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.GroupOrgReq)
+	return groupOrgReq{groupID: req.GetGroupID()}, nil
+}
+
+func encodeGroupOrgResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(groupOrgRes)
+	return &mainflux.OrgID{Value: res.orgID}, nil
+}
+
+func encodeMemberGroupsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(memberGroupsRes)
+	return &mainflux.MemberGroupsRes{GroupIDs: res.groupIDs}, nil
+}
+
 func encodeRetrieveRoleResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(retrieveRoleRes)
 	return &mainflux.RetrieveRoleRes{Role: res.role}, nil
@@ -233,6 +309,8 @@ func encodeError(err error) error {
 		return status.Error(codes.Unauthenticated, err.Error())
 	case errors.Contains(err, errors.ErrAuthorization):
 		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Contains(err, errors.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
 	default:
 		return status.Error(codes.Internal, "internal server error")
 	}
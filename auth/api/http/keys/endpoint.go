@@ -5,6 +5,10 @@ package keys
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/auth"
@@ -18,10 +22,16 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
+		// Scopes are validated as a part of the request validation.
+		scopes, _ := auth.ParseScopes(req.Scopes)
+
 		now := time.Now().UTC()
 		newKey := auth.Key{
-			IssuedAt: now,
-			Type:     req.Type,
+			Scopes:      scopes,
+			IssuedAt:    now,
+			Type:        req.Type,
+			Name:        req.Name,
+			Description: req.Description,
 		}
 
 		duration := time.Duration(req.Duration * time.Second)
@@ -38,6 +48,7 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 		res := issueKeyRes{
 			ID:       key.ID,
 			Value:    secret,
+			Scopes:   key.ScopeStrings(),
 			IssuedAt: key.IssuedAt,
 		}
 		if !key.ExpiresAt.IsZero() {
@@ -60,19 +71,82 @@ func retrieveEndpoint(svc auth.Service) endpoint.Endpoint {
 		if err != nil {
 			return nil, err
 		}
-		ret := retrieveKeyRes{
-			ID:       key.ID,
-			IssuerID: key.IssuerID,
-			Subject:  key.Subject,
-			Type:     key.Type,
-			IssuedAt: key.IssuedAt,
+
+		return toRetrieveKeyRes(key), nil
+	}
+}
+
+func listKeysEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listKeysReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
 		}
-		if !key.ExpiresAt.IsZero() {
-			ret.ExpiresAt = &key.ExpiresAt
+
+		pm := auth.KeyPageMetadata{
+			Offset:  req.offset,
+			Limit:   req.limit,
+			Type:    req.keyType,
+			Expired: req.expired,
+			Subject: req.subject,
+		}
+		page, err := svc.ListKeys(ctx, req.token, pm)
+		if err != nil {
+			return nil, err
+		}
+
+		res := keysPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			Keys: []retrieveKeyRes{},
+		}
+		for _, key := range page.Keys {
+			res.Keys = append(res.Keys, toRetrieveKeyRes(key))
+		}
+
+		return res, nil
+	}
+}
+
+func revokeKeysEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeKeysReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RevokeKeys(ctx, req.token, req.KeyIDs...); err != nil {
+			return nil, err
 		}
 
-		return ret, nil
+		return revokeKeyRes{}, nil
+	}
+}
+
+func toRetrieveKeyRes(key auth.Key) retrieveKeyRes {
+	res := retrieveKeyRes{
+		ID:          key.ID,
+		IssuerID:    key.IssuerID,
+		Subject:     key.Subject,
+		Name:        key.Name,
+		Description: key.Description,
+		Type:        key.Type,
+		Scopes:      key.ScopeStrings(),
+		IssuedAt:    key.IssuedAt,
+	}
+	if !key.ExpiresAt.IsZero() {
+		res.ExpiresAt = &key.ExpiresAt
 	}
+	if !key.LastUsedAt.IsZero() {
+		res.LastUsedAt = &key.LastUsedAt
+	}
+
+	return res
 }
 
 func revokeEndpoint(svc auth.Service) endpoint.Endpoint {
@@ -90,3 +164,47 @@ func revokeEndpoint(svc auth.Service) endpoint.Endpoint {
 		return revokeKeyRes{}, nil
 	}
 }
+
+func jwksEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		pks, err := svc.RetrievePublicKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res := jwksRes{Keys: []jwk{}}
+		for _, pk := range pks {
+			if k, ok := toJWK(pk); ok {
+				res.Keys = append(res.Keys, k)
+			}
+		}
+
+		return res, nil
+	}
+}
+
+func toJWK(pk auth.PublicKey) (jwk, bool) {
+	k := jwk{
+		Use: "sig",
+		Alg: pk.Algorithm,
+		Kid: pk.ID,
+	}
+
+	switch pub := pk.Key.(type) {
+	case *rsa.PublicKey:
+		k.Kty = "RSA"
+		k.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+		k.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		// Coordinates are padded to the curve size as required by RFC 7518.
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		k.Kty = "EC"
+		k.Crv = pub.Curve.Params().Name
+		k.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, size)))
+		k.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, size)))
+	default:
+		return jwk{}, false
+	}
+
+	return k, true
+}
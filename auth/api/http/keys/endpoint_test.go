@@ -36,6 +36,7 @@ const (
 type issueRequest struct {
 	Duration time.Duration `json:"duration,omitempty"`
 	Type     uint32        `json:"type,omitempty"`
+	Scopes   []string      `json:"scopes,omitempty"`
 }
 
 type testRequest struct {
@@ -64,9 +65,12 @@ func (tr testRequest) make() (*http.Response, error) {
 }
 
 func newService() auth.Service {
+	return newServiceWithTokenizer(jwt.New(secret))
+}
+
+func newServiceWithTokenizer(t auth.Tokenizer) auth.Service {
 	repo := mocks.NewKeyRepository()
 	idProvider := uuid.NewMock()
-	t := jwt.New(secret)
 
 	return auth.New(nil, nil, nil, repo, nil, nil, idProvider, t, loginDuration)
 }
@@ -94,6 +98,9 @@ func TestIssue(t *testing.T) {
 	lk := issueRequest{Type: auth.LoginKey}
 	ak := issueRequest{Type: auth.APIKey, Duration: time.Hour}
 	rk := issueRequest{Type: auth.RecoveryKey}
+	sk := issueRequest{Type: auth.APIKey, Duration: time.Hour, Scopes: []string{"things:read", "messages:read:chanID"}}
+	ik := issueRequest{Type: auth.APIKey, Duration: time.Hour, Scopes: []string{"things:delete"}}
+	srk := issueRequest{Type: auth.RecoveryKey, Scopes: []string{"things:read"}}
 
 	cases := []struct {
 		desc   string
@@ -116,6 +123,27 @@ func TestIssue(t *testing.T) {
 			token:  loginSecret,
 			status: http.StatusCreated,
 		},
+		{
+			desc:   "issue scoped API key",
+			req:    toJSON(sk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "issue API key with invalid scope",
+			req:    toJSON(ik),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "issue scoped recovery key",
+			req:    toJSON(srk),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
 		{
 			desc:   "issue recovery key",
 			req:    toJSON(rk),
@@ -291,3 +319,272 @@ func TestRevoke(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 	}
 }
+
+type keysPageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+	Keys   []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"keys"`
+}
+
+func TestListKeys(t *testing.T) {
+	svc := newService()
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	n := 5
+	for i := 0; i < n; i++ {
+		key := auth.Key{Type: auth.APIKey, Name: fmt.Sprintf("key-%d", i), IssuedAt: time.Now(), IssuerID: id, Subject: email}
+		_, _, err := svc.Issue(context.Background(), loginSecret, key)
+		assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+	}
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		query  string
+		token  string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list keys",
+			query:  "",
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list keys with limit",
+			query:  "?limit=2",
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   2,
+		},
+		{
+			desc:   "list expired keys",
+			query:  "?expired=true",
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   0,
+		},
+		{
+			desc:   "list API keys",
+			query:  fmt.Sprintf("?type=%d", auth.APIKey),
+			token:  loginSecret,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list keys with invalid type",
+			query:  "?type=22",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys with invalid expired filter",
+			query:  "?expired=invalid",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys with limit too big",
+			query:  "?limit=1000",
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list keys with invalid token",
+			query:  "",
+			token:  "wrong",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/keys%s", ts.URL, tc.query),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusOK {
+			continue
+		}
+
+		var body keysPageRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Len(t, body.Keys, tc.size, fmt.Sprintf("%s: expected %d keys got %d", tc.desc, tc.size, len(body.Keys)))
+	}
+}
+
+func TestRevokeKeys(t *testing.T) {
+	svc := newService()
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		k, _, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+		assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+		ids = append(ids, k.ID)
+	}
+
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	cases := []struct {
+		desc   string
+		req    string
+		ct     string
+		token  string
+		status int
+	}{
+		{
+			desc:   "revoke keys",
+			req:    toJSON(map[string][]string{"key_ids": ids}),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "revoke keys with empty list",
+			req:    toJSON(map[string][]string{"key_ids": {}}),
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "revoke keys with invalid JSON",
+			req:    "{",
+			ct:     contentType,
+			token:  loginSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "revoke keys with wrong content type",
+			req:    toJSON(map[string][]string{"key_ids": ids}),
+			ct:     "",
+			token:  loginSecret,
+			status: http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:   "revoke keys with invalid token",
+			req:    toJSON(map[string][]string{"key_ids": ids}),
+			ct:     contentType,
+			token:  "wrong",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodDelete,
+			url:         fmt.Sprintf("%s/keys", ts.URL),
+			contentType: tc.ct,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwksRes struct {
+	Keys []jwk `json:"keys"`
+}
+
+func TestJWKS(t *testing.T) {
+	cfg := jwt.Config{Rotation: time.Hour, Retention: time.Hour}
+
+	cfg.Algorithm = jwt.RS256
+	rs, err := jwt.NewAsymmetric(cfg, mocks.NewSigningKeyRepository(), uuid.New())
+	assert.Nil(t, err, fmt.Sprintf("Creating RS256 tokenizer expected to succeed: %s", err))
+
+	cfg.Algorithm = jwt.ES256
+	es, err := jwt.NewAsymmetric(cfg, mocks.NewSigningKeyRepository(), uuid.New())
+	assert.Nil(t, err, fmt.Sprintf("Creating ES256 tokenizer expected to succeed: %s", err))
+
+	cases := []struct {
+		desc      string
+		tokenizer auth.Tokenizer
+		keys      int
+		kty       string
+	}{
+		{
+			desc:      "retrieve JWKS with shared secret",
+			tokenizer: jwt.New(secret),
+			keys:      0,
+		},
+		{
+			desc:      "retrieve JWKS with RS256 signing keys",
+			tokenizer: rs,
+			keys:      1,
+			kty:       "RSA",
+		},
+		{
+			desc:      "retrieve JWKS with ES256 signing keys",
+			tokenizer: es,
+			keys:      1,
+			kty:       "EC",
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newServer(newServiceWithTokenizer(tc.tokenizer))
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/.well-known/jwks.json", ts.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, http.StatusOK, res.StatusCode))
+
+		var body jwksRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		ts.Close()
+
+		assert.Len(t, body.Keys, tc.keys, fmt.Sprintf("%s: expected %d keys got %d", tc.desc, tc.keys, len(body.Keys)))
+		for _, k := range body.Keys {
+			assert.Equal(t, tc.kty, k.Kty, fmt.Sprintf("%s: expected key type %s got %s", tc.desc, tc.kty, k.Kty))
+			assert.Equal(t, "sig", k.Use, fmt.Sprintf("%s: expected key use sig got %s", tc.desc, k.Use))
+			assert.NotEmpty(t, k.Kid, fmt.Sprintf("%s: expected key ID to be set", tc.desc))
+			switch k.Kty {
+			case "RSA":
+				assert.NotEmpty(t, k.N, fmt.Sprintf("%s: expected modulus to be set", tc.desc))
+				assert.Equal(t, "AQAB", k.E, fmt.Sprintf("%s: expected exponent AQAB got %s", tc.desc, k.E))
+			case "EC":
+				assert.Equal(t, "P-256", k.Crv, fmt.Sprintf("%s: expected curve P-256 got %s", tc.desc, k.Crv))
+				assert.Len(t, k.X, 43, fmt.Sprintf("%s: expected padded x coordinate", tc.desc))
+				assert.Len(t, k.Y, 43, fmt.Sprintf("%s: expected padded y coordinate", tc.desc))
+			}
+		}
+	}
+}
@@ -10,10 +10,19 @@ import (
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 )
 
+const (
+	maxNameSize  = 254
+	maxDescSize  = 1024
+	maxLimitSize = 100
+)
+
 type issueKeyReq struct {
-	token    string
-	Type     uint32        `json:"type,omitempty"`
-	Duration time.Duration `json:"duration,omitempty"`
+	token       string
+	Type        uint32        `json:"type,omitempty"`
+	Duration    time.Duration `json:"duration,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Scopes      []string      `json:"scopes,omitempty"`
 }
 
 // It is not possible to issue Reset key using HTTP API.
@@ -28,6 +37,18 @@ func (req issueKeyReq) validate() error {
 		return apiutil.ErrInvalidAPIKey
 	}
 
+	if len(req.Name) > maxNameSize || len(req.Description) > maxDescSize {
+		return apiutil.ErrNameSize
+	}
+
+	if len(req.Scopes) > 0 && req.Type != auth.APIKey {
+		return auth.ErrInvalidScope
+	}
+
+	if _, err := auth.ParseScopes(req.Scopes); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -46,3 +67,54 @@ func (req keyReq) validate() error {
 	}
 	return nil
 }
+
+type listKeysReq struct {
+	token   string
+	offset  uint64
+	limit   uint64
+	keyType *uint32
+	expired *bool
+	subject string
+}
+
+func (req listKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	if req.keyType != nil &&
+		*req.keyType != auth.LoginKey &&
+		*req.keyType != auth.RecoveryKey &&
+		*req.keyType != auth.APIKey {
+		return apiutil.ErrInvalidAPIKey
+	}
+
+	return nil
+}
+
+type revokeKeysReq struct {
+	token  string
+	KeyIDs []string `json:"key_ids"`
+}
+
+func (req revokeKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.KeyIDs) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, id := range req.KeyIDs {
+		if id == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
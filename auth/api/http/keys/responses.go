@@ -13,11 +13,14 @@ import (
 var (
 	_ mainflux.Response = (*issueKeyRes)(nil)
 	_ mainflux.Response = (*revokeKeyRes)(nil)
+	_ mainflux.Response = (*keysPageRes)(nil)
+	_ mainflux.Response = (*jwksRes)(nil)
 )
 
 type issueKeyRes struct {
 	ID        string     `json:"id,omitempty"`
 	Value     string     `json:"value,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
 	IssuedAt  time.Time  `json:"issued_at,omitempty"`
 	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
@@ -35,12 +38,16 @@ func (res issueKeyRes) Empty() bool {
 }
 
 type retrieveKeyRes struct {
-	ID        string     `json:"id,omitempty"`
-	IssuerID  string     `json:"issuer_id,omitempty"`
-	Subject   string     `json:"subject,omitempty"`
-	Type      uint32     `json:"type,omitempty"`
-	IssuedAt  time.Time  `json:"issued_at,omitempty"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ID          string     `json:"id,omitempty"`
+	IssuerID    string     `json:"issuer_id,omitempty"`
+	Subject     string     `json:"subject,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Scopes      []string   `json:"scopes,omitempty"`
+	Type        uint32     `json:"type,omitempty"`
+	IssuedAt    time.Time  `json:"issued_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
 }
 
 func (res retrieveKeyRes) Code() int {
@@ -55,6 +62,29 @@ func (res retrieveKeyRes) Empty() bool {
 	return false
 }
 
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type keysPageRes struct {
+	pageRes
+	Keys []retrieveKeyRes `json:"keys"`
+}
+
+func (res keysPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res keysPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res keysPageRes) Empty() bool {
+	return false
+}
+
 type revokeKeyRes struct {
 }
 
@@ -69,3 +99,31 @@ func (res revokeKeyRes) Headers() map[string]string {
 func (res revokeKeyRes) Empty() bool {
 	return true
 }
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+type jwksRes struct {
+	Keys []jwk `json:"keys"`
+}
+
+func (res jwksRes) Code() int {
+	return http.StatusOK
+}
+
+func (res jwksRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res jwksRes) Empty() bool {
+	return false
+}
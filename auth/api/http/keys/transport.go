@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
@@ -20,12 +21,22 @@ import (
 	"github.com/opentracing/opentracing-go"
 )
 
-const contentType = "application/json"
+const (
+	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	typeKey     = "type"
+	expiredKey  = "expired"
+	subjectKey  = "subject"
+	defOffset   = 0
+	defLimit    = 10
+)
 
 // MakeHandler returns a HTTP handler for API endpoints.
 func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, logger logger.Logger) *bone.Mux {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(audit.PopulateSourceIP),
 	}
 	mux.Post("/keys", kithttp.NewServer(
 		kitot.TraceServer(tracer, "issue")(issueEndpoint(svc)),
@@ -34,6 +45,20 @@ func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, log
 		opts...,
 	))
 
+	mux.Get("/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "list_keys")(listKeysEndpoint(svc)),
+		decodeListKeys,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Delete("/keys", kithttp.NewServer(
+		kitot.TraceServer(tracer, "revoke_keys")(revokeKeysEndpoint(svc)),
+		decodeRevokeKeys,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/keys/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "retrieve")(retrieveEndpoint(svc)),
 		decodeKeyReq,
@@ -48,6 +73,13 @@ func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, log
 		opts...,
 	))
 
+	mux.Get("/.well-known/jwks.json", kithttp.NewServer(
+		kitot.TraceServer(tracer, "jwks")(jwksEndpoint(svc)),
+		decodeJWKS,
+		encodeResponse,
+		opts...,
+	))
+
 	return mux
 }
 
@@ -72,6 +104,67 @@ func decodeKeyReq(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeListKeys(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := apiutil.ReadStringQuery(r, subjectKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req := listKeysReq{
+		token:   apiutil.ExtractBearerToken(r),
+		offset:  o,
+		limit:   l,
+		subject: s,
+	}
+
+	// Type and expiration filters are applied only if present.
+	if _, ok := r.URL.Query()[typeKey]; ok {
+		t, err := apiutil.ReadUintQuery(r, typeKey, 0)
+		if err != nil {
+			return nil, err
+		}
+		kt := uint32(t)
+		req.keyType = &kt
+	}
+
+	if _, ok := r.URL.Query()[expiredKey]; ok {
+		e, err := apiutil.ReadBoolQuery(r, expiredKey, false)
+		if err != nil {
+			return nil, err
+		}
+		req.expired = &e
+	}
+
+	return req, nil
+}
+
+func decodeRevokeKeys(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := revokeKeysReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeJWKS(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
 func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
 	w.Header().Set("Content-Type", contentType)
 
@@ -94,7 +187,12 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, apiutil.ErrMalformedEntity),
 		err == apiutil.ErrMissingID,
-		err == apiutil.ErrInvalidAPIKey:
+		err == apiutil.ErrInvalidAPIKey,
+		err == apiutil.ErrNameSize,
+		err == apiutil.ErrLimitSize,
+		err == apiutil.ErrEmptyList,
+		err == apiutil.ErrInvalidQueryParams,
+		errors.Contains(err, auth.ErrInvalidScope):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
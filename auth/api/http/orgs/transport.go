@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
@@ -36,6 +37,7 @@ const (
 func MakeHandler(svc auth.Service, mux *bone.Mux, tracer opentracing.Tracer, logger logger.Logger) *bone.Mux {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(audit.PopulateSourceIP),
 	}
 	mux.Post("/orgs", kithttp.NewServer(
 		kitot.TraceServer(tracer, "create_org")(createOrgEndpoint(svc)),
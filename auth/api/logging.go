@@ -56,6 +56,32 @@ func (lm *loggingMiddleware) Revoke(ctx context.Context, token, id string) (err
 	return lm.svc.Revoke(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) RevokeKeys(ctx context.Context, token string, ids ...string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method revoke_keys took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RevokeKeys(ctx, token, ids...)
+}
+
+func (lm *loggingMiddleware) ListKeys(ctx context.Context, token string, pm auth.KeyPageMetadata) (kp auth.KeysPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_keys took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListKeys(ctx, token, pm)
+}
+
 func (lm *loggingMiddleware) RetrieveKey(ctx context.Context, token, id string) (key auth.Key, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method retrieve for key %s took %s to complete", id, time.Since(begin))
@@ -82,6 +108,32 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, key string) (id auth.
 	return lm.svc.Identify(ctx, key)
 }
 
+func (lm *loggingMiddleware) IdentifyVerification(ctx context.Context, token string) (id auth.Identity, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method identify_verification took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.IdentifyVerification(ctx, token)
+}
+
+func (lm *loggingMiddleware) RetrievePublicKeys(ctx context.Context) (keys []auth.PublicKey, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method retrieve_public_keys took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RetrievePublicKeys(ctx)
+}
+
 func (lm *loggingMiddleware) Authorize(ctx context.Context, ar auth.AuthzReq) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method authorize took %s to complete", time.Since(begin))
@@ -237,6 +289,45 @@ func (lm *loggingMiddleware) UpdateMembers(ctx context.Context, token, orgID str
 	return lm.svc.UpdateMembers(ctx, token, orgID, oms...)
 }
 
+func (lm *loggingMiddleware) RetrieveGroupOrg(ctx context.Context, groupID string) (orgID string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method retrieve_group_org for group id %s took %s to complete", groupID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RetrieveGroupOrg(ctx, groupID)
+}
+
+func (lm *loggingMiddleware) RetrieveMemberGroups(ctx context.Context, token string) (groupIDs []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method retrieve_member_groups took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.RetrieveMemberGroups(ctx, token)
+}
+
+func (lm *loggingMiddleware) JoinOrg(ctx context.Context, orgID, memberID string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method join_org for org id %s and member id %s took %s to complete", orgID, memberID, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.JoinOrg(ctx, orgID, memberID)
+}
+
 func (lm *loggingMiddleware) AssignGroups(ctx context.Context, token, orgID string, groupIDs ...string) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method assign_groups for token %s , group ids %s and org id %s took %s to complete", token, groupIDs, orgID, time.Since(begin))
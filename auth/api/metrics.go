@@ -48,6 +48,24 @@ func (ms *metricsMiddleware) Revoke(ctx context.Context, token, id string) error
 	return ms.svc.Revoke(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) RevokeKeys(ctx context.Context, token string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_keys").Add(1)
+		ms.latency.With("method", "revoke_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeKeys(ctx, token, ids...)
+}
+
+func (ms *metricsMiddleware) ListKeys(ctx context.Context, token string, pm auth.KeyPageMetadata) (auth.KeysPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_keys").Add(1)
+		ms.latency.With("method", "list_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListKeys(ctx, token, pm)
+}
+
 func (ms *metricsMiddleware) RetrieveKey(ctx context.Context, token, id string) (auth.Key, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "retrieve_key").Add(1)
@@ -66,6 +84,24 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (auth.I
 	return ms.svc.Identify(ctx, token)
 }
 
+func (ms *metricsMiddleware) IdentifyVerification(ctx context.Context, token string) (auth.Identity, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "identify_verification").Add(1)
+		ms.latency.With("method", "identify_verification").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.IdentifyVerification(ctx, token)
+}
+
+func (ms *metricsMiddleware) RetrievePublicKeys(ctx context.Context) ([]auth.PublicKey, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_public_keys").Add(1)
+		ms.latency.With("method", "retrieve_public_keys").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrievePublicKeys(ctx)
+}
+
 func (ms *metricsMiddleware) Authorize(ctx context.Context, ar auth.AuthzReq) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize").Add(1)
@@ -170,6 +206,33 @@ func (ms *metricsMiddleware) ListOrgMembers(ctx context.Context, token, orgID st
 	return ms.svc.ListOrgMembers(ctx, token, orgID, pm)
 }
 
+func (ms *metricsMiddleware) RetrieveGroupOrg(ctx context.Context, groupID string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_group_org").Add(1)
+		ms.latency.With("method", "retrieve_group_org").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveGroupOrg(ctx, groupID)
+}
+
+func (ms *metricsMiddleware) RetrieveMemberGroups(ctx context.Context, token string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_member_groups").Add(1)
+		ms.latency.With("method", "retrieve_member_groups").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveMemberGroups(ctx, token)
+}
+
+func (ms *metricsMiddleware) JoinOrg(ctx context.Context, orgID, memberID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "join_org").Add(1)
+		ms.latency.With("method", "join_org").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.JoinOrg(ctx, orgID, memberID)
+}
+
 func (ms *metricsMiddleware) AssignGroups(ctx context.Context, token, orgID string, groupIDs ...string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "assign_groups").Add(1)
@@ -0,0 +1,315 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// HS256 represents HMAC SHA-256 signing algorithm using the shared secret.
+	HS256 = "HS256"
+	// RS256 represents RSA PKCS#1 v1.5 SHA-256 signing algorithm.
+	RS256 = "RS256"
+	// ES256 represents ECDSA P-256 SHA-256 signing algorithm.
+	ES256 = "ES256"
+
+	rsaKeyBits = 2048
+	// Unknown key IDs cause signing keys reload at most once per interval.
+	refreshInterval = time.Second
+)
+
+// neverExpires is the expiration time of the signing keys of the API keys
+// which never expire.
+var neverExpires = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+var (
+	// ErrUnsupportedAlgorithm indicates that the signing algorithm is not supported.
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+
+	// ErrInvalidRotation indicates invalid key rotation or retention period.
+	ErrInvalidRotation = errors.New("invalid signing key rotation period")
+
+	errUnknownKey = errors.New("unknown signing key")
+)
+
+// Config contains the asymmetric tokenizer settings.
+type Config struct {
+	// Algorithm used for signing tokens, RS256 or ES256.
+	Algorithm string
+
+	// Rotation is the period after which a new signing key is generated.
+	Rotation time.Duration
+
+	// Retention is the period during which the rotated key is still
+	// used to verify previously issued tokens. The keys which signed API
+	// keys are kept until the API keys expire.
+	Retention time.Duration
+}
+
+type asymmetricTokenizer struct {
+	mu        sync.Mutex
+	cfg       Config
+	method    jwt.SigningMethod
+	repo      auth.SigningKeyRepository
+	idp       mainflux.IDProvider
+	keys      map[string]auth.SigningKey
+	signing   auth.SigningKey
+	refreshed time.Time
+}
+
+// NewAsymmetric returns new JWT Tokenizer, which signs tokens using RS256 or
+// ES256 algorithm. Every token carries the ID of its signing key in the kid
+// header. Signing keys are rotated after the configured period, and rotated
+// keys are kept during the retention period, or until the API keys they signed
+// expire, to verify the tokens they signed.
+func NewAsymmetric(cfg Config, repo auth.SigningKeyRepository, idp mainflux.IDProvider) (auth.Tokenizer, error) {
+	method, err := signingMethod(cfg.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Rotation <= 0 || cfg.Retention < 0 {
+		return nil, ErrInvalidRotation
+	}
+
+	t := &asymmetricTokenizer{
+		cfg:    cfg,
+		method: method,
+		repo:   repo,
+		idp:    idp,
+		keys:   make(map[string]auth.SigningKey),
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, err := t.signingKey(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *asymmetricTokenizer) Issue(key auth.Key) (string, error) {
+	t.mu.Lock()
+	sk, err := t.signingKey()
+	if err == nil && key.Type == auth.APIKey {
+		err = t.retain(sk, key.ExpiresAt)
+	}
+	t.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(t.method, newClaims(key))
+	token.Header["kid"] = sk.ID
+	return token.SignedString(sk.PrivateKey)
+}
+
+func (t *asymmetricTokenizer) Parse(token string) (auth.Key, error) {
+	return parse(token, func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, errUnknownKey
+		}
+		sk, err := t.verificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		// Algorithm is bound to the key, so the token header can't downgrade it.
+		if token.Method.Alg() != sk.Algorithm {
+			return nil, errors.ErrAuthentication
+		}
+
+		return sk.PrivateKey.Public(), nil
+	})
+}
+
+func (t *asymmetricTokenizer) PublicKeys() ([]auth.PublicKey, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if time.Since(t.refreshed) >= refreshInterval {
+		if err := t.refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	keys := []auth.SigningKey{}
+	for _, k := range t.keys {
+		if k.ExpiresAt.After(now) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.After(keys[j].CreatedAt)
+	})
+
+	pks := make([]auth.PublicKey, len(keys))
+	for i, k := range keys {
+		pks[i] = auth.PublicKey{
+			ID:        k.ID,
+			Algorithm: k.Algorithm,
+			Key:       k.PrivateKey.Public(),
+		}
+	}
+
+	return pks, nil
+}
+
+// signingKey returns the active signing key, generating a new one if the
+// current key is due for rotation. The caller must hold the lock.
+func (t *asymmetricTokenizer) signingKey() (auth.SigningKey, error) {
+	if t.active(t.signing) {
+		return t.signing, nil
+	}
+
+	// Another instance may have already rotated the key.
+	if err := t.refresh(); err != nil {
+		return auth.SigningKey{}, err
+	}
+	if t.active(t.signing) {
+		return t.signing, nil
+	}
+
+	id, err := t.idp.ID()
+	if err != nil {
+		return auth.SigningKey{}, err
+	}
+	priv, err := generateKey(t.cfg.Algorithm)
+	if err != nil {
+		return auth.SigningKey{}, err
+	}
+	now := time.Now().UTC()
+	sk := auth.SigningKey{
+		ID:         id,
+		Algorithm:  t.cfg.Algorithm,
+		PrivateKey: priv,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(t.cfg.Rotation + t.cfg.Retention),
+	}
+
+	ctx := context.Background()
+	if err := t.repo.Save(ctx, sk); err != nil {
+		return auth.SigningKey{}, err
+	}
+	if err := t.repo.RemoveExpired(ctx); err != nil {
+		return auth.SigningKey{}, err
+	}
+	t.keys[sk.ID] = sk
+	t.signing = sk
+
+	return sk, nil
+}
+
+// retain keeps the signing key for verification until the API key it signs
+// expires. The caller must hold the lock.
+func (t *asymmetricTokenizer) retain(sk auth.SigningKey, until time.Time) error {
+	if until.IsZero() {
+		until = neverExpires
+	}
+	if !until.After(sk.ExpiresAt) {
+		return nil
+	}
+
+	if err := t.repo.Extend(context.Background(), sk.ID, until); err != nil {
+		return err
+	}
+	sk.ExpiresAt = until
+	t.keys[sk.ID] = sk
+	if t.signing.ID == sk.ID {
+		t.signing = sk
+	}
+
+	return nil
+}
+
+func (t *asymmetricTokenizer) verificationKey(kid string) (auth.SigningKey, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if sk, ok := t.unexpired(kid); ok {
+		return sk, nil
+	}
+
+	if time.Since(t.refreshed) < refreshInterval {
+		return auth.SigningKey{}, errUnknownKey
+	}
+	if err := t.refresh(); err != nil {
+		return auth.SigningKey{}, err
+	}
+	if sk, ok := t.unexpired(kid); ok {
+		return sk, nil
+	}
+
+	return auth.SigningKey{}, errUnknownKey
+}
+
+// unexpired returns the signing key which has not expired yet. The caller
+// must hold the lock.
+func (t *asymmetricTokenizer) unexpired(kid string) (auth.SigningKey, bool) {
+	sk, ok := t.keys[kid]
+	return sk, ok && sk.ExpiresAt.After(time.Now())
+}
+
+// refresh reloads the signing keys from the repository. The caller must hold the lock.
+func (t *asymmetricTokenizer) refresh() error {
+	keys, err := t.repo.RetrieveAll(context.Background())
+	if err != nil {
+		return err
+	}
+
+	t.keys = make(map[string]auth.SigningKey, len(keys))
+	t.signing = auth.SigningKey{}
+	for _, k := range keys {
+		t.keys[k.ID] = k
+		if t.active(k) && k.CreatedAt.After(t.signing.CreatedAt) {
+			t.signing = k
+		}
+	}
+	t.refreshed = time.Now()
+
+	return nil
+}
+
+func (t *asymmetricTokenizer) active(key auth.SigningKey) bool {
+	return key.PrivateKey != nil &&
+		key.Algorithm == t.cfg.Algorithm &&
+		key.CreatedAt.Add(t.cfg.Rotation).After(time.Now())
+}
+
+func signingMethod(alg string) (jwt.SigningMethod, error) {
+	switch alg {
+	case RS256:
+		return jwt.SigningMethodRS256, nil
+	case ES256:
+		return jwt.SigningMethodES256, nil
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+func generateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case RS256:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case ES256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
@@ -10,12 +10,18 @@ import (
 
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/auth/jwt"
+	"github.com/MainfluxLabs/mainflux/auth/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-const secret = "test"
+const (
+	secret    = "test"
+	rotation  = time.Hour
+	retention = 24 * time.Hour
+)
 
 func key() auth.Key {
 	exp := time.Now().UTC().Add(10 * time.Minute).Round(time.Second)
@@ -62,6 +68,12 @@ func TestParse(t *testing.T) {
 	apiToken, err := tokenizer.Issue(apiKey)
 	require.Nil(t, err, fmt.Sprintf("issuing user key expected to succeed: %s", err))
 
+	scopedKey := key()
+	scopedKey.Type = auth.APIKey
+	scopedKey.Scopes = []auth.Scope{{Resource: auth.ThingsSubject, Action: auth.ReadScope}, {Resource: auth.MessagesSubject, Action: auth.ReadScope, ID: "chanID"}}
+	scopedToken, err := tokenizer.Issue(scopedKey)
+	require.Nil(t, err, fmt.Sprintf("issuing scoped key expected to succeed: %s", err))
+
 	expKey := key()
 	expKey.ExpiresAt = time.Now().UTC().Add(-1 * time.Minute).Round(time.Second)
 	expToken, err := tokenizer.Issue(expKey)
@@ -97,6 +109,12 @@ func TestParse(t *testing.T) {
 			token: apiToken,
 			err:   auth.ErrAPIKeyExpired,
 		},
+		{
+			desc:  "parse scoped API key",
+			key:   scopedKey,
+			token: scopedToken,
+			err:   nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -105,3 +123,210 @@ func TestParse(t *testing.T) {
 		assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.key, key))
 	}
 }
+
+func newAsymmetric(t *testing.T, alg string, rotation time.Duration, repo auth.SigningKeyRepository) auth.Tokenizer {
+	tokenizer, err := jwt.NewAsymmetric(jwt.Config{Algorithm: alg, Rotation: rotation, Retention: retention}, repo, uuid.New())
+	require.Nil(t, err, fmt.Sprintf("creating %s tokenizer expected to succeed: %s", alg, err))
+	return tokenizer
+}
+
+func TestNewAsymmetric(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  jwt.Config
+		err  error
+	}{
+		{
+			desc: "create RS256 tokenizer",
+			cfg:  jwt.Config{Algorithm: jwt.RS256, Rotation: rotation, Retention: retention},
+			err:  nil,
+		},
+		{
+			desc: "create ES256 tokenizer",
+			cfg:  jwt.Config{Algorithm: jwt.ES256, Rotation: rotation, Retention: retention},
+			err:  nil,
+		},
+		{
+			desc: "create tokenizer with unsupported algorithm",
+			cfg:  jwt.Config{Algorithm: jwt.HS256, Rotation: rotation, Retention: retention},
+			err:  jwt.ErrUnsupportedAlgorithm,
+		},
+		{
+			desc: "create tokenizer with invalid rotation",
+			cfg:  jwt.Config{Algorithm: jwt.ES256, Rotation: 0, Retention: retention},
+			err:  jwt.ErrInvalidRotation,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := jwt.NewAsymmetric(tc.cfg, mocks.NewSigningKeyRepository(), uuid.New())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestAsymmetricParse(t *testing.T) {
+	for _, alg := range []string{jwt.RS256, jwt.ES256} {
+		tokenizer := newAsymmetric(t, alg, rotation, mocks.NewSigningKeyRepository())
+		other := newAsymmetric(t, alg, rotation, mocks.NewSigningKeyRepository())
+
+		token, err := tokenizer.Issue(key())
+		require.Nil(t, err, fmt.Sprintf("%s: issuing key expected to succeed: %s", alg, err))
+
+		otherToken, err := other.Issue(key())
+		require.Nil(t, err, fmt.Sprintf("%s: issuing key expected to succeed: %s", alg, err))
+
+		hsToken, err := jwt.New(secret).Issue(key())
+		require.Nil(t, err, fmt.Sprintf("%s: issuing key expected to succeed: %s", alg, err))
+
+		expKey := key()
+		expKey.ExpiresAt = time.Now().UTC().Add(-1 * time.Minute).Round(time.Second)
+		expToken, err := tokenizer.Issue(expKey)
+		require.Nil(t, err, fmt.Sprintf("%s: issuing expired key expected to succeed: %s", alg, err))
+
+		cases := []struct {
+			desc  string
+			key   auth.Key
+			token string
+			err   error
+		}{
+			{
+				desc:  "parse valid key",
+				key:   key(),
+				token: token,
+				err:   nil,
+			},
+			{
+				desc:  "parse key signed with unknown signing key",
+				key:   auth.Key{},
+				token: otherToken,
+				err:   errors.ErrAuthentication,
+			},
+			{
+				desc:  "parse key signed with shared secret",
+				key:   auth.Key{},
+				token: hsToken,
+				err:   errors.ErrAuthentication,
+			},
+			{
+				desc:  "parse expired key",
+				key:   auth.Key{},
+				token: expToken,
+				err:   auth.ErrKeyExpired,
+			},
+		}
+
+		for _, tc := range cases {
+			key, err := tokenizer.Parse(tc.token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: %s expected %s, got %s", alg, tc.desc, tc.err, err))
+			assert.Equal(t, tc.key, key, fmt.Sprintf("%s: %s expected %v, got %v", alg, tc.desc, tc.key, key))
+		}
+	}
+}
+
+func TestRotation(t *testing.T) {
+	tokenizer := newAsymmetric(t, jwt.ES256, time.Millisecond, mocks.NewSigningKeyRepository())
+
+	key := key()
+	first, err := tokenizer.Issue(key)
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+	time.Sleep(2 * time.Millisecond)
+	second, err := tokenizer.Issue(key)
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	for _, token := range []string{first, second} {
+		k, err := tokenizer.Parse(token)
+		assert.Nil(t, err, fmt.Sprintf("parsing token signed with rotated key expected to succeed: %s", err))
+		assert.Equal(t, key, k, fmt.Sprintf("expected %v, got %v", key, k))
+	}
+
+	// Wait for the keys to be reloaded from the repository.
+	time.Sleep(time.Second)
+	pks, err := tokenizer.PublicKeys()
+	assert.Nil(t, err, fmt.Sprintf("retrieving public keys expected to succeed: %s", err))
+	assert.GreaterOrEqual(t, len(pks), 2, fmt.Sprintf("expected at least 2 public keys, got %d", len(pks)))
+	for _, pk := range pks {
+		assert.Equal(t, jwt.ES256, pk.Algorithm, fmt.Sprintf("expected algorithm %s, got %s", jwt.ES256, pk.Algorithm))
+	}
+}
+
+func TestAPIKeyRetention(t *testing.T) {
+	tokenizer, err := jwt.NewAsymmetric(jwt.Config{Algorithm: jwt.ES256, Rotation: time.Millisecond}, mocks.NewSigningKeyRepository(), uuid.New())
+	require.Nil(t, err, fmt.Sprintf("creating tokenizer expected to succeed: %s", err))
+
+	loginKey := key()
+	loginToken, err := tokenizer.Issue(loginKey)
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	// Signing key is rotated, so the API keys are signed with the new key.
+	time.Sleep(2 * time.Millisecond)
+	apiKey := key()
+	apiKey.Type = auth.APIKey
+	apiToken, err := tokenizer.Issue(apiKey)
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	time.Sleep(2 * time.Millisecond)
+	foreverKey := key()
+	foreverKey.Type = auth.APIKey
+	foreverKey.ExpiresAt = time.Time{}
+	foreverToken, err := tokenizer.Issue(foreverKey)
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	// Wait for the signing keys to expire and be reloaded from the repository.
+	time.Sleep(time.Second)
+
+	cases := []struct {
+		desc  string
+		key   auth.Key
+		token string
+		err   error
+	}{
+		{
+			desc:  "parse key signed with expired signing key",
+			key:   auth.Key{},
+			token: loginToken,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "parse API key signed with rotated signing key",
+			key:   apiKey,
+			token: apiToken,
+			err:   nil,
+		},
+		{
+			desc:  "parse API key without expiration signed with rotated signing key",
+			key:   foreverKey,
+			token: foreverToken,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		key, err := tokenizer.Parse(tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.key, key))
+	}
+}
+
+func TestSharedSigningKeys(t *testing.T) {
+	repo := mocks.NewSigningKeyRepository()
+	verifier := newAsymmetric(t, jwt.ES256, rotation, repo)
+	signer := newAsymmetric(t, jwt.RS256, rotation, repo)
+
+	key := key()
+	token, err := signer.Issue(key)
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	// Unknown signing key is looked up in the repository at most once per second.
+	time.Sleep(time.Second)
+	k, err := verifier.Parse(token)
+	assert.Nil(t, err, fmt.Sprintf("parsing token signed by another instance expected to succeed: %s", err))
+	assert.Equal(t, key, k, fmt.Sprintf("expected %v, got %v", key, k))
+
+	pks, err := verifier.PublicKeys()
+	assert.Nil(t, err, fmt.Sprintf("retrieving public keys expected to succeed: %s", err))
+	assert.Len(t, pks, 2, fmt.Sprintf("expected 2 public keys, got %d", len(pks)))
+
+	pks, err = jwt.New(secret).PublicKeys()
+	assert.Nil(t, err, fmt.Sprintf("retrieving public keys expected to succeed: %s", err))
+	assert.Empty(t, pks, fmt.Sprintf("expected no public keys for shared secret, got %d", len(pks)))
+}
@@ -15,14 +15,18 @@ const issuerName = "mainflux.auth"
 
 type claims struct {
 	jwt.StandardClaims
-	IssuerID string  `json:"issuer_id,omitempty"`
-	Type     *uint32 `json:"type,omitempty"`
+	IssuerID string   `json:"issuer_id,omitempty"`
+	Type     *uint32  `json:"type,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
 }
 
 func (c claims) Valid() error {
-	if c.Type == nil || *c.Type > auth.APIKey || c.Issuer != issuerName {
+	if c.Type == nil || *c.Type > auth.VerificationKey || c.Issuer != issuerName {
 		return errors.ErrMalformedEntity
 	}
+	if _, err := auth.ParseScopes(c.Scopes); err != nil {
+		return errors.Wrap(errors.ErrMalformedEntity, err)
+	}
 
 	return c.StandardClaims.Valid()
 }
@@ -31,12 +35,31 @@ type tokenizer struct {
 	secret string
 }
 
-// New returns new JWT Tokenizer.
+// New returns new JWT Tokenizer, which signs tokens with the shared secret
+// using HS256 algorithm.
 func New(secret string) auth.Tokenizer {
 	return tokenizer{secret: secret}
 }
 
 func (svc tokenizer) Issue(key auth.Key) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims(key))
+	return token.SignedString([]byte(svc.secret))
+}
+
+func (svc tokenizer) Parse(token string) (auth.Key, error) {
+	return parse(token, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.ErrAuthentication
+		}
+		return []byte(svc.secret), nil
+	})
+}
+
+func (svc tokenizer) PublicKeys() ([]auth.PublicKey, error) {
+	return []auth.PublicKey{}, nil
+}
+
+func newClaims(key auth.Key) claims {
 	claims := claims{
 		StandardClaims: jwt.StandardClaims{
 			Issuer:   issuerName,
@@ -45,6 +68,7 @@ func (svc tokenizer) Issue(key auth.Key) (string, error) {
 		},
 		IssuerID: key.IssuerID,
 		Type:     &key.Type,
+		Scopes:   key.ScopeStrings(),
 	}
 
 	if !key.ExpiresAt.IsZero() {
@@ -54,18 +78,12 @@ func (svc tokenizer) Issue(key auth.Key) (string, error) {
 		claims.Id = key.ID
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(svc.secret))
+	return claims
 }
 
-func (svc tokenizer) Parse(token string) (auth.Key, error) {
+func parse(token string, keyFunc jwt.Keyfunc) (auth.Key, error) {
 	c := claims{}
-	_, err := jwt.ParseWithClaims(token, &c, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.ErrAuthentication
-		}
-		return []byte(svc.secret), nil
-	})
+	_, err := jwt.ParseWithClaims(token, &c, keyFunc)
 
 	if err != nil {
 		if e, ok := err.(*jwt.ValidationError); ok && e.Errors == jwt.ValidationErrorExpired {
@@ -97,5 +115,8 @@ func (c claims) toKey() auth.Key {
 		key.Type = *c.Type
 	}
 
+	// Scopes are validated as a part of claims validation.
+	key.Scopes, _ = auth.ParseScopes(c.Scopes)
+
 	return key
 }
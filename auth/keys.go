@@ -28,16 +28,40 @@ const (
 	RecoveryKey
 	// APIKey enables the one to act on behalf of the user.
 	APIKey
+	// VerificationKey represents a key for verifying the user email.
+	VerificationKey
 )
 
 // Key represents API key.
 type Key struct {
-	ID        string
-	Type      uint32
-	IssuerID  string
-	Subject   string
-	IssuedAt  time.Time
-	ExpiresAt time.Time
+	ID          string
+	Type        uint32
+	IssuerID    string
+	Subject     string
+	Name        string
+	Description string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+	LastUsedAt  time.Time
+	Scopes      []Scope
+}
+
+// KeyPageMetadata contains page metadata and filters used for listing keys.
+// Nil Type and Expired filters match keys of any type and expiration status.
+type KeyPageMetadata struct {
+	Total   uint64
+	Offset  uint64
+	Limit   uint64
+	Type    *uint32
+	Expired *bool
+	Subject string
+}
+
+// KeysPage contains page related metadata as well as list of keys that
+// belong to this page.
+type KeysPage struct {
+	KeyPageMetadata
+	Keys []Key
 }
 
 // Identity contains ID and Email.
@@ -63,6 +87,15 @@ type KeyRepository interface {
 	// Retrieve retrieves Key by its unique identifier.
 	Retrieve(context.Context, string, string) (Key, error)
 
+	// RetrieveByIssuer retrieves a page of Keys issued by the given issuer.
+	RetrieveByIssuer(ctx context.Context, issuerID string, pm KeyPageMetadata) (KeysPage, error)
+
+	// UpdateLastUsed records the time the Key was last used.
+	UpdateLastUsed(ctx context.Context, issuerID, id string, at time.Time) error
+
 	// Remove removes Key with provided ID.
 	Remove(context.Context, string, string) error
+
+	// RemoveKeys removes Keys with provided IDs issued by the given issuer.
+	RemoveKeys(ctx context.Context, issuerID string, ids ...string) error
 }
@@ -5,7 +5,9 @@ package mocks
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -54,3 +56,67 @@ func (krm *keyRepositoryMock) Remove(ctx context.Context, issuerID, id string) e
 	}
 	return nil
 }
+
+func (krm *keyRepositoryMock) RetrieveByIssuer(ctx context.Context, issuerID string, pm auth.KeyPageMetadata) (auth.KeysPage, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	keys := []auth.Key{}
+	for _, key := range krm.keys {
+		if key.IssuerID != issuerID {
+			continue
+		}
+		if pm.Type != nil && key.Type != *pm.Type {
+			continue
+		}
+		if pm.Subject != "" && key.Subject != pm.Subject {
+			continue
+		}
+		if pm.Expired != nil && key.Expired() != *pm.Expired {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].IssuedAt.After(keys[j].IssuedAt)
+	})
+
+	pm.Total = uint64(len(keys))
+	start := pm.Offset
+	if start > pm.Total {
+		start = pm.Total
+	}
+	end := pm.Total
+	if pm.Limit > 0 && start+pm.Limit < end {
+		end = start + pm.Limit
+	}
+
+	return auth.KeysPage{
+		KeyPageMetadata: pm,
+		Keys:            keys[start:end],
+	}, nil
+}
+
+func (krm *keyRepositoryMock) UpdateLastUsed(ctx context.Context, issuerID, id string, at time.Time) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	if key, ok := krm.keys[id]; ok && key.IssuerID == issuerID {
+		key.LastUsedAt = at
+		krm.keys[id] = key
+	}
+	return nil
+}
+
+func (krm *keyRepositoryMock) RemoveKeys(ctx context.Context, issuerID string, ids ...string) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for _, id := range ids {
+		if key, ok := krm.keys[id]; ok && key.IssuerID == issuerID {
+			delete(krm.keys, id)
+		}
+	}
+	return nil
+}
@@ -145,6 +145,7 @@ func (orm *orgRepositoryMock) AssignMembers(ctx context.Context, oms ...auth.Org
 		}
 		orm.orgMembers[om.MemberID] = auth.OrgMember{
 			MemberID: om.MemberID,
+			OrgID:    om.OrgID,
 			Role:     om.Role,
 		}
 	}
@@ -176,6 +177,7 @@ func (orm *orgRepositoryMock) UpdateMembers(ctx context.Context, oms ...auth.Org
 		}
 		orm.orgMembers[om.MemberID] = auth.OrgMember{
 			MemberID: om.MemberID,
+			OrgID:    om.OrgID,
 			Role:     om.Role,
 		}
 	}
@@ -233,6 +235,7 @@ func (orm *orgRepositoryMock) AssignGroups(ctx context.Context, ogs ...auth.OrgG
 		}
 		orm.orgGroups[gr.GroupID] = auth.OrgGroup{
 			GroupID: gr.GroupID,
+			OrgID:   gr.OrgID,
 		}
 	}
 
@@ -290,7 +293,26 @@ func (orm *orgRepositoryMock) RetrieveByGroupID(ctx context.Context, groupID str
 		return auth.Org{}, errors.ErrNotFound
 	}
 
-	return orm.orgs[org.GroupID], nil
+	return orm.orgs[org.OrgID], nil
+}
+
+func (orm *orgRepositoryMock) RetrieveMemberGroups(ctx context.Context, memberID string) ([]string, error) {
+	orm.mu.Lock()
+	defer orm.mu.Unlock()
+
+	om, ok := orm.orgMembers[memberID]
+	if !ok {
+		return nil, nil
+	}
+
+	var groupIDs []string
+	for _, og := range orm.orgGroups {
+		if og.OrgID == om.OrgID {
+			groupIDs = append(groupIDs, og.GroupID)
+		}
+	}
+
+	return groupIDs, nil
 }
 
 func (orm *orgRepositoryMock) RetrieveAll(ctx context.Context) ([]auth.Org, error) {
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ auth.SigningKeyRepository = (*signingKeyRepositoryMock)(nil)
+
+type signingKeyRepositoryMock struct {
+	mu   sync.Mutex
+	keys map[string]auth.SigningKey
+}
+
+// NewSigningKeyRepository creates in-memory token signing key repository.
+func NewSigningKeyRepository() auth.SigningKeyRepository {
+	return &signingKeyRepositoryMock{
+		keys: make(map[string]auth.SigningKey),
+	}
+}
+
+func (skrm *signingKeyRepositoryMock) Save(ctx context.Context, key auth.SigningKey) error {
+	skrm.mu.Lock()
+	defer skrm.mu.Unlock()
+
+	if _, ok := skrm.keys[key.ID]; ok {
+		return errors.ErrConflict
+	}
+
+	skrm.keys[key.ID] = key
+	return nil
+}
+
+func (skrm *signingKeyRepositoryMock) RetrieveAll(ctx context.Context) ([]auth.SigningKey, error) {
+	skrm.mu.Lock()
+	defer skrm.mu.Unlock()
+
+	now := time.Now()
+	keys := []auth.SigningKey{}
+	for _, k := range skrm.keys {
+		if k.ExpiresAt.After(now) {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys, nil
+}
+
+func (skrm *signingKeyRepositoryMock) Extend(ctx context.Context, id string, expiresAt time.Time) error {
+	skrm.mu.Lock()
+	defer skrm.mu.Unlock()
+
+	k, ok := skrm.keys[id]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if expiresAt.After(k.ExpiresAt) {
+		k.ExpiresAt = expiresAt
+		skrm.keys[id] = k
+	}
+
+	return nil
+}
+
+func (skrm *signingKeyRepositoryMock) RemoveExpired(ctx context.Context) error {
+	skrm.mu.Lock()
+	defer skrm.mu.Unlock()
+
+	now := time.Now()
+	for id, k := range skrm.keys {
+		if !k.ExpiresAt.After(now) {
+			delete(skrm.keys, id)
+		}
+	}
+
+	return nil
+}
@@ -136,6 +136,12 @@ type Orgs interface {
 	// AssignMembers adds members with member emails into the org identified by orgID.
 	AssignMembers(ctx context.Context, token, orgID string, oms ...OrgMember) error
 
+	// JoinOrg adds the user identified by memberID to the org identified by orgID
+	// with the viewer role. It's used by the users service for the orgs mapped to
+	// the identity provider groups, so it's not exposed over HTTP. Joining the org
+	// the user is already a member of has no effect.
+	JoinOrg(ctx context.Context, orgID, memberID string) error
+
 	// UnassignMembers removes members with member ids from org identified by orgID.
 	UnassignMembers(ctx context.Context, token string, orgID string, memberIDs ...string) error
 
@@ -157,6 +163,18 @@ type Orgs interface {
 	//ViewGroupMembership retrieves orgs where group is assigned.
 	ViewGroupMembership(ctx context.Context, token, groupID string) (Org, error)
 
+	// RetrieveGroupOrg retrieves the ID of the org the group is assigned to, or
+	// an empty ID if the group isn't assigned to any org. It's used by the other
+	// services to scope their audit records to the org, so it's not exposed over
+	// HTTP.
+	RetrieveGroupOrg(ctx context.Context, groupID string) (string, error)
+
+	// RetrieveMemberGroups retrieves the IDs of the groups assigned to the orgs
+	// the user identified by the provided token is a member of. It's used by
+	// the things service to list the things and channels of the groups, so
+	// it's not exposed over HTTP.
+	RetrieveMemberGroups(ctx context.Context, token string) ([]string, error)
+
 	// ListOrgGroups retrieves groups assigned to an org identified by orgID.
 	ListOrgGroups(ctx context.Context, token, orgID string, pm PageMetadata) (GroupsPage, error)
 
@@ -223,6 +241,10 @@ type OrgRepository interface {
 	// RetrieveByGroupID retrieves org where group is assigned.
 	RetrieveByGroupID(ctx context.Context, groupID string) (Org, error)
 
+	// RetrieveMemberGroups retrieves the IDs of the groups assigned to the
+	// orgs the member belongs to.
+	RetrieveMemberGroups(ctx context.Context, memberID string) ([]string, error)
+
 	// RetrieveAllOrgGroups retrieves all org groups.
 	RetrieveAllOrgGroups(ctx context.Context) ([]OrgGroup, error)
 }
@@ -147,6 +147,53 @@ func migrateDB(db *sqlx.DB) error {
 					`ALTER TABLE group_relations ADD CONSTRAINT group_relations_org_id_fkey FOREIGN KEY (org_id) REFERENCES orgs (id) ON DELETE CASCADE ON UPDATE CASCADE`,
 				},
 			},
+			{
+				Id: "auth_8",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS signing_keys (
+						id          VARCHAR(254) NOT NULL,
+						algorithm   VARCHAR(16) NOT NULL,
+						private_key TEXT NOT NULL,
+						created_at  TIMESTAMP NOT NULL,
+						expires_at  TIMESTAMP NOT NULL,
+						PRIMARY KEY (id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS signing_keys`,
+				},
+			},
+			{
+				Id: "auth_9",
+				Up: []string{
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS name VARCHAR(254)`,
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS description VARCHAR(1024)`,
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS last_used_at TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE keys DROP COLUMN IF EXISTS name`,
+					`ALTER TABLE keys DROP COLUMN IF EXISTS description`,
+					`ALTER TABLE keys DROP COLUMN IF EXISTS last_used_at`,
+				},
+			},
+			{
+				Id: "auth_10",
+				Up: []string{
+					`ALTER TABLE keys ADD COLUMN IF NOT EXISTS scopes TEXT`,
+				},
+				Down: []string{
+					`ALTER TABLE keys DROP COLUMN IF EXISTS scopes`,
+				},
+			},
+			{
+				Id: "auth_11",
+				Up: []string{
+					`ALTER TABLE signing_keys ADD COLUMN IF NOT EXISTS master_key_id VARCHAR(254)`,
+				},
+				Down: []string{
+					`ALTER TABLE signing_keys DROP COLUMN IF EXISTS master_key_id`,
+				},
+			},
 		},
 	}
 
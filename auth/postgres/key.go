@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/auth"
@@ -11,6 +13,9 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// Scopes are stored space separated, the same way OAuth 2.0 represents them.
+const scopesSep = " "
+
 var _ auth.KeyRepository = (*repo)(nil)
 
 type repo struct {
@@ -25,8 +30,8 @@ func New(db Database) auth.KeyRepository {
 }
 
 func (kr repo) Save(ctx context.Context, key auth.Key) (string, error) {
-	q := `INSERT INTO keys (id, type, issuer_id, subject, issued_at, expires_at)
-	      VALUES (:id, :type, :issuer_id, :subject, :issued_at, :expires_at)`
+	q := `INSERT INTO keys (id, type, issuer_id, subject, name, description, scopes, issued_at, expires_at)
+	      VALUES (:id, :type, :issuer_id, :subject, :name, :description, :scopes, :issued_at, :expires_at)`
 
 	dbKey := toDBKey(key)
 	if _, err := kr.db.NamedExecContext(ctx, q, dbKey); err != nil {
@@ -42,7 +47,7 @@ func (kr repo) Save(ctx context.Context, key auth.Key) (string, error) {
 }
 
 func (kr repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, error) {
-	q := `SELECT id, type, issuer_id, subject, issued_at, expires_at FROM keys WHERE issuer_id = $1 AND id = $2`
+	q := `SELECT id, type, issuer_id, subject, name, description, scopes, issued_at, expires_at, last_used_at FROM keys WHERE issuer_id = $1 AND id = $2`
 	key := dbKey{}
 	if err := kr.db.QueryRowxContext(ctx, q, issuerID, id).StructScan(&key); err != nil {
 		pgErr, ok := err.(*pgconn.PgError)
@@ -56,6 +61,86 @@ func (kr repo) Retrieve(ctx context.Context, issuerID, id string) (auth.Key, err
 	return toKey(key), nil
 }
 
+func (kr repo) RetrieveByIssuer(ctx context.Context, issuerID string, pm auth.KeyPageMetadata) (auth.KeysPage, error) {
+	query := []string{"issuer_id = :issuer_id"}
+	if pm.Type != nil {
+		query = append(query, "type = :type")
+	}
+	if pm.Subject != "" {
+		query = append(query, "subject = :subject")
+	}
+	if pm.Expired != nil {
+		// API keys without expiration time never expire.
+		eq := "(expires_at IS NULL OR expires_at >= :now)"
+		if *pm.Expired {
+			eq = "expires_at < :now"
+		}
+		query = append(query, eq)
+	}
+	whereClause := fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, type, issuer_id, subject, name, description, scopes, issued_at, expires_at, last_used_at FROM keys
+	      %s ORDER BY issued_at DESC %s;`, whereClause, olq)
+
+	params := map[string]interface{}{
+		"issuer_id": issuerID,
+		"subject":   pm.Subject,
+		"now":       time.Now().UTC(),
+		"limit":     pm.Limit,
+		"offset":    pm.Offset,
+	}
+	if pm.Type != nil {
+		params["type"] = *pm.Type
+	}
+
+	rows, err := kr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return auth.KeysPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	keys := []auth.Key{}
+	for rows.Next() {
+		dbk := dbKey{}
+		if err := rows.StructScan(&dbk); err != nil {
+			return auth.KeysPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		keys = append(keys, toKey(dbk))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM keys %s;`, whereClause)
+	total, err := total(ctx, kr.db, cq, params)
+	if err != nil {
+		return auth.KeysPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	pm.Total = total
+	return auth.KeysPage{
+		KeyPageMetadata: pm,
+		Keys:            keys,
+	}, nil
+}
+
+func (kr repo) UpdateLastUsed(ctx context.Context, issuerID, id string, at time.Time) error {
+	q := `UPDATE keys SET last_used_at = :last_used_at WHERE issuer_id = :issuer_id AND id = :id`
+	key := dbKey{
+		ID:         id,
+		IssuerID:   issuerID,
+		LastUsedAt: sql.NullTime{Time: at, Valid: true},
+	}
+	if _, err := kr.db.NamedExecContext(ctx, q, key); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (kr repo) Remove(ctx context.Context, issuerID, id string) error {
 	q := `DELETE FROM keys WHERE issuer_id = :issuer_id AND id = :id`
 	key := dbKey{
@@ -69,14 +154,43 @@ func (kr repo) Remove(ctx context.Context, issuerID, id string) error {
 	return nil
 }
 
+func (kr repo) RemoveKeys(ctx context.Context, issuerID string, ids ...string) error {
+	tx, err := kr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	q := `DELETE FROM keys WHERE issuer_id = :issuer_id AND id = :id`
+	for _, id := range ids {
+		key := dbKey{
+			ID:       id,
+			IssuerID: issuerID,
+		}
+		if _, err := tx.NamedExecContext(ctx, q, key); err != nil {
+			tx.Rollback()
+			return errors.Wrap(errors.ErrRemoveEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 type dbKey struct {
-	ID        string       `db:"id"`
-	Type      uint32       `db:"type"`
-	IssuerID  string       `db:"issuer_id"`
-	Subject   string       `db:"subject"`
-	Revoked   bool         `db:"revoked"`
-	IssuedAt  time.Time    `db:"issued_at"`
-	ExpiresAt sql.NullTime `db:"expires_at"`
+	ID          string         `db:"id"`
+	Type        uint32         `db:"type"`
+	IssuerID    string         `db:"issuer_id"`
+	Subject     string         `db:"subject"`
+	Name        sql.NullString `db:"name"`
+	Description sql.NullString `db:"description"`
+	Scopes      sql.NullString `db:"scopes"`
+	Revoked     bool           `db:"revoked"`
+	IssuedAt    time.Time      `db:"issued_at"`
+	ExpiresAt   sql.NullTime   `db:"expires_at"`
+	LastUsedAt  sql.NullTime   `db:"last_used_at"`
 }
 
 func toDBKey(key auth.Key) dbKey {
@@ -87,24 +201,45 @@ func toDBKey(key auth.Key) dbKey {
 		Subject:  key.Subject,
 		IssuedAt: key.IssuedAt,
 	}
+	if key.Name != "" {
+		ret.Name = sql.NullString{String: key.Name, Valid: true}
+	}
+	if key.Description != "" {
+		ret.Description = sql.NullString{String: key.Description, Valid: true}
+	}
+	if key.Scoped() {
+		ret.Scopes = sql.NullString{String: strings.Join(key.ScopeStrings(), scopesSep), Valid: true}
+	}
 	if !key.ExpiresAt.IsZero() {
 		ret.ExpiresAt = sql.NullTime{Time: key.ExpiresAt, Valid: true}
 	}
+	if !key.LastUsedAt.IsZero() {
+		ret.LastUsedAt = sql.NullTime{Time: key.LastUsedAt, Valid: true}
+	}
 
 	return ret
 }
 
 func toKey(key dbKey) auth.Key {
 	ret := auth.Key{
-		ID:       key.ID,
-		Type:     key.Type,
-		IssuerID: key.IssuerID,
-		Subject:  key.Subject,
-		IssuedAt: key.IssuedAt,
+		ID:          key.ID,
+		Type:        key.Type,
+		IssuerID:    key.IssuerID,
+		Subject:     key.Subject,
+		Name:        key.Name.String,
+		Description: key.Description.String,
+		IssuedAt:    key.IssuedAt,
 	}
 	if key.ExpiresAt.Valid {
 		ret.ExpiresAt = key.ExpiresAt.Time
 	}
+	if key.LastUsedAt.Valid {
+		ret.LastUsedAt = key.LastUsedAt.Time
+	}
+	if key.Scopes.Valid {
+		// Scopes are validated before they are stored.
+		ret.Scopes, _ = auth.ParseScopes(strings.Fields(key.Scopes.String))
+	}
 
 	return ret
 }
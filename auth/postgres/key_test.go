@@ -158,3 +158,100 @@ func TestKeyRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestKeyRetrieveByIssuer(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.New(dbMiddleware)
+
+	issuerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		id, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+		key := auth.Key{
+			ID:       id,
+			Type:     auth.APIKey,
+			Subject:  email,
+			Name:     fmt.Sprintf("key-%d", i),
+			IssuerID: issuerID,
+			IssuedAt: time.Now(),
+		}
+		// Every other key is already expired.
+		if i%2 == 1 {
+			key.ExpiresAt = time.Now().Add(-time.Minute)
+		}
+		_, err = repo.Save(context.Background(), key)
+		require.Nil(t, err, fmt.Sprintf("Storing Key expected to succeed: %s", err))
+	}
+
+	expired := true
+	apiKey := auth.APIKey
+
+	cases := []struct {
+		desc  string
+		pm    auth.KeyPageMetadata
+		size  uint64
+		total uint64
+	}{
+		{
+			desc:  "retrieve all keys",
+			pm:    auth.KeyPageMetadata{},
+			size:  n,
+			total: n,
+		},
+		{
+			desc:  "retrieve keys with limit and offset",
+			pm:    auth.KeyPageMetadata{Offset: 2, Limit: 3},
+			size:  3,
+			total: n,
+		},
+		{
+			desc:  "retrieve expired keys",
+			pm:    auth.KeyPageMetadata{Expired: &expired},
+			size:  n / 2,
+			total: n / 2,
+		},
+		{
+			desc:  "retrieve API keys by subject",
+			pm:    auth.KeyPageMetadata{Type: &apiKey, Subject: email},
+			size:  n,
+			total: n,
+		},
+		{
+			desc:  "retrieve keys by unknown subject",
+			pm:    auth.KeyPageMetadata{Subject: "unknown@example.com"},
+			size:  0,
+			total: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveByIssuer(context.Background(), issuerID, tc.pm)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.size, uint64(len(page.Keys)), fmt.Sprintf("%s: expected %d keys got %d\n", tc.desc, tc.size, len(page.Keys)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+
+	page, err := repo.RetrieveByIssuer(context.Background(), issuerID, auth.KeyPageMetadata{})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	err = repo.UpdateLastUsed(context.Background(), issuerID, page.Keys[0].ID, now)
+	assert.Nil(t, err, fmt.Sprintf("update last used: expected no error got %s\n", err))
+	key, err := repo.Retrieve(context.Background(), issuerID, page.Keys[0].ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve key: expected no error got %s\n", err))
+	assert.True(t, now.Equal(key.LastUsedAt), fmt.Sprintf("update last used: expected %s got %s\n", now, key.LastUsedAt))
+
+	var ids []string
+	for _, k := range page.Keys {
+		ids = append(ids, k.ID)
+	}
+	err = repo.RemoveKeys(context.Background(), issuerID, ids...)
+	assert.Nil(t, err, fmt.Sprintf("remove keys: expected no error got %s\n", err))
+	page, err = repo.RetrieveByIssuer(context.Background(), issuerID, auth.KeyPageMetadata{})
+	assert.Nil(t, err, fmt.Sprintf("retrieve keys: expected no error got %s\n", err))
+	assert.Empty(t, page.Keys, fmt.Sprintf("remove keys: expected no keys got %d\n", len(page.Keys)))
+}
@@ -615,6 +615,29 @@ func (or orgRepository) RetrieveAllOrgMembers(ctx context.Context) ([]auth.OrgMe
 	return oms, nil
 }
 
+func (or orgRepository) RetrieveMemberGroups(ctx context.Context, memberID string) ([]string, error) {
+	q := `SELECT gre.group_id FROM group_relations gre, member_relations mre
+		WHERE gre.org_id = mre.org_id AND mre.member_id = :member_id;`
+
+	rows, err := or.db.NamedQueryContext(ctx, q, map[string]interface{}{"member_id": memberID})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var groupIDs []string
+	for rows.Next() {
+		var groupID string
+		if err := rows.Scan(&groupID); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		groupIDs = append(groupIDs, groupID)
+	}
+
+	return groupIDs, nil
+}
+
 func (or orgRepository) RetrieveAllOrgGroups(ctx context.Context) ([]auth.OrgGroup, error) {
 	q := `SELECT org_id, group_id, created_at, updated_at FROM group_relations;`
 
@@ -1626,3 +1626,74 @@ func TestRetrieveAllOrgGroups(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestRetrieveMemberGroups(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewOrgRepo(dbMiddleware)
+
+	ownerID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	memberID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	outsiderID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	orgID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	org := auth.Org{
+		ID:          orgID,
+		OwnerID:     ownerID,
+		Name:        orgName,
+		Description: orgDesc,
+		Metadata:    map[string]interface{}{"key": "value"},
+	}
+
+	err = repo.Save(context.Background(), org)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	om := auth.OrgMember{
+		OrgID:     orgID,
+		MemberID:  memberID,
+		Role:      auth.ViewerRole,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err = repo.AssignMembers(context.Background(), om)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	og := auth.OrgGroup{
+		OrgID:     orgID,
+		GroupID:   groupID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err = repo.AssignGroups(context.Background(), og)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		memberID string
+		groupIDs []string
+	}{
+		{
+			desc:     "retrieve groups of org member",
+			memberID: memberID,
+			groupIDs: []string{groupID},
+		},
+		{
+			desc:     "retrieve groups of user without orgs",
+			memberID: outsiderID,
+			groupIDs: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		groupIDs, err := repo.RetrieveMemberGroups(context.Background(), tc.memberID)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.groupIDs, groupIDs, fmt.Sprintf("%s: expected groups %v got %v\n", tc.desc, tc.groupIDs, groupIDs))
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"database/sql"
+	"encoding/base64"
+	"encoding/pem"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const pemType = "PRIVATE KEY"
+
+var (
+	_ auth.SigningKeyRepository = (*signingKeyRepository)(nil)
+
+	errInvalidPrivateKey = errors.New("invalid signing private key")
+)
+
+type signingKeyRepository struct {
+	db      Database
+	keyring encryption.Keyring
+}
+
+// NewSigningKeyRepository instantiates a PostgreSQL implementation of
+// token signing key repository. Private keys are stored wrapped by the
+// current master key of the keyring.
+func NewSigningKeyRepository(db Database, keyring encryption.Keyring) auth.SigningKeyRepository {
+	return &signingKeyRepository{
+		db:      db,
+		keyring: keyring,
+	}
+}
+
+func (skr signingKeyRepository) Save(ctx context.Context, key auth.SigningKey) error {
+	q := `INSERT INTO signing_keys (id, algorithm, private_key, master_key_id, created_at, expires_at)
+	      VALUES (:id, :algorithm, :private_key, :master_key_id, :created_at, :expires_at)`
+
+	dbsk, err := skr.toDBSigningKey(key)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	if _, err := skr.db.NamedExecContext(ctx, q, dbsk); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (skr signingKeyRepository) RetrieveAll(ctx context.Context) ([]auth.SigningKey, error) {
+	q := `SELECT id, algorithm, private_key, master_key_id, created_at, expires_at FROM signing_keys
+	      WHERE expires_at > $1 ORDER BY created_at DESC`
+
+	rows, err := skr.db.QueryxContext(ctx, q, time.Now().UTC())
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	keys := []auth.SigningKey{}
+	for rows.Next() {
+		dbsk := dbSigningKey{}
+		if err := rows.StructScan(&dbsk); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		key, err := skr.toSigningKey(dbsk)
+		if err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (skr signingKeyRepository) Extend(ctx context.Context, id string, expiresAt time.Time) error {
+	q := `UPDATE signing_keys SET expires_at = GREATEST(expires_at, :expires_at) WHERE id = :id`
+
+	res, err := skr.db.NamedExecContext(ctx, q, dbSigningKey{ID: id, ExpiresAt: expiresAt.UTC()})
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (skr signingKeyRepository) RemoveExpired(ctx context.Context) error {
+	q := `DELETE FROM signing_keys WHERE expires_at <= :expires_at`
+
+	if _, err := skr.db.NamedExecContext(ctx, q, dbSigningKey{ExpiresAt: time.Now().UTC()}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbSigningKey struct {
+	ID          string         `db:"id"`
+	Algorithm   string         `db:"algorithm"`
+	PrivateKey  string         `db:"private_key"`
+	MasterKeyID sql.NullString `db:"master_key_id"`
+	CreatedAt   time.Time      `db:"created_at"`
+	ExpiresAt   time.Time      `db:"expires_at"`
+}
+
+// toDBSigningKey wraps the private key by the current master key. The
+// wrapped key is bound to the signing key ID, so the stored private keys
+// can't be swapped.
+func (skr signingKeyRepository) toDBSigningKey(key auth.SigningKey) (dbSigningKey, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return dbSigningKey{}, err
+	}
+
+	mkID, wrapped, err := skr.keyring.Wrap(der, []byte(key.ID))
+	if err != nil {
+		return dbSigningKey{}, err
+	}
+
+	return dbSigningKey{
+		ID:          key.ID,
+		Algorithm:   key.Algorithm,
+		PrivateKey:  base64.StdEncoding.EncodeToString(wrapped),
+		MasterKeyID: sql.NullString{String: mkID, Valid: true},
+		CreatedAt:   key.CreatedAt,
+		ExpiresAt:   key.ExpiresAt,
+	}, nil
+}
+
+func (skr signingKeyRepository) toSigningKey(key dbSigningKey) (auth.SigningKey, error) {
+	der, err := skr.privateKey(key)
+	if err != nil {
+		return auth.SigningKey{}, err
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return auth.SigningKey{}, err
+	}
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return auth.SigningKey{}, errInvalidPrivateKey
+	}
+
+	return auth.SigningKey{
+		ID:         key.ID,
+		Algorithm:  key.Algorithm,
+		PrivateKey: signer,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+	}, nil
+}
+
+// privateKey returns the DER encoded private key. The keys stored before the
+// private keys were encrypted, which have no master key, are PEM encoded.
+func (skr signingKeyRepository) privateKey(key dbSigningKey) ([]byte, error) {
+	if !key.MasterKeyID.Valid {
+		block, _ := pem.Decode([]byte(key.PrivateKey))
+		if block == nil {
+			return nil, errInvalidPrivateKey
+		}
+		return block.Bytes, nil
+	}
+
+	wrapped, err := base64.StdEncoding.DecodeString(key.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(errInvalidPrivateKey, err)
+	}
+
+	return skr.keyring.Unwrap(key.MasterKeyID.String, wrapped, []byte(key.ID))
+}
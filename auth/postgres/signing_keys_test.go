@@ -0,0 +1,189 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/auth/postgres"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	masterKey = encryption.MasterKey{ID: "mk1", Key: bytes.Repeat([]byte{1}, encryption.MasterKeySize)}
+	newMaster = encryption.MasterKey{ID: "mk2", Key: bytes.Repeat([]byte{2}, encryption.MasterKeySize)}
+)
+
+func newKeyring(t *testing.T, current encryption.MasterKey, previous ...encryption.MasterKey) encryption.Keyring {
+	kr, err := encryption.NewKeyring(current, previous...)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return kr
+}
+
+func newSigningKey(t *testing.T, expiresAt time.Time) auth.SigningKey {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return auth.SigningKey{
+		ID:         id,
+		Algorithm:  "ES256",
+		PrivateKey: priv,
+		CreatedAt:  time.Now().UTC().Round(time.Millisecond),
+		ExpiresAt:  expiresAt.UTC().Round(time.Millisecond),
+	}
+}
+
+func TestSigningKeySave(t *testing.T) {
+	repo := postgres.NewSigningKeyRepository(postgres.NewDatabase(db), newKeyring(t, masterKey))
+	key := newSigningKey(t, expTime)
+
+	cases := []struct {
+		desc string
+		key  auth.SigningKey
+		err  error
+	}{
+		{
+			desc: "save a new signing key",
+			key:  key,
+			err:  nil,
+		},
+		{
+			desc: "save signing key with duplicate id",
+			key:  key,
+			err:  errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Save(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSigningKeyRetrieveAll(t *testing.T) {
+	repo := postgres.NewSigningKeyRepository(postgres.NewDatabase(db), newKeyring(t, masterKey))
+
+	active := newSigningKey(t, expTime)
+	err := repo.Save(context.Background(), active)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	expired := newSigningKey(t, time.Now().Add(-time.Minute))
+	err = repo.Save(context.Background(), expired)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	keys, err := repo.RetrieveAll(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("retrieve signing keys: expected no error got %s\n", err))
+
+	ids := map[string]auth.SigningKey{}
+	for _, k := range keys {
+		ids[k.ID] = k
+	}
+	k, ok := ids[active.ID]
+	assert.True(t, ok, "retrieve signing keys: expected active key to be retrieved")
+	assert.Equal(t, active.PrivateKey.Public(), k.PrivateKey.Public(), "retrieve signing keys: expected private key to match")
+	_, ok = ids[expired.ID]
+	assert.False(t, ok, "retrieve signing keys: expected expired key not to be retrieved")
+
+	err = repo.RemoveExpired(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("remove expired signing keys: expected no error got %s\n", err))
+	err = repo.Save(context.Background(), expired)
+	assert.Nil(t, err, fmt.Sprintf("save removed signing key: expected no error got %s\n", err))
+}
+
+func TestSigningKeyEncryption(t *testing.T) {
+	repo := postgres.NewSigningKeyRepository(postgres.NewDatabase(db), newKeyring(t, masterKey))
+	key := newSigningKey(t, expTime)
+	err := repo.Save(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	var stored string
+	err = db.QueryRow("SELECT private_key FROM signing_keys WHERE id = $1", key.ID).Scan(&stored)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.NotContains(t, stored, "PRIVATE KEY", "expected private key to be stored encrypted")
+
+	cases := []struct {
+		desc string
+		repo auth.SigningKeyRepository
+		err  error
+	}{
+		{
+			desc: "retrieve signing keys with rotated master key",
+			repo: postgres.NewSigningKeyRepository(postgres.NewDatabase(db), newKeyring(t, newMaster, masterKey)),
+			err:  nil,
+		},
+		{
+			desc: "retrieve signing keys with unknown master key",
+			repo: postgres.NewSigningKeyRepository(postgres.NewDatabase(db), newKeyring(t, newMaster)),
+			err:  errors.ErrRetrieveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := tc.repo.RetrieveAll(context.Background())
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestSigningKeyExtend(t *testing.T) {
+	repo := postgres.NewSigningKeyRepository(postgres.NewDatabase(db), newKeyring(t, masterKey))
+	key := newSigningKey(t, time.Now().Add(time.Hour))
+	err := repo.Save(context.Background(), key)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	extended := key.ExpiresAt.Add(24 * time.Hour)
+	cases := []struct {
+		desc      string
+		id        string
+		expiresAt time.Time
+		expected  time.Time
+		err       error
+	}{
+		{
+			desc:      "extend signing key",
+			id:        key.ID,
+			expiresAt: extended,
+			expected:  extended,
+			err:       nil,
+		},
+		{
+			desc:      "extend signing key to earlier time",
+			id:        key.ID,
+			expiresAt: key.ExpiresAt,
+			expected:  extended,
+			err:       nil,
+		},
+		{
+			desc:      "extend non-existing signing key",
+			id:        "non-existing",
+			expiresAt: extended,
+			err:       errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Extend(context.Background(), tc.id, tc.expiresAt)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err != nil {
+			continue
+		}
+
+		var expiresAt time.Time
+		err = db.QueryRow("SELECT expires_at FROM signing_keys WHERE id = $1", tc.id).Scan(&expiresAt)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		assert.True(t, tc.expected.Equal(expiresAt), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.expected, expiresAt))
+	}
+}
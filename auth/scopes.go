@@ -0,0 +1,150 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// ThingsSubject represents things resource subject and scope.
+	ThingsSubject = "things"
+	// ChannelsSubject represents channels resource subject and scope.
+	ChannelsSubject = "channels"
+	// MessagesSubject represents messages resource subject and scope.
+	MessagesSubject = "messages"
+	// GroupsSubject represents groups resource scope.
+	GroupsSubject = "groups"
+
+	// ReadScope allows reading the resource.
+	ReadScope = "read"
+	// WriteScope allows reading and modifying the resource.
+	WriteScope = "write"
+
+	scopeSep = ":"
+)
+
+// ErrInvalidScope indicates malformed or unsupported API key scope.
+var ErrInvalidScope = errors.New("invalid API key scope")
+
+// Scope restricts the API key to the action on the resource type,
+// optionally limited to a single resource identified by ID. Things
+// and channels scopes can be limited to a group by the group ID, in
+// which case Things service checks them against the group membership.
+type Scope struct {
+	Resource string
+	Action   string
+	ID       string
+}
+
+// ParseScope parses scope from its string representation,
+// in format <resource>:<action>[:<id>], e.g. things:read.
+func ParseScope(s string) (Scope, error) {
+	parts := strings.SplitN(s, scopeSep, 3)
+	if len(parts) < 2 {
+		return Scope{}, ErrInvalidScope
+	}
+
+	sc := Scope{
+		Resource: parts[0],
+		Action:   parts[1],
+	}
+	if len(parts) == 3 {
+		if parts[2] == "" {
+			return Scope{}, ErrInvalidScope
+		}
+		sc.ID = parts[2]
+	}
+
+	if err := sc.Validate(); err != nil {
+		return Scope{}, err
+	}
+
+	return sc, nil
+}
+
+// ParseScopes parses the list of scopes from their string representations.
+func ParseScopes(scopes []string) ([]Scope, error) {
+	var ret []Scope
+	for _, s := range scopes {
+		sc, err := ParseScope(s)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, sc)
+	}
+
+	return ret, nil
+}
+
+// String returns string representation of the scope.
+func (s Scope) String() string {
+	ret := s.Resource + scopeSep + s.Action
+	if s.ID != "" {
+		ret += scopeSep + s.ID
+	}
+
+	return ret
+}
+
+// Validate checks whether the scope resource and action are supported.
+func (s Scope) Validate() error {
+	switch s.Resource {
+	case ThingsSubject, ChannelsSubject, MessagesSubject, GroupsSubject:
+	default:
+		return ErrInvalidScope
+	}
+
+	if s.Action != ReadScope && s.Action != WriteScope {
+		return ErrInvalidScope
+	}
+
+	return nil
+}
+
+// allows checks whether the scope allows the action on the resource.
+// Write scope implies read access.
+func (s Scope) allows(resource, action, id string) bool {
+	if s.Resource != resource {
+		return false
+	}
+	if action != ReadAction && s.Action != WriteScope {
+		return false
+	}
+
+	return s.ID == "" || s.ID == id
+}
+
+// Scoped returns true if the key is restricted by scopes.
+func (k Key) Scoped() bool {
+	return len(k.Scopes) > 0
+}
+
+// Allows checks whether the key is allowed to perform the action on the
+// resource identified by the given ID. Keys without scopes are not restricted.
+func (k Key) Allows(resource, action, id string) bool {
+	if !k.Scoped() {
+		return true
+	}
+
+	for _, s := range k.Scopes {
+		if s.allows(resource, action, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScopeStrings returns string representation of the key scopes.
+func (k Key) ScopeStrings() []string {
+	var ret []string
+	for _, s := range k.Scopes {
+		ret = append(ret, s.String())
+	}
+
+	return ret
+}
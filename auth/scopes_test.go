@@ -0,0 +1,149 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScope(t *testing.T) {
+	cases := []struct {
+		desc  string
+		scope string
+		res   auth.Scope
+		err   error
+	}{
+		{
+			desc:  "parse read scope",
+			scope: "things:read",
+			res:   auth.Scope{Resource: auth.ThingsSubject, Action: auth.ReadScope},
+			err:   nil,
+		},
+		{
+			desc:  "parse write scope on a single resource",
+			scope: "channels:write:chanID",
+			res:   auth.Scope{Resource: auth.ChannelsSubject, Action: auth.WriteScope, ID: "chanID"},
+			err:   nil,
+		},
+		{
+			desc:  "parse scope without action",
+			scope: "messages",
+			res:   auth.Scope{},
+			err:   auth.ErrInvalidScope,
+		},
+		{
+			desc:  "parse scope with empty ID",
+			scope: "messages:read:",
+			res:   auth.Scope{},
+			err:   auth.ErrInvalidScope,
+		},
+		{
+			desc:  "parse scope with unknown resource",
+			scope: "users:read",
+			res:   auth.Scope{},
+			err:   auth.ErrInvalidScope,
+		},
+		{
+			desc:  "parse scope with unknown action",
+			scope: "things:delete",
+			res:   auth.Scope{},
+			err:   auth.ErrInvalidScope,
+		},
+	}
+
+	for _, tc := range cases {
+		res, err := auth.ParseScope(tc.scope)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+		if err == nil {
+			assert.Equal(t, tc.scope, res.String(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.scope, res.String()))
+		}
+	}
+}
+
+func TestAllows(t *testing.T) {
+	key := auth.Key{
+		Type: auth.APIKey,
+		Scopes: []auth.Scope{
+			{Resource: auth.ThingsSubject, Action: auth.ReadScope},
+			{Resource: auth.ChannelsSubject, Action: auth.WriteScope, ID: "chanID"},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		key      auth.Key
+		resource string
+		action   string
+		id       string
+		allowed  bool
+	}{
+		{
+			desc:     "read any thing with read scope",
+			key:      key,
+			resource: auth.ThingsSubject,
+			action:   auth.ReadAction,
+			id:       "thingID",
+			allowed:  true,
+		},
+		{
+			desc:     "write thing with read scope",
+			key:      key,
+			resource: auth.ThingsSubject,
+			action:   auth.WriteAction,
+			id:       "thingID",
+			allowed:  false,
+		},
+		{
+			desc:     "read channel with write scope",
+			key:      key,
+			resource: auth.ChannelsSubject,
+			action:   auth.ReadAction,
+			id:       "chanID",
+			allowed:  true,
+		},
+		{
+			desc:     "write channel with write scope",
+			key:      key,
+			resource: auth.ChannelsSubject,
+			action:   auth.WriteAction,
+			id:       "chanID",
+			allowed:  true,
+		},
+		{
+			desc:     "write other channel",
+			key:      key,
+			resource: auth.ChannelsSubject,
+			action:   auth.WriteAction,
+			id:       "otherID",
+			allowed:  false,
+		},
+		{
+			desc:     "read messages without scope",
+			key:      key,
+			resource: auth.MessagesSubject,
+			action:   auth.ReadAction,
+			id:       "chanID",
+			allowed:  false,
+		},
+		{
+			desc:     "read messages with unscoped key",
+			key:      auth.Key{Type: auth.APIKey},
+			resource: auth.MessagesSubject,
+			action:   auth.ReadAction,
+			id:       "chanID",
+			allowed:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		allowed := tc.key.Allows(tc.resource, tc.action, tc.id)
+		assert.Equal(t, tc.allowed, allowed, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.allowed, allowed))
+	}
+}
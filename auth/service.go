@@ -12,13 +12,17 @@ import (
 )
 
 const (
-	recoveryDuration = 5 * time.Minute
+	recoveryDuration     = 5 * time.Minute
+	verificationDuration = 24 * time.Hour
+	// lastUsedInterval limits how often API key usage is recorded.
+	lastUsedInterval = time.Minute
 	ViewerRole       = "viewer"
 	AdminRole        = "admin"
 	OwnerRole        = "owner"
 	EditorRole       = "editor"
 	RootSubject      = "root"
 	GroupSubject     = "group"
+	OrgSubject       = "org"
 	ReadAction       = "read"
 	WriteAction      = "read_write"
 	RPolicy          = "read"
@@ -60,14 +64,31 @@ type Authn interface {
 	// issued by the user identified by the provided key.
 	Revoke(ctx context.Context, token, id string) error
 
+	// RevokeKeys removes the Keys with the provided ids that are
+	// issued by the user identified by the provided key.
+	RevokeKeys(ctx context.Context, token string, ids ...string) error
+
 	// RetrieveKey retrieves data for the Key identified by the provided
 	// ID, that is issued by the user identified by the provided key.
 	RetrieveKey(ctx context.Context, token, id string) (Key, error)
 
+	// ListKeys retrieves a page of Keys issued by the user identified
+	// by the provided key.
+	ListKeys(ctx context.Context, token string, pm KeyPageMetadata) (KeysPage, error)
+
 	// Identify validates token token. If token is valid, content
 	// is returned. If token is invalid, or invocation failed for some
 	// other reason, non-nil error value is returned in response.
 	Identify(ctx context.Context, token string) (Identity, error)
+
+	// IdentifyVerification validates the email verification token and returns
+	// the identity of the user it's issued to. Tokens of other key types are
+	// rejected, and the verification token isn't accepted by Identify.
+	IdentifyVerification(ctx context.Context, token string) (Identity, error)
+
+	// RetrievePublicKeys retrieves the public keys which can be used
+	// to verify issued tokens.
+	RetrievePublicKeys(ctx context.Context) ([]PublicKey, error)
 }
 
 // AuthzReq represents an argument struct for making an authz related function calls.
@@ -130,11 +151,22 @@ func (svc service) Issue(ctx context.Context, token string, key Key) (Key, strin
 	if key.IssuedAt.IsZero() {
 		return Key{}, "", ErrInvalidKeyIssuedAt
 	}
+	// Only API keys can be restricted by scopes.
+	if key.Scoped() && key.Type != APIKey {
+		return Key{}, "", ErrInvalidScope
+	}
+	for _, sc := range key.Scopes {
+		if err := sc.Validate(); err != nil {
+			return Key{}, "", err
+		}
+	}
 	switch key.Type {
 	case APIKey:
 		return svc.userKey(ctx, token, key)
 	case RecoveryKey:
 		return svc.tmpKey(recoveryDuration, key)
+	case VerificationKey:
+		return svc.tmpKey(verificationDuration, key)
 	default:
 		return svc.tmpKey(svc.loginDuration, key)
 	}
@@ -151,6 +183,17 @@ func (svc service) Revoke(ctx context.Context, token, id string) error {
 	return nil
 }
 
+func (svc service) RevokeKeys(ctx context.Context, token string, ids ...string) error {
+	issuerID, _, err := svc.login(token)
+	if err != nil {
+		return errors.Wrap(errRevoke, err)
+	}
+	if err := svc.keys.RemoveKeys(ctx, issuerID, ids...); err != nil {
+		return errors.Wrap(errRevoke, err)
+	}
+	return nil
+}
+
 func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, error) {
 	issuerID, _, err := svc.login(token)
 	if err != nil {
@@ -160,21 +203,80 @@ func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, erro
 	return svc.keys.Retrieve(ctx, issuerID, id)
 }
 
+func (svc service) ListKeys(ctx context.Context, token string, pm KeyPageMetadata) (KeysPage, error) {
+	issuerID, _, err := svc.login(token)
+	if err != nil {
+		return KeysPage{}, errors.Wrap(errRetrieve, err)
+	}
+
+	return svc.keys.RetrieveByIssuer(ctx, issuerID, pm)
+}
+
 func (svc service) Identify(ctx context.Context, token string) (Identity, error) {
 	return svc.identify(ctx, token)
 }
 
+func (svc service) IdentifyVerification(ctx context.Context, token string) (Identity, error) {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return Identity{}, errors.Wrap(errIdentify, err)
+	}
+
+	if key.Type != VerificationKey {
+		return Identity{}, errors.ErrAuthentication
+	}
+
+	return Identity{ID: key.IssuerID, Email: key.Subject}, nil
+}
+
+func (svc service) RetrievePublicKeys(ctx context.Context) ([]PublicKey, error) {
+	return svc.tokenizer.PublicKeys()
+}
+
 func (svc service) Authorize(ctx context.Context, ar AuthzReq) error {
 	switch ar.Subject {
 	case RootSubject:
+		if err := svc.authorizeScope(ar.Token, "", "", ""); err != nil {
+			return err
+		}
 		return svc.isAdmin(ctx, ar.Token)
 	case GroupSubject:
+		if err := svc.authorizeScope(ar.Token, GroupsSubject, ar.Action, ar.Object); err != nil {
+			return err
+		}
 		return svc.canAccessGroup(ctx, ar.Token, ar.Object, ar.Action)
+	case OrgSubject:
+		// The action is the minimal org role required, e.g. owner.
+		if err := svc.authorizeScope(ar.Token, "", "", ""); err != nil {
+			return err
+		}
+		return svc.orgRolesAuth(ctx, ar.Token, ar.Object, ar.Action)
+	case ThingsSubject, ChannelsSubject, MessagesSubject, GroupsSubject:
+		// Resource ownership is verified by the service managing the resource.
+		return svc.authorizeScope(ar.Token, ar.Subject, ar.Action, ar.Object)
 	default:
 		return errUnknownSubject
 	}
 }
 
+// authorizeScope verifies that the key scopes allow the action on the resource.
+// Scoped keys are never authorized as root, which is requested with empty resource.
+func (svc service) authorizeScope(token, resource, action, id string) error {
+	key, err := svc.tokenizer.Parse(token)
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if !key.Scoped() {
+		return nil
+	}
+	if resource == "" || !key.Allows(resource, action, id) {
+		return errors.ErrAuthorization
+	}
+
+	return nil
+}
+
 func (svc service) tmpKey(duration time.Duration, key Key) (Key, string, error) {
 	key.ExpiresAt = key.IssuedAt.Add(duration)
 	secret, err := svc.tokenizer.Issue(key)
@@ -379,6 +481,27 @@ func (svc service) AssignMembers(ctx context.Context, token, orgID string, oms .
 	return nil
 }
 
+func (svc service) JoinOrg(ctx context.Context, orgID, memberID string) error {
+	if _, err := svc.orgs.RetrieveByID(ctx, orgID); err != nil {
+		return err
+	}
+
+	if _, err := svc.orgs.RetrieveRole(ctx, memberID, orgID); err == nil {
+		return nil
+	}
+
+	timestamp := getTimestmap()
+	om := OrgMember{
+		OrgID:     orgID,
+		MemberID:  memberID,
+		Role:      ViewerRole,
+		CreatedAt: timestamp,
+		UpdatedAt: timestamp,
+	}
+
+	return svc.orgs.AssignMembers(ctx, om)
+}
+
 func (svc service) UnassignMembers(ctx context.Context, token string, orgID string, memberIDs ...string) error {
 	if err := svc.canAssignMembers(ctx, token, orgID, memberIDs...); err != nil {
 		return err
@@ -758,27 +881,42 @@ func (svc service) ViewGroupMembership(ctx context.Context, token, groupID strin
 	return svc.orgs.RetrieveByGroupID(ctx, groupID)
 }
 
-func (svc service) canAccessGroup(ctx context.Context, token, Object, action string) error {
-	if err := svc.isAdmin(ctx, token); err == nil {
-		return nil
+func (svc service) RetrieveGroupOrg(ctx context.Context, groupID string) (string, error) {
+	org, err := svc.orgs.RetrieveByGroupID(ctx, groupID)
+	if err != nil {
+		if errors.Contains(err, errors.ErrNotFound) {
+			return "", nil
+		}
+		return "", err
 	}
 
+	return org.ID, nil
+}
+
+func (svc service) RetrieveMemberGroups(ctx context.Context, token string) ([]string, error) {
 	user, err := svc.Identify(ctx, token)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	gp := GroupPolicy{
-		MemberID: user.ID,
-		GroupID:  Object,
+	return svc.orgs.RetrieveMemberGroups(ctx, user.ID)
+}
+
+// canAccessGroup verifies that the user can perform the action on the group.
+// Members of the org the group is assigned to are granted the access by their
+// org roles: owners, admins and editors can read and write, while viewers can
+// only read. Editors can be restricted to read-only by the group policy.
+func (svc service) canAccessGroup(ctx context.Context, token, groupID, action string) error {
+	if err := svc.isAdmin(ctx, token); err == nil {
+		return nil
 	}
 
-	policy, err := svc.policies.RetrieveGroupPolicy(ctx, gp)
+	user, err := svc.Identify(ctx, token)
 	if err != nil {
 		return err
 	}
 
-	org, err := svc.orgs.RetrieveByGroupID(ctx, Object)
+	org, err := svc.orgs.RetrieveByGroupID(ctx, groupID)
 	if err != nil {
 		return err
 	}
@@ -788,24 +926,38 @@ func (svc service) canAccessGroup(ctx context.Context, token, Object, action str
 		return err
 	}
 
-	if role == OwnerRole || role == AdminRole {
+	switch role {
+	case OwnerRole, AdminRole:
 		return nil
-	}
+	case EditorRole:
+		if action != WriteAction {
+			return nil
+		}
 
-	if role == "" || policy == "" {
-		return errors.ErrAuthorization
-	}
+		gp := GroupPolicy{
+			MemberID: user.ID,
+			GroupID:  groupID,
+		}
+
+		policy, err := svc.policies.RetrieveGroupPolicy(ctx, gp)
+		if err != nil {
+			return err
+		}
 
-	switch action {
-	case WriteAction:
-		if policy == RPolicy || role == ViewerRole {
+		if policy == RPolicy {
 			return errors.ErrAuthorization
 		}
-	default:
+
 		return nil
-	}
+	case ViewerRole:
+		if action == WriteAction {
+			return errors.ErrAuthorization
+		}
 
-	return nil
+		return nil
+	default:
+		return errors.ErrAuthorization
+	}
 }
 
 func (svc service) AddPolicy(ctx context.Context, token, groupID, policy string) error {
@@ -986,10 +1138,16 @@ func (svc service) identify(ctx context.Context, token string) (Identity, error)
 	case RecoveryKey, LoginKey:
 		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
 	case APIKey:
-		_, err := svc.keys.Retrieve(context.TODO(), key.IssuerID, key.ID)
+		k, err := svc.keys.Retrieve(ctx, key.IssuerID, key.ID)
 		if err != nil {
 			return Identity{}, errors.ErrAuthentication
 		}
+		// Usage is recorded at most once per interval to avoid a write on every request.
+		if now := getTimestmap(); now.Sub(k.LastUsedAt) >= lastUsedInterval {
+			if err := svc.keys.UpdateLastUsed(ctx, key.IssuerID, key.ID, now); err != nil {
+				return Identity{}, errors.Wrap(errIdentify, err)
+			}
+		}
 		return Identity{ID: key.IssuerID, Email: key.Subject}, nil
 	default:
 		return Identity{}, errors.ErrAuthentication
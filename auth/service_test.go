@@ -116,6 +116,36 @@ func TestIssue(t *testing.T) {
 			token: secret,
 			err:   nil,
 		},
+		{
+			desc: "issue scoped API key",
+			key: auth.Key{
+				Type:     auth.APIKey,
+				IssuedAt: time.Now(),
+				Scopes:   []auth.Scope{{Resource: auth.ThingsSubject, Action: auth.ReadScope}},
+			},
+			token: secret,
+			err:   nil,
+		},
+		{
+			desc: "issue API key with an invalid scope",
+			key: auth.Key{
+				Type:     auth.APIKey,
+				IssuedAt: time.Now(),
+				Scopes:   []auth.Scope{{Resource: auth.ThingsSubject, Action: invalid}},
+			},
+			token: secret,
+			err:   auth.ErrInvalidScope,
+		},
+		{
+			desc: "issue scoped login key",
+			key: auth.Key{
+				Type:     auth.LoginKey,
+				IssuedAt: time.Now(),
+				Scopes:   []auth.Scope{{Resource: auth.ThingsSubject, Action: auth.ReadScope}},
+			},
+			token: secret,
+			err:   auth.ErrInvalidScope,
+		},
 		{
 			desc: "issue API key with an invalid token",
 			key: auth.Key{
@@ -150,6 +180,15 @@ func TestIssue(t *testing.T) {
 			token: secret,
 			err:   auth.ErrInvalidKeyIssuedAt,
 		},
+		{
+			desc: "issue verification key",
+			key: auth.Key{
+				Type:     auth.VerificationKey,
+				IssuedAt: time.Now(),
+			},
+			token: "",
+			err:   nil,
+		},
 	}
 
 	for _, tc := range cases {
@@ -268,6 +307,175 @@ func TestRetrieve(t *testing.T) {
 	}
 }
 
+func TestRevokeKeys(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	var ids []string
+	for i := 0; i < n; i++ {
+		k, _, err := svc.Issue(context.Background(), secret, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+		require.Nil(t, err, fmt.Sprintf("Issuing user's key expected to succeed: %s", err))
+		ids = append(ids, k.ID)
+	}
+
+	cases := []struct {
+		desc  string
+		ids   []string
+		token string
+		err   error
+	}{
+		{
+			desc:  "revoke keys with empty login key",
+			ids:   ids,
+			token: "",
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "revoke keys",
+			ids:   ids,
+			token: secret,
+			err:   nil,
+		},
+		{
+			desc:  "revoke non-existing keys",
+			ids:   ids,
+			token: secret,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RevokeKeys(context.Background(), tc.token, tc.ids...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	page, err := svc.ListKeys(context.Background(), secret, auth.KeyPageMetadata{})
+	assert.Nil(t, err, fmt.Sprintf("Listing keys expected to succeed: %s", err))
+	assert.Empty(t, page.Keys, fmt.Sprintf("expected no keys after revoking, got %d", len(page.Keys)))
+}
+
+func TestListKeys(t *testing.T) {
+	svc := newService()
+	_, secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, otherSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	for i := 0; i < n; i++ {
+		key := auth.Key{Type: auth.APIKey, Name: fmt.Sprintf("%s-%d", name, i), IssuedAt: time.Now(), IssuerID: id}
+		// Every other key is already expired.
+		if i%2 == 1 {
+			key.ExpiresAt = time.Now().Add(-time.Minute)
+		}
+		_, _, err := svc.Issue(context.Background(), secret, key)
+		require.Nil(t, err, fmt.Sprintf("Issuing user's key expected to succeed: %s", err))
+	}
+
+	expired := true
+	active := false
+	apiKey := auth.APIKey
+	loginKey := auth.LoginKey
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    auth.KeyPageMetadata
+		size  int
+		total uint64
+		err   error
+	}{
+		{
+			desc:  "list all keys",
+			token: secret,
+			pm:    auth.KeyPageMetadata{},
+			size:  n,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list keys with limit and offset",
+			token: secret,
+			pm:    auth.KeyPageMetadata{Offset: 2, Limit: 5},
+			size:  5,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list expired keys",
+			token: secret,
+			pm:    auth.KeyPageMetadata{Expired: &expired},
+			size:  n / 2,
+			total: n / 2,
+			err:   nil,
+		},
+		{
+			desc:  "list active keys",
+			token: secret,
+			pm:    auth.KeyPageMetadata{Expired: &active},
+			size:  n / 2,
+			total: n / 2,
+			err:   nil,
+		},
+		{
+			desc:  "list API keys",
+			token: secret,
+			pm:    auth.KeyPageMetadata{Type: &apiKey},
+			size:  n,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list login keys",
+			token: secret,
+			pm:    auth.KeyPageMetadata{Type: &loginKey},
+			size:  0,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "list keys by subject",
+			token: secret,
+			pm:    auth.KeyPageMetadata{Subject: email},
+			size:  n,
+			total: n,
+			err:   nil,
+		},
+		{
+			desc:  "list keys by unknown subject",
+			token: secret,
+			pm:    auth.KeyPageMetadata{Subject: invalid},
+			size:  0,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "list keys issued by another user",
+			token: otherSecret,
+			pm:    auth.KeyPageMetadata{},
+			size:  0,
+			total: 0,
+			err:   nil,
+		},
+		{
+			desc:  "list keys with invalid token",
+			token: invalid,
+			pm:    auth.KeyPageMetadata{},
+			size:  0,
+			total: 0,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListKeys(context.Background(), tc.token, tc.pm)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Keys), fmt.Sprintf("%s expected %d keys got %d\n", tc.desc, tc.size, len(page.Keys)))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s expected total %d got %d\n", tc.desc, tc.total, page.Total))
+	}
+}
+
 func TestIdentify(t *testing.T) {
 	svc := newService()
 
@@ -277,6 +485,9 @@ func TestIdentify(t *testing.T) {
 	_, recoverySecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RecoveryKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
 	assert.Nil(t, err, fmt.Sprintf("Issuing reset key expected to succeed: %s", err))
 
+	_, verificationSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.VerificationKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing verification key expected to succeed: %s", err))
+
 	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuerID: id, Subject: email, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 
@@ -305,6 +516,12 @@ func TestIdentify(t *testing.T) {
 			idt:  auth.Identity{id, email},
 			err:  nil,
 		},
+		{
+			desc: "identify verification key",
+			key:  verificationSecret,
+			idt:  auth.Identity{},
+			err:  errors.ErrAuthentication,
+		},
 		{
 			desc: "identify API key",
 			key:  apiSecret,
@@ -338,6 +555,87 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestIdentifyVerification(t *testing.T) {
+	svc := newService()
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	_, recoverySecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RecoveryKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing reset key expected to succeed: %s", err))
+
+	_, verificationSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.VerificationKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing verification key expected to succeed: %s", err))
+
+	_, apiSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuerID: id, Subject: email, IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Minute)})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		key  string
+		idt  auth.Identity
+		err  error
+	}{
+		{
+			desc: "identify verification key",
+			key:  verificationSecret,
+			idt:  auth.Identity{id, email},
+			err:  nil,
+		},
+		{
+			desc: "identify login key",
+			key:  loginSecret,
+			idt:  auth.Identity{},
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "identify recovery key",
+			key:  recoverySecret,
+			idt:  auth.Identity{},
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "identify API key",
+			key:  apiSecret,
+			idt:  auth.Identity{},
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "identify invalid key",
+			key:  "invalid",
+			idt:  auth.Identity{},
+			err:  errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		idt, err := svc.IdentifyVerification(context.Background(), tc.key)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.idt, idt))
+	}
+}
+
+func TestIdentifyRecordsLastUsed(t *testing.T) {
+	svc := newService()
+
+	_, loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	key, apiSecret, err := svc.Issue(context.Background(), loginSecret, auth.Key{Type: auth.APIKey, IssuerID: id, Subject: email, IssuedAt: time.Now()})
+	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	k, err := svc.RetrieveKey(context.Background(), loginSecret, key.ID)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving API key expected to succeed: %s", err))
+	assert.True(t, k.LastUsedAt.IsZero(), fmt.Sprintf("expected unused key, got last used at %s", k.LastUsedAt))
+
+	_, err = svc.Identify(context.Background(), apiSecret)
+	assert.Nil(t, err, fmt.Sprintf("Identifying API key expected to succeed: %s", err))
+
+	k, err = svc.RetrieveKey(context.Background(), loginSecret, key.ID)
+	assert.Nil(t, err, fmt.Sprintf("Retrieving API key expected to succeed: %s", err))
+	assert.False(t, k.LastUsedAt.IsZero(), "expected key usage to be recorded")
+}
+
 func TestAuthorize(t *testing.T) {
 	svc := newService()
 
@@ -352,6 +650,248 @@ func TestAuthorize(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("authorizing initial %v authz request expected to succeed: %s", pr, err))
 }
 
+func TestAuthorizeScopes(t *testing.T) {
+	svc := newService()
+
+	_, loginToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	err = svc.AssignRole(context.Background(), adminID, auth.RoleAdmin)
+	require.Nil(t, err, fmt.Sprintf("saving role expected to succeed: %s", err))
+
+	_, apiToken, err := svc.Issue(context.Background(), loginToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now()})
+	require.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
+
+	scopes := []auth.Scope{
+		{Resource: auth.ThingsSubject, Action: auth.ReadScope},
+		{Resource: auth.MessagesSubject, Action: auth.ReadScope, ID: "chanID"},
+	}
+	_, scopedToken, err := svc.Issue(context.Background(), loginToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scopes: scopes})
+	require.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc string
+		req  auth.AuthzReq
+		err  error
+	}{
+		{
+			desc: "authorize unscoped key as root",
+			req:  auth.AuthzReq{Token: apiToken, Subject: auth.RootSubject},
+			err:  nil,
+		},
+		{
+			desc: "authorize scoped key as root",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.RootSubject},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize unscoped key to write things",
+			req:  auth.AuthzReq{Token: apiToken, Subject: auth.ThingsSubject, Action: auth.WriteAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize scoped key to read things",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.ThingsSubject, Object: "thingID", Action: auth.ReadAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize scoped key to write things",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.ThingsSubject, Object: "thingID", Action: auth.WriteAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize scoped key to read channels",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.ChannelsSubject, Object: "chanID", Action: auth.ReadAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize scoped key to read channel messages",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.MessagesSubject, Object: "chanID", Action: auth.ReadAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize scoped key to read other channel messages",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.MessagesSubject, Object: "otherID", Action: auth.ReadAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize scoped key to read group",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.GroupSubject, Object: "groupID", Action: auth.ReadAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize unscoped key to write groups",
+			req:  auth.AuthzReq{Token: apiToken, Subject: auth.GroupsSubject, Action: auth.WriteAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize scoped key to read groups",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.GroupsSubject, Action: auth.ReadAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize invalid token",
+			req:  auth.AuthzReq{Token: invalid, Subject: auth.ThingsSubject, Action: auth.ReadAction},
+			err:  errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Authorize(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAuthorizeOrg(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, adminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, scopedToken, err := svc.Issue(context.Background(), ownerToken, auth.Key{Type: auth.APIKey, IssuedAt: time.Now(), Scopes: []auth.Scope{{Resource: auth.ThingsSubject, Action: auth.ReadScope}}})
+	require.Nil(t, err, fmt.Sprintf("Issuing scoped API key expected to succeed: %s", err))
+
+	res, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, res.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc string
+		req  auth.AuthzReq
+		err  error
+	}{
+		{
+			desc: "authorize owner as org owner",
+			req:  auth.AuthzReq{Token: ownerToken, Subject: auth.OrgSubject, Object: res.ID, Action: auth.OwnerRole},
+			err:  nil,
+		},
+		{
+			desc: "authorize viewer as org viewer",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.OrgSubject, Object: res.ID, Action: auth.ViewerRole},
+			err:  nil,
+		},
+		{
+			desc: "authorize viewer as org owner",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.OrgSubject, Object: res.ID, Action: auth.OwnerRole},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize admin as org owner",
+			req:  auth.AuthzReq{Token: adminToken, Subject: auth.OrgSubject, Object: res.ID, Action: auth.OwnerRole},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize scoped key as org owner",
+			req:  auth.AuthzReq{Token: scopedToken, Subject: auth.OrgSubject, Object: res.ID, Action: auth.OwnerRole},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize with invalid token",
+			req:  auth.AuthzReq{Token: invalid, Subject: auth.OrgSubject, Object: res.ID, Action: auth.ViewerRole},
+			err:  errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Authorize(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAuthorizeGroup(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, adminToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: adminID, Subject: adminEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, editorToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: editorID, Subject: editorEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, nonMemberToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: id, Subject: email})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	unassignedGroupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignMembers(context.Background(), ownerToken, or.ID, members...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, groupID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc string
+		req  auth.AuthzReq
+		err  error
+	}{
+		{
+			desc: "authorize owner to write to group",
+			req:  auth.AuthzReq{Token: ownerToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.WriteAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize admin to write to group",
+			req:  auth.AuthzReq{Token: adminToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.WriteAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize editor without group policy to write to group",
+			req:  auth.AuthzReq{Token: editorToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.WriteAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize viewer to read group",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction},
+			err:  nil,
+		},
+		{
+			desc: "authorize viewer to write to group",
+			req:  auth.AuthzReq{Token: viewerToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.WriteAction},
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "authorize non-member to read group",
+			req:  auth.AuthzReq{Token: nonMemberToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction},
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "authorize owner to read group not assigned to org",
+			req:  auth.AuthzReq{Token: ownerToken, Subject: auth.GroupSubject, Object: unassignedGroupID, Action: auth.ReadAction},
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "authorize with invalid token",
+			req:  auth.AuthzReq{Token: invalid, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction},
+			err:  errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Authorize(context.Background(), tc.req)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.CreateGroupPolicies(context.Background(), ownerToken, groupID, auth.GroupPolicyByID{MemberID: editorID, Policy: auth.RPolicy})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: editorToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction})
+	assert.Nil(t, err, fmt.Sprintf("authorize editor with read group policy to read group: unexpected error: %s\n", err))
+	err = svc.Authorize(context.Background(), auth.AuthzReq{Token: editorToken, Subject: auth.GroupSubject, Object: groupID, Action: auth.WriteAction})
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("authorize editor with read group policy to write to group: expected %s got %s\n", errors.ErrAuthorization, err))
+}
+
 func TestCreateOrg(t *testing.T) {
 	svc := newService()
 
@@ -865,6 +1405,61 @@ func TestAssignMembers(t *testing.T) {
 	}
 }
 
+func TestJoinOrg(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		orgID    string
+		memberID string
+		err      error
+	}{
+		{
+			desc:     "join org",
+			orgID:    or.ID,
+			memberID: viewerID,
+			err:      nil,
+		},
+		{
+			desc:     "join org as existing member",
+			orgID:    or.ID,
+			memberID: viewerID,
+			err:      nil,
+		},
+		{
+			desc:     "join org as owner",
+			orgID:    or.ID,
+			memberID: ownerID,
+			err:      nil,
+		},
+		{
+			desc:     "join non-existing org",
+			orgID:    invalid,
+			memberID: viewerID,
+			err:      errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.JoinOrg(context.Background(), tc.orgID, tc.memberID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	om, err := svc.ViewMember(context.Background(), ownerToken, or.ID, viewerID)
+	require.Nil(t, err, fmt.Sprintf("retrieving org member expected to succeed: %s", err))
+	assert.Equal(t, auth.ViewerRole, om.Role, fmt.Sprintf("expected role %s got %s\n", auth.ViewerRole, om.Role))
+
+	om, err = svc.ViewMember(context.Background(), ownerToken, or.ID, ownerID)
+	require.Nil(t, err, fmt.Sprintf("retrieving org member expected to succeed: %s", err))
+	assert.Equal(t, auth.OwnerRole, om.Role, fmt.Sprintf("expected role %s got %s\n", auth.OwnerRole, om.Role))
+}
+
 func TestUnAssignMembers(t *testing.T) {
 	svc := newService()
 
@@ -1403,6 +1998,100 @@ func TestUnAssignGroups(t *testing.T) {
 	}
 }
 
+func TestRetrieveGroupOrg(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	unassignedGroupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, groupID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc    string
+		groupID string
+		orgID   string
+		err     error
+	}{
+		{
+			desc:    "retrieve org of assigned group",
+			groupID: groupID,
+			orgID:   or.ID,
+			err:     nil,
+		},
+		{
+			desc:    "retrieve org of unassigned group",
+			groupID: unassignedGroupID,
+			orgID:   "",
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		orgID, err := svc.RetrieveGroupOrg(context.Background(), tc.groupID)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.orgID, orgID, fmt.Sprintf("%s expected org %s got %s\n", tc.desc, tc.orgID, orgID))
+	}
+}
+
+func TestRetrieveMemberGroups(t *testing.T) {
+	svc := newService()
+
+	_, ownerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: ownerID, Subject: ownerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+	_, viewerToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.LoginKey, IssuedAt: time.Now(), IssuerID: viewerID, Subject: viewerEmail})
+	require.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
+
+	groupID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	or, err := svc.CreateOrg(context.Background(), ownerToken, org)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignGroups(context.Background(), ownerToken, or.ID, groupID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		groupIDs []string
+		err      error
+	}{
+		{
+			desc:     "retrieve groups of org member",
+			token:    ownerToken,
+			groupIDs: []string{groupID},
+			err:      nil,
+		},
+		{
+			desc:     "retrieve groups of user without orgs",
+			token:    viewerToken,
+			groupIDs: nil,
+			err:      nil,
+		},
+		{
+			desc:     "retrieve groups with invalid token",
+			token:    invalid,
+			groupIDs: nil,
+			err:      errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		groupIDs, err := svc.RetrieveMemberGroups(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.groupIDs, groupIDs, fmt.Sprintf("%s expected groups %v got %v\n", tc.desc, tc.groupIDs, groupIDs))
+	}
+}
+
 func TestListOrgGroups(t *testing.T) {
 	svc := newService()
 
@@ -3,6 +3,12 @@
 
 package auth
 
+import (
+	"context"
+	"crypto"
+	"time"
+)
+
 // Tokenizer specifies API for encoding and decoding between string and Key.
 type Tokenizer interface {
 	// Issue converts API Key to its string representation.
@@ -10,4 +16,40 @@ type Tokenizer interface {
 
 	// Parse extracts API Key data from string token.
 	Parse(string) (Key, error)
+
+	// PublicKeys returns the keys which can be used to verify issued tokens.
+	// Tokenizers relying on a shared secret return no keys.
+	PublicKeys() ([]PublicKey, error)
+}
+
+// PublicKey represents the public part of the token signing key.
+type PublicKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// SigningKey represents the asymmetric key used for signing tokens.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+// SigningKeyRepository specifies token signing keys persistence API.
+type SigningKeyRepository interface {
+	// Save persists the signing key.
+	Save(ctx context.Context, key SigningKey) error
+
+	// RetrieveAll retrieves all the signing keys which have not expired yet.
+	RetrieveAll(ctx context.Context) ([]SigningKey, error)
+
+	// Extend postpones the expiration of the signing key to the given time,
+	// unless the key already expires later.
+	Extend(ctx context.Context, id string, expiresAt time.Time) error
+
+	// RemoveExpired removes the signing keys which have expired.
+	RemoveExpired(ctx context.Context) error
 }
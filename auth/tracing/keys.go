@@ -7,15 +7,19 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/auth"
 	opentracing "github.com/opentracing/opentracing-go"
 )
 
 const (
-	saveOp     = "save"
-	retrieveOp = "retrieve_by_id"
-	revokeOp   = "remove"
+	saveOp             = "save"
+	retrieveOp         = "retrieve_by_id"
+	retrieveByIssuerOp = "retrieve_by_issuer"
+	updateLastUsedOp   = "update_last_used"
+	revokeOp           = "remove"
+	revokeKeysOp       = "remove_keys"
 )
 
 var _ auth.KeyRepository = (*keyRepositoryMiddleware)(nil)
@@ -60,6 +64,30 @@ func (krm keyRepositoryMiddleware) Remove(ctx context.Context, owner, id string)
 	return krm.repo.Remove(ctx, owner, id)
 }
 
+func (krm keyRepositoryMiddleware) RetrieveByIssuer(ctx context.Context, issuerID string, pm auth.KeyPageMetadata) (auth.KeysPage, error) {
+	span := createSpan(ctx, krm.tracer, retrieveByIssuerOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RetrieveByIssuer(ctx, issuerID, pm)
+}
+
+func (krm keyRepositoryMiddleware) UpdateLastUsed(ctx context.Context, issuerID, id string, at time.Time) error {
+	span := createSpan(ctx, krm.tracer, updateLastUsedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.UpdateLastUsed(ctx, issuerID, id, at)
+}
+
+func (krm keyRepositoryMiddleware) RemoveKeys(ctx context.Context, issuerID string, ids ...string) error {
+	span := createSpan(ctx, krm.tracer, revokeKeysOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return krm.repo.RemoveKeys(ctx, issuerID, ids...)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
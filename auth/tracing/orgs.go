@@ -25,6 +25,7 @@ const (
 	unassignOrgMembers    = "unassign_org_members"
 	unassignOrgGroups     = "unassign_org_groups"
 	retrieveByGroupID     = "retrieve_by_group_id"
+	retrieveMemberGroups  = "retrieve_member_groups"
 	updateOrgMembers      = "update_org_members"
 	retrieveAll           = "retrieve_all_orgs"
 	retrieveAllOrgMembers = "retrieve_all_member_elations"
@@ -190,6 +191,14 @@ func (orm orgRepositoryMiddleware) RetrieveByGroupID(ctx context.Context, groupI
 	return orm.repo.RetrieveByGroupID(ctx, groupID)
 }
 
+func (orm orgRepositoryMiddleware) RetrieveMemberGroups(ctx context.Context, memberID string) ([]string, error) {
+	span := createSpan(ctx, orm.tracer, retrieveMemberGroups)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return orm.repo.RetrieveMemberGroups(ctx, memberID)
+}
+
 func (orm orgRepositoryMiddleware) RetrieveAllOrgGroups(ctx context.Context) ([]auth.OrgGroup, error) {
 	span := createSpan(ctx, orm.tracer, retrieveAllOrgGroups)
 	defer span.Finish()
@@ -0,0 +1,220 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/bootstrap"
+	log "github.com/MainfluxLabs/mainflux/logger"
+	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+)
+
+var _ bootstrap.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	svc       bootstrap.Service
+	auth      mainflux.AuthServiceClient
+	sdk       mfsdk.SDK
+	publisher audit.Publisher
+	logger    log.Logger
+}
+
+// NewAuditMiddleware publishes the audit records of the successful operations
+// which change bootstrap configs. The records of the configs whose things
+// belong to the groups assigned to an org are visible to the org owner.
+func NewAuditMiddleware(svc bootstrap.Service, auth mainflux.AuthServiceClient, sdk mfsdk.SDK, publisher audit.Publisher, logger log.Logger) bootstrap.Service {
+	return &auditMiddleware{
+		svc:       svc,
+		auth:      auth,
+		sdk:       sdk,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (am *auditMiddleware) Add(ctx context.Context, token string, cfg bootstrap.Config) (bootstrap.Config, error) {
+	saved, err := am.svc.Add(ctx, token, cfg)
+	if err != nil {
+		return saved, err
+	}
+
+	am.publish(ctx, saved.Owner, audit.ActionCreate, saved.ThingID, am.org(ctx, token, saved.ThingID), nil, configFields(saved))
+
+	return saved, nil
+}
+
+func (am *auditMiddleware) View(ctx context.Context, token, id string) (bootstrap.Config, error) {
+	return am.svc.View(ctx, token, id)
+}
+
+func (am *auditMiddleware) Update(ctx context.Context, token string, cfg bootstrap.Config) error {
+	before, _ := am.svc.View(ctx, token, cfg.ThingID)
+
+	if err := am.svc.Update(ctx, token, cfg); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		// The request contains only the updated fields, so the config is
+		// read again to record its stored state.
+		after, err := am.svc.View(ctx, token, cfg.ThingID)
+		if err != nil {
+			after = cfg
+		}
+		am.publish(ctx, actor, audit.ActionUpdate, cfg.ThingID, am.org(ctx, token, cfg.ThingID), configFields(before), configFields(after))
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) UpdateCert(ctx context.Context, token, thingID, clientCert, clientKey, caCert string) error {
+	if err := am.svc.UpdateCert(ctx, token, thingID, clientCert, clientKey, caCert); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		am.publish(ctx, actor, audit.ActionUpdateKey, thingID, am.org(ctx, token, thingID), nil, nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) UpdateConnections(ctx context.Context, token, id string, connections []string) error {
+	before, _ := am.svc.View(ctx, token, id)
+
+	if err := am.svc.UpdateConnections(ctx, token, id, connections); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		b := map[string]interface{}{"channels": channelIDs(before.Channels)}
+		a := map[string]interface{}{"channels": connections}
+		am.publish(ctx, actor, audit.ActionUpdate, id, am.org(ctx, token, id), b, a)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) List(ctx context.Context, token string, filter bootstrap.Filter, offset, limit uint64) (bootstrap.ConfigsPage, error) {
+	return am.svc.List(ctx, token, filter, offset, limit)
+}
+
+func (am *auditMiddleware) Remove(ctx context.Context, token, id string) error {
+	actor, ok := am.identify(ctx, token)
+	before, _ := am.svc.View(ctx, token, id)
+	orgID := am.org(ctx, token, id)
+
+	if err := am.svc.Remove(ctx, token, id); err != nil {
+		return err
+	}
+
+	if ok {
+		am.publish(ctx, actor, audit.ActionRemove, id, orgID, configFields(before), nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) Bootstrap(ctx context.Context, externalKey, externalID string, secure bool) (bootstrap.Config, error) {
+	return am.svc.Bootstrap(ctx, externalKey, externalID, secure)
+}
+
+func (am *auditMiddleware) ChangeState(ctx context.Context, token, id string, state bootstrap.State) error {
+	before, _ := am.svc.View(ctx, token, id)
+
+	if err := am.svc.ChangeState(ctx, token, id, state); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		b := map[string]interface{}{"state": int(before.State)}
+		a := map[string]interface{}{"state": int(state)}
+		am.publish(ctx, actor, audit.ActionUpdate, id, am.org(ctx, token, id), b, a)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) UpdateChannelHandler(ctx context.Context, channel bootstrap.Channel) error {
+	return am.svc.UpdateChannelHandler(ctx, channel)
+}
+
+func (am *auditMiddleware) RemoveConfigHandler(ctx context.Context, id string) error {
+	return am.svc.RemoveConfigHandler(ctx, id)
+}
+
+func (am *auditMiddleware) RemoveChannelHandler(ctx context.Context, id string) error {
+	return am.svc.RemoveChannelHandler(ctx, id)
+}
+
+func (am *auditMiddleware) DisconnectThingHandler(ctx context.Context, channelID, thingID string) error {
+	return am.svc.DisconnectThingHandler(ctx, channelID, thingID)
+}
+
+func (am *auditMiddleware) identify(ctx context.Context, token string) (string, bool) {
+	res, err := am.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to identify actor of bootstrap operation: %s", err))
+		return "", false
+	}
+
+	return res.GetId(), true
+}
+
+// org returns the ID of the org the group of the config thing is assigned
+// to, or an empty ID if there is no such org.
+func (am *auditMiddleware) org(ctx context.Context, token, thingID string) string {
+	gr, err := am.sdk.ViewThingMembership(thingID, token, 0, 1)
+	if err != nil || gr.ID == "" {
+		return ""
+	}
+
+	res, err := am.auth.RetrieveGroupOrg(ctx, &mainflux.GroupOrgReq{GroupID: gr.ID})
+	if err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to retrieve org of group %s: %s", gr.ID, err))
+		return ""
+	}
+
+	return res.GetValue()
+}
+
+// publish publishes the record of the operation performed on the config.
+// Failures are logged, since the operation itself has already succeeded.
+func (am *auditMiddleware) publish(ctx context.Context, actor, action, id, orgID string, before, after map[string]interface{}) {
+	record := audit.NewRecord(ctx, actor, action, audit.EntityConfig, id, before, after)
+	record.OrgID = orgID
+	if err := am.publisher.Publish(ctx, record); err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to publish audit record of config %s: %s", action, err))
+	}
+}
+
+// Keys and certificates of the configs are never recorded.
+func configFields(cfg bootstrap.Config) map[string]interface{} {
+	if cfg.ThingID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"name":        cfg.Name,
+		"external_id": cfg.ExternalID,
+		"channels":    channelIDs(cfg.Channels),
+		"content":     cfg.Content,
+		"state":       int(cfg.State),
+	}
+}
+
+func channelIDs(channels []bootstrap.Channel) []string {
+	ids := []string{}
+	for _, ch := range channels {
+		ids = append(ids, ch.ID)
+	}
+
+	return ids
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 )
@@ -125,6 +126,10 @@ func (bs bootstrapService) Add(ctx context.Context, token string, cfg Config) (C
 		return Config{}, err
 	}
 
+	if err := bs.authorize(token, auth.WriteAction, cfg.ThingID); err != nil {
+		return Config{}, err
+	}
+
 	toConnect := bs.toIDList(cfg.Channels)
 
 	// Check if channels exist. This is the way to prevent fetching channels that already exist.
@@ -172,6 +177,10 @@ func (bs bootstrapService) View(ctx context.Context, token, id string) (Config,
 		return Config{}, err
 	}
 
+	if err := bs.authorize(token, auth.ReadAction, id); err != nil {
+		return Config{}, err
+	}
+
 	return bs.configs.RetrieveByID(owner, id)
 }
 
@@ -181,6 +190,10 @@ func (bs bootstrapService) Update(ctx context.Context, token string, cfg Config)
 		return err
 	}
 
+	if err := bs.authorize(token, auth.WriteAction, cfg.ThingID); err != nil {
+		return err
+	}
+
 	cfg.Owner = owner
 
 	return bs.configs.Update(cfg)
@@ -191,6 +204,11 @@ func (bs bootstrapService) UpdateCert(ctx context.Context, token, thingID, clien
 	if err != nil {
 		return err
 	}
+
+	if err := bs.authorize(token, auth.WriteAction, thingID); err != nil {
+		return err
+	}
+
 	if err := bs.configs.UpdateCert(owner, thingID, clientCert, clientKey, caCert); err != nil {
 		return errors.Wrap(errUpdateCert, err)
 	}
@@ -203,6 +221,10 @@ func (bs bootstrapService) UpdateConnections(ctx context.Context, token, id stri
 		return err
 	}
 
+	if err := bs.authorize(token, auth.WriteAction, id); err != nil {
+		return err
+	}
+
 	cfg, err := bs.configs.RetrieveByID(owner, id)
 	if err != nil {
 		return errors.Wrap(errUpdateConnections, err)
@@ -260,6 +282,10 @@ func (bs bootstrapService) List(ctx context.Context, token string, filter Filter
 		return ConfigsPage{}, err
 	}
 
+	if err := bs.authorize(token, auth.ReadAction, ""); err != nil {
+		return ConfigsPage{}, err
+	}
+
 	return bs.configs.RetrieveAll(owner, filter, offset, limit), nil
 }
 
@@ -268,6 +294,11 @@ func (bs bootstrapService) Remove(ctx context.Context, token, id string) error {
 	if err != nil {
 		return err
 	}
+
+	if err := bs.authorize(token, auth.WriteAction, id); err != nil {
+		return err
+	}
+
 	if err := bs.configs.Remove(owner, id); err != nil {
 		return errors.Wrap(errRemoveBootstrap, err)
 	}
@@ -301,6 +332,10 @@ func (bs bootstrapService) ChangeState(ctx context.Context, token, id string, st
 		return err
 	}
 
+	if err := bs.authorize(token, auth.WriteAction, id); err != nil {
+		return err
+	}
+
 	cfg, err := bs.configs.RetrieveByID(owner, id)
 	if err != nil {
 		return errors.Wrap(errChangeState, err)
@@ -385,6 +420,25 @@ func (bs bootstrapService) identify(token string) (string, error) {
 	return res.GetId(), nil
 }
 
+// authorize checks whether the token scopes allow the action on the thing
+// the bootstrap configuration belongs to.
+func (bs bootstrapService) authorize(token, action, thingID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: auth.ThingsSubject,
+		Object:  thingID,
+		Action:  action,
+	}
+	if _, err := bs.auth.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
 // Method thing retrieves Mainflux Thing creating one if an empty ID is passed.
 func (bs bootstrapService) thing(token, id string) (mfsdk.Thing, error) {
 	thingID := id
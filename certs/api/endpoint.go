@@ -94,3 +94,30 @@ func revokeCert(svc certs.Service) endpoint.Endpoint {
 		return svc.RevokeCert(ctx, req.token, req.certID)
 	}
 }
+
+func viewCRL(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		crl, err := svc.CRL(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return crlRes{crl: crl}, nil
+	}
+}
+
+func respondOCSP(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ocspReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		res, err := svc.OCSP(ctx, req.body)
+		if err != nil {
+			return nil, err
+		}
+
+		return ocspRes{body: res}, nil
+	}
+}
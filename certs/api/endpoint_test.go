@@ -0,0 +1,158 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/certs"
+	"github.com/MainfluxLabs/mainflux/certs/api"
+	ctmocks "github.com/MainfluxLabs/mainflux/certs/mocks"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/MainfluxLabs/mainflux/things"
+	httpapi "github.com/MainfluxLabs/mainflux/things/api/things/http"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType = "application/json"
+	email       = "user@example.com"
+	token       = email
+	password    = "password"
+	thingID     = "1"
+	thingKey    = "thingKey"
+	ttl         = "1h"
+	keyBits     = 2048
+
+	caPath         = "../../docker/ssl/certs/ca.crt"
+	caKeyPath      = "../../docker/ssl/certs/ca.key"
+	signHoursValid = "24h"
+	authTimeout    = time.Second
+)
+
+var usersList = []users.User{{Email: email, Password: password}}
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+func newService(t *testing.T) certs.Service {
+	auth := mocks.NewAuthService("", usersList)
+	ths := map[string]things.Thing{thingID: {ID: thingID, Key: thingKey, Owner: email}}
+	thingsServer := httptest.NewServer(httpapi.MakeHandler(mocktracer.New(), mocks.NewThingsService(ths, map[string]things.Channel{}, auth), logger.NewMock()))
+	t.Cleanup(thingsServer.Close)
+	sdk := mfsdk.NewSDK(mfsdk.Config{ThingsURL: thingsServer.URL})
+
+	tlsCert, err := tls.LoadX509KeyPair(caPath, caKeyPath)
+	require.Nil(t, err, fmt.Sprintf("unexpected CA loading error: %s\n", err))
+	caCert, err := x509.ParseCertificate(tlsCert.Certificate[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected CA parsing error: %s\n", err))
+
+	c := certs.Config{
+		SignTLSCert:    tlsCert,
+		SignX509Cert:   caCert,
+		SignHoursValid: signHoursValid,
+		SignRSABits:    keyBits,
+		LocalPKI:       true,
+	}
+	pki := ctmocks.NewPkiAgent(tlsCert, caCert, keyBits, signHoursValid, authTimeout)
+
+	return certs.New(auth, ctmocks.NewCertsRepository(), sdk, c, pki)
+}
+
+func newServer(svc certs.Service) *httptest.Server {
+	mux := api.MakeHandler(svc, logger.NewMock())
+	return httptest.NewServer(mux)
+}
+
+func TestIssueCert(t *testing.T) {
+	ts := newServer(newService(t))
+	defer ts.Close()
+
+	cases := []struct {
+		desc    string
+		keyType string
+		keyBits int
+		status  int
+	}{
+		{
+			desc:    "issue RSA cert",
+			keyType: "rsa",
+			keyBits: keyBits,
+			status:  http.StatusCreated,
+		},
+		{
+			desc:    "issue RSA cert with missing key bits",
+			keyType: "rsa",
+			keyBits: 0,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "issue RSA cert with too small key bits",
+			keyType: "rsa",
+			keyBits: 1024,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "issue RSA cert with unsupported key bits",
+			keyType: "rsa",
+			keyBits: 2049,
+			status:  http.StatusBadRequest,
+		},
+		{
+			desc:    "issue RSA cert with too large key bits",
+			keyType: "rsa",
+			keyBits: 65536,
+			status:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		body := fmt.Sprintf(`{"thing_id":"%s","ttl":"%s","key_type":"%s","key_bits":%d}`, thingID, ttl, tc.keyType, tc.keyBits)
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/certs", ts.URL),
+			contentType: contentType,
+			token:       token,
+			body:        strings.NewReader(body),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
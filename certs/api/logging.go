@@ -90,3 +90,29 @@ func (lm *loggingMiddleware) RevokeCert(ctx context.Context, token, thingID stri
 
 	return lm.svc.RevokeCert(ctx, token, thingID)
 }
+
+func (lm *loggingMiddleware) CRL(ctx context.Context) (crl []byte, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method crl took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.CRL(ctx)
+}
+
+func (lm *loggingMiddleware) OCSP(ctx context.Context, req []byte) (res []byte, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method ocsp took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OCSP(ctx, req)
+}
@@ -74,3 +74,21 @@ func (ms *metricsMiddleware) RevokeCert(ctx context.Context, token, thingID stri
 
 	return ms.svc.RevokeCert(ctx, token, thingID)
 }
+
+func (ms *metricsMiddleware) CRL(ctx context.Context) ([]byte, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "crl").Add(1)
+		ms.latency.With("method", "crl").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CRL(ctx)
+}
+
+func (ms *metricsMiddleware) OCSP(ctx context.Context, req []byte) ([]byte, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "ocsp").Add(1)
+		ms.latency.With("method", "ocsp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OCSP(ctx, req)
+}
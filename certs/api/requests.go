@@ -3,10 +3,16 @@
 
 package api
 
-import "github.com/MainfluxLabs/mainflux/internal/apiutil"
+import (
+	"github.com/MainfluxLabs/mainflux/certs/pki"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+)
 
 const maxLimitSize = 100
 
+// rsaKeyBits are the supported RSA private key sizes.
+var rsaKeyBits = map[int]bool{2048: true, 3072: true, 4096: true}
+
 type addCertsReq struct {
 	token   string
 	ThingID string `json:"thing_id"`
@@ -28,6 +34,10 @@ func (req addCertsReq) validate() error {
 		return apiutil.ErrMissingCertData
 	}
 
+	if req.KeyType == pki.RSAKeyType && !rsaKeyBits[req.KeyBits] {
+		return apiutil.ErrMalformedEntity
+	}
+
 	return nil
 }
 
@@ -80,3 +90,15 @@ func (req *revokeReq) validate() error {
 
 	return nil
 }
+
+type ocspReq struct {
+	body []byte
+}
+
+func (req ocspReq) validate() error {
+	if len(req.body) == 0 {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
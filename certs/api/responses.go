@@ -55,3 +55,11 @@ func (res certsRes) Headers() map[string]string {
 func (res certsRes) Empty() bool {
 	return false
 }
+
+type crlRes struct {
+	crl []byte
+}
+
+type ocspRes struct {
+	body []byte
+}
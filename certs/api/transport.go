@@ -6,6 +6,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/MainfluxLabs/mainflux"
@@ -20,6 +21,10 @@ import (
 
 const (
 	contentType = "application/json"
+	crlType     = "application/pkix-crl"
+	ocspReqType = "application/ocsp-request"
+	ocspResType = "application/ocsp-response"
+	maxOCSPSize = 10 * 1024
 	offsetKey   = "offset"
 	limitKey    = "limit"
 	defOffset   = 0
@@ -62,6 +67,20 @@ func MakeHandler(svc certs.Service, logger logger.Logger) http.Handler {
 		opts...,
 	))
 
+	r.Get("/crl", kithttp.NewServer(
+		viewCRL(svc),
+		decodeCRL,
+		encodeCRL,
+		opts...,
+	))
+
+	r.Post("/ocsp", kithttp.NewServer(
+		respondOCSP(svc),
+		decodeOCSP,
+		encodeOCSP,
+		opts...,
+	))
+
 	r.Handle("/metrics", promhttp.Handler())
 	r.GetFunc("/health", mainflux.Health("certs"))
 
@@ -86,6 +105,20 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 	return json.NewEncoder(w).Encode(response)
 }
 
+func encodeCRL(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(crlRes)
+	w.Header().Set("Content-Type", crlType)
+	_, err := w.Write(res.crl)
+	return err
+}
+
+func encodeOCSP(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(ocspRes)
+	w.Header().Set("Content-Type", ocspResType)
+	_, err := w.Write(res.body)
+	return err
+}
+
 func decodeListCerts(_ context.Context, r *http.Request) (interface{}, error) {
 	l, err := apiutil.ReadUintQuery(r, limitKey, defLimit)
 	if err != nil {
@@ -127,6 +160,23 @@ func decodeCerts(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeCRL(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeOCSP(_ context.Context, r *http.Request) (interface{}, error) {
+	if r.Header.Get("Content-Type") != ocspReqType {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxOCSPSize))
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return ocspReq{body: body}, nil
+}
+
 func decodeRevokeCerts(_ context.Context, r *http.Request) (interface{}, error) {
 	req := revokeReq{
 		token:  apiutil.ExtractBearerToken(r),
@@ -150,6 +200,8 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrConflict):
 		w.WriteHeader(http.StatusConflict)
+	case errors.Contains(err, certs.ErrRevocationUnavailable):
+		w.WriteHeader(http.StatusNotImplemented)
 
 	case errors.Contains(err, errors.ErrCreateEntity),
 		errors.Contains(err, errors.ErrRetrieveEntity),
@@ -3,7 +3,17 @@
 
 package certs
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// Certificate statuses, as reported by the OCSP responder.
+const (
+	StatusGood    = "good"
+	StatusRevoked = "revoked"
+	StatusUnknown = "unknown"
+)
 
 // ConfigsPage contains page related metadata as well as list
 type Page struct {
@@ -13,6 +23,21 @@ type Page struct {
 	Certs  []Cert
 }
 
+// RevokedCert contains info on the revoked certificate.
+type RevokedCert struct {
+	Serial         string
+	ThingID        string
+	RevocationTime time.Time
+	Expire         time.Time
+}
+
+// Status contains the revocation status of the certificate.
+type Status struct {
+	Serial         string
+	Status         string
+	RevocationTime time.Time
+}
+
 // Repository specifies a Config persistence API.
 type Repository interface {
 	// Save  saves cert for thing into database
@@ -29,4 +54,13 @@ type Repository interface {
 
 	// RetrieveBySerial retrieves a certificate for a given serial ID
 	RetrieveBySerial(ctx context.Context, ownerID, serialID string) (Cert, error)
+
+	// SaveRevoked saves the revoked certificate
+	SaveRevoked(ctx context.Context, cert RevokedCert) error
+
+	// RetrieveRevoked retrieves revoked certificates which have not expired yet
+	RetrieveRevoked(ctx context.Context) ([]RevokedCert, error)
+
+	// RetrieveStatus retrieves revocation status of the certificate with given serial ID
+	RetrieveStatus(ctx context.Context, serialID string) (Status, error)
 }
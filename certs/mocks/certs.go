@@ -6,6 +6,7 @@ package mocks
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/certs"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -18,6 +19,7 @@ type certsRepoMock struct {
 	counter        uint64
 	certsBySerial  map[string]certs.Cert
 	certsByThingID map[string]map[string][]certs.Cert
+	revoked        map[string]certs.RevokedCert
 }
 
 // NewCertsRepository creates in-memory certs repository.
@@ -25,6 +27,7 @@ func NewCertsRepository() certs.Repository {
 	return &certsRepoMock{
 		certsBySerial:  make(map[string]certs.Cert),
 		certsByThingID: make(map[string]map[string][]certs.Cert),
+		revoked:        make(map[string]certs.RevokedCert),
 	}
 }
 
@@ -135,3 +138,43 @@ func (c *certsRepoMock) RetrieveBySerial(ctx context.Context, ownerID, serialID
 
 	return crt, nil
 }
+
+func (c *certsRepoMock) SaveRevoked(ctx context.Context, cert certs.RevokedCert) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.revoked[cert.Serial]; !ok {
+		c.revoked[cert.Serial] = cert
+	}
+
+	return nil
+}
+
+func (c *certsRepoMock) RetrieveRevoked(ctx context.Context) ([]certs.RevokedCert, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	revoked := []certs.RevokedCert{}
+	for _, rc := range c.revoked {
+		if rc.Expire.After(now) {
+			revoked = append(revoked, rc)
+		}
+	}
+
+	return revoked, nil
+}
+
+func (c *certsRepoMock) RetrieveStatus(ctx context.Context, serialID string) (certs.Status, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if rc, ok := c.revoked[serialID]; ok {
+		return certs.Status{Serial: serialID, Status: certs.StatusRevoked, RevocationTime: rc.RevocationTime}, nil
+	}
+	if _, ok := c.certsBySerial[serialID]; ok {
+		return certs.Status{Serial: serialID, Status: certs.StatusGood}, nil
+	}
+
+	return certs.Status{Serial: serialID, Status: certs.StatusUnknown}, nil
+}
@@ -130,7 +130,8 @@ func (a *agent) IssueCert(cn string, ttl, keyType string, keyBits int) (pki.Cert
 	buffKeyOut.Flush()
 	key := keyOut.String()
 
-	a.certs[x509cert.SerialNumber.String()] = pki.Cert{
+	serial := pki.FormatSerial(x509cert.SerialNumber)
+	a.certs[serial] = pki.Cert{
 		ClientCert: cert,
 	}
 	a.counter++
@@ -138,7 +139,7 @@ func (a *agent) IssueCert(cn string, ttl, keyType string, keyBits int) (pki.Cert
 	return pki.Cert{
 		ClientCert: cert,
 		ClientKey:  key,
-		Serial:     x509cert.SerialNumber.String(),
+		Serial:     serial,
 		Expire:     x509cert.NotAfter,
 		IssuingCA:  x509cert.Issuer.String(),
 	}, nil
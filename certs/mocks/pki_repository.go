@@ -0,0 +1,69 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/certs/pki"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ pki.Repository = (*pkiRepoMock)(nil)
+
+type pkiRepoMock struct {
+	mu      sync.Mutex
+	certs   map[string]pki.Cert
+	revoked map[string]time.Time
+}
+
+// NewPKIRepository creates in-memory repository of locally issued certificates.
+func NewPKIRepository() pki.Repository {
+	return &pkiRepoMock{
+		certs:   make(map[string]pki.Cert),
+		revoked: make(map[string]time.Time),
+	}
+}
+
+func (r *pkiRepoMock) Save(ctx context.Context, cert pki.Cert) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.certs[cert.Serial]; ok {
+		return errors.ErrConflict
+	}
+	cert.ClientKey = ""
+	r.certs[cert.Serial] = cert
+
+	return nil
+}
+
+func (r *pkiRepoMock) RetrieveBySerial(ctx context.Context, serial string) (pki.Cert, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cert, ok := r.certs[serial]
+	if !ok {
+		return pki.Cert{}, errors.ErrNotFound
+	}
+
+	return cert, nil
+}
+
+func (r *pkiRepoMock) Revoke(ctx context.Context, serial string, at time.Time) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.certs[serial]; !ok {
+		return time.Time{}, errors.ErrNotFound
+	}
+	if revoked, ok := r.revoked[serial]; ok {
+		return revoked, nil
+	}
+	r.revoked[serial] = at
+
+	return at, nil
+}
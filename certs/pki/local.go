@@ -0,0 +1,243 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package pki
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	// RSAKeyType represents RSA private key type.
+	RSAKeyType = "rsa"
+	// ECKeyType represents elliptic curve private key type.
+	ECKeyType = "ec"
+
+	serialBits = 128
+)
+
+var (
+	errUnsupportedKeyType = errors.New("unsupported private key type")
+	errUnsupportedKeyBits = errors.New("unsupported private key size")
+	errInvalidTTL         = errors.New("invalid certificate ttl")
+	errExpiredCA          = errors.New("CA certificate expired")
+)
+
+var _ Agent = (*localAgent)(nil)
+
+// Repository specifies the persistence API of the certificates
+// issued by the local certificate authority.
+type Repository interface {
+	// Save persists the issued certificate. The private key is never stored.
+	Save(ctx context.Context, cert Cert) error
+
+	// RetrieveBySerial retrieves the certificate with the given serial.
+	RetrieveBySerial(ctx context.Context, serial string) (Cert, error)
+
+	// Revoke marks the certificate with the given serial as revoked and
+	// returns the revocation time. Revoking an already revoked certificate
+	// returns its original revocation time.
+	Revoke(ctx context.Context, serial string, at time.Time) (time.Time, error)
+}
+
+type localAgent struct {
+	tlsCert tls.Certificate
+	caCert  *x509.Certificate
+	caPEM   string
+	ttl     string
+	repo    Repository
+}
+
+// NewLocalAgent instantiates a PKI agent which signs client certificates with
+// the given CA certificate and key, without relying on 3rd party PKI.
+func NewLocalAgent(tlsCert tls.Certificate, caCert *x509.Certificate, ttl string, repo Repository) (Agent, error) {
+	if caCert == nil || tlsCert.PrivateKey == nil {
+		return nil, ErrMissingCACertificate
+	}
+
+	return &localAgent{
+		tlsCert: tlsCert,
+		caCert:  caCert,
+		caPEM:   string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})),
+		ttl:     ttl,
+		repo:    repo,
+	}, nil
+}
+
+func (a *localAgent) IssueCert(cn string, ttl, keyType string, keyBits int) (Cert, error) {
+	if ttl == "" {
+		ttl = a.ttl
+	}
+	validFor, err := time.ParseDuration(ttl)
+	if err != nil || validFor <= 0 {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, errInvalidTTL)
+	}
+
+	if keyType == "" {
+		keyType = RSAKeyType
+	}
+	priv, err := generateKey(keyType, keyBits)
+	if err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+	pub := priv.Public()
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialBits))
+	if err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+
+	skid, err := subjectKeyID(pub)
+	if err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+
+	// Client certificate must not outlive the CA which issued it.
+	notBefore := time.Now()
+	if !notBefore.Before(a.caCert.NotAfter) {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, errExpiredCA)
+	}
+	notAfter := notBefore.Add(validFor)
+	if notAfter.After(a.caCert.NotAfter) {
+		notAfter = a.caCert.NotAfter
+	}
+
+	keyUsage := x509.KeyUsageDigitalSignature
+	if keyType == RSAKeyType {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	tmpl := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			Organization:       []string{"Mainflux"},
+			OrganizationalUnit: []string{"mainflux"},
+			CommonName:         cn,
+		},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     keyUsage,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		SubjectKeyId: skid,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, a.caCert, pub, a.tlsCert.PrivateKey)
+	if err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+
+	keyBlock, err := pemBlockForKey(priv)
+	if err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+
+	cert := Cert{
+		ClientCert:     string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		IssuingCA:      a.caPEM,
+		CAChain:        []string{a.caPEM},
+		PrivateKeyType: keyType,
+		Serial:         FormatSerial(serial),
+		Expire:         notAfter,
+	}
+	if err := a.repo.Save(context.Background(), cert); err != nil {
+		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
+	}
+
+	cert.ClientKey = string(pem.EncodeToMemory(keyBlock))
+	return cert, nil
+}
+
+func (a *localAgent) Read(serial string) (Cert, error) {
+	return a.repo.RetrieveBySerial(context.Background(), serial)
+}
+
+func (a *localAgent) Revoke(serial string) (time.Time, error) {
+	revoked, err := a.repo.Revoke(context.Background(), serial, time.Now())
+	if err != nil {
+		return time.Time{}, errors.Wrap(ErrFailedCertRevocation, err)
+	}
+
+	return revoked, nil
+}
+
+// FormatSerial formats certificate serial number the same way Vault
+// does, as colon separated hex encoded bytes.
+func FormatSerial(serial *big.Int) string {
+	b := serial.Bytes()
+	parts := make([]string, len(b))
+	for i := range b {
+		parts[i] = hex.EncodeToString(b[i : i+1])
+	}
+
+	return strings.Join(parts, ":")
+}
+
+func generateKey(keyType string, keyBits int) (crypto.Signer, error) {
+	switch keyType {
+	case RSAKeyType:
+		switch keyBits {
+		case 2048, 3072, 4096:
+		default:
+			return nil, errUnsupportedKeyBits
+		}
+		return rsa.GenerateKey(rand.Reader, keyBits)
+	case ECKeyType:
+		var curve elliptic.Curve
+		switch keyBits {
+		case 224:
+			curve = elliptic.P224()
+		case 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, errUnsupportedKeyBits
+		}
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return nil, errUnsupportedKeyType
+	}
+}
+
+func subjectKeyID(pub crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	id := sha1.Sum(der)
+
+	return id[:], nil
+}
+
+func pemBlockForKey(priv crypto.Signer) (*pem.Block, error) {
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		return &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}, nil
+	case *ecdsa.PrivateKey:
+		b, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: b}, nil
+	default:
+		return nil, errUnsupportedKeyType
+	}
+}
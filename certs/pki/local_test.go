@@ -0,0 +1,229 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package pki_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/certs/mocks"
+	"github.com/MainfluxLabs/mainflux/certs/pki"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	thingKey = "thingKey"
+	ttl      = "1h"
+	defTTL   = "24h"
+	keyBits  = 2048
+
+	caValidity = 365 * 24 * time.Hour
+)
+
+func newAgent(t *testing.T) (pki.Agent, *x509.Certificate) {
+	return newAgentWithCA(t, time.Now().Add(caValidity))
+}
+
+func newAgentWithCA(t *testing.T, notAfter time.Time) (pki.Agent, *x509.Certificate) {
+	tlsCert, caCert := newCA(t, notAfter)
+
+	agent, err := pki.NewLocalAgent(tlsCert, caCert, defTTL, mocks.NewPKIRepository())
+	require.Nil(t, err, fmt.Sprintf("unexpected agent creation error: %s\n", err))
+
+	return agent, caCert
+}
+
+func newCA(t *testing.T, notAfter time.Time) (tls.Certificate, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("unexpected CA key generation error: %s\n", err))
+
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Mainflux CA"},
+		NotBefore:             notAfter.Add(-2 * caValidity),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	require.Nil(t, err, fmt.Sprintf("unexpected CA creation error: %s\n", err))
+	caCert, err := x509.ParseCertificate(der)
+	require.Nil(t, err, fmt.Sprintf("unexpected CA parsing error: %s\n", err))
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: caCert}, caCert
+}
+
+func TestNewLocalAgent(t *testing.T) {
+	_, err := pki.NewLocalAgent(tls.Certificate{}, nil, defTTL, mocks.NewPKIRepository())
+	assert.True(t, errors.Contains(err, pki.ErrMissingCACertificate), fmt.Sprintf("expected %s got %s\n", pki.ErrMissingCACertificate, err))
+}
+
+func TestIssueCert(t *testing.T) {
+	agent, caCert := newAgent(t)
+
+	cases := []struct {
+		desc    string
+		ttl     string
+		keyType string
+		keyBits int
+		err     error
+	}{
+		{
+			desc:    "issue RSA cert",
+			ttl:     ttl,
+			keyType: pki.RSAKeyType,
+			keyBits: keyBits,
+			err:     nil,
+		},
+		{
+			desc:    "issue EC cert",
+			ttl:     ttl,
+			keyType: pki.ECKeyType,
+			keyBits: 256,
+			err:     nil,
+		},
+		{
+			desc:    "issue cert with default ttl and key type",
+			ttl:     "",
+			keyType: "",
+			keyBits: keyBits,
+			err:     nil,
+		},
+		{
+			desc:    "issue cert with invalid ttl",
+			ttl:     "invalid",
+			keyType: pki.RSAKeyType,
+			keyBits: keyBits,
+			err:     pki.ErrFailedCertCreation,
+		},
+		{
+			desc:    "issue cert with unsupported key type",
+			ttl:     ttl,
+			keyType: "dsa",
+			keyBits: keyBits,
+			err:     pki.ErrFailedCertCreation,
+		},
+		{
+			desc:    "issue RSA cert with too small key bits",
+			ttl:     ttl,
+			keyType: pki.RSAKeyType,
+			keyBits: 1024,
+			err:     pki.ErrFailedCertCreation,
+		},
+		{
+			desc:    "issue RSA cert with too large key bits",
+			ttl:     ttl,
+			keyType: pki.RSAKeyType,
+			keyBits: 65536,
+			err:     pki.ErrFailedCertCreation,
+		},
+		{
+			desc:    "issue EC cert with unsupported key bits",
+			ttl:     ttl,
+			keyType: pki.ECKeyType,
+			keyBits: keyBits,
+			err:     pki.ErrFailedCertCreation,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := agent.IssueCert(thingKey, tc.ttl, tc.keyType, tc.keyBits)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err != nil {
+			continue
+		}
+
+		block, _ := pem.Decode([]byte(c.ClientCert))
+		require.NotNil(t, block, fmt.Sprintf("%s: failed to decode issued certificate\n", tc.desc))
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected certificate parsing error: %s\n", tc.desc, err))
+
+		assert.Equal(t, thingKey, cert.Subject.CommonName, fmt.Sprintf("%s: expected CN %s got %s\n", tc.desc, thingKey, cert.Subject.CommonName))
+		assert.Equal(t, pki.FormatSerial(cert.SerialNumber), c.Serial, fmt.Sprintf("%s: expected serial %s got %s\n", tc.desc, pki.FormatSerial(cert.SerialNumber), c.Serial))
+		assert.NotEmpty(t, c.ClientKey, fmt.Sprintf("%s: expected private key to be returned\n", tc.desc))
+		err = cert.CheckSignatureFrom(caCert)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected certificate signed by CA got %s\n", tc.desc, err))
+	}
+}
+
+func TestRead(t *testing.T) {
+	agent, _ := newAgent(t)
+
+	c, err := agent.IssueCert(thingKey, ttl, pki.RSAKeyType, keyBits)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert issuing error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		serial string
+		err    error
+	}{
+		{
+			desc:   "read issued cert",
+			serial: c.Serial,
+			err:    nil,
+		},
+		{
+			desc:   "read non-existing cert",
+			serial: "non-existing",
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		cert, err := agent.Read(tc.serial)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, c.ClientCert, cert.ClientCert, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, c.ClientCert, cert.ClientCert))
+			assert.Empty(t, cert.ClientKey, fmt.Sprintf("%s: expected private key not to be stored\n", tc.desc))
+		}
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	agent, _ := newAgent(t)
+
+	c, err := agent.IssueCert(thingKey, ttl, pki.RSAKeyType, keyBits)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert issuing error: %s\n", err))
+
+	revoked, err := agent.Revoke(c.Serial)
+	assert.Nil(t, err, fmt.Sprintf("revoking issued cert: expected no error got %s\n", err))
+	assert.False(t, revoked.IsZero(), "revoking issued cert: expected revocation time to be set")
+
+	again, err := agent.Revoke(c.Serial)
+	assert.Nil(t, err, fmt.Sprintf("revoking revoked cert: expected no error got %s\n", err))
+	assert.Equal(t, revoked, again, fmt.Sprintf("revoking revoked cert: expected %s got %s\n", revoked, again))
+
+	_, err = agent.Revoke("non-existing")
+	assert.True(t, errors.Contains(err, pki.ErrFailedCertRevocation), fmt.Sprintf("revoking non-existing cert: expected %s got %s\n", pki.ErrFailedCertRevocation, err))
+}
+
+func TestFormatSerial(t *testing.T) {
+	serial := pki.FormatSerial(big.NewInt(0x1a2b3c))
+	assert.Equal(t, "1a:2b:3c", serial, fmt.Sprintf("expected 1a:2b:3c got %s\n", serial))
+}
+
+func TestIssueCertExpiry(t *testing.T) {
+	agent, caCert := newAgent(t)
+
+	c, err := agent.IssueCert(thingKey, fmt.Sprintf("%dh", 24*365*100), pki.RSAKeyType, keyBits)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert issuing error: %s\n", err))
+	assert.False(t, c.Expire.After(caCert.NotAfter), fmt.Sprintf("expected expiration not after %s got %s\n", caCert.NotAfter, c.Expire))
+	assert.True(t, c.Expire.After(time.Now()), fmt.Sprintf("expected expiration in future got %s\n", c.Expire))
+
+	agent, _ = newAgentWithCA(t, time.Now().Add(-time.Hour))
+	_, err = agent.IssueCert(thingKey, ttl, pki.RSAKeyType, keyBits)
+	assert.True(t, errors.Contains(err, pki.ErrFailedCertCreation), fmt.Sprintf("issuing cert with expired CA: expected %s got %s\n", pki.ErrFailedCertCreation, err))
+}
@@ -1,7 +1,8 @@
 // Copyright (c) Mainflux
 // SPDX-License-Identifier: Apache-2.0
 
-// Package pki wraps vault client
+// Package pki contains PKI agents used for issuing and revoking client
+// certificates, either using Vault or a local certificate authority.
 package pki
 
 import (
@@ -169,6 +169,66 @@ func (cr certsRepository) RetrieveBySerial(ctx context.Context, ownerID, serialI
 	return c, nil
 }
 
+func (cr certsRepository) SaveRevoked(ctx context.Context, cert certs.RevokedCert) error {
+	q := `INSERT INTO revoked_certs (serial, thing_id, revoked, expire) VALUES (:serial, :thing_id, :revoked, :expire)
+	      ON CONFLICT (serial) DO NOTHING;`
+
+	if _, err := cr.db.NamedExecContext(ctx, q, toDBRevokedCert(cert)); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (cr certsRepository) RetrieveRevoked(ctx context.Context) ([]certs.RevokedCert, error) {
+	q := `SELECT serial, thing_id, revoked, expire FROM revoked_certs WHERE expire > $1 ORDER BY revoked;`
+
+	rows, err := cr.db.QueryxContext(ctx, q, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	revoked := []certs.RevokedCert{}
+	for rows.Next() {
+		var dbrc dbRevokedCert
+		if err := rows.StructScan(&dbrc); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		revoked = append(revoked, toRevokedCert(dbrc))
+	}
+
+	return revoked, nil
+}
+
+func (cr certsRepository) RetrieveStatus(ctx context.Context, serialID string) (certs.Status, error) {
+	status := certs.Status{Serial: serialID}
+
+	q := `SELECT revoked FROM revoked_certs WHERE serial = $1;`
+	err := cr.db.QueryRowxContext(ctx, q, serialID).Scan(&status.RevocationTime)
+	switch err {
+	case nil:
+		status.Status = certs.StatusRevoked
+		return status, nil
+	case sql.ErrNoRows:
+	default:
+		return certs.Status{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	var exists bool
+	q = `SELECT EXISTS (SELECT 1 FROM certs WHERE serial = $1);`
+	if err := cr.db.QueryRowxContext(ctx, q, serialID).Scan(&exists); err != nil {
+		return certs.Status{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	status.Status = certs.StatusUnknown
+	if exists {
+		status.Status = certs.StatusGood
+	}
+
+	return status, nil
+}
+
 func (cr certsRepository) rollback(content string, tx *sqlx.Tx, err error) {
 	cr.log.Error(fmt.Sprintf("%s %s", content, err))
 
@@ -201,3 +261,28 @@ func toCert(cdb dbCert) certs.Cert {
 	c.Expire = cdb.Expire
 	return c
 }
+
+type dbRevokedCert struct {
+	Serial  string    `db:"serial"`
+	ThingID string    `db:"thing_id"`
+	Revoked time.Time `db:"revoked"`
+	Expire  time.Time `db:"expire"`
+}
+
+func toDBRevokedCert(rc certs.RevokedCert) dbRevokedCert {
+	return dbRevokedCert{
+		Serial:  rc.Serial,
+		ThingID: rc.ThingID,
+		Revoked: rc.RevocationTime,
+		Expire:  rc.Expire,
+	}
+}
+
+func toRevokedCert(dbrc dbRevokedCert) certs.RevokedCert {
+	return certs.RevokedCert{
+		Serial:         dbrc.Serial,
+		ThingID:        dbrc.ThingID,
+		RevocationTime: dbrc.Revoked,
+		Expire:         dbrc.Expire,
+	}
+}
@@ -72,6 +72,35 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE IF EXISTS certs;",
 				},
 			},
+			{
+				Id: "certs_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS pki_certs (
+						serial       TEXT PRIMARY KEY,
+						client_cert  TEXT NOT NULL,
+						issuing_ca   TEXT NOT NULL,
+						expire       TIMESTAMPTZ NOT NULL,
+						revoked      TIMESTAMPTZ
+					);`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS pki_certs;",
+				},
+			},
+			{
+				Id: "certs_3",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS revoked_certs (
+						serial       TEXT PRIMARY KEY,
+						thing_id     TEXT NOT NULL,
+						revoked      TIMESTAMPTZ NOT NULL,
+						expire       TIMESTAMPTZ NOT NULL
+					);`,
+				},
+				Down: []string{
+					"DROP TABLE IF EXISTS revoked_certs;",
+				},
+			},
 		},
 	}
 
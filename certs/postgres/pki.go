@@ -0,0 +1,95 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/certs/pki"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ pki.Repository = (*pkiRepository)(nil)
+
+type pkiRepository struct {
+	db *sqlx.DB
+}
+
+// NewPKIRepository instantiates a PostgreSQL implementation of the
+// repository of certificates issued by the local certificate authority.
+func NewPKIRepository(db *sqlx.DB) pki.Repository {
+	return &pkiRepository{db: db}
+}
+
+func (pr pkiRepository) Save(ctx context.Context, cert pki.Cert) error {
+	q := `INSERT INTO pki_certs (serial, client_cert, issuing_ca, expire) VALUES (:serial, :client_cert, :issuing_ca, :expire);`
+
+	if _, err := pr.db.NamedExecContext(ctx, q, toDBPKICert(cert)); err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pr pkiRepository) RetrieveBySerial(ctx context.Context, serial string) (pki.Cert, error) {
+	q := `SELECT serial, client_cert, issuing_ca, expire FROM pki_certs WHERE serial = $1;`
+
+	var dbc dbPKICert
+	if err := pr.db.QueryRowxContext(ctx, q, serial).StructScan(&dbc); err != nil {
+		if err == sql.ErrNoRows {
+			return pki.Cert{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return pki.Cert{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toPKICert(dbc), nil
+}
+
+func (pr pkiRepository) Revoke(ctx context.Context, serial string, at time.Time) (time.Time, error) {
+	q := `UPDATE pki_certs SET revoked = COALESCE(revoked, $2) WHERE serial = $1 RETURNING revoked;`
+
+	var revoked time.Time
+	if err := pr.db.QueryRowxContext(ctx, q, serial, at).Scan(&revoked); err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return time.Time{}, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return revoked, nil
+}
+
+type dbPKICert struct {
+	Serial     string    `db:"serial"`
+	ClientCert string    `db:"client_cert"`
+	IssuingCA  string    `db:"issuing_ca"`
+	Expire     time.Time `db:"expire"`
+}
+
+func toDBPKICert(c pki.Cert) dbPKICert {
+	return dbPKICert{
+		Serial:     c.Serial,
+		ClientCert: c.ClientCert,
+		IssuingCA:  c.IssuingCA,
+		Expire:     c.Expire,
+	}
+}
+
+func toPKICert(dbc dbPKICert) pki.Cert {
+	return pki.Cert{
+		Serial:     dbc.Serial,
+		ClientCert: dbc.ClientCert,
+		IssuingCA:  dbc.IssuingCA,
+		CAChain:    []string{dbc.IssuingCA},
+		Expire:     dbc.Expire,
+	}
+}
@@ -5,14 +5,28 @@ package certs
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/certs/pki"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	crlValidity  = 24 * time.Hour
+	ocspValidity = time.Hour
 )
 
 var (
@@ -22,7 +36,19 @@ var (
 	// ErrFailedCertRevocation failed to revoke certificate
 	ErrFailedCertRevocation = errors.New("failed to revoke certificate")
 
+	// ErrFailedCRLCreation failed to create certificate revocation list
+	ErrFailedCRLCreation = errors.New("failed to create certificate revocation list")
+
+	// ErrFailedOCSPResponse failed to create OCSP response
+	ErrFailedOCSPResponse = errors.New("failed to create OCSP response")
+
+	// ErrRevocationUnavailable indicates that CRL and OCSP are served only
+	// when certificates are issued by the local CA
+	ErrRevocationUnavailable = errors.New("revocation status is available only with the local PKI agent")
+
 	errFailedToRemoveCertFromDB = errors.New("failed to remove cert serial from db")
+	errMissingCA                = errors.New("missing CA certificate for signing")
+	errInvalidSerial            = errors.New("invalid certificate serial")
 )
 
 var _ Service = (*certsService)(nil)
@@ -44,6 +70,12 @@ type Service interface {
 
 	// RevokeCert revokes a certificate for a given serial ID
 	RevokeCert(ctx context.Context, token, serialID string) (Revoke, error)
+
+	// CRL returns DER encoded certificate revocation list signed by the CA
+	CRL(ctx context.Context) ([]byte, error)
+
+	// OCSP returns DER encoded OCSP response to the DER encoded OCSP request
+	OCSP(ctx context.Context, req []byte) ([]byte, error)
 }
 
 // Config defines the service parameters
@@ -66,6 +98,9 @@ type Config struct {
 	PKIPath        string
 	PKIRole        string
 	PKIToken       string
+	// LocalPKI reports whether certificates are issued by the local CA,
+	// which is the only one the CRL and OCSP responses can be signed with.
+	LocalPKI bool
 }
 
 type certsService struct {
@@ -74,6 +109,10 @@ type certsService struct {
 	sdk       mfsdk.SDK
 	conf      Config
 	pki       pki.Agent
+
+	crlMu         sync.Mutex
+	crl           []byte
+	crlNextUpdate time.Time
 }
 
 // New returns new Certs service.
@@ -111,6 +150,10 @@ func (cs *certsService) IssueCert(ctx context.Context, token, thingID string, tt
 		return Cert{}, err
 	}
 
+	if err := cs.authorizeScope(ctx, token, auth.WriteAction, thingID); err != nil {
+		return Cert{}, err
+	}
+
 	thing, err := cs.sdk.Thing(thingID, token)
 	if err != nil {
 		return Cert{}, errors.Wrap(ErrFailedCertCreation, err)
@@ -143,6 +186,11 @@ func (cs *certsService) RevokeCert(ctx context.Context, token, thingID string) (
 	if err != nil {
 		return revoke, err
 	}
+
+	if err := cs.authorizeScope(ctx, token, auth.WriteAction, thingID); err != nil {
+		return revoke, err
+	}
+
 	thing, err := cs.sdk.Thing(thingID, token)
 	if err != nil {
 		return revoke, errors.Wrap(ErrFailedCertRevocation, err)
@@ -161,11 +209,34 @@ func (cs *certsService) RevokeCert(ctx context.Context, token, thingID string) (
 			return revoke, errors.Wrap(ErrFailedCertRevocation, err)
 		}
 		revoke.RevocationTime = revTime
+
+		rc := RevokedCert{
+			Serial:         c.Serial,
+			ThingID:        c.ThingID,
+			RevocationTime: revTime,
+			Expire:         c.Expire,
+		}
+		if err := cs.certsRepo.SaveRevoked(ctx, rc); err != nil {
+			return revoke, errors.Wrap(ErrFailedCertRevocation, err)
+		}
+
 		if err = cs.certsRepo.Remove(context.Background(), u.GetId(), c.Serial); err != nil {
 			return revoke, errors.Wrap(errFailedToRemoveCertFromDB, err)
 		}
 	}
 
+	if !cs.conf.LocalPKI {
+		return revoke, nil
+	}
+
+	// Revocation is already stored, so failing to regenerate the CRL here
+	// only defers it to the next CRL request.
+	cs.crlMu.Lock()
+	if err := cs.updateCRL(ctx); err != nil {
+		cs.crl = nil
+	}
+	cs.crlMu.Unlock()
+
 	return revoke, nil
 }
 
@@ -175,6 +246,10 @@ func (cs *certsService) ListCerts(ctx context.Context, token, thingID string, of
 		return Page{}, err
 	}
 
+	if err := cs.authorizeScope(ctx, token, auth.ReadAction, thingID); err != nil {
+		return Page{}, err
+	}
+
 	cp, err := cs.certsRepo.RetrieveByThing(ctx, u.GetId(), thingID, offset, limit)
 	if err != nil {
 		return Page{}, err
@@ -198,6 +273,10 @@ func (cs *certsService) ListSerials(ctx context.Context, token, thingID string,
 		return Page{}, err
 	}
 
+	if err := cs.authorizeScope(ctx, token, auth.ReadAction, thingID); err != nil {
+		return Page{}, err
+	}
+
 	return cs.certsRepo.RetrieveByThing(ctx, u.GetId(), thingID, offset, limit)
 }
 
@@ -212,6 +291,10 @@ func (cs *certsService) ViewCert(ctx context.Context, token, serialID string) (C
 		return Cert{}, err
 	}
 
+	if err := cs.authorizeScope(ctx, token, auth.ReadAction, cert.ThingID); err != nil {
+		return Cert{}, err
+	}
+
 	vcert, err := cs.pki.Read(serialID)
 	if err != nil {
 		return Cert{}, err
@@ -226,3 +309,180 @@ func (cs *certsService) ViewCert(ctx context.Context, token, serialID string) (C
 
 	return c, nil
 }
+
+func (cs *certsService) CRL(ctx context.Context) ([]byte, error) {
+	if !cs.conf.LocalPKI {
+		return nil, ErrRevocationUnavailable
+	}
+
+	cs.crlMu.Lock()
+	defer cs.crlMu.Unlock()
+
+	// Regenerate the CRL well before it expires, so clients never see a stale one.
+	if cs.crl == nil || time.Now().After(cs.crlNextUpdate.Add(-crlValidity/2)) {
+		if err := cs.updateCRL(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return cs.crl, nil
+}
+
+func (cs *certsService) OCSP(ctx context.Context, req []byte) ([]byte, error) {
+	if !cs.conf.LocalPKI {
+		return nil, ErrRevocationUnavailable
+	}
+
+	ca, signer, err := cs.signer()
+	if err != nil {
+		return nil, errors.Wrap(ErrFailedOCSPResponse, err)
+	}
+
+	// OCSP protocol errors are reported to the client in the response itself.
+	ocspReq, err := ocsp.ParseRequest(req)
+	if err != nil {
+		return ocsp.MalformedRequestErrorResponse, nil
+	}
+	if !issuedBy(ocspReq, ca) {
+		return ocsp.UnauthorizedErrorResponse, nil
+	}
+
+	status, err := cs.certsRepo.RetrieveStatus(ctx, pki.FormatSerial(ocspReq.SerialNumber))
+	if err != nil {
+		return nil, errors.Wrap(ErrFailedOCSPResponse, err)
+	}
+
+	now := time.Now()
+	tmpl := ocsp.Response{
+		SerialNumber: ocspReq.SerialNumber,
+		ThisUpdate:   now,
+		NextUpdate:   now.Add(ocspValidity),
+	}
+	switch status.Status {
+	case StatusGood:
+		tmpl.Status = ocsp.Good
+	case StatusRevoked:
+		tmpl.Status = ocsp.Revoked
+		tmpl.RevokedAt = status.RevocationTime
+		tmpl.RevocationReason = ocsp.Unspecified
+	default:
+		tmpl.Status = ocsp.Unknown
+	}
+
+	res, err := ocsp.CreateResponse(ca, ca, tmpl, signer)
+	if err != nil {
+		return nil, errors.Wrap(ErrFailedOCSPResponse, err)
+	}
+
+	return res, nil
+}
+
+// updateCRL regenerates the CRL, and must be called with crlMu held.
+func (cs *certsService) updateCRL(ctx context.Context) error {
+	ca, signer, err := cs.signer()
+	if err != nil {
+		return errors.Wrap(ErrFailedCRLCreation, err)
+	}
+
+	revoked, err := cs.certsRepo.RetrieveRevoked(ctx)
+	if err != nil {
+		return errors.Wrap(ErrFailedCRLCreation, err)
+	}
+
+	now := time.Now()
+	var entries []pkix.RevokedCertificate
+	for _, rc := range revoked {
+		// Expired certificates are invalid anyway, so listing them would
+		// only grow the CRL.
+		if rc.Expire.Before(now) {
+			continue
+		}
+		serial, err := parseSerial(rc.Serial)
+		if err != nil {
+			return errors.Wrap(ErrFailedCRLCreation, err)
+		}
+		entries = append(entries, pkix.RevokedCertificate{
+			SerialNumber:   serial,
+			RevocationTime: rc.RevocationTime,
+		})
+	}
+
+	tmpl := x509.RevocationList{
+		// CRL number must increase with every issued CRL.
+		Number:              big.NewInt(now.UnixNano()),
+		ThisUpdate:          now,
+		NextUpdate:          now.Add(crlValidity),
+		RevokedCertificates: entries,
+	}
+
+	// CA without key usage extension is not restricted to certificate signing.
+	issuer := *ca
+	if issuer.KeyUsage == 0 {
+		issuer.KeyUsage = x509.KeyUsageCRLSign
+	}
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &tmpl, &issuer, signer)
+	if err != nil {
+		return errors.Wrap(ErrFailedCRLCreation, err)
+	}
+
+	cs.crl = crl
+	cs.crlNextUpdate = tmpl.NextUpdate
+
+	return nil
+}
+
+func (cs *certsService) signer() (*x509.Certificate, crypto.Signer, error) {
+	signer, ok := cs.conf.SignTLSCert.PrivateKey.(crypto.Signer)
+	if cs.conf.SignX509Cert == nil || !ok {
+		return nil, nil, errMissingCA
+	}
+
+	return cs.conf.SignX509Cert, signer, nil
+}
+
+// issuedBy checks whether the OCSP request refers to the certificate issued by the CA.
+func issuedBy(req *ocsp.Request, ca *x509.Certificate) bool {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(ca.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return false
+	}
+	if !req.HashAlgorithm.Available() {
+		return false
+	}
+
+	h := req.HashAlgorithm.New()
+	h.Write(spki.PublicKey.RightAlign())
+
+	return string(h.Sum(nil)) == string(req.IssuerKeyHash)
+}
+
+// parseSerial parses the serial formatted as colon separated hex encoded bytes.
+func parseSerial(serial string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(strings.ReplaceAll(serial, ":", ""), 16)
+	if !ok {
+		return nil, errInvalidSerial
+	}
+
+	return n, nil
+}
+
+// authorizeScope checks whether the token scopes allow the action on the
+// thing the certificates are issued for.
+func (cs *certsService) authorizeScope(ctx context.Context, token, action, thingID string) error {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: auth.ThingsSubject,
+		Object:  thingID,
+		Action:  action,
+	}
+
+	if _, err := cs.auth.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"net/http/httptest"
 	"os"
 	"strconv"
@@ -31,6 +32,7 @@ import (
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
 )
 
 const (
@@ -63,7 +65,7 @@ const (
 
 var usersList = []users.User{{Email: email, Password: password}}
 
-func newService() (certs.Service, error) {
+func newService(localPKI bool) (certs.Service, error) {
 	auth := mocks.NewAuthService("", usersList)
 	ac := auth
 	server := newThingsServer(newThingsService(ac))
@@ -96,6 +98,7 @@ func newService() (certs.Service, error) {
 		SignX509Cert:   caCert,
 		SignHoursValid: cfgSignHoursValid,
 		SignRSABits:    cfgSignRSABits,
+		LocalPKI:       localPKI,
 	}
 
 	pki := ctmocks.NewPkiAgent(tlsCert, caCert, cfgSignRSABits, cfgSignHoursValid, authTimeout)
@@ -118,7 +121,7 @@ func newThingsService(auth mainflux.AuthServiceClient) things.Service {
 }
 
 func TestIssueCert(t *testing.T) {
-	svc, err := newService()
+	svc, err := newService(true)
 	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
 
 	cases := []struct {
@@ -189,7 +192,7 @@ func TestIssueCert(t *testing.T) {
 }
 
 func TestRevokeCert(t *testing.T) {
-	svc, err := newService()
+	svc, err := newService(true)
 	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
 
 	_, err = svc.IssueCert(context.Background(), token, thingID, ttl, keyBits, key)
@@ -229,7 +232,7 @@ func TestRevokeCert(t *testing.T) {
 }
 
 func TestListCerts(t *testing.T) {
-	svc, err := newService()
+	svc, err := newService(true)
 	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
 
 	for i := 0; i < certNum; i++ {
@@ -293,7 +296,7 @@ func TestListCerts(t *testing.T) {
 }
 
 func TestListSerials(t *testing.T) {
-	svc, err := newService()
+	svc, err := newService(true)
 	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
 
 	var issuedCerts []certs.Cert
@@ -365,7 +368,7 @@ func TestListSerials(t *testing.T) {
 }
 
 func TestViewCert(t *testing.T) {
-	svc, err := newService()
+	svc, err := newService(true)
 	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
 
 	ic, err := svc.IssueCert(context.Background(), token, thingID, ttl, keyBits, key)
@@ -415,6 +418,121 @@ func TestViewCert(t *testing.T) {
 	}
 }
 
+func TestCRL(t *testing.T) {
+	svc, err := newService(true)
+	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
+
+	_, caCert, err := loadCertificates(caPath, caKeyPath)
+	require.Nil(t, err, fmt.Sprintf("unexpected CA loading error: %s\n", err))
+
+	ic, err := svc.IssueCert(context.Background(), token, thingID, ttl, keyBits, key)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert creation error: %s\n", err))
+	issued, err := readCert([]byte(ic.ClientCert))
+	require.Nil(t, err, fmt.Sprintf("unexpected cert parsing error: %s\n", err))
+
+	der, err := svc.CRL(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected CRL error: %s\n", err))
+	crl, err := x509.ParseRevocationList(der)
+	require.Nil(t, err, fmt.Sprintf("unexpected CRL parsing error: %s\n", err))
+	assert.Nil(t, crl.CheckSignatureFrom(caCert), "expected CRL to be signed by CA")
+	assert.Empty(t, crl.RevokedCertificates, fmt.Sprintf("expected empty CRL got %v\n", crl.RevokedCertificates))
+
+	_, err = svc.RevokeCert(context.Background(), token, thingID)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert revocation error: %s\n", err))
+
+	der, err = svc.CRL(context.Background())
+	require.Nil(t, err, fmt.Sprintf("unexpected CRL error: %s\n", err))
+	crl, err = x509.ParseRevocationList(der)
+	require.Nil(t, err, fmt.Sprintf("unexpected CRL parsing error: %s\n", err))
+	require.Len(t, crl.RevokedCertificates, 1, fmt.Sprintf("expected 1 revoked cert got %d\n", len(crl.RevokedCertificates)))
+	assert.Equal(t, issued.SerialNumber, crl.RevokedCertificates[0].SerialNumber, fmt.Sprintf("expected serial %s got %s\n", issued.SerialNumber, crl.RevokedCertificates[0].SerialNumber))
+}
+
+func TestOCSP(t *testing.T) {
+	svc, err := newService(true)
+	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
+
+	_, caCert, err := loadCertificates(caPath, caKeyPath)
+	require.Nil(t, err, fmt.Sprintf("unexpected CA loading error: %s\n", err))
+
+	ic, err := svc.IssueCert(context.Background(), token, thingID, ttl, keyBits, key)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert creation error: %s\n", err))
+	issued, err := readCert([]byte(ic.ClientCert))
+	require.Nil(t, err, fmt.Sprintf("unexpected cert parsing error: %s\n", err))
+
+	req, err := ocsp.CreateRequest(issued, caCert, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected OCSP request creation error: %s\n", err))
+
+	unknown := *issued
+	unknown.SerialNumber = big.NewInt(42)
+	unknownReq, err := ocsp.CreateRequest(&unknown, caCert, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected OCSP request creation error: %s\n", err))
+
+	cases := []struct {
+		desc   string
+		req    []byte
+		revoke bool
+		status int
+	}{
+		{
+			desc:   "check status of issued cert",
+			req:    req,
+			status: ocsp.Good,
+		},
+		{
+			desc:   "check status of unknown cert",
+			req:    unknownReq,
+			status: ocsp.Unknown,
+		},
+		{
+			desc:   "check status of revoked cert",
+			req:    req,
+			revoke: true,
+			status: ocsp.Revoked,
+		},
+	}
+
+	for _, tc := range cases {
+		if tc.revoke {
+			_, err := svc.RevokeCert(context.Background(), token, thingID)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected cert revocation error: %s\n", tc.desc, err))
+		}
+		der, err := svc.OCSP(context.Background(), tc.req)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected OCSP error: %s\n", tc.desc, err))
+		res, err := ocsp.ParseResponse(der, caCert)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected OCSP response parsing error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %d got %d\n", tc.desc, tc.status, res.Status))
+	}
+
+	der, err := svc.OCSP(context.Background(), []byte(wrongValue))
+	assert.Nil(t, err, fmt.Sprintf("malformed OCSP request: expected no error got %s\n", err))
+	assert.Equal(t, ocsp.MalformedRequestErrorResponse, der, "malformed OCSP request: expected malformed request response")
+}
+
+func TestRevocationWithoutLocalPKI(t *testing.T) {
+	svc, err := newService(false)
+	require.Nil(t, err, fmt.Sprintf("unexpected service creation error: %s\n", err))
+
+	_, caCert, err := loadCertificates(caPath, caKeyPath)
+	require.Nil(t, err, fmt.Sprintf("unexpected CA loading error: %s\n", err))
+
+	ic, err := svc.IssueCert(context.Background(), token, thingID, ttl, keyBits, key)
+	require.Nil(t, err, fmt.Sprintf("unexpected cert creation error: %s\n", err))
+	issued, err := readCert([]byte(ic.ClientCert))
+	require.Nil(t, err, fmt.Sprintf("unexpected cert parsing error: %s\n", err))
+
+	_, err = svc.RevokeCert(context.Background(), token, thingID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected cert revocation error: %s\n", err))
+
+	_, err = svc.CRL(context.Background())
+	assert.True(t, errors.Contains(err, certs.ErrRevocationUnavailable), fmt.Sprintf("CRL: expected %s got %s\n", certs.ErrRevocationUnavailable, err))
+
+	req, err := ocsp.CreateRequest(issued, caCert, nil)
+	require.Nil(t, err, fmt.Sprintf("unexpected OCSP request creation error: %s\n", err))
+	_, err = svc.OCSP(context.Background(), req)
+	assert.True(t, errors.Contains(err, certs.ErrRevocationUnavailable), fmt.Sprintf("OCSP: expected %s got %s\n", certs.ErrRevocationUnavailable, err))
+}
+
 func newThingsServer(svc things.Service) *httptest.Server {
 	logger := logger.NewMock()
 	mux := httpapi.MakeHandler(mocktracer.New(), svc, logger)
@@ -4,8 +4,10 @@
 package cli
 
 import (
+	"encoding/json"
 	"time"
 
+	mfxsdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
 	"github.com/spf13/cobra"
 )
 
@@ -72,14 +74,61 @@ var cmdAPIKeys = []cobra.Command{
 			logJSON(rk)
 		},
 	},
+	{
+		Use:   "list <user_auth_token>",
+		Short: "List keys",
+		Long:  `Lists API keys issued by the user`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			pm := mfxsdk.KeysPageMetadata{
+				Offset: uint64(Offset),
+				Limit:  uint64(Limit),
+			}
+			kp, err := sdk.Keys(args[0], pm)
+			if err != nil {
+				logError(err)
+				return
+			}
+
+			logJSON(kp)
+		},
+	},
+	{
+		Use:   "revoke-many <JSON_key_ids> <user_auth_token>",
+		Short: "Revoke keys",
+		Long:  `Removes API keys with given ids from database`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsage(cmd.Use)
+				return
+			}
+
+			var ids []string
+			if err := json.Unmarshal([]byte(args[0]), &ids); err != nil {
+				logError(err)
+				return
+			}
+
+			if err := sdk.RevokeKeys(args[1], ids); err != nil {
+				logError(err)
+				return
+			}
+
+			logOK()
+		},
+	},
 }
 
 // NewKeysCmd returns keys command.
 func NewKeysCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "keys [issue | revoke | retrieve]",
+		Use:   "keys [issue | revoke | retrieve | list | revoke-many]",
 		Short: "Keys management",
-		Long:  `Keys management: issue, revoke, or retrieve API key.`,
+		Long:  `Keys management: issue, revoke, retrieve or list API keys.`,
 	}
 
 	for i := range cmdAPIKeys {
@@ -185,14 +185,14 @@ var cmdThings = []cobra.Command{
 	{
 		Use:   "connections <thing_id> <user_auth_token>",
 		Short: "Connected list",
-		Long:  `Retrieve Channel connected to Thing`,
+		Long:  `List of Channels connected to a Thing`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if len(args) != 2 {
 				logUsage(cmd.Use)
 				return
 			}
 
-			cl, err := sdk.ViewChannelByThing(args[1], args[0])
+			cl, err := sdk.ChannelsByThing(args[1], args[0], uint64(Offset), uint64(Limit))
 			if err != nil {
 				logError(err)
 				return
@@ -0,0 +1,323 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/audit/api"
+	httpapi "github.com/MainfluxLabs/mainflux/audit/api/http"
+	"github.com/MainfluxLabs/mainflux/audit/postgres"
+	"github.com/MainfluxLabs/mainflux/audit/redis/consumer"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	svcName      = "audit"
+	stopWaitTime = 5 * time.Second
+
+	defLogLevel        = "error"
+	defHTTPPort        = "9028"
+	defClientTLS       = "false"
+	defCACerts         = ""
+	defDBHost          = "localhost"
+	defDBPort          = "5432"
+	defDBUser          = "mainflux"
+	defDBPass          = "mainflux"
+	defDB              = "audit"
+	defDBSSLMode       = "disable"
+	defDBSSLCert       = ""
+	defDBSSLKey        = ""
+	defDBSSLRootCert   = ""
+	defJaegerURL       = ""
+	defESURL           = "localhost:6379"
+	defESPass          = ""
+	defESDB            = "0"
+	defESConsumerName  = "audit"
+	defAuthGRPCURL     = "localhost:8181"
+	defAuthGRPCTimeout = "1s"
+
+	envLogLevel        = "MF_AUDIT_LOG_LEVEL"
+	envHTTPPort        = "MF_AUDIT_HTTP_PORT"
+	envClientTLS       = "MF_AUDIT_CLIENT_TLS"
+	envCACerts         = "MF_AUDIT_CA_CERTS"
+	envDBHost          = "MF_AUDIT_DB_HOST"
+	envDBPort          = "MF_AUDIT_DB_PORT"
+	envDBUser          = "MF_AUDIT_DB_USER"
+	envDBPass          = "MF_AUDIT_DB_PASS"
+	envDB              = "MF_AUDIT_DB"
+	envDBSSLMode       = "MF_AUDIT_DB_SSL_MODE"
+	envDBSSLCert       = "MF_AUDIT_DB_SSL_CERT"
+	envDBSSLKey        = "MF_AUDIT_DB_SSL_KEY"
+	envDBSSLRootCert   = "MF_AUDIT_DB_SSL_ROOT_CERT"
+	envJaegerURL       = "MF_JAEGER_URL"
+	envESURL           = "MF_AUDIT_ES_URL"
+	envESPass          = "MF_AUDIT_ES_PASS"
+	envESDB            = "MF_AUDIT_ES_DB"
+	envESConsumerName  = "MF_AUDIT_EVENT_CONSUMER"
+	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+)
+
+type config struct {
+	logLevel        string
+	httpPort        string
+	clientTLS       bool
+	caCerts         string
+	dbConfig        postgres.Config
+	jaegerURL       string
+	esURL           string
+	esPass          string
+	esDB            string
+	esConsumerName  string
+	authGRPCURL     string
+	authGRPCTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
+
+	esClient := connectToRedis(cfg.esURL, cfg.esPass, cfg.esDB, logger)
+	defer esClient.Close()
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToGRPC(cfg, cfg.authGRPCURL, "auth", logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+
+	svc := newService(auth, db, logger)
+
+	auditTracer, auditCloser := initJaeger(svcName, cfg.jaegerURL, logger)
+	defer auditCloser.Close()
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, httpapi.MakeHandler(auditTracer, svc, logger), cfg.httpPort, logger)
+	})
+
+	g.Go(func() error {
+		return subscribeToES(ctx, svc, esClient, cfg.esConsumerName, logger)
+	})
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("Audit service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("Audit service terminated: %s", err))
+	}
+}
+
+func loadConfig() config {
+	dbConfig := postgres.Config{
+		Host:        mainflux.Env(envDBHost, defDBHost),
+		Port:        mainflux.Env(envDBPort, defDBPort),
+		User:        mainflux.Env(envDBUser, defDBUser),
+		Pass:        mainflux.Env(envDBPass, defDBPass),
+		Name:        mainflux.Env(envDB, defDB),
+		SSLMode:     mainflux.Env(envDBSSLMode, defDBSSLMode),
+		SSLCert:     mainflux.Env(envDBSSLCert, defDBSSLCert),
+		SSLKey:      mainflux.Env(envDBSSLKey, defDBSSLKey),
+		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	return config{
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
+		clientTLS:       tls,
+		caCerts:         mainflux.Env(envCACerts, defCACerts),
+		dbConfig:        dbConfig,
+		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
+		esURL:           mainflux.Env(envESURL, defESURL),
+		esPass:          mainflux.Env(envESPass, defESPass),
+		esDB:            mainflux.Env(envESDB, defESDB),
+		esConsumerName:  mainflux.Env(envESConsumerName, defESConsumerName),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+	}
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToDB(dbConfig postgres.Config, logger logger.Logger) *sqlx.DB {
+	db, err := postgres.Connect(dbConfig)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to Postgres: %s", err))
+		os.Exit(1)
+	}
+
+	return db
+}
+
+func connectToRedis(url, pass, db string, logger logger.Logger) *redis.Client {
+	n, err := strconv.Atoi(db)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to event store: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     url,
+		Password: pass,
+		DB:       n,
+	})
+}
+
+func connectToGRPC(cfg config, url, name string, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(url, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to %s service: %s", name, err))
+		os.Exit(1)
+	}
+
+	logger.Info(fmt.Sprintf("Established gRPC connection to %s via gRPC: %s", name, url))
+	return conn
+}
+
+func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger) audit.Service {
+	database := postgres.NewDatabase(db)
+	recordsRepo := postgres.NewRecordRepository(database)
+	idProvider := uuid.New()
+
+	svc := audit.New(ac, recordsRepo, idProvider)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "audit",
+			Subsystem: "api",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "audit",
+			Subsystem: "api",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
+
+func subscribeToES(ctx context.Context, svc audit.Service, client *redis.Client, name string, logger logger.Logger) error {
+	es := consumer.NewEventStore(svc, client, name, logger)
+	logger.Info("Subscribed to Redis Event Store")
+
+	if err := es.Subscribe(ctx); err != nil && ctx.Err() == nil {
+		return fmt.Errorf("audit service failed to subscribe to event sourcing: %w", err)
+	}
+
+	return nil
+}
+
+func startHTTPServer(ctx context.Context, handler http.Handler, port string, logger logger.Logger) error {
+	p := fmt.Sprintf(":%s", port)
+	errCh := make(chan error)
+	server := &http.Server{Addr: p, Handler: handler}
+
+	logger.Info(fmt.Sprintf("Audit service started using http, exposed port %s", port))
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		ctxShutdown, cancelShutdown := context.WithTimeout(context.Background(), stopWaitTime)
+		defer cancelShutdown()
+		if err := server.Shutdown(ctxShutdown); err != nil {
+			logger.Error(fmt.Sprintf("Audit service error occurred during shutdown at %s: %s", p, err))
+			return fmt.Errorf("audit service occurred during shutdown at %s: %w", p, err)
+		}
+		logger.Info(fmt.Sprintf("Audit service shutdown of http at %s", p))
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	auditproducer "github.com/MainfluxLabs/mainflux/audit/redis/producer"
 	"github.com/MainfluxLabs/mainflux/auth"
 	api "github.com/MainfluxLabs/mainflux/auth/api"
 	grpcapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
@@ -21,11 +23,13 @@ import (
 	"github.com/MainfluxLabs/mainflux/auth/postgres"
 	"github.com/MainfluxLabs/mainflux/auth/tracing"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	usersapi "github.com/MainfluxLabs/mainflux/users/api/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -40,57 +44,73 @@ const (
 	httpProtocol  = "http"
 	httpsProtocol = "https"
 
-	defLogLevel        = "error"
-	defDBHost          = "localhost"
-	defDBPort          = "5432"
-	defDBUser          = "mainflux"
-	defDBPass          = "mainflux"
-	defDB              = "auth"
-	defDBSSLMode       = "disable"
-	defDBSSLCert       = ""
-	defDBSSLKey        = ""
-	defDBSSLRootCert   = ""
-	defHTTPPort        = "8180"
-	defGRPCPort        = "8181"
-	defSecret          = "auth"
-	defServerCert      = ""
-	defServerKey       = ""
-	defJaegerURL       = ""
-	defLoginDuration   = "10h"
-	defAdminEmail      = ""
-	defTimeout         = "1s"
-	defThingsGRPCURL   = "localhost:8183"
-	defThingsCACerts   = ""
-	defThingsClientTLS = "false"
-	defUsersCACerts    = ""
-	defUsersClientTLS  = "false"
-	defUsersGRPCURL    = "localhost:8184"
-
-	envLogLevel        = "MF_AUTH_LOG_LEVEL"
-	envDBHost          = "MF_AUTH_DB_HOST"
-	envDBPort          = "MF_AUTH_DB_PORT"
-	envDBUser          = "MF_AUTH_DB_USER"
-	envDBPass          = "MF_AUTH_DB_PASS"
-	envDB              = "MF_AUTH_DB"
-	envDBSSLMode       = "MF_AUTH_DB_SSL_MODE"
-	envDBSSLCert       = "MF_AUTH_DB_SSL_CERT"
-	envDBSSLKey        = "MF_AUTH_DB_SSL_KEY"
-	envDBSSLRootCert   = "MF_AUTH_DB_SSL_ROOT_CERT"
-	envHTTPPort        = "MF_AUTH_HTTP_PORT"
-	envGRPCPort        = "MF_AUTH_GRPC_PORT"
-	envTimeout         = "MF_AUTH_GRPC_TIMEOUT"
-	envSecret          = "MF_AUTH_SECRET"
-	envServerCert      = "MF_AUTH_SERVER_CERT"
-	envServerKey       = "MF_AUTH_SERVER_KEY"
-	envJaegerURL       = "MF_JAEGER_URL"
-	envLoginDuration   = "MF_AUTH_LOGIN_TOKEN_DURATION"
-	envAdminEmail      = "MF_USERS_ADMIN_EMAIL"
-	envThingsGRPCURL   = "MF_THINGS_AUTH_GRPC_URL"
-	envThingsCACerts   = "MF_THINGS_CA_CERTS"
-	envThingsClientTLS = "MF_THINGS_CLIENT_TLS"
-	envUsersGRPCURL    = "MF_USERS_GRPC_URL"
-	envUsersCACerts    = "MF_USERS_CA_CERTS"
-	envUsersClientTLS  = "MF_USERS_CLIENT_TLS"
+	defLogLevel         = "error"
+	defDBHost           = "localhost"
+	defDBPort           = "5432"
+	defDBUser           = "mainflux"
+	defDBPass           = "mainflux"
+	defDB               = "auth"
+	defDBSSLMode        = "disable"
+	defDBSSLCert        = ""
+	defDBSSLKey         = ""
+	defDBSSLRootCert    = ""
+	defHTTPPort         = "8180"
+	defGRPCPort         = "8181"
+	defSecret           = "auth"
+	defJWTAlgorithm     = jwt.HS256
+	defJWTRotation      = "720h"
+	defJWTRetention     = "2160h"
+	defJWTMasterKey     = ""
+	defJWTOldMasterKeys = ""
+	defServerCert       = ""
+	defServerKey        = ""
+	defJaegerURL        = ""
+	defLoginDuration    = "10h"
+	defAdminEmail       = ""
+	defTimeout          = "1s"
+	defThingsGRPCURL    = "localhost:8183"
+	defThingsCACerts    = ""
+	defThingsClientTLS  = "false"
+	defUsersCACerts     = ""
+	defUsersClientTLS   = "false"
+	defUsersGRPCURL     = "localhost:8184"
+	defAuditESURL       = ""
+	defAuditESPass      = ""
+	defAuditESDB        = "0"
+
+	envLogLevel         = "MF_AUTH_LOG_LEVEL"
+	envDBHost           = "MF_AUTH_DB_HOST"
+	envDBPort           = "MF_AUTH_DB_PORT"
+	envDBUser           = "MF_AUTH_DB_USER"
+	envDBPass           = "MF_AUTH_DB_PASS"
+	envDB               = "MF_AUTH_DB"
+	envDBSSLMode        = "MF_AUTH_DB_SSL_MODE"
+	envDBSSLCert        = "MF_AUTH_DB_SSL_CERT"
+	envDBSSLKey         = "MF_AUTH_DB_SSL_KEY"
+	envDBSSLRootCert    = "MF_AUTH_DB_SSL_ROOT_CERT"
+	envHTTPPort         = "MF_AUTH_HTTP_PORT"
+	envGRPCPort         = "MF_AUTH_GRPC_PORT"
+	envTimeout          = "MF_AUTH_GRPC_TIMEOUT"
+	envSecret           = "MF_AUTH_SECRET"
+	envJWTAlgorithm     = "MF_AUTH_JWT_ALGORITHM"
+	envJWTRotation      = "MF_AUTH_JWT_ROTATION"
+	envJWTRetention     = "MF_AUTH_JWT_RETENTION"
+	envJWTMasterKey     = "MF_AUTH_JWT_MASTER_KEY"
+	envJWTOldMasterKeys = "MF_AUTH_JWT_OLD_MASTER_KEYS"
+	envServerCert       = "MF_AUTH_SERVER_CERT"
+	envServerKey        = "MF_AUTH_SERVER_KEY"
+	envJaegerURL        = "MF_JAEGER_URL"
+	envLoginDuration    = "MF_AUTH_LOGIN_TOKEN_DURATION"
+	envAdminEmail       = "MF_USERS_ADMIN_EMAIL"
+	envThingsGRPCURL    = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsCACerts    = "MF_THINGS_CA_CERTS"
+	envThingsClientTLS  = "MF_THINGS_CLIENT_TLS"
+	envUsersGRPCURL     = "MF_USERS_GRPC_URL"
+	envUsersCACerts     = "MF_USERS_CA_CERTS"
+	envUsersClientTLS   = "MF_USERS_CLIENT_TLS"
+	envAuditESURL       = "MF_AUDIT_ES_URL"
+	envAuditESPass      = "MF_AUDIT_ES_PASS"
+	envAuditESDB        = "MF_AUDIT_ES_DB"
 )
 
 type config struct {
@@ -99,6 +119,8 @@ type config struct {
 	httpPort        string
 	grpcPort        string
 	secret          string
+	jwtConfig       jwt.Config
+	jwtKeyring      encryption.Keyring
 	serverCert      string
 	serverKey       string
 	jaegerURL       string
@@ -111,6 +133,9 @@ type config struct {
 	usersClientTLS  bool
 	usersCACerts    string
 	usersGRPCURL    string
+	auditESURL      string
+	auditESPass     string
+	auditESDB       string
 }
 
 func main() {
@@ -147,7 +172,14 @@ func main() {
 
 	tc := thingsapi.NewClient(thConn, thingsTracer, cfg.timeout)
 
-	svc := newService(db, tc, uc, dbTracer, cfg.secret, logger, cfg.loginDuration)
+	var auditPub audit.Publisher
+	if cfg.auditESURL != "" {
+		auditClient := connectToRedis(cfg.auditESURL, cfg.auditESPass, cfg.auditESDB, logger)
+		defer auditClient.Close()
+		auditPub = auditproducer.NewPublisher(auditClient)
+	}
+
+	svc := newService(db, tc, uc, dbTracer, auditPub, cfg, logger)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
@@ -201,12 +233,39 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
 	}
 
+	jwtRotation, err := time.ParseDuration(mainflux.Env(envJWTRotation, defJWTRotation))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envJWTRotation, err.Error())
+	}
+
+	jwtRetention, err := time.ParseDuration(mainflux.Env(envJWTRetention, defJWTRetention))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envJWTRetention, err.Error())
+	}
+
+	jwtConfig := jwt.Config{
+		Algorithm: mainflux.Env(envJWTAlgorithm, defJWTAlgorithm),
+		Rotation:  jwtRotation,
+		Retention: jwtRetention,
+	}
+
+	// Signing private keys are stored wrapped by the master key.
+	var jwtKeyring encryption.Keyring
+	if jwtConfig.Algorithm != jwt.HS256 {
+		jwtKeyring, err = encryption.ParseKeyring(mainflux.Env(envJWTMasterKey, defJWTMasterKey), mainflux.Env(envJWTOldMasterKeys, defJWTOldMasterKeys))
+		if err != nil {
+			log.Fatalf("Invalid %s or %s value: %s", envJWTMasterKey, envJWTOldMasterKeys, err.Error())
+		}
+	}
+
 	return config{
 		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
 		dbConfig:        dbConfig,
 		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
 		grpcPort:        mainflux.Env(envGRPCPort, defGRPCPort),
 		secret:          mainflux.Env(envSecret, defSecret),
+		jwtConfig:       jwtConfig,
+		jwtKeyring:      jwtKeyring,
 		serverCert:      mainflux.Env(envServerCert, defServerCert),
 		serverKey:       mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
@@ -219,6 +278,9 @@ func loadConfig() config {
 		usersClientTLS:  usersClientTLS,
 		usersCACerts:    mainflux.Env(envUsersCACerts, defUsersCACerts),
 		usersGRPCURL:    mainflux.Env(envUsersGRPCURL, defUsersGRPCURL),
+		auditESURL:      mainflux.Env(envAuditESURL, defAuditESURL),
+		auditESPass:     mainflux.Env(envAuditESPass, defAuditESPass),
+		auditESDB:       mainflux.Env(envAuditESDB, defAuditESDB),
 	}
 
 }
@@ -307,7 +369,21 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(db *sqlx.DB, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, tracer opentracing.Tracer, secret string, logger logger.Logger, duration time.Duration) auth.Service {
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func newService(db *sqlx.DB, tc mainflux.ThingsServiceClient, uc mainflux.UsersServiceClient, tracer opentracing.Tracer, auditPub audit.Publisher, cfg config, logger logger.Logger) auth.Service {
 	orgsRepo := postgres.NewOrgRepo(db)
 	orgsRepo = tracing.OrgRepositoryMiddleware(tracer, orgsRepo)
 
@@ -321,9 +397,12 @@ func newService(db *sqlx.DB, tc mainflux.ThingsServiceClient, uc mainflux.UsersS
 	policiesRepo = tracing.PoliciesRepositoryMiddleware(tracer, policiesRepo)
 
 	idProvider := uuid.New()
-	t := jwt.New(secret)
+	t := newTokenizer(database, idProvider, cfg, logger)
 
-	svc := auth.New(orgsRepo, tc, uc, keysRepo, rolesRepo, policiesRepo, idProvider, t, duration)
+	svc := auth.New(orgsRepo, tc, uc, keysRepo, rolesRepo, policiesRepo, idProvider, t, cfg.loginDuration)
+	if auditPub != nil {
+		svc = api.AuditMiddleware(svc, auditPub, logger)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -344,6 +423,20 @@ func newService(db *sqlx.DB, tc mainflux.ThingsServiceClient, uc mainflux.UsersS
 	return svc
 }
 
+func newTokenizer(db postgres.Database, idp mainflux.IDProvider, cfg config, logger logger.Logger) auth.Tokenizer {
+	if cfg.jwtConfig.Algorithm == jwt.HS256 {
+		return jwt.New(cfg.secret)
+	}
+
+	t, err := jwt.NewAsymmetric(cfg.jwtConfig, postgres.NewSigningKeyRepository(db, cfg.jwtKeyring), idp)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create %s tokenizer: %s", cfg.jwtConfig.Algorithm, err))
+		os.Exit(1)
+	}
+
+	return t
+}
+
 func startHTTPServer(ctx context.Context, tracer opentracing.Tracer, svc auth.Service, port string, certFile string, keyFile string, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	server := &http.Server{Addr: p, Handler: httpapi.MakeHandler(svc, tracer, logger)}
@@ -16,6 +16,8 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	auditproducer "github.com/MainfluxLabs/mainflux/audit/redis/producer"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/bootstrap"
 	api "github.com/MainfluxLabs/mainflux/bootstrap/api"
@@ -65,6 +67,9 @@ const (
 	defESPass          = ""
 	defESDB            = "0"
 	defESConsumerName  = "bootstrap"
+	defAuditESURL      = ""
+	defAuditESPass     = ""
+	defAuditESDB       = "0"
 	defJaegerURL       = ""
 	defAuthGRPCURL     = "localhost:8181"
 	defAuthGRPCTimeout = "1s"
@@ -93,6 +98,9 @@ const (
 	envESPass          = "MF_BOOTSTRAP_ES_PASS"
 	envESDB            = "MF_BOOTSTRAP_ES_DB"
 	envESConsumerName  = "MF_BOOTSTRAP_EVENT_CONSUMER"
+	envAuditESURL      = "MF_AUDIT_ES_URL"
+	envAuditESPass     = "MF_AUDIT_ES_PASS"
+	envAuditESDB       = "MF_AUDIT_ES_DB"
 	envJaegerURL       = "MF_JAEGER_URL"
 	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
 	envAuthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
@@ -115,6 +123,9 @@ type config struct {
 	esPass          string
 	esDB            string
 	esConsumerName  string
+	auditESURL      string
+	auditESPass     string
+	auditESDB       string
 	jaegerURL       string
 	authGRPCURL     string
 	authGRPCTimeout time.Duration
@@ -147,7 +158,14 @@ func main() {
 
 	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
 
-	svc := newService(auth, db, logger, esClient, cfg)
+	var auditPub audit.Publisher
+	if cfg.auditESURL != "" {
+		auditClient := connectToRedis(cfg.auditESURL, cfg.auditESPass, cfg.auditESDB, logger)
+		defer auditClient.Close()
+		auditPub = auditproducer.NewPublisher(auditClient)
+	}
+
+	svc := newService(auth, db, logger, esClient, auditPub, cfg)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, svc, cfg, logger)
@@ -217,6 +235,9 @@ func loadConfig() config {
 		esPass:          mainflux.Env(envESPass, defESPass),
 		esDB:            mainflux.Env(envESDB, defESDB),
 		esConsumerName:  mainflux.Env(envESConsumerName, defESConsumerName),
+		auditESURL:      mainflux.Env(envAuditESURL, defAuditESURL),
+		auditESPass:     mainflux.Env(envAuditESPass, defAuditESPass),
+		auditESDB:       mainflux.Env(envAuditESDB, defAuditESDB),
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		authGRPCTimeout: authGRPCTimeout,
@@ -270,7 +291,7 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger, esClient *r.Client, cfg config) bootstrap.Service {
+func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger, esClient *r.Client, auditPub audit.Publisher, cfg config) bootstrap.Service {
 	thingsRepo := postgres.NewConfigRepository(db, logger)
 
 	config := mfsdk.Config{
@@ -281,6 +302,9 @@ func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger
 
 	svc := bootstrap.New(ac, thingsRepo, sdk, cfg.encKey)
 	svc = redisprod.NewEventStoreMiddleware(svc, esClient)
+	if auditPub != nil {
+		svc = api.NewAuditMiddleware(svc, ac, sdk, auditPub, logger)
+	}
 	svc = api.NewLoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
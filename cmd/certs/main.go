@@ -19,7 +19,7 @@ import (
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/certs"
 	"github.com/MainfluxLabs/mainflux/certs/api"
-	vault "github.com/MainfluxLabs/mainflux/certs/pki"
+	"github.com/MainfluxLabs/mainflux/certs/pki"
 	"github.com/MainfluxLabs/mainflux/certs/postgres"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -37,6 +37,9 @@ import (
 const (
 	stopWaitTime = 5 * time.Second
 
+	vaultAgent = "vault"
+	localAgent = "local"
+
 	defLogLevel        = "error"
 	defDBHost          = "localhost"
 	defDBPort          = "5432"
@@ -63,6 +66,8 @@ const (
 	defSignHoursValid = "2048h"
 	defSignRSABits    = ""
 
+	defPKIAgent = "vault"
+
 	defVaultHost       = ""
 	defVaultRole       = "mainflux"
 	defVaultToken      = ""
@@ -93,6 +98,8 @@ const (
 	envSignHoursValid  = "MF_CERTS_SIGN_HOURS_VALID"
 	envSignRSABits     = "MF_CERTS_SIGN_RSA_BITS"
 
+	envPKIAgent = "MF_CERTS_PKI_AGENT"
+
 	envVaultHost       = "MF_CERTS_VAULT_HOST"
 	envVaultPKIIntPath = "MF_VAULT_PKI_INT_PATH"
 	envVaultRole       = "MF_VAULT_CA_ROLE_NAME"
@@ -104,6 +111,8 @@ var (
 	errFailedCertDecode      = errors.New("failed to decode certificate")
 	errCACertificateNotExist = errors.New("CA certificate does not exist")
 	errCAKeyNotExist         = errors.New("CA certificate key does not exist")
+	errMissingPKIHost        = errors.New("no host specified for PKI engine")
+	errUnknownPKIAgent       = errors.New("unknown PKI agent")
 )
 
 type config struct {
@@ -124,6 +133,8 @@ type config struct {
 	signCAKeyPath  string
 	signRSABits    int
 	signHoursValid string
+	// PKI agent used for issuing certificates, either vault or local
+	pkiAgent string
 	// 3rd party PKI API access settings
 	pkiPath  string
 	pkiToken string
@@ -146,18 +157,14 @@ func main() {
 		logger.Error("Failed to load CA certificates for issuing client certs")
 	}
 
-	if cfg.pkiHost == "" {
-		log.Fatalf("No host specified for PKI engine")
-	}
+	db := connectToDB(cfg.dbConfig, logger)
+	defer db.Close()
 
-	pkiClient, err := vault.NewVaultClient(cfg.pkiToken, cfg.pkiHost, cfg.pkiPath, cfg.pkiRole)
+	pkiClient, err := newPKIAgent(cfg, db, tlsCert, caCert)
 	if err != nil {
-		log.Fatalf("Failed to configure client for PKI engine")
+		log.Fatalf("Failed to configure client for PKI engine: %s", err)
 	}
 
-	db := connectToDB(cfg.dbConfig, logger)
-	defer db.Close()
-
 	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
 	defer authCloser.Close()
 
@@ -231,6 +238,7 @@ func loadConfig() config {
 		signHoursValid: mainflux.Env(envSignHoursValid, defSignHoursValid),
 		signRSABits:    signRSABits,
 
+		pkiAgent: mainflux.Env(envPKIAgent, defPKIAgent),
 		pkiToken: mainflux.Env(envVaultToken, defVaultToken),
 		pkiPath:  mainflux.Env(envVaultPKIIntPath, defVaultPKIIntPath),
 		pkiRole:  mainflux.Env(envVaultRole, defVaultRole),
@@ -297,7 +305,21 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger, tlsCert tls.Certificate, x509Cert *x509.Certificate, cfg config, pkiAgent vault.Agent) certs.Service {
+func newPKIAgent(cfg config, db *sqlx.DB, tlsCert tls.Certificate, caCert *x509.Certificate) (pki.Agent, error) {
+	switch cfg.pkiAgent {
+	case vaultAgent:
+		if cfg.pkiHost == "" {
+			return nil, errMissingPKIHost
+		}
+		return pki.NewVaultClient(cfg.pkiToken, cfg.pkiHost, cfg.pkiPath, cfg.pkiRole)
+	case localAgent:
+		return pki.NewLocalAgent(tlsCert, caCert, cfg.signHoursValid, postgres.NewPKIRepository(db))
+	default:
+		return nil, errUnknownPKIAgent
+	}
+}
+
+func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger, tlsCert tls.Certificate, x509Cert *x509.Certificate, cfg config, pkiAgent pki.Agent) certs.Service {
 	certsRepo := postgres.NewRepository(db, logger)
 
 	certsConfig := certs.Config{
@@ -319,6 +341,7 @@ func newService(ac mainflux.AuthServiceClient, db *sqlx.DB, logger logger.Logger
 		PKIHost:        cfg.pkiHost,
 		PKIPath:        cfg.pkiPath,
 		PKIRole:        cfg.pkiRole,
+		LocalPKI:       cfg.pkiAgent == localAgent,
 	}
 
 	config := mfsdk.Config{
@@ -20,8 +20,10 @@ import (
 	logger "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	opentracing "github.com/opentracing/opentracing-go"
 	gocoap "github.com/plgd-dev/go-coap/v2"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -42,6 +44,9 @@ const (
 	defJaegerURL         = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
+	defAuthCacheURL      = "localhost:6379"
+	defAuthCachePass     = ""
+	defAuthCacheDB       = "0"
 
 	envPort              = "MF_COAP_ADAPTER_PORT"
 	envBrokerURL         = "MF_BROKER_URL"
@@ -51,6 +56,9 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthCacheURL      = "MF_AUTH_CACHE_URL"
+	envAuthCachePass     = "MF_AUTH_CACHE_PASS"
+	envAuthCacheDB       = "MF_AUTH_CACHE_DB"
 )
 
 type config struct {
@@ -62,6 +70,10 @@ type config struct {
 	jaegerURL         string
 	thingsGRPCURL     string
 	thingsGRPCTimeout time.Duration
+	authCacheURL      string
+	authCachePass     string
+	authCacheDB       string
+	rateLimit         ratelimit.Config
 }
 
 func main() {
@@ -89,6 +101,12 @@ func main() {
 	}
 	defer nps.Close()
 
+	if cfg.rateLimit.Enabled() {
+		rc := connectToRedis(cfg.authCacheURL, cfg.authCachePass, cfg.authCacheDB, logger)
+		defer rc.Close()
+		nps = ratelimit.NewPubSub(nps, ratelimit.NewRedisLimiter(rc, cfg.rateLimit))
+	}
+
 	svc := coap.New(tc, nps)
 
 	svc = api.LoggingMiddleware(svc, logger)
@@ -141,6 +159,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	rateLimit, err := ratelimit.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid rate limit configuration: %s", err)
+	}
+
 	return config{
 		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		port:              mainflux.Env(envPort, defPort),
@@ -150,6 +173,10 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		authCacheURL:      mainflux.Env(envAuthCacheURL, defAuthCacheURL),
+		authCachePass:     mainflux.Env(envAuthCachePass, defAuthCachePass),
+		authCacheDB:       mainflux.Env(envAuthCacheDB, defAuthCacheDB),
+		rateLimit:         rateLimit,
 	}
 }
 
@@ -241,3 +268,17 @@ func startCOAPServer(ctx context.Context, cfg config, svc coap.Service, l logger
 		return err
 	}
 }
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
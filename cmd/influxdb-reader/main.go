@@ -14,7 +14,10 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encinfluxdb "github.com/MainfluxLabs/mainflux/pkg/encryption/influxdb"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
 	"github.com/MainfluxLabs/mainflux/readers/influxdb"
@@ -40,6 +43,7 @@ const (
 	defDBUser            = "mainflux"
 	defDBPass            = "mainflux"
 	defDBBucket          = "mainflux-bucket"
+	defDBKeysBucket      = "mainflux-keys"
 	defDBOrg             = "mainflux"
 	defDBToken           = "mainflux-token"
 	defClientTLS         = "false"
@@ -51,6 +55,8 @@ const (
 	defThingsGRPCTimeout = "1s"
 	defAuthGRPCURL       = "localhost:8181"
 	defAuthGRPCTimeout   = "1s"
+	defMasterKey         = ""
+	defOldMasterKeys     = ""
 
 	envLogLevel          = "MF_INFLUX_READER_LOG_LEVEL"
 	envPort              = "MF_INFLUX_READER_PORT"
@@ -60,6 +66,7 @@ const (
 	envDBUser            = "MF_INFLUXDB_ADMIN_USER"
 	envDBPass            = "MF_INFLUXDB_ADMIN_PASSWORD"
 	envDBBucket          = "MF_INFLUXDB_BUCKET"
+	envDBKeysBucket      = "MF_INFLUXDB_KEYS_BUCKET"
 	envDBOrg             = "MF_INFLUXDB_ORG"
 	envDBToken           = "MF_INFLUXDB_TOKEN"
 	envClientTLS         = "MF_INFLUX_READER_CLIENT_TLS"
@@ -71,6 +78,8 @@ const (
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
 	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envMasterKey         = "MF_INFLUX_READER_MASTER_KEY"
+	envOldMasterKeys     = "MF_INFLUX_READER_OLD_MASTER_KEYS"
 )
 
 type config struct {
@@ -82,6 +91,7 @@ type config struct {
 	dbUser            string
 	dbPass            string
 	dbBucket          string
+	dbKeysBucket      string
 	dbOrg             string
 	dbToken           string
 	dbUrl             string
@@ -94,6 +104,7 @@ type config struct {
 	authGRPCURL       string
 	thingsGRPCTimeout time.Duration
 	authGRPCTimeout   time.Duration
+	keyring           *encryption.Keyring
 }
 
 func main() {
@@ -128,7 +139,13 @@ func main() {
 	}
 	defer client.Close()
 
-	repo := newService(client, repoCfg, logger)
+	keys, err := newEncryption(ctx, client, cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create InfluxDB keys bucket: %s", err))
+		os.Exit(1)
+	}
+
+	repo := newService(client, repoCfg, keys, logger)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, repo, tc, auth, cfg, logger)
@@ -195,6 +212,15 @@ func loadConfigs() (config, influxdb.RepoConfig) {
 		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
 	}
 
+	var keyring *encryption.Keyring
+	if masterKey := mainflux.Env(envMasterKey, defMasterKey); masterKey != "" {
+		kr, err := encryption.ParseKeyring(masterKey, mainflux.Env(envOldMasterKeys, defOldMasterKeys))
+		if err != nil {
+			log.Fatalf("Invalid %s or %s value: %s", envMasterKey, envOldMasterKeys, err.Error())
+		}
+		keyring = &kr
+	}
+
 	cfg := config{
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
 		port:              mainflux.Env(envPort, defPort),
@@ -204,6 +230,7 @@ func loadConfigs() (config, influxdb.RepoConfig) {
 		dbUser:            mainflux.Env(envDBUser, defDBUser),
 		dbPass:            mainflux.Env(envDBPass, defDBPass),
 		dbBucket:          mainflux.Env(envDBBucket, defDBBucket),
+		dbKeysBucket:      mainflux.Env(envDBKeysBucket, defDBKeysBucket),
 		dbOrg:             mainflux.Env(envDBOrg, defDBOrg),
 		dbToken:           mainflux.Env(envDBToken, defDBToken),
 		clientTLS:         tls,
@@ -215,6 +242,7 @@ func loadConfigs() (config, influxdb.RepoConfig) {
 		thingsGRPCTimeout: thingsGRPCTimeout,
 		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		authGRPCTimeout:   authGRPCTimeout,
+		keyring:           keyring,
 	}
 
 	cfg.dbUrl = fmt.Sprintf("http://%s:%s", cfg.dbHost, cfg.dbPort)
@@ -276,8 +304,24 @@ func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer,
 	return tracer, closer
 }
 
-func newService(client influxdb2.Client, repoCfg influxdb.RepoConfig, logger logger.Logger) readers.MessageRepository {
+// newEncryption returns the encryption service, or nil if the master key is
+// not set and the messages are stored in plaintext.
+func newEncryption(ctx context.Context, client influxdb2.Client, cfg config) (encryption.Service, error) {
+	if cfg.keyring == nil {
+		return nil, nil
+	}
+	keys, err := encinfluxdb.New(ctx, client, cfg.dbOrg, cfg.dbKeysBucket)
+	if err != nil {
+		return nil, err
+	}
+	return encryption.New(keys, *cfg.keyring, uuid.New()), nil
+}
+
+func newService(client influxdb2.Client, repoCfg influxdb.RepoConfig, keys encryption.Service, logger logger.Logger) readers.MessageRepository {
 	repo := influxdb.New(client, repoCfg)
+	if keys != nil {
+		repo = api.EncryptionMiddleware(repo, keys)
+	}
 	repo = api.LoggingMiddleware(repo, logger)
 	repo = api.MetricsMiddleware(
 		repo,
@@ -6,63 +6,112 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/influxdb"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encinfluxdb "github.com/MainfluxLabs/mainflux/pkg/encryption/influxdb"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	svcName      = "influxdb-writer"
 	stopWaitTime = 5 * time.Second
 
-	defBrokerURL = "nats://localhost:4222"
-	defLogLevel  = "error"
-	defPort      = "8180"
-	defDBHost    = "localhost"
-	defDBPort    = "8086"
-	defDBUser    = "mainflux"
-	defDBPass    = "mainflux"
-	defDBBucket  = "mainflux-bucket"
-	defDBOrg     = "mainflux"
-	defDBToken   = "mainflux-token"
-
-	envBrokerURL = "MF_BROKER_URL"
-	envLogLevel  = "MF_INFLUX_WRITER_LOG_LEVEL"
-	envPort      = "MF_INFLUX_WRITER_PORT"
-	envDBHost    = "MF_INFLUXDB_HOST"
-	envDBPort    = "MF_INFLUXDB_PORT"
-	envDBUser    = "MF_INFLUXDB_ADMIN_USER"
-	envDBPass    = "MF_INFLUXDB_ADMIN_PASSWORD"
-	envDBBucket  = "MF_INFLUXDB_BUCKET"
-	envDBOrg     = "MF_INFLUXDB_ORG"
-	envDBToken   = "MF_INFLUXDB_TOKEN"
+	defBrokerURL         = "nats://localhost:4222"
+	defLogLevel          = "error"
+	defPort              = "8180"
+	defDBHost            = "localhost"
+	defDBPort            = "8086"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defDBBucket          = "mainflux-bucket"
+	defDBKeysBucket      = "mainflux-keys"
+	defDBOrg             = "mainflux"
+	defDBToken           = "mainflux-token"
+	defPruneInterval     = "1h"
+	defDeadLetters       = "1000"
+	defMasterKey         = ""
+	defOldMasterKeys     = ""
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defAuthGRPCURL       = "localhost:8181"
+	defAuthGRPCTimeout   = "1s"
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+
+	envBrokerURL         = "MF_BROKER_URL"
+	envLogLevel          = "MF_INFLUX_WRITER_LOG_LEVEL"
+	envPort              = "MF_INFLUX_WRITER_PORT"
+	envDBHost            = "MF_INFLUXDB_HOST"
+	envDBPort            = "MF_INFLUXDB_PORT"
+	envDBUser            = "MF_INFLUXDB_ADMIN_USER"
+	envDBPass            = "MF_INFLUXDB_ADMIN_PASSWORD"
+	envDBBucket          = "MF_INFLUXDB_BUCKET"
+	envDBKeysBucket      = "MF_INFLUXDB_KEYS_BUCKET"
+	envDBOrg             = "MF_INFLUXDB_ORG"
+	envDBToken           = "MF_INFLUXDB_TOKEN"
+	envPruneInterval     = "MF_INFLUX_WRITER_PRUNE_INTERVAL"
+	envDeadLetters       = "MF_INFLUX_WRITER_DEAD_LETTERS"
+	envMasterKey         = "MF_INFLUX_WRITER_MASTER_KEY"
+	envOldMasterKeys     = "MF_INFLUX_WRITER_OLD_MASTER_KEYS"
+	envClientTLS         = "MF_INFLUX_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_INFLUX_WRITER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 )
 
 type config struct {
-	brokerURL string
-	logLevel  string
-	port      string
-	dbHost    string
-	dbPort    string
-	dbUser    string
-	dbPass    string
-	dbBucket  string
-	dbOrg     string
-	dbToken   string
-	dbUrl     string
+	brokerURL         string
+	logLevel          string
+	port              string
+	dbHost            string
+	dbPort            string
+	dbUser            string
+	dbPass            string
+	dbBucket          string
+	dbKeysBucket      string
+	dbOrg             string
+	dbToken           string
+	dbUrl             string
+	pruneInterval     time.Duration
+	deadLetters       int
+	keyring           *encryption.Keyring
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
 }
 
 func main() {
@@ -82,6 +131,13 @@ func main() {
 	}
 	defer pubSub.Close()
 
+	dlPub, err := brokers.NewDeadLetterPublisher(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer dlPub.Close()
+
 	client, err := connectToInfluxDB(cfg)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to create InfluxDB client: %s", err))
@@ -90,17 +146,52 @@ func main() {
 	defer client.Close()
 
 	repo := influxdb.New(client, repoCfg)
+	keys, err := newEncryption(ctx, client, cfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create InfluxDB keys bucket: %s", err))
+		os.Exit(1)
+	}
+	if keys != nil {
+		repo = api.EncryptionMiddleware(repo, keys)
+	}
 	counter, latency := makeMetrics()
 	repo = api.LoggingMiddleware(repo, logger)
 	repo = api.MetricsMiddleware(repo, counter, latency)
 
-	if err := consumers.Start(svcName, pubSub, repo, brokers.SubjectSenML, brokers.SubjectJSON); err != nil {
+	dls := newDeadLetters(repo, dlPub, keys, cfg.deadLetters, logger)
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	thingsConn := connectToThings(cfg, logger)
+	defer thingsConn.Close()
+
+	tc := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsGRPCTimeout)
+
+	retention := writers.NewRetention()
+	if err := retention.Load(ctx, tc); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to load retention policies: %s", err))
+	}
+	if err := consumers.StartWithDeadLetters(svcName, retention.Subscriber(pubSub), repo, dls, brokers.SubjectSenML, brokers.SubjectJSON); err != nil {
 		logger.Error(fmt.Sprintf("Failed to start InfluxDB writer: %s", err))
 		os.Exit(1)
 	}
 
+	pruner := newPruner(client, repoCfg)
+	g.Go(func() error {
+		writers.StartPruning(ctx, pruner, retention, cfg.pruneInterval, logger)
+		return nil
+	})
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+
 	g.Go(func() error {
-		return startHTTPService(ctx, cfg.port, logger)
+		return startHTTPService(ctx, cfg.port, dls, keys, auth, logger)
 	})
 
 	g.Go(func() error {
@@ -123,17 +214,62 @@ func connectToInfluxDB(cfg config) (influxdb2.Client, error) {
 }
 
 func loadConfigs() (config, influxdb.RepoConfig) {
+	pruneInterval, err := time.ParseDuration(mainflux.Env(envPruneInterval, defPruneInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envPruneInterval, err.Error())
+	}
+
+	deadLetters, err := strconv.Atoi(mainflux.Env(envDeadLetters, defDeadLetters))
+	if err != nil || deadLetters < 0 {
+		log.Fatalf("Invalid %s value: %s", envDeadLetters, mainflux.Env(envDeadLetters, defDeadLetters))
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	var keyring *encryption.Keyring
+	if masterKey := mainflux.Env(envMasterKey, defMasterKey); masterKey != "" {
+		kr, err := encryption.ParseKeyring(masterKey, mainflux.Env(envOldMasterKeys, defOldMasterKeys))
+		if err != nil {
+			log.Fatalf("Invalid %s or %s value: %s", envMasterKey, envOldMasterKeys, err.Error())
+		}
+		keyring = &kr
+	}
+
 	cfg := config{
-		brokerURL: mainflux.Env(envBrokerURL, defBrokerURL),
-		logLevel:  mainflux.Env(envLogLevel, defLogLevel),
-		port:      mainflux.Env(envPort, defPort),
-		dbHost:    mainflux.Env(envDBHost, defDBHost),
-		dbPort:    mainflux.Env(envDBPort, defDBPort),
-		dbUser:    mainflux.Env(envDBUser, defDBUser),
-		dbPass:    mainflux.Env(envDBPass, defDBPass),
-		dbBucket:  mainflux.Env(envDBBucket, defDBBucket),
-		dbOrg:     mainflux.Env(envDBOrg, defDBOrg),
-		dbToken:   mainflux.Env(envDBToken, defDBToken),
+		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		port:              mainflux.Env(envPort, defPort),
+		dbHost:            mainflux.Env(envDBHost, defDBHost),
+		dbPort:            mainflux.Env(envDBPort, defDBPort),
+		dbUser:            mainflux.Env(envDBUser, defDBUser),
+		dbPass:            mainflux.Env(envDBPass, defDBPass),
+		dbBucket:          mainflux.Env(envDBBucket, defDBBucket),
+		dbKeysBucket:      mainflux.Env(envDBKeysBucket, defDBKeysBucket),
+		dbOrg:             mainflux.Env(envDBOrg, defDBOrg),
+		dbToken:           mainflux.Env(envDBToken, defDBToken),
+		pruneInterval:     pruneInterval,
+		deadLetters:       deadLetters,
+		keyring:           keyring,
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:   authGRPCTimeout,
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
 	}
 	cfg.dbUrl = fmt.Sprintf("http://%s:%s", cfg.dbHost, cfg.dbPort)
 
@@ -162,10 +298,10 @@ func makeMetrics() (*kitprometheus.Counter, *kitprometheus.Summary) {
 	return counter, latency
 }
 
-func startHTTPService(ctx context.Context, port string, logger logger.Logger) error {
+func startHTTPService(ctx context.Context, port string, dls consumers.DeadLetters, keys encryption.Service, ac mainflux.AuthServiceClient, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName, dls, keys, ac, logger)}
 
 	logger.Info(fmt.Sprintf("InfluxDB writer service started, exposed port %s", p))
 
@@ -187,3 +323,142 @@ func startHTTPService(ctx context.Context, port string, logger logger.Logger) er
 		return err
 	}
 }
+
+// newEncryption returns the encryption service, or nil if the master key is
+// not set and the messages are stored in plaintext.
+func newEncryption(ctx context.Context, client influxdb2.Client, cfg config) (encryption.Service, error) {
+	if cfg.keyring == nil {
+		return nil, nil
+	}
+	keys, err := encinfluxdb.New(ctx, client, cfg.dbOrg, cfg.dbKeysBucket)
+	if err != nil {
+		return nil, err
+	}
+	return encryption.New(keys, *cfg.keyring, uuid.New()), nil
+}
+
+func newPruner(client influxdb2.Client, cfg influxdb.RepoConfig) writers.Pruner {
+	pruner := influxdb.NewPruner(client, cfg)
+	return api.PrunerMetricsMiddleware(
+		pruner,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "message_pruner",
+			Name:      "request_count",
+			Help:      "Number of prune requests.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "influxdb",
+			Subsystem: "message_pruner",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of prune requests in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "message_pruner",
+			Name:      "removed_messages_count",
+			Help:      "Number of messages removed due to channel retention policies.",
+		}, []string{}),
+	)
+}
+
+func newDeadLetters(consumer consumers.Consumer, pub messaging.DeadLetterPublisher, keys encryption.Service, capacity int, logger logger.Logger) consumers.DeadLetters {
+	dls := consumers.NewDeadLetters(svcName, consumer, pub, keys, uuid.New(), capacity)
+	dls = api.DeadLettersLoggingMiddleware(dls, logger)
+	return api.DeadLettersMetricsMiddleware(
+		dls,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "dead_letters",
+			Name:      "request_count",
+			Help:      "Number of dead letter requests.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "influxdb",
+			Subsystem: "dead_letters",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of dead letter requests in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "influxdb",
+			Subsystem: "dead_letters",
+			Name:      "failures_count",
+			Help:      "Number of messages which failed to be handled, per failure reason.",
+		}, []string{"reason"}),
+	)
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	logger.Info("Connecting to auth via gRPC")
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to auth via gRPC: %s", cfg.authGRPCURL))
+	return conn
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to things via gRPC: %s", cfg.thingsGRPCURL))
+	return conn
+}
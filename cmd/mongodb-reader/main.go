@@ -17,7 +17,10 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encmongodb "github.com/MainfluxLabs/mainflux/pkg/encryption/mongodb"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
 	"github.com/MainfluxLabs/mainflux/readers/mongodb"
@@ -50,6 +53,8 @@ const (
 	defThingsGRPCTimeout = "1s"
 	defAuthGRPCURL       = "localhost:8181"
 	defAuthGRPCTimeout   = "1s"
+	defMasterKey         = ""
+	defOldMasterKeys     = ""
 
 	envLogLevel          = "MF_MONGO_READER_LOG_LEVEL"
 	envPort              = "MF_MONGO_READER_PORT"
@@ -65,6 +70,8 @@ const (
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
 	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envMasterKey         = "MF_MONGO_READER_MASTER_KEY"
+	envOldMasterKeys     = "MF_MONGO_READER_OLD_MASTER_KEYS"
 )
 
 type config struct {
@@ -82,6 +89,7 @@ type config struct {
 	authGRPCURL       string
 	thingsGRPCTimeout time.Duration
 	authGRPCTimeout   time.Duration
+	keyring           *encryption.Keyring
 }
 
 func main() {
@@ -111,7 +119,7 @@ func main() {
 
 	db := connectToMongoDB(cfg.dbHost, cfg.dbPort, cfg.dbName, logger)
 
-	repo := newService(db, logger)
+	repo := newService(db, cfg.keyring, logger)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, repo, tc, auth, cfg, logger)
@@ -173,6 +181,15 @@ func loadConfigs() config {
 		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
 	}
 
+	var keyring *encryption.Keyring
+	if masterKey := mainflux.Env(envMasterKey, defMasterKey); masterKey != "" {
+		kr, err := encryption.ParseKeyring(masterKey, mainflux.Env(envOldMasterKeys, defOldMasterKeys))
+		if err != nil {
+			log.Fatalf("Invalid %s or %s value: %s", envMasterKey, envOldMasterKeys, err.Error())
+		}
+		keyring = &kr
+	}
+
 	return config{
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
 		port:              mainflux.Env(envPort, defPort),
@@ -188,6 +205,7 @@ func loadConfigs() config {
 		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
 		authGRPCTimeout:   authGRPCTimeout,
+		keyring:           keyring,
 	}
 }
 
@@ -251,8 +269,12 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(db *mongo.Database, logger logger.Logger) readers.MessageRepository {
+func newService(db *mongo.Database, keyring *encryption.Keyring, logger logger.Logger) readers.MessageRepository {
 	repo := mongodb.New(db)
+	if keyring != nil {
+		keys := encryption.New(encmongodb.New(db), *keyring, uuid.New())
+		repo = api.EncryptionMiddleware(repo, keys)
+	}
 	repo = api.LoggingMiddleware(repo, logger)
 	repo = api.MetricsMiddleware(
 		repo,
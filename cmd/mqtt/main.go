@@ -23,6 +23,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
 	mqttpub "github.com/MainfluxLabs/mainflux/pkg/messaging/mqtt"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
 	"github.com/MainfluxLabs/mainflux/pkg/ulid"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	"github.com/MainfluxLabs/mproxy/logger"
@@ -154,6 +155,7 @@ type config struct {
 	serverKey         string
 	authGRPCTimeout   time.Duration
 	dbConfig          postgres.Config
+	rateLimit         ratelimit.Config
 }
 
 func main() {
@@ -240,11 +242,12 @@ func main() {
 	tc := thingsapi.NewClient(conn, thingsTracer, cfg.thingsGRPCTimeout)
 
 	authClient := auth.New(ac, tc)
+	limiter := ratelimit.NewRedisLimiter(ac, cfg.rateLimit)
 
 	svc := newService(usersAuth, tc, db, logger)
 
 	// Event handler for MQTT hooks
-	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient, svc)
+	h := mqtt.NewHandler([]messaging.Publisher{np}, es, logger, authClient, svc, limiter)
 
 	logger.Info(fmt.Sprintf("Starting MQTT proxy on port %s", cfg.port))
 	g.Go(func() error {
@@ -307,6 +310,11 @@ func loadConfig() config {
 		SSLRootCert: mainflux.Env(envDBSSLRootCert, defDBSSLRootCert),
 	}
 
+	rateLimit, err := ratelimit.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid rate limit configuration: %s", err)
+	}
+
 	return config{
 		port:              mainflux.Env(envMQTTPort, defMQTTPort),
 		targetHost:        mainflux.Env(envTargetHost, defTargetHost),
@@ -337,6 +345,7 @@ func loadConfig() config {
 		serverKey:         mainflux.Env(envServerKey, defServerKey),
 		authGRPCTimeout:   authGRPCTimeout,
 		dbConfig:          dbConfig,
+		rateLimit:         rateLimit,
 	}
 }
 
@@ -16,6 +16,8 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	auditproducer "github.com/MainfluxLabs/mainflux/audit/redis/producer"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -61,6 +63,9 @@ const (
 	defESURL           = "localhost:6379"
 	defESPass          = ""
 	defESDB            = "0"
+	defAuditESURL      = ""
+	defAuditESPass     = ""
+	defAuditESDB       = "0"
 	defHTTPPort        = "8182"
 	defAuthHTTPPort    = "8989"
 	defAuthGRPCPort    = "8181"
@@ -90,6 +95,9 @@ const (
 	envESURL           = "MF_THINGS_ES_URL"
 	envESPass          = "MF_THINGS_ES_PASS"
 	envESDB            = "MF_THINGS_ES_DB"
+	envAuditESURL      = "MF_AUDIT_ES_URL"
+	envAuditESPass     = "MF_AUDIT_ES_PASS"
+	envAuditESDB       = "MF_AUDIT_ES_DB"
 	envHTTPPort        = "MF_THINGS_HTTP_PORT"
 	envAuthHTTPPort    = "MF_THINGS_AUTH_HTTP_PORT"
 	envAuthGRPCPort    = "MF_THINGS_AUTH_GRPC_PORT"
@@ -113,6 +121,9 @@ type config struct {
 	esURL           string
 	esPass          string
 	esDB            string
+	auditESURL      string
+	auditESPass     string
+	auditESDB       string
 	httpPort        string
 	authHTTPPort    string
 	authGRPCPort    string
@@ -159,7 +170,14 @@ func main() {
 	cacheTracer, cacheCloser := initJaeger("things_cache", cfg.jaegerURL, logger)
 	defer cacheCloser.Close()
 
-	svc := newService(auth, dbTracer, cacheTracer, db, cacheClient, esClient, logger)
+	var auditPub audit.Publisher
+	if cfg.auditESURL != "" {
+		auditClient := connectToRedis(cfg.auditESURL, cfg.auditESPass, cfg.auditESDB, logger)
+		defer auditClient.Close()
+		auditPub = auditproducer.NewPublisher(auditClient)
+	}
+
+	svc := newService(auth, dbTracer, cacheTracer, db, cacheClient, esClient, auditPub, logger)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, "thing-http", thhttpapi.MakeHandler(thingsTracer, svc, logger), cfg.httpPort, cfg, logger)
@@ -220,6 +238,9 @@ func loadConfig() config {
 		esURL:           mainflux.Env(envESURL, defESURL),
 		esPass:          mainflux.Env(envESPass, defESPass),
 		esDB:            mainflux.Env(envESDB, defESDB),
+		auditESURL:      mainflux.Env(envAuditESURL, defAuditESURL),
+		auditESPass:     mainflux.Env(envAuditESPass, defAuditESPass),
+		auditESDB:       mainflux.Env(envAuditESDB, defAuditESDB),
 		httpPort:        mainflux.Env(envHTTPPort, defHTTPPort),
 		authHTTPPort:    mainflux.Env(envAuthHTTPPort, defAuthHTTPPort),
 		authGRPCPort:    mainflux.Env(envAuthGRPCPort, defAuthGRPCPort),
@@ -314,7 +335,7 @@ func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cacheTracer opentracing.Tracer, db *sqlx.DB, cacheClient *redis.Client, esClient *redis.Client, logger logger.Logger) things.Service {
+func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cacheTracer opentracing.Tracer, db *sqlx.DB, cacheClient *redis.Client, esClient *redis.Client, auditPub audit.Publisher, logger logger.Logger) things.Service {
 	database := postgres.NewDatabase(db)
 
 	thingsRepo := postgres.NewThingRepository(database)
@@ -335,6 +356,9 @@ func newService(ac mainflux.AuthServiceClient, dbTracer opentracing.Tracer, cach
 
 	svc := things.New(ac, thingsRepo, channelsRepo, groupsRepo, chanCache, thingCache, idProvider)
 	svc = rediscache.NewEventStoreMiddleware(svc, esClient)
+	if auditPub != nil {
+		svc = api.AuditMiddleware(svc, ac, auditPub, logger)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -17,7 +17,10 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encpostgres "github.com/MainfluxLabs/mainflux/pkg/encryption/postgres"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
 	"github.com/MainfluxLabs/mainflux/readers/timescale"
@@ -54,6 +57,8 @@ const (
 	defThingsGRPCTimeout = "1s"
 	defAuthGRPCURL       = "localhost:8181"
 	defAuthGRPCTimeout   = "1s"
+	defMasterKey         = ""
+	defOldMasterKeys     = ""
 
 	envLogLevel          = "MF_TIMESCALE_READER_LOG_LEVEL"
 	envPort              = "MF_TIMESCALE_READER_PORT"
@@ -73,6 +78,8 @@ const (
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
 	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
 	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envMasterKey         = "MF_TIMESCALE_READER_MASTER_KEY"
+	envOldMasterKeys     = "MF_TIMESCALE_READER_OLD_MASTER_KEYS"
 )
 
 type config struct {
@@ -86,6 +93,7 @@ type config struct {
 	authGRPCURL       string
 	thingsGRPCTimeout time.Duration
 	authGRPCTimeout   time.Duration
+	keyring           *encryption.Keyring
 }
 
 func main() {
@@ -116,7 +124,7 @@ func main() {
 	db := connectToDB(cfg.dbConfig, logger)
 	defer db.Close()
 
-	repo := newService(db, logger)
+	repo := newService(db, cfg.keyring, logger)
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, repo, tc, auth, cfg.port, logger)
@@ -163,6 +171,15 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	var keyring *encryption.Keyring
+	if masterKey := mainflux.Env(envMasterKey, defMasterKey); masterKey != "" {
+		kr, err := encryption.ParseKeyring(masterKey, mainflux.Env(envOldMasterKeys, defOldMasterKeys))
+		if err != nil {
+			log.Fatalf("Invalid %s or %s value: %s", envMasterKey, envOldMasterKeys, err.Error())
+		}
+		keyring = &kr
+	}
+
 	return config{
 		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
 		port:              mainflux.Env(envPort, defPort),
@@ -174,6 +191,7 @@ func loadConfig() config {
 		thingsGRPCTimeout: thingsGRPCTimeout,
 		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
 		authGRPCTimeout:   authGRPCTimeout,
+		keyring:           keyring,
 	}
 }
 
@@ -260,8 +278,12 @@ func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
 	return conn
 }
 
-func newService(db *sqlx.DB, logger logger.Logger) readers.MessageRepository {
+func newService(db *sqlx.DB, keyring *encryption.Keyring, logger logger.Logger) readers.MessageRepository {
 	svc := timescale.New(db)
+	if keyring != nil {
+		keys := encryption.New(encpostgres.New(db), *keyring, uuid.New())
+		svc = api.EncryptionMiddleware(svc, keys)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
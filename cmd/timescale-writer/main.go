@@ -6,63 +6,109 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/timescale"
 	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encpostgres "github.com/MainfluxLabs/mainflux/pkg/encryption/postgres"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
 	"github.com/jmoiron/sqlx"
+	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	svcName      = "timescaledb-writer"
 	stopWaitTime = 5 * time.Second
 
-	defLogLevel      = "error"
-	defBrokerURL     = "nats://localhost:4222"
-	defPort          = "8180"
-	defDBHost        = "localhost"
-	defDBPort        = "5432"
-	defDBUser        = "mainflux"
-	defDBPass        = "mainflux"
-	defDB            = "mainflux"
-	defDBSSLMode     = "disable"
-	defDBSSLCert     = ""
-	defDBSSLKey      = ""
-	defDBSSLRootCert = ""
-	defConfigPath    = "/config.toml"
-
-	envBrokerURL     = "MF_BROKER_URL"
-	envLogLevel      = "MF_TIMESCALE_WRITER_LOG_LEVEL"
-	envPort          = "MF_TIMESCALE_WRITER_PORT"
-	envDBHost        = "MF_TIMESCALE_WRITER_DB_HOST"
-	envDBPort        = "MF_TIMESCALE_WRITER_DB_PORT"
-	envDBUser        = "MF_TIMESCALE_WRITER_DB_USER"
-	envDBPass        = "MF_TIMESCALE_WRITER_DB_PASS"
-	envDB            = "MF_TIMESCALE_WRITER_DB"
-	envDBSSLMode     = "MF_TIMESCALE_WRITER_DB_SSL_MODE"
-	envDBSSLCert     = "MF_TIMESCALE_WRITER_DB_SSL_CERT"
-	envDBSSLKey      = "MF_TIMESCALE_WRITER_DB_SSL_KEY"
-	envDBSSLRootCert = "MF_TIMESCALE_WRITER_DB_SSL_ROOT_CERT"
-	envConfigPath    = "MF_TIMESCALE_WRITER_CONFIG_PATH"
+	defLogLevel          = "error"
+	defBrokerURL         = "nats://localhost:4222"
+	defPort              = "8180"
+	defDBHost            = "localhost"
+	defDBPort            = "5432"
+	defDBUser            = "mainflux"
+	defDBPass            = "mainflux"
+	defDB                = "mainflux"
+	defDBSSLMode         = "disable"
+	defDBSSLCert         = ""
+	defDBSSLKey          = ""
+	defDBSSLRootCert     = ""
+	defPruneInterval     = "1h"
+	defDeadLetters       = "1000"
+	defMasterKey         = ""
+	defOldMasterKeys     = ""
+	defClientTLS         = "false"
+	defCACerts           = ""
+	defJaegerURL         = ""
+	defAuthGRPCURL       = "localhost:8181"
+	defAuthGRPCTimeout   = "1s"
+	defThingsGRPCURL     = "localhost:8183"
+	defThingsGRPCTimeout = "1s"
+	defConfigPath        = "/config.toml"
+
+	envBrokerURL         = "MF_BROKER_URL"
+	envLogLevel          = "MF_TIMESCALE_WRITER_LOG_LEVEL"
+	envPort              = "MF_TIMESCALE_WRITER_PORT"
+	envDBHost            = "MF_TIMESCALE_WRITER_DB_HOST"
+	envDBPort            = "MF_TIMESCALE_WRITER_DB_PORT"
+	envDBUser            = "MF_TIMESCALE_WRITER_DB_USER"
+	envDBPass            = "MF_TIMESCALE_WRITER_DB_PASS"
+	envDB                = "MF_TIMESCALE_WRITER_DB"
+	envDBSSLMode         = "MF_TIMESCALE_WRITER_DB_SSL_MODE"
+	envDBSSLCert         = "MF_TIMESCALE_WRITER_DB_SSL_CERT"
+	envDBSSLKey          = "MF_TIMESCALE_WRITER_DB_SSL_KEY"
+	envDBSSLRootCert     = "MF_TIMESCALE_WRITER_DB_SSL_ROOT_CERT"
+	envPruneInterval     = "MF_TIMESCALE_WRITER_PRUNE_INTERVAL"
+	envDeadLetters       = "MF_TIMESCALE_WRITER_DEAD_LETTERS"
+	envMasterKey         = "MF_TIMESCALE_WRITER_MASTER_KEY"
+	envOldMasterKeys     = "MF_TIMESCALE_WRITER_OLD_MASTER_KEYS"
+	envClientTLS         = "MF_TIMESCALE_WRITER_CLIENT_TLS"
+	envCACerts           = "MF_TIMESCALE_WRITER_CA_CERTS"
+	envJaegerURL         = "MF_JAEGER_URL"
+	envAuthGRPCURL       = "MF_AUTH_GRPC_URL"
+	envAuthGRPCTimeout   = "MF_AUTH_GRPC_TIMEOUT"
+	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
+	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envConfigPath        = "MF_TIMESCALE_WRITER_CONFIG_PATH"
 )
 
 type config struct {
-	brokerURL  string
-	logLevel   string
-	port       string
-	configPath string
-	dbConfig   timescale.Config
+	brokerURL         string
+	logLevel          string
+	port              string
+	configPath        string
+	dbConfig          timescale.Config
+	pruneInterval     time.Duration
+	deadLetters       int
+	keyring           *encryption.Keyring
+	clientTLS         bool
+	caCerts           string
+	jaegerURL         string
+	authGRPCURL       string
+	authGRPCTimeout   time.Duration
+	thingsGRPCURL     string
+	thingsGRPCTimeout time.Duration
 }
 
 func main() {
@@ -82,17 +128,52 @@ func main() {
 	}
 	defer pubSub.Close()
 
+	dlPub, err := brokers.NewDeadLetterPublisher(cfg.brokerURL)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer dlPub.Close()
+
 	db := connectToDB(cfg.dbConfig, logger)
 	defer db.Close()
 
-	repo := newService(db, logger)
+	keys := newEncryption(db, cfg.keyring)
+	repo := newService(db, keys, logger)
 
-	if err = consumers.Start(svcName, pubSub, repo, brokers.SubjectSenML, brokers.SubjectJSON); err != nil {
+	dls := newDeadLetters(repo, dlPub, keys, cfg.deadLetters, logger)
+	thingsTracer, thingsCloser := initJaeger("things", cfg.jaegerURL, logger)
+	defer thingsCloser.Close()
+
+	thingsConn := connectToThings(cfg, logger)
+	defer thingsConn.Close()
+
+	tc := thingsapi.NewClient(thingsConn, thingsTracer, cfg.thingsGRPCTimeout)
+
+	retention := writers.NewRetention()
+	if err := retention.Load(ctx, tc); err != nil {
+		logger.Warn(fmt.Sprintf("Failed to load retention policies: %s", err))
+	}
+	if err = consumers.StartWithDeadLetters(svcName, retention.Subscriber(pubSub), repo, dls, brokers.SubjectSenML, brokers.SubjectJSON); err != nil {
 		logger.Error(fmt.Sprintf("Failed to create Timescale writer: %s", err))
 	}
 
+	pruner := newPruner(db)
 	g.Go(func() error {
-		return startHTTPServer(ctx, cfg.port, logger)
+		writers.StartPruning(ctx, pruner, retention, cfg.pruneInterval, logger)
+		return nil
+	})
+
+	authTracer, authCloser := initJaeger("auth", cfg.jaegerURL, logger)
+	defer authCloser.Close()
+
+	authConn := connectToAuth(cfg, logger)
+	defer authConn.Close()
+
+	auth := authapi.NewClient(authTracer, authConn, cfg.authGRPCTimeout)
+
+	g.Go(func() error {
+		return startHTTPServer(ctx, cfg.port, dls, keys, auth, logger)
 	})
 
 	g.Go(func() error {
@@ -109,6 +190,40 @@ func main() {
 }
 
 func loadConfig() config {
+	pruneInterval, err := time.ParseDuration(mainflux.Env(envPruneInterval, defPruneInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envPruneInterval, err.Error())
+	}
+
+	deadLetters, err := strconv.Atoi(mainflux.Env(envDeadLetters, defDeadLetters))
+	if err != nil || deadLetters < 0 {
+		log.Fatalf("Invalid %s value: %s", envDeadLetters, mainflux.Env(envDeadLetters, defDeadLetters))
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envClientTLS, defClientTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envClientTLS)
+	}
+
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envAuthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAuthGRPCTimeout, err.Error())
+	}
+
+	thingsGRPCTimeout, err := time.ParseDuration(mainflux.Env(envThingsGRPCTimeout, defThingsGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
+	}
+
+	var keyring *encryption.Keyring
+	if masterKey := mainflux.Env(envMasterKey, defMasterKey); masterKey != "" {
+		kr, err := encryption.ParseKeyring(masterKey, mainflux.Env(envOldMasterKeys, defOldMasterKeys))
+		if err != nil {
+			log.Fatalf("Invalid %s or %s value: %s", envMasterKey, envOldMasterKeys, err.Error())
+		}
+		keyring = &kr
+	}
+
 	dbConfig := timescale.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -122,11 +237,21 @@ func loadConfig() config {
 	}
 
 	return config{
-		brokerURL:  mainflux.Env(envBrokerURL, defBrokerURL),
-		logLevel:   mainflux.Env(envLogLevel, defLogLevel),
-		port:       mainflux.Env(envPort, defPort),
-		configPath: mainflux.Env(envConfigPath, defConfigPath),
-		dbConfig:   dbConfig,
+		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
+		logLevel:          mainflux.Env(envLogLevel, defLogLevel),
+		port:              mainflux.Env(envPort, defPort),
+		configPath:        mainflux.Env(envConfigPath, defConfigPath),
+		dbConfig:          dbConfig,
+		pruneInterval:     pruneInterval,
+		deadLetters:       deadLetters,
+		keyring:           keyring,
+		clientTLS:         tls,
+		caCerts:           mainflux.Env(envCACerts, defCACerts),
+		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
+		authGRPCURL:       mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout:   authGRPCTimeout,
+		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
+		thingsGRPCTimeout: thingsGRPCTimeout,
 	}
 }
 
@@ -139,8 +264,11 @@ func connectToDB(dbConfig timescale.Config, logger logger.Logger) *sqlx.DB {
 	return db
 }
 
-func newService(db *sqlx.DB, logger logger.Logger) consumers.Consumer {
+func newService(db *sqlx.DB, keys encryption.Service, logger logger.Logger) consumers.Consumer {
 	svc := timescale.New(db)
+	if keys != nil {
+		svc = api.EncryptionMiddleware(svc, keys)
+	}
 	svc = api.LoggingMiddleware(svc, logger)
 	svc = api.MetricsMiddleware(
 		svc,
@@ -161,10 +289,10 @@ func newService(db *sqlx.DB, logger logger.Logger) consumers.Consumer {
 	return svc
 }
 
-func startHTTPServer(ctx context.Context, port string, logger logger.Logger) error {
+func startHTTPServer(ctx context.Context, port string, dls consumers.DeadLetters, keys encryption.Service, ac mainflux.AuthServiceClient, logger logger.Logger) error {
 	p := fmt.Sprintf(":%s", port)
 	errCh := make(chan error)
-	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName)}
+	server := &http.Server{Addr: p, Handler: api.MakeHandler(svcName, dls, keys, ac, logger)}
 
 	logger.Info(fmt.Sprintf("Timescale writer service started, exposed port %s", port))
 	go func() {
@@ -186,3 +314,138 @@ func startHTTPServer(ctx context.Context, port string, logger logger.Logger) err
 	}
 
 }
+
+// newEncryption returns the encryption service, or nil if the master key is
+// not set and the messages are stored in plaintext.
+func newEncryption(db *sqlx.DB, keyring *encryption.Keyring) encryption.Service {
+	if keyring == nil {
+		return nil
+	}
+	return encryption.New(encpostgres.New(db), *keyring, uuid.New())
+}
+
+func newPruner(db *sqlx.DB) writers.Pruner {
+	pruner := timescale.NewPruner(db)
+	return api.PrunerMetricsMiddleware(
+		pruner,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "timescale",
+			Subsystem: "message_pruner",
+			Name:      "request_count",
+			Help:      "Number of prune requests.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "timescale",
+			Subsystem: "message_pruner",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of prune requests in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "timescale",
+			Subsystem: "message_pruner",
+			Name:      "removed_messages_count",
+			Help:      "Number of messages removed due to channel retention policies.",
+		}, []string{}),
+	)
+}
+
+func newDeadLetters(consumer consumers.Consumer, pub messaging.DeadLetterPublisher, keys encryption.Service, capacity int, logger logger.Logger) consumers.DeadLetters {
+	dls := consumers.NewDeadLetters(svcName, consumer, pub, keys, uuid.New(), capacity)
+	dls = api.DeadLettersLoggingMiddleware(dls, logger)
+	return api.DeadLettersMetricsMiddleware(
+		dls,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "timescale",
+			Subsystem: "dead_letters",
+			Name:      "request_count",
+			Help:      "Number of dead letter requests.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "timescale",
+			Subsystem: "dead_letters",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of dead letter requests in microseconds.",
+		}, []string{"method"}),
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "timescale",
+			Subsystem: "dead_letters",
+			Name:      "failures_count",
+			Help:      "Number of messages which failed to be handled, per failure reason.",
+		}, []string{"reason"}),
+	)
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger client: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToAuth(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	logger.Info("Connecting to auth via gRPC")
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to auth via gRPC: %s", cfg.authGRPCURL))
+	return conn
+}
+
+func connectToThings(cfg config, logger logger.Logger) *grpc.ClientConn {
+	var opts []grpc.DialOption
+	if cfg.clientTLS {
+		if cfg.caCerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.caCerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to load certs: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		logger.Info("gRPC communication is not encrypted")
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(cfg.thingsGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to things service: %s", err))
+		os.Exit(1)
+	}
+	logger.Info(fmt.Sprintf("Established gRPC connection to things via gRPC: %s", cfg.thingsGRPCURL))
+	return conn
+}
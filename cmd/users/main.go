@@ -14,26 +14,34 @@ import (
 	"os"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/email"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/mainflux/users/aes"
+	"github.com/MainfluxLabs/mainflux/users/argon2"
 	"github.com/MainfluxLabs/mainflux/users/bcrypt"
 	"github.com/MainfluxLabs/mainflux/users/emailer"
+	"github.com/MainfluxLabs/mainflux/users/oidc"
 	"github.com/MainfluxLabs/mainflux/users/tracing"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	auditproducer "github.com/MainfluxLabs/mainflux/audit/redis/producer"
 	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
 	"github.com/MainfluxLabs/mainflux/logger"
 	grpcapi "github.com/MainfluxLabs/mainflux/users/api/grpc"
 	httpapi "github.com/MainfluxLabs/mainflux/users/api/http"
 	"github.com/MainfluxLabs/mainflux/users/postgres"
+	usersredis "github.com/MainfluxLabs/mainflux/users/redis"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"github.com/jmoiron/sqlx"
 	opentracing "github.com/opentracing/opentracing-go"
 	stdprometheus "github.com/prometheus/client_golang/prometheus"
@@ -42,6 +50,8 @@ import (
 
 const (
 	stopWaitTime = 5 * time.Second
+	argon2Hasher = "argon2id"
+	bcryptHasher = "bcrypt"
 
 	defLogLevel      = "error"
 	defDBHost        = "localhost"
@@ -68,8 +78,10 @@ const (
 	defAdminEmail       = ""
 	defAdminPassword    = ""
 	defPassRegex        = "^.{8,}$"
+	defSecretKey        = "users"
 
-	defTokenResetEndpoint = "/reset-request" // URL where user lands after click on the reset link from email
+	defTokenResetEndpoint        = "/reset-request" // URL where user lands after click on the reset link from email
+	defTokenVerificationEndpoint = "/verify-email"  // URL where user lands after click on the verification link from email
 
 	defAuthTLS         = "false"
 	defAuthCACerts     = ""
@@ -77,7 +89,37 @@ const (
 	defAuthGRPCTimeout = "1s"
 	defGRPCPort        = "8184"
 
-	defSelfRegister = "true" // By default, everybody can create a user. Otherwise, only admin can create a user.
+	defSelfRegister    = "true" // By default, everybody can create a user. Otherwise, only admin can create a user.
+	defUnverifiedTTL   = "24h"
+	defCleanupInterval = "1h"
+
+	defOIDCProvider     = "" // OpenID Connect login is disabled if the provider name is empty.
+	defOIDCIssuer       = ""
+	defOIDCClientID     = ""
+	defOIDCClientSecret = ""
+	defOIDCRedirectURL  = ""
+	defOIDCScopes       = "openid email profile"
+	defOIDCGroupsClaim  = "groups"
+	defOIDCGroupOrgs    = ""
+	defOIDCMFA          = "false"
+
+	defCacheURL  = "localhost:6379"
+	defCachePass = ""
+	defCacheDB   = "0"
+
+	defLockoutAttempts    = "5" // Lockout is disabled if the number of attempts is zero.
+	defLockoutWindow      = "15m"
+	defLockoutCooldown    = "1m"
+	defLockoutMaxCooldown = "1h"
+
+	defHasher        = argon2Hasher
+	defArgon2Time    = "3"
+	defArgon2Memory  = "65536"
+	defArgon2Threads = "2"
+
+	defAuditESURL  = "" // Auditing is disabled if the audit event store URL is empty.
+	defAuditESPass = ""
+	defAuditESDB   = "0"
 
 	envLogLevel      = "MF_USERS_LOG_LEVEL"
 	envDBHost        = "MF_USERS_DB_HOST"
@@ -97,6 +139,7 @@ const (
 	envAdminEmail    = "MF_USERS_ADMIN_EMAIL"
 	envAdminPassword = "MF_USERS_ADMIN_PASSWORD"
 	envPassRegex     = "MF_USERS_PASS_REGEX"
+	envSecretKey     = "MF_USERS_SECRET_KEY"
 
 	envEmailHost        = "MF_EMAIL_HOST"
 	envEmailPort        = "MF_EMAIL_PORT"
@@ -106,7 +149,8 @@ const (
 	envEmailFromName    = "MF_EMAIL_FROM_NAME"
 	envEmailTemplate    = "MF_EMAIL_TEMPLATE"
 
-	envTokenResetEndpoint = "MF_TOKEN_RESET_ENDPOINT"
+	envTokenResetEndpoint        = "MF_TOKEN_RESET_ENDPOINT"
+	envTokenVerificationEndpoint = "MF_TOKEN_VERIFICATION_ENDPOINT"
 
 	envAuthTLS         = "MF_AUTH_CLIENT_TLS"
 	envAuthCACerts     = "MF_AUTH_CA_CERTS"
@@ -114,7 +158,37 @@ const (
 	envauthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
 	envGRPCPort        = "MF_USERS_GRPC_PORT"
 
-	envSelfRegister = "MF_USERS_ALLOW_SELF_REGISTER"
+	envSelfRegister    = "MF_USERS_ALLOW_SELF_REGISTER"
+	envUnverifiedTTL   = "MF_USERS_UNVERIFIED_TTL"
+	envCleanupInterval = "MF_USERS_CLEANUP_INTERVAL"
+
+	envOIDCProvider     = "MF_USERS_OIDC_PROVIDER"
+	envOIDCIssuer       = "MF_USERS_OIDC_ISSUER"
+	envOIDCClientID     = "MF_USERS_OIDC_CLIENT_ID"
+	envOIDCClientSecret = "MF_USERS_OIDC_CLIENT_SECRET"
+	envOIDCRedirectURL  = "MF_USERS_OIDC_REDIRECT_URL"
+	envOIDCScopes       = "MF_USERS_OIDC_SCOPES"
+	envOIDCGroupsClaim  = "MF_USERS_OIDC_GROUPS_CLAIM"
+	envOIDCGroupOrgs    = "MF_USERS_OIDC_GROUP_ORGS"
+	envOIDCMFA          = "MF_USERS_OIDC_MFA"
+
+	envCacheURL  = "MF_USERS_CACHE_URL"
+	envCachePass = "MF_USERS_CACHE_PASS"
+	envCacheDB   = "MF_USERS_CACHE_DB"
+
+	envLockoutAttempts    = "MF_USERS_LOCKOUT_ATTEMPTS"
+	envLockoutWindow      = "MF_USERS_LOCKOUT_WINDOW"
+	envLockoutCooldown    = "MF_USERS_LOCKOUT_COOLDOWN"
+	envLockoutMaxCooldown = "MF_USERS_LOCKOUT_MAX_COOLDOWN"
+
+	envHasher        = "MF_USERS_HASHER"
+	envArgon2Time    = "MF_USERS_ARGON2_TIME"
+	envArgon2Memory  = "MF_USERS_ARGON2_MEMORY"
+	envArgon2Threads = "MF_USERS_ARGON2_THREADS"
+
+	envAuditESURL  = "MF_AUDIT_ES_URL"
+	envAuditESPass = "MF_AUDIT_ES_PASS"
+	envAuditESDB   = "MF_AUDIT_ES_DB"
 )
 
 type config struct {
@@ -127,6 +201,7 @@ type config struct {
 	serverKey       string
 	jaegerURL       string
 	resetURL        string
+	verifyURL       string
 	authTLS         bool
 	authCACerts     string
 	authURL         string
@@ -134,7 +209,28 @@ type config struct {
 	adminEmail      string
 	adminPassword   string
 	passRegex       *regexp.Regexp
+	secretKey       string
 	selfRegister    bool
+	unverifiedTTL   time.Duration
+	cleanupInterval time.Duration
+	oidcProvider    string
+	oidcConfig      oidc.Config
+	cacheURL        string
+	cachePass       string
+	cacheDB         string
+	lockout         lockoutConfig
+	hasher          string
+	argon2Config    argon2.Config
+	auditESURL      string
+	auditESPass     string
+	auditESDB       string
+}
+
+type lockoutConfig struct {
+	attempts    uint
+	window      time.Duration
+	cooldown    time.Duration
+	maxCooldown time.Duration
 }
 
 func main() {
@@ -166,7 +262,27 @@ func main() {
 	dbTracer, dbCloser := initJaeger("users_db", cfg.jaegerURL, logger)
 	defer dbCloser.Close()
 
-	svc := newService(db, dbTracer, auth, cfg, logger)
+	var lockout users.Lockout
+	if cfg.lockout.attempts > 0 {
+		cacheClient := connectToRedis(cfg.cacheURL, cfg.cachePass, cfg.cacheDB, logger)
+		defer cacheClient.Close()
+		lockout = usersredis.NewLockout(cacheClient, cfg.lockout.attempts, cfg.lockout.window, cfg.lockout.cooldown, cfg.lockout.maxCooldown)
+	}
+
+	var auditPub audit.Publisher
+	if cfg.auditESURL != "" {
+		auditClient := connectToRedis(cfg.auditESURL, cfg.auditESPass, cfg.auditESDB, logger)
+		defer auditClient.Close()
+		auditPub = auditproducer.NewPublisher(auditClient)
+	}
+
+	svc := newService(db, dbTracer, auth, lockout, auditPub, cfg, logger)
+
+	userRepo := postgres.NewUserRepo(postgres.NewDatabase(db))
+	g.Go(func() error {
+		users.StartCleanup(ctx, userRepo, cfg.unverifiedTTL, cfg.cleanupInterval, logger)
+		return nil
+	})
 
 	g.Go(func() error {
 		return startHTTPServer(ctx, tracer, svc, cfg.httpPort, cfg.serverCert, cfg.serverKey, logger)
@@ -210,6 +326,85 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envSelfRegister, err.Error())
 	}
 
+	unverifiedTTL, err := time.ParseDuration(mainflux.Env(envUnverifiedTTL, defUnverifiedTTL))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envUnverifiedTTL, err.Error())
+	}
+
+	cleanupInterval, err := time.ParseDuration(mainflux.Env(envCleanupInterval, defCleanupInterval))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envCleanupInterval, err.Error())
+	}
+
+	lockoutAttempts, err := strconv.ParseUint(mainflux.Env(envLockoutAttempts, defLockoutAttempts), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envLockoutAttempts, err.Error())
+	}
+
+	lockoutWindow, err := time.ParseDuration(mainflux.Env(envLockoutWindow, defLockoutWindow))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envLockoutWindow, err.Error())
+	}
+
+	lockoutCooldown, err := time.ParseDuration(mainflux.Env(envLockoutCooldown, defLockoutCooldown))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envLockoutCooldown, err.Error())
+	}
+
+	lockoutMaxCooldown, err := time.ParseDuration(mainflux.Env(envLockoutMaxCooldown, defLockoutMaxCooldown))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envLockoutMaxCooldown, err.Error())
+	}
+
+	lockout := lockoutConfig{
+		attempts:    uint(lockoutAttempts),
+		window:      lockoutWindow,
+		cooldown:    lockoutCooldown,
+		maxCooldown: lockoutMaxCooldown,
+	}
+
+	hasher := mainflux.Env(envHasher, defHasher)
+	if hasher != argon2Hasher && hasher != bcryptHasher {
+		log.Fatalf("Invalid %s value: %s", envHasher, hasher)
+	}
+
+	argon2Time, err := strconv.ParseUint(mainflux.Env(envArgon2Time, defArgon2Time), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envArgon2Time, err.Error())
+	}
+
+	argon2Memory, err := strconv.ParseUint(mainflux.Env(envArgon2Memory, defArgon2Memory), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envArgon2Memory, err.Error())
+	}
+
+	argon2Threads, err := strconv.ParseUint(mainflux.Env(envArgon2Threads, defArgon2Threads), 10, 8)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envArgon2Threads, err.Error())
+	}
+
+	argon2Config := argon2.Config{
+		Time:    uint32(argon2Time),
+		Memory:  uint32(argon2Memory),
+		Threads: uint8(argon2Threads),
+	}
+
+	oidcMFA, err := strconv.ParseBool(mainflux.Env(envOIDCMFA, defOIDCMFA))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envOIDCMFA, err.Error())
+	}
+
+	oidcConfig := oidc.Config{
+		Issuer:       mainflux.Env(envOIDCIssuer, defOIDCIssuer),
+		ClientID:     mainflux.Env(envOIDCClientID, defOIDCClientID),
+		ClientSecret: mainflux.Env(envOIDCClientSecret, defOIDCClientSecret),
+		RedirectURL:  mainflux.Env(envOIDCRedirectURL, defOIDCRedirectURL),
+		Scopes:       strings.Fields(mainflux.Env(envOIDCScopes, defOIDCScopes)),
+		GroupsClaim:  mainflux.Env(envOIDCGroupsClaim, defOIDCGroupsClaim),
+		GroupOrgs:    parseGroupOrgs(mainflux.Env(envOIDCGroupOrgs, defOIDCGroupOrgs)),
+		MFA:          oidcMFA,
+	}
+
 	dbConfig := postgres.Config{
 		Host:        mainflux.Env(envDBHost, defDBHost),
 		Port:        mainflux.Env(envDBPort, defDBPort),
@@ -242,6 +437,7 @@ func loadConfig() config {
 		serverKey:       mainflux.Env(envServerKey, defServerKey),
 		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
 		resetURL:        mainflux.Env(envTokenResetEndpoint, defTokenResetEndpoint),
+		verifyURL:       mainflux.Env(envTokenVerificationEndpoint, defTokenVerificationEndpoint),
 		authTLS:         tls,
 		authCACerts:     mainflux.Env(envAuthCACerts, defAuthCACerts),
 		authURL:         mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
@@ -249,9 +445,43 @@ func loadConfig() config {
 		adminEmail:      mainflux.Env(envAdminEmail, defAdminEmail),
 		adminPassword:   mainflux.Env(envAdminPassword, defAdminPassword),
 		passRegex:       passRegex,
+		secretKey:       mainflux.Env(envSecretKey, defSecretKey),
+		oidcProvider:    mainflux.Env(envOIDCProvider, defOIDCProvider),
+		oidcConfig:      oidcConfig,
 		selfRegister:    selfRegister,
+		unverifiedTTL:   unverifiedTTL,
+		cleanupInterval: cleanupInterval,
+		cacheURL:        mainflux.Env(envCacheURL, defCacheURL),
+		cachePass:       mainflux.Env(envCachePass, defCachePass),
+		cacheDB:         mainflux.Env(envCacheDB, defCacheDB),
+		lockout:         lockout,
+		hasher:          hasher,
+		argon2Config:    argon2Config,
+		auditESURL:      mainflux.Env(envAuditESURL, defAuditESURL),
+		auditESPass:     mainflux.Env(envAuditESPass, defAuditESPass),
+		auditESDB:       mainflux.Env(envAuditESDB, defAuditESDB),
+	}
+
+}
+
+// parseGroupOrgs parses comma-separated list of identity provider groups
+// mapped to orgs in the "group=orgID" format.
+func parseGroupOrgs(s string) map[string][]string {
+	groupOrgs := make(map[string][]string)
+	for _, g := range strings.Split(s, ",") {
+		if strings.TrimSpace(g) == "" {
+			continue
+		}
+
+		kv := strings.SplitN(g, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[1]) == "" {
+			log.Fatalf("Invalid %s value: %s", envOIDCGroupOrgs, g)
+		}
+		group := strings.TrimSpace(kv[0])
+		groupOrgs[group] = append(groupOrgs[group], strings.TrimSpace(kv[1]))
 	}
 
+	return groupOrgs
 }
 
 func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
@@ -311,19 +541,59 @@ func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger)
 	return authapi.NewClient(tracer, conn, cfg.authGRPCTimeout), conn.Close
 }
 
-func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceClient, c config, logger logger.Logger) users.Service {
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
+
+func newService(db *sqlx.DB, tracer opentracing.Tracer, ac mainflux.AuthServiceClient, lockout users.Lockout, auditPub audit.Publisher, c config, logger logger.Logger) users.Service {
 	database := postgres.NewDatabase(db)
 	hasher := bcrypt.New()
+	if c.hasher == argon2Hasher {
+		h, err := argon2.New(c.argon2Config)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to create argon2 hasher: %s", err))
+			os.Exit(1)
+		}
+		hasher = h
+	}
+	cipher, err := aes.New(c.secretKey)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to create secrets cipher: %s", err))
+		os.Exit(1)
+	}
 	userRepo := tracing.UserRepositoryMiddleware(postgres.NewUserRepo(database), tracer)
 
-	emailer, err := emailer.New(c.resetURL, &c.emailConf)
+	emailer, err := emailer.New(c.resetURL, c.verifyURL, &c.emailConf)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to configure e-mailing util: %s", err.Error()))
 	}
 
 	idProvider := uuid.New()
 
-	svc := users.New(userRepo, hasher, ac, emailer, idProvider, c.passRegex)
+	providers := map[string]users.IdentityProvider{}
+	if c.oidcProvider != "" {
+		p, err := oidc.New(context.Background(), c.oidcConfig)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to configure %s identity provider: %s", c.oidcProvider, err))
+			os.Exit(1)
+		}
+		providers[c.oidcProvider] = p
+	}
+
+	svc := users.New(userRepo, hasher, cipher, ac, emailer, idProvider, c.passRegex, providers, lockout)
+	if auditPub != nil {
+		svc = httpapi.AuditMiddleware(svc, ac, auditPub, logger)
+	}
 	svc = httpapi.LoggingMiddleware(svc, logger)
 	svc = httpapi.MetricsMiddleware(
 		svc,
@@ -0,0 +1,304 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	authapi "github.com/MainfluxLabs/mainflux/auth/api/grpc"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/notifiers"
+	"github.com/MainfluxLabs/mainflux/consumers/notifiers/api"
+	"github.com/MainfluxLabs/mainflux/consumers/notifiers/webhook"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/ulid"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	opentracing "github.com/opentracing/opentracing-go"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	jconfig "github.com/uber/jaeger-client-go/config"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	svcName      = "webhook-notifier"
+	defLogLevel  = "error"
+	defFrom      = ""
+	defJaegerURL = ""
+	defBrokerURL = "nats://localhost:4222"
+
+	defHeaders        = ""
+	defSecret         = ""
+	defRetries        = "3"
+	defBackoff        = "1s"
+	defTimeout        = "5s"
+	defWorkers        = "10"
+	defQueueSize      = "1000"
+	defAllowPrivate   = "false"
+	defDeadLetterFile = ""
+
+	defAuthTLS         = "false"
+	defAuthCACerts     = ""
+	defAuthGRPCURL     = "localhost:8181"
+	defAuthGRPCTimeout = "1s"
+
+	envLogLevel  = "MF_WEBHOOK_NOTIFIER_LOG_LEVEL"
+	envFrom      = "MF_WEBHOOK_NOTIFIER_FROM"
+	envJaegerURL = "MF_JAEGER_URL"
+	envBrokerURL = "MF_BROKER_URL"
+
+	envHeaders        = "MF_WEBHOOK_NOTIFIER_HEADERS"
+	envSecret         = "MF_WEBHOOK_NOTIFIER_SECRET"
+	envRetries        = "MF_WEBHOOK_NOTIFIER_RETRIES"
+	envBackoff        = "MF_WEBHOOK_NOTIFIER_BACKOFF"
+	envTimeout        = "MF_WEBHOOK_NOTIFIER_TIMEOUT"
+	envWorkers        = "MF_WEBHOOK_NOTIFIER_WORKERS"
+	envQueueSize      = "MF_WEBHOOK_NOTIFIER_QUEUE_SIZE"
+	envAllowPrivate   = "MF_WEBHOOK_NOTIFIER_ALLOW_PRIVATE"
+	envDeadLetterFile = "MF_WEBHOOK_NOTIFIER_DEAD_LETTER_FILE"
+
+	envAuthTLS         = "MF_AUTH_CLIENT_TLS"
+	envAuthCACerts     = "MF_AUTH_CA_CERTS"
+	envAuthGRPCURL     = "MF_AUTH_GRPC_URL"
+	envauthGRPCTimeout = "MF_AUTH_GRPC_TIMEOUT"
+)
+
+type config struct {
+	brokerURL       string
+	logLevel        string
+	webhookConf     webhook.Config
+	deadLetterFile  string
+	from            string
+	jaegerURL       string
+	authTLS         bool
+	authCACerts     string
+	authGRPCURL     string
+	authGRPCTimeout time.Duration
+}
+
+func main() {
+	cfg := loadConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	logger, err := logger.New(os.Stdout, cfg.logLevel)
+	if err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	pubSub, err := brokers.NewPubSub(cfg.brokerURL, "", logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to message broker: %s", err))
+		os.Exit(1)
+	}
+	defer pubSub.Close()
+
+	authTracer, closer := initJaeger("auth", cfg.jaegerURL, logger)
+	defer closer.Close()
+
+	auth, close := connectToAuth(cfg, authTracer, logger)
+	if close != nil {
+		defer close()
+	}
+
+	svc := newService(auth, cfg, logger)
+
+	if err = consumers.Start(svcName, pubSub, svc, brokers.SubjectWebhook); err != nil {
+		logger.Error(fmt.Sprintf("Failed to create webhook notifier: %s", err))
+	}
+
+	g.Go(func() error {
+		if sig := errors.SignalHandler(ctx); sig != nil {
+			cancel()
+			logger.Info(fmt.Sprintf("webhook notifier service shutdown by signal: %s", sig))
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("webhook notifier service terminated: %s", err))
+	}
+
+}
+
+func loadConfig() config {
+	authGRPCTimeout, err := time.ParseDuration(mainflux.Env(envauthGRPCTimeout, defAuthGRPCTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envauthGRPCTimeout, err.Error())
+	}
+
+	tls, err := strconv.ParseBool(mainflux.Env(envAuthTLS, defAuthTLS))
+	if err != nil {
+		log.Fatalf("Invalid value passed for %s\n", envAuthTLS)
+	}
+
+	retries, err := strconv.ParseUint(mainflux.Env(envRetries, defRetries), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envRetries, err.Error())
+	}
+
+	backoff, err := time.ParseDuration(mainflux.Env(envBackoff, defBackoff))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envBackoff, err.Error())
+	}
+
+	timeout, err := time.ParseDuration(mainflux.Env(envTimeout, defTimeout))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envTimeout, err.Error())
+	}
+
+	workers, err := strconv.ParseUint(mainflux.Env(envWorkers, defWorkers), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envWorkers, err.Error())
+	}
+
+	queueSize, err := strconv.ParseUint(mainflux.Env(envQueueSize, defQueueSize), 10, 32)
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envQueueSize, err.Error())
+	}
+
+	allowPrivate, err := strconv.ParseBool(mainflux.Env(envAllowPrivate, defAllowPrivate))
+	if err != nil {
+		log.Fatalf("Invalid %s value: %s", envAllowPrivate, err.Error())
+	}
+
+	webhookConf := webhook.Config{
+		Headers:      parseHeaders(mainflux.Env(envHeaders, defHeaders)),
+		Secret:       mainflux.Env(envSecret, defSecret),
+		Retries:      uint(retries),
+		Backoff:      backoff,
+		Timeout:      timeout,
+		Workers:      uint(workers),
+		QueueSize:    uint(queueSize),
+		AllowPrivate: allowPrivate,
+	}
+
+	return config{
+		logLevel:        mainflux.Env(envLogLevel, defLogLevel),
+		brokerURL:       mainflux.Env(envBrokerURL, defBrokerURL),
+		webhookConf:     webhookConf,
+		deadLetterFile:  mainflux.Env(envDeadLetterFile, defDeadLetterFile),
+		from:            mainflux.Env(envFrom, defFrom),
+		jaegerURL:       mainflux.Env(envJaegerURL, defJaegerURL),
+		authTLS:         tls,
+		authCACerts:     mainflux.Env(envAuthCACerts, defAuthCACerts),
+		authGRPCURL:     mainflux.Env(envAuthGRPCURL, defAuthGRPCURL),
+		authGRPCTimeout: authGRPCTimeout,
+	}
+
+}
+
+// parseHeaders parses comma-separated list of headers in the "Key:Value" format.
+func parseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, h := range strings.Split(s, ",") {
+		if strings.TrimSpace(h) == "" {
+			continue
+		}
+
+		kv := strings.SplitN(h, ":", 2)
+		if len(kv) != 2 {
+			log.Fatalf("Invalid %s value: %s", envHeaders, h)
+		}
+		headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+
+	return headers
+}
+
+func initJaeger(svcName, url string, logger logger.Logger) (opentracing.Tracer, io.Closer) {
+	if url == "" {
+		return opentracing.NoopTracer{}, ioutil.NopCloser(nil)
+	}
+
+	tracer, closer, err := jconfig.Configuration{
+		ServiceName: svcName,
+		Sampler: &jconfig.SamplerConfig{
+			Type:  "const",
+			Param: 1,
+		},
+		Reporter: &jconfig.ReporterConfig{
+			LocalAgentHostPort: url,
+			LogSpans:           true,
+		},
+	}.NewTracer()
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
+		os.Exit(1)
+	}
+
+	return tracer, closer
+}
+
+func connectToAuth(cfg config, tracer opentracing.Tracer, logger logger.Logger) (mainflux.AuthServiceClient, func() error) {
+	var opts []grpc.DialOption
+	if cfg.authTLS {
+		if cfg.authCACerts != "" {
+			tpc, err := credentials.NewClientTLSFromFile(cfg.authCACerts, "")
+			if err != nil {
+				logger.Error(fmt.Sprintf("Failed to create tls credentials: %s", err))
+				os.Exit(1)
+			}
+			opts = append(opts, grpc.WithTransportCredentials(tpc))
+		}
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+		logger.Info("gRPC communication is not encrypted")
+	}
+
+	conn, err := grpc.Dial(cfg.authGRPCURL, opts...)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to auth service: %s", err))
+		os.Exit(1)
+	}
+
+	return authapi.NewClient(tracer, conn, cfg.authGRPCTimeout), conn.Close
+}
+
+func newService(ac mainflux.AuthServiceClient, c config, logger logger.Logger) notifiers.Service {
+	idp := ulid.New()
+
+	deadLetter := io.Writer(os.Stderr)
+	if c.deadLetterFile != "" {
+		f, err := os.OpenFile(c.deadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to open dead-letter file: %s", err))
+			os.Exit(1)
+		}
+		deadLetter = f
+	}
+
+	notifier := webhook.New(c.webhookConf, deadLetter)
+	svc := notifiers.New(ac, idp, notifier, c.from)
+	svc = api.LoggingMiddleware(svc, logger)
+	svc = api.MetricsMiddleware(
+		svc,
+		kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: "notifier",
+			Subsystem: "webhook",
+			Name:      "request_count",
+			Help:      "Number of requests received.",
+		}, []string{"method"}),
+		kitprometheus.NewSummaryFrom(stdprometheus.SummaryOpts{
+			Namespace: "notifier",
+			Subsystem: "webhook",
+			Name:      "request_latency_microseconds",
+			Help:      "Total duration of requests in microseconds.",
+		}, []string{"method"}),
+	)
+
+	return svc
+}
@@ -16,12 +16,14 @@ import (
 
 	"github.com/MainfluxLabs/mainflux"
 	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	"github.com/go-redis/redis/v8"
 	"golang.org/x/sync/errgroup"
 
 	logger "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
 	thingsapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
 	adapter "github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/api"
@@ -44,6 +46,9 @@ const (
 	defJaegerURL         = ""
 	defThingsGRPCURL     = "localhost:8183"
 	defThingsGRPCTimeout = "1s"
+	defAuthCacheURL      = "localhost:6379"
+	defAuthCachePass     = ""
+	defAuthCacheDB       = "0"
 
 	envPort              = "MF_WS_ADAPTER_PORT"
 	envBrokerURL         = "MF_BROKER_URL"
@@ -53,6 +58,9 @@ const (
 	envJaegerURL         = "MF_JAEGER_URL"
 	envThingsGRPCURL     = "MF_THINGS_AUTH_GRPC_URL"
 	envThingsGRPCTimeout = "MF_THINGS_AUTH_GRPC_TIMEOUT"
+	envAuthCacheURL      = "MF_AUTH_CACHE_URL"
+	envAuthCachePass     = "MF_AUTH_CACHE_PASS"
+	envAuthCacheDB       = "MF_AUTH_CACHE_DB"
 )
 
 type config struct {
@@ -64,6 +72,10 @@ type config struct {
 	jaegerURL         string
 	thingsGRPCURL     string
 	thingsGRPCTimeout time.Duration
+	authCacheURL      string
+	authCachePass     string
+	authCacheDB       string
+	rateLimit         ratelimit.Config
 }
 
 func main() {
@@ -92,6 +104,12 @@ func main() {
 	}
 	defer nps.Close()
 
+	if cfg.rateLimit.Enabled() {
+		rc := connectToRedis(cfg.authCacheURL, cfg.authCachePass, cfg.authCacheDB, logger)
+		defer rc.Close()
+		nps = ratelimit.NewPubSub(nps, ratelimit.NewRedisLimiter(rc, cfg.rateLimit))
+	}
+
 	svc := newService(tc, nps, logger)
 
 	g.Go(func() error {
@@ -123,6 +141,11 @@ func loadConfig() config {
 		log.Fatalf("Invalid %s value: %s", envThingsGRPCTimeout, err.Error())
 	}
 
+	rateLimit, err := ratelimit.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid rate limit configuration: %s", err)
+	}
+
 	return config{
 		brokerURL:         mainflux.Env(envBrokerURL, defBrokerURL),
 		port:              mainflux.Env(envPort, defPort),
@@ -132,6 +155,10 @@ func loadConfig() config {
 		jaegerURL:         mainflux.Env(envJaegerURL, defJaegerURL),
 		thingsGRPCURL:     mainflux.Env(envThingsGRPCURL, defThingsGRPCURL),
 		thingsGRPCTimeout: thingsGRPCTimeout,
+		authCacheURL:      mainflux.Env(envAuthCacheURL, defAuthCacheURL),
+		authCachePass:     mainflux.Env(envAuthCachePass, defAuthCachePass),
+		authCacheDB:       mainflux.Env(envAuthCacheDB, defAuthCacheDB),
+		rateLimit:         rateLimit,
 	}
 }
 
@@ -230,3 +257,17 @@ func startWSServer(ctx context.Context, cfg config, svc adapter.Service, l logge
 		return err
 	}
 }
+
+func connectToRedis(redisURL, redisPass, redisDB string, logger logger.Logger) *redis.Client {
+	db, err := strconv.Atoi(redisDB)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to connect to redis: %s", err))
+		os.Exit(1)
+	}
+
+	return redis.NewClient(&redis.Options{
+		Addr:     redisURL,
+		Password: redisPass,
+		DB:       db,
+	})
+}
@@ -57,12 +57,16 @@ func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
 
 func (svc *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
 	cr := &mainflux.ConnByKeyReq{
-		Key: key,
+		Key:    key,
+		ChanID: msg.Channel,
 	}
 	conn, err := svc.things.GetConnByKey(ctx, cr)
 	if err != nil {
 		return errors.Wrap(errors.ErrAuthorization, err)
 	}
+	if err := messaging.ValidatePayload(conn, msg.Payload); err != nil {
+		return err
+	}
 	m := messaging.CreateMessage(conn, msg.Protocol, msg.Subtopic, &msg.Payload)
 
 	return svc.pubsub.Publish(m)
@@ -70,7 +74,8 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg messagin
 
 func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
 	cr := &mainflux.ConnByKeyReq{
-		Key: key,
+		Key:    key,
+		ChanID: chanID,
 	}
 	if _, err := svc.things.GetConnByKey(ctx, cr); err != nil {
 		return errors.Wrap(errors.ErrAuthorization, err)
@@ -84,7 +89,8 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 
 func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
 	cr := &mainflux.ConnByKeyReq{
-		Key: key,
+		Key:    key,
+		ChanID: chanID,
 	}
 	conn, err := svc.things.GetConnByKey(ctx, cr)
 	if err != nil {
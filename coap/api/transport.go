@@ -4,6 +4,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io/ioutil"
@@ -16,6 +17,7 @@ import (
 	log "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
 	"github.com/go-zoo/bone"
 	"github.com/plgd-dev/go-coap/v2/message"
 	"github.com/plgd-dev/go-coap/v2/message/codes"
@@ -27,6 +29,9 @@ const (
 	protocol     = "coap"
 	authQuery    = "auth"
 	startObserve = 0 // observe option value that indicates start of observation
+
+	// tooManyRequests is the 4.29 response code defined by RFC 8516.
+	tooManyRequests codes.Code = 157
 )
 
 var errBadOptions = errors.New("bad options")
@@ -98,6 +103,11 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		case errors.Contains(err, errors.ErrAuthorization),
 			errors.Contains(err, errors.ErrAuthentication):
 			resp.Code = codes.Unauthorized
+		case errors.Contains(err, messaging.ErrSchemaValidation):
+			resp.Code = codes.BadRequest
+			resp.Body = bytes.NewReader([]byte(err.Error()))
+		case errors.Contains(err, ratelimit.ErrLimitExceeded):
+			resp.Code = tooManyRequests
 		default:
 			resp.Code = codes.InternalServerError
 		}
@@ -129,6 +139,11 @@ func decodeMessage(msg *mux.Message) (messaging.Message, error) {
 		return messaging.Message{}, err
 	}
 
+	chanID, err := messaging.ExtractChannel(path)
+	if err != nil {
+		return messaging.Message{}, err
+	}
+
 	subtopic, err := messaging.ExtractSubtopic(path)
 	if err != nil {
 		return messaging.Message{}, messaging.ErrMalformedSubtopic
@@ -142,6 +157,7 @@ func decodeMessage(msg *mux.Message) (messaging.Message, error) {
 
 	ret := messaging.Message{
 		Protocol: protocol,
+		Channel:  chanID,
 		Subtopic: subject,
 		Payload:  []byte{},
 		Created:  time.Now().UnixNano(),
@@ -4,6 +4,7 @@
 package consumers
 
 import (
+	"context"
 	"errors"
 
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
@@ -24,20 +25,31 @@ type Consumer interface {
 
 // Start method starts consuming messages received from Message broker.
 func Start(id string, sub messaging.Subscriber, consumer Consumer, subjects ...string) error {
+	return start(id, sub, consumer, nil, subjects)
+}
+
+// StartWithDeadLetters starts consuming messages received from Message broker.
+// Messages which fail to be transformed or consumed are added to the dead
+// letters instead of being dropped. If the broker redelivers the messages,
+// the message is added to the dead letters once its last delivery fails.
+func StartWithDeadLetters(id string, sub messaging.Subscriber, consumer Consumer, dls DeadLetters, subjects ...string) error {
+	return start(id, sub, consumer, dls, subjects)
+}
+
+func start(id string, sub messaging.Subscriber, consumer Consumer, dls DeadLetters, subjects []string) error {
 	for _, subject := range subjects {
-		var transformer transformers.Transformer
-		switch subject {
-		case brokers.SubjectSenML:
-			transformer = senml.New()
-		case brokers.SubjectJSON:
-			transformer = json.New()
-		case brokers.SubjectSmtp, brokers.SubjectSmpp:
-			transformer = nil
-		default:
-			return errUnkownSubject
+		transformer, err := transformer(subject)
+		if err != nil {
+			return err
+		}
+
+		h := handle(transformer, consumer)
+		var mh messaging.MessageHandler = h
+		if dls != nil {
+			mh = handleDeadLetters(id, subject, h, dls)
 		}
 
-		if err := sub.Subscribe(id, subject, handle(transformer, consumer)); err != nil {
+		if err := sub.Subscribe(id, subject, mh); err != nil {
 			return err
 		}
 	}
@@ -45,27 +57,88 @@ func Start(id string, sub messaging.Subscriber, consumer Consumer, subjects ...s
 	return nil
 }
 
-func handle(t transformers.Transformer, c Consumer) handleFunc {
-	return func(msg messaging.Message) error {
+func transformer(subject string) (transformers.Transformer, error) {
+	switch subject {
+	case brokers.SubjectSenML:
+		return senml.New(), nil
+	case brokers.SubjectJSON:
+		return json.New(), nil
+	case brokers.SubjectSmtp, brokers.SubjectSmpp, brokers.SubjectWebhook:
+		return nil, nil
+	default:
+		return nil, errUnkownSubject
+	}
+}
+
+// handle returns the handler which transforms and consumes the messages.
+// The returned failure reason is empty if the message is handled.
+func handle(t transformers.Transformer, c Consumer) reasonFunc {
+	return func(msg messaging.Message) (string, error) {
 		m := interface{}(msg)
 		var err error
 		if t != nil {
 			m, err = t.Transform(msg)
 			if err != nil {
-				return err
+				return ReasonTransform, err
 			}
 		}
-		return c.Consume(m)
+		if err := c.Consume(m); err != nil {
+			return ReasonConsume, err
+		}
+		return "", nil
+	}
+}
+
+// handleDeadLetters returns the handler which adds the messages that failed
+// to be handled on their last delivery to the dead letters. The handling error
+// is returned if the message is going to be redelivered or if the dead letter
+// can't be added, leaving the message to the broker.
+func handleDeadLetters(id, subject string, h reasonFunc, dls DeadLetters) deliveryFunc {
+	return func(msg messaging.Message, d messaging.Delivery) error {
+		reason, err := h(msg)
+		if err == nil || !d.Last {
+			return err
+		}
+
+		dl := DeadLetter{
+			Consumer: id,
+			Subject:  subject,
+			Reason:   reason,
+			Error:    err.Error(),
+			Attempts: d.Attempt,
+			Message:  msg,
+		}
+		if _, e := dls.Add(context.Background(), dl); e != nil {
+			return err
+		}
+
+		return nil
 	}
 }
 
-type handleFunc func(msg messaging.Message) error
+type reasonFunc func(msg messaging.Message) (string, error)
 
-func (h handleFunc) Handle(msg messaging.Message) error {
-	return h(msg)
+func (h reasonFunc) Handle(msg messaging.Message) error {
+	_, err := h(msg)
+	return err
+}
+
+func (h reasonFunc) Cancel() error {
+	return nil
+}
+
+type deliveryFunc func(msg messaging.Message, d messaging.Delivery) error
+
+// Handle handles the message of the broker which doesn't redeliver messages,
+// so each delivery is the first and the last one.
+func (h deliveryFunc) Handle(msg messaging.Message) error {
+	return h(msg, messaging.Delivery{Attempt: 1, Last: true})
+}
 
+func (h deliveryFunc) HandleDelivery(msg messaging.Message, d messaging.Delivery) error {
+	return h(msg, d)
 }
 
-func (h handleFunc) Cancel() error {
+func (h deliveryFunc) Cancel() error {
 	return nil
 }
@@ -0,0 +1,320 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Reasons of the message handling failures.
+const (
+	ReasonTransform = "transform"
+	ReasonConsume   = "consume"
+)
+
+var errMissingCipher = errors.New("missing cipher of the encrypted dead letter")
+
+var (
+	// ErrPublishDeadLetter indicates failure occurred while publishing the
+	// dead letter to the dead-letter subject.
+	ErrPublishDeadLetter = errors.New("failed to publish dead letter")
+
+	// ErrReplay indicates that the replayed message failed to be handled.
+	ErrReplay = errors.New("failed to replay dead letter")
+
+	// ErrRetrieveDeadLetters indicates failure occurred while retrieving the
+	// dead letters kept by the message broker.
+	ErrRetrieveDeadLetters = errors.New("failed to retrieve dead letters")
+)
+
+// PayloadCipher specifies the API for encrypting the payloads of the dead
+// letters, so the messages of the encrypted channels aren't exposed in
+// plaintext by the dead letters.
+type PayloadCipher interface {
+	// Encrypt encrypts the payload of the message sent to the channel.
+	Encrypt(ctx context.Context, chanID string, payload []byte) ([]byte, error)
+
+	// Decrypt decrypts the payload encrypted by Encrypt.
+	Decrypt(ctx context.Context, chanID string, payload []byte) ([]byte, error)
+}
+
+// DeadLetter represents the message which the consumer failed to handle.
+type DeadLetter struct {
+	ID        string            `json:"id"`
+	Consumer  string            `json:"consumer"`
+	Subject   string            `json:"subject"`
+	Reason    string            `json:"reason"`
+	Error     string            `json:"error"`
+	Attempts  uint64            `json:"attempts"`
+	Encrypted bool              `json:"encrypted"`
+	Message   messaging.Message `json:"message"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// DeadLettersPage contains a page of dead letters.
+type DeadLettersPage struct {
+	Total       uint64
+	Offset      uint64
+	Limit       uint64
+	DeadLetters []DeadLetter
+}
+
+// DeadLetters specifies the API for keeping and replaying the messages which
+// the consumer failed to handle.
+type DeadLetters interface {
+	// Add publishes the dead letter to the dead-letter subject of the
+	// consumer and keeps it for replay.
+	Add(ctx context.Context, dl DeadLetter) (DeadLetter, error)
+
+	// List retrieves the subset of the kept dead letters, oldest first.
+	List(ctx context.Context, offset, limit uint64) (DeadLettersPage, error)
+
+	// Replay handles the message of the dead letter again. The dead letter
+	// is removed once its message is successfully handled.
+	Replay(ctx context.Context, id string) error
+}
+
+var _ DeadLetters = (*deadLetters)(nil)
+
+type deadLetters struct {
+	mu         sync.Mutex
+	name       string
+	consumer   Consumer
+	publisher  messaging.DeadLetterPublisher
+	store      messaging.DeadLetterStore
+	cipher     PayloadCipher
+	idProvider mainflux.IDProvider
+	capacity   int
+	letters    []DeadLetter
+}
+
+// storedLetter is the dead letter along with its sequence in the store.
+type storedLetter struct {
+	DeadLetter
+	seq uint64
+}
+
+// NewDeadLetters returns the dead letters of the named consumer. All of them
+// are published using the given publisher, while up to the capacity of the
+// latest dead letters are available for replay. If the publisher implements
+// messaging.DeadLetterStore, the dead letters are kept by the message broker,
+// otherwise they are kept in memory. If the cipher is given, the message
+// payloads of the dead letters are encrypted.
+func NewDeadLetters(name string, consumer Consumer, publisher messaging.DeadLetterPublisher, cipher PayloadCipher, idp mainflux.IDProvider, capacity int) DeadLetters {
+	store, _ := publisher.(messaging.DeadLetterStore)
+	return &deadLetters{
+		name:       name,
+		consumer:   consumer,
+		publisher:  publisher,
+		store:      store,
+		cipher:     cipher,
+		idProvider: idp,
+		capacity:   capacity,
+	}
+}
+
+func (d *deadLetters) Add(ctx context.Context, dl DeadLetter) (DeadLetter, error) {
+	id, err := d.idProvider.ID()
+	if err != nil {
+		return DeadLetter{}, err
+	}
+	dl.ID = id
+	dl.CreatedAt = time.Now()
+
+	if d.cipher != nil && !dl.Encrypted {
+		payload, err := d.cipher.Encrypt(ctx, dl.Message.Channel, dl.Message.Payload)
+		if err != nil {
+			return DeadLetter{}, err
+		}
+		dl.Message.Payload = payload
+		dl.Encrypted = true
+	}
+
+	if err := d.publish(dl); err != nil {
+		return DeadLetter{}, err
+	}
+	if d.store != nil {
+		return dl, nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.letters = append(d.letters, dl)
+	if len(d.letters) > d.capacity {
+		d.letters = d.letters[len(d.letters)-d.capacity:]
+	}
+
+	return dl, nil
+}
+
+func (d *deadLetters) List(_ context.Context, offset, limit uint64) (DeadLettersPage, error) {
+	letters, err := d.all()
+	if err != nil {
+		return DeadLettersPage{}, err
+	}
+
+	total := uint64(len(letters))
+	page := DeadLettersPage{
+		Total:       total,
+		Offset:      offset,
+		Limit:       limit,
+		DeadLetters: []DeadLetter{},
+	}
+
+	if offset >= total {
+		return page, nil
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	for _, l := range letters[offset:end] {
+		page.DeadLetters = append(page.DeadLetters, l.DeadLetter)
+	}
+
+	return page, nil
+}
+
+func (d *deadLetters) Replay(ctx context.Context, id string) error {
+	dl, err := d.retrieve(id)
+	if err != nil {
+		return err
+	}
+
+	t, err := transformer(dl.Subject)
+	if err != nil {
+		return errors.Wrap(ErrReplay, err)
+	}
+
+	msg := dl.Message
+	if dl.Encrypted {
+		if d.cipher == nil {
+			return errors.Wrap(ErrReplay, errMissingCipher)
+		}
+		if msg.Payload, err = d.cipher.Decrypt(ctx, msg.Channel, msg.Payload); err != nil {
+			return errors.Wrap(ErrReplay, err)
+		}
+	}
+
+	reason, err := handle(t, d.consumer)(msg)
+	if d.store != nil {
+		return d.replayed(dl, reason, err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i := range d.letters {
+		if d.letters[i].ID != id {
+			continue
+		}
+		if err == nil {
+			d.letters = append(d.letters[:i], d.letters[i+1:]...)
+			return nil
+		}
+		d.letters[i].Reason = reason
+		d.letters[i].Error = err.Error()
+		d.letters[i].Attempts++
+		break
+	}
+
+	if err != nil {
+		return errors.Wrap(ErrReplay, err)
+	}
+
+	return nil
+}
+
+// replayed updates the dead letter kept by the store once its message is
+// replayed. The handled dead letter is removed, while the dead letter which
+// failed again is published with the updated attempts, replacing the old one.
+func (d *deadLetters) replayed(dl storedLetter, reason string, err error) error {
+	if err != nil {
+		failed := dl.DeadLetter
+		failed.Reason = reason
+		failed.Error = err.Error()
+		failed.Attempts++
+		if e := d.publish(failed); e != nil {
+			return errors.Wrap(ErrReplay, e)
+		}
+	}
+
+	if e := d.store.RemoveDeadLetter(dl.seq); e != nil {
+		return errors.Wrap(ErrReplay, e)
+	}
+
+	if err != nil {
+		return errors.Wrap(ErrReplay, err)
+	}
+
+	return nil
+}
+
+func (d *deadLetters) publish(dl DeadLetter) error {
+	data, err := json.Marshal(dl)
+	if err != nil {
+		return errors.Wrap(ErrPublishDeadLetter, err)
+	}
+	if err := d.publisher.PublishDeadLetter(dl.Consumer, data); err != nil {
+		return errors.Wrap(ErrPublishDeadLetter, err)
+	}
+
+	return nil
+}
+
+// all returns the dead letters available for replay, oldest first.
+func (d *deadLetters) all() ([]storedLetter, error) {
+	if d.store == nil {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		letters := make([]storedLetter, 0, len(d.letters))
+		for _, dl := range d.letters {
+			letters = append(letters, storedLetter{DeadLetter: dl})
+		}
+		return letters, nil
+	}
+
+	stored, err := d.store.RetrieveDeadLetters(d.name)
+	if err != nil {
+		return nil, errors.Wrap(ErrRetrieveDeadLetters, err)
+	}
+
+	letters := []storedLetter{}
+	for _, s := range stored {
+		var dl DeadLetter
+		if err := json.Unmarshal(s.Data, &dl); err != nil {
+			continue
+		}
+		letters = append(letters, storedLetter{DeadLetter: dl, seq: s.Seq})
+	}
+	if len(letters) > d.capacity {
+		letters = letters[len(letters)-d.capacity:]
+	}
+
+	return letters, nil
+}
+
+func (d *deadLetters) retrieve(id string) (storedLetter, error) {
+	letters, err := d.all()
+	if err != nil {
+		return storedLetter{}, err
+	}
+
+	for _, dl := range letters {
+		if dl.ID == id {
+			return dl, nil
+		}
+	}
+
+	return storedLetter{}, errors.ErrNotFound
+}
@@ -0,0 +1,488 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encmocks "github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	consumerName = "writer"
+	capacity     = 2
+)
+
+var (
+	errFailed = errors.New("failed")
+	senmlMsg  = messaging.Message{
+		Channel: "1",
+		Payload: []byte(`[{"bn":"base-name","n":"temperature","v":17}]`),
+		Profile: &messaging.Profile{ContentType: messaging.SenmlContentType},
+	}
+	invalidMsg = messaging.Message{
+		Channel: "1",
+		Payload: []byte("invalid"),
+		Profile: &messaging.Profile{ContentType: messaging.SenmlContentType},
+	}
+)
+
+func TestStartWithDeadLetters(t *testing.T) {
+	cases := []struct {
+		desc     string
+		msg      messaging.Message
+		fail     bool
+		reason   string
+		dead     int
+		consumed int
+	}{
+		{
+			desc:     "handle valid message",
+			msg:      senmlMsg,
+			dead:     0,
+			consumed: 1,
+		},
+		{
+			desc:   "handle message failing transformation",
+			msg:    invalidMsg,
+			reason: consumers.ReasonTransform,
+			dead:   1,
+		},
+		{
+			desc:   "handle message failing consumption",
+			msg:    senmlMsg,
+			fail:   true,
+			reason: consumers.ReasonConsume,
+			dead:   1,
+		},
+	}
+
+	for _, tc := range cases {
+		sub := newSubscriber()
+		pub := newDeadLetterPublisher()
+		c := &consumer{fail: tc.fail}
+		dls := consumers.NewDeadLetters(consumerName, c, pub, nil, uuid.NewMock(), capacity)
+
+		err := consumers.StartWithDeadLetters(consumerName, sub, c, dls, brokers.SubjectSenML)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		err = sub.handlers[brokers.SubjectSenML].Handle(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+		assert.Equal(t, tc.consumed, c.consumed, fmt.Sprintf("%s: expected %d consumed got %d", tc.desc, tc.consumed, c.consumed))
+
+		page, err := dls.List(context.Background(), 0, 10)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		require.Len(t, page.DeadLetters, tc.dead, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.dead, len(page.DeadLetters)))
+		require.Len(t, pub.published, tc.dead, fmt.Sprintf("%s: expected %d published got %d", tc.desc, tc.dead, len(pub.published)))
+		if tc.dead == 0 {
+			continue
+		}
+
+		dl := page.DeadLetters[0]
+		assert.Equal(t, consumerName, dl.Consumer, fmt.Sprintf("%s: expected consumer %s got %s", tc.desc, consumerName, dl.Consumer))
+		assert.Equal(t, brokers.SubjectSenML, dl.Subject, fmt.Sprintf("%s: expected subject %s got %s", tc.desc, brokers.SubjectSenML, dl.Subject))
+		assert.Equal(t, tc.reason, dl.Reason, fmt.Sprintf("%s: expected reason %s got %s", tc.desc, tc.reason, dl.Reason))
+		assert.Equal(t, uint64(1), dl.Attempts, fmt.Sprintf("%s: expected 1 attempt got %d", tc.desc, dl.Attempts))
+		assert.Equal(t, tc.msg, dl.Message, fmt.Sprintf("%s: expected message %v got %v", tc.desc, tc.msg, dl.Message))
+
+		var published consumers.DeadLetter
+		err = json.Unmarshal(pub.published[0], &published)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, dl.ID, published.ID, fmt.Sprintf("%s: expected published id %s got %s", tc.desc, dl.ID, published.ID))
+	}
+}
+
+func TestDeadLettersRedelivery(t *testing.T) {
+	sub := newSubscriber()
+	pub := newDeadLetterPublisher()
+	c := &consumer{fail: true}
+	dls := consumers.NewDeadLetters(consumerName, c, pub, nil, uuid.NewMock(), capacity)
+
+	err := consumers.StartWithDeadLetters(consumerName, sub, c, dls, brokers.SubjectSenML)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	h, ok := sub.handlers[brokers.SubjectSenML].(messaging.DeliveryHandler)
+	require.True(t, ok, "expected delivery handler")
+
+	cases := []struct {
+		desc     string
+		delivery messaging.Delivery
+		err      error
+		dead     int
+	}{
+		{
+			desc:     "handle failing message which is going to be redelivered",
+			delivery: messaging.Delivery{Attempt: 1},
+			err:      errFailed,
+			dead:     0,
+		},
+		{
+			desc:     "handle failing message on last delivery",
+			delivery: messaging.Delivery{Attempt: 3, Last: true},
+			err:      nil,
+			dead:     1,
+		},
+	}
+
+	for _, tc := range cases {
+		err := h.HandleDelivery(senmlMsg, tc.delivery)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+
+		page, err := dls.List(context.Background(), 0, 10)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		require.Len(t, page.DeadLetters, tc.dead, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.dead, len(page.DeadLetters)))
+		if tc.dead > 0 {
+			assert.Equal(t, tc.delivery.Attempt, page.DeadLetters[0].Attempts, fmt.Sprintf("%s: expected %d attempts got %d", tc.desc, tc.delivery.Attempt, page.DeadLetters[0].Attempts))
+		}
+	}
+}
+
+func TestDeadLettersFailedPublish(t *testing.T) {
+	sub := newSubscriber()
+	pub := newDeadLetterPublisher()
+	pub.fail = true
+	c := &consumer{fail: true}
+	dls := consumers.NewDeadLetters(consumerName, c, pub, nil, uuid.NewMock(), capacity)
+
+	err := consumers.StartWithDeadLetters(consumerName, sub, c, dls, brokers.SubjectSenML)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = sub.handlers[brokers.SubjectSenML].Handle(senmlMsg)
+	assert.True(t, errors.Contains(err, errFailed), fmt.Sprintf("expected %s got %s", errFailed, err))
+
+	page, err := dls.List(context.Background(), 0, 10)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Len(t, page.DeadLetters, 0, fmt.Sprintf("expected no dead letters got %d", len(page.DeadLetters)))
+}
+
+func TestListDeadLetters(t *testing.T) {
+	c := &consumer{fail: true}
+	dls := consumers.NewDeadLetters(consumerName, c, newDeadLetterPublisher(), nil, uuid.NewMock(), capacity)
+
+	var ids []string
+	for i := 0; i < capacity+1; i++ {
+		dl, err := dls.Add(context.Background(), consumers.DeadLetter{Consumer: consumerName, Message: senmlMsg})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ids = append(ids, dl.ID)
+	}
+
+	cases := []struct {
+		desc   string
+		offset uint64
+		limit  uint64
+		total  uint64
+		ids    []string
+	}{
+		{
+			desc:   "list all dead letters",
+			offset: 0,
+			limit:  10,
+			total:  capacity,
+			ids:    ids[1:],
+		},
+		{
+			desc:   "list dead letters with offset and limit",
+			offset: 1,
+			limit:  1,
+			total:  capacity,
+			ids:    ids[2:],
+		},
+		{
+			desc:   "list dead letters with offset out of range",
+			offset: capacity,
+			limit:  10,
+			total:  capacity,
+			ids:    []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := dls.List(context.Background(), tc.offset, tc.limit)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		got := []string{}
+		for _, dl := range page.DeadLetters {
+			got = append(got, dl.ID)
+		}
+		assert.Equal(t, tc.ids, got, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.ids, got))
+	}
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	c := &consumer{fail: true}
+	dls := consumers.NewDeadLetters(consumerName, c, newDeadLetterPublisher(), nil, uuid.NewMock(), capacity)
+
+	dl, err := dls.Add(context.Background(), consumers.DeadLetter{
+		Consumer: consumerName,
+		Subject:  brokers.SubjectSenML,
+		Reason:   consumers.ReasonConsume,
+		Attempts: 1,
+		Message:  senmlMsg,
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		fail     bool
+		attempts uint64
+		dead     int
+		err      error
+	}{
+		{
+			desc:     "replay dead letter failing again",
+			id:       dl.ID,
+			fail:     true,
+			attempts: 2,
+			dead:     1,
+			err:      consumers.ErrReplay,
+		},
+		{
+			desc: "replay dead letter",
+			id:   dl.ID,
+			dead: 0,
+			err:  nil,
+		},
+		{
+			desc: "replay removed dead letter",
+			id:   dl.ID,
+			dead: 0,
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "replay non-existing dead letter",
+			id:   "non-existing",
+			dead: 0,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		c.fail = tc.fail
+		err := dls.Replay(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+
+		page, err := dls.List(context.Background(), 0, 10)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		require.Len(t, page.DeadLetters, tc.dead, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.dead, len(page.DeadLetters)))
+		if tc.dead > 0 {
+			assert.Equal(t, tc.attempts, page.DeadLetters[0].Attempts, fmt.Sprintf("%s: expected %d attempts got %d", tc.desc, tc.attempts, page.DeadLetters[0].Attempts))
+		}
+	}
+}
+
+func TestEncryptedDeadLetters(t *testing.T) {
+	kr, err := encryption.NewKeyring(encryption.MasterKey{ID: "mk1", Key: bytes.Repeat([]byte{1}, encryption.MasterKeySize)})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	keys := encryption.New(encmocks.NewKeyRepository(), kr, uuid.NewMock())
+
+	c := &consumer{fail: true}
+	pub := newDeadLetterPublisher()
+	dls := consumers.NewDeadLetters(consumerName, c, pub, keys, uuid.NewMock(), capacity)
+
+	dl, err := dls.Add(context.Background(), consumers.DeadLetter{
+		Consumer: consumerName,
+		Subject:  brokers.SubjectSenML,
+		Reason:   consumers.ReasonConsume,
+		Attempts: 1,
+		Message:  senmlMsg,
+	})
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.True(t, dl.Encrypted, "expected encrypted dead letter")
+	assert.NotEqual(t, senmlMsg.Payload, dl.Message.Payload, "expected encrypted payload")
+	assert.NotContains(t, string(pub.published[0]), "temperature", "expected no plaintext payload in published dead letter")
+
+	page, err := dls.List(context.Background(), 0, 10)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	require.Len(t, page.DeadLetters, 1)
+	assert.NotEqual(t, senmlMsg.Payload, page.DeadLetters[0].Message.Payload, "expected encrypted listed payload")
+
+	c.fail = false
+	err = dls.Replay(context.Background(), dl.ID)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+	assert.Equal(t, 1, c.consumed, fmt.Sprintf("expected 1 consumed got %d", c.consumed))
+}
+
+func TestStoredDeadLetters(t *testing.T) {
+	store := newDeadLetterStore()
+	c := &consumer{fail: true}
+	dls := consumers.NewDeadLetters(consumerName, c, store, nil, uuid.NewMock(), capacity)
+	replica := consumers.NewDeadLetters(consumerName, c, store, nil, uuid.NewMock(), capacity)
+
+	var ids []string
+	for i := 0; i < capacity+1; i++ {
+		dl, err := dls.Add(context.Background(), consumers.DeadLetter{
+			Consumer: consumerName,
+			Subject:  brokers.SubjectSenML,
+			Reason:   consumers.ReasonConsume,
+			Attempts: 1,
+			Message:  senmlMsg,
+		})
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ids = append(ids, dl.ID)
+	}
+
+	page, err := replica.List(context.Background(), 0, 10)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, uint64(capacity), page.Total, fmt.Sprintf("expected total %d got %d", capacity, page.Total))
+	require.Len(t, page.DeadLetters, capacity)
+	assert.Equal(t, ids[1], page.DeadLetters[0].ID, fmt.Sprintf("expected id %s got %s", ids[1], page.DeadLetters[0].ID))
+
+	cases := []struct {
+		desc     string
+		id       string
+		fail     bool
+		attempts uint64
+		dead     int
+		err      error
+	}{
+		{
+			desc:     "replay stored dead letter failing again",
+			id:       ids[2],
+			fail:     true,
+			attempts: 2,
+			dead:     capacity,
+			err:      consumers.ErrReplay,
+		},
+		{
+			desc: "replay stored dead letter",
+			id:   ids[2],
+			dead: capacity,
+			err:  nil,
+		},
+		{
+			desc: "replay removed stored dead letter",
+			id:   ids[2],
+			dead: capacity,
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		c.fail = tc.fail
+		err := replica.Replay(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+
+		page, err := dls.List(context.Background(), 0, 10)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		require.Len(t, page.DeadLetters, tc.dead, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.dead, len(page.DeadLetters)))
+		if tc.attempts > 0 {
+			last := page.DeadLetters[len(page.DeadLetters)-1]
+			assert.Equal(t, tc.id, last.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.id, last.ID))
+			assert.Equal(t, tc.attempts, last.Attempts, fmt.Sprintf("%s: expected %d attempts got %d", tc.desc, tc.attempts, last.Attempts))
+		}
+	}
+}
+
+type consumer struct {
+	fail     bool
+	consumed int
+}
+
+func (c *consumer) Consume(msgs interface{}) error {
+	if c.fail {
+		return errFailed
+	}
+	c.consumed++
+	return nil
+}
+
+type subscriber struct {
+	handlers map[string]messaging.MessageHandler
+}
+
+func newSubscriber() *subscriber {
+	return &subscriber{handlers: make(map[string]messaging.MessageHandler)}
+}
+
+func (s *subscriber) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	s.handlers[topic] = handler
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(id, topic string) error {
+	delete(s.handlers, topic)
+	return nil
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+type deadLetterPublisher struct {
+	mu        sync.Mutex
+	fail      bool
+	published [][]byte
+}
+
+func newDeadLetterPublisher() *deadLetterPublisher {
+	return &deadLetterPublisher{}
+}
+
+func (pub *deadLetterPublisher) PublishDeadLetter(consumer string, data []byte) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	if pub.fail {
+		return errFailed
+	}
+	pub.published = append(pub.published, data)
+	return nil
+}
+
+func (pub *deadLetterPublisher) Close() error {
+	return nil
+}
+
+type deadLetterStore struct {
+	deadLetterPublisher
+	seq    uint64
+	stored []messaging.StoredDeadLetter
+}
+
+func newDeadLetterStore() *deadLetterStore {
+	return &deadLetterStore{}
+}
+
+func (s *deadLetterStore) PublishDeadLetter(consumer string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+	s.stored = append(s.stored, messaging.StoredDeadLetter{Seq: s.seq, Data: data})
+	return nil
+}
+
+func (s *deadLetterStore) RetrieveDeadLetters(consumer string) ([]messaging.StoredDeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]messaging.StoredDeadLetter{}, s.stored...), nil
+}
+
+func (s *deadLetterStore) RemoveDeadLetter(seq uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, dl := range s.stored {
+		if dl.Seq == seq {
+			s.stored = append(s.stored[:i], s.stored[i+1:]...)
+			return nil
+		}
+	}
+	return errors.ErrNotFound
+}
@@ -0,0 +1,30 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import "time"
+
+// Config represents webhook notifier configuration.
+type Config struct {
+	// Headers are added to every webhook request.
+	Headers map[string]string
+	// Secret is used to sign the request timestamp and body using
+	// HMAC-SHA256. If empty, requests are not signed.
+	Secret string
+	// Retries is the number of times a failed request is retried.
+	Retries uint
+	// Backoff is the delay before the first retry, doubled on every
+	// subsequent retry.
+	Backoff time.Duration
+	// Timeout is the webhook request timeout.
+	Timeout time.Duration
+	// Workers is the number of workers delivering the queued requests.
+	Workers uint
+	// QueueSize is the number of requests waiting for delivery. The messages
+	// received while the queue is full are written to the dead-letter log.
+	QueueSize uint
+	// AllowPrivate allows sending requests to loopback, private and
+	// link-local addresses.
+	AllowPrivate bool
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var (
+	// ErrInvalidURL indicates that the webhook URL isn't an absolute HTTP or
+	// HTTPS URL.
+	ErrInvalidURL = errors.New("invalid webhook URL")
+
+	// ErrForbiddenDestination indicates that the webhook URL points to a
+	// loopback, private or link-local address.
+	ErrForbiddenDestination = errors.New("forbidden webhook destination")
+)
+
+// ValidateURL returns an error if the URL isn't an absolute HTTP or HTTPS URL,
+// or if its host is a loopback, private or link-local address. Host names are
+// checked once they are resolved, when the request is sent.
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(ErrInvalidURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidURL
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenDestination
+	}
+	if ip := net.ParseIP(host); ip != nil && forbidden(ip) {
+		return ErrForbiddenDestination
+	}
+
+	return nil
+}
+
+// controlDestination rejects the connections to the forbidden addresses. It
+// is called with the resolved address, so host names that resolve to these
+// addresses are rejected as well.
+func controlDestination(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || forbidden(ip) {
+		return ErrForbiddenDestination
+	}
+
+	return nil
+}
+
+func forbidden(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast()
+}
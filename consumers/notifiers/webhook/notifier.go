@@ -0,0 +1,238 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	notifiers "github.com/MainfluxLabs/mainflux/consumers/notifiers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	// SignatureHeader is the header containing the HMAC-SHA256 signature of
+	// the request timestamp and body, in the "sha256=<hex>" format.
+	SignatureHeader = "X-Mainflux-Signature"
+	// TimestampHeader is the header containing the time the request was
+	// signed at, in Unix seconds.
+	TimestampHeader = "X-Mainflux-Timestamp"
+	// ChannelHeader is the header containing the message channel ID.
+	ChannelHeader = "X-Mainflux-Channel"
+	// PublisherHeader is the header containing the message publisher ID.
+	PublisherHeader = "X-Mainflux-Publisher"
+	// SubtopicHeader is the header containing the message subtopic.
+	SubtopicHeader = "X-Mainflux-Subtopic"
+
+	signaturePrefix = "sha256="
+	userAgent       = "Mainflux-Webhook-Notifier"
+)
+
+var (
+	errUnexpectedStatus = errors.New("unexpected response status")
+	errQueueFull        = errors.New("webhook request queue is full")
+)
+
+var _ notifiers.Notifier = (*notifier)(nil)
+
+type notifier struct {
+	cfg        Config
+	client     *http.Client
+	deadLetter io.Writer
+	requests   chan request
+	mu         sync.Mutex
+}
+
+// request represents the message waiting to be delivered to the webhook.
+type request struct {
+	url string
+	msg messaging.Message
+}
+
+// deadLetter represents the message that couldn't be delivered to the webhook.
+type deadLetter struct {
+	URL       string `json:"url"`
+	Channel   string `json:"channel"`
+	Publisher string `json:"publisher"`
+	Subtopic  string `json:"subtopic,omitempty"`
+	Created   int64  `json:"created"`
+	Payload   []byte `json:"payload"`
+	Error     string `json:"error"`
+	FailedAt  int64  `json:"failed_at"`
+}
+
+// New instantiates webhook message notifier. The requests are queued and
+// delivered by the workers, so that the retries don't hold up the following
+// messages. The messages that couldn't be delivered once all the retries are
+// exhausted are written to the dead-letter writer as JSON lines.
+func New(cfg Config, deadLetter io.Writer) notifiers.Notifier {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	if !cfg.AllowPrivate {
+		dialer := &net.Dialer{Timeout: cfg.Timeout, Control: controlDestination}
+		transport.DialContext = dialer.DialContext
+	}
+
+	n := &notifier{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: cfg.Timeout, Transport: transport},
+		deadLetter: deadLetter,
+		requests:   make(chan request, cfg.QueueSize),
+	}
+
+	workers := cfg.Workers
+	if workers == 0 {
+		workers = 1
+	}
+	for i := uint(0); i < workers; i++ {
+		go n.work()
+	}
+
+	return n
+}
+
+// Notify queues the message for delivery to the URLs. An error is returned
+// if the URL is invalid or the queue is full.
+func (n *notifier) Notify(from string, to []string, msg messaging.Message) error {
+	var ret error
+	for _, url := range to {
+		if err := n.validate(url); err != nil {
+			n.writeDeadLetter(url, msg, err)
+			ret = errors.Wrap(notifiers.ErrNotify, err)
+			continue
+		}
+
+		select {
+		case n.requests <- request{url: url, msg: msg}:
+		default:
+			n.writeDeadLetter(url, msg, errQueueFull)
+			ret = errors.Wrap(notifiers.ErrNotify, errQueueFull)
+		}
+	}
+
+	return ret
+}
+
+func (n *notifier) validate(url string) error {
+	err := ValidateURL(url)
+	if n.cfg.AllowPrivate && err == ErrForbiddenDestination {
+		return nil
+	}
+
+	return err
+}
+
+func (n *notifier) work() {
+	for req := range n.requests {
+		if err := n.send(req.url, req.msg); err != nil {
+			n.writeDeadLetter(req.url, req.msg, err)
+		}
+	}
+}
+
+func (n *notifier) send(url string, msg messaging.Message) error {
+	backoff := n.cfg.Backoff
+	var err error
+	for attempt := uint(0); attempt <= n.cfg.Retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		var retry bool
+		if retry, err = n.post(url, msg); err == nil || !retry {
+			return err
+		}
+	}
+
+	return err
+}
+
+// post sends the message to the URL and reports whether the failed request
+// should be retried.
+func (n *notifier) post(url string, msg messaging.Message) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(msg.Payload))
+	if err != nil {
+		return false, err
+	}
+
+	for k, v := range n.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	if msg.Profile != nil && msg.Profile.ContentType != "" {
+		req.Header.Set("Content-Type", msg.Profile.ContentType)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(ChannelHeader, msg.Channel)
+	req.Header.Set(PublisherHeader, msg.Publisher)
+	if msg.Subtopic != "" {
+		req.Header.Set(SubtopicHeader, msg.Subtopic)
+	}
+	if n.cfg.Secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(n.cfg.Secret, timestamp, msg.Payload))
+	}
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	switch {
+	case res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices:
+		return false, nil
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError:
+		return true, errors.Wrap(errUnexpectedStatus, fmt.Errorf("%s", res.Status))
+	default:
+		return false, errors.Wrap(errUnexpectedStatus, fmt.Errorf("%s", res.Status))
+	}
+}
+
+func (n *notifier) writeDeadLetter(url string, msg messaging.Message, err error) {
+	if n.deadLetter == nil {
+		return
+	}
+
+	dl := deadLetter{
+		URL:       url,
+		Channel:   msg.Channel,
+		Publisher: msg.Publisher,
+		Subtopic:  msg.Subtopic,
+		Created:   msg.Created,
+		Payload:   msg.Payload,
+		Error:     err.Error(),
+		FailedAt:  time.Now().UnixNano(),
+	}
+	data, mErr := json.Marshal(dl)
+	if mErr != nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.deadLetter.Write(append(data, '\n'))
+}
+
+// Sign returns the HMAC-SHA256 signature of the timestamp and the body,
+// joined by the dot, in the format used by the signature header.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
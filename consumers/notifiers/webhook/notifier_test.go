@@ -0,0 +1,239 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	notifiers "github.com/MainfluxLabs/mainflux/consumers/notifiers"
+	"github.com/MainfluxLabs/mainflux/consumers/notifiers/webhook"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	secret      = "secret"
+	headerKey   = "Authorization"
+	headerValue = "Bearer token"
+	retries     = 2
+	waitFor     = 5 * time.Second
+	tick        = 10 * time.Millisecond
+)
+
+var msg = messaging.Message{
+	Channel:   "chan",
+	Publisher: "thing",
+	Subtopic:  "alarm",
+	Payload:   []byte(`{"temperature":42}`),
+	Profile:   &messaging.Profile{ContentType: messaging.JsonContentType},
+}
+
+type handler struct {
+	mu     sync.Mutex
+	status int
+	calls  int32
+	header http.Header
+	body   []byte
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls++
+	h.header = r.Header
+	h.body, _ = io.ReadAll(r.Body)
+	w.WriteHeader(h.status)
+}
+
+func (h *handler) received() (int32, http.Header, []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.calls, h.header, h.body
+}
+
+// buffer is the dead-letter writer safe for concurrent use.
+type buffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *buffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *buffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+func newNotifier(dl io.Writer, allowPrivate bool) notifiers.Notifier {
+	cfg := webhook.Config{
+		Headers:      map[string]string{headerKey: headerValue},
+		Secret:       secret,
+		Retries:      retries,
+		Backoff:      time.Millisecond,
+		Timeout:      time.Second,
+		Workers:      1,
+		QueueSize:    1,
+		AllowPrivate: allowPrivate,
+	}
+	return webhook.New(cfg, dl)
+}
+
+func TestNotify(t *testing.T) {
+	cases := []struct {
+		desc       string
+		status     int
+		calls      int32
+		deadLetter bool
+		err        error
+	}{
+		{
+			desc:   "notify successfully",
+			status: http.StatusOK,
+			calls:  1,
+		},
+		{
+			desc:       "notify with server error",
+			status:     http.StatusInternalServerError,
+			calls:      retries + 1,
+			deadLetter: true,
+			err:        notifiers.ErrNotify,
+		},
+		{
+			desc:       "notify with too many requests",
+			status:     http.StatusTooManyRequests,
+			calls:      retries + 1,
+			deadLetter: true,
+			err:        notifiers.ErrNotify,
+		},
+		{
+			desc:       "notify with client error",
+			status:     http.StatusBadRequest,
+			calls:      1,
+			deadLetter: true,
+			err:        notifiers.ErrNotify,
+		},
+	}
+
+	for _, tc := range cases {
+		h := &handler{status: tc.status}
+		ts := httptest.NewServer(h)
+		dl := &buffer{}
+		n := newNotifier(dl, true)
+
+		err := n.Notify("", []string{ts.URL}, msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected notify error %s\n", tc.desc, err))
+		assert.Eventually(t, func() bool {
+			calls, _, _ := h.received()
+			return calls == tc.calls && (!tc.deadLetter || len(dl.Bytes()) > 0)
+		}, waitFor, tick, fmt.Sprintf("%s: expected %d calls\n", tc.desc, tc.calls))
+		ts.Close()
+
+		calls, header, body := h.received()
+		assert.Equal(t, tc.calls, calls, fmt.Sprintf("%s: expected %d calls got %d\n", tc.desc, tc.calls, calls))
+		assert.Equal(t, msg.Payload, body, fmt.Sprintf("%s: expected body %s got %s\n", tc.desc, msg.Payload, body))
+		assert.Equal(t, headerValue, header.Get(headerKey), fmt.Sprintf("%s: expected configured header to be set\n", tc.desc))
+		assert.Equal(t, messaging.JsonContentType, header.Get("Content-Type"), fmt.Sprintf("%s: expected content type to be set\n", tc.desc))
+		assert.Equal(t, msg.Channel, header.Get(webhook.ChannelHeader), fmt.Sprintf("%s: expected channel header to be set\n", tc.desc))
+		timestamp := header.Get(webhook.TimestampHeader)
+		assert.Equal(t, webhook.Sign(secret, timestamp, msg.Payload), header.Get(webhook.SignatureHeader), fmt.Sprintf("%s: expected valid signature\n", tc.desc))
+		ts64, err := strconv.ParseInt(timestamp, 10, 64)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected timestamp %s\n", tc.desc, timestamp))
+		assert.WithinDuration(t, time.Now(), time.Unix(ts64, 0), time.Minute, fmt.Sprintf("%s: expected current timestamp\n", tc.desc))
+
+		if !tc.deadLetter {
+			assert.Zero(t, len(dl.Bytes()), fmt.Sprintf("%s: expected empty dead-letter log\n", tc.desc))
+			continue
+		}
+		var rec map[string]interface{}
+		require.Nil(t, json.Unmarshal(dl.Bytes(), &rec), fmt.Sprintf("%s: unexpected dead-letter record %s\n", tc.desc, dl.Bytes()))
+		assert.Equal(t, ts.URL, rec["url"], fmt.Sprintf("%s: expected dead-letter URL %s got %v\n", tc.desc, ts.URL, rec["url"]))
+		assert.Equal(t, msg.Channel, rec["channel"], fmt.Sprintf("%s: expected dead-letter channel %s got %v\n", tc.desc, msg.Channel, rec["channel"]))
+	}
+}
+
+func TestNotifyUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	url := ts.URL
+	ts.Close()
+
+	dl := &buffer{}
+	n := newNotifier(dl, true)
+	err := n.Notify("", []string{url}, msg)
+	assert.Nil(t, err, fmt.Sprintf("unexpected notify error %s\n", err))
+	assert.Eventually(t, func() bool { return len(dl.Bytes()) > 0 }, waitFor, tick, "expected dead-letter record for unreachable URL")
+}
+
+func TestNotifyForbiddenDestination(t *testing.T) {
+	h := &handler{status: http.StatusOK}
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	cases := []struct {
+		desc string
+		url  string
+		err  error
+	}{
+		{
+			desc: "notify loopback address",
+			url:  ts.URL,
+			err:  webhook.ErrForbiddenDestination,
+		},
+		{
+			desc: "notify localhost",
+			url:  "http://localhost/hook",
+			err:  webhook.ErrForbiddenDestination,
+		},
+		{
+			desc: "notify private address",
+			url:  "http://10.0.0.1/hook",
+			err:  webhook.ErrForbiddenDestination,
+		},
+		{
+			desc: "notify link-local address",
+			url:  "http://169.254.169.254/latest/meta-data",
+			err:  webhook.ErrForbiddenDestination,
+		},
+		{
+			desc: "notify invalid URL",
+			url:  "ftp://example.com",
+			err:  webhook.ErrInvalidURL,
+		},
+	}
+
+	for _, tc := range cases {
+		dl := &buffer{}
+		n := newNotifier(dl, false)
+		err := n.Notify("", []string{tc.url}, msg)
+		assert.True(t, errors.Contains(err, notifiers.ErrNotify), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, notifiers.ErrNotify, err))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.NotZero(t, len(dl.Bytes()), fmt.Sprintf("%s: expected dead-letter record\n", tc.desc))
+	}
+
+	calls, _, _ := h.received()
+	assert.Zero(t, calls, "expected no requests to forbidden destinations")
+}
+
+func TestSign(t *testing.T) {
+	sig := webhook.Sign(secret, "1700000000", msg.Payload)
+	assert.Equal(t, sig, webhook.Sign(secret, "1700000000", msg.Payload), "expected deterministic signature")
+	assert.NotEqual(t, sig, webhook.Sign("other", "1700000000", msg.Payload), "expected different signature for different secret")
+	assert.NotEqual(t, sig, webhook.Sign(secret, "1700000001", msg.Payload), "expected different signature for different timestamp")
+	assert.Regexp(t, "^sha256=[0-9a-f]{64}$", sig, "expected hex encoded signature")
+}
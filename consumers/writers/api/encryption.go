@@ -0,0 +1,61 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+var _ consumers.Consumer = (*encryptionMiddleware)(nil)
+
+type encryptionMiddleware struct {
+	svc      encryption.Service
+	consumer consumers.Consumer
+}
+
+// EncryptionMiddleware encrypts the values of the SenML messages and the
+// payloads of the JSON messages before they are stored.
+func EncryptionMiddleware(consumer consumers.Consumer, svc encryption.Service) consumers.Consumer {
+	return &encryptionMiddleware{
+		svc:      svc,
+		consumer: consumer,
+	}
+}
+
+func (em *encryptionMiddleware) Consume(msgs interface{}) error {
+	ctx := context.Background()
+
+	switch m := msgs.(type) {
+	case []senml.Message:
+		enc := make([]senml.Message, len(m))
+		for i, msg := range m {
+			var err error
+			if enc[i], err = encryption.EncryptSenML(ctx, em.svc, msg); err != nil {
+				return err
+			}
+		}
+		msgs = enc
+	case json.Messages:
+		enc := json.Messages{
+			Data:   make([]json.Message, len(m.Data)),
+			Format: m.Format,
+		}
+		for i, msg := range m.Data {
+			pld, err := encryption.EncryptPayload(ctx, em.svc, msg.Channel, msg.Payload)
+			if err != nil {
+				return err
+			}
+			msg.Payload = pld
+			enc.Data[i] = msg
+		}
+		msgs = enc
+	}
+
+	return em.consumer.Consume(msgs)
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encmocks "github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptionMiddleware(t *testing.T) {
+	c := &consumer{}
+	keys := newEncryption(t, encmocks.NewKeyRepository(), masterKey)
+	em := api.EncryptionMiddleware(c, keys)
+
+	v := 17.0
+	senmlMsgs := []senml.Message{{Channel: msg.Channel, Name: "temperature", Value: &v}}
+	err := em.Consume(senmlMsgs)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	consumed, ok := c.consumed.([]senml.Message)
+	require.True(t, ok, fmt.Sprintf("expected SenML messages got %T", c.consumed))
+	require.Len(t, consumed, 1)
+	assert.Nil(t, consumed[0].Value, "expected no plaintext value")
+	require.NotNil(t, consumed[0].DataValue, "expected encrypted data value")
+	assert.True(t, strings.HasPrefix(*consumed[0].DataValue, encryption.EnvelopePrefix), fmt.Sprintf("expected envelope got %s", *consumed[0].DataValue))
+	assert.Equal(t, &v, senmlMsgs[0].Value, "expected consumed messages not to be modified")
+
+	dec, err := encryption.DecryptSenML(context.Background(), keys, consumed[0])
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, senmlMsgs[0], dec, fmt.Sprintf("expected %v got %v", senmlMsgs[0], dec))
+
+	payload := mfjson.Payload{"temperature": 17.0}
+	jsonMsgs := mfjson.Messages{
+		Data:   []mfjson.Message{{Channel: msg.Channel, Payload: payload}},
+		Format: "json",
+	}
+	err = em.Consume(jsonMsgs)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	consumedJSON, ok := c.consumed.(mfjson.Messages)
+	require.True(t, ok, fmt.Sprintf("expected JSON messages got %T", c.consumed))
+	require.Len(t, consumedJSON.Data, 1)
+	assert.Equal(t, jsonMsgs.Format, consumedJSON.Format, fmt.Sprintf("expected format %s got %s", jsonMsgs.Format, consumedJSON.Format))
+	assert.NotContains(t, consumedJSON.Data[0].Payload, "temperature", "expected no plaintext payload")
+
+	pld, err := encryption.DecryptPayload(context.Background(), keys, msg.Channel, consumedJSON.Data[0].Payload)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, map[string]interface{}(payload), pld, fmt.Sprintf("expected %v got %v", payload, pld))
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func listDeadLettersEndpoint(dls consumers.DeadLetters, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listDeadLettersReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeAdmin(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		page, err := dls.List(ctx, req.offset, req.limit)
+		if err != nil {
+			return nil, err
+		}
+
+		res := deadLettersPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+			},
+			DeadLetters: []deadLetterRes{},
+		}
+
+		for _, dl := range page.DeadLetters {
+			res.DeadLetters = append(res.DeadLetters, deadLetterRes{
+				ID:        dl.ID,
+				Consumer:  dl.Consumer,
+				Subject:   dl.Subject,
+				Reason:    dl.Reason,
+				Error:     dl.Error,
+				Attempts:  dl.Attempts,
+				Encrypted: dl.Encrypted,
+				Message:   dl.Message,
+				CreatedAt: dl.CreatedAt,
+			})
+		}
+
+		return res, nil
+	}
+}
+
+func replayDeadLetterEndpoint(dls consumers.DeadLetters, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(replayDeadLetterReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeAdmin(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		if err := dls.Replay(ctx, req.id); err != nil {
+			return nil, err
+		}
+
+		return replayRes{}, nil
+	}
+}
+
+func rotateDataKeyEndpoint(keys encryption.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rotateDataKeyReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeAdmin(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		dk, err := keys.RotateDataKey(ctx, req.chanID)
+		if err != nil {
+			return nil, err
+		}
+
+		res := dataKeyRes{
+			ID:          dk.ID,
+			ChannelID:   dk.ChannelID,
+			MasterKeyID: dk.MasterKeyID,
+			CreatedAt:   dk.CreatedAt,
+		}
+
+		return res, nil
+	}
+}
+
+func rewrapDataKeysEndpoint(keys encryption.Service, ac mainflux.AuthServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rewrapDataKeysReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorizeAdmin(ctx, ac, req.token); err != nil {
+			return nil, err
+		}
+
+		n, err := keys.RewrapDataKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return rewrapRes{Rewrapped: n}, nil
+	}
+}
+
+// authorizeAdmin verifies that the token belongs to the root admin.
+func authorizeAdmin(ctx context.Context, ac mainflux.AuthServiceClient, token string) error {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: auth.RootSubject,
+	}
+
+	if _, err := ac.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
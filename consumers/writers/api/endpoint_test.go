@@ -0,0 +1,438 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers/api"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encmocks "github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/brokers"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	svcName    = "test-writer"
+	capacity   = 100
+	n          = 10
+	adminToken = "admin@example.com"
+	userToken  = "user@example.com"
+	wrongValue = "wrong-value"
+)
+
+var (
+	errFailed    = errors.New("failed")
+	admin        = users.User{ID: "574106f7-030e-4881-8ab0-151195c29f93", Email: adminToken, Password: "password"}
+	user         = users.User{ID: "574106f7-030e-4881-8ab0-151195c29f94", Email: userToken, Password: "password"}
+	masterKey    = encryption.MasterKey{ID: "mk1", Key: bytes.Repeat([]byte{1}, encryption.MasterKeySize)}
+	newMasterKey = encryption.MasterKey{ID: "mk2", Key: bytes.Repeat([]byte{2}, encryption.MasterKeySize)}
+	msg          = messaging.Message{
+		Channel: "1",
+		Payload: []byte(`[{"bn":"base-name","n":"temperature","v":17}]`),
+		Profile: &messaging.Profile{ContentType: messaging.SenmlContentType},
+	}
+)
+
+type consumer struct {
+	fail     bool
+	consumed interface{}
+}
+
+func (c *consumer) Consume(msgs interface{}) error {
+	if c.fail {
+		return errFailed
+	}
+	c.consumed = msgs
+	return nil
+}
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	return tr.client.Do(req)
+}
+
+type dataKeyRes struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channel"`
+	MasterKeyID string `json:"master_key_id"`
+}
+
+type rewrapRes struct {
+	Rewrapped uint64 `json:"rewrapped"`
+}
+
+type deadLettersPageRes struct {
+	Total       uint64                 `json:"total"`
+	Offset      uint64                 `json:"offset"`
+	Limit       uint64                 `json:"limit"`
+	DeadLetters []consumers.DeadLetter `json:"dead_letters"`
+}
+
+func newServer(dls consumers.DeadLetters, keys encryption.Service) *httptest.Server {
+	auth := mocks.NewAuthService(admin.ID, []users.User{admin, user})
+	mux := api.MakeHandler(svcName, dls, keys, auth, logger.NewMock())
+	return httptest.NewServer(mux)
+}
+
+func addDeadLetters(t *testing.T, dls consumers.DeadLetters) []consumers.DeadLetter {
+	var saved []consumers.DeadLetter
+	for i := 0; i < n; i++ {
+		dl, err := dls.Add(context.Background(), consumers.DeadLetter{
+			Consumer: svcName,
+			Subject:  brokers.SubjectSenML,
+			Reason:   consumers.ReasonConsume,
+			Error:    errFailed.Error(),
+			Attempts: 1,
+			Message:  msg,
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		saved = append(saved, dl)
+	}
+	return saved
+}
+
+func TestListDeadLetters(t *testing.T) {
+	dls := consumers.NewDeadLetters("writer", &consumer{}, mocks.NewDeadLetterPublisher(), nil, uuid.NewMock(), capacity)
+	ts := newServer(dls, nil)
+	defer ts.Close()
+	saved := addDeadLetters(t, dls)
+
+	cases := []struct {
+		desc   string
+		url    string
+		token  string
+		status int
+		total  uint64
+		size   int
+	}{
+		{
+			desc:   "list dead letters",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  adminToken,
+			status: http.StatusOK,
+			total:  n,
+			size:   n,
+		},
+		{
+			desc:   "list dead letters with offset and limit",
+			url:    fmt.Sprintf("%s/deadletters?offset=%d&limit=%d", ts.URL, 8, 5),
+			token:  adminToken,
+			status: http.StatusOK,
+			total:  n,
+			size:   2,
+		},
+		{
+			desc:   "list dead letters with limit exceeding max",
+			url:    fmt.Sprintf("%s/deadletters?limit=%d", ts.URL, 1000),
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters with invalid offset",
+			url:    fmt.Sprintf("%s/deadletters?offset=invalid", ts.URL),
+			token:  adminToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list dead letters as non-admin user",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "list dead letters with invalid token",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list dead letters without token",
+			url:    fmt.Sprintf("%s/deadletters", ts.URL),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page deadLettersPageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+		assert.Len(t, page.DeadLetters, tc.size, fmt.Sprintf("%s: expected %d dead letters got %d", tc.desc, tc.size, len(page.DeadLetters)))
+	}
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/deadletters?limit=1", ts.URL),
+		token:  adminToken,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var page deadLettersPageRes
+	err = json.NewDecoder(res.Body).Decode(&page)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, page.DeadLetters, 1)
+	assert.Equal(t, saved[0].ID, page.DeadLetters[0].ID, fmt.Sprintf("expected id %s got %s", saved[0].ID, page.DeadLetters[0].ID))
+	assert.Equal(t, msg, page.DeadLetters[0].Message, fmt.Sprintf("expected message %v got %v", msg, page.DeadLetters[0].Message))
+}
+
+func TestReplayDeadLetter(t *testing.T) {
+	c := &consumer{}
+	dls := consumers.NewDeadLetters("writer", c, mocks.NewDeadLetterPublisher(), nil, uuid.NewMock(), capacity)
+	ts := newServer(dls, nil)
+	defer ts.Close()
+	saved := addDeadLetters(t, dls)
+
+	cases := []struct {
+		desc   string
+		id     string
+		token  string
+		fail   bool
+		status int
+	}{
+		{
+			desc:   "replay dead letter as non-admin user",
+			id:     saved[0].ID,
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "replay dead letter without token",
+			id:     saved[0].ID,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "replay dead letter failing again",
+			id:     saved[0].ID,
+			token:  adminToken,
+			fail:   true,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			desc:   "replay dead letter",
+			id:     saved[0].ID,
+			token:  adminToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:   "replay already replayed dead letter",
+			id:     saved[0].ID,
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "replay non-existing dead letter",
+			id:     "non-existing",
+			token:  adminToken,
+			status: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		c.fail = tc.fail
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/deadletters/%s/replay", ts.URL, tc.id),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func newEncryption(t *testing.T, keys encryption.KeyRepository, current encryption.MasterKey, previous ...encryption.MasterKey) encryption.Service {
+	kr, err := encryption.NewKeyring(current, previous...)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return encryption.New(keys, kr, uuid.NewMock())
+}
+
+func TestRotateDataKey(t *testing.T) {
+	dls := consumers.NewDeadLetters("writer", &consumer{}, mocks.NewDeadLetterPublisher(), nil, uuid.NewMock(), capacity)
+	ts := newServer(dls, newEncryption(t, encmocks.NewKeyRepository(), masterKey))
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		chanID string
+		token  string
+		status int
+	}{
+		{
+			desc:   "rotate data key of channel",
+			chanID: msg.Channel,
+			token:  adminToken,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "rotate data key of channel again",
+			chanID: msg.Channel,
+			token:  adminToken,
+			status: http.StatusCreated,
+		},
+		{
+			desc:   "rotate data key of channel as non-admin user",
+			chanID: msg.Channel,
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "rotate data key of channel without token",
+			chanID: msg.Channel,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	var ids []string
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/channels/%s/keys", ts.URL, tc.chanID),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusCreated {
+			continue
+		}
+
+		var key dataKeyRes
+		err = json.NewDecoder(res.Body).Decode(&key)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.chanID, key.ChannelID, fmt.Sprintf("%s: expected channel %s got %s", tc.desc, tc.chanID, key.ChannelID))
+		assert.Equal(t, masterKey.ID, key.MasterKeyID, fmt.Sprintf("%s: expected master key %s got %s", tc.desc, masterKey.ID, key.MasterKeyID))
+		assert.NotContains(t, ids, key.ID, fmt.Sprintf("%s: expected new data key got %s", tc.desc, key.ID))
+		ids = append(ids, key.ID)
+	}
+}
+
+func TestRewrapDataKeys(t *testing.T) {
+	keys := encmocks.NewKeyRepository()
+	_, err := newEncryption(t, keys, masterKey).RotateDataKey(context.Background(), msg.Channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	dls := consumers.NewDeadLetters("writer", &consumer{}, mocks.NewDeadLetterPublisher(), nil, uuid.NewMock(), capacity)
+
+	cases := []struct {
+		desc      string
+		keys      encryption.Service
+		token     string
+		status    int
+		rewrapped uint64
+	}{
+		{
+			desc:   "rewrap data keys as non-admin user",
+			keys:   newEncryption(t, keys, newMasterKey, masterKey),
+			token:  userToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "rewrap data keys with invalid token",
+			keys:   newEncryption(t, keys, newMasterKey, masterKey),
+			token:  wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "rewrap data keys without previous master key",
+			keys:   newEncryption(t, keys, newMasterKey),
+			token:  adminToken,
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			desc:      "rewrap data keys",
+			keys:      newEncryption(t, keys, newMasterKey, masterKey),
+			token:     adminToken,
+			status:    http.StatusOK,
+			rewrapped: 1,
+		},
+		{
+			desc:      "rewrap already rewrapped data keys",
+			keys:      newEncryption(t, keys, newMasterKey, masterKey),
+			token:     adminToken,
+			status:    http.StatusOK,
+			rewrapped: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		ts := newServer(dls, tc.keys)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/keys/rewrap", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusOK {
+			var body rewrapRes
+			err = json.NewDecoder(res.Body).Decode(&body)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			assert.Equal(t, tc.rewrapped, body.Rewrapped, fmt.Sprintf("%s: expected %d rewrapped got %d", tc.desc, tc.rewrapped, body.Rewrapped))
+		}
+		ts.Close()
+	}
+}
+
+func TestKeysEndpointsWithoutEncryption(t *testing.T) {
+	dls := consumers.NewDeadLetters("writer", &consumer{}, mocks.NewDeadLetterPublisher(), nil, uuid.NewMock(), capacity)
+	ts := newServer(dls, nil)
+	defer ts.Close()
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodPost,
+		url:    fmt.Sprintf("%s/keys/rewrap", ts.URL),
+		token:  adminToken,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, http.StatusNotFound, res.StatusCode, fmt.Sprintf("expected status code %d got %d", http.StatusNotFound, res.StatusCode))
+}
@@ -6,6 +6,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -40,3 +41,57 @@ func (lm *loggingMiddleware) Consume(msgs interface{}) (err error) {
 
 	return lm.consumer.Consume(msgs)
 }
+
+var _ consumers.DeadLetters = (*deadLettersLoggingMiddleware)(nil)
+
+type deadLettersLoggingMiddleware struct {
+	logger      log.Logger
+	deadLetters consumers.DeadLetters
+}
+
+// DeadLettersLoggingMiddleware adds logging facilities to the dead letters.
+func DeadLettersLoggingMiddleware(dls consumers.DeadLetters, logger log.Logger) consumers.DeadLetters {
+	return &deadLettersLoggingMiddleware{
+		logger:      logger,
+		deadLetters: dls,
+	}
+}
+
+func (lm *deadLettersLoggingMiddleware) Add(ctx context.Context, dl consumers.DeadLetter) (saved consumers.DeadLetter, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method add_dead_letter for message of channel %s failed to %s took %s to complete", dl.Message.Channel, dl.Reason, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Warn(fmt.Sprintf("%s, added dead letter %s.", message, saved.ID))
+	}(time.Now())
+
+	return lm.deadLetters.Add(ctx, dl)
+}
+
+func (lm *deadLettersLoggingMiddleware) List(ctx context.Context, offset, limit uint64) (page consumers.DeadLettersPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_dead_letters took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.deadLetters.List(ctx, offset, limit)
+}
+
+func (lm *deadLettersLoggingMiddleware) Replay(ctx context.Context, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method replay_dead_letter for id %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.deadLetters.Replay(ctx, id)
+}
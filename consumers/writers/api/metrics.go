@@ -6,9 +6,11 @@
 package api
 
 import (
+	"context"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
 	"github.com/go-kit/kit/metrics"
 )
 
@@ -37,3 +39,103 @@ func (mm *metricsMiddleware) Consume(msgs interface{}) error {
 	}(time.Now())
 	return mm.consumer.Consume(msgs)
 }
+
+var (
+	_ writers.Pruner      = (*prunerMetricsMiddleware)(nil)
+	_ writers.ChunkPruner = (*prunerMetricsMiddleware)(nil)
+)
+
+type prunerMetricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	removed metrics.Counter
+	pruner  writers.Pruner
+}
+
+// PrunerMetricsMiddleware returns new pruner with Prune method wrapped to
+// expose metrics, including the number of removed messages.
+func PrunerMetricsMiddleware(pruner writers.Pruner, counter metrics.Counter, latency metrics.Histogram, removed metrics.Counter) writers.Pruner {
+	return &prunerMetricsMiddleware{
+		counter: counter,
+		latency: latency,
+		removed: removed,
+		pruner:  pruner,
+	}
+}
+
+func (pm *prunerMetricsMiddleware) Prune(ctx context.Context, chanID string, formats []string, before time.Time) (uint64, error) {
+	defer func(begin time.Time) {
+		pm.counter.With("method", "prune").Add(1)
+		pm.latency.With("method", "prune").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	n, err := pm.pruner.Prune(ctx, chanID, formats, before)
+	pm.removed.Add(float64(n))
+	return n, err
+}
+
+// DropChunks drops the expired chunks if the wrapped pruner partitions the
+// messages into chunks, and does nothing otherwise.
+func (pm *prunerMetricsMiddleware) DropChunks(ctx context.Context, policies map[string]writers.Policy, now time.Time) (uint64, error) {
+	cp, ok := pm.pruner.(writers.ChunkPruner)
+	if !ok {
+		return 0, nil
+	}
+
+	defer func(begin time.Time) {
+		pm.counter.With("method", "drop_chunks").Add(1)
+		pm.latency.With("method", "drop_chunks").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	n, err := cp.DropChunks(ctx, policies, now)
+	pm.removed.Add(float64(n))
+	return n, err
+}
+
+var _ consumers.DeadLetters = (*deadLettersMetricsMiddleware)(nil)
+
+type deadLettersMetricsMiddleware struct {
+	counter     metrics.Counter
+	latency     metrics.Histogram
+	failures    metrics.Counter
+	deadLetters consumers.DeadLetters
+}
+
+// DeadLettersMetricsMiddleware returns new dead letters with methods wrapped
+// to expose metrics, including the number of dead letters per failure reason.
+func DeadLettersMetricsMiddleware(dls consumers.DeadLetters, counter metrics.Counter, latency metrics.Histogram, failures metrics.Counter) consumers.DeadLetters {
+	return &deadLettersMetricsMiddleware{
+		counter:     counter,
+		latency:     latency,
+		failures:    failures,
+		deadLetters: dls,
+	}
+}
+
+func (dm *deadLettersMetricsMiddleware) Add(ctx context.Context, dl consumers.DeadLetter) (consumers.DeadLetter, error) {
+	defer func(begin time.Time) {
+		dm.counter.With("method", "add").Add(1)
+		dm.latency.With("method", "add").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	dm.failures.With("reason", dl.Reason).Add(1)
+	return dm.deadLetters.Add(ctx, dl)
+}
+
+func (dm *deadLettersMetricsMiddleware) List(ctx context.Context, offset, limit uint64) (consumers.DeadLettersPage, error) {
+	defer func(begin time.Time) {
+		dm.counter.With("method", "list").Add(1)
+		dm.latency.With("method", "list").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return dm.deadLetters.List(ctx, offset, limit)
+}
+
+func (dm *deadLettersMetricsMiddleware) Replay(ctx context.Context, id string) error {
+	defer func(begin time.Time) {
+		dm.counter.With("method", "replay").Add(1)
+		dm.latency.With("method", "replay").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return dm.deadLetters.Replay(ctx, id)
+}
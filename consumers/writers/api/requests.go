@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import "github.com/MainfluxLabs/mainflux/internal/apiutil"
+
+const maxLimitSize = 100
+
+type listDeadLettersReq struct {
+	token  string
+	offset uint64
+	limit  uint64
+}
+
+func (req listDeadLettersReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type replayDeadLetterReq struct {
+	token string
+	id    string
+}
+
+func (req replayDeadLetterReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type rotateDataKeyReq struct {
+	token  string
+	chanID string
+}
+
+func (req rotateDataKeyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type rewrapDataKeysReq struct {
+	token string
+}
+
+func (req rewrapDataKeysReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
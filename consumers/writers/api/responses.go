@@ -0,0 +1,103 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var (
+	_ mainflux.Response = (*deadLettersPageRes)(nil)
+	_ mainflux.Response = (*replayRes)(nil)
+	_ mainflux.Response = (*dataKeyRes)(nil)
+	_ mainflux.Response = (*rewrapRes)(nil)
+)
+
+type deadLetterRes struct {
+	ID        string            `json:"id"`
+	Consumer  string            `json:"consumer"`
+	Subject   string            `json:"subject"`
+	Reason    string            `json:"reason"`
+	Error     string            `json:"error"`
+	Attempts  uint64            `json:"attempts"`
+	Encrypted bool              `json:"encrypted"`
+	Message   messaging.Message `json:"message"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type deadLettersPageRes struct {
+	pageRes
+	DeadLetters []deadLetterRes `json:"dead_letters"`
+}
+
+func (res deadLettersPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res deadLettersPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res deadLettersPageRes) Empty() bool {
+	return false
+}
+
+type replayRes struct{}
+
+func (res replayRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res replayRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res replayRes) Empty() bool {
+	return true
+}
+
+type dataKeyRes struct {
+	ID          string    `json:"id"`
+	ChannelID   string    `json:"channel"`
+	MasterKeyID string    `json:"master_key_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (res dataKeyRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res dataKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res dataKeyRes) Empty() bool {
+	return false
+}
+
+type rewrapRes struct {
+	Rewrapped uint64 `json:"rewrapped"`
+}
+
+func (res rewrapRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rewrapRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rewrapRes) Empty() bool {
+	return false
+}
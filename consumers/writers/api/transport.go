@@ -4,18 +4,165 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-// MakeHandler returns a HTTP API handler with health check and metrics.
-func MakeHandler(svcName string) http.Handler {
+const (
+	contentType = "application/json"
+	offsetKey   = "offset"
+	limitKey    = "limit"
+	idKey       = "id"
+	defOffset   = 0
+	defLimit    = 10
+)
+
+// MakeHandler returns a HTTP API handler with health check, metrics and
+// the dead letters endpoints. If the encryption service is given, the data
+// keys rotation endpoints are added as well. The dead letters and the data
+// keys can be managed by the root admin only.
+func MakeHandler(svcName string, dls consumers.DeadLetters, keys encryption.Service, ac mainflux.AuthServiceClient, logger log.Logger) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+	}
+
 	r := bone.New()
+
+	r.Get("/deadletters", kithttp.NewServer(
+		listDeadLettersEndpoint(dls, ac),
+		decodeListDeadLetters,
+		encodeResponse,
+		opts...,
+	))
+
+	r.Post("/deadletters/:id/replay", kithttp.NewServer(
+		replayDeadLetterEndpoint(dls, ac),
+		decodeReplayDeadLetter,
+		encodeResponse,
+		opts...,
+	))
+
+	if keys != nil {
+		r.Post("/channels/:id/keys", kithttp.NewServer(
+			rotateDataKeyEndpoint(keys, ac),
+			decodeRotateDataKey,
+			encodeResponse,
+			opts...,
+		))
+
+		r.Post("/keys/rewrap", kithttp.NewServer(
+			rewrapDataKeysEndpoint(keys, ac),
+			decodeRewrapDataKeys,
+			encodeResponse,
+			opts...,
+		))
+	}
+
 	r.GetFunc("/health", mainflux.Health(svcName))
 	r.Handle("/metrics", promhttp.Handler())
 
 	return r
 }
+
+func decodeListDeadLetters(_ context.Context, r *http.Request) (interface{}, error) {
+	o, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := apiutil.ReadLimitQuery(r, limitKey, defLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	req := listDeadLettersReq{
+		token:  apiutil.ExtractBearerToken(r),
+		offset: o,
+		limit:  l,
+	}
+
+	return req, nil
+}
+
+func decodeReplayDeadLetter(_ context.Context, r *http.Request) (interface{}, error) {
+	req := replayDeadLetterReq{
+		token: apiutil.ExtractBearerToken(r),
+		id:    bone.GetValue(r, idKey),
+	}
+
+	return req, nil
+}
+
+func decodeRotateDataKey(_ context.Context, r *http.Request) (interface{}, error) {
+	req := rotateDataKeyReq{
+		token:  apiutil.ExtractBearerToken(r),
+		chanID: bone.GetValue(r, idKey),
+	}
+
+	return req, nil
+}
+
+func decodeRewrapDataKeys(_ context.Context, r *http.Request) (interface{}, error) {
+	req := rewrapDataKeysReq{
+		token: apiutil.ExtractBearerToken(r),
+	}
+
+	return req, nil
+}
+
+func encodeResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	w.Header().Set("Content-Type", contentType)
+
+	if ar, ok := response.(mainflux.Response); ok {
+		for k, v := range ar.Headers() {
+			w.Header().Set(k, v)
+		}
+
+		w.WriteHeader(ar.Code())
+
+		if ar.Empty() {
+			return nil
+		}
+	}
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+func encodeError(_ context.Context, err error, w http.ResponseWriter) {
+	switch {
+	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		err == apiutil.ErrLimitSize,
+		err == apiutil.ErrMissingID:
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, errors.ErrAuthentication),
+		err == apiutil.ErrBearerToken:
+		w.WriteHeader(http.StatusUnauthorized)
+	case errors.Contains(err, errors.ErrAuthorization):
+		w.WriteHeader(http.StatusForbidden)
+	case errors.Contains(err, errors.ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	case errors.Contains(err, consumers.ErrReplay),
+		errors.Contains(err, encryption.ErrUnknownMasterKey):
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if errorVal, ok := err.(errors.Error); ok {
+		w.Header().Set("Content-Type", contentType)
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+}
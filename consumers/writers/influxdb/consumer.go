@@ -5,10 +5,12 @@ package influxdb
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -20,7 +22,10 @@ import (
 
 const senmlPoints = "messages"
 
-var _ consumers.Consumer = (*influxRepo)(nil)
+var (
+	_ consumers.Consumer = (*influxRepo)(nil)
+	_ writers.Pruner     = (*influxRepo)(nil)
+)
 
 type RepoConfig struct {
 	Bucket string
@@ -39,6 +44,14 @@ func New(client influxdb2.Client, config RepoConfig) consumers.Consumer {
 	}
 }
 
+// NewPruner returns new InfluxDB messages pruner.
+func NewPruner(client influxdb2.Client, config RepoConfig) writers.Pruner {
+	return &influxRepo{
+		client: client,
+		cfg:    config,
+	}
+}
+
 func (repo *influxRepo) Consume(message interface{}) error {
 	var err error
 	var pts []*influxdb2write.Point
@@ -131,3 +144,48 @@ func (repo *influxRepo) jsonPoints(msgs json.Messages) ([]*influxdb2write.Point,
 
 	return pts, nil
 }
+
+func (repo *influxRepo) Prune(ctx context.Context, chanID string, formats []string, before time.Time) (uint64, error) {
+	var total uint64
+	for _, measurement := range append([]string{senmlPoints}, formats...) {
+		// Delete API doesn't report the number of removed points, so count them first.
+		n, err := repo.count(ctx, measurement, chanID, before)
+		if err != nil {
+			return total, errors.Wrap(writers.ErrPrune, err)
+		}
+		if n == 0 {
+			continue
+		}
+
+		predicate := fmt.Sprintf(`_measurement="%s" AND channel="%s"`, measurement, chanID)
+		if err := repo.client.DeleteAPI().DeleteWithName(ctx, repo.cfg.Org, repo.cfg.Bucket, time.Unix(0, 0), before, predicate); err != nil {
+			return total, errors.Wrap(writers.ErrPrune, err)
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+func (repo *influxRepo) count(ctx context.Context, measurement, chanID string, before time.Time) (uint64, error) {
+	// Every point has the protocol field, so counting it counts the points.
+	query := fmt.Sprintf(`from(bucket: "%s")
+		|> range(start: 0, stop: time(v: %d))
+		|> filter(fn: (r) => r._measurement == "%s" and r.channel == "%s" and r._field == "protocol")
+		|> group()
+		|> count()`, repo.cfg.Bucket, before.UnixNano(), measurement, chanID)
+
+	resp, err := repo.client.QueryAPI(repo.cfg.Org).Query(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	var n uint64
+	for resp.Next() {
+		if v, ok := resp.Record().Value().(int64); ok {
+			n = uint64(v)
+		}
+	}
+
+	return n, resp.Err()
+}
@@ -5,10 +5,13 @@ package mongodb
 
 import (
 	"context"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -16,7 +19,10 @@ import (
 
 const senmlCollection string = "messages"
 
-var _ consumers.Consumer = (*mongoRepo)(nil)
+var (
+	_ consumers.Consumer = (*mongoRepo)(nil)
+	_ writers.Pruner     = (*mongoRepo)(nil)
+)
 
 type mongoRepo struct {
 	db *mongo.Database
@@ -27,6 +33,11 @@ func New(db *mongo.Database) consumers.Consumer {
 	return &mongoRepo{db}
 }
 
+// NewPruner returns new MongoDB messages pruner.
+func NewPruner(db *mongo.Database) writers.Pruner {
+	return &mongoRepo{db}
+}
+
 func (repo *mongoRepo) Consume(message interface{}) error {
 	switch m := message.(type) {
 	case json.Messages:
@@ -71,3 +82,25 @@ func (repo *mongoRepo) saveJSON(msgs json.Messages) error {
 
 	return nil
 }
+
+func (repo *mongoRepo) Prune(ctx context.Context, chanID string, formats []string, before time.Time) (uint64, error) {
+	// SenML time is stored in seconds, while JSON messages are created in nanoseconds.
+	sec := float64(before.UnixNano()) / float64(time.Second)
+	filter := bson.D{{Key: "channel", Value: chanID}, {Key: "time", Value: bson.M{"$lt": sec}}}
+	res, err := repo.db.Collection(senmlCollection).DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, errors.Wrap(writers.ErrPrune, err)
+	}
+	total := uint64(res.DeletedCount)
+
+	for _, format := range formats {
+		filter := bson.D{{Key: "channel", Value: chanID}, {Key: "created", Value: bson.M{"$lt": before.UnixNano()}}}
+		res, err := repo.db.Collection(format).DeleteMany(ctx, filter)
+		if err != nil {
+			return total, errors.Wrap(writers.ErrPrune, err)
+		}
+		total += uint64(res.DeletedCount)
+	}
+
+	return total, nil
+}
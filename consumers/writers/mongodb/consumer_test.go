@@ -134,3 +134,80 @@ func TestSaveJSON(t *testing.T) {
 	err = repo.Consume(msgs)
 	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 }
+
+func TestPrune(t *testing.T) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(addr))
+	require.Nil(t, err, fmt.Sprintf("Creating new MongoDB client expected to succeed: %s.\n", err))
+
+	db := client.Database(testDB)
+	repo := mongodb.New(db)
+	pruner := mongodb.NewPruner(db)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now()
+	var senmlMsgs []senml.Message
+	jsonMsgs := json.Messages{
+		Format: "prune_json",
+	}
+	for i := 0; i < msgsNum; i++ {
+		created := now.Add(-time.Duration(i) * time.Hour)
+		senmlMsgs = append(senmlMsgs, senml.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Time:      float64(created.Unix()),
+			Value:     &v,
+		})
+		jsonMsgs.Data = append(jsonMsgs.Data, json.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Created:   created.UnixNano(),
+			Protocol:  "mqtt",
+			Payload:   map[string]interface{}{"field_1": 123},
+		})
+	}
+
+	err = repo.Consume(senmlMsgs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = repo.Consume(jsonMsgs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Messages published within the last 10 hours are kept.
+	before := now.Add(-10*time.Hour + time.Minute)
+	expected := uint64(2 * (msgsNum - 10))
+
+	cases := []struct {
+		desc    string
+		chanID  string
+		formats []string
+		removed uint64
+	}{
+		{
+			desc:    "prune messages of unknown channel",
+			chanID:  "unknown",
+			formats: []string{"prune_json"},
+			removed: 0,
+		},
+		{
+			desc:    "prune messages with non-existing format",
+			chanID:  chid.String(),
+			formats: []string{"prune_json", "unknown_json"},
+			removed: expected,
+		},
+		{
+			desc:    "prune already pruned messages",
+			chanID:  chid.String(),
+			formats: []string{"prune_json"},
+			removed: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		removed, err := pruner.Prune(context.Background(), tc.chanID, tc.formats, before)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.removed, removed, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.removed, removed))
+	}
+}
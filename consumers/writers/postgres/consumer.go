@@ -7,13 +7,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/gofrs/uuid"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx" // required for DB access
 )
@@ -24,7 +28,10 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
-var _ consumers.Consumer = (*postgresRepo)(nil)
+var (
+	_ consumers.Consumer = (*postgresRepo)(nil)
+	_ writers.Pruner     = (*postgresRepo)(nil)
+)
 
 type postgresRepo struct {
 	db *sqlx.DB
@@ -35,6 +42,11 @@ func New(db *sqlx.DB) consumers.Consumer {
 	return &postgresRepo{db: db}
 }
 
+// NewPruner returns new PostgreSQL messages pruner.
+func NewPruner(db *sqlx.DB) writers.Pruner {
+	return &postgresRepo{db: db}
+}
+
 func (pr postgresRepo) Consume(message interface{}) (err error) {
 	switch m := message.(type) {
 	case mfjson.Messages:
@@ -211,3 +223,41 @@ func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
 
 	return m, nil
 }
+
+func (pr postgresRepo) Prune(ctx context.Context, chanID string, formats []string, before time.Time) (uint64, error) {
+	// SenML time is stored in seconds, while JSON messages are created in nanoseconds.
+	sec := float64(before.UnixNano()) / float64(time.Second)
+	res, err := pr.db.ExecContext(ctx, "DELETE FROM messages WHERE channel = $1 AND time < $2;", chanID, sec)
+	if err != nil {
+		return 0, errors.Wrap(writers.ErrPrune, err)
+	}
+	total, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(writers.ErrPrune, err)
+	}
+
+	for _, format := range formats {
+		q := fmt.Sprintf("DELETE FROM %s WHERE channel = $1 AND created < $2;", tableName(format))
+		res, err := pr.db.ExecContext(ctx, q, chanID, before.UnixNano())
+		if err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UndefinedTable {
+				continue
+			}
+			return uint64(total), errors.Wrap(writers.ErrPrune, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return uint64(total), errors.Wrap(writers.ErrPrune, err)
+		}
+		total += n
+	}
+
+	return uint64(total), nil
+}
+
+// tableName returns the quoted name of the table holding the messages of the
+// JSON format, which is derived from the message subtopic. The tables are
+// created with unquoted names, which PostgreSQL folds to lower case.
+func tableName(format string) string {
+	return pgx.Identifier{strings.ToLower(format)}.Sanitize()
+}
@@ -0,0 +1,264 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+var (
+	// ErrPrune indicates failure occurred while removing expired messages.
+	ErrPrune = errors.New("failed to prune messages")
+
+	// ErrLoadRetention indicates failure occurred while loading retention
+	// policies from the channel profiles.
+	ErrLoadRetention = errors.New("failed to load retention policies")
+)
+
+// Pruner specifies the API for removing expired messages from a data store.
+type Pruner interface {
+	// Prune removes messages of the channel created before the given time,
+	// both from the SenML messages and from the messages stored in the given
+	// JSON formats, and returns the number of removed messages.
+	Prune(ctx context.Context, chanID string, formats []string, before time.Time) (uint64, error)
+}
+
+// ChunkPruner is implemented by the pruners of the data stores which
+// partition messages into time chunks, so that the chunks holding only
+// expired messages are dropped at once, before pruning the channels.
+type ChunkPruner interface {
+	// DropChunks drops the chunks whose messages all belong to the channels
+	// of the given policies and were created before their retention periods,
+	// as of the given time, and returns the number of removed messages.
+	DropChunks(ctx context.Context, policies map[string]Policy, now time.Time) (uint64, error)
+}
+
+// Policy represents retention policy of a single channel.
+type Policy struct {
+	Period  time.Duration
+	Formats []string
+
+	// retention is the period as defined by the profile, used to detect
+	// the profile changes without parsing the period again.
+	retention string
+}
+
+// Retention keeps retention policies of the channels, as defined by the writer
+// section of their profiles. The policies are loaded from the things service
+// and updated by the profiles of the received messages.
+type Retention struct {
+	mu       sync.RWMutex
+	policies map[string]Policy
+}
+
+// NewRetention returns empty retention policies registry.
+func NewRetention() *Retention {
+	return &Retention{
+		policies: make(map[string]Policy),
+	}
+}
+
+// Load records retention policies of all the channels whose profiles define
+// the retention period, so that their messages are pruned even if no new
+// message is received. JSON formats are known from the writer subtopics of
+// the profile, while the other formats are recorded once their messages are
+// observed.
+func (r *Retention) Load(ctx context.Context, things mainflux.ThingsServiceClient) error {
+	res, err := things.GetRetentionProfiles(ctx, &empty.Empty{})
+	if err != nil {
+		return errors.Wrap(ErrLoadRetention, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, cp := range res.GetChannelProfiles() {
+		profile := cp.GetProfile()
+		var formats []string
+		if profile.GetContentType() == messaging.JsonContentType {
+			for _, subtopic := range profile.GetWriter().GetSubtopics() {
+				formats = appendFormat(formats, format(subtopic))
+			}
+		}
+		r.set(cp.GetChannelID(), profile.GetWriter().GetRetention(), formats...)
+	}
+
+	return nil
+}
+
+// Observe records retention policy of the message's channel. Channels
+// without a valid retention period keep their messages forever.
+func (r *Retention) Observe(msg messaging.Message) {
+	if msg.Profile == nil || msg.Profile.Writer == nil {
+		return
+	}
+
+	retention := msg.Profile.Writer.Retention
+	var formats []string
+	if msg.Profile.ContentType == messaging.JsonContentType && msg.Subtopic != "" {
+		formats = append(formats, format(msg.Subtopic))
+	}
+
+	// Most of the messages don't change the policy, so check it under the
+	// read lock first.
+	if !r.changed(msg.Channel, retention, formats) {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.set(msg.Channel, retention, formats...)
+}
+
+func (r *Retention) changed(chanID, retention string, formats []string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	p, ok := r.policies[chanID]
+	if !ok {
+		return retention != ""
+	}
+	if p.retention != retention {
+		return true
+	}
+	for _, f := range formats {
+		if !hasFormat(p.Formats, f) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Retention) set(chanID, retention string, formats ...string) {
+	period, err := time.ParseDuration(retention)
+	if err != nil || period <= 0 {
+		delete(r.policies, chanID)
+		return
+	}
+
+	p := r.policies[chanID]
+	p.Period = period
+	p.retention = retention
+	for _, f := range formats {
+		p.Formats = appendFormat(p.Formats, f)
+	}
+	r.policies[chanID] = p
+}
+
+// Policies returns a copy of the recorded policies mapped by channel ID.
+func (r *Retention) Policies() map[string]Policy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policies := make(map[string]Policy, len(r.policies))
+	for id, p := range r.policies {
+		policies[id] = Policy{
+			Period:  p.Period,
+			Formats: append([]string(nil), p.Formats...),
+		}
+	}
+
+	return policies
+}
+
+// Subscriber decorates the subscriber so that every received message
+// is observed before being handed over to the handler.
+func (r *Retention) Subscriber(sub messaging.Subscriber) messaging.Subscriber {
+	return &retentionSubscriber{
+		Subscriber: sub,
+		retention:  r,
+	}
+}
+
+// StartPruning removes the expired messages of all the observed channels
+// once per interval, until the context is canceled.
+func StartPruning(ctx context.Context, pruner Pruner, retention *Retention, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			policies := retention.Policies()
+			if cp, ok := pruner.(ChunkPruner); ok {
+				if _, err := cp.DropChunks(ctx, policies, now); err != nil {
+					logger.Warn(fmt.Sprintf("Failed to drop expired chunks: %s", err))
+				}
+			}
+			for chanID, p := range policies {
+				if _, err := pruner.Prune(ctx, chanID, p.Formats, now.Add(-p.Period)); err != nil {
+					logger.Warn(fmt.Sprintf("Failed to prune messages of channel %s: %s", chanID, err))
+				}
+			}
+		}
+	}
+}
+
+// format returns the JSON format of the messages published to the subtopic,
+// which is the last token of the subtopic.
+func format(subtopic string) string {
+	subs := strings.Split(subtopic, ".")
+	return subs[len(subs)-1]
+}
+
+func appendFormat(formats []string, format string) []string {
+	if hasFormat(formats, format) {
+		return formats
+	}
+	return append(formats, format)
+}
+
+func hasFormat(formats []string, format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+type retentionSubscriber struct {
+	messaging.Subscriber
+	retention *Retention
+}
+
+func (rs *retentionSubscriber) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	return rs.Subscriber.Subscribe(id, topic, retentionHandler{
+		MessageHandler: handler,
+		retention:      rs.retention,
+	})
+}
+
+type retentionHandler struct {
+	messaging.MessageHandler
+	retention *Retention
+}
+
+func (rh retentionHandler) Handle(msg messaging.Message) error {
+	rh.retention.Observe(msg)
+	return rh.MessageHandler.Handle(msg)
+}
+
+func (rh retentionHandler) HandleDelivery(msg messaging.Message, d messaging.Delivery) error {
+	dh, ok := rh.MessageHandler.(messaging.DeliveryHandler)
+	if !ok {
+		return rh.Handle(msg)
+	}
+	rh.retention.Observe(msg)
+	return dh.HandleDelivery(msg, d)
+}
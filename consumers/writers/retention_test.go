@@ -0,0 +1,228 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package writers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+const (
+	chanID  = "chan-1"
+	topic   = "channels.>"
+	subID   = "writer"
+	retDay  = "24h"
+	retHour = "1h"
+)
+
+func TestObserve(t *testing.T) {
+	cases := []struct {
+		desc     string
+		msgs     []messaging.Message
+		policies map[string]writers.Policy
+	}{
+		{
+			desc:     "observe message without profile",
+			msgs:     []messaging.Message{{Channel: chanID}},
+			policies: map[string]writers.Policy{},
+		},
+		{
+			desc:     "observe message without retention",
+			msgs:     []messaging.Message{newMessage(chanID, "", "", messaging.SenmlContentType)},
+			policies: map[string]writers.Policy{},
+		},
+		{
+			desc:     "observe message with invalid retention",
+			msgs:     []messaging.Message{newMessage(chanID, "", "forever", messaging.SenmlContentType)},
+			policies: map[string]writers.Policy{},
+		},
+		{
+			desc:     "observe message with negative retention",
+			msgs:     []messaging.Message{newMessage(chanID, "", "-1h", messaging.SenmlContentType)},
+			policies: map[string]writers.Policy{},
+		},
+		{
+			desc: "observe SenML message with retention",
+			msgs: []messaging.Message{newMessage(chanID, "", retDay, messaging.SenmlContentType)},
+			policies: map[string]writers.Policy{
+				chanID: {Period: 24 * time.Hour},
+			},
+		},
+		{
+			desc: "observe JSON messages with retention",
+			msgs: []messaging.Message{
+				newMessage(chanID, "devices.temperature", retDay, messaging.JsonContentType),
+				newMessage(chanID, "humidity", retDay, messaging.JsonContentType),
+				newMessage(chanID, "temperature", retHour, messaging.JsonContentType),
+			},
+			policies: map[string]writers.Policy{
+				chanID: {Period: time.Hour, Formats: []string{"temperature", "humidity"}},
+			},
+		},
+		{
+			desc: "observe messages with unchanged retention",
+			msgs: []messaging.Message{
+				newMessage(chanID, "temperature", retDay, messaging.JsonContentType),
+				newMessage(chanID, "temperature", retDay, messaging.JsonContentType),
+			},
+			policies: map[string]writers.Policy{
+				chanID: {Period: 24 * time.Hour, Formats: []string{"temperature"}},
+			},
+		},
+		{
+			desc: "observe message with removed retention",
+			msgs: []messaging.Message{
+				newMessage(chanID, "", retDay, messaging.SenmlContentType),
+				newMessage(chanID, "", "", messaging.SenmlContentType),
+			},
+			policies: map[string]writers.Policy{},
+		},
+	}
+
+	for _, tc := range cases {
+		retention := writers.NewRetention()
+		for _, msg := range tc.msgs {
+			retention.Observe(msg)
+		}
+		assert.Equal(t, tc.policies, retention.Policies(), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policies, retention.Policies()))
+	}
+}
+
+func TestLoad(t *testing.T) {
+	profiles := []*mainflux.ChannelProfile{
+		{
+			ChannelID: chanID,
+			Profile: &mainflux.Profile{
+				ContentType: messaging.SenmlContentType,
+				Writer:      &mainflux.Writer{Retention: retDay, Subtopics: []string{"temperature"}},
+			},
+		},
+		{
+			ChannelID: "chan-2",
+			Profile: &mainflux.Profile{
+				ContentType: messaging.JsonContentType,
+				Writer:      &mainflux.Writer{Retention: retHour, Subtopics: []string{"devices.temperature", "humidity", "temperature"}},
+			},
+		},
+		{
+			ChannelID: "chan-3",
+			Profile: &mainflux.Profile{
+				ContentType: messaging.JsonContentType,
+				Writer:      &mainflux.Writer{Retention: "forever"},
+			},
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		things   mainflux.ThingsServiceClient
+		policies map[string]writers.Policy
+		err      error
+	}{
+		{
+			desc:   "load retention policies",
+			things: thingsClient{profiles: profiles},
+			policies: map[string]writers.Policy{
+				chanID:   {Period: 24 * time.Hour},
+				"chan-2": {Period: time.Hour, Formats: []string{"temperature", "humidity"}},
+			},
+			err: nil,
+		},
+		{
+			desc:     "load retention policies with unavailable things service",
+			things:   thingsClient{err: errors.ErrNotFound},
+			policies: map[string]writers.Policy{},
+			err:      writers.ErrLoadRetention,
+		},
+	}
+
+	for _, tc := range cases {
+		retention := writers.NewRetention()
+		err := retention.Load(context.Background(), tc.things)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.policies, retention.Policies(), fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.policies, retention.Policies()))
+	}
+}
+
+func TestSubscriber(t *testing.T) {
+	retention := writers.NewRetention()
+	sub := &subscriber{}
+	h := &handler{}
+
+	err := retention.Subscriber(sub).Subscribe(subID, topic, h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msg := newMessage(chanID, "", retDay, messaging.SenmlContentType)
+	err = sub.handler.Handle(msg)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.Equal(t, 1, h.handled, fmt.Sprintf("expected message to be handled once, got %d\n", h.handled))
+
+	expected := map[string]writers.Policy{chanID: {Period: 24 * time.Hour}}
+	assert.Equal(t, expected, retention.Policies(), fmt.Sprintf("expected %v got %v\n", expected, retention.Policies()))
+}
+
+func newMessage(chanID, subtopic, retention, contentType string) messaging.Message {
+	return messaging.Message{
+		Channel:  chanID,
+		Subtopic: subtopic,
+		Profile: &messaging.Profile{
+			ContentType: contentType,
+			Writer:      &messaging.Writer{Retention: retention},
+		},
+	}
+}
+
+type thingsClient struct {
+	mainflux.ThingsServiceClient
+	profiles []*mainflux.ChannelProfile
+	err      error
+}
+
+func (tc thingsClient) GetRetentionProfiles(context.Context, *empty.Empty, ...grpc.CallOption) (*mainflux.ChannelProfilesRes, error) {
+	if tc.err != nil {
+		return nil, tc.err
+	}
+	return &mainflux.ChannelProfilesRes{ChannelProfiles: tc.profiles}, nil
+}
+
+type subscriber struct {
+	handler messaging.MessageHandler
+}
+
+func (s *subscriber) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	s.handler = handler
+	return nil
+}
+
+func (s *subscriber) Unsubscribe(id, topic string) error {
+	return nil
+}
+
+func (s *subscriber) Close() error {
+	return nil
+}
+
+type handler struct {
+	handled int
+}
+
+func (h *handler) Handle(msg messaging.Message) error {
+	h.handled++
+	return nil
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
@@ -5,14 +5,19 @@ package timescale
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	mfjson "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx" // required for DB access
 )
@@ -23,7 +28,11 @@ var (
 	errNoTable        = errors.New("relation does not exist")
 )
 
-var _ consumers.Consumer = (*timescaleRepo)(nil)
+var (
+	_ consumers.Consumer  = (*timescaleRepo)(nil)
+	_ writers.Pruner      = (*timescaleRepo)(nil)
+	_ writers.ChunkPruner = (*timescaleRepo)(nil)
+)
 
 type timescaleRepo struct {
 	db *sqlx.DB
@@ -34,6 +43,11 @@ func New(db *sqlx.DB) consumers.Consumer {
 	return &timescaleRepo{db: db}
 }
 
+// NewPruner returns new TimescaleSQL messages pruner.
+func NewPruner(db *sqlx.DB) writers.Pruner {
+	return &timescaleRepo{db: db}
+}
+
 func (tr timescaleRepo) Consume(message interface{}) (err error) {
 	switch m := message.(type) {
 	case mfjson.Messages:
@@ -158,6 +172,12 @@ func (tr timescaleRepo) createTable(name string) error {
         );`
 	q = fmt.Sprintf(q, name)
 
+	if _, err := tr.db.Exec(q); err != nil {
+		return err
+	}
+
+	// JSON messages are created in nanoseconds, so chunks hold one day of messages.
+	q = fmt.Sprintf("SELECT create_hypertable('%s', 'created', create_default_indexes => FALSE, chunk_time_interval => 86400000000000, if_not_exists => TRUE);", name)
 	_, err := tr.db.Exec(q)
 	return err
 }
@@ -196,3 +216,133 @@ func toJSONMessage(msg mfjson.Message) (jsonMessage, error) {
 
 	return m, nil
 }
+
+func (tr timescaleRepo) Prune(ctx context.Context, chanID string, formats []string, before time.Time) (uint64, error) {
+	// SenML time is stored in seconds, while JSON messages are created in nanoseconds.
+	sec := float64(before.UnixNano()) / float64(time.Second)
+	res, err := tr.db.ExecContext(ctx, "DELETE FROM messages WHERE channel = $1 AND time < $2;", chanID, sec)
+	if err != nil {
+		return 0, errors.Wrap(writers.ErrPrune, err)
+	}
+	total, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(writers.ErrPrune, err)
+	}
+
+	for _, format := range formats {
+		q := fmt.Sprintf("DELETE FROM %s WHERE channel = $1 AND created < $2;", tableName(format))
+		res, err := tr.db.ExecContext(ctx, q, chanID, before.UnixNano())
+		if err != nil {
+			if pgErr, ok := err.(*pgconn.PgError); ok && pgErr.Code == pgerrcode.UndefinedTable {
+				continue
+			}
+			return uint64(total), errors.Wrap(writers.ErrPrune, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return uint64(total), errors.Wrap(writers.ErrPrune, err)
+		}
+		total += n
+	}
+
+	return uint64(total), nil
+}
+
+// tableName returns the quoted name of the table holding the messages of the
+// JSON format, which is derived from the message subtopic. The tables are
+// created with unquoted names, which PostgreSQL folds to lower case.
+func tableName(format string) string {
+	return pgx.Identifier{strings.ToLower(format)}.Sanitize()
+}
+
+type chunk struct {
+	Start int64 `db:"range_start_integer"`
+	End   int64 `db:"range_end_integer"`
+}
+
+// DropChunks drops the chunks of the SenML messages and the JSON formats
+// hypertables which hold only expired messages. Dropping a chunk is much
+// cheaper than deleting its messages, which Prune does for the chunks shared
+// with the channels which still retain their messages.
+func (tr timescaleRepo) DropChunks(ctx context.Context, policies map[string]writers.Policy, now time.Time) (uint64, error) {
+	// SenML time is stored in seconds, while JSON messages are created in nanoseconds.
+	total, err := tr.dropExpiredChunks(ctx, "messages", "time", time.Second, policies, now)
+	if err != nil {
+		return total, errors.Wrap(writers.ErrPrune, err)
+	}
+
+	formats := make(map[string]bool)
+	for _, p := range policies {
+		for _, f := range p.Formats {
+			formats[f] = true
+		}
+	}
+
+	for format := range formats {
+		n, err := tr.dropExpiredChunks(ctx, format, "created", time.Nanosecond, policies, now)
+		total += n
+		if err != nil {
+			return total, errors.Wrap(writers.ErrPrune, err)
+		}
+	}
+
+	return total, nil
+}
+
+// dropExpiredChunks drops the expired chunks of the hypertable whose time
+// column is stored in the given units.
+func (tr timescaleRepo) dropExpiredChunks(ctx context.Context, table, column string, unit time.Duration, policies map[string]writers.Policy, now time.Time) (uint64, error) {
+	var chunks []chunk
+	q := "SELECT range_start_integer, range_end_integer FROM timescaledb_information.chunks WHERE hypertable_name = $1;"
+	if err := tr.db.SelectContext(ctx, &chunks, q, strings.ToLower(table)); err != nil {
+		return 0, err
+	}
+
+	var total uint64
+	for _, c := range chunks {
+		n, expired, err := tr.expiredChunk(ctx, table, column, unit, c, policies, now)
+		if err != nil {
+			return total, err
+		}
+		if !expired {
+			continue
+		}
+
+		q := "SELECT drop_chunks(CAST($1 AS REGCLASS), older_than => CAST($2 AS BIGINT), newer_than => CAST($3 AS BIGINT));"
+		if _, err := tr.db.ExecContext(ctx, q, tableName(table), c.End, c.Start); err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	return total, nil
+}
+
+// expiredChunk returns the number of messages of the chunk and whether they
+// all belong to the channels with retention policies and were created before
+// the retention period of their channel.
+func (tr timescaleRepo) expiredChunk(ctx context.Context, table, column string, unit time.Duration, c chunk, policies map[string]writers.Policy, now time.Time) (uint64, bool, error) {
+	q := fmt.Sprintf("SELECT channel, COUNT(*) FROM %s WHERE %s >= $1 AND %s < $2 GROUP BY channel;", tableName(table), column, column)
+	rows, err := tr.db.QueryxContext(ctx, q, c.Start, c.End)
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+
+	var total uint64
+	for rows.Next() {
+		var chanID sql.NullString
+		var n uint64
+		if err := rows.Scan(&chanID, &n); err != nil {
+			return 0, false, err
+		}
+
+		p, ok := policies[chanID.String]
+		if !chanID.Valid || !ok || now.Add(-p.Period).UnixNano()/int64(unit) < c.End {
+			return 0, false, nil
+		}
+		total += n
+	}
+
+	return total, true, rows.Err()
+}
@@ -4,10 +4,12 @@
 package timescale_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux/consumers/writers"
 	"github.com/MainfluxLabs/mainflux/consumers/writers/timescale"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/json"
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -111,3 +113,170 @@ func TestSaveJSON(t *testing.T) {
 	err = repo.Consume(msgs)
 	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
 }
+
+func TestPrune(t *testing.T) {
+	repo := timescale.New(db)
+	pruner := timescale.NewPruner(db)
+
+	chid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubid, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	unknownID, err := uuid.NewV4()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	now := time.Now()
+	var senmlMsgs []senml.Message
+	jsonMsgs := json.Messages{
+		Format: "prune_json",
+	}
+	for i := 0; i < msgsNum; i++ {
+		created := now.Add(-time.Duration(i) * time.Hour)
+		senmlMsgs = append(senmlMsgs, senml.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Time:      float64(created.Unix()),
+			Value:     &v,
+		})
+		jsonMsgs.Data = append(jsonMsgs.Data, json.Message{
+			Channel:   chid.String(),
+			Publisher: pubid.String(),
+			Created:   created.UnixNano(),
+			Protocol:  "mqtt",
+			Payload:   map[string]interface{}{"field_1": 123},
+		})
+	}
+
+	err = repo.Consume(senmlMsgs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = repo.Consume(jsonMsgs)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Messages published within the last 10 hours are kept.
+	before := now.Add(-10*time.Hour + time.Minute)
+	expected := uint64(2 * (msgsNum - 10))
+
+	cases := []struct {
+		desc    string
+		chanID  string
+		formats []string
+		removed uint64
+	}{
+		{
+			desc:    "prune messages of unknown channel",
+			chanID:  unknownID.String(),
+			formats: []string{"prune_json"},
+			removed: 0,
+		},
+		{
+			desc:    "prune messages with non-existing format",
+			chanID:  chid.String(),
+			formats: []string{"prune_json", "unknown_json"},
+			removed: expected,
+		},
+		{
+			desc:    "prune already pruned messages",
+			chanID:  chid.String(),
+			formats: []string{"prune_json"},
+			removed: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		removed, err := pruner.Prune(context.Background(), tc.chanID, tc.formats, before)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.removed, removed, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.removed, removed))
+	}
+}
+
+func TestDropChunks(t *testing.T) {
+	repo := timescale.New(db)
+	pruner, ok := timescale.NewPruner(db).(writers.ChunkPruner)
+	require.True(t, ok, "expected Timescale pruner to drop chunks")
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id, err := uuid.NewV4()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ids = append(ids, id.String())
+	}
+	expiredID, sharedID, keptID := ids[0], ids[1], ids[2]
+
+	// Chunks hold one day of messages, so the messages of a single day share the chunk.
+	day := 24 * time.Hour
+	now := time.Now()
+	save := func(chanID string, created time.Time, format string) {
+		var senmlMsgs []senml.Message
+		jsonMsgs := json.Messages{
+			Format: format,
+		}
+		for i := 0; i < msgsNum; i++ {
+			created := created.Add(time.Duration(i) * time.Second)
+			senmlMsgs = append(senmlMsgs, senml.Message{
+				Channel:   chanID,
+				Publisher: chanID,
+				Time:      float64(created.Unix()),
+				Value:     &v,
+			})
+			jsonMsgs.Data = append(jsonMsgs.Data, json.Message{
+				Channel:   chanID,
+				Publisher: chanID,
+				Created:   created.UnixNano(),
+				Protocol:  "mqtt",
+				Payload:   map[string]interface{}{"field_1": 123},
+			})
+		}
+
+		err := repo.Consume(senmlMsgs)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		err = repo.Consume(jsonMsgs)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	save(expiredID, now.Add(-30*day).Truncate(day).Add(time.Hour), "drop_json")
+	save(keptID, now.Add(-40*day).Truncate(day).Add(time.Hour), "drop_json")
+	shared := now.Add(-50 * day).Truncate(day).Add(time.Hour)
+	save(sharedID, shared, "drop_json")
+	save(keptID, shared.Add(time.Hour), "drop_json")
+
+	policies := map[string]writers.Policy{
+		expiredID: {Period: 7 * day, Formats: []string{"drop_json"}},
+		sharedID:  {Period: 7 * day, Formats: []string{"drop_json"}},
+	}
+
+	cases := []struct {
+		desc     string
+		policies map[string]writers.Policy
+		removed  uint64
+	}{
+		{
+			desc:     "drop chunks without policies",
+			policies: map[string]writers.Policy{},
+			removed:  0,
+		},
+		{
+			desc:     "drop expired chunks",
+			policies: policies,
+			removed:  2 * msgsNum,
+		},
+		{
+			desc:     "drop already dropped chunks",
+			policies: policies,
+			removed:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		removed, err := pruner.DropChunks(context.Background(), tc.policies, now)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+		assert.Equal(t, tc.removed, removed, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.removed, removed))
+	}
+
+	// Messages of the channels without policy and the chunks shared with them are kept.
+	for _, id := range []string{sharedID, keptID} {
+		var count uint64
+		err := db.QueryRow("SELECT COUNT(*) FROM messages WHERE channel = $1;", id).Scan(&count)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		assert.NotZero(t, count, fmt.Sprintf("expected messages of channel %s to be kept", id))
+	}
+}
@@ -70,6 +70,33 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS data_keys (
+                        id            UUID,
+                        channel       UUID NOT NULL,
+                        master_key_id VARCHAR(254) NOT NULL,
+                        wrapped_key   BYTEA NOT NULL,
+                        created_at    TIMESTAMPTZ NOT NULL,
+                        PRIMARY KEY (id)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS data_keys_channel_idx ON data_keys (channel, created_at)`,
+				},
+				Down: []string{
+					"DROP TABLE data_keys",
+				},
+			},
+			{
+				Id: "messages_3",
+				Up: []string{
+					// Message time is stored in seconds, so chunks hold one day of messages.
+					`SELECT set_chunk_time_interval('messages', 86400);`,
+				},
+				Down: []string{
+					`SELECT set_chunk_time_interval('messages', 86400000);`,
+				},
+			},
 		},
 	}
 
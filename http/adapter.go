@@ -35,12 +35,16 @@ func New(publisher messaging.Publisher, things mainflux.ThingsServiceClient) Ser
 
 func (as *adapterService) Publish(ctx context.Context, key string, msg messaging.Message) error {
 	cr := &mainflux.ConnByKeyReq{
-		Key: key,
+		Key:    key,
+		ChanID: msg.Channel,
 	}
 	conn, err := as.things.GetConnByKey(ctx, cr)
 	if err != nil {
 		return err
 	}
+	if err := messaging.ValidatePayload(conn, msg.Payload); err != nil {
+		return err
+	}
 	m := messaging.CreateMessage(conn, msg.Protocol, msg.Subtopic, &msg.Payload)
 
 	return as.publisher.Publish(m)
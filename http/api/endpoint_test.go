@@ -4,6 +4,8 @@
 package api_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,8 +19,12 @@ import (
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	rlmocks "github.com/MainfluxLabs/mainflux/pkg/ratelimit/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 )
 
 const ServiceErrToken = "unavailable"
@@ -28,6 +34,11 @@ func newService(tc mainflux.ThingsServiceClient) adapter.Service {
 	return adapter.New(pub, tc)
 }
 
+func newLimitedService(tc mainflux.ThingsServiceClient, burst uint) adapter.Service {
+	pub := ratelimit.NewPublisher(mocks.NewPublisher(), rlmocks.NewLimiter(burst))
+	return adapter.New(pub, tc)
+}
+
 func newHTTPServer(svc adapter.Service) *httptest.Server {
 	logger := logger.NewMock()
 	mux := api.MakeHandler(svc, mocktracer.New(), logger)
@@ -148,7 +159,7 @@ func TestPublish(t *testing.T) {
 			msg:         msg,
 			contentType: ctSenmlJSON,
 			key:         thingKey,
-			status:      http.StatusAccepted,
+			status:      http.StatusBadRequest,
 		},
 		"publish message unable to authorize": {
 			chanID:      chanID,
@@ -174,3 +185,127 @@ func TestPublish(t *testing.T) {
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", desc, tc.status, res.StatusCode))
 	}
 }
+
+func TestPublishRateLimit(t *testing.T) {
+	chanID := "1"
+	thingKey := "thing_key"
+	msg := `[{"n":"current","t":-1,"v":1.6}]`
+	thingsClient := mocks.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
+	svc := newLimitedService(thingsClient, 2)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		status int
+	}{
+		{
+			desc:   "publish first message within limit",
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "publish second message within limit",
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "publish message over limit",
+			status: http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			contentType: "application/senml+json",
+			token:       thingKey,
+			body:        strings.NewReader(msg),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestPublishSchema(t *testing.T) {
+	chanID := "1"
+	thingKey := "thing_key"
+	schema := `{"type":"array","items":{"type":"object","required":["n","v"],"properties":{"n":{"type":"string"},"v":{"type":"number"}}}}`
+	profile := &mainflux.Profile{ContentType: messaging.SenmlContentType, Schema: []byte(schema)}
+	thingsClient := schemaThingsClient{
+		ThingsServiceClient: mocks.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil),
+		profile:             profile,
+	}
+	svc := newService(thingsClient)
+	ts := newHTTPServer(svc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc   string
+		msg    string
+		status int
+		err    string
+	}{
+		{
+			desc:   "publish message matching schema",
+			msg:    `[{"n":"current","t":-1,"v":1.6}]`,
+			status: http.StatusAccepted,
+		},
+		{
+			desc:   "publish message missing required field",
+			msg:    `[{"n":"current","t":-1}]`,
+			status: http.StatusBadRequest,
+			err:    "0: v is required",
+		},
+		{
+			desc:   "publish message with field of invalid type",
+			msg:    `[{"n":"current","t":-1,"v":"1.6"}]`,
+			status: http.StatusBadRequest,
+			err:    "0.v: Invalid type. Expected: number, given: string",
+		},
+		{
+			desc:   "publish malformed message",
+			msg:    `[{"n":"current"`,
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			contentType: messaging.SenmlContentType,
+			token:       thingKey,
+			body:        strings.NewReader(tc.msg),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status == http.StatusAccepted {
+			continue
+		}
+
+		var body apiutil.ErrorRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Contains(t, body.Err, messaging.ErrSchemaValidation.Error(), fmt.Sprintf("%s: expected schema validation error got %s", tc.desc, body.Err))
+		assert.Contains(t, body.Err, tc.err, fmt.Sprintf("%s: expected error describing %s got %s", tc.desc, tc.err, body.Err))
+	}
+}
+
+// schemaThingsClient returns the connections to the channel with the given profile.
+type schemaThingsClient struct {
+	mainflux.ThingsServiceClient
+	profile *mainflux.Profile
+}
+
+func (tc schemaThingsClient) GetConnByKey(ctx context.Context, in *mainflux.ConnByKeyReq, opts ...grpc.CallOption) (*mainflux.ConnByKeyRes, error) {
+	conn, err := tc.ThingsServiceClient.GetConnByKey(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	conn.Profile = tc.profile
+	return conn, nil
+}
@@ -18,5 +18,9 @@ func (req publishReq) validate() error {
 		return apiutil.ErrBearerToken
 	}
 
+	if req.msg.Channel == "" {
+		return apiutil.ErrMissingID
+	}
+
 	return nil
 }
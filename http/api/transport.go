@@ -16,6 +16,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
 	kitot "github.com/go-kit/kit/tracing/opentracing"
 	kithttp "github.com/go-kit/kit/transport/http"
 	"github.com/go-zoo/bone"
@@ -46,13 +47,6 @@ func MakeHandler(svc adapter.Service, tracer opentracing.Tracer, logger logger.L
 		opts...,
 	))
 
-	r.Post("/messages", kithttp.NewServer(
-		kitot.TraceServer(tracer, "publish")(sendMessageEndpoint(svc)),
-		decodeRequest,
-		encodeResponse,
-		opts...,
-	))
-
 	r.Post("/channels/:id/messages/*", kithttp.NewServer(
 		kitot.TraceServer(tracer, "publish")(sendMessageEndpoint(svc)),
 		decodeRequest,
@@ -60,13 +54,6 @@ func MakeHandler(svc adapter.Service, tracer opentracing.Tracer, logger logger.L
 		opts...,
 	))
 
-	r.Post("/messages/*", kithttp.NewServer(
-		kitot.TraceServer(tracer, "publish")(sendMessageEndpoint(svc)),
-		decodeRequest,
-		encodeResponse,
-		opts...,
-	))
-
 	r.GetFunc("/health", mainflux.Health("http"))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -107,6 +94,7 @@ func decodeRequest(ctx context.Context, r *http.Request) (interface{}, error) {
 	req := publishReq{
 		msg: messaging.Message{
 			Protocol: protocol,
+			Channel:  bone.GetValue(r, "id"),
 			Subtopic: subject,
 			Payload:  payload,
 			Created:  time.Now().UnixNano(),
@@ -132,8 +120,12 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, messaging.ErrMalformedSubtopic),
-		errors.Contains(err, apiutil.ErrMalformedEntity):
+		errors.Contains(err, messaging.ErrSchemaValidation),
+		errors.Contains(err, apiutil.ErrMalformedEntity),
+		err == apiutil.ErrMissingID:
 		w.WriteHeader(http.StatusBadRequest)
+	case errors.Contains(err, ratelimit.ErrLimitExceeded):
+		w.WriteHeader(http.StatusTooManyRequests)
 
 	default:
 		switch e, ok := status.FromError(err); {
@@ -154,8 +146,14 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	}
 
 	if errorVal, ok := err.(errors.Error); ok {
+		msg := errorVal.Msg()
+		// Schema validation errors describe the parts of the payload which
+		// don't match the channel schema.
+		if errors.Contains(err, messaging.ErrSchemaValidation) {
+			msg = err.Error()
+		}
 		w.Header().Set("Content-Type", ctJSON)
-		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: errorVal.Msg()}); err != nil {
+		if err := json.NewEncoder(w).Encode(apiutil.ErrorRes{Err: msg}); err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -87,6 +87,15 @@ var (
 	// ErrInvalidComparator indicates an invalid comparator.
 	ErrInvalidComparator = errors.New("invalid comparator")
 
+	// ErrInvalidAggregation indicates an invalid aggregation.
+	ErrInvalidAggregation = errors.New("invalid aggregation")
+
+	// ErrInvalidInterval indicates an invalid aggregation interval.
+	ErrInvalidInterval = errors.New("invalid aggregation interval")
+
+	// ErrInvalidExportType indicates an invalid export type.
+	ErrInvalidExportType = errors.New("invalid export type")
+
 	// ErrMissingMemberType indicates missing group member type.
 	ErrMissingMemberType = errors.New("missing group member type")
 
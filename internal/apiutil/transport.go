@@ -43,6 +43,9 @@ func LoggingErrorEncoder(logger logger.Logger, enc kithttp.ErrorEncoder) kithttp
 			errors.Contains(err, ErrMissingConfPass),
 			errors.Contains(err, ErrInvalidResetPass),
 			errors.Contains(err, ErrInvalidComparator),
+			errors.Contains(err, ErrInvalidAggregation),
+			errors.Contains(err, ErrInvalidInterval),
+			errors.Contains(err, ErrInvalidExportType),
 			errors.Contains(err, ErrMissingMemberType),
 			errors.Contains(err, ErrInvalidAPIKey),
 			errors.Contains(err, ErrMaxLevelExceeded),
@@ -15,6 +15,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 )
 
@@ -23,6 +24,7 @@ var _ session.Handler = (*handler)(nil)
 const (
 	protocol  = "mqtt"
 	connected = "connected"
+	wildcards = "+#"
 )
 
 const (
@@ -49,6 +51,7 @@ var (
 	ErrMissingClientID           = errors.New("client_id not found")
 	ErrMissingTopicPub           = errors.New("failed to publish due to missing topic")
 	ErrMissingTopicSub           = errors.New("failed to subscribe due to missing topic")
+	ErrWildcardTopicPub          = errors.New("failed to publish due to wildcard in topic")
 	ErrAuthentication            = errors.New("failed to perform authentication over the entity")
 	ErrSubscriptionAlreadyExists = errors.New("subscription already exists")
 )
@@ -60,17 +63,19 @@ type handler struct {
 	logger     logger.Logger
 	es         redis.EventStore
 	service    Service
+	limiter    ratelimit.Limiter
 }
 
 // NewHandler creates new Handler entity
 func NewHandler(publishers []messaging.Publisher, es redis.EventStore,
-	logger logger.Logger, auth auth.Client, svc Service) session.Handler {
+	logger logger.Logger, auth auth.Client, svc Service, limiter ratelimit.Limiter) session.Handler {
 	return &handler{
 		es:         es,
 		logger:     logger,
 		publishers: publishers,
 		auth:       auth,
 		service:    svc,
+		limiter:    limiter,
 	}
 }
 
@@ -111,10 +116,29 @@ func (h *handler) AuthPublish(c *session.Client, topic *string, payload *[]byte)
 		return ErrMissingTopicPub
 	}
 
-	if _, err := h.authAccess(c); err != nil {
+	// Wildcards are allowed in subscriptions only.
+	if strings.ContainsAny(*topic, wildcards) {
+		return ErrWildcardTopicPub
+	}
+
+	chanID, err := parseChannel(*topic)
+	if err != nil {
 		return err
 	}
 
+	conn, err := h.authAccess(c, chanID)
+	if err != nil {
+		return err
+	}
+
+	// Returning an error disconnects the client publishing the payload which
+	// doesn't match the channel schema, as MQTT can't reject the message.
+	if payload != nil {
+		if err := messaging.ValidatePayload(&conn, *payload); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -128,8 +152,17 @@ func (h *handler) AuthSubscribe(c *session.Client, topics *[]string) error {
 		return ErrMissingTopicSub
 	}
 
-	if _, err := h.authAccess(c); err != nil {
-		return err
+	// Every topic has to point to the channel the thing is connected to,
+	// so wildcards are accepted only in the subtopic part.
+	for _, topic := range *topics {
+		chanID, err := parseChannel(topic)
+		if err != nil {
+			return err
+		}
+
+		if _, err := h.authAccess(c, chanID); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -155,6 +188,12 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 
+	chanID, err := parseChannel(*topic)
+	if err != nil {
+		h.logger.Error(LogErrFailedPublish + (ErrMalformedTopic).Error())
+		return
+	}
+
 	subtopic, err := messaging.ExtractSubtopic(*topic)
 	if err != nil {
 		h.logger.Error(LogErrFailedPublish + (ErrMalformedTopic).Error())
@@ -167,9 +206,17 @@ func (h *handler) Publish(c *session.Client, topic *string, payload *[]byte) {
 		return
 	}
 
-	conn, err := h.auth.GetConnByKey(context.Background(), string(c.Password))
+	conn, err := h.authAccess(c, chanID)
 	if err != nil {
 		h.logger.Error(LogErrFailedPublish + (ErrAuthentication).Error())
+		return
+	}
+
+	// The messages exceeding the publish rate are dropped instead of
+	// disconnecting the client.
+	if err := h.limiter.Allow(context.Background(), conn.ThingID, conn.ChannelID, ratelimit.ConnOverrides(conn.Profile)); err != nil {
+		h.logger.Warn(LogErrFailedPublish + err.Error())
+		return
 	}
 
 	m := messaging.CreateMessage(&conn, protocol, subject, payload)
@@ -239,8 +286,8 @@ func (h *handler) Disconnect(c *session.Client) {
 	}
 }
 
-func (h *handler) authAccess(c *session.Client) (mainflux.ConnByKeyRes, error) {
-	conn, err := h.auth.GetConnByKey(context.Background(), string(c.Password))
+func (h *handler) authAccess(c *session.Client, chanID string) (mainflux.ConnByKeyRes, error) {
+	conn, err := h.auth.GetConnByKey(context.Background(), chanID, string(c.Password))
 	if err != nil {
 		return mainflux.ConnByKeyRes{}, err
 	}
@@ -249,19 +296,27 @@ func (h *handler) authAccess(c *session.Client) (mainflux.ConnByKeyRes, error) {
 		return mainflux.ConnByKeyRes{}, ErrAuthentication
 	}
 
+	if conn.ChannelID != chanID {
+		return mainflux.ConnByKeyRes{}, errors.ErrAuthorization
+	}
+
 	return conn, nil
 }
 
 func (h *handler) getSubcriptions(c *session.Client, topics *[]string) ([]Subscription, error) {
 	var subs []Subscription
 	for _, t := range *topics {
+		chanID, err := parseChannel(t)
+		if err != nil {
+			return nil, err
+		}
 
 		subtopic, err := messaging.ExtractSubtopic(t)
 		if err != nil {
 			return nil, err
 		}
 
-		conn, err := h.auth.GetConnByKey(context.Background(), string(c.Password))
+		conn, err := h.authAccess(c, chanID)
 		if err != nil {
 			return nil, err
 		}
@@ -284,3 +339,14 @@ func (h *handler) getSubcriptions(c *session.Client, topics *[]string) ([]Subscr
 
 	return subs, nil
 }
+
+// parseChannel extracts channel ID from the topic in the format
+// channels/<channel_id>/messages/<subtopic>/...
+func parseChannel(topic string) (string, error) {
+	chanID, err := messaging.ExtractChannel(topic)
+	if err != nil {
+		return "", ErrMalformedTopic
+	}
+
+	return chanID, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"strings"
 	"testing"
 
 	"github.com/MainfluxLabs/mainflux/logger"
@@ -12,6 +13,8 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	pubmocks "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	rlmocks "github.com/MainfluxLabs/mainflux/pkg/ratelimit/mocks"
 	"github.com/MainfluxLabs/mproxy/pkg/session"
 	"github.com/stretchr/testify/assert"
 )
@@ -19,19 +22,26 @@ import (
 const (
 	thingID               = "513d02d2-16c1-4f23-98be-9e12f8fee898"
 	chanID                = "123e4567-e89b-12d3-a456-000000000001"
+	otherChanID           = "123e4567-e89b-12d3-a456-000000000002"
+	foreignChanID         = "123e4567-e89b-12d3-a456-000000000003"
 	invalidID             = "invalidID"
 	clientID              = "clientID"
 	password              = "password"
 	subtopic              = "testSubtopic"
 	invalidChannelIDTopic = "channels/**/messages"
+	publishLimit          = 100
 )
 
 var (
-	topicMsg     = "channels/%s/messages"
-	topic        = fmt.Sprintf(topicMsg, chanID)
-	invalidTopic = "invalidTopic"
-	payload      = []byte("[{'n':'test-name', 'v': 1.2}]")
-	topics       = []string{topic}
+	topicMsg                = "channels/%s/messages"
+	topic                   = fmt.Sprintf(topicMsg, chanID)
+	otherTopic              = fmt.Sprintf(topicMsg, otherChanID)
+	foreignTopic            = fmt.Sprintf(topicMsg, foreignChanID)
+	singleWildcardChanTopic = fmt.Sprintf(topicMsg, "+")
+	multiWildcardSubtopic   = topic + "/#"
+	invalidTopic            = "invalidTopic"
+	payload                 = []byte("[{'n':'test-name', 'v': 1.2}]")
+	topics                  = []string{topic}
 	//Test log messages for cases the handler does not provide a return value.
 	logBuffer     = bytes.Buffer{}
 	sessionClient = session.Client{
@@ -97,6 +107,7 @@ func TestAuthConnect(t *testing.T) {
 
 func TestAuthPublish(t *testing.T) {
 	handler := newHandler()
+	invalidChanTopic := invalidChannelIDTopic
 
 	cases := []struct {
 		desc    string
@@ -119,6 +130,48 @@ func TestAuthPublish(t *testing.T) {
 			topic:   nil,
 			payload: payload,
 		},
+		{
+			desc:    "publish with invalid topic",
+			client:  &sessionClient,
+			err:     mqtt.ErrMalformedTopic,
+			topic:   &invalidTopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish with invalid channel ID",
+			client:  &sessionClient,
+			err:     mqtt.ErrMalformedTopic,
+			topic:   &invalidChanTopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish with single-level wildcard instead of channel ID",
+			client:  &sessionClient,
+			err:     mqtt.ErrWildcardTopicPub,
+			topic:   &singleWildcardChanTopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish with multi-level wildcard in subtopic",
+			client:  &sessionClient,
+			err:     mqtt.ErrWildcardTopicPub,
+			topic:   &multiWildcardSubtopic,
+			payload: payload,
+		},
+		{
+			desc:    "publish with invalid thing ID",
+			client:  &invalidThingSessionClient,
+			err:     mqtt.ErrAuthentication,
+			topic:   &topic,
+			payload: payload,
+		},
+		{
+			desc:    "publish to channel the thing is not connected to",
+			client:  &sessionClient,
+			err:     errors.ErrAuthorization,
+			topic:   &foreignTopic,
+			payload: payload,
+		},
 		{
 			desc:    "publish successfully",
 			client:  &sessionClient,
@@ -126,6 +179,13 @@ func TestAuthPublish(t *testing.T) {
 			topic:   &topic,
 			payload: payload,
 		},
+		{
+			desc:    "publish successfully to other connected channel",
+			client:  &sessionClient,
+			err:     nil,
+			topic:   &otherTopic,
+			payload: payload,
+		},
 	}
 
 	for _, tc := range cases {
@@ -161,12 +221,66 @@ func TestAuthSubscribe(t *testing.T) {
 			err:    mqtt.ErrAuthentication,
 			topic:  &topics,
 		},
+		{
+			desc:   "subscribe to channel the thing is not connected to",
+			client: &sessionClient,
+			err:    errors.ErrAuthorization,
+			topic:  &[]string{foreignTopic},
+		},
+		{
+			desc:   "subscribe to connected and not connected channels",
+			client: &sessionClient,
+			err:    errors.ErrAuthorization,
+			topic:  &[]string{topic, foreignTopic},
+		},
+		{
+			desc:   "subscribe to subtopics of channel the thing is not connected to",
+			client: &sessionClient,
+			err:    errors.ErrAuthorization,
+			topic:  &[]string{foreignTopic + "/#"},
+		},
+		{
+			desc:   "subscribe with single-level wildcard instead of channel ID",
+			client: &sessionClient,
+			err:    mqtt.ErrMalformedTopic,
+			topic:  &[]string{singleWildcardChanTopic},
+		},
+		{
+			desc:   "subscribe with multi-level wildcard instead of channel ID",
+			client: &sessionClient,
+			err:    mqtt.ErrMalformedTopic,
+			topic:  &[]string{"channels/#"},
+		},
+		{
+			desc:   "subscribe to all topics",
+			client: &sessionClient,
+			err:    mqtt.ErrMalformedTopic,
+			topic:  &[]string{"#"},
+		},
+		{
+			desc:   "subscribe with invalid topic",
+			client: &sessionClient,
+			err:    mqtt.ErrMalformedTopic,
+			topic:  &[]string{invalidTopic},
+		},
 		{
 			desc:   "subscribe with active session and valid topics",
 			client: &sessionClient,
 			err:    nil,
 			topic:  &topics,
 		},
+		{
+			desc:   "subscribe to multiple connected channels",
+			client: &sessionClient,
+			err:    nil,
+			topic:  &[]string{topic, otherTopic},
+		},
+		{
+			desc:   "subscribe with wildcards in subtopic",
+			client: &sessionClient,
+			err:    nil,
+			topic:  &[]string{topic + "/+/" + subtopic, multiWildcardSubtopic},
+		},
 	}
 
 	for _, tc := range cases {
@@ -266,6 +380,13 @@ func TestPublish(t *testing.T) {
 			payload: payload,
 			logMsg:  "",
 		},
+		{
+			desc:    "publish to channel the thing is not connected to",
+			client:  &sessionClient,
+			topic:   foreignTopic,
+			payload: payload,
+			logMsg:  mqtt.LogErrFailedPublish + mqtt.ErrAuthentication.Error(),
+		},
 	}
 
 	for _, tc := range cases {
@@ -274,6 +395,38 @@ func TestPublish(t *testing.T) {
 	}
 }
 
+func TestPublishRateLimit(t *testing.T) {
+	handler := newLimitedHandler(1)
+	logBuffer.Reset()
+
+	limitMsg := mqtt.LogErrFailedPublish + ratelimit.ErrLimitExceeded.Error()
+
+	cases := []struct {
+		desc    string
+		topic   string
+		limited bool
+	}{
+		{
+			desc:    "publish within limit",
+			topic:   topic,
+			limited: false,
+		},
+		{
+			desc:    "publish over thing limit",
+			topic:   otherTopic,
+			limited: true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := handler.AuthPublish(&sessionClient, &tc.topic, &payload)
+		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %s\n", tc.desc, err))
+
+		handler.Publish(&sessionClient, &tc.topic, &payload)
+		assert.Equal(t, tc.limited, strings.Contains(logBuffer.String(), limitMsg), fmt.Sprintf("%s: expected limited %t\n", tc.desc, tc.limited))
+	}
+}
+
 func TestSubscribe(t *testing.T) {
 	handler := newHandler()
 	logBuffer.Reset()
@@ -365,12 +518,16 @@ func TestDisconnect(t *testing.T) {
 }
 
 func newHandler() session.Handler {
+	return newLimitedHandler(publishLimit)
+}
+
+func newLimitedHandler(burst uint) session.Handler {
 	logger, err := logger.New(&logBuffer, "debug")
 	if err != nil {
 		log.Fatalf("failed to create logger: %s", err)
 	}
 
-	authClient := mocks.NewClient(map[string]string{password: thingID}, map[string]string{thingID: chanID})
+	authClient := mocks.NewClient(map[string]string{password: thingID}, map[string][]string{thingID: {chanID, otherChanID}})
 	eventStore := mocks.NewEventStore()
-	return mqtt.NewHandler([]messaging.Publisher{pubmocks.NewPublisher()}, eventStore, logger, authClient, newService())
+	return mqtt.NewHandler([]messaging.Publisher{pubmocks.NewPublisher()}, eventStore, logger, authClient, newService(), rlmocks.NewLimiter(burst))
 }
@@ -14,30 +14,33 @@ var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 
 type MockClient struct {
 	key   map[string]string
-	conns map[string]string
+	conns map[string][]string
 }
 
-func NewClient(key map[string]string, conns map[string]string) auth.Client {
+// NewClient creates mock of thing authentication client. Keys map thing keys
+// to thing IDs and conns map thing IDs to IDs of the connected channels.
+func NewClient(key map[string]string, conns map[string][]string) auth.Client {
 	return MockClient{key: key, conns: conns}
 }
 
-func (cli MockClient) GetConnByKey(ctx context.Context, key string) (mainflux.ConnByKeyRes, error) {
+func (cli MockClient) GetConnByKey(ctx context.Context, chanID, key string) (mainflux.ConnByKeyRes, error) {
 	thID, ok := cli.key[key]
 	if !ok {
 		return mainflux.ConnByKeyRes{}, errors.ErrAuthentication
 	}
 
-	chID, ok := cli.conns[thID]
-	if !ok {
-		return mainflux.ConnByKeyRes{}, errors.ErrAuthentication
-	}
+	for _, chID := range cli.conns[thID] {
+		if chID == chanID {
+			conn := &mainflux.ConnByKeyRes{
+				ThingID:   thID,
+				ChannelID: chID,
+			}
 
-	conn := &mainflux.ConnByKeyRes{
-		ThingID:   thID,
-		ChannelID: chID,
+			return *conn, nil
+		}
 	}
 
-	return *conn, nil
+	return mainflux.ConnByKeyRes{}, errors.ErrAuthorization
 }
 
 func (cli MockClient) Identify(ctx context.Context, thingKey string) (string, error) {
@@ -69,6 +72,10 @@ func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opt
 	return nil, errors.ErrAuthentication
 }
 
+func (svc authServiceMock) IdentifyVerification(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	panic("not implemented")
+}
+
 func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
 	if id, ok := svc.users[in.GetEmail()]; ok {
 		switch in.Type {
@@ -106,3 +113,15 @@ func (svc authServiceMock) AssignRole(ctx context.Context, req *mainflux.AssignR
 func (svc authServiceMock) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) JoinOrg(ctx context.Context, req *mainflux.JoinOrgReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RetrieveGroupOrg(ctx context.Context, req *mainflux.GroupOrgReq, _ ...grpc.CallOption) (r *mainflux.OrgID, err error) {
+	panic("not implemented")
+}
+
+func (svc authServiceMock) RetrieveMemberGroups(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (r *mainflux.MemberGroupsRes, err error) {
+	panic("not implemented")
+}
@@ -89,7 +89,7 @@ func (ms *mqttService) authorize(ctx context.Context, token, key, chanID string)
 		}
 		return nil
 	default:
-		if _, err := ms.things.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: key}); err != nil {
+		if _, err := ms.things.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: key, ChanID: chanID}); err != nil {
 			return err
 		}
 		return nil
@@ -13,7 +13,7 @@ import (
 // Client represents Auth cache.
 type Client interface {
 	Identify(ctx context.Context, thingKey string) (string, error)
-	GetConnByKey(ctx context.Context, thingKey string) (mainflux.ConnByKeyRes, error)
+	GetConnByKey(ctx context.Context, chanID, thingKey string) (mainflux.ConnByKeyRes, error)
 }
 
 const (
@@ -51,9 +51,10 @@ func (c client) Identify(ctx context.Context, thingKey string) (string, error) {
 	return thingID, nil
 }
 
-func (c client) GetConnByKey(ctx context.Context, thingKey string) (mainflux.ConnByKeyRes, error) {
+func (c client) GetConnByKey(ctx context.Context, chanID, thingKey string) (mainflux.ConnByKeyRes, error) {
 	req := &mainflux.ConnByKeyReq{
-		Key: thingKey,
+		Key:    thingKey,
+		ChanID: chanID,
 	}
 
 	conn, err := c.things.GetConnByKey(ctx, req)
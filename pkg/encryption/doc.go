@@ -0,0 +1,9 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package encryption provides envelope encryption of the stored messages.
+// The payload fields of the messages are encrypted with AES-256-GCM using the
+// data key of their channel, while the data keys are stored wrapped (encrypted)
+// by the master key. The master keys are never stored, so rotating the master
+// key only requires rewrapping the data keys, not re-encrypting the messages.
+package encryption
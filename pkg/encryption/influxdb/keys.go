@@ -0,0 +1,175 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package influxdb
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api/query"
+)
+
+const measurement = "data_keys"
+
+var (
+	_ encryption.KeyRepository = (*keyRepository)(nil)
+
+	errInvalidKey = errors.New("invalid data key representation")
+)
+
+type keyRepository struct {
+	client influxdb2.Client
+	org    string
+	bucket string
+}
+
+// New returns the data keys repository stored in the bucket of the
+// organization. The bucket, kept apart from the messages bucket so that the
+// keys outlive the messages retention, is created with infinite retention if
+// it doesn't exist.
+func New(ctx context.Context, client influxdb2.Client, org, bucket string) (encryption.KeyRepository, error) {
+	bucketsAPI := client.BucketsAPI()
+	if _, err := bucketsAPI.FindBucketByName(ctx, bucket); err != nil {
+		o, err := client.OrganizationsAPI().FindOrganizationByName(ctx, org)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := bucketsAPI.CreateBucketWithName(ctx, o, bucket); err != nil {
+			return nil, err
+		}
+	}
+
+	return &keyRepository{
+		client: client,
+		org:    org,
+		bucket: bucket,
+	}, nil
+}
+
+func (kr keyRepository) Save(ctx context.Context, key encryption.DataKey) error {
+	if err := kr.write(ctx, key); err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (kr keyRepository) RetrieveByID(ctx context.Context, chanID, id string) (encryption.DataKey, error) {
+	filter := fmt.Sprintf(`|> filter(fn: (r) => r.channel == %q and r.id == %q)`, chanID, id)
+
+	return kr.retrieveOne(ctx, filter)
+}
+
+func (kr keyRepository) RetrieveLatest(ctx context.Context, chanID string) (encryption.DataKey, error) {
+	filter := fmt.Sprintf(`|> filter(fn: (r) => r.channel == %q)`, chanID)
+
+	return kr.retrieveOne(ctx, filter+`|> sort(columns: ["_time"], desc: true)|> limit(n: 1)`)
+}
+
+func (kr keyRepository) RetrieveAll(ctx context.Context) ([]encryption.DataKey, error) {
+	keys, err := kr.retrieve(ctx, `|> sort(columns: ["_time"])`)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return keys, nil
+}
+
+// Update overwrites the fields of the data key, since the point of the same
+// measurement, tags and time replaces the existing one.
+func (kr keyRepository) Update(ctx context.Context, key encryption.DataKey) error {
+	stored, err := kr.RetrieveByID(ctx, key.ChannelID, key.ID)
+	if err != nil {
+		return err
+	}
+
+	stored.MasterKeyID = key.MasterKeyID
+	stored.WrappedKey = key.WrappedKey
+	if err := kr.write(ctx, stored); err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (kr keyRepository) write(ctx context.Context, key encryption.DataKey) error {
+	tags := map[string]string{
+		"channel": key.ChannelID,
+		"id":      key.ID,
+	}
+	fields := map[string]interface{}{
+		"master_key_id": key.MasterKeyID,
+		"wrapped_key":   base64.StdEncoding.EncodeToString(key.WrappedKey),
+	}
+	pt := influxdb2.NewPoint(measurement, tags, fields, key.CreatedAt)
+
+	return kr.client.WriteAPIBlocking(kr.org, kr.bucket).WritePoint(ctx, pt)
+}
+
+func (kr keyRepository) retrieveOne(ctx context.Context, filter string) (encryption.DataKey, error) {
+	keys, err := kr.retrieve(ctx, filter)
+	if err != nil {
+		return encryption.DataKey{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	if len(keys) == 0 {
+		return encryption.DataKey{}, errors.ErrNotFound
+	}
+
+	return keys[0], nil
+}
+
+func (kr keyRepository) retrieve(ctx context.Context, filter string) ([]encryption.DataKey, error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`from(bucket: %q)`, kr.bucket))
+	sb.WriteString(`|> range(start: 0)`)
+	sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r._measurement == %q)`, measurement))
+	sb.WriteString(`|> pivot(rowKey: ["_time"], columnKey: ["_field"], valueColumn: "_value")`)
+	sb.WriteString(`|> group()`)
+	sb.WriteString(filter)
+
+	resp, err := kr.client.QueryAPI(kr.org).Query(ctx, sb.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []encryption.DataKey
+	for resp.Next() {
+		key, err := toKey(resp.Record())
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if resp.Err() != nil {
+		return nil, resp.Err()
+	}
+
+	return keys, nil
+}
+
+func toKey(r *query.FluxRecord) (encryption.DataKey, error) {
+	vals := r.Values()
+	chanID, _ := vals["channel"].(string)
+	id, _ := vals["id"].(string)
+	mkID, _ := vals["master_key_id"].(string)
+	wrapped, _ := vals["wrapped_key"].(string)
+
+	wk, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil || id == "" || chanID == "" {
+		return encryption.DataKey{}, errInvalidKey
+	}
+
+	return encryption.DataKey{
+		ID:          id,
+		ChannelID:   chanID,
+		MasterKeyID: mkID,
+		WrappedKey:  wk,
+		CreatedAt:   r.Time(),
+	}, nil
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+// MasterKeySize is the size of the master key in bytes.
+const MasterKeySize = 32
+
+// ErrInvalidMasterKey indicates malformed master key.
+var ErrInvalidMasterKey = errors.New("invalid master key")
+
+// MasterKey represents the key used to wrap the data keys.
+type MasterKey struct {
+	ID  string
+	Key []byte
+}
+
+// Keyring holds the current master key, used to wrap the new data keys, and
+// the previous master keys, still used to unwrap the data keys which are not
+// rewrapped yet.
+type Keyring struct {
+	current MasterKey
+	keys    map[string]MasterKey
+}
+
+// NewKeyring returns the keyring with the current and the previous master keys.
+func NewKeyring(current MasterKey, previous ...MasterKey) (Keyring, error) {
+	kr := Keyring{
+		current: current,
+		keys:    make(map[string]MasterKey),
+	}
+
+	for _, mk := range append([]MasterKey{current}, previous...) {
+		if mk.ID == "" || len(mk.Key) != MasterKeySize {
+			return Keyring{}, ErrInvalidMasterKey
+		}
+		if _, ok := kr.keys[mk.ID]; ok {
+			return Keyring{}, errors.Wrap(ErrInvalidMasterKey, errors.New("duplicate master key ID "+mk.ID))
+		}
+		kr.keys[mk.ID] = mk
+	}
+
+	return kr, nil
+}
+
+// ParseKeyring returns the keyring from the current master key and the comma
+// separated list of the previous master keys, each formatted as "<id>:<base64 key>".
+func ParseKeyring(current, previous string) (Keyring, error) {
+	cur, err := ParseMasterKey(current)
+	if err != nil {
+		return Keyring{}, err
+	}
+
+	var prev []MasterKey
+	for _, s := range strings.Split(previous, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		mk, err := ParseMasterKey(s)
+		if err != nil {
+			return Keyring{}, err
+		}
+		prev = append(prev, mk)
+	}
+
+	return NewKeyring(cur, prev...)
+}
+
+// ParseMasterKey returns the master key formatted as "<id>:<base64 key>".
+func ParseMasterKey(s string) (MasterKey, error) {
+	id, enc, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || id == "" {
+		return MasterKey{}, ErrInvalidMasterKey
+	}
+
+	key, err := base64.StdEncoding.DecodeString(enc)
+	if err != nil {
+		return MasterKey{}, errors.Wrap(ErrInvalidMasterKey, err)
+	}
+	if len(key) != MasterKeySize {
+		return MasterKey{}, ErrInvalidMasterKey
+	}
+
+	return MasterKey{ID: id, Key: key}, nil
+}
+
+// Current returns the current master key.
+func (kr Keyring) Current() MasterKey {
+	return kr.current
+}
+
+// Key returns the master key by its ID.
+func (kr Keyring) Key(id string) (MasterKey, bool) {
+	mk, ok := kr.keys[id]
+	return mk, ok
+}
+
+// Wrap encrypts the secret with the current master key. The secret is bound
+// to the associated data, which has to match when the secret is unwrapped.
+// It returns the ID of the master key along with the wrapped secret.
+func (kr Keyring) Wrap(secret, aad []byte) (string, []byte, error) {
+	nonce, ciphertext, err := seal(kr.current.Key, secret, aad)
+	if err != nil {
+		return "", nil, err
+	}
+	return kr.current.ID, append(nonce, ciphertext...), nil
+}
+
+// Unwrap decrypts the secret wrapped by the master key with the given ID.
+func (kr Keyring) Unwrap(masterKeyID string, wrapped, aad []byte) ([]byte, error) {
+	mk, ok := kr.Key(masterKeyID)
+	if !ok {
+		return nil, ErrUnknownMasterKey
+	}
+	return open(mk.Key, wrapped, aad)
+}
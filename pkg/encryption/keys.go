@@ -0,0 +1,37 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"time"
+)
+
+// DataKey represents the key used to encrypt the messages of the channel.
+// The key is kept wrapped by the master key identified by the MasterKeyID.
+type DataKey struct {
+	ID          string
+	ChannelID   string
+	MasterKeyID string
+	WrappedKey  []byte
+	CreatedAt   time.Time
+}
+
+// KeyRepository specifies the data keys persistence API.
+type KeyRepository interface {
+	// Save persists the data key.
+	Save(ctx context.Context, key DataKey) error
+
+	// RetrieveByID retrieves the data key of the channel by its ID.
+	RetrieveByID(ctx context.Context, chanID, id string) (DataKey, error)
+
+	// RetrieveLatest retrieves the most recently created data key of the channel.
+	RetrieveLatest(ctx context.Context, chanID string) (DataKey, error)
+
+	// RetrieveAll retrieves the data keys of all the channels.
+	RetrieveAll(ctx context.Context) ([]DataKey, error)
+
+	// Update updates the master key ID and the wrapped key of the data key.
+	Update(ctx context.Context, key DataKey) error
+}
@@ -0,0 +1,146 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+const (
+	// EnvelopePrefix prefixes the base64 encoded envelopes stored in place
+	// of the encrypted fields.
+	EnvelopePrefix = "mfenc:"
+
+	// PayloadKey is the key of the envelope in the encrypted JSON payload.
+	PayloadKey = "envelope"
+)
+
+type senmlValues struct {
+	Value       *float64 `json:"v,omitempty"`
+	StringValue *string  `json:"vs,omitempty"`
+	DataValue   *string  `json:"vd,omitempty"`
+}
+
+// EncryptSenML encrypts the value, the string value and the data value of the
+// message. The encrypted values are stored in the data value, while the value
+// and the string value are cleared. The bool value and the sum are kept as
+// they are.
+func EncryptSenML(ctx context.Context, svc Service, msg senml.Message) (senml.Message, error) {
+	if msg.Value == nil && msg.StringValue == nil && msg.DataValue == nil {
+		return msg, nil
+	}
+
+	vals := senmlValues{
+		Value:       msg.Value,
+		StringValue: msg.StringValue,
+		DataValue:   msg.DataValue,
+	}
+	data, err := json.Marshal(vals)
+	if err != nil {
+		return senml.Message{}, errors.Wrap(ErrEncrypt, err)
+	}
+
+	env, err := encode(ctx, svc, msg.Channel, data)
+	if err != nil {
+		return senml.Message{}, err
+	}
+
+	msg.Value = nil
+	msg.StringValue = nil
+	msg.DataValue = &env
+
+	return msg, nil
+}
+
+// DecryptSenML restores the values of the message encrypted by EncryptSenML.
+// The messages which are not encrypted are returned as they are.
+func DecryptSenML(ctx context.Context, svc Service, msg senml.Message) (senml.Message, error) {
+	if msg.DataValue == nil || !strings.HasPrefix(*msg.DataValue, EnvelopePrefix) {
+		return msg, nil
+	}
+
+	data, err := decode(ctx, svc, msg.Channel, *msg.DataValue)
+	if err != nil {
+		return senml.Message{}, err
+	}
+
+	var vals senmlValues
+	if err := json.Unmarshal(data, &vals); err != nil {
+		return senml.Message{}, errors.Wrap(ErrDecrypt, err)
+	}
+
+	msg.Value = vals.Value
+	msg.StringValue = vals.StringValue
+	msg.DataValue = vals.DataValue
+
+	return msg, nil
+}
+
+// EncryptPayload encrypts the JSON payload of the channel message. The
+// encrypted payload holds only the envelope under the PayloadKey.
+func EncryptPayload(ctx context.Context, svc Service, chanID string, payload map[string]interface{}) (map[string]interface{}, error) {
+	if len(payload) == 0 {
+		return payload, nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(ErrEncrypt, err)
+	}
+
+	env, err := encode(ctx, svc, chanID, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{PayloadKey: env}, nil
+}
+
+// DecryptPayload restores the JSON payload encrypted by EncryptPayload. The
+// payloads which are not encrypted are returned as they are.
+func DecryptPayload(ctx context.Context, svc Service, chanID string, payload map[string]interface{}) (map[string]interface{}, error) {
+	if len(payload) != 1 {
+		return payload, nil
+	}
+	env, ok := payload[PayloadKey].(string)
+	if !ok || !strings.HasPrefix(env, EnvelopePrefix) {
+		return payload, nil
+	}
+
+	data, err := decode(ctx, svc, chanID, env)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret map[string]interface{}
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, errors.Wrap(ErrDecrypt, err)
+	}
+
+	return ret, nil
+}
+
+func encode(ctx context.Context, svc Service, chanID string, data []byte) (string, error) {
+	env, err := svc.Encrypt(ctx, chanID, data)
+	if err != nil {
+		return "", err
+	}
+
+	return EnvelopePrefix + base64.StdEncoding.EncodeToString(env), nil
+}
+
+func decode(ctx context.Context, svc Service, chanID, s string) ([]byte, error) {
+	env, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s, EnvelopePrefix))
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformedEnvelope, err)
+	}
+
+	return svc.Decrypt(ctx, chanID, env)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptSenML(t *testing.T) {
+	svc := newService(t, mocks.NewKeyRepository(), masterKey)
+
+	v := 17.5
+	vs := "value"
+	vd := "base64"
+	vb := true
+
+	cases := []struct {
+		desc      string
+		msg       senml.Message
+		encrypted bool
+	}{
+		{
+			desc:      "encrypt message with value",
+			msg:       senml.Message{Channel: chanID, Name: "temperature", Value: &v},
+			encrypted: true,
+		},
+		{
+			desc:      "encrypt message with string and data values",
+			msg:       senml.Message{Channel: chanID, Name: "status", StringValue: &vs, DataValue: &vd},
+			encrypted: true,
+		},
+		{
+			desc:      "encrypt message with bool value",
+			msg:       senml.Message{Channel: chanID, Name: "on", BoolValue: &vb},
+			encrypted: false,
+		},
+	}
+
+	for _, tc := range cases {
+		enc, err := encryption.EncryptSenML(context.Background(), svc, tc.msg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Nil(t, enc.Value, fmt.Sprintf("%s: expected no plaintext value", tc.desc))
+		assert.Nil(t, enc.StringValue, fmt.Sprintf("%s: expected no plaintext string value", tc.desc))
+		assert.Equal(t, tc.msg.BoolValue, enc.BoolValue, fmt.Sprintf("%s: expected bool value %v got %v", tc.desc, tc.msg.BoolValue, enc.BoolValue))
+		if tc.encrypted {
+			require.NotNil(t, enc.DataValue, fmt.Sprintf("%s: expected encrypted data value", tc.desc))
+			assert.True(t, strings.HasPrefix(*enc.DataValue, encryption.EnvelopePrefix), fmt.Sprintf("%s: expected envelope got %s", tc.desc, *enc.DataValue))
+		}
+
+		dec, err := encryption.DecryptSenML(context.Background(), svc, enc)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.msg, dec, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.msg, dec))
+	}
+}
+
+func TestEncryptPayload(t *testing.T) {
+	svc := newService(t, mocks.NewKeyRepository(), masterKey)
+
+	cases := []struct {
+		desc    string
+		payload map[string]interface{}
+	}{
+		{
+			desc: "encrypt payload",
+			payload: map[string]interface{}{
+				"temperature": 17.5,
+				"status":      "ok",
+				"location":    map[string]interface{}{"lat": 44.8, "lon": 20.4},
+			},
+		},
+		{
+			desc:    "encrypt empty payload",
+			payload: map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		enc, err := encryption.EncryptPayload(context.Background(), svc, chanID, tc.payload)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		if len(tc.payload) > 0 {
+			assert.Len(t, enc, 1, fmt.Sprintf("%s: expected only the envelope got %v", tc.desc, enc))
+			assert.Contains(t, enc, encryption.PayloadKey, fmt.Sprintf("%s: expected envelope got %v", tc.desc, enc))
+		}
+
+		dec, err := encryption.DecryptPayload(context.Background(), svc, chanID, enc)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.payload, dec, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.payload, dec))
+	}
+
+	// The plaintext payloads, stored before the encryption was enabled, are
+	// returned as they are.
+	plain := map[string]interface{}{encryption.PayloadKey: "plaintext"}
+	dec, err := encryption.DecryptPayload(context.Background(), svc, chanID, plain)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, plain, dec, fmt.Sprintf("expected %v got %v", plain, dec))
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+var _ encryption.KeyRepository = (*keyRepositoryMock)(nil)
+
+type keyRepositoryMock struct {
+	mu   sync.Mutex
+	keys []encryption.DataKey
+}
+
+// NewKeyRepository returns mock data keys repository.
+func NewKeyRepository() encryption.KeyRepository {
+	return &keyRepositoryMock{}
+}
+
+func (krm *keyRepositoryMock) Save(_ context.Context, key encryption.DataKey) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for _, k := range krm.keys {
+		if k.ID == key.ID {
+			return errors.ErrConflict
+		}
+	}
+	krm.keys = append(krm.keys, key)
+
+	return nil
+}
+
+func (krm *keyRepositoryMock) RetrieveByID(_ context.Context, chanID, id string) (encryption.DataKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for _, k := range krm.keys {
+		if k.ChannelID == chanID && k.ID == id {
+			return k, nil
+		}
+	}
+
+	return encryption.DataKey{}, errors.ErrNotFound
+}
+
+func (krm *keyRepositoryMock) RetrieveLatest(_ context.Context, chanID string) (encryption.DataKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	// The keys are kept in the order they were saved in.
+	for i := len(krm.keys) - 1; i >= 0; i-- {
+		if krm.keys[i].ChannelID == chanID {
+			return krm.keys[i], nil
+		}
+	}
+
+	return encryption.DataKey{}, errors.ErrNotFound
+}
+
+func (krm *keyRepositoryMock) RetrieveAll(_ context.Context) ([]encryption.DataKey, error) {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	keys := append([]encryption.DataKey{}, krm.keys...)
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].CreatedAt.Before(keys[j].CreatedAt)
+	})
+
+	return keys, nil
+}
+
+func (krm *keyRepositoryMock) Update(_ context.Context, key encryption.DataKey) error {
+	krm.mu.Lock()
+	defer krm.mu.Unlock()
+
+	for i, k := range krm.keys {
+		if k.ID == key.ID {
+			krm.keys[i].MasterKeyID = key.MasterKeyID
+			krm.keys[i].WrappedKey = key.WrappedKey
+			return nil
+		}
+	}
+
+	return errors.ErrNotFound
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const collection = "data_keys"
+
+var _ encryption.KeyRepository = (*keyRepository)(nil)
+
+type keyRepository struct {
+	db *mongo.Database
+}
+
+// New returns the data keys repository stored in the data_keys collection.
+func New(db *mongo.Database) encryption.KeyRepository {
+	return &keyRepository{db: db}
+}
+
+func (kr keyRepository) Save(ctx context.Context, key encryption.DataKey) error {
+	if _, err := kr.db.Collection(collection).InsertOne(ctx, toDBKey(key)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (kr keyRepository) RetrieveByID(ctx context.Context, chanID, id string) (encryption.DataKey, error) {
+	filter := bson.D{{Key: "_id", Value: id}, {Key: "channel", Value: chanID}}
+
+	return kr.retrieve(ctx, filter, options.FindOne())
+}
+
+func (kr keyRepository) RetrieveLatest(ctx context.Context, chanID string) (encryption.DataKey, error) {
+	filter := bson.D{{Key: "channel", Value: chanID}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	return kr.retrieve(ctx, filter, opts)
+}
+
+func (kr keyRepository) RetrieveAll(ctx context.Context) ([]encryption.DataKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := kr.db.Collection(collection).Find(ctx, bson.D{}, opts)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer cursor.Close(ctx)
+
+	var keys []encryption.DataKey
+	for cursor.Next(ctx) {
+		var dbk dbKey
+		if err := cursor.Decode(&dbk); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		keys = append(keys, toKey(dbk))
+	}
+
+	return keys, nil
+}
+
+func (kr keyRepository) Update(ctx context.Context, key encryption.DataKey) error {
+	filter := bson.D{{Key: "_id", Value: key.ID}}
+	update := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "master_key_id", Value: key.MasterKeyID},
+		{Key: "wrapped_key", Value: key.WrappedKey},
+	}}}
+
+	res, err := kr.db.Collection(collection).UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if res.MatchedCount != 1 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (kr keyRepository) retrieve(ctx context.Context, filter bson.D, opts *options.FindOneOptions) (encryption.DataKey, error) {
+	var dbk dbKey
+	if err := kr.db.Collection(collection).FindOne(ctx, filter, opts).Decode(&dbk); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return encryption.DataKey{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return encryption.DataKey{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toKey(dbk), nil
+}
+
+type dbKey struct {
+	ID          string    `bson:"_id"`
+	ChannelID   string    `bson:"channel"`
+	MasterKeyID string    `bson:"master_key_id"`
+	WrappedKey  []byte    `bson:"wrapped_key"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
+
+func toDBKey(key encryption.DataKey) dbKey {
+	return dbKey{
+		ID:          key.ID,
+		ChannelID:   key.ChannelID,
+		MasterKeyID: key.MasterKeyID,
+		WrappedKey:  key.WrappedKey,
+		CreatedAt:   key.CreatedAt,
+	}
+}
+
+func toKey(dbk dbKey) encryption.DataKey {
+	return encryption.DataKey{
+		ID:          dbk.ID,
+		ChannelID:   dbk.ChannelID,
+		MasterKeyID: dbk.MasterKeyID,
+		WrappedKey:  dbk.WrappedKey,
+		CreatedAt:   dbk.CreatedAt,
+	}
+}
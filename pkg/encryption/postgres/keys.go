@@ -0,0 +1,138 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ encryption.KeyRepository = (*keyRepository)(nil)
+
+type keyRepository struct {
+	db *sqlx.DB
+}
+
+// New returns the data keys repository stored in the data_keys table,
+// created by the migrations of the Postgres and the Timescale writers and
+// readers.
+func New(db *sqlx.DB) encryption.KeyRepository {
+	return &keyRepository{db: db}
+}
+
+func (kr keyRepository) Save(ctx context.Context, key encryption.DataKey) error {
+	q := `INSERT INTO data_keys (id, channel, master_key_id, wrapped_key, created_at)
+          VALUES (:id, :channel, :master_key_id, :wrapped_key, :created_at)`
+
+	if _, err := kr.db.NamedExecContext(ctx, q, toDBKey(key)); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.UniqueViolation {
+			return errors.Wrap(errors.ErrConflict, err)
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (kr keyRepository) RetrieveByID(ctx context.Context, chanID, id string) (encryption.DataKey, error) {
+	q := `SELECT id, channel, master_key_id, wrapped_key, created_at FROM data_keys WHERE channel = $1 AND id = $2`
+
+	return kr.retrieve(ctx, q, chanID, id)
+}
+
+func (kr keyRepository) RetrieveLatest(ctx context.Context, chanID string) (encryption.DataKey, error) {
+	q := `SELECT id, channel, master_key_id, wrapped_key, created_at FROM data_keys WHERE channel = $1
+          ORDER BY created_at DESC LIMIT 1`
+
+	return kr.retrieve(ctx, q, chanID)
+}
+
+func (kr keyRepository) RetrieveAll(ctx context.Context) ([]encryption.DataKey, error) {
+	q := `SELECT id, channel, master_key_id, wrapped_key, created_at FROM data_keys ORDER BY created_at`
+
+	rows, err := kr.db.QueryxContext(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	var keys []encryption.DataKey
+	for rows.Next() {
+		var dbk dbKey
+		if err := rows.StructScan(&dbk); err != nil {
+			return nil, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+		keys = append(keys, toKey(dbk))
+	}
+
+	return keys, nil
+}
+
+func (kr keyRepository) Update(ctx context.Context, key encryption.DataKey) error {
+	q := `UPDATE data_keys SET master_key_id = :master_key_id, wrapped_key = :wrapped_key WHERE id = :id`
+
+	res, err := kr.db.NamedExecContext(ctx, q, toDBKey(key))
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if cnt != 1 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (kr keyRepository) retrieve(ctx context.Context, q string, args ...interface{}) (encryption.DataKey, error) {
+	var dbk dbKey
+	if err := kr.db.QueryRowxContext(ctx, q, args...).StructScan(&dbk); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if err == sql.ErrNoRows || ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return encryption.DataKey{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return encryption.DataKey{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toKey(dbk), nil
+}
+
+type dbKey struct {
+	ID          string    `db:"id"`
+	ChannelID   string    `db:"channel"`
+	MasterKeyID string    `db:"master_key_id"`
+	WrappedKey  []byte    `db:"wrapped_key"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func toDBKey(key encryption.DataKey) dbKey {
+	return dbKey{
+		ID:          key.ID,
+		ChannelID:   key.ChannelID,
+		MasterKeyID: key.MasterKeyID,
+		WrappedKey:  key.WrappedKey,
+		CreatedAt:   key.CreatedAt,
+	}
+}
+
+func toKey(dbk dbKey) encryption.DataKey {
+	return encryption.DataKey{
+		ID:          dbk.ID,
+		ChannelID:   dbk.ChannelID,
+		MasterKeyID: dbk.MasterKeyID,
+		WrappedKey:  dbk.WrappedKey,
+		CreatedAt:   dbk.CreatedAt,
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	dataKeySize     = 32
+	envelopeVersion = 1
+	// The data keys are cached for a limited time, so the data keys rotated
+	// by other replicas are picked up, and the unwrapped data keys which are
+	// no longer used don't stay in memory.
+	keyTTL = time.Minute
+)
+
+var (
+	// ErrEncrypt indicates failure to encrypt the message.
+	ErrEncrypt = errors.New("failed to encrypt message")
+
+	// ErrDecrypt indicates failure to decrypt the message.
+	ErrDecrypt = errors.New("failed to decrypt message")
+
+	// ErrMalformedEnvelope indicates the encrypted message can't be parsed.
+	ErrMalformedEnvelope = errors.New("malformed envelope")
+
+	// ErrUnknownMasterKey indicates the data key is wrapped by the master key
+	// which is not in the keyring.
+	ErrUnknownMasterKey = errors.New("unknown master key")
+
+	// ErrUnknownDataKey indicates the envelope refers to the data key which
+	// doesn't exist.
+	ErrUnknownDataKey = errors.New("unknown data key")
+
+	// ErrRewrap indicates failure to rewrap the data keys.
+	ErrRewrap = errors.New("failed to rewrap data keys")
+)
+
+// Service specifies the envelope encryption API.
+type Service interface {
+	// Encrypt encrypts the plaintext with the current data key of the
+	// channel, creating the data key if the channel has none.
+	Encrypt(ctx context.Context, chanID string, plaintext []byte) ([]byte, error)
+
+	// Decrypt decrypts the envelope created by Encrypt for the same channel.
+	Decrypt(ctx context.Context, chanID string, envelope []byte) ([]byte, error)
+
+	// RotateDataKey creates the new data key of the channel, used to encrypt
+	// the messages from now on. The messages encrypted with the previous data
+	// keys remain readable.
+	RotateDataKey(ctx context.Context, chanID string) (DataKey, error)
+
+	// RewrapDataKeys wraps the data keys wrapped by the previous master keys
+	// with the current master key, and returns the number of rewrapped keys.
+	RewrapDataKeys(ctx context.Context) (uint64, error)
+
+	// Encrypted reports whether the channel has a data key, i.e. whether
+	// its messages are encrypted.
+	Encrypted(ctx context.Context, chanID string) (bool, error)
+}
+
+type dataKey struct {
+	id      string
+	key     []byte
+	expires time.Time
+}
+
+type service struct {
+	keys    KeyRepository
+	keyring Keyring
+	idp     mainflux.IDProvider
+
+	mu sync.RWMutex
+	// latest holds the current data key per channel, while plain holds the
+	// unwrapped data keys by their IDs.
+	latest map[string]dataKey
+	plain  map[string]dataKey
+}
+
+var _ Service = (*service)(nil)
+
+// New instantiates the envelope encryption service.
+func New(keys KeyRepository, keyring Keyring, idp mainflux.IDProvider) Service {
+	return &service{
+		keys:    keys,
+		keyring: keyring,
+		idp:     idp,
+		latest:  make(map[string]dataKey),
+		plain:   make(map[string]dataKey),
+	}
+}
+
+func (svc *service) Encrypt(ctx context.Context, chanID string, plaintext []byte) ([]byte, error) {
+	dk, err := svc.latestKey(ctx, chanID)
+	if err != nil {
+		return nil, errors.Wrap(ErrEncrypt, err)
+	}
+
+	nonce, ciphertext, err := seal(dk.key, plaintext, []byte(chanID))
+	if err != nil {
+		return nil, errors.Wrap(ErrEncrypt, err)
+	}
+
+	// The envelope holds the format version, the length prefixed data key
+	// ID, the nonce and the ciphertext.
+	envelope := make([]byte, 0, 2+len(dk.id)+len(nonce)+len(ciphertext))
+	envelope = append(envelope, envelopeVersion, byte(len(dk.id)))
+	envelope = append(envelope, dk.id...)
+	envelope = append(envelope, nonce...)
+	envelope = append(envelope, ciphertext...)
+
+	return envelope, nil
+}
+
+func (svc *service) Decrypt(ctx context.Context, chanID string, envelope []byte) ([]byte, error) {
+	if len(envelope) < 2 || envelope[0] != envelopeVersion {
+		return nil, ErrMalformedEnvelope
+	}
+	idLen := int(envelope[1])
+	if len(envelope) < 2+idLen {
+		return nil, ErrMalformedEnvelope
+	}
+	id := string(envelope[2 : 2+idLen])
+
+	key, err := svc.keyByID(ctx, chanID, id)
+	if err != nil {
+		return nil, errors.Wrap(ErrDecrypt, err)
+	}
+
+	plaintext, err := open(key, envelope[2+idLen:], []byte(chanID))
+	if err != nil {
+		return nil, errors.Wrap(ErrDecrypt, err)
+	}
+
+	return plaintext, nil
+}
+
+func (svc *service) RotateDataKey(ctx context.Context, chanID string) (DataKey, error) {
+	key, dk, err := svc.createKey(ctx, chanID)
+	if err != nil {
+		return DataKey{}, err
+	}
+
+	svc.mu.Lock()
+	svc.cache(chanID, dataKey{id: dk.ID, key: key, expires: time.Now().Add(keyTTL)})
+	svc.mu.Unlock()
+
+	return dk, nil
+}
+
+func (svc *service) RewrapDataKeys(ctx context.Context) (uint64, error) {
+	dks, err := svc.keys.RetrieveAll(ctx)
+	if err != nil {
+		return 0, errors.Wrap(ErrRewrap, err)
+	}
+
+	current := svc.keyring.Current()
+	var n uint64
+	for _, dk := range dks {
+		if dk.MasterKeyID == current.ID {
+			continue
+		}
+
+		key, err := svc.unwrap(dk)
+		if err != nil {
+			return n, errors.Wrap(ErrRewrap, err)
+		}
+
+		wrapped, err := wrap(current, dk.ChannelID, dk.ID, key)
+		if err != nil {
+			return n, errors.Wrap(ErrRewrap, err)
+		}
+
+		dk.MasterKeyID = current.ID
+		dk.WrappedKey = wrapped
+		if err := svc.keys.Update(ctx, dk); err != nil {
+			return n, errors.Wrap(ErrRewrap, err)
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+func (svc *service) Encrypted(ctx context.Context, chanID string) (bool, error) {
+	svc.mu.RLock()
+	_, ok := svc.latest[chanID]
+	svc.mu.RUnlock()
+	if ok {
+		return true, nil
+	}
+
+	_, err := svc.keys.RetrieveLatest(ctx, chanID)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Contains(err, errors.ErrNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// latestKey returns the current data key of the channel, creating the data
+// key if the channel has none.
+func (svc *service) latestKey(ctx context.Context, chanID string) (dataKey, error) {
+	svc.mu.RLock()
+	dk, ok := svc.latest[chanID]
+	svc.mu.RUnlock()
+	if ok && time.Now().Before(dk.expires) {
+		return dk, nil
+	}
+
+	var key []byte
+	stored, err := svc.keys.RetrieveLatest(ctx, chanID)
+	switch {
+	case err == nil:
+		if key, err = svc.unwrap(stored); err != nil {
+			return dataKey{}, err
+		}
+	case errors.Contains(err, errors.ErrNotFound):
+		if key, stored, err = svc.createKey(ctx, chanID); err != nil {
+			return dataKey{}, err
+		}
+	default:
+		return dataKey{}, err
+	}
+
+	dk = dataKey{id: stored.ID, key: key, expires: time.Now().Add(keyTTL)}
+	svc.mu.Lock()
+	svc.cache(chanID, dk)
+	svc.mu.Unlock()
+
+	return dk, nil
+}
+
+// keyByID returns the unwrapped data key of the channel by its ID.
+func (svc *service) keyByID(ctx context.Context, chanID, id string) ([]byte, error) {
+	svc.mu.RLock()
+	cached, ok := svc.plain[id]
+	svc.mu.RUnlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.key, nil
+	}
+
+	dk, err := svc.keys.RetrieveByID(ctx, chanID, id)
+	if err != nil {
+		if errors.Contains(err, errors.ErrNotFound) {
+			return nil, ErrUnknownDataKey
+		}
+		return nil, err
+	}
+	key, err := svc.unwrap(dk)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.mu.Lock()
+	svc.cache("", dataKey{id: id, key: key, expires: time.Now().Add(keyTTL)})
+	svc.mu.Unlock()
+
+	return key, nil
+}
+
+// cache caches the unwrapped data key, as the current data key of the
+// channel if the channel is given, and removes the expired data keys. It must
+// be called with mu held.
+func (svc *service) cache(chanID string, dk dataKey) {
+	now := time.Now()
+	for id, k := range svc.plain {
+		if !now.Before(k.expires) {
+			delete(svc.plain, id)
+		}
+	}
+	for ch, k := range svc.latest {
+		if !now.Before(k.expires) {
+			delete(svc.latest, ch)
+		}
+	}
+
+	svc.plain[dk.id] = dk
+	if chanID != "" {
+		svc.latest[chanID] = dk
+	}
+}
+
+// createKey generates and saves the new data key of the channel, wrapped by
+// the current master key.
+func (svc *service) createKey(ctx context.Context, chanID string) ([]byte, DataKey, error) {
+	id, err := svc.idp.ID()
+	if err != nil {
+		return nil, DataKey{}, err
+	}
+
+	key := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, DataKey{}, err
+	}
+
+	current := svc.keyring.Current()
+	wrapped, err := wrap(current, chanID, id, key)
+	if err != nil {
+		return nil, DataKey{}, err
+	}
+
+	dk := DataKey{
+		ID:          id,
+		ChannelID:   chanID,
+		MasterKeyID: current.ID,
+		WrappedKey:  wrapped,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := svc.keys.Save(ctx, dk); err != nil {
+		return nil, DataKey{}, err
+	}
+
+	return key, dk, nil
+}
+
+func (svc *service) unwrap(dk DataKey) ([]byte, error) {
+	mk, ok := svc.keyring.Key(dk.MasterKeyID)
+	if !ok {
+		return nil, ErrUnknownMasterKey
+	}
+
+	return open(mk.Key, dk.WrappedKey, keyAAD(dk.ChannelID, dk.ID))
+}
+
+// wrap encrypts the data key with the master key. The data key is bound to
+// its channel and ID, so the wrapped keys can't be swapped in the storage.
+func wrap(mk MasterKey, chanID, id string, key []byte) ([]byte, error) {
+	nonce, ciphertext, err := seal(mk.Key, key, keyAAD(chanID, id))
+	if err != nil {
+		return nil, err
+	}
+	return append(nonce, ciphertext...), nil
+}
+
+func keyAAD(chanID, id string) []byte {
+	return []byte(chanID + "/" + id)
+}
+
+func seal(key, plaintext, aad []byte) ([]byte, []byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, err
+	}
+
+	return nonce, aead.Seal(nil, nonce, plaintext, aad), nil
+}
+
+// open decrypts the data prefixed by the nonce.
+func open(key, data, aad []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, ErrMalformedEnvelope
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, ciphertext, aad)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
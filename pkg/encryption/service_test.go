@@ -0,0 +1,252 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package encryption_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chanID      = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	otherChanID = "1f0a4b8e-5d6f-4c1b-9e0a-3c2d1b0a9f8e"
+)
+
+var (
+	plaintext = []byte(`{"temperature":17}`)
+	masterKey = encryption.MasterKey{ID: "mk1", Key: bytes.Repeat([]byte{1}, encryption.MasterKeySize)}
+	newMaster = encryption.MasterKey{ID: "mk2", Key: bytes.Repeat([]byte{2}, encryption.MasterKeySize)}
+)
+
+func newService(t *testing.T, keys encryption.KeyRepository, current encryption.MasterKey, previous ...encryption.MasterKey) encryption.Service {
+	kr, err := encryption.NewKeyring(current, previous...)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	return encryption.New(keys, kr, uuid.NewMock())
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	svc := newService(t, mocks.NewKeyRepository(), masterKey)
+
+	env, err := svc.Encrypt(context.Background(), chanID, plaintext)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.False(t, bytes.Contains(env, plaintext), "expected envelope not to contain plaintext")
+
+	tampered := append([]byte{}, env...)
+	tampered[len(tampered)-1] ^= 1
+
+	cases := []struct {
+		desc      string
+		chanID    string
+		envelope  []byte
+		plaintext []byte
+		err       error
+	}{
+		{
+			desc:      "decrypt envelope",
+			chanID:    chanID,
+			envelope:  env,
+			plaintext: plaintext,
+			err:       nil,
+		},
+		{
+			desc:     "decrypt envelope of another channel",
+			chanID:   otherChanID,
+			envelope: env,
+			err:      encryption.ErrDecrypt,
+		},
+		{
+			desc:     "decrypt tampered envelope",
+			chanID:   chanID,
+			envelope: tampered,
+			err:      encryption.ErrDecrypt,
+		},
+		{
+			desc:     "decrypt malformed envelope",
+			chanID:   chanID,
+			envelope: []byte{0},
+			err:      encryption.ErrMalformedEnvelope,
+		},
+	}
+
+	for _, tc := range cases {
+		pt, err := svc.Decrypt(context.Background(), tc.chanID, tc.envelope)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.plaintext, pt, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.plaintext, pt))
+	}
+}
+
+func TestRotateDataKey(t *testing.T) {
+	keys := mocks.NewKeyRepository()
+	svc := newService(t, keys, masterKey)
+
+	old, err := svc.Encrypt(context.Background(), chanID, plaintext)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	dk, err := svc.RotateDataKey(context.Background(), chanID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, chanID, dk.ChannelID, fmt.Sprintf("expected channel %s got %s", chanID, dk.ChannelID))
+	assert.Equal(t, masterKey.ID, dk.MasterKeyID, fmt.Sprintf("expected master key %s got %s", masterKey.ID, dk.MasterKeyID))
+
+	env, err := svc.Encrypt(context.Background(), chanID, plaintext)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.True(t, bytes.Contains(env, []byte(dk.ID)), "expected envelope to use the rotated data key")
+
+	// A fresh service, without cached keys, decrypts both envelopes.
+	svc = newService(t, keys, masterKey)
+	for _, e := range [][]byte{old, env} {
+		pt, err := svc.Decrypt(context.Background(), chanID, e)
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+		assert.Equal(t, plaintext, pt, fmt.Sprintf("expected %s got %s", plaintext, pt))
+	}
+}
+
+func TestRewrapDataKeys(t *testing.T) {
+	keys := mocks.NewKeyRepository()
+	svc := newService(t, keys, masterKey)
+
+	var envs [][]byte
+	for _, id := range []string{chanID, otherChanID} {
+		env, err := svc.Encrypt(context.Background(), id, plaintext)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		envs = append(envs, env)
+	}
+
+	// Without the master key wrapping the data keys, they can't be unwrapped.
+	svc = newService(t, keys, newMaster)
+	_, err := svc.Decrypt(context.Background(), chanID, envs[0])
+	assert.True(t, errors.Contains(err, encryption.ErrUnknownMasterKey), fmt.Sprintf("expected %s got %s", encryption.ErrUnknownMasterKey, err))
+	_, err = svc.RewrapDataKeys(context.Background())
+	assert.True(t, errors.Contains(err, encryption.ErrUnknownMasterKey), fmt.Sprintf("expected %s got %s", encryption.ErrUnknownMasterKey, err))
+
+	svc = newService(t, keys, newMaster, masterKey)
+	n, err := svc.RewrapDataKeys(context.Background())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, uint64(2), n, fmt.Sprintf("expected 2 rewrapped keys got %d", n))
+
+	n, err = svc.RewrapDataKeys(context.Background())
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, uint64(0), n, fmt.Sprintf("expected no rewrapped keys got %d", n))
+
+	// Once rewrapped, the previous master key is no longer needed.
+	svc = newService(t, keys, newMaster)
+	for i, id := range []string{chanID, otherChanID} {
+		pt, err := svc.Decrypt(context.Background(), id, envs[i])
+		assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+		assert.Equal(t, plaintext, pt, fmt.Sprintf("expected %s got %s", plaintext, pt))
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(masterKey.Key)
+	prev := base64.StdEncoding.EncodeToString(newMaster.Key)
+
+	cases := []struct {
+		desc     string
+		current  string
+		previous string
+		err      error
+	}{
+		{
+			desc:    "parse current master key",
+			current: "mk1:" + key,
+			err:     nil,
+		},
+		{
+			desc:     "parse current and previous master keys",
+			current:  "mk1:" + key,
+			previous: "mk2:" + prev + ",mk3:" + prev,
+			err:      nil,
+		},
+		{
+			desc:    "parse master key without ID",
+			current: key,
+			err:     encryption.ErrInvalidMasterKey,
+		},
+		{
+			desc:    "parse master key of invalid size",
+			current: "mk1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+			err:     encryption.ErrInvalidMasterKey,
+		},
+		{
+			desc:    "parse master key with invalid encoding",
+			current: "mk1:invalid!",
+			err:     encryption.ErrInvalidMasterKey,
+		},
+		{
+			desc:     "parse master keys with duplicate IDs",
+			current:  "mk1:" + key,
+			previous: "mk1:" + prev,
+			err:      encryption.ErrInvalidMasterKey,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := encryption.ParseKeyring(tc.current, tc.previous)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+	}
+}
+
+func TestKeyringWrap(t *testing.T) {
+	aad := []byte("key-id")
+	kr, err := encryption.NewKeyring(masterKey)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	mkID, wrapped, err := kr.Wrap(plaintext, aad)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, masterKey.ID, mkID, fmt.Sprintf("expected master key %s got %s", masterKey.ID, mkID))
+	assert.False(t, bytes.Contains(wrapped, plaintext), "expected secret to be encrypted")
+
+	rotated, err := encryption.NewKeyring(newMaster, masterKey)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	other, err := encryption.NewKeyring(newMaster)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		keyring encryption.Keyring
+		aad     []byte
+		secret  []byte
+		fails   bool
+	}{
+		{
+			desc:    "unwrap secret",
+			keyring: kr,
+			aad:     aad,
+			secret:  plaintext,
+		},
+		{
+			desc:    "unwrap secret with previous master key",
+			keyring: rotated,
+			aad:     aad,
+			secret:  plaintext,
+		},
+		{
+			desc:    "unwrap secret with unknown master key",
+			keyring: other,
+			aad:     aad,
+			fails:   true,
+		},
+		{
+			desc:    "unwrap secret bound to other data",
+			keyring: kr,
+			aad:     []byte("other-key-id"),
+			fails:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		secret, err := tc.keyring.Unwrap(mkID, wrapped, tc.aad)
+		assert.Equal(t, tc.fails, err != nil, fmt.Sprintf("%s: expected failure %t got %s", tc.desc, tc.fails, err))
+		assert.Equal(t, tc.secret, secret, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.secret, secret))
+	}
+}
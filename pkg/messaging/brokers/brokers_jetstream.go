@@ -0,0 +1,118 @@
+//go:build jetstream
+// +build jetstream
+
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package brokers
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/jetstream"
+)
+
+const (
+	// SubjectSenML represents subject to subscribe for the SenML messages.
+	SubjectSenML = "channels.*.senml.>"
+	// SubjectJSON represents subject to subscribe for the JSON messages.
+	SubjectJSON = "channels.*.json.>"
+	// SubjectSmtp represents subject to subscribe for the SMTP notifications.
+	SubjectSmtp = "smtp"
+	// SubjectSmpp represents subject to subscribe for the SMPP notifications.
+	SubjectSmpp = "smpp"
+	// SubjectWebhook represents subject to subscribe for the webhook notifications.
+	SubjectWebhook = "webhook"
+)
+
+const (
+	defAckWait    = "30s"
+	defMaxDeliver = "5"
+	defBackoff    = "1s"
+	defMaxBackoff = "15s"
+	defMaxAge     = "24h"
+
+	envAckWait    = "MF_JETSTREAM_ACK_WAIT"
+	envMaxDeliver = "MF_JETSTREAM_MAX_DELIVER"
+	envBackoff    = "MF_JETSTREAM_BACKOFF"
+	envMaxBackoff = "MF_JETSTREAM_MAX_BACKOFF"
+	envMaxAge     = "MF_JETSTREAM_MAX_AGE"
+)
+
+func init() {
+	log.Println("The binary was build using NATS JetStream as the message broker")
+}
+
+func NewPublisher(url string) (messaging.Publisher, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pb, err := jetstream.NewPublisher(url, cfg.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
+func NewPubSub(url, queue string, logger logger.Logger) (messaging.PubSub, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pb, err := jetstream.NewPubSub(url, queue, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
+func NewDeadLetterPublisher(url string) (messaging.DeadLetterPublisher, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pb, err := jetstream.NewDeadLetterPublisher(url, cfg.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
+func loadConfig() (jetstream.Config, error) {
+	var cfg jetstream.Config
+	durations := []struct {
+		env string
+		def string
+		val *time.Duration
+	}{
+		{envAckWait, defAckWait, &cfg.AckWait},
+		{envBackoff, defBackoff, &cfg.Backoff},
+		{envMaxBackoff, defMaxBackoff, &cfg.MaxBackoff},
+		{envMaxAge, defMaxAge, &cfg.MaxAge},
+	}
+	for _, d := range durations {
+		val, err := time.ParseDuration(mainflux.Env(d.env, d.def))
+		if err != nil {
+			return jetstream.Config{}, errors.Wrap(jetstream.ErrInvalidConfig, err)
+		}
+		*d.val = val
+	}
+
+	maxDeliver, err := strconv.Atoi(mainflux.Env(envMaxDeliver, defMaxDeliver))
+	if err != nil {
+		return jetstream.Config{}, errors.Wrap(jetstream.ErrInvalidConfig, err)
+	}
+	cfg.MaxDeliver = maxDeliver
+
+	return cfg, nil
+}
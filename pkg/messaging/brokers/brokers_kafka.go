@@ -0,0 +1,94 @@
+//go:build kafka
+// +build kafka
+
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package brokers
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/kafka"
+)
+
+const (
+	// SubjectSenML represents subject to subscribe for the SenML messages.
+	SubjectSenML = "channels.*.senml.>"
+	// SubjectJSON represents subject to subscribe for the JSON messages.
+	SubjectJSON = "channels.*.json.>"
+	// SubjectSmtp represents subject to subscribe for the SMTP notifications.
+	SubjectSmtp = "smtp"
+	// SubjectSmpp represents subject to subscribe for the SMPP notifications.
+	SubjectSmpp = "smpp"
+	// SubjectWebhook represents subject to subscribe for the webhook notifications.
+	SubjectWebhook = "webhook"
+)
+
+const (
+	defMaxDeliver = "5"
+	defBackoff    = "1s"
+	defMaxBackoff = "15s"
+
+	envMaxDeliver = "MF_KAFKA_MAX_DELIVER"
+	envBackoff    = "MF_KAFKA_BACKOFF"
+	envMaxBackoff = "MF_KAFKA_MAX_BACKOFF"
+)
+
+func init() {
+	log.Println("The binary was build using Kafka as the message broker")
+}
+
+func NewPublisher(url string) (messaging.Publisher, error) {
+	return kafka.NewPublisher(kafka.NewClient(url)), nil
+}
+
+func NewPubSub(url, queue string, logger logger.Logger) (messaging.PubSub, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pb, err := kafka.NewPubSub(kafka.NewClient(url), queue, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
+
+func NewDeadLetterPublisher(url string) (messaging.DeadLetterPublisher, error) {
+	return kafka.NewDeadLetterPublisher(kafka.NewClient(url)), nil
+}
+
+func loadConfig() (kafka.Config, error) {
+	var cfg kafka.Config
+	durations := []struct {
+		env string
+		def string
+		val *time.Duration
+	}{
+		{envBackoff, defBackoff, &cfg.Backoff},
+		{envMaxBackoff, defMaxBackoff, &cfg.MaxBackoff},
+	}
+	for _, d := range durations {
+		val, err := time.ParseDuration(mainflux.Env(d.env, d.def))
+		if err != nil {
+			return kafka.Config{}, errors.Wrap(kafka.ErrInvalidConfig, err)
+		}
+		*d.val = val
+	}
+
+	maxDeliver, err := strconv.Atoi(mainflux.Env(envMaxDeliver, defMaxDeliver))
+	if err != nil {
+		return kafka.Config{}, errors.Wrap(kafka.ErrInvalidConfig, err)
+	}
+	cfg.MaxDeliver = maxDeliver
+
+	return cfg, nil
+}
@@ -1,5 +1,5 @@
-//go:build !rabbitmq
-// +build !rabbitmq
+//go:build !rabbitmq && !jetstream && !kafka
+// +build !rabbitmq,!jetstream,!kafka
 
 // Copyright (c) Mainflux
 // SPDX-License-Identifier: Apache-2.0
@@ -23,6 +23,8 @@ const (
 	SubjectSmtp = "smtp"
 	// SubjectSmpp represents subject to subscribe for the SMPP notifications.
 	SubjectSmpp = "smpp"
+	// SubjectWebhook represents subject to subscribe for the webhook notifications.
+	SubjectWebhook = "webhook"
 )
 
 func init() {
@@ -45,3 +47,11 @@ func NewPubSub(url, queue string, logger logger.Logger) (messaging.PubSub, error
 	}
 	return pb, nil
 }
+
+func NewDeadLetterPublisher(url string) (messaging.DeadLetterPublisher, error) {
+	pb, err := nats.NewDeadLetterPublisher(url)
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
@@ -14,8 +14,16 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/messaging/rabbitmq"
 )
 
-// SubjectAllChannels represents subject to subscribe for all the channels.
-const SubjectAllChannels = "channels.#"
+const (
+	// SubjectAllChannels represents subject to subscribe for all the channels.
+	SubjectAllChannels = "channels.#"
+	// SubjectSmtp represents subject to subscribe for the SMTP notifications.
+	SubjectSmtp = "smtp"
+	// SubjectSmpp represents subject to subscribe for the SMPP notifications.
+	SubjectSmpp = "smpp"
+	// SubjectWebhook represents subject to subscribe for the webhook notifications.
+	SubjectWebhook = "webhook"
+)
 
 func init() {
 	log.Println("The binary was build using RabbitMQ as the message broker")
@@ -36,3 +44,11 @@ func NewPubSub(url, queue string, logger logger.Logger) (messaging.PubSub, error
 	}
 	return pb, nil
 }
+
+func NewDeadLetterPublisher(url string) (messaging.DeadLetterPublisher, error) {
+	pb, err := rabbitmq.NewDeadLetterPublisher(url)
+	if err != nil {
+		return nil, err
+	}
+	return pb, nil
+}
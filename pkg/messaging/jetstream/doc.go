@@ -0,0 +1,10 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jetstream holds the implementation of the Publisher and PubSub
+// interfaces for NATS JetStream, the persistence layer of the NATS messaging
+// system. Messages are stored in a stream and delivered to the durable
+// consumers, which acknowledge the messages only once they are successfully
+// handled. Messages which fail to be handled are redelivered with an
+// exponential backoff, up to the maximum number of deliveries.
+package jetstream
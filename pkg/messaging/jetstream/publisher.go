@@ -0,0 +1,243 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/gogo/protobuf/proto"
+	broker "github.com/nats-io/nats.go"
+)
+
+// A maximum number of reconnect attempts before NATS connection closes permanently.
+// Value -1 represents an unlimited number of reconnect retries, i.e. the client
+// will never give up on retrying to re-establish connection to NATS server.
+const (
+	maxReconnects  = -1
+	messagesSuffix = "messages"
+	chansPrefix    = "channels"
+
+	// deadLettersTimeout is the maximum wait for the next kept dead letter.
+	deadLettersTimeout = 5 * time.Second
+
+	// StreamName is the name of the JetStream stream which stores the messages.
+	StreamName = "mainflux"
+)
+
+var (
+	// ErrStream indicates that the stream can't be created.
+	ErrStream = errors.New("failed to create stream")
+
+	streamSubjects = []string{
+		chansPrefix + ".>",
+		messaging.SMTPProtocol,
+		messaging.SMPPProtocol,
+		messaging.WebhookProtocol,
+		messaging.DeadLetterPrefix + ".>",
+	}
+)
+
+var (
+	_ messaging.Publisher           = (*publisher)(nil)
+	_ messaging.DeadLetterPublisher = (*publisher)(nil)
+	_ messaging.DeadLetterStore     = (*publisher)(nil)
+)
+
+type publisher struct {
+	conn *broker.Conn
+	js   broker.JetStreamContext
+}
+
+// NewPublisher returns JetStream message Publisher. The stream is created if
+// it doesn't exist, keeping the messages up to the given maximum age. The zero
+// maximum age keeps the messages until they are removed by the other limits.
+func NewPublisher(url string, maxAge time.Duration) (messaging.Publisher, error) {
+	pub, err := newPublisher(url, maxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pub, nil
+}
+
+// NewDeadLetterPublisher returns JetStream dead-letter publisher. The dead
+// letters are kept in the stream along with the messages, and the returned
+// publisher implements messaging.DeadLetterStore.
+func NewDeadLetterPublisher(url string, maxAge time.Duration) (messaging.DeadLetterPublisher, error) {
+	pub, err := newPublisher(url, maxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pub, nil
+}
+
+func newPublisher(url string, maxAge time.Duration) (publisher, error) {
+	conn, err := broker.Connect(url, broker.MaxReconnects(maxReconnects))
+	if err != nil {
+		return publisher{}, err
+	}
+
+	js, err := conn.JetStream()
+	if err != nil {
+		conn.Close()
+		return publisher{}, err
+	}
+
+	if err := createStream(js, maxAge); err != nil {
+		conn.Close()
+		return publisher{}, errors.Wrap(ErrStream, err)
+	}
+
+	return publisher{
+		conn: conn,
+		js:   js,
+	}, nil
+}
+
+func (pub *publisher) Publish(msg messaging.Message) (err error) {
+	format, err := getFormat(msg.Profile.ContentType)
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	var subjects []string
+	if msg.Profile.Writer == nil || msg.Profile.Writer.Retain {
+		subject := fmt.Sprintf("%s.%s.%s.%s", chansPrefix, msg.Channel, format, messagesSuffix)
+		if msg.Subtopic != "" {
+			subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+		}
+
+		switch len(msg.Profile.Writer.Subtopics) {
+		case 0:
+			subjects = append(subjects, subject)
+		default:
+			for _, s := range msg.Profile.Writer.Subtopics {
+				if s == msg.Subtopic {
+					subjects = append(subjects, subject)
+				}
+			}
+		}
+	}
+
+	if sub := messaging.NotifierSubject(msg); sub != "" {
+		subjects = append(subjects, sub)
+	}
+
+	for _, subject := range subjects {
+		if _, err := pub.js.Publish(subject, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pub *publisher) PublishDeadLetter(consumer string, data []byte) error {
+	_, err := pub.js.Publish(messaging.DeadLetterSubject(consumer), data)
+	return err
+}
+
+func (pub *publisher) RetrieveDeadLetters(consumer string) ([]messaging.StoredDeadLetter, error) {
+	sub, err := pub.js.SubscribeSync(messaging.DeadLetterSubject(consumer), broker.OrderedConsumer(), broker.DeliverAll())
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	dls := []messaging.StoredDeadLetter{}
+	info, err := sub.ConsumerInfo()
+	if err != nil {
+		return nil, err
+	}
+	if info.NumPending == 0 && info.Delivered.Consumer == 0 {
+		return dls, nil
+	}
+
+	for {
+		m, err := sub.NextMsg(deadLettersTimeout)
+		if err != nil {
+			return nil, err
+		}
+		meta, err := m.Metadata()
+		if err != nil {
+			return nil, err
+		}
+		dls = append(dls, messaging.StoredDeadLetter{Seq: meta.Sequence.Stream, Data: m.Data})
+		if meta.NumPending == 0 {
+			return dls, nil
+		}
+	}
+}
+
+func (pub *publisher) RemoveDeadLetter(seq uint64) error {
+	return pub.js.DeleteMsg(StreamName, seq)
+}
+
+func (pub *publisher) Close() error {
+	pub.conn.Close()
+	return nil
+}
+
+// createStream creates the stream, or updates its subjects and maximum age if
+// the stream already exists.
+func createStream(js broker.JetStreamContext, maxAge time.Duration) error {
+	cfg := &broker.StreamConfig{
+		Name:      StreamName,
+		Subjects:  streamSubjects,
+		Retention: broker.LimitsPolicy,
+		Storage:   broker.FileStorage,
+		MaxAge:    maxAge,
+	}
+
+	info, err := js.StreamInfo(StreamName)
+	switch err {
+	case nil:
+		if info.Config.MaxAge == maxAge && equalSubjects(info.Config.Subjects, streamSubjects) {
+			return nil
+		}
+		info.Config.MaxAge = maxAge
+		info.Config.Subjects = streamSubjects
+		_, err = js.UpdateStream(&info.Config)
+		return err
+	case broker.ErrStreamNotFound:
+		_, err = js.AddStream(cfg)
+		return err
+	default:
+		return err
+	}
+}
+
+func equalSubjects(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func getFormat(ct string) (format string, err error) {
+	switch ct {
+	case messaging.JsonContentType:
+		return messaging.JsonFormat, nil
+	case messaging.SenmlContentType:
+		return messaging.SenmlFormat, nil
+	case messaging.CborContentType:
+		return messaging.CborFormat, nil
+	default:
+		return "", messaging.ErrUnknownContent
+	}
+}
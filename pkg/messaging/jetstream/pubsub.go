@@ -0,0 +1,257 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	broker "github.com/nats-io/nats.go"
+)
+
+var (
+	// ErrInvalidConfig indicates that the delivery configuration is invalid.
+	ErrInvalidConfig = errors.New("invalid delivery configuration")
+
+	durableReplacer = strings.NewReplacer(".", "_", "*", "any", ">", "all", " ", "_")
+)
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// Config specifies the delivery of the messages to the durable consumers.
+type Config struct {
+	// AckWait is the period after which the unacknowledged message is
+	// redelivered. It has to be longer than the maximum backoff.
+	AckWait time.Duration
+
+	// MaxDeliver is the maximum number of deliveries of a message. The
+	// message is dropped once it fails to be handled that many times.
+	MaxDeliver int
+
+	// Backoff is the delay of the first redelivery of the message which
+	// failed to be handled. The delay doubles with each next redelivery.
+	Backoff time.Duration
+
+	// MaxBackoff is the maximum delay of the redelivery.
+	MaxBackoff time.Duration
+
+	// MaxAge is the maximum age of the messages kept in the stream.
+	MaxAge time.Duration
+}
+
+// Validate returns an error if the configuration is invalid.
+func (cfg Config) Validate() error {
+	if cfg.AckWait <= 0 || cfg.MaxDeliver < 1 || cfg.Backoff < 0 || cfg.MaxBackoff < cfg.Backoff {
+		return ErrInvalidConfig
+	}
+	if cfg.AckWait <= cfg.MaxBackoff {
+		return errors.Wrap(ErrInvalidConfig, errors.New("ack wait must be longer than max backoff"))
+	}
+
+	return nil
+}
+
+type subscription struct {
+	*broker.Subscription
+	cancel func() error
+}
+
+type pubsub struct {
+	publisher
+	cfg           Config
+	logger        log.Logger
+	mu            sync.Mutex
+	queue         string
+	subscriptions map[string]map[string]subscription
+}
+
+// NewPubSub returns JetStream message publisher/subscriber. Each subscription
+// is backed by a durable consumer, named after the queue and the topic. If the
+// queue is empty, the subscriber ID is used instead. Subscribers sharing the
+// durable consumer, such as the replicas of a service, receive each message
+// once, while the messages published while none of them is running are
+// delivered once they subscribe again. A message is acknowledged only once
+// its handler succeeds.
+func NewPubSub(url, queue string, cfg Config, logger log.Logger) (messaging.PubSub, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	pub, err := newPublisher(url, cfg.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &pubsub{
+		publisher:     pub,
+		cfg:           cfg,
+		queue:         queue,
+		logger:        logger,
+		subscriptions: make(map[string]map[string]subscription),
+	}
+	return ret, nil
+}
+
+func (ps *pubsub) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	if id == "" {
+		return messaging.ErrEmptyID
+	}
+	if topic == "" {
+		return messaging.ErrEmptyTopic
+	}
+
+	ps.mu.Lock()
+	// Check topic
+	s, ok := ps.subscriptions[topic]
+	if ok {
+		// Check client ID
+		if _, ok := s[id]; ok {
+			// Unlocking, so that Unsubscribe() can access ps.subscriptions
+			ps.mu.Unlock()
+			if err := ps.Unsubscribe(id, topic); err != nil {
+				return err
+			}
+
+			ps.mu.Lock()
+			// value of s can be changed while ps.mu is unlocked
+			s = ps.subscriptions[topic]
+		}
+	}
+	defer ps.mu.Unlock()
+	if s == nil {
+		s = make(map[string]subscription)
+		ps.subscriptions[topic] = s
+	}
+
+	group := ps.queue
+	if group == "" {
+		group = id
+	}
+	durable := durableName(group, topic)
+
+	sub, err := ps.js.QueueSubscribe(topic, durable, ps.natsHandler(handler),
+		broker.Durable(durable),
+		broker.DeliverNew(),
+		broker.ManualAck(),
+		broker.AckExplicit(),
+		broker.AckWait(ps.cfg.AckWait),
+		broker.MaxDeliver(ps.cfg.MaxDeliver),
+	)
+	if err != nil {
+		return err
+	}
+	s[id] = subscription{
+		Subscription: sub,
+		cancel:       handler.Cancel,
+	}
+
+	return nil
+}
+
+// Unsubscribe removes the subscription along with its durable consumer.
+func (ps *pubsub) Unsubscribe(id, topic string) error {
+	if id == "" {
+		return messaging.ErrEmptyID
+	}
+	if topic == "" {
+		return messaging.ErrEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	// Check topic
+	s, ok := ps.subscriptions[topic]
+	if !ok {
+		return messaging.ErrNotSubscribed
+	}
+	// Check topic ID
+	current, ok := s[id]
+	if !ok {
+		return messaging.ErrNotSubscribed
+	}
+	if current.cancel != nil {
+		if err := current.cancel(); err != nil {
+			return err
+		}
+	}
+	if err := current.Unsubscribe(); err != nil {
+		return err
+	}
+
+	delete(s, id)
+	if len(s) == 0 {
+		delete(ps.subscriptions, topic)
+	}
+	return nil
+}
+
+func (ps *pubsub) natsHandler(h messaging.MessageHandler) broker.MsgHandler {
+	return func(m *broker.Msg) {
+		var msg messaging.Message
+		if err := proto.Unmarshal(m.Data, &msg); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+			ps.term(m)
+			return
+		}
+
+		var delivered uint64 = 1
+		if meta, e := m.Metadata(); e == nil {
+			delivered = meta.NumDelivered
+		}
+		last := delivered >= uint64(ps.cfg.MaxDeliver)
+
+		err := messaging.Deliver(h, msg, messaging.Delivery{Attempt: delivered, Last: last})
+		if err == nil {
+			if err := m.Ack(); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to acknowledge message: %s", err))
+			}
+			return
+		}
+
+		if last {
+			ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message, dropping it after %d deliveries: %s", delivered, err))
+			ps.term(m)
+			return
+		}
+
+		ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message, delivery %d of %d: %s", delivered, ps.cfg.MaxDeliver, err))
+		time.AfterFunc(ps.backoff(delivered), func() {
+			if err := m.Nak(); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to request message redelivery: %s", err))
+			}
+		})
+	}
+}
+
+// backoff returns the delay of the redelivery of the message which failed to
+// be handled the given number of times.
+func (ps *pubsub) backoff(delivered uint64) time.Duration {
+	d := ps.cfg.Backoff
+	for i := uint64(1); i < delivered && d < ps.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > ps.cfg.MaxBackoff {
+		d = ps.cfg.MaxBackoff
+	}
+
+	return d
+}
+
+func (ps *pubsub) term(m *broker.Msg) {
+	if err := m.Term(); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to terminate message delivery: %s", err))
+	}
+}
+
+// durableName returns the name of the durable consumer of the group for the
+// topic. Consumer names can't contain the subject tokens and wildcards.
+func durableName(group, topic string) string {
+	return durableReplacer.Replace(fmt.Sprintf("%s-%s", group, topic))
+}
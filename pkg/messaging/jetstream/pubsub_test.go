@@ -0,0 +1,403 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream_test
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chansPrefix      = "channels"
+	channel          = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	subtopic         = "engine"
+	clientID         = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	senmlContentType = "application/senml+json"
+	senmlFormat      = "senml"
+	messagesSuffix   = "messages"
+	timeout          = 5 * time.Second
+)
+
+var (
+	data       = []byte("payload")
+	errFailed  = errors.New("failed")
+	msgProfile = &messaging.Profile{ContentType: senmlContentType, TimeField: &messaging.TimeField{}, Writer: &messaging.Writer{Retain: true}, Notifier: &messaging.Notifier{}}
+)
+
+func TestPublisher(t *testing.T) {
+	h := newHandler(0)
+	format := senmlFormat + "." + messagesSuffix
+	err := pubsub.Subscribe(clientID, fmt.Sprintf("%s.%s.%s", chansPrefix, channel, format), h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Subscribe(clientID, fmt.Sprintf("%s.%s.%s.%s", chansPrefix, channel, format, subtopic), h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		subtopic string
+		payload  []byte
+	}{
+		{
+			desc:    "publish message with nil payload",
+			payload: nil,
+		},
+		{
+			desc:    "publish message with string payload",
+			payload: data,
+		},
+		{
+			desc:     "publish message with subtopic",
+			payload:  data,
+			subtopic: subtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{
+			Channel:  channel,
+			Subtopic: tc.subtopic,
+			Payload:  tc.payload,
+			Profile:  msgProfile,
+		}
+
+		err = publisher.Publish(expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		receivedMsg := h.receive(t)
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+	}
+
+	err = pubsub.Unsubscribe(clientID, fmt.Sprintf("%s.%s.%s", chansPrefix, channel, format))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Unsubscribe(clientID, fmt.Sprintf("%s.%s.%s.%s", chansPrefix, channel, format, subtopic))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+}
+
+func TestPubsub(t *testing.T) {
+	topic := fmt.Sprintf("%s.%s", chansPrefix, channel)
+
+	cases := []struct {
+		desc      string
+		topic     string
+		clientID  string
+		err       error
+		subscribe bool
+		handler   messaging.MessageHandler
+	}{
+		{
+			desc:      "subscribe to a topic with an ID",
+			topic:     topic,
+			clientID:  "clientid1",
+			err:       nil,
+			subscribe: true,
+			handler:   newHandler(0),
+		},
+		{
+			desc:      "subscribe to the same topic with a different ID",
+			topic:     topic,
+			clientID:  "clientid2",
+			err:       nil,
+			subscribe: true,
+			handler:   newHandler(0),
+		},
+		{
+			desc:      "subscribe to an already subscribed topic with an ID",
+			topic:     topic,
+			clientID:  "clientid1",
+			err:       nil,
+			subscribe: true,
+			handler:   newHandler(0),
+		},
+		{
+			desc:      "unsubscribe from a topic with an ID",
+			topic:     topic,
+			clientID:  "clientid1",
+			err:       nil,
+			subscribe: false,
+		},
+		{
+			desc:      "unsubscribe from an already unsubscribed topic with an ID",
+			topic:     topic,
+			clientID:  "clientid1",
+			err:       messaging.ErrNotSubscribed,
+			subscribe: false,
+		},
+		{
+			desc:      "unsubscribe from a non-existent topic with an ID",
+			topic:     "h",
+			clientID:  "clientid1",
+			err:       messaging.ErrNotSubscribed,
+			subscribe: false,
+		},
+		{
+			desc:      "subscribe to an empty topic with an ID",
+			topic:     "",
+			clientID:  "clientid1",
+			err:       messaging.ErrEmptyTopic,
+			subscribe: true,
+			handler:   newHandler(0),
+		},
+		{
+			desc:      "unsubscribe from an empty topic with an ID",
+			topic:     "",
+			clientID:  "clientid1",
+			err:       messaging.ErrEmptyTopic,
+			subscribe: false,
+		},
+		{
+			desc:      "subscribe to a topic with empty id",
+			topic:     topic,
+			clientID:  "",
+			err:       messaging.ErrEmptyID,
+			subscribe: true,
+			handler:   newHandler(0),
+		},
+		{
+			desc:      "unsubscribe from a topic with empty id",
+			topic:     topic,
+			clientID:  "",
+			err:       messaging.ErrEmptyID,
+			subscribe: false,
+		},
+		{
+			desc:      "unsubscribe from a topic with an ID",
+			topic:     topic,
+			clientID:  "clientid2",
+			err:       nil,
+			subscribe: false,
+		},
+	}
+
+	for _, tc := range cases {
+		var err error
+		switch tc.subscribe {
+		case true:
+			err = pubsub.Subscribe(tc.clientID, tc.topic, tc.handler)
+		default:
+			err = pubsub.Unsubscribe(tc.clientID, tc.topic)
+		}
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRedelivery(t *testing.T) {
+	cases := []struct {
+		desc      string
+		id        string
+		fails     int32
+		delivered int32
+	}{
+		{
+			desc:      "handle message successfully",
+			id:        "redelivery1",
+			fails:     0,
+			delivered: 1,
+		},
+		{
+			desc:      "handle message after failures",
+			id:        "redelivery2",
+			fails:     int32(config.MaxDeliver) - 1,
+			delivered: int32(config.MaxDeliver),
+		},
+		{
+			desc:      "drop message after max deliveries",
+			id:        "redelivery3",
+			fails:     int32(config.MaxDeliver) + 1,
+			delivered: int32(config.MaxDeliver),
+		},
+	}
+
+	for _, tc := range cases {
+		topic := fmt.Sprintf("%s.%s.%s.%s", chansPrefix, tc.id, senmlFormat, messagesSuffix)
+		h := newHandler(tc.fails)
+		err := pubsub.Subscribe(tc.id, topic, h)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		msg := messaging.Message{
+			Channel: tc.id,
+			Payload: data,
+			Profile: msgProfile,
+		}
+		err = publisher.Publish(msg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		for i := int32(0); i < tc.delivered; i++ {
+			h.receive(t)
+		}
+		h.none(t, time.Second)
+		assert.Equal(t, tc.delivered, atomic.LoadInt32(&h.delivered), fmt.Sprintf("%s: expected %d deliveries got %d\n", tc.desc, tc.delivered, h.delivered))
+
+		err = pubsub.Unsubscribe(tc.id, topic)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+	}
+}
+
+func TestDurable(t *testing.T) {
+	id := "durable"
+	topic := fmt.Sprintf("%s.%s.%s.%s", chansPrefix, id, senmlFormat, messagesSuffix)
+
+	ps, err := jetstream.NewPubSub(address, "", config, testLog)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = ps.Subscribe(id, topic, newHandler(0))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	// Closing the connection keeps the durable consumer, as if the
+	// subscriber went down.
+	err = ps.Close()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	expectedMsg := messaging.Message{
+		Channel: id,
+		Payload: data,
+		Profile: msgProfile,
+	}
+	err = publisher.Publish(expectedMsg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ps, err = jetstream.NewPubSub(address, "", config, testLog)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	h := newHandler(0)
+	err = ps.Subscribe(id, topic, h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	receivedMsg := h.receive(t)
+	assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("expected %+v got %+v\n", expectedMsg, receivedMsg))
+
+	err = ps.Unsubscribe(id, topic)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = ps.Close()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		config jetstream.Config
+		err    error
+	}{
+		{
+			desc:   "validate valid config",
+			config: config,
+			err:    nil,
+		},
+		{
+			desc:   "validate config without ack wait",
+			config: jetstream.Config{MaxDeliver: 1},
+			err:    jetstream.ErrInvalidConfig,
+		},
+		{
+			desc:   "validate config without max deliver",
+			config: jetstream.Config{AckWait: time.Second},
+			err:    jetstream.ErrInvalidConfig,
+		},
+		{
+			desc:   "validate config with backoff longer than max backoff",
+			config: jetstream.Config{AckWait: time.Minute, MaxDeliver: 1, Backoff: time.Second},
+			err:    jetstream.ErrInvalidConfig,
+		},
+		{
+			desc:   "validate config with max backoff longer than ack wait",
+			config: jetstream.Config{AckWait: time.Second, MaxDeliver: 1, Backoff: time.Second, MaxBackoff: time.Minute},
+			err:    jetstream.ErrInvalidConfig,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.config.Validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+// handler fails to handle the first given number of the deliveries.
+type handler struct {
+	fails     int32
+	delivered int32
+	msgs      chan messaging.Message
+}
+
+func newHandler(fails int32) *handler {
+	return &handler{
+		fails: fails,
+		msgs:  make(chan messaging.Message, 10),
+	}
+}
+
+func (h *handler) Handle(msg messaging.Message) error {
+	n := atomic.AddInt32(&h.delivered, 1)
+	h.msgs <- msg
+	if n <= h.fails {
+		return errFailed
+	}
+	return nil
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
+
+func (h *handler) receive(t *testing.T) messaging.Message {
+	select {
+	case msg := <-h.msgs:
+		return msg
+	case <-time.After(timeout):
+		require.Fail(t, "message not received")
+		return messaging.Message{}
+	}
+}
+
+func (h *handler) none(t *testing.T, d time.Duration) {
+	select {
+	case msg := <-h.msgs:
+		assert.Fail(t, fmt.Sprintf("unexpected message received: %+v", msg))
+	case <-time.After(d):
+	}
+}
+
+func TestPublishDeadLetter(t *testing.T) {
+	pub, err := jetstream.NewDeadLetterPublisher(address, config.MaxAge)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pub.Close()
+
+	err = pub.PublishDeadLetter("writer", data)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+}
+
+func TestDeadLetterStore(t *testing.T) {
+	pub, err := jetstream.NewDeadLetterPublisher(address, config.MaxAge)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer pub.Close()
+
+	store, ok := pub.(messaging.DeadLetterStore)
+	require.True(t, ok, "expected dead-letter store")
+
+	consumer := "store-writer"
+	dls, err := store.RetrieveDeadLetters(consumer)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Len(t, dls, 0, fmt.Sprintf("expected no dead letters got %d", len(dls)))
+
+	for _, d := range []string{"first", "second"} {
+		err := store.PublishDeadLetter(consumer, []byte(d))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	dls, err = store.RetrieveDeadLetters(consumer)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	require.Len(t, dls, 2, fmt.Sprintf("expected 2 dead letters got %d", len(dls)))
+	assert.Equal(t, []byte("first"), dls[0].Data, fmt.Sprintf("expected first dead letter got %s", dls[0].Data))
+
+	err = store.RemoveDeadLetter(dls[0].Seq)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	dls, err = store.RetrieveDeadLetters(consumer)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	require.Len(t, dls, 1, fmt.Sprintf("expected 1 dead letter got %d", len(dls)))
+	assert.Equal(t, []byte("second"), dls[0].Data, fmt.Sprintf("expected second dead letter got %s", dls[0].Data))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package jetstream_test
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/jetstream"
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+var (
+	address   string
+	publisher messaging.Publisher
+	pubsub    messaging.PubSub
+	testLog   logger.Logger
+	config    = jetstream.Config{
+		AckWait:    2 * time.Second,
+		MaxDeliver: 3,
+		Backoff:    10 * time.Millisecond,
+		MaxBackoff: 50 * time.Millisecond,
+		MaxAge:     time.Hour,
+	}
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "jetstream")
+	if err != nil {
+		log.Fatalf("Could not create storage directory: %s", err)
+	}
+
+	srv, err := server.NewServer(&server.Options{
+		Host:      "127.0.0.1",
+		Port:      server.RANDOM_PORT,
+		JetStream: true,
+		StoreDir:  dir,
+		NoLog:     true,
+		NoSigs:    true,
+	})
+	if err != nil {
+		log.Fatalf("Could not create NATS server: %s", err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(10 * time.Second) {
+		log.Fatalf("Could not start NATS server")
+	}
+	address = srv.ClientURL()
+
+	if publisher, err = jetstream.NewPublisher(address, config.MaxAge); err != nil {
+		log.Fatalf("Could not connect to NATS server: %s", err)
+	}
+
+	if testLog, err = logger.New(os.Stdout, "error"); err != nil {
+		log.Fatalf(err.Error())
+	}
+	if pubsub, err = jetstream.NewPubSub(address, "", config, testLog); err != nil {
+		log.Fatalf("Could not connect to NATS server: %s", err)
+	}
+
+	code := m.Run()
+
+	srv.Shutdown()
+	os.RemoveAll(dir)
+
+	os.Exit(code)
+}
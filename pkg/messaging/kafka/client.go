@@ -0,0 +1,124 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	urlScheme = "kafka://"
+
+	// batchTimeout is the maximum wait for the batch of the written messages
+	// to fill up, which delays every synchronous write.
+	batchTimeout = 10 * time.Millisecond
+
+	// lookupTimeout is the maximum duration of the partitions lookup.
+	lookupTimeout = 10 * time.Second
+)
+
+// Writer specifies the API for producing the Kafka messages.
+type Writer interface {
+	// WriteMessages writes the messages to their topics.
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+
+	// Close flushes the pending messages and closes the writer.
+	Close() error
+}
+
+// Reader specifies the API for consuming the Kafka messages as a member of
+// the consumer group.
+type Reader interface {
+	// FetchMessage blocks until the next message is available.
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+
+	// CommitMessages commits the offsets of the messages for the group.
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+
+	// Close leaves the consumer group and closes the reader.
+	Close() error
+}
+
+// Client creates the Kafka writers and readers.
+type Client interface {
+	// Writer returns the writer of the messages of any topic. The messages
+	// with the same key are written to the same partition.
+	Writer() Writer
+
+	// Reader returns the reader of the topic for the consumer group.
+	Reader(group, topic string) Reader
+
+	// PartitionReader returns the reader of the partition of the topic which
+	// holds the messages with the given key. The reader doesn't join any
+	// consumer group and starts with the messages written after it was
+	// created, so its offsets can't be committed.
+	PartitionReader(topic string, key []byte) (Reader, error)
+}
+
+var _ Client = (*client)(nil)
+
+type client struct {
+	brokers []string
+}
+
+// NewClient returns the Kafka client of the cluster with the given comma
+// separated broker addresses, optionally prefixed with the kafka:// scheme.
+// The topics are created on the first write, if the cluster allows it.
+func NewClient(url string) Client {
+	return &client{
+		brokers: strings.Split(strings.TrimPrefix(url, urlScheme), ","),
+	}
+}
+
+func (c *client) Writer() Writer {
+	return &kafka.Writer{
+		Addr:                   kafka.TCP(c.brokers...),
+		Balancer:               &kafka.Hash{},
+		RequiredAcks:           kafka.RequireAll,
+		AllowAutoTopicCreation: true,
+		BatchTimeout:           batchTimeout,
+	}
+}
+
+func (c *client) Reader(group, topic string) Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     c.brokers,
+		GroupID:     group,
+		Topic:       topic,
+		StartOffset: kafka.LastOffset,
+	})
+}
+
+func (c *client) PartitionReader(topic string, key []byte) (Reader, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lookupTimeout)
+	defer cancel()
+
+	parts, err := kafka.DefaultDialer.LookupPartitions(ctx, "tcp", c.brokers[0], topic)
+	if err != nil {
+		return nil, err
+	}
+
+	// The writer balances the messages over the partitions ordered by ID.
+	partitions := make([]int, len(parts))
+	for i := range partitions {
+		partitions[i] = i
+	}
+	p := (&kafka.Hash{}).Balance(kafka.Message{Key: key}, partitions...)
+
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   c.brokers,
+		Topic:     topic,
+		Partition: p,
+	})
+	if err := r.SetOffset(kafka.LastOffset); err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
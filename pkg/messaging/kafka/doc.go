@@ -0,0 +1,12 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package kafka holds the implementation of the Publisher and PubSub
+// interfaces for the Apache Kafka event streaming platform. Subjects are
+// mapped to the Kafka topics named after their first token, e.g. all the
+// channel messages are published to the "channels" topic, using the channel
+// ID as the partition key so that the messages of each channel are consumed
+// in the order they were published. The full subject is carried in the
+// message header and matched against the subscribed subject, which supports
+// the NATS wildcards.
+package kafka
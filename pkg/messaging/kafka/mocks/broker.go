@@ -0,0 +1,280 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"hash/fnv"
+	"io"
+	"sync"
+
+	mfkafka "github.com/MainfluxLabs/mainflux/pkg/messaging/kafka"
+	"github.com/segmentio/kafka-go"
+)
+
+var _ mfkafka.Client = (*Broker)(nil)
+
+// Broker is the in-process stand-in for the Kafka cluster. Messages are
+// written to the partitions of their topic by the hash of their key, and the
+// partitions of a topic are shared among the readers of the consumer group.
+type Broker struct {
+	mu         sync.Mutex
+	partitions int
+	topics     map[string][][]kafka.Message
+	groups     map[string]*group
+	// changed is closed and replaced whenever a message is written or the
+	// members of a group change, waking up the blocked readers.
+	changed chan struct{}
+}
+
+type group struct {
+	// next and committed hold the offsets of the next message to fetch and
+	// of the next message to commit, per partition.
+	next      []int64
+	committed []int64
+	members   []*reader
+}
+
+// NewBroker returns the broker with the given number of partitions per topic.
+func NewBroker(partitions int) *Broker {
+	return &Broker{
+		partitions: partitions,
+		topics:     make(map[string][][]kafka.Message),
+		groups:     make(map[string]*group),
+		changed:    make(chan struct{}),
+	}
+}
+
+// Writer returns the writer of the messages of any topic.
+func (b *Broker) Writer() mfkafka.Writer {
+	return writer{broker: b}
+}
+
+// Reader returns the reader of the topic joining the consumer group. The new
+// consumer group starts with the messages written after it was created.
+func (b *Broker) Reader(groupID, topic string) mfkafka.Reader {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := groupID + "/" + topic
+	g, ok := b.groups[key]
+	if !ok {
+		parts := b.topic(topic)
+		g = &group{
+			next:      make([]int64, b.partitions),
+			committed: make([]int64, b.partitions),
+		}
+		for p := range parts {
+			g.next[p] = int64(len(parts[p]))
+			g.committed[p] = g.next[p]
+		}
+		b.groups[key] = g
+	}
+
+	r := &reader{
+		broker:    b,
+		topic:     topic,
+		group:     g,
+		partition: -1,
+		closed:    make(chan struct{}),
+	}
+	g.members = append(g.members, r)
+	b.notify()
+
+	return r
+}
+
+// PartitionReader returns the reader of the partition of the topic which
+// holds the messages with the key. The reader starts with the messages
+// written after it was created.
+func (b *Broker) PartitionReader(topic string, key []byte) (mfkafka.Reader, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	parts := b.topic(topic)
+	p := b.partition(key)
+	g := &group{
+		next:      make([]int64, b.partitions),
+		committed: make([]int64, b.partitions),
+	}
+	g.next[p] = int64(len(parts[p]))
+	g.committed[p] = g.next[p]
+
+	r := &reader{
+		broker:    b,
+		topic:     topic,
+		group:     g,
+		partition: p,
+		closed:    make(chan struct{}),
+	}
+	g.members = append(g.members, r)
+
+	return r, nil
+}
+
+// Messages returns the messages written to the topic, ordered by partition
+// and offset.
+func (b *Broker) Messages(topic string) []kafka.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var msgs []kafka.Message
+	for _, part := range b.topics[topic] {
+		msgs = append(msgs, part...)
+	}
+	return msgs
+}
+
+// topic returns the partitions of the topic, creating it if necessary.
+func (b *Broker) topic(name string) [][]kafka.Message {
+	parts, ok := b.topics[name]
+	if !ok {
+		parts = make([][]kafka.Message, b.partitions)
+		b.topics[name] = parts
+	}
+	return parts
+}
+
+func (b *Broker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func (b *Broker) partition(key []byte) int {
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % uint32(b.partitions))
+}
+
+type writer struct {
+	broker *Broker
+}
+
+func (w writer) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	b := w.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, msg := range msgs {
+		parts := b.topic(msg.Topic)
+		p := b.partition(msg.Key)
+		msg.Partition = p
+		msg.Offset = int64(len(parts[p]))
+		parts[p] = append(parts[p], msg)
+	}
+	b.notify()
+
+	return nil
+}
+
+func (w writer) Close() error {
+	return nil
+}
+
+type reader struct {
+	broker *Broker
+	topic  string
+	group  *group
+	// partition is the only partition read by the reader, or -1 if the
+	// reader shares the partitions of the topic with its group.
+	partition int
+	closed    chan struct{}
+}
+
+func (r *reader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	b := r.broker
+	for {
+		b.mu.Lock()
+		select {
+		case <-r.closed:
+			b.mu.Unlock()
+			return kafka.Message{}, io.EOF
+		default:
+		}
+		if msg, ok := r.next(); ok {
+			b.mu.Unlock()
+			return msg, nil
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return kafka.Message{}, ctx.Err()
+		case <-r.closed:
+			return kafka.Message{}, io.EOF
+		case <-changed:
+		}
+	}
+}
+
+// next returns the next message of the partitions assigned to the reader.
+func (r *reader) next() (kafka.Message, bool) {
+	parts := r.broker.topic(r.topic)
+	for p := range parts {
+		if !r.assigned(p) {
+			continue
+		}
+		if off := r.group.next[p]; off < int64(len(parts[p])) {
+			r.group.next[p]++
+			return parts[p][off], true
+		}
+	}
+	return kafka.Message{}, false
+}
+
+// assigned reports whether the partition is assigned to the reader. The
+// partitions are assigned to the members of the group in a round-robin way.
+func (r *reader) assigned(partition int) bool {
+	if r.partition >= 0 {
+		return partition == r.partition
+	}
+	members := r.group.members
+	return members[partition%len(members)] == r
+}
+
+func (r *reader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	b := r.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, msg := range msgs {
+		if off := msg.Offset + 1; off > r.group.committed[msg.Partition] {
+			r.group.committed[msg.Partition] = off
+		}
+	}
+
+	return nil
+}
+
+// Close leaves the consumer group. The messages fetched, but not committed,
+// by the reader are fetched again by the remaining members of the group.
+func (r *reader) Close() error {
+	b := r.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	select {
+	case <-r.closed:
+		return nil
+	default:
+	}
+	close(r.closed)
+
+	g := r.group
+	for p := range g.next {
+		if r.assigned(p) {
+			g.next[p] = g.committed[p]
+		}
+	}
+	for i, m := range g.members {
+		if m == r {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			break
+		}
+	}
+	b.notify()
+
+	return nil
+}
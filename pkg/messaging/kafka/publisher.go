@@ -0,0 +1,129 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/gogo/protobuf/proto"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	chansPrefix    = "channels"
+	messagesSuffix = "messages"
+	subjectHeader  = "subject"
+)
+
+var (
+	_ messaging.Publisher           = (*publisher)(nil)
+	_ messaging.DeadLetterPublisher = (*publisher)(nil)
+)
+
+type publisher struct {
+	writer Writer
+}
+
+// NewPublisher returns Kafka message Publisher.
+func NewPublisher(client Client) messaging.Publisher {
+	return &publisher{
+		writer: client.Writer(),
+	}
+}
+
+// NewDeadLetterPublisher returns Kafka dead-letter publisher. The dead letters
+// are published to the "deadletters" topic, keyed by the consumer name.
+func NewDeadLetterPublisher(client Client) messaging.DeadLetterPublisher {
+	return &publisher{
+		writer: client.Writer(),
+	}
+}
+
+func (pub *publisher) Publish(msg messaging.Message) error {
+	format, err := getFormat(msg.Profile.ContentType)
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(&msg)
+	if err != nil {
+		return err
+	}
+
+	var subjects []string
+	if msg.Profile.Writer == nil || msg.Profile.Writer.Retain {
+		subject := fmt.Sprintf("%s.%s.%s.%s", chansPrefix, msg.Channel, format, messagesSuffix)
+		if msg.Subtopic != "" {
+			subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
+		}
+
+		switch len(msg.Profile.Writer.Subtopics) {
+		case 0:
+			subjects = append(subjects, subject)
+		default:
+			for _, s := range msg.Profile.Writer.Subtopics {
+				if s == msg.Subtopic {
+					subjects = append(subjects, subject)
+				}
+			}
+		}
+	}
+
+	if sub := messaging.NotifierSubject(msg); sub != "" {
+		subjects = append(subjects, sub)
+	}
+
+	var msgs []kafka.Message
+	for _, subject := range subjects {
+		msgs = append(msgs, message(subject, msg.Channel, data))
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	return pub.writer.WriteMessages(context.Background(), msgs...)
+}
+
+func (pub *publisher) PublishDeadLetter(consumer string, data []byte) error {
+	subject := messaging.DeadLetterSubject(consumer)
+	return pub.writer.WriteMessages(context.Background(), message(subject, consumer, data))
+}
+
+func (pub *publisher) Close() error {
+	return pub.writer.Close()
+}
+
+// message returns the Kafka message of the subject. The key determines the
+// partition of the message.
+func message(subject, key string, data []byte) kafka.Message {
+	return kafka.Message{
+		Topic: topic(subject),
+		Key:   []byte(key),
+		Value: data,
+		Headers: []kafka.Header{
+			{Key: subjectHeader, Value: []byte(subject)},
+		},
+	}
+}
+
+// topic returns the Kafka topic of the subject, which is its first token.
+func topic(subject string) string {
+	return strings.SplitN(subject, ".", 2)[0]
+}
+
+func getFormat(ct string) (format string, err error) {
+	switch ct {
+	case messaging.JsonContentType:
+		return messaging.JsonFormat, nil
+	case messaging.SenmlContentType:
+		return messaging.SenmlFormat, nil
+	case messaging.CborContentType:
+		return messaging.CborFormat, nil
+	default:
+		return "", messaging.ErrUnknownContent
+	}
+}
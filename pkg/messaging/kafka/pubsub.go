@@ -0,0 +1,338 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/segmentio/kafka-go"
+)
+
+// ErrInvalidConfig indicates that the delivery configuration is invalid.
+var ErrInvalidConfig = errors.New("invalid delivery configuration")
+
+var _ messaging.PubSub = (*pubsub)(nil)
+
+// Config specifies the redelivery of the messages which failed to be handled.
+type Config struct {
+	// MaxDeliver is the maximum number of deliveries of a message. The
+	// message is dropped once it fails to be handled that many times.
+	MaxDeliver int
+
+	// Backoff is the delay of the first redelivery of the message which
+	// failed to be handled. The delay doubles with each next redelivery.
+	Backoff time.Duration
+
+	// MaxBackoff is the maximum delay of the redelivery.
+	MaxBackoff time.Duration
+}
+
+// Validate returns an error if the configuration is invalid.
+func (cfg Config) Validate() error {
+	if cfg.MaxDeliver < 1 || cfg.Backoff < 0 || cfg.MaxBackoff < cfg.Backoff {
+		return ErrInvalidConfig
+	}
+
+	return nil
+}
+
+type subscription struct {
+	reader Reader
+	group  bool
+	stop   context.CancelFunc
+	done   chan struct{}
+	cancel func() error
+}
+
+type pubsub struct {
+	publisher
+	client        Client
+	cfg           Config
+	logger        log.Logger
+	mu            sync.Mutex
+	queue         string
+	subscriptions map[string]map[string]subscription
+}
+
+// NewPubSub returns Kafka message publisher/subscriber. The subscription to
+// the messages of a single channel reads the partition which holds the
+// messages of the channel, so each subscriber receives all of them. Any other
+// subscription joins the consumer group named after the queue and the topic.
+// If the queue is empty, the subscriber ID is used instead. Subscribers
+// sharing the consumer group, such as the replicas of a service, share the
+// partitions of the topic, so each message is received by one of them. New
+// consumer groups start with the messages published after they subscribed.
+// The message which failed to be handled is redelivered to the handler, and
+// its offset is committed only once it is handled or dropped.
+func NewPubSub(client Client, queue string, cfg Config, logger log.Logger) (messaging.PubSub, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	ret := &pubsub{
+		publisher: publisher{
+			writer: client.Writer(),
+		},
+		client:        client,
+		cfg:           cfg,
+		queue:         queue,
+		logger:        logger,
+		subscriptions: make(map[string]map[string]subscription),
+	}
+	return ret, nil
+}
+
+func (ps *pubsub) Subscribe(id, topic string, handler messaging.MessageHandler) error {
+	if id == "" {
+		return messaging.ErrEmptyID
+	}
+	if topic == "" {
+		return messaging.ErrEmptyTopic
+	}
+
+	ps.mu.Lock()
+	// Check topic
+	s, ok := ps.subscriptions[topic]
+	if ok {
+		// Check client ID
+		if _, ok := s[id]; ok {
+			// Unlocking, so that Unsubscribe() can access ps.subscriptions
+			ps.mu.Unlock()
+			if err := ps.Unsubscribe(id, topic); err != nil {
+				return err
+			}
+
+			ps.mu.Lock()
+			// value of s can be changed while ps.mu is unlocked
+			s = ps.subscriptions[topic]
+		}
+	}
+	defer ps.mu.Unlock()
+	if s == nil {
+		s = make(map[string]subscription)
+		ps.subscriptions[topic] = s
+	}
+
+	sub := subscription{
+		done:   make(chan struct{}),
+		cancel: handler.Cancel,
+	}
+	switch chanID, ok := channel(topic); ok {
+	case true:
+		r, err := ps.client.PartitionReader(kafkaTopic(topic), []byte(chanID))
+		if err != nil {
+			return err
+		}
+		sub.reader = r
+	default:
+		group := ps.queue
+		if group == "" {
+			group = id
+		}
+		sub.reader = ps.client.Reader(groupID(group, topic), kafkaTopic(topic))
+		sub.group = true
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	sub.stop = stop
+	go ps.consume(ctx, sub, topic, handler)
+	s[id] = sub
+
+	return nil
+}
+
+func (ps *pubsub) Unsubscribe(id, topic string) error {
+	if id == "" {
+		return messaging.ErrEmptyID
+	}
+	if topic == "" {
+		return messaging.ErrEmptyTopic
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	// Check topic
+	s, ok := ps.subscriptions[topic]
+	if !ok {
+		return messaging.ErrNotSubscribed
+	}
+	// Check topic ID
+	current, ok := s[id]
+	if !ok {
+		return messaging.ErrNotSubscribed
+	}
+	if current.cancel != nil {
+		if err := current.cancel(); err != nil {
+			return err
+		}
+	}
+	if err := current.close(); err != nil {
+		return err
+	}
+
+	delete(s, id)
+	if len(s) == 0 {
+		delete(ps.subscriptions, topic)
+	}
+	return nil
+}
+
+// Close closes the readers of all the subscriptions and the writer.
+func (ps *pubsub) Close() error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	for topic, s := range ps.subscriptions {
+		for id, sub := range s {
+			if err := sub.close(); err != nil {
+				return err
+			}
+			delete(s, id)
+		}
+		delete(ps.subscriptions, topic)
+	}
+
+	return ps.publisher.Close()
+}
+
+func (ps *pubsub) consume(ctx context.Context, sub subscription, topic string, h messaging.MessageHandler) {
+	defer close(sub.done)
+
+	for {
+		m, err := sub.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to fetch message: %s", err))
+			}
+			return
+		}
+
+		// The message which is being redelivered when the subscription is
+		// closed isn't committed, so it's fetched again by the group.
+		if match(topic, subject(m)) && !ps.handle(ctx, m, h) {
+			return
+		}
+		if !sub.group {
+			continue
+		}
+
+		if err := sub.reader.CommitMessages(ctx, m); err != nil && ctx.Err() == nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to commit message: %s", err))
+		}
+	}
+}
+
+// handle handles the message, redelivering it after the backoff until it is
+// handled or delivered the maximum number of times. It returns false if the
+// context is canceled before the message is handled or dropped.
+func (ps *pubsub) handle(ctx context.Context, m kafka.Message, h messaging.MessageHandler) bool {
+	var msg messaging.Message
+	if err := proto.Unmarshal(m.Value, &msg); err != nil {
+		ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
+		return true
+	}
+
+	for delivered := uint64(1); ; delivered++ {
+		last := delivered >= uint64(ps.cfg.MaxDeliver)
+		err := messaging.Deliver(h, msg, messaging.Delivery{Attempt: delivered, Last: last})
+		if err == nil {
+			return true
+		}
+		if last {
+			ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message, dropping it after %d deliveries: %s", delivered, err))
+			return true
+		}
+
+		ps.logger.Warn(fmt.Sprintf("Failed to handle Mainflux message, delivery %d of %d: %s", delivered, ps.cfg.MaxDeliver, err))
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(ps.backoff(delivered)):
+		}
+	}
+}
+
+// backoff returns the delay of the redelivery of the message which failed to
+// be handled the given number of times.
+func (ps *pubsub) backoff(delivered uint64) time.Duration {
+	d := ps.cfg.Backoff
+	for i := uint64(1); i < delivered && d < ps.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > ps.cfg.MaxBackoff {
+		d = ps.cfg.MaxBackoff
+	}
+
+	return d
+}
+
+// close stops consuming the messages and closes the reader.
+func (sub subscription) close() error {
+	sub.stop()
+	err := sub.reader.Close()
+	<-sub.done
+	return err
+}
+
+// kafkaTopic returns the Kafka topic of the subscribed subject.
+func kafkaTopic(subject string) string {
+	return topic(subject)
+}
+
+// channel returns the ID of the channel whose messages are the only ones
+// matching the subscribed subject.
+func channel(subject string) (string, bool) {
+	tokens := strings.Split(subject, ".")
+	if len(tokens) < 2 || tokens[0] != chansPrefix || tokens[1] == "*" || tokens[1] == ">" {
+		return "", false
+	}
+	return tokens[1], true
+}
+
+// groupID returns the ID of the consumer group of the group for the subject.
+// Each subscribed subject has its own consumer group, since the subjects of
+// the same topic are filtered by the subscribers.
+func groupID(group, subject string) string {
+	return fmt.Sprintf("%s-%s", group, subject)
+}
+
+// subject returns the subject the message was published to.
+func subject(m kafka.Message) string {
+	for _, h := range m.Headers {
+		if h.Key == subjectHeader {
+			return string(h.Value)
+		}
+	}
+	return m.Topic
+}
+
+// match reports whether the subject matches the pattern, where the '*'
+// token matches any single token and the trailing '>' token matches one or
+// more tokens.
+func match(pattern, subject string) bool {
+	pts := strings.Split(pattern, ".")
+	sts := strings.Split(subject, ".")
+
+	for i, pt := range pts {
+		if pt == ">" {
+			return i == len(pts)-1 && len(sts) > i
+		}
+		if i >= len(sts) {
+			return false
+		}
+		if pt != "*" && pt != sts[i] {
+			return false
+		}
+	}
+
+	return len(pts) == len(sts)
+}
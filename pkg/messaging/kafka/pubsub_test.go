@@ -0,0 +1,579 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka_test
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/kafka"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chansPrefix      = "channels"
+	channel          = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	subtopic         = "engine"
+	clientID         = "9b7b1b3f-b1b0-46a8-a717-b8213f9eda3b"
+	senmlContentType = "application/senml+json"
+	senmlFormat      = "senml"
+	messagesSuffix   = "messages"
+	subjectHeader    = "subject"
+	timeout          = 5 * time.Second
+)
+
+var (
+	errFailed  = errors.New("failed to handle message")
+	data       = []byte("payload")
+	msgProfile = &messaging.Profile{ContentType: senmlContentType, TimeField: &messaging.TimeField{}, Writer: &messaging.Writer{Retain: true}, Notifier: &messaging.Notifier{}}
+)
+
+func TestPublisher(t *testing.T) {
+	h := newHandler()
+	format := senmlFormat + "." + messagesSuffix
+	err := pubsub.Subscribe(clientID, fmt.Sprintf("%s.%s.%s", chansPrefix, channel, format), h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Subscribe(clientID, fmt.Sprintf("%s.%s.%s.%s", chansPrefix, channel, format, subtopic), h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		subtopic string
+		payload  []byte
+	}{
+		{
+			desc:    "publish message with nil payload",
+			payload: nil,
+		},
+		{
+			desc:    "publish message with string payload",
+			payload: data,
+		},
+		{
+			desc:     "publish message with subtopic",
+			payload:  data,
+			subtopic: subtopic,
+		},
+	}
+
+	for _, tc := range cases {
+		expectedMsg := messaging.Message{
+			Channel:  channel,
+			Subtopic: tc.subtopic,
+			Payload:  tc.payload,
+			Profile:  msgProfile,
+		}
+
+		err = publisher.Publish(expectedMsg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		receivedMsg := h.receive(t)
+		assert.Equal(t, expectedMsg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, expectedMsg, receivedMsg))
+		h.none(t, 100*time.Millisecond)
+	}
+
+	err = pubsub.Unsubscribe(clientID, fmt.Sprintf("%s.%s.%s", chansPrefix, channel, format))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	err = pubsub.Unsubscribe(clientID, fmt.Sprintf("%s.%s.%s.%s", chansPrefix, channel, format, subtopic))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+}
+
+func TestPubsub(t *testing.T) {
+	topic := fmt.Sprintf("%s.%s", chansPrefix, channel)
+
+	cases := []struct {
+		desc      string
+		topic     string
+		clientID  string
+		err       error
+		subscribe bool
+		handler   messaging.MessageHandler
+	}{
+		{
+			desc:      "subscribe to a topic with an ID",
+			topic:     topic,
+			clientID:  "clientid1",
+			err:       nil,
+			subscribe: true,
+			handler:   newHandler(),
+		},
+		{
+			desc:      "subscribe to the same topic with a different ID",
+			topic:     topic,
+			clientID:  "clientid2",
+			err:       nil,
+			subscribe: true,
+			handler:   newHandler(),
+		},
+		{
+			desc:      "subscribe to an already subscribed topic with an ID",
+			topic:     topic,
+			clientID:  "clientid1",
+			err:       nil,
+			subscribe: true,
+			handler:   newHandler(),
+		},
+		{
+			desc:      "unsubscribe from a topic with an ID",
+			topic:     topic,
+			clientID:  "clientid1",
+			err:       nil,
+			subscribe: false,
+		},
+		{
+			desc:      "unsubscribe from an already unsubscribed topic with an ID",
+			topic:     topic,
+			clientID:  "clientid1",
+			err:       messaging.ErrNotSubscribed,
+			subscribe: false,
+		},
+		{
+			desc:      "unsubscribe from a non-existent topic with an ID",
+			topic:     "h",
+			clientID:  "clientid1",
+			err:       messaging.ErrNotSubscribed,
+			subscribe: false,
+		},
+		{
+			desc:      "subscribe to an empty topic with an ID",
+			topic:     "",
+			clientID:  "clientid1",
+			err:       messaging.ErrEmptyTopic,
+			subscribe: true,
+			handler:   newHandler(),
+		},
+		{
+			desc:      "unsubscribe from an empty topic with an ID",
+			topic:     "",
+			clientID:  "clientid1",
+			err:       messaging.ErrEmptyTopic,
+			subscribe: false,
+		},
+		{
+			desc:      "subscribe to a topic with empty id",
+			topic:     topic,
+			clientID:  "",
+			err:       messaging.ErrEmptyID,
+			subscribe: true,
+			handler:   newHandler(),
+		},
+		{
+			desc:      "unsubscribe from a topic with empty id",
+			topic:     topic,
+			clientID:  "",
+			err:       messaging.ErrEmptyID,
+			subscribe: false,
+		},
+		{
+			desc:      "unsubscribe from a topic with an ID",
+			topic:     topic,
+			clientID:  "clientid2",
+			err:       nil,
+			subscribe: false,
+		},
+	}
+
+	for _, tc := range cases {
+		var err error
+		switch tc.subscribe {
+		case true:
+			err = pubsub.Subscribe(tc.clientID, tc.topic, tc.handler)
+		default:
+			err = pubsub.Unsubscribe(tc.clientID, tc.topic)
+		}
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestWildcards(t *testing.T) {
+	cases := []struct {
+		desc     string
+		topic    string
+		subtopic string
+		received bool
+	}{
+		{
+			desc:     "receive message matching single token wildcard",
+			topic:    fmt.Sprintf("%s.*.%s.%s", chansPrefix, senmlFormat, messagesSuffix),
+			received: true,
+		},
+		{
+			desc:     "receive message with subtopic matching full wildcard",
+			topic:    fmt.Sprintf("%s.%s.>", chansPrefix, channel),
+			subtopic: subtopic,
+			received: true,
+		},
+		{
+			desc:     "receive no message with subtopic not matching single token wildcard",
+			topic:    fmt.Sprintf("%s.*.%s.%s", chansPrefix, senmlFormat, messagesSuffix),
+			subtopic: subtopic,
+			received: false,
+		},
+		{
+			desc:     "receive no message of another format",
+			topic:    fmt.Sprintf("%s.*.json.>", chansPrefix),
+			received: false,
+		},
+	}
+
+	for _, tc := range cases {
+		h := newHandler()
+		err := pubsub.Subscribe(clientID, tc.topic, h)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		msg := messaging.Message{
+			Channel:  channel,
+			Subtopic: tc.subtopic,
+			Payload:  data,
+			Profile:  msgProfile,
+		}
+		err = publisher.Publish(msg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		switch tc.received {
+		case true:
+			receivedMsg := h.receive(t)
+			assert.Equal(t, msg, receivedMsg, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, msg, receivedMsg))
+		default:
+			h.none(t, 100*time.Millisecond)
+		}
+
+		err = pubsub.Unsubscribe(clientID, tc.topic)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+	}
+}
+
+func TestOrdering(t *testing.T) {
+	topic := fmt.Sprintf("%s.>", chansPrefix)
+	chans := []string{"ordering1", "ordering2", "ordering3"}
+	n := 20
+
+	h := newHandler()
+	err := pubsub.Subscribe(clientID, topic, h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := 0; i < n; i++ {
+		for _, ch := range chans {
+			msg := messaging.Message{
+				Channel: ch,
+				Payload: []byte(strconv.Itoa(i)),
+				Profile: msgProfile,
+			}
+			err := publisher.Publish(msg)
+			require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		}
+	}
+
+	received := make(map[string][]string)
+	for i := 0; i < n*len(chans); i++ {
+		msg := h.receive(t)
+		received[msg.Channel] = append(received[msg.Channel], string(msg.Payload))
+	}
+	for _, ch := range chans {
+		var expected []string
+		for i := 0; i < n; i++ {
+			expected = append(expected, strconv.Itoa(i))
+		}
+		assert.Equal(t, expected, received[ch], fmt.Sprintf("%s: expected messages in publishing order %v got %v", ch, expected, received[ch]))
+	}
+
+	partitions := make(map[string]map[int]bool)
+	for _, m := range broker.Messages(chansPrefix) {
+		ch := string(m.Key)
+		if partitions[ch] == nil {
+			partitions[ch] = make(map[int]bool)
+		}
+		partitions[ch][m.Partition] = true
+	}
+	for _, ch := range chans {
+		assert.Len(t, partitions[ch], 1, fmt.Sprintf("%s: expected messages in a single partition got %d", ch, len(partitions[ch])))
+	}
+
+	err = pubsub.Unsubscribe(clientID, topic)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+}
+
+func TestQueue(t *testing.T) {
+	topic := fmt.Sprintf("%s.>", chansPrefix)
+	n := 40
+
+	cases := []struct {
+		desc     string
+		queues   []string
+		received int
+	}{
+		{
+			desc:     "share messages among subscribers of the same queue",
+			queues:   []string{"writers", "writers"},
+			received: n,
+		},
+		{
+			desc:     "fan out messages to subscribers of different queues",
+			queues:   []string{"writers", "notifiers"},
+			received: 2 * n,
+		},
+	}
+
+	for _, tc := range cases {
+		var pss []messaging.PubSub
+		var hs []*handler
+		for i, queue := range tc.queues {
+			ps, err := kafka.NewPubSub(broker, queue, cfg, testLog)
+			require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+			h := newHandler()
+			err = ps.Subscribe(fmt.Sprintf("queue%d", i), topic, h)
+			require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+			pss = append(pss, ps)
+			hs = append(hs, h)
+		}
+
+		for i := 0; i < n; i++ {
+			msg := messaging.Message{
+				Channel: fmt.Sprintf("queue-channel%d", i),
+				Payload: data,
+				Profile: msgProfile,
+			}
+			err := publisher.Publish(msg)
+			require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		}
+
+		received := func() int {
+			var total int32
+			for _, h := range hs {
+				total += atomic.LoadInt32(&h.delivered)
+			}
+			return int(total)
+		}
+		assert.Eventually(t, func() bool { return received() == tc.received }, timeout, 10*time.Millisecond, fmt.Sprintf("%s: expected %d messages received", tc.desc, tc.received))
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, tc.received, received(), fmt.Sprintf("%s: expected %d messages received got %d", tc.desc, tc.received, received()))
+
+		for _, ps := range pss {
+			err := ps.Close()
+			require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+		}
+	}
+}
+
+func TestNewPubSub(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  kafka.Config
+		err  error
+	}{
+		{
+			desc: "create pubsub with valid config",
+			cfg:  cfg,
+			err:  nil,
+		},
+		{
+			desc: "create pubsub without deliveries",
+			cfg:  kafka.Config{MaxDeliver: 0, Backoff: cfg.Backoff, MaxBackoff: cfg.MaxBackoff},
+			err:  kafka.ErrInvalidConfig,
+		},
+		{
+			desc: "create pubsub with max backoff below backoff",
+			cfg:  kafka.Config{MaxDeliver: cfg.MaxDeliver, Backoff: cfg.MaxBackoff, MaxBackoff: cfg.Backoff},
+			err:  kafka.ErrInvalidConfig,
+		},
+	}
+
+	for _, tc := range cases {
+		ps, err := kafka.NewPubSub(broker, "", tc.cfg, testLog)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if ps != nil {
+			ps.Close()
+		}
+	}
+}
+
+func TestRedelivery(t *testing.T) {
+	topic := fmt.Sprintf("%s.>", chansPrefix)
+
+	cases := []struct {
+		desc      string
+		failures  int32
+		delivered int32
+		last      bool
+	}{
+		{
+			desc:      "redeliver message until it is handled",
+			failures:  1,
+			delivered: 2,
+			last:      false,
+		},
+		{
+			desc:      "drop message after the last delivery",
+			failures:  int32(cfg.MaxDeliver),
+			delivered: int32(cfg.MaxDeliver),
+			last:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		h := newHandler()
+		h.failures = tc.failures
+		err := pubsub.Subscribe(tc.desc, topic, h)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		msg := messaging.Message{
+			Channel: "redelivery-channel",
+			Payload: data,
+			Profile: msgProfile,
+		}
+		err = publisher.Publish(msg)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+
+		assert.Eventually(t, func() bool { return atomic.LoadInt32(&h.delivered) == tc.delivered }, timeout, 10*time.Millisecond, fmt.Sprintf("%s: expected %d deliveries", tc.desc, tc.delivered))
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, tc.delivered, atomic.LoadInt32(&h.delivered), fmt.Sprintf("%s: expected %d deliveries got %d", tc.desc, tc.delivered, atomic.LoadInt32(&h.delivered)))
+		assert.Equal(t, tc.last, h.lastFailed(), fmt.Sprintf("%s: expected last failed delivery %t", tc.desc, tc.last))
+
+		err = pubsub.Unsubscribe(tc.desc, topic)
+		require.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s", tc.desc, err))
+	}
+}
+
+func TestUncommittedRedelivery(t *testing.T) {
+	topic := fmt.Sprintf("%s.>", chansPrefix)
+	queue := "uncommitted"
+	slow := kafka.Config{MaxDeliver: 2, Backoff: time.Hour, MaxBackoff: time.Hour}
+
+	ps, err := kafka.NewPubSub(broker, queue, slow, testLog)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	h := newHandler()
+	h.failures = 1
+	err = ps.Subscribe(queue, topic, h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	msg := messaging.Message{
+		Channel: "uncommitted-channel",
+		Payload: data,
+		Profile: msgProfile,
+	}
+	err = publisher.Publish(msg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&h.delivered) == 1 }, timeout, 10*time.Millisecond, "expected failed delivery")
+
+	err = ps.Close()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	ps, err = kafka.NewPubSub(broker, queue, cfg, testLog)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	defer ps.Close()
+	h = newHandler()
+	err = ps.Subscribe(queue, topic, h)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	received := h.receive(t)
+	assert.Equal(t, msg, received, fmt.Sprintf("expected uncommitted message %+v got %+v", msg, received))
+}
+
+func TestChannelSubscribers(t *testing.T) {
+	topic := fmt.Sprintf("%s.%s.>", chansPrefix, channel)
+	queue := "adapter"
+
+	var hs []*handler
+	for i := 0; i < 2; i++ {
+		ps, err := kafka.NewPubSub(broker, queue, cfg, testLog)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		defer ps.Close()
+		h := newHandler()
+		err = ps.Subscribe(fmt.Sprintf("subscriber%d", i), topic, h)
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		hs = append(hs, h)
+	}
+
+	msg := messaging.Message{
+		Channel: channel,
+		Payload: data,
+		Profile: msgProfile,
+	}
+	err := publisher.Publish(msg)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i, h := range hs {
+		received := h.receive(t)
+		assert.Equal(t, msg, received, fmt.Sprintf("subscriber %d: expected %+v got %+v", i, msg, received))
+	}
+}
+
+func TestPublishDeadLetter(t *testing.T) {
+	consumer := "writer"
+	pub := kafka.NewDeadLetterPublisher(broker)
+	defer pub.Close()
+
+	err := pub.PublishDeadLetter(consumer, data)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s", err))
+
+	msgs := broker.Messages(messaging.DeadLetterPrefix)
+	require.Len(t, msgs, 1, fmt.Sprintf("expected 1 dead letter got %d", len(msgs)))
+	assert.Equal(t, consumer, string(msgs[0].Key), fmt.Sprintf("expected key %s got %s", consumer, msgs[0].Key))
+	assert.Equal(t, data, msgs[0].Value, fmt.Sprintf("expected value %s got %s", data, msgs[0].Value))
+	require.Len(t, msgs[0].Headers, 1, fmt.Sprintf("expected 1 header got %d", len(msgs[0].Headers)))
+	assert.Equal(t, subjectHeader, msgs[0].Headers[0].Key, fmt.Sprintf("expected header %s got %s", subjectHeader, msgs[0].Headers[0].Key))
+	subject := messaging.DeadLetterSubject(consumer)
+	assert.Equal(t, subject, string(msgs[0].Headers[0].Value), fmt.Sprintf("expected subject %s got %s", subject, msgs[0].Headers[0].Value))
+}
+
+type handler struct {
+	delivered int32
+	// failures is the number of deliveries failed by the handler.
+	failures int32
+	last     int32
+	msgs     chan messaging.Message
+}
+
+func newHandler() *handler {
+	return &handler{
+		msgs: make(chan messaging.Message, 100),
+	}
+}
+
+func (h *handler) Handle(msg messaging.Message) error {
+	return h.HandleDelivery(msg, messaging.Delivery{Attempt: 1, Last: true})
+}
+
+func (h *handler) HandleDelivery(msg messaging.Message, d messaging.Delivery) error {
+	if n := atomic.AddInt32(&h.delivered, 1); n <= atomic.LoadInt32(&h.failures) {
+		if d.Last {
+			atomic.StoreInt32(&h.last, 1)
+		}
+		return errFailed
+	}
+	h.msgs <- msg
+	return nil
+}
+
+func (h *handler) lastFailed() bool {
+	return atomic.LoadInt32(&h.last) == 1
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
+
+func (h *handler) receive(t *testing.T) messaging.Message {
+	select {
+	case msg := <-h.msgs:
+		return msg
+	case <-time.After(timeout):
+		require.Fail(t, "message not received")
+		return messaging.Message{}
+	}
+}
+
+func (h *handler) none(t *testing.T, d time.Duration) {
+	select {
+	case msg := <-h.msgs:
+		assert.Fail(t, fmt.Sprintf("unexpected message received: %+v", msg))
+	case <-time.After(d):
+	}
+}
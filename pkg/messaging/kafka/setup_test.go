@@ -0,0 +1,55 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package kafka_test
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/kafka"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging/kafka/mocks"
+)
+
+const partitions = 4
+
+var cfg = kafka.Config{
+	MaxDeliver: 3,
+	Backoff:    10 * time.Millisecond,
+	MaxBackoff: 40 * time.Millisecond,
+}
+
+var (
+	broker    *mocks.Broker
+	publisher messaging.Publisher
+	pubsub    messaging.PubSub
+	testLog   logger.Logger
+)
+
+func TestMain(m *testing.M) {
+	var err error
+	if testLog, err = logger.New(os.Stdout, "error"); err != nil {
+		log.Fatalf(err.Error())
+	}
+
+	broker = mocks.NewBroker(partitions)
+	publisher = kafka.NewPublisher(broker)
+	if pubsub, err = kafka.NewPubSub(broker, "", cfg, testLog); err != nil {
+		log.Fatalf("Could not create pubsub: %s", err)
+	}
+
+	code := m.Run()
+
+	if err := pubsub.Close(); err != nil {
+		log.Fatalf("Could not close pubsub: %s", err)
+	}
+	if err := publisher.Close(); err != nil {
+		log.Fatalf("Could not close publisher: %s", err)
+	}
+
+	os.Exit(code)
+}
@@ -4,6 +4,7 @@
 package messaging
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	io "io"
@@ -123,6 +124,7 @@ type Profile struct {
 	TimeField            *TimeField `protobuf:"bytes,2,opt,name=timeField,proto3" json:"timeField,omitempty"`
 	Writer               *Writer    `protobuf:"bytes,3,opt,name=writer,proto3" json:"writer,omitempty"`
 	Notifier             *Notifier  `protobuf:"bytes,4,opt,name=notifier,proto3" json:"notifier,omitempty"`
+	RateLimit            *RateLimit `protobuf:"bytes,5,opt,name=rateLimit,proto3" json:"rateLimit,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
 	XXX_unrecognized     []byte     `json:"-"`
 	XXX_sizecache        int32      `json:"-"`
@@ -189,9 +191,17 @@ func (m *Profile) GetNotifier() *Notifier {
 	return nil
 }
 
+func (m *Profile) GetRateLimit() *RateLimit {
+	if m != nil {
+		return m.RateLimit
+	}
+	return nil
+}
+
 type Writer struct {
 	Retain               bool     `protobuf:"varint,3,opt,name=retain,proto3" json:"retain,omitempty"`
 	Subtopics            []string `protobuf:"bytes,2,rep,name=subtopics,proto3" json:"subtopics,omitempty"`
+	Retention            string   `protobuf:"bytes,4,opt,name=retention,proto3" json:"retention,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -244,6 +254,13 @@ func (m *Writer) GetSubtopics() []string {
 	return nil
 }
 
+func (m *Writer) GetRetention() string {
+	if m != nil {
+		return m.Retention
+	}
+	return ""
+}
+
 type TimeField struct {
 	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Format               string   `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
@@ -370,43 +387,160 @@ func (m *Notifier) GetContacts() []string {
 	return nil
 }
 
+type RateLimit struct {
+	Thing                *Limit   `protobuf:"bytes,1,opt,name=thing,proto3" json:"thing,omitempty"`
+	Channel              *Limit   `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RateLimit) Reset()         { *m = RateLimit{} }
+func (m *RateLimit) String() string { return proto.CompactTextString(m) }
+func (*RateLimit) ProtoMessage()    {}
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e5e29d24c44e4762, []int{5}
+}
+func (m *RateLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RateLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RateLimit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RateLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RateLimit.Merge(m, src)
+}
+func (m *RateLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *RateLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RateLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RateLimit proto.InternalMessageInfo
+
+func (m *RateLimit) GetThing() *Limit {
+	if m != nil {
+		return m.Thing
+	}
+	return nil
+}
+
+func (m *RateLimit) GetChannel() *Limit {
+	if m != nil {
+		return m.Channel
+	}
+	return nil
+}
+
+type Limit struct {
+	Rate                 float64  `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	Burst                uint32   `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Limit) Reset()         { *m = Limit{} }
+func (m *Limit) String() string { return proto.CompactTextString(m) }
+func (*Limit) ProtoMessage()    {}
+func (*Limit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e5e29d24c44e4762, []int{6}
+}
+func (m *Limit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Limit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Limit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Limit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Limit.Merge(m, src)
+}
+func (m *Limit) XXX_Size() int {
+	return m.Size()
+}
+func (m *Limit) XXX_DiscardUnknown() {
+	xxx_messageInfo_Limit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Limit proto.InternalMessageInfo
+
+func (m *Limit) GetRate() float64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *Limit) GetBurst() uint32 {
+	if m != nil {
+		return m.Burst
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Message)(nil), "messaging.Message")
 	proto.RegisterType((*Profile)(nil), "messaging.Profile")
 	proto.RegisterType((*Writer)(nil), "messaging.Writer")
 	proto.RegisterType((*TimeField)(nil), "messaging.TimeField")
 	proto.RegisterType((*Notifier)(nil), "messaging.Notifier")
+	proto.RegisterType((*RateLimit)(nil), "messaging.RateLimit")
+	proto.RegisterType((*Limit)(nil), "messaging.Limit")
 }
 
 func init() { proto.RegisterFile("pkg/messaging/message.proto", fileDescriptor_e5e29d24c44e4762) }
 
 var fileDescriptor_e5e29d24c44e4762 = []byte{
-	// 396 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x51, 0x3d, 0x8f, 0xd4, 0x30,
-	0x10, 0xc5, 0x97, 0x23, 0x1f, 0x13, 0x0a, 0x30, 0x08, 0x59, 0x80, 0xa2, 0x28, 0x55, 0x90, 0xd0,
-	0x9e, 0x14, 0x7a, 0x0a, 0x0a, 0x3a, 0x10, 0x32, 0x27, 0xd1, 0xe2, 0xcd, 0x7a, 0xef, 0x2c, 0x12,
-	0x3b, 0x72, 0xbc, 0x42, 0xfb, 0x4f, 0xf8, 0x2f, 0xfc, 0x01, 0x4a, 0x7a, 0x1a, 0xb4, 0xfc, 0x11,
-	0x64, 0xc7, 0xf6, 0x06, 0x0a, 0x3a, 0xbf, 0x79, 0x6f, 0xc6, 0xf3, 0xe6, 0xc1, 0xd3, 0xe9, 0xf3,
-	0xcd, 0xd5, 0xc8, 0xe7, 0x99, 0xdd, 0x08, 0x19, 0x5e, 0x7c, 0x33, 0x69, 0x65, 0x14, 0x2e, 0x22,
-	0xd1, 0xfc, 0x44, 0x90, 0xbd, 0x5d, 0x48, 0x4c, 0x20, 0xeb, 0x6f, 0x99, 0x94, 0x7c, 0x20, 0xa8,
-	0x46, 0x6d, 0x41, 0x03, 0xc4, 0x4f, 0x20, 0x9f, 0x0f, 0x5b, 0xa3, 0x26, 0xd1, 0x93, 0x0b, 0x47,
-	0x45, 0x8c, 0x9f, 0x41, 0x31, 0x1d, 0xb6, 0x83, 0x98, 0x6f, 0xb9, 0x26, 0x89, 0x23, 0xcf, 0x05,
-	0xdb, 0xe9, 0xfe, 0xec, 0xd5, 0x40, 0x2e, 0x97, 0xce, 0x80, 0xed, 0x7f, 0x13, 0x3b, 0x0e, 0x8a,
-	0xed, 0xc8, 0xdd, 0x1a, 0xb5, 0xf7, 0x68, 0x80, 0x6e, 0x13, 0xcd, 0x99, 0xe1, 0x3b, 0x92, 0xd6,
-	0xa8, 0x4d, 0x68, 0x80, 0xf8, 0x05, 0x64, 0x93, 0x56, 0x7b, 0x31, 0x70, 0x92, 0xd5, 0xa8, 0x2d,
-	0x3b, 0xbc, 0x89, 0x66, 0x36, 0xef, 0x17, 0x86, 0x06, 0x49, 0xf3, 0x0d, 0x41, 0xe6, 0x8b, 0xb8,
-	0x86, 0xb2, 0x57, 0xd2, 0x70, 0x69, 0xae, 0x8f, 0x13, 0xf7, 0x0e, 0xd7, 0x25, 0xdc, 0x41, 0x61,
-	0xc4, 0xc8, 0xdf, 0x08, 0x3e, 0xec, 0x9c, 0xcd, 0xb2, 0x7b, 0xb4, 0x9a, 0x7e, 0x1d, 0x38, 0x7a,
-	0x96, 0xe1, 0xe7, 0x90, 0x7e, 0xd1, 0xc2, 0x78, 0xeb, 0x65, 0xf7, 0x60, 0xd5, 0xf0, 0xd1, 0x11,
-	0xd4, 0x0b, 0xf0, 0x15, 0xe4, 0x52, 0x19, 0xb1, 0x17, 0x5c, 0xbb, 0x53, 0x94, 0xdd, 0xc3, 0x95,
-	0xf8, 0x9d, 0xa7, 0x68, 0x14, 0x35, 0xaf, 0x20, 0x5d, 0x46, 0xe0, 0xc7, 0x90, 0x6a, 0x6e, 0x98,
-	0x90, 0xee, 0x97, 0x9c, 0x7a, 0x64, 0x6f, 0x1f, 0x72, 0x98, 0xc9, 0x45, 0x9d, 0xd8, 0xdb, 0xc7,
-	0x42, 0xf3, 0x01, 0x8a, 0xb8, 0x33, 0xc6, 0x70, 0x29, 0xd9, 0x18, 0x7c, 0xbb, 0xb7, 0x1d, 0xbb,
-	0x57, 0x7a, 0x64, 0xc6, 0x87, 0xea, 0x91, 0x0d, 0x6d, 0x50, 0x3d, 0x33, 0x42, 0x49, 0x9f, 0x68,
-	0xc4, 0xcd, 0x27, 0xc8, 0xc3, 0xaa, 0x7f, 0x85, 0x8b, 0xfe, 0x09, 0xf7, 0xbf, 0xab, 0xd9, 0x4e,
-	0x7b, 0x79, 0xd6, 0x9b, 0x99, 0x24, 0x8e, 0x8c, 0xf8, 0xf5, 0xfd, 0xef, 0xa7, 0x0a, 0xfd, 0x38,
-	0x55, 0xe8, 0xd7, 0xa9, 0x42, 0x5f, 0x7f, 0x57, 0x77, 0xb6, 0xa9, 0x9b, 0xfa, 0xf2, 0x4f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xbc, 0xd2, 0x05, 0x61, 0xd5, 0x02, 0x00, 0x00,
+	// 480 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xcd, 0xaa, 0xd4, 0x30,
+	0x14, 0x36, 0xf3, 0xd7, 0xf6, 0x54, 0xe1, 0x1a, 0x2f, 0x12, 0xae, 0x32, 0x94, 0x2e, 0x64, 0x14,
+	0x99, 0x8b, 0xf5, 0x0d, 0x5c, 0xb8, 0x52, 0x91, 0x78, 0xc1, 0x8d, 0xa0, 0x99, 0x4e, 0x66, 0x26,
+	0xd8, 0x26, 0x25, 0xcd, 0x20, 0xf7, 0x4d, 0x7c, 0x24, 0x97, 0xee, 0xdd, 0xc8, 0xf8, 0x00, 0xbe,
+	0x82, 0x24, 0x69, 0xda, 0xaa, 0xe8, 0xae, 0xdf, 0xf9, 0xbe, 0x9c, 0x9f, 0xef, 0x9c, 0xc2, 0xbd,
+	0xe6, 0xe3, 0xfe, 0xb2, 0xe6, 0x6d, 0xcb, 0xf6, 0x42, 0x86, 0x2f, 0xbe, 0x6e, 0xb4, 0x32, 0x0a,
+	0x27, 0x3d, 0x91, 0x7f, 0x43, 0x10, 0xbd, 0xf4, 0x24, 0x26, 0x10, 0x95, 0x07, 0x26, 0x25, 0xaf,
+	0x08, 0xca, 0xd0, 0x2a, 0xa1, 0x01, 0xe2, 0x0b, 0x88, 0xdb, 0xe3, 0xc6, 0xa8, 0x46, 0x94, 0x64,
+	0xe2, 0xa8, 0x1e, 0xe3, 0xfb, 0x90, 0x34, 0xc7, 0x4d, 0x25, 0xda, 0x03, 0xd7, 0x64, 0xea, 0xc8,
+	0x21, 0x60, 0x5f, 0xba, 0x9a, 0xa5, 0xaa, 0xc8, 0xcc, 0xbf, 0x0c, 0xd8, 0xd6, 0x6b, 0xd8, 0x75,
+	0xa5, 0xd8, 0x96, 0xcc, 0x33, 0xb4, 0xba, 0x49, 0x03, 0x74, 0x9d, 0x68, 0xce, 0x0c, 0xdf, 0x92,
+	0x45, 0x86, 0x56, 0x53, 0x1a, 0x20, 0x7e, 0x0c, 0x51, 0xa3, 0xd5, 0x4e, 0x54, 0x9c, 0x44, 0x19,
+	0x5a, 0xa5, 0x05, 0x5e, 0xf7, 0xc3, 0xac, 0x5f, 0x7b, 0x86, 0x06, 0x49, 0xfe, 0x13, 0x41, 0xd4,
+	0x05, 0x71, 0x06, 0x69, 0xa9, 0xa4, 0xe1, 0xd2, 0x5c, 0x5d, 0x37, 0xbc, 0x9b, 0x70, 0x1c, 0xc2,
+	0x05, 0x24, 0x46, 0xd4, 0xfc, 0xb9, 0xe0, 0xd5, 0xd6, 0x8d, 0x99, 0x16, 0xe7, 0xa3, 0xec, 0x57,
+	0x81, 0xa3, 0x83, 0x0c, 0x3f, 0x84, 0xc5, 0x27, 0x2d, 0x4c, 0x37, 0x7a, 0x5a, 0xdc, 0x1e, 0x3d,
+	0x78, 0xeb, 0x08, 0xda, 0x09, 0xf0, 0x25, 0xc4, 0x52, 0x19, 0xb1, 0x13, 0x5c, 0x3b, 0x2b, 0xd2,
+	0xe2, 0xce, 0x48, 0xfc, 0xaa, 0xa3, 0x68, 0x2f, 0xb2, 0xfd, 0x68, 0x66, 0xf8, 0x0b, 0x51, 0x0b,
+	0x43, 0xe6, 0x7f, 0xf5, 0x43, 0x03, 0x47, 0x07, 0x59, 0xfe, 0x0e, 0x16, 0xbe, 0x2c, 0xbe, 0x0b,
+	0x0b, 0xcd, 0x0d, 0x13, 0xd2, 0x75, 0x16, 0xd3, 0x0e, 0xd9, 0x7d, 0x85, 0xdd, 0xb5, 0x64, 0x92,
+	0x4d, 0xed, 0xbe, 0xfa, 0x80, 0x65, 0x35, 0xb7, 0x8e, 0x08, 0x25, 0xbb, 0x85, 0x0d, 0x81, 0xfc,
+	0x0d, 0x24, 0xbd, 0x0b, 0x18, 0xc3, 0x4c, 0xb2, 0x3a, 0x38, 0xe9, 0xbe, 0x6d, 0xd1, 0x9d, 0xd2,
+	0x35, 0x33, 0xdd, 0x99, 0x74, 0xc8, 0x9e, 0x41, 0xa5, 0x4a, 0xe6, 0xb2, 0xfa, 0x1b, 0xe9, 0x71,
+	0xfe, 0x01, 0xe2, 0x30, 0xfc, 0x6f, 0xe7, 0x82, 0xfe, 0x38, 0x97, 0xff, 0x37, 0x7e, 0x01, 0xb1,
+	0xdd, 0x25, 0x2b, 0x4d, 0x4b, 0xa6, 0x8e, 0xec, 0x71, 0xfe, 0x1e, 0x92, 0xde, 0x2c, 0xfc, 0x00,
+	0xe6, 0xe6, 0x20, 0xe4, 0xde, 0xe5, 0x4f, 0x8b, 0xb3, 0x91, 0xa3, 0xde, 0x4d, 0x4f, 0xe3, 0x47,
+	0xc3, 0xdf, 0x30, 0xf9, 0x87, 0x32, 0x08, 0xf2, 0x27, 0x30, 0xf7, 0xc9, 0x31, 0xcc, 0xec, 0x2e,
+	0x5c, 0x6e, 0x44, 0xdd, 0x37, 0x3e, 0x87, 0xf9, 0xe6, 0xa8, 0x5b, 0x6f, 0xc9, 0x2d, 0xea, 0xc1,
+	0xb3, 0xb3, 0x2f, 0xa7, 0x25, 0xfa, 0x7a, 0x5a, 0xa2, 0xef, 0xa7, 0x25, 0xfa, 0xfc, 0x63, 0x79,
+	0x63, 0xb3, 0x70, 0x93, 0x3e, 0xfd, 0x35, 0x00, 0x76, 0xd3, 0x85, 0xdb, 0xbb, 0x03, 0x00, 0x00,
 }
 
 func (m *Message) Marshal() (dAtA []byte, err error) {
@@ -512,6 +646,18 @@ func (m *Profile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.RateLimit != nil {
+		{
+			size, err := m.RateLimit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintMessage(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.Notifier != nil {
 		{
 			size, err := m.Notifier.MarshalToSizedBuffer(dAtA[:i])
@@ -582,6 +728,13 @@ func (m *Writer) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Retention) > 0 {
+		i -= len(m.Retention)
+		copy(dAtA[i:], m.Retention)
+		i = encodeVarintMessage(dAtA, i, uint64(len(m.Retention)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.Retain {
 		i--
 		if m.Retain {
@@ -704,6 +857,95 @@ func (m *Notifier) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RateLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RateLimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RateLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Channel != nil {
+		{
+			size, err := m.Channel.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintMessage(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Thing != nil {
+		{
+			size, err := m.Thing.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintMessage(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Limit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Limit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Limit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Burst != 0 {
+		i = encodeVarintMessage(dAtA, i, uint64(m.Burst))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Rate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Rate))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintMessage(dAtA []byte, offset int, v uint64) int {
 	offset -= sovMessage(v)
 	base := offset
@@ -776,6 +1018,10 @@ func (m *Profile) Size() (n int) {
 		l = m.Notifier.Size()
 		n += 1 + l + sovMessage(uint64(l))
 	}
+	if m.RateLimit != nil {
+		l = m.RateLimit.Size()
+		n += 1 + l + sovMessage(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -797,6 +1043,10 @@ func (m *Writer) Size() (n int) {
 	if m.Retain {
 		n += 2
 	}
+	l = len(m.Retention)
+	if l > 0 {
+		n += 1 + l + sovMessage(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -855,6 +1105,44 @@ func (m *Notifier) Size() (n int) {
 	return n
 }
 
+func (m *RateLimit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Thing != nil {
+		l = m.Thing.Size()
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.Channel != nil {
+		l = m.Channel.Size()
+		n += 1 + l + sovMessage(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Limit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Rate != 0 {
+		n += 9
+	}
+	if m.Burst != 0 {
+		n += 1 + sovMessage(uint64(m.Burst))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovMessage(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1298,6 +1586,42 @@ func (m *Profile) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RateLimit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RateLimit == nil {
+				m.RateLimit = &RateLimit{}
+			}
+			if err := m.RateLimit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
@@ -1401,6 +1725,38 @@ func (m *Writer) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Retain = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Retention", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Retention = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMessage(dAtA[iNdEx:])
@@ -1717,6 +2073,210 @@ func (m *Notifier) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RateLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RateLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RateLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Thing", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Thing == nil {
+				m.Thing = &Limit{}
+			}
+			if err := m.Thing.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMessage
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Channel == nil {
+				m.Channel = &Limit{}
+			}
+			if err := m.Channel.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Limit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowMessage
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Limit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Limit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rate", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Rate = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Burst", wireType)
+			}
+			m.Burst = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMessage
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Burst |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipMessage(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthMessage
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipMessage(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -37,7 +37,22 @@ func (pub publisher) Publish(msg messaging.Message) error {
 	if err != nil {
 		return err
 	}
-	token := pub.client.Publish(msg.Subtopic, qos, false, data)
+	topics := []string{msg.Subtopic}
+	if sub := messaging.NotifierSubject(msg); sub != "" {
+		topics = append(topics, sub)
+	}
+
+	for _, topic := range topics {
+		if err := pub.publish(topic, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pub publisher) publish(topic string, data []byte) error {
+	token := pub.client.Publish(topic, qos, false, data)
 	if token.Error() != nil {
 		return token.Error()
 	}
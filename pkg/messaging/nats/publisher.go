@@ -17,11 +17,12 @@ import (
 const (
 	maxReconnects  = -1
 	messagesSuffix = "messages"
-	subjectSMTP    = "smtp"
-	subjectSMPP    = "smpp"
 )
 
-var _ messaging.Publisher = (*publisher)(nil)
+var (
+	_ messaging.Publisher           = (*publisher)(nil)
+	_ messaging.DeadLetterPublisher = (*publisher)(nil)
+)
 
 type publisher struct {
 	conn *broker.Conn
@@ -41,6 +42,16 @@ func NewPublisher(url string) (messaging.Publisher, error) {
 	}
 	return ret, nil
 }
+
+// NewDeadLetterPublisher returns NATS dead-letter publisher.
+func NewDeadLetterPublisher(url string) (messaging.DeadLetterPublisher, error) {
+	conn, err := broker.Connect(url, broker.MaxReconnects(maxReconnects))
+	if err != nil {
+		return nil, err
+	}
+	return &publisher{conn: conn}, nil
+}
+
 func (pub *publisher) Publish(msg messaging.Message) (err error) {
 	format, err := getFormat(msg.Profile.ContentType)
 	if err != nil {
@@ -71,14 +82,8 @@ func (pub *publisher) Publish(msg messaging.Message) (err error) {
 		}
 	}
 
-	if msg.Profile.Notifier != nil &&
-		(msg.Profile.Notifier.Protocol == subjectSMTP || msg.Profile.Notifier.Protocol == subjectSMPP) {
-		sub := msg.Profile.Notifier.Protocol
-		for _, subtopic := range msg.Profile.Notifier.Subtopics {
-			if subtopic == msg.Subtopic {
-				subjects = append(subjects, sub)
-			}
-		}
+	if sub := messaging.NotifierSubject(msg); sub != "" {
+		subjects = append(subjects, sub)
 	}
 
 	for _, subject := range subjects {
@@ -90,6 +95,10 @@ func (pub *publisher) Publish(msg messaging.Message) (err error) {
 	return nil
 }
 
+func (pub *publisher) PublishDeadLetter(consumer string, data []byte) error {
+	return pub.conn.Publish(messaging.DeadLetterSubject(consumer), data)
+}
+
 func (pub *publisher) Close() error {
 	pub.conn.Close()
 	return nil
@@ -5,6 +5,7 @@ package messaging
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
@@ -21,9 +22,22 @@ const (
 	JsonFormat       = "json"
 	CborFormat       = "cbor"
 	regExParts       = 2
+
+	// DeadLetterPrefix is the prefix of the dead-letter subjects.
+	DeadLetterPrefix = "deadletters"
+)
+
+// Notifier protocols supported by the message brokers.
+const (
+	SMTPProtocol    = "smtp"
+	SMPPProtocol    = "smpp"
+	WebhookProtocol = "webhook"
 )
 
-var subtopicRegExp = regexp.MustCompile(`(?:^/channels/[\w\-]+)?/messages(/[^?]*)?(\?.*)?$`)
+var (
+	subtopicRegExp = regexp.MustCompile(`(?:^/channels/[\w\-]+)?/messages(/[^?]*)?(\?.*)?$`)
+	channelRegExp  = regexp.MustCompile(`^/?channels/([\w\-]+)/messages(/[^?]*)?(\?.*)?$`)
+)
 
 var (
 	// ErrConnect indicates that connection to MQTT broker failed
@@ -50,6 +64,9 @@ var (
 	// ErrMalformedSubtopic indicates that the subtopic is malformed.
 	ErrMalformedSubtopic = errors.New("malformed subtopic")
 
+	// ErrMalformedTopic indicates that the topic is malformed.
+	ErrMalformedTopic = errors.New("malformed topic")
+
 	// ErrEmptyID indicates the absence of ID.
 	ErrEmptyID = errors.New("empty ID")
 
@@ -75,6 +92,35 @@ type MessageHandler interface {
 	Cancel() error
 }
 
+// Delivery describes the delivery of the message by the broker which
+// redelivers the messages that failed to be handled.
+type Delivery struct {
+	// Attempt is the number of the delivery of the message, starting from 1.
+	Attempt uint64
+
+	// Last reports whether the message is dropped if it fails to be handled.
+	Last bool
+}
+
+// DeliveryHandler represents Message handler which is informed about the
+// delivery of the message. The brokers which redeliver the messages that
+// failed to be handled call HandleDelivery instead of Handle.
+type DeliveryHandler interface {
+	MessageHandler
+
+	// HandleDelivery handles the message of the given delivery.
+	HandleDelivery(msg Message, d Delivery) error
+}
+
+// Deliver hands the message of the given delivery over to the handler, using
+// HandleDelivery if the handler is a DeliveryHandler.
+func Deliver(h MessageHandler, msg Message, d Delivery) error {
+	if dh, ok := h.(DeliveryHandler); ok {
+		return dh.HandleDelivery(msg, d)
+	}
+	return h.Handle(msg)
+}
+
 // Subscriber specifies message subscription API.
 type Subscriber interface {
 	// Subscribe subscribes to the message stream and consumes messages.
@@ -88,6 +134,37 @@ type Subscriber interface {
 	Close() error
 }
 
+// DeadLetterPublisher specifies the API for publishing the messages which the
+// consumers failed to handle to the dead-letter subjects.
+type DeadLetterPublisher interface {
+	// PublishDeadLetter publishes the encoded dead letter to the dead-letter
+	// subject of the consumer.
+	PublishDeadLetter(consumer string, data []byte) error
+
+	// Close gracefully closes dead-letter publisher's connection.
+	Close() error
+}
+
+// StoredDeadLetter represents the encoded dead letter kept by the broker.
+type StoredDeadLetter struct {
+	Seq  uint64
+	Data []byte
+}
+
+// DeadLetterStore specifies the API of the dead-letter publisher which keeps
+// the published dead letters, so they are shared by the replicas of the
+// consumer and survive their restarts.
+type DeadLetterStore interface {
+	DeadLetterPublisher
+
+	// RetrieveDeadLetters retrieves the kept dead letters of the consumer,
+	// oldest first.
+	RetrieveDeadLetters(consumer string) ([]StoredDeadLetter, error)
+
+	// RemoveDeadLetter removes the kept dead letter with the given sequence.
+	RemoveDeadLetter(seq uint64) error
+}
+
 // PubSub  represents aggregation interface for publisher and subscriber.
 type PubSub interface {
 	Publisher
@@ -123,6 +200,7 @@ func CreateMessage(conn *mainflux.ConnByKeyRes, protocol, subject string, payloa
 		msg.Profile.Writer = &Writer{
 			Retain:    conn.Profile.Writer.Retain,
 			Subtopics: conn.Profile.Writer.Subtopics,
+			Retention: conn.Profile.Writer.Retention,
 		}
 	}
 
@@ -134,6 +212,13 @@ func CreateMessage(conn *mainflux.ConnByKeyRes, protocol, subject string, payloa
 		}
 	}
 
+	if rl := conn.Profile.RateLimit; rl != nil {
+		msg.Profile.RateLimit = &RateLimit{
+			Thing:   limit(rl.Thing),
+			Channel: limit(rl.Channel),
+		}
+	}
+
 	if conn.Profile.TimeField != nil && conn.Profile.ContentType == JsonContentType {
 		msg.Profile.TimeField = &TimeField{
 			Name:     conn.Profile.TimeField.Name,
@@ -145,6 +230,53 @@ func CreateMessage(conn *mainflux.ConnByKeyRes, protocol, subject string, payloa
 	return msg
 }
 
+func limit(l *mainflux.Limit) *Limit {
+	if l == nil {
+		return nil
+	}
+	return &Limit{Rate: l.Rate, Burst: l.Burst}
+}
+
+// NotifierSubject returns the subject the message has to be forwarded to in
+// order to be delivered by the notifier specified in the message profile.
+// An empty string is returned if the message shouldn't be forwarded.
+func NotifierSubject(msg Message) string {
+	if msg.Profile == nil || msg.Profile.Notifier == nil {
+		return ""
+	}
+
+	switch msg.Profile.Notifier.Protocol {
+	case SMTPProtocol, SMPPProtocol, WebhookProtocol:
+	default:
+		return ""
+	}
+
+	for _, subtopic := range msg.Profile.Notifier.Subtopics {
+		if subtopic == msg.Subtopic {
+			return msg.Profile.Notifier.Protocol
+		}
+	}
+
+	return ""
+}
+
+// DeadLetterSubject returns the subject the dead letters of the consumer are
+// published to.
+func DeadLetterSubject(consumer string) string {
+	return fmt.Sprintf("%s.%s", DeadLetterPrefix, consumer)
+}
+
+// ExtractChannel extracts channel ID from the topic or path in the format
+// channels/<channel_id>/messages/<subtopic>/...
+func ExtractChannel(path string) (string, error) {
+	channelParts := channelRegExp.FindStringSubmatch(path)
+	if len(channelParts) < regExParts {
+		return "", ErrMalformedTopic
+	}
+
+	return channelParts[1], nil
+}
+
 func ExtractSubtopic(path string) (string, error) {
 	subtopicParts := subtopicRegExp.FindStringSubmatch(path)
 	if len(subtopicParts) < regExParts {
@@ -13,7 +13,10 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-var _ messaging.Publisher = (*publisher)(nil)
+var (
+	_ messaging.Publisher           = (*publisher)(nil)
+	_ messaging.DeadLetterPublisher = (*publisher)(nil)
+)
 
 type publisher struct {
 	conn *amqp.Connection
@@ -22,6 +25,23 @@ type publisher struct {
 
 // NewPublisher returns RabbitMQ message Publisher.
 func NewPublisher(url string) (messaging.Publisher, error) {
+	pub, err := newPublisher(url)
+	if err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+// NewDeadLetterPublisher returns RabbitMQ dead-letter publisher.
+func NewDeadLetterPublisher(url string) (messaging.DeadLetterPublisher, error) {
+	pub, err := newPublisher(url)
+	if err != nil {
+		return nil, err
+	}
+	return pub, nil
+}
+
+func newPublisher(url string) (*publisher, error) {
 	conn, err := amqp.Dial(url)
 	if err != nil {
 		return nil, err
@@ -50,26 +70,46 @@ func (pub *publisher) Publish(msg messaging.Message) error {
 	if msg.Subtopic != "" {
 		subject = fmt.Sprintf("%s.%s", subject, msg.Subtopic)
 	}
-	subject = formatTopic(subject)
+	subjects := []string{formatTopic(subject)}
+	if sub := messaging.NotifierSubject(msg); sub != "" {
+		subjects = append(subjects, sub)
+	}
 
-	err = pub.ch.PublishWithContext(
+	for _, subject := range subjects {
+		err = pub.ch.PublishWithContext(
+			context.Background(),
+			exchangeName,
+			subject,
+			false,
+			false,
+			amqp.Publishing{
+				Headers:     amqp.Table{},
+				ContentType: "application/octet-stream",
+				AppId:       "mainflux-publisher",
+				Body:        data,
+			})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pub *publisher) PublishDeadLetter(consumer string, data []byte) error {
+	return pub.ch.PublishWithContext(
 		context.Background(),
 		exchangeName,
-		subject,
+		messaging.DeadLetterSubject(consumer),
 		false,
 		false,
 		amqp.Publishing{
 			Headers:     amqp.Table{},
-			ContentType: "application/octet-stream",
+			ContentType: "application/json",
 			AppId:       "mainflux-publisher",
 			Body:        data,
 		})
-
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
 
 func (pub *publisher) Close() error {
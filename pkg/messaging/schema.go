@@ -0,0 +1,104 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+var (
+	// ErrInvalidSchema indicates that the channel schema is not a valid JSON Schema.
+	ErrInvalidSchema = errors.New("invalid channel schema")
+
+	// ErrSchemaValidation indicates that the message payload doesn't match the channel schema.
+	ErrSchemaValidation = errors.New("message payload doesn't match channel schema")
+)
+
+// maxCachedSchemas is the number of compiled schemas kept in the cache,
+// which is emptied when the number is reached.
+const maxCachedSchemas = 1000
+
+var schemas = schemaCache{schemas: make(map[string]*gojsonschema.Schema)}
+
+// schemaCache keeps the compiled channel schemas keyed by their content,
+// so the schema isn't compiled again for every message.
+type schemaCache struct {
+	mu      sync.RWMutex
+	schemas map[string]*gojsonschema.Schema
+}
+
+func (sc *schemaCache) get(schema []byte) (*gojsonschema.Schema, error) {
+	key := string(schema)
+
+	sc.mu.RLock()
+	s, ok := sc.schemas[key]
+	sc.mu.RUnlock()
+	if ok {
+		return s, nil
+	}
+
+	s, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema))
+	if err != nil {
+		return nil, err
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if len(sc.schemas) >= maxCachedSchemas {
+		sc.schemas = make(map[string]*gojsonschema.Schema)
+	}
+	sc.schemas[key] = s
+
+	return s, nil
+}
+
+// ValidateSchema returns an error if the schema is not a valid JSON Schema.
+func ValidateSchema(schema []byte) error {
+	if _, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema)); err != nil {
+		return errors.Wrap(ErrInvalidSchema, err)
+	}
+
+	return nil
+}
+
+// ValidatePayload validates the payload against the JSON Schema of the
+// channel profile of the connection. Only the payloads of the JSON based
+// content types are validated, while the payloads of the channels without
+// the schema are accepted as they are.
+func ValidatePayload(conn *mainflux.ConnByKeyRes, payload []byte) error {
+	profile := conn.GetProfile()
+	if len(profile.GetSchema()) == 0 {
+		return nil
+	}
+
+	switch profile.GetContentType() {
+	case "", SenmlContentType, JsonContentType:
+	default:
+		return nil
+	}
+
+	schema, err := schemas.get(profile.GetSchema())
+	if err != nil {
+		return errors.Wrap(ErrInvalidSchema, err)
+	}
+
+	res, err := schema.Validate(gojsonschema.NewBytesLoader(payload))
+	if err != nil {
+		return errors.Wrap(ErrSchemaValidation, err)
+	}
+	if !res.Valid() {
+		var descs []string
+		for _, e := range res.Errors() {
+			descs = append(descs, e.String())
+		}
+		return errors.Wrap(ErrSchemaValidation, errors.New(strings.Join(descs, "; ")))
+	}
+
+	return nil
+}
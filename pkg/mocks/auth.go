@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/users"
 	"github.com/golang/protobuf/ptypes/empty"
@@ -18,6 +19,9 @@ var _ mainflux.AuthServiceClient = (*authServiceMock)(nil)
 type authServiceMock struct {
 	roles        map[string]string
 	usersByEmail map[string]users.User
+	groups       map[string]map[string]string
+	verification map[string]users.User
+	keys         map[string]auth.Key
 }
 
 // NewAuthService creates mock of users service.
@@ -32,17 +36,65 @@ func NewAuthService(adminID string, userList []users.User) mainflux.AuthServiceC
 	return &authServiceMock{
 		roles:        roles,
 		usersByEmail: usersByEmail,
+		verification: make(map[string]users.User),
 	}
 }
 
+// NewAuthServiceWithGroups creates mock of users service which authorizes the
+// access to the groups. The groups map the IDs of the users to the actions
+// they're allowed to perform, e.g. read or read_write.
+func NewAuthServiceWithGroups(adminID string, userList []users.User, groups map[string]map[string]string) mainflux.AuthServiceClient {
+	svc := NewAuthService(adminID, userList).(*authServiceMock)
+	svc.groups = groups
+
+	return svc
+}
+
+// NewAuthServiceWithScopes creates mock of users service which identifies the
+// scoped API keys as well. The keys are mapped by their tokens, and the key
+// subject is the email of the user the key is issued to.
+func NewAuthServiceWithScopes(adminID string, userList []users.User, groups map[string]map[string]string, keys map[string]auth.Key) mainflux.AuthServiceClient {
+	svc := NewAuthServiceWithGroups(adminID, userList, groups).(*authServiceMock)
+	svc.keys = keys
+
+	return svc
+}
+
+// user returns the user identified by the token, and the key if the token
+// is the scoped API key.
+func (svc authServiceMock) user(token string) (users.User, auth.Key, bool) {
+	if k, ok := svc.keys[token]; ok {
+		u, ok := svc.usersByEmail[k.Subject]
+		return u, k, ok
+	}
+
+	u, ok := svc.usersByEmail[token]
+	return u, auth.Key{}, ok
+}
+
 func (svc authServiceMock) Identify(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
-	if u, ok := svc.usersByEmail[in.Value]; ok {
+	if u, _, ok := svc.user(in.Value); ok {
+		return &mainflux.UserIdentity{Id: u.ID, Email: u.Email}, nil
+	}
+	return nil, errors.ErrAuthentication
+}
+
+func (svc authServiceMock) IdentifyVerification(ctx context.Context, in *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	if u, ok := svc.verification[in.Value]; ok {
 		return &mainflux.UserIdentity{Id: u.ID, Email: u.Email}, nil
 	}
 	return nil, errors.ErrAuthentication
 }
 
 func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opts ...grpc.CallOption) (*mainflux.Token, error) {
+	// Verification keys are issued to the users unknown to auth, so the
+	// verified user is identified by the issued key afterwards.
+	if in.Type == auth.VerificationKey && in.GetEmail() != "" {
+		u := users.User{ID: in.GetId(), Email: in.GetEmail()}
+		svc.usersByEmail[in.GetEmail()] = u
+		svc.verification[in.GetEmail()] = u
+		return &mainflux.Token{Value: in.GetEmail()}, nil
+	}
 	if u, ok := svc.usersByEmail[in.GetEmail()]; ok {
 		switch in.Type {
 		default:
@@ -53,14 +105,29 @@ func (svc authServiceMock) Issue(ctx context.Context, in *mainflux.IssueReq, opt
 }
 
 func (svc authServiceMock) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
-	u, ok := svc.usersByEmail[req.Token]
+	u, key, ok := svc.user(req.Token)
 	if !ok {
 		return &empty.Empty{}, errors.ErrAuthentication
 	}
 
 	switch req.Subject {
 	case "root":
-		if svc.roles["root"] != u.ID {
+		if key.Scoped() || svc.roles["root"] != u.ID {
+			return &empty.Empty{}, errors.ErrAuthorization
+		}
+	case "things", "channels", "messages", "groups":
+		if !key.Allows(req.Subject, req.Action, req.Object) {
+			return &empty.Empty{}, errors.ErrAuthorization
+		}
+	case "group":
+		if !key.Allows(auth.GroupsSubject, req.Action, req.Object) {
+			return &empty.Empty{}, errors.ErrAuthorization
+		}
+		action, ok := svc.groups[req.Object][u.ID]
+		if svc.roles["root"] == u.ID {
+			break
+		}
+		if !ok || (req.Action == auth.WriteAction && action != auth.WriteAction) {
 			return &empty.Empty{}, errors.ErrAuthorization
 		}
 	default:
@@ -89,3 +156,27 @@ func (svc authServiceMock) AssignRole(ctx context.Context, in *mainflux.AssignRo
 func (svc authServiceMock) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
 	panic("not implemented")
 }
+
+func (svc authServiceMock) JoinOrg(ctx context.Context, req *mainflux.JoinOrgReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, nil
+}
+
+func (svc authServiceMock) RetrieveGroupOrg(ctx context.Context, req *mainflux.GroupOrgReq, _ ...grpc.CallOption) (r *mainflux.OrgID, err error) {
+	return &mainflux.OrgID{}, nil
+}
+
+func (svc authServiceMock) RetrieveMemberGroups(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.MemberGroupsRes, error) {
+	u, _, ok := svc.user(token.GetValue())
+	if !ok {
+		return nil, errors.ErrAuthentication
+	}
+
+	var groupIDs []string
+	for groupID, members := range svc.groups {
+		if _, ok := members[u.ID]; ok {
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+
+	return &mainflux.MemberGroupsRes{GroupIDs: groupIDs}, nil
+}
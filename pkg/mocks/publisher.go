@@ -21,3 +21,18 @@ func (pub mockPublisher) Publish(msg messaging.Message) error {
 func (pub mockPublisher) Close() error {
 	return nil
 }
+
+type mockDeadLetterPublisher struct{}
+
+// NewDeadLetterPublisher returns mock dead-letter publisher.
+func NewDeadLetterPublisher() messaging.DeadLetterPublisher {
+	return mockDeadLetterPublisher{}
+}
+
+func (pub mockDeadLetterPublisher) PublishDeadLetter(consumer string, data []byte) error {
+	return nil
+}
+
+func (pub mockDeadLetterPublisher) Close() error {
+	return nil
+}
@@ -165,7 +165,7 @@ func (svc *mainfluxThings) ListThings(context.Context, string, bool, things.Page
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) ViewChannelByThing(context.Context, string, string) (things.Channel, error) {
+func (svc *mainfluxThings) ListChannelsByThing(context.Context, string, string, things.PageMetadata) (things.ChannelsPage, error) {
 	panic("not implemented")
 }
 
@@ -215,7 +215,11 @@ func (svc *mainfluxThings) ViewChannelProfile(ctx context.Context, chID string)
 	panic("not implemented")
 }
 
-func (svc *mainfluxThings) GetConnByKey(context.Context, string) (things.Connection, error) {
+func (svc *mainfluxThings) ListRetentionProfiles(context.Context) (map[string]things.Profile, error) {
+	panic("not implemented")
+}
+
+func (svc *mainfluxThings) GetConnByKey(context.Context, string, string) (things.Connection, error) {
 	panic("not implemented")
 }
 
@@ -49,7 +49,12 @@ func (svc thingsServiceMock) GetConnByKey(ctx context.Context, in *mainflux.Conn
 		return nil, status.Error(codes.Internal, "internal server error")
 	}
 
-	return &mainflux.ConnByKeyRes{ChannelID: key, ThingID: key}, nil
+	chanID := in.GetChanID()
+	if chanID == "" {
+		return nil, errors.ErrAuthorization
+	}
+
+	return &mainflux.ConnByKeyRes{ChannelID: chanID, ThingID: key}, nil
 }
 
 func (svc thingsServiceMock) IsChannelOwner(ctx context.Context, in *mainflux.ChannelOwnerReq, opts ...grpc.CallOption) (*empty.Empty, error) {
@@ -75,3 +80,11 @@ func (svc thingsServiceMock) GetGroupsByIDs(ctx context.Context, req *mainflux.G
 
 	return &mainflux.GroupsRes{Groups: groups}, nil
 }
+
+func (svc thingsServiceMock) GetRetentionProfiles(context.Context, *empty.Empty, ...grpc.CallOption) (*mainflux.ChannelProfilesRes, error) {
+	return &mainflux.ChannelProfilesRes{}, nil
+}
+
+func (svc thingsServiceMock) GetChannelProfile(context.Context, *mainflux.ChannelID, ...grpc.CallOption) (*mainflux.Profile, error) {
+	return &mainflux.Profile{}, nil
+}
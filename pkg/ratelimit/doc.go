@@ -0,0 +1,7 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ratelimit provides token-bucket rate limiting of the messages
+// published by things. Buckets are kept per thing and per channel in Redis,
+// so the limits hold across all adapter replicas sharing the same instance.
+package ratelimit
@@ -0,0 +1,53 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+)
+
+var _ ratelimit.Limiter = (*limiterMock)(nil)
+
+type limiterMock struct {
+	mu     sync.Mutex
+	burst  uint
+	things map[string]uint
+	chans  map[string]uint
+}
+
+// NewLimiter returns mock limiter which lets each thing and each channel
+// publish at most burst messages and never refills the buckets. The
+// overridden limits are enforced the same way, using their burst if it is
+// lower.
+func NewLimiter(burst uint) ratelimit.Limiter {
+	return &limiterMock{
+		burst:  burst,
+		things: make(map[string]uint),
+		chans:  make(map[string]uint),
+	}
+}
+
+func (lm *limiterMock) Allow(_ context.Context, thingID, chanID string, o ratelimit.Overrides) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	thingBurst, chanBurst := lm.burst, lm.burst
+	if o.Thing != nil && o.Thing.Enabled() && o.Thing.Burst < thingBurst {
+		thingBurst = o.Thing.Burst
+	}
+	if o.Channel != nil && o.Channel.Enabled() && o.Channel.Burst < chanBurst {
+		chanBurst = o.Channel.Burst
+	}
+
+	if lm.things[thingID] >= thingBurst || lm.chans[chanID] >= chanBurst {
+		return ratelimit.ErrLimitExceeded
+	}
+	lm.things[thingID]++
+	lm.chans[chanID]++
+
+	return nil
+}
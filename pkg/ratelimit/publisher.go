@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+var (
+	_ messaging.Publisher = (*publisher)(nil)
+	_ messaging.PubSub    = (*pubsub)(nil)
+)
+
+type publisher struct {
+	messaging.Publisher
+	limiter Limiter
+}
+
+// NewPublisher decorates the publisher so that messages exceeding the
+// publisher's or the channel's rate are rejected with ErrLimitExceeded
+// instead of being forwarded to the message broker. The rate limit of the
+// message profile lowers the limits of the limiter.
+func NewPublisher(pub messaging.Publisher, limiter Limiter) messaging.Publisher {
+	return &publisher{
+		Publisher: pub,
+		limiter:   limiter,
+	}
+}
+
+func (p *publisher) Publish(msg messaging.Message) error {
+	if err := p.limiter.Allow(context.Background(), msg.Publisher, msg.Channel, ProfileOverrides(msg.Profile)); err != nil {
+		return err
+	}
+	return p.Publisher.Publish(msg)
+}
+
+type pubsub struct {
+	messaging.PubSub
+	limiter Limiter
+}
+
+// NewPubSub decorates the pubsub the same way NewPublisher decorates the publisher.
+func NewPubSub(ps messaging.PubSub, limiter Limiter) messaging.PubSub {
+	return &pubsub{
+		PubSub:  ps,
+		limiter: limiter,
+	}
+}
+
+func (ps *pubsub) Publish(msg messaging.Message) error {
+	if err := ps.limiter.Allow(context.Background(), msg.Publisher, msg.Channel, ProfileOverrides(msg.Profile)); err != nil {
+		return err
+	}
+	return ps.PubSub.Publish(msg)
+}
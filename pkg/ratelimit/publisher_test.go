@@ -0,0 +1,99 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	rlmocks "github.com/MainfluxLabs/mainflux/pkg/ratelimit/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish(t *testing.T) {
+	pub := ratelimit.NewPublisher(mocks.NewPublisher(), rlmocks.NewLimiter(2))
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		err  error
+	}{
+		{
+			desc: "publish first message",
+			msg:  messaging.Message{Publisher: "thing1", Channel: "chan1"},
+			err:  nil,
+		},
+		{
+			desc: "publish second message",
+			msg:  messaging.Message{Publisher: "thing1", Channel: "chan1"},
+			err:  nil,
+		},
+		{
+			desc: "publish message over thing limit",
+			msg:  messaging.Message{Publisher: "thing1", Channel: "chan2"},
+			err:  ratelimit.ErrLimitExceeded,
+		},
+		{
+			desc: "publish message over channel limit",
+			msg:  messaging.Message{Publisher: "thing2", Channel: "chan1"},
+			err:  ratelimit.ErrLimitExceeded,
+		},
+		{
+			desc: "publish message within limits",
+			msg:  messaging.Message{Publisher: "thing2", Channel: "chan2"},
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := pub.Publish(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestPublishProfileRateLimit(t *testing.T) {
+	pub := ratelimit.NewPublisher(mocks.NewPublisher(), rlmocks.NewLimiter(2))
+	profile := &messaging.Profile{
+		RateLimit: &messaging.RateLimit{
+			Thing:   &messaging.Limit{Rate: 10, Burst: 1},
+			Channel: &messaging.Limit{Rate: 10, Burst: 5},
+		},
+	}
+
+	cases := []struct {
+		desc string
+		msg  messaging.Message
+		err  error
+	}{
+		{
+			desc: "publish first message",
+			msg:  messaging.Message{Publisher: "thing1", Channel: "chan1", Profile: profile},
+			err:  nil,
+		},
+		{
+			desc: "publish message over overridden thing limit",
+			msg:  messaging.Message{Publisher: "thing1", Channel: "chan1", Profile: profile},
+			err:  ratelimit.ErrLimitExceeded,
+		},
+		{
+			desc: "publish message within configured channel limit",
+			msg:  messaging.Message{Publisher: "thing2", Channel: "chan1", Profile: profile},
+			err:  nil,
+		},
+		{
+			desc: "publish message over configured channel limit lower than overridden one",
+			msg:  messaging.Message{Publisher: "thing3", Channel: "chan1", Profile: profile},
+			err:  ratelimit.ErrLimitExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		err := pub.Publish(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
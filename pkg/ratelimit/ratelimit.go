@@ -0,0 +1,165 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+const (
+	defRateLimit  = "0"
+	defBurstLimit = "0"
+
+	envThingRateLimit    = "MF_THING_RATE_LIMIT"
+	envThingBurstLimit   = "MF_THING_BURST_LIMIT"
+	envChannelRateLimit  = "MF_CHANNEL_RATE_LIMIT"
+	envChannelBurstLimit = "MF_CHANNEL_BURST_LIMIT"
+)
+
+var errNegativeRate = errors.New("negative rate")
+
+var (
+	// ErrLimitExceeded indicates that the thing or the channel exceeded its publish rate.
+	ErrLimitExceeded = errors.New("publish rate limit exceeded")
+
+	// ErrInvalidConfig indicates that the rate limit configuration is invalid.
+	ErrInvalidConfig = errors.New("invalid rate limit configuration")
+)
+
+// Limit represents a token bucket refilled with Rate tokens per second and
+// holding at most Burst tokens. Zero Rate disables the limit, while zero
+// Burst lets the bucket hold a single second worth of tokens.
+type Limit struct {
+	Rate  float64
+	Burst uint
+}
+
+// Enabled returns true if the limit should be enforced.
+func (l Limit) Enabled() bool {
+	return l.Rate > 0
+}
+
+func (l Limit) capacity() uint {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return uint(math.Ceil(l.Rate))
+}
+
+// Config contains the limits applied to every thing and every channel.
+type Config struct {
+	Thing   Limit
+	Channel Limit
+}
+
+// Enabled returns true if any of the limits should be enforced.
+func (c Config) Enabled() bool {
+	return c.Thing.Enabled() || c.Channel.Enabled()
+}
+
+// Override returns the config restricted by the given limits. The configured
+// limits are a ceiling, so the overrides can only lower them, while the
+// disabled limits are replaced by the overrides.
+func (c Config) Override(o Overrides) Config {
+	c.Thing = c.Thing.restrict(o.Thing)
+	c.Channel = c.Channel.restrict(o.Channel)
+	return c
+}
+
+// restrict returns the stricter of the limit and the override. Nil override
+// and override with zero rate keep the limit.
+func (l Limit) restrict(o *Limit) Limit {
+	if o == nil || !o.Enabled() {
+		return l
+	}
+	if !l.Enabled() {
+		return *o
+	}
+	burst := l.capacity()
+	if c := o.capacity(); c < burst {
+		burst = c
+	}
+	return Limit{Rate: math.Min(l.Rate, o.Rate), Burst: burst}
+}
+
+// Overrides contains the limits set by the channel profile, which restrict the
+// configured limits of the channel and of the things publishing to it. Nil
+// limit keeps the configured one.
+type Overrides struct {
+	Thing   *Limit
+	Channel *Limit
+}
+
+// ProfileOverrides returns the overrides set by the profile of the message.
+func ProfileOverrides(p *messaging.Profile) Overrides {
+	var o Overrides
+	if l := p.GetRateLimit().GetThing(); l != nil {
+		o.Thing = &Limit{Rate: l.Rate, Burst: uint(l.Burst)}
+	}
+	if l := p.GetRateLimit().GetChannel(); l != nil {
+		o.Channel = &Limit{Rate: l.Rate, Burst: uint(l.Burst)}
+	}
+	return o
+}
+
+// ConnOverrides returns the overrides set by the channel profile of the
+// connection.
+func ConnOverrides(p *mainflux.Profile) Overrides {
+	var o Overrides
+	if l := p.GetRateLimit().GetThing(); l != nil {
+		o.Thing = &Limit{Rate: l.Rate, Burst: uint(l.Burst)}
+	}
+	if l := p.GetRateLimit().GetChannel(); l != nil {
+		o.Channel = &Limit{Rate: l.Rate, Burst: uint(l.Burst)}
+	}
+	return o
+}
+
+// LoadConfig loads the limits applied to every thing and every channel from
+// the environment.
+func LoadConfig() (Config, error) {
+	thing, err := loadLimit(envThingRateLimit, envThingBurstLimit)
+	if err != nil {
+		return Config{}, err
+	}
+
+	channel, err := loadLimit(envChannelRateLimit, envChannelBurstLimit)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return Config{Thing: thing, Channel: channel}, nil
+}
+
+func loadLimit(envRate, envBurst string) (Limit, error) {
+	rate, err := strconv.ParseFloat(mainflux.Env(envRate, defRateLimit), 64)
+	if err != nil {
+		return Limit{}, errors.Wrap(ErrInvalidConfig, err)
+	}
+	if rate < 0 {
+		return Limit{}, errors.Wrap(ErrInvalidConfig, errNegativeRate)
+	}
+
+	burst, err := strconv.ParseUint(mainflux.Env(envBurst, defBurstLimit), 10, 32)
+	if err != nil {
+		return Limit{}, errors.Wrap(ErrInvalidConfig, err)
+	}
+
+	return Limit{Rate: rate, Burst: uint(burst)}, nil
+}
+
+// Limiter specifies the publish rate limiting API.
+type Limiter interface {
+	// Allow takes a token from both the thing and the channel bucket, using
+	// the configured limits replaced by the given overrides. It returns
+	// ErrLimitExceeded without taking any tokens if either of the buckets
+	// is empty.
+	Allow(ctx context.Context, thingID, chanID string, o Overrides) error
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		desc string
+		env  map[string]string
+		cfg  ratelimit.Config
+		err  error
+	}{
+		{
+			desc: "load default config",
+			env:  map[string]string{},
+			cfg:  ratelimit.Config{},
+			err:  nil,
+		},
+		{
+			desc: "load config",
+			env: map[string]string{
+				"MF_THING_RATE_LIMIT":    "1.5",
+				"MF_THING_BURST_LIMIT":   "3",
+				"MF_CHANNEL_RATE_LIMIT":  "10",
+				"MF_CHANNEL_BURST_LIMIT": "20",
+			},
+			cfg: ratelimit.Config{
+				Thing:   ratelimit.Limit{Rate: 1.5, Burst: 3},
+				Channel: ratelimit.Limit{Rate: 10, Burst: 20},
+			},
+			err: nil,
+		},
+		{
+			desc: "load config with invalid rate",
+			env:  map[string]string{"MF_THING_RATE_LIMIT": "fast"},
+			err:  ratelimit.ErrInvalidConfig,
+		},
+		{
+			desc: "load config with negative rate",
+			env:  map[string]string{"MF_CHANNEL_RATE_LIMIT": "-1"},
+			err:  ratelimit.ErrInvalidConfig,
+		},
+		{
+			desc: "load config with negative burst",
+			env:  map[string]string{"MF_CHANNEL_BURST_LIMIT": "-1"},
+			err:  ratelimit.ErrInvalidConfig,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			cfg, err := ratelimit.LoadConfig()
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.cfg, cfg, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.cfg, cfg))
+		})
+	}
+}
+
+func TestOverride(t *testing.T) {
+	cfg := ratelimit.Config{
+		Thing:   ratelimit.Limit{Rate: 1, Burst: 2},
+		Channel: ratelimit.Limit{Rate: 10, Burst: 20},
+	}
+
+	cases := []struct {
+		desc    string
+		profile *messaging.Profile
+		cfg     ratelimit.Config
+	}{
+		{
+			desc:    "override with no profile",
+			profile: nil,
+			cfg:     cfg,
+		},
+		{
+			desc:    "override with profile without rate limit",
+			profile: &messaging.Profile{},
+			cfg:     cfg,
+		},
+		{
+			desc: "override thing limit",
+			profile: &messaging.Profile{
+				RateLimit: &messaging.RateLimit{Thing: &messaging.Limit{Rate: 0.5, Burst: 1}},
+			},
+			cfg: ratelimit.Config{
+				Thing:   ratelimit.Limit{Rate: 0.5, Burst: 1},
+				Channel: cfg.Channel,
+			},
+		},
+		{
+			desc: "override channel limit with higher rate and lower burst",
+			profile: &messaging.Profile{
+				RateLimit: &messaging.RateLimit{Channel: &messaging.Limit{Rate: 100, Burst: 5}},
+			},
+			cfg: ratelimit.Config{
+				Thing:   cfg.Thing,
+				Channel: ratelimit.Limit{Rate: 10, Burst: 5},
+			},
+		},
+		{
+			desc: "override thing limit with higher rate and burst",
+			profile: &messaging.Profile{
+				RateLimit: &messaging.RateLimit{Thing: &messaging.Limit{Rate: 5, Burst: 6}},
+			},
+			cfg: cfg,
+		},
+		{
+			desc: "override channel limit with zero rate",
+			profile: &messaging.Profile{
+				RateLimit: &messaging.RateLimit{Channel: &messaging.Limit{}},
+			},
+			cfg: cfg,
+		},
+	}
+
+	for _, tc := range cases {
+		got := cfg.Override(ratelimit.ProfileOverrides(tc.profile))
+		assert.Equal(t, tc.cfg, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.cfg, got))
+	}
+}
+
+func TestOverrideDisabled(t *testing.T) {
+	cfg := ratelimit.Config{
+		Thing: ratelimit.Limit{Rate: 1, Burst: 2},
+	}
+
+	cases := []struct {
+		desc    string
+		profile *messaging.Profile
+		cfg     ratelimit.Config
+	}{
+		{
+			desc: "override disabled channel limit",
+			profile: &messaging.Profile{
+				RateLimit: &messaging.RateLimit{Channel: &messaging.Limit{Rate: 100, Burst: 200}},
+			},
+			cfg: ratelimit.Config{
+				Thing:   cfg.Thing,
+				Channel: ratelimit.Limit{Rate: 100, Burst: 200},
+			},
+		},
+		{
+			desc: "override disabled channel limit with zero rate",
+			profile: &messaging.Profile{
+				RateLimit: &messaging.RateLimit{Channel: &messaging.Limit{Burst: 200}},
+			},
+			cfg: cfg,
+		},
+	}
+
+	for _, tc := range cases {
+		got := cfg.Override(ratelimit.ProfileOverrides(tc.profile))
+		assert.Equal(t, tc.cfg, got, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.cfg, got))
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	thingPrefix   = "ratelimit:thing"
+	channelPrefix = "ratelimit:channel"
+)
+
+// allowScript atomically refills and checks every bucket passed in KEYS,
+// taking a token from each of them only if all of them have one available.
+// ARGV holds the current time in seconds, followed by the rate and burst
+// of each bucket.
+var allowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local tokens = {}
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[2 * i])
+	local burst = tonumber(ARGV[2 * i + 1])
+	local bucket = redis.call("HMGET", key, "tokens", "ts")
+	local t = tonumber(bucket[1]) or burst
+	local ts = tonumber(bucket[2]) or now
+	t = math.min(burst, t + math.max(0, now - ts) * rate)
+	if t < 1 then
+		return 0
+	end
+	tokens[i] = t
+end
+for i, key in ipairs(KEYS) do
+	local rate = tonumber(ARGV[2 * i])
+	local burst = tonumber(ARGV[2 * i + 1])
+	redis.call("HMSET", key, "tokens", tostring(tokens[i] - 1), "ts", tostring(now))
+	redis.call("PEXPIRE", key, math.ceil(burst / rate * 1000) + 1000)
+end
+return 1
+`)
+
+var _ Limiter = (*redisLimiter)(nil)
+
+type redisLimiter struct {
+	client *redis.Client
+	cfg    Config
+}
+
+// NewRedisLimiter returns Redis backed token-bucket limiter.
+func NewRedisLimiter(client *redis.Client, cfg Config) Limiter {
+	return &redisLimiter{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+func (rl *redisLimiter) Allow(ctx context.Context, thingID, chanID string, o Overrides) error {
+	cfg := rl.cfg.Override(o)
+	now := float64(time.Now().UnixNano()) / float64(time.Second)
+	keys := []string{}
+	args := []interface{}{now}
+	if cfg.Thing.Enabled() && thingID != "" {
+		keys = append(keys, fmt.Sprintf("%s:%s", thingPrefix, thingID))
+		args = append(args, cfg.Thing.Rate, cfg.Thing.capacity())
+	}
+	if cfg.Channel.Enabled() && chanID != "" {
+		keys = append(keys, fmt.Sprintf("%s:%s", channelPrefix, chanID))
+		args = append(args, cfg.Channel.Rate, cfg.Channel.capacity())
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	allowed, err := allowScript.Run(ctx, rl.client, keys, args...).Int()
+	if err != nil {
+		return err
+	}
+	if allowed == 0 {
+		return ErrLimitExceeded
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllow(t *testing.T) {
+	cfg := ratelimit.Config{
+		Thing:   ratelimit.Limit{Rate: 10, Burst: 3},
+		Channel: ratelimit.Limit{Rate: 10, Burst: 5},
+	}
+	limiter := ratelimit.NewRedisLimiter(redisClient, cfg)
+
+	chanID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thingID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherThingID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	for i := uint(0); i < cfg.Thing.Burst; i++ {
+		err := limiter.Allow(context.Background(), thingID, chanID, ratelimit.Overrides{})
+		assert.Nil(t, err, fmt.Sprintf("publish %d within thing burst: expected no error got %s", i, err))
+	}
+
+	cases := []struct {
+		desc    string
+		thingID string
+		sleep   time.Duration
+		err     error
+	}{
+		{
+			desc:    "publish over thing burst",
+			thingID: thingID,
+			err:     ratelimit.ErrLimitExceeded,
+		},
+		{
+			desc:    "publish within channel burst from other thing",
+			thingID: otherThingID,
+			err:     nil,
+		},
+		{
+			desc:    "publish within channel burst from other thing again",
+			thingID: otherThingID,
+			err:     nil,
+		},
+		{
+			desc:    "publish over channel burst from other thing",
+			thingID: otherThingID,
+			err:     ratelimit.ErrLimitExceeded,
+		},
+		{
+			desc:    "publish after the buckets are refilled",
+			thingID: thingID,
+			sleep:   200 * time.Millisecond,
+			err:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		time.Sleep(tc.sleep)
+		err := limiter.Allow(context.Background(), tc.thingID, chanID, ratelimit.Overrides{})
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAllowDisabled(t *testing.T) {
+	limiter := ratelimit.NewRedisLimiter(redisClient, ratelimit.Config{})
+
+	for i := 0; i < 100; i++ {
+		err := limiter.Allow(context.Background(), "thing", "channel", ratelimit.Overrides{})
+		assert.Nil(t, err, fmt.Sprintf("publish %d with disabled limits: expected no error got %s", i, err))
+	}
+}
+
+func TestAllowOverrides(t *testing.T) {
+	cfg := ratelimit.Config{
+		Thing:   ratelimit.Limit{Rate: 10, Burst: 3},
+		Channel: ratelimit.Limit{Rate: 10, Burst: 5},
+	}
+	limiter := ratelimit.NewRedisLimiter(redisClient, cfg)
+
+	chanID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	thingID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherThingID, err := uuid.New().ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	o := ratelimit.Overrides{
+		Thing:   &ratelimit.Limit{Rate: 10, Burst: 1},
+		Channel: &ratelimit.Limit{},
+	}
+	err = limiter.Allow(context.Background(), thingID, chanID, o)
+	assert.Nil(t, err, fmt.Sprintf("publish within overridden thing burst: expected no error got %s", err))
+
+	err = limiter.Allow(context.Background(), thingID, chanID, o)
+	assert.True(t, errors.Contains(err, ratelimit.ErrLimitExceeded), fmt.Sprintf("publish over overridden thing burst: expected %s got %s\n", ratelimit.ErrLimitExceeded, err))
+
+	o.Thing = &ratelimit.Limit{Rate: 100, Burst: 10}
+	for i := 0; i < 3; i++ {
+		err := limiter.Allow(context.Background(), otherThingID, chanID, o)
+		assert.Nil(t, err, fmt.Sprintf("publish %d within configured thing burst: expected no error got %s", i, err))
+	}
+
+	err = limiter.Allow(context.Background(), otherThingID, chanID, o)
+	assert.True(t, errors.Contains(err, ratelimit.ErrLimitExceeded), fmt.Sprintf("publish over configured thing burst lower than overridden one: expected %s got %s\n", ratelimit.ErrLimitExceeded, err))
+}
@@ -96,34 +96,34 @@ func (sdk mfSDK) Channels(token string, pm PageMetadata) (ChannelsPage, error) {
 	return cp, nil
 }
 
-func (sdk mfSDK) ViewChannelByThing(token, thingID string) (Channel, error) {
-	url := fmt.Sprintf("%s/things/%s/channels", sdk.thingsURL, thingID)
+func (sdk mfSDK) ChannelsByThing(token, thingID string, offset, limit uint64) (ChannelsPage, error) {
+	url := fmt.Sprintf("%s/things/%s/channels?offset=%d&limit=%d", sdk.thingsURL, thingID, offset, limit)
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return Channel{}, err
+		return ChannelsPage{}, err
 	}
 
 	resp, err := sdk.sendRequest(req, token, string(CTJSON))
 	if err != nil {
-		return Channel{}, err
+		return ChannelsPage{}, err
 	}
 	defer resp.Body.Close()
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return Channel{}, err
+		return ChannelsPage{}, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return Channel{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+		return ChannelsPage{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
 	}
 
-	var ch Channel
-	if err := json.Unmarshal(body, &ch); err != nil {
-		return Channel{}, err
+	var cp ChannelsPage
+	if err := json.Unmarshal(body, &cp); err != nil {
+		return ChannelsPage{}, err
 	}
 
-	return ch, nil
+	return cp, nil
 }
 
 func (sdk mfSDK) Channel(id, token string) (Channel, error) {
@@ -313,7 +313,7 @@ func TestChannels(t *testing.T) {
 	}
 }
 
-func TestViewChannelByThing(t *testing.T) {
+func TestChannelsByThing(t *testing.T) {
 	svc := newThingsService()
 	ts := newThingsServer(svc)
 	defer ts.Close()
@@ -334,74 +334,95 @@ func TestViewChannelByThing(t *testing.T) {
 	err = mainfluxSDK.AssignThing([]string{tid}, gr, token)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	ch := sdk.Channel{Name: name}
-	cid, err := mainfluxSDK.CreateChannel(ch, token)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	n := 3
+	var resChans []sdk.Channel
+	for i := 0; i < n; i++ {
+		ch := sdk.Channel{Name: fmt.Sprintf("%s-%d", name, i)}
+		cid, err := mainfluxSDK.CreateChannel(ch, token)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = mainfluxSDK.AssignChannel([]string{cid}, gr, token)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		err = mainfluxSDK.AssignChannel([]string{cid}, gr, token)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	connIDs := sdk.ConnectionIDs{
-		ChannelID: cid,
-		ThingIDs:  []string{tid},
-	}
+		connIDs := sdk.ConnectionIDs{
+			ChannelID: cid,
+			ThingIDs:  []string{tid},
+		}
+		err = mainfluxSDK.Connect(connIDs, token)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	resChan := sdk.Channel{
-		ID:   cid,
-		Name: name,
+		resChans = append(resChans, sdk.Channel{ID: cid, Name: ch.Name})
 	}
 
-	err = mainfluxSDK.Connect(connIDs, token)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-
 	cases := []struct {
 		desc     string
 		thing    string
 		token    string
+		offset   uint64
+		limit    uint64
 		err      error
-		response sdk.Channel
+		response []sdk.Channel
 	}{
 		{
-			desc:     "view channel by thing",
+			desc:     "get a list of channels by thing",
 			thing:    tid,
 			token:    token,
+			offset:   0,
+			limit:    10,
 			err:      nil,
-			response: resChan,
+			response: resChans,
 		},
 		{
-			desc:     "view channel by thing with invalid token",
+			desc:     "get a list of channels by thing with limit",
+			thing:    tid,
+			token:    token,
+			offset:   0,
+			limit:    2,
+			err:      nil,
+			response: resChans[0:2],
+		},
+		{
+			desc:     "get a list of channels by thing with invalid token",
 			thing:    tid,
 			token:    wrongValue,
+			offset:   0,
+			limit:    10,
 			err:      createError(sdk.ErrFailedFetch, http.StatusUnauthorized),
-			response: sdk.Channel{},
+			response: nil,
 		},
 		{
-			desc:     "view channel by thing with empty token",
+			desc:     "get a list of channels by thing with empty token",
 			thing:    tid,
 			token:    "",
+			offset:   0,
+			limit:    10,
 			err:      createError(sdk.ErrFailedFetch, http.StatusUnauthorized),
-			response: sdk.Channel{},
+			response: nil,
 		},
 		{
-			desc:     "view channel by thing with empty thing id",
+			desc:     "get a list of channels by thing with empty thing id",
 			thing:    "",
 			token:    token,
+			offset:   0,
+			limit:    10,
 			err:      createError(sdk.ErrFailedFetch, http.StatusBadRequest),
-			response: sdk.Channel{},
+			response: nil,
 		},
 		{
-			desc:     "view channel by thing with unknown thing id",
+			desc:     "get a list of channels by thing with unknown thing id",
 			thing:    wrongID,
 			token:    token,
+			offset:   0,
+			limit:    10,
 			err:      createError(sdk.ErrFailedFetch, http.StatusNotFound),
-			response: sdk.Channel{},
+			response: nil,
 		},
 	}
 
 	for _, tc := range cases {
-		ch, err := mainfluxSDK.ViewChannelByThing(tc.token, tc.thing)
+		page, err := mainfluxSDK.ChannelsByThing(tc.token, tc.thing, tc.offset, tc.limit)
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
-		assert.Equal(t, tc.response, ch, fmt.Sprintf("%s: expected response channel %s, got %s", tc.desc, tc.response, ch))
+		assert.Equal(t, tc.response, page.Channels, fmt.Sprintf("%s: expected response channels %s, got %s", tc.desc, tc.response, page.Channels))
 	}
 }
 
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -16,6 +18,10 @@ type keyReq struct {
 	Duration time.Duration `json:"duration,omitempty"`
 }
 
+type revokeKeysReq struct {
+	KeyIDs []string `json:"key_ids"`
+}
+
 const keysEndpoint = "keys"
 
 const (
@@ -112,3 +118,70 @@ func (sdk mfSDK) RetrieveKey(id, token string) (retrieveKeyRes, error) {
 
 	return key, nil
 }
+
+func (sdk mfSDK) Keys(token string, pm KeysPageMetadata) (KeysPage, error) {
+	q := url.Values{}
+	q.Add("offset", strconv.FormatUint(pm.Offset, 10))
+	q.Add("limit", strconv.FormatUint(pm.Limit, 10))
+	if pm.Type != nil {
+		q.Add("type", strconv.FormatUint(uint64(*pm.Type), 10))
+	}
+	if pm.Expired != nil {
+		q.Add("expired", strconv.FormatBool(*pm.Expired))
+	}
+	if pm.Subject != "" {
+		q.Add("subject", pm.Subject)
+	}
+
+	url := fmt.Sprintf("%s/%s?%s", sdk.authURL, keysEndpoint, q.Encode())
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return KeysPage{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return KeysPage{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return KeysPage{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return KeysPage{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var kp KeysPage
+	if err := json.Unmarshal(body, &kp); err != nil {
+		return KeysPage{}, err
+	}
+
+	return kp, nil
+}
+
+func (sdk mfSDK) RevokeKeys(token string, ids []string) error {
+	data, err := json.Marshal(revokeKeysReq{KeyIDs: ids})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.authURL, keysEndpoint)
+	req, err := http.NewRequest(http.MethodDelete, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Wrap(ErrFailedRemoval, errors.New(resp.Status))
+	}
+
+	return nil
+}
@@ -81,7 +81,7 @@ func TestSendMessage(t *testing.T) {
 			chanID: "",
 			msg:    msg,
 			auth:   atoken,
-			err:    nil,
+			err:    createError(sdk.ErrFailedPublish, http.StatusBadRequest),
 		},
 		"publish message unable to authorize": {
 			chanID: chanID,
@@ -28,6 +28,11 @@ type deleteChannelsReq struct {
 	ChannelIDs []string `json:"channel_ids"`
 }
 
+// deleteRulesReq contains IDs of rules to be deleted
+type deleteRulesReq struct {
+	RuleIDs []string `json:"rule_ids"`
+}
+
 // deleteThingsReq contains IDs of things to be deleted
 type deleteThingsReq struct {
 	ThingIDs []string `json:"thing_ids"`
@@ -22,6 +22,10 @@ type createChannelsRes struct {
 	Channels []Channel `json:"channels"`
 }
 
+type createRulesRes struct {
+	Rules []Rule `json:"rules"`
+}
+
 type createGroupsRes struct {
 	Groups []Group `json:"groups"`
 }
@@ -44,6 +48,12 @@ type ChannelsPage struct {
 	pageRes
 }
 
+// RulesPage contains list of rules in a page with proper metadata.
+type RulesPage struct {
+	Rules []Rule `json:"rules"`
+	pageRes
+}
+
 // MessagesPage contains list of messages in a page with proper metadata.
 type MessagesPage struct {
 	Messages []senml.Message `json:"messages,omitempty"`
@@ -90,11 +100,16 @@ func (res KeyRes) Empty() bool {
 }
 
 type retrieveKeyRes struct {
-	ID        string     `json:"id,omitempty"`
-	IssuerID  string     `json:"issuer_id,omitempty"`
-	Subject   string     `json:"subject,omitempty"`
-	IssuedAt  time.Time  `json:"issued_at,omitempty"`
-	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	ID          string     `json:"id,omitempty"`
+	IssuerID    string     `json:"issuer_id,omitempty"`
+	Subject     string     `json:"subject,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Scopes      []string   `json:"scopes,omitempty"`
+	Type        uint32     `json:"type,omitempty"`
+	IssuedAt    time.Time  `json:"issued_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
 }
 
 func (res retrieveKeyRes) Code() int {
@@ -108,3 +123,9 @@ func (res retrieveKeyRes) Headers() map[string]string {
 func (res retrieveKeyRes) Empty() bool {
 	return false
 }
+
+// KeysPage contains list of keys in a page with proper metadata.
+type KeysPage struct {
+	Keys []retrieveKeyRes `json:"keys"`
+	pageRes
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const rulesEndpoint = "rules"
+
+func (sdk mfSDK) CreateRules(rules []Rule, token string) ([]Rule, error) {
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return []Rule{}, err
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.rulesURL, rulesEndpoint)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return []Rule{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return []Rule{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return []Rule{}, errors.Wrap(ErrFailedCreation, errors.New(resp.Status))
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return []Rule{}, err
+	}
+
+	var crr createRulesRes
+	if err := json.Unmarshal(body, &crr); err != nil {
+		return []Rule{}, err
+	}
+
+	return crr.Rules, nil
+}
+
+func (sdk mfSDK) Rules(token string, offset, limit uint64) (RulesPage, error) {
+	url := fmt.Sprintf("%s/%s?offset=%d&limit=%d", sdk.rulesURL, rulesEndpoint, offset, limit)
+	return sdk.rulesPage(url, token)
+}
+
+func (sdk mfSDK) RulesByChannel(chanID, token string, offset, limit uint64) (RulesPage, error) {
+	url := fmt.Sprintf("%s/%s/%s/%s?offset=%d&limit=%d", sdk.rulesURL, channelsEndpoint, chanID, rulesEndpoint, offset, limit)
+	return sdk.rulesPage(url, token)
+}
+
+func (sdk mfSDK) Rule(id, token string) (Rule, error) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.rulesURL, rulesEndpoint, id)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return Rule{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Rule{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var r Rule
+	if err := json.Unmarshal(body, &r); err != nil {
+		return Rule{}, err
+	}
+
+	return r, nil
+}
+
+func (sdk mfSDK) UpdateRule(r Rule, token string) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.rulesURL, rulesEndpoint, r.ID)
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Wrap(ErrFailedUpdate, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
+func (sdk mfSDK) DeleteRules(ids []string, token string) error {
+	delReq := deleteRulesReq{RuleIDs: ids}
+	data, err := json.Marshal(delReq)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s", sdk.rulesURL, rulesEndpoint)
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusNoContent {
+		return errors.Wrap(ErrFailedRemoval, errors.New(resp.Status))
+	}
+
+	return nil
+}
+
+func (sdk mfSDK) rulesPage(url, token string) (RulesPage, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return RulesPage{}, err
+	}
+
+	resp, err := sdk.sendRequest(req, token, string(CTJSON))
+	if err != nil {
+		return RulesPage{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return RulesPage{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return RulesPage{}, errors.Wrap(ErrFailedFetch, errors.New(resp.Status))
+	}
+
+	var rp RulesPage
+	if err := json.Unmarshal(body, &rp); err != nil {
+		return RulesPage{}, err
+	}
+
+	return rp, nil
+}
@@ -0,0 +1,310 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/mocks"
+	sdk "github.com/MainfluxLabs/mainflux/pkg/sdk/go"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/rules"
+	rulesapi "github.com/MainfluxLabs/mainflux/rules/api/http"
+	rulesmocks "github.com/MainfluxLabs/mainflux/rules/mocks"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ruleChanID   = "5384fb1c-d0ae-4cbe-be52-c54223150fe0"
+	targetChanID = "d2ebb3f1-ee6f-4b2a-8a4a-3e8f2ec6d1b1"
+)
+
+var rule = sdk.Rule{
+	Name:      "overheating",
+	ChannelID: ruleChanID,
+	Condition: sdk.RuleCondition{Field: "temp", Operator: ">", Threshold: 40, Consecutive: 3},
+	Actions:   []sdk.RuleAction{{Type: "publish", ChannelID: targetChanID}},
+}
+
+func newRulesService() rules.Service {
+	auth := mocks.NewAuthService("", usersList)
+	things := rulesmocks.NewThingsServiceClient(map[string][]string{
+		user.ID: {ruleChanID, targetChanID},
+	}, nil)
+
+	return rules.New(auth, things, rulesmocks.NewRuleRepository(), rulesmocks.NewCounterRepository(), rulesmocks.NewPublisher(), uuid.NewMock())
+}
+
+func newRulesServer(svc rules.Service) *httptest.Server {
+	logger := logger.NewMock()
+	mux := rulesapi.MakeHandler(mocktracer.New(), svc, logger)
+	return httptest.NewServer(mux)
+}
+
+func TestCreateRules(t *testing.T) {
+	svc := newRulesService()
+	ts := newRulesServer(svc)
+	defer ts.Close()
+
+	mainfluxSDK := sdk.NewSDK(sdk.Config{RulesURL: ts.URL})
+
+	invalidRule := rule
+	invalidRule.Condition.Operator = wrongValue
+
+	cases := []struct {
+		desc  string
+		rules []sdk.Rule
+		token string
+		err   error
+		size  int
+	}{
+		{
+			desc:  "create new rules",
+			rules: []sdk.Rule{rule, rule},
+			token: token,
+			err:   nil,
+			size:  2,
+		},
+		{
+			desc:  "create rule with invalid condition",
+			rules: []sdk.Rule{invalidRule},
+			token: token,
+			err:   createError(sdk.ErrFailedCreation, http.StatusBadRequest),
+			size:  0,
+		},
+		{
+			desc:  "create rule for channel owned by other user",
+			rules: []sdk.Rule{rule},
+			token: otherToken,
+			err:   createError(sdk.ErrFailedCreation, http.StatusForbidden),
+			size:  0,
+		},
+		{
+			desc:  "create rule with invalid token",
+			rules: []sdk.Rule{rule},
+			token: wrongValue,
+			err:   createError(sdk.ErrFailedCreation, http.StatusUnauthorized),
+			size:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		rs, err := mainfluxSDK.CreateRules(tc.rules, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(rs), fmt.Sprintf("%s: expected %d rules got %d", tc.desc, tc.size, len(rs)))
+	}
+}
+
+func TestRule(t *testing.T) {
+	svc := newRulesService()
+	ts := newRulesServer(svc)
+	defer ts.Close()
+
+	mainfluxSDK := sdk.NewSDK(sdk.Config{RulesURL: ts.URL})
+	rs, err := mainfluxSDK.CreateRules([]sdk.Rule{rule}, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	created := rs[0]
+
+	cases := []struct {
+		desc  string
+		id    string
+		token string
+		err   error
+		rule  sdk.Rule
+	}{
+		{
+			desc:  "get existing rule",
+			id:    created.ID,
+			token: token,
+			err:   nil,
+			rule:  created,
+		},
+		{
+			desc:  "get non-existent rule",
+			id:    wrongID,
+			token: token,
+			err:   createError(sdk.ErrFailedFetch, http.StatusNotFound),
+			rule:  sdk.Rule{},
+		},
+		{
+			desc:  "get rule with invalid token",
+			id:    created.ID,
+			token: wrongValue,
+			err:   createError(sdk.ErrFailedFetch, http.StatusUnauthorized),
+			rule:  sdk.Rule{},
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := mainfluxSDK.Rule(tc.id, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.rule, r, fmt.Sprintf("%s: expected response rule %v, got %v", tc.desc, tc.rule, r))
+	}
+}
+
+func TestRules(t *testing.T) {
+	svc := newRulesService()
+	ts := newRulesServer(svc)
+	defer ts.Close()
+
+	mainfluxSDK := sdk.NewSDK(sdk.Config{RulesURL: ts.URL})
+	_, err := mainfluxSDK.CreateRules([]sdk.Rule{rule, rule, rule}, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		token  string
+		offset uint64
+		limit  uint64
+		err    error
+		size   int
+	}{
+		{
+			desc:   "get a list of rules",
+			token:  token,
+			offset: 0,
+			limit:  5,
+			err:    nil,
+			size:   3,
+		},
+		{
+			desc:   "get a list of channel rules",
+			chanID: ruleChanID,
+			token:  token,
+			offset: 1,
+			limit:  5,
+			err:    nil,
+			size:   2,
+		},
+		{
+			desc:   "get a list of rules of channel without rules",
+			chanID: targetChanID,
+			token:  token,
+			offset: 0,
+			limit:  5,
+			err:    nil,
+			size:   0,
+		},
+		{
+			desc:   "get a list of rules with invalid token",
+			token:  wrongValue,
+			offset: 0,
+			limit:  5,
+			err:    createError(sdk.ErrFailedFetch, http.StatusUnauthorized),
+			size:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		var page sdk.RulesPage
+		var err error
+		if tc.chanID == "" {
+			page, err = mainfluxSDK.Rules(tc.token, tc.offset, tc.limit)
+		} else {
+			page, err = mainfluxSDK.RulesByChannel(tc.chanID, tc.token, tc.offset, tc.limit)
+		}
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+		assert.Equal(t, tc.size, len(page.Rules), fmt.Sprintf("%s: expected %d rules got %d", tc.desc, tc.size, len(page.Rules)))
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc := newRulesService()
+	ts := newRulesServer(svc)
+	defer ts.Close()
+
+	mainfluxSDK := sdk.NewSDK(sdk.Config{RulesURL: ts.URL})
+	rs, err := mainfluxSDK.CreateRules([]sdk.Rule{rule}, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	updated := rs[0]
+	updated.Name = "updated"
+	updated.Condition.Threshold = 50
+
+	nonexistent := updated
+	nonexistent.ID = wrongID
+
+	cases := []struct {
+		desc  string
+		rule  sdk.Rule
+		token string
+		err   error
+	}{
+		{
+			desc:  "update existing rule",
+			rule:  updated,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "update non-existing rule",
+			rule:  nonexistent,
+			token: token,
+			err:   createError(sdk.ErrFailedUpdate, http.StatusNotFound),
+		},
+		{
+			desc:  "update rule with invalid token",
+			rule:  updated,
+			token: wrongValue,
+			err:   createError(sdk.ErrFailedUpdate, http.StatusUnauthorized),
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.UpdateRule(tc.rule, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+
+	r, err := mainfluxSDK.Rule(updated.ID, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, updated, r, fmt.Sprintf("expected rule %v, got %v", updated, r))
+}
+
+func TestDeleteRules(t *testing.T) {
+	svc := newRulesService()
+	ts := newRulesServer(svc)
+	defer ts.Close()
+
+	mainfluxSDK := sdk.NewSDK(sdk.Config{RulesURL: ts.URL})
+	rs, err := mainfluxSDK.CreateRules([]sdk.Rule{rule}, token)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		ids   []string
+		token string
+		err   error
+	}{
+		{
+			desc:  "delete rules with invalid token",
+			ids:   []string{rs[0].ID},
+			token: wrongValue,
+			err:   createError(sdk.ErrFailedRemoval, http.StatusUnauthorized),
+		},
+		{
+			desc:  "delete rules without IDs",
+			ids:   []string{},
+			token: token,
+			err:   createError(sdk.ErrFailedRemoval, http.StatusBadRequest),
+		},
+		{
+			desc:  "delete existing rules",
+			ids:   []string{rs[0].ID},
+			token: token,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := mainfluxSDK.DeleteRules(tc.ids, tc.token)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %s, got %s", tc.desc, tc.err, err))
+	}
+}
@@ -126,6 +126,33 @@ type Channel struct {
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// Rule represents mainflux rule.
+type Rule struct {
+	ID        string        `json:"id,omitempty"`
+	Owner     string        `json:"owner,omitempty"`
+	ChannelID string        `json:"channel_id,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	Condition RuleCondition `json:"condition"`
+	Actions   []RuleAction  `json:"actions"`
+}
+
+// RuleCondition represents a condition that has to be satisfied by the
+// given number of consecutive messages for the rule to be triggered.
+type RuleCondition struct {
+	Field       string  `json:"field"`
+	Operator    string  `json:"operator"`
+	Threshold   float64 `json:"threshold"`
+	Consecutive uint    `json:"consecutive,omitempty"`
+}
+
+// RuleAction represents an action performed once the rule is triggered.
+type RuleAction struct {
+	Type      string   `json:"type"`
+	ChannelID string   `json:"channel_id,omitempty"`
+	Contacts  []string `json:"contacts,omitempty"`
+	URL       string   `json:"url,omitempty"`
+}
+
 type Key struct {
 	ID        string
 	Type      uint32
@@ -135,6 +162,16 @@ type Key struct {
 	ExpiresAt time.Time
 }
 
+// KeysPageMetadata contains page metadata and filters used for listing
+// API keys. Nil Type and Expired filters match all keys.
+type KeysPageMetadata struct {
+	Offset  uint64
+	Limit   uint64
+	Type    *uint32
+	Expired *bool
+	Subject string
+}
+
 // SDK contains Mainflux API.
 type SDK interface {
 	// CreateUser creates mainflux user.
@@ -149,7 +186,8 @@ type SDK interface {
 	// CreateToken receives credentials and returns user token.
 	CreateToken(user User) (string, error)
 
-	// RegisterUser registers mainflux user.
+	// RegisterUser registers mainflux user. The account is enabled once the
+	// user verifies the email.
 	RegisterUser(user User) (string, error)
 
 	// UpdateUser updates existing user.
@@ -231,8 +269,8 @@ type SDK interface {
 	// Channels returns page of channels.
 	Channels(token string, pm PageMetadata) (ChannelsPage, error)
 
-	// ViewChannelByThing returns channel that are connected to specified thing.
-	ViewChannelByThing(token, thingID string) (Channel, error)
+	// ChannelsByThing returns page of channels that are connected to specified thing.
+	ChannelsByThing(token, thingID string, offset, limit uint64) (ChannelsPage, error)
 
 	// Channel returns channel data by id.
 	Channel(id, token string) (Channel, error)
@@ -308,6 +346,30 @@ type SDK interface {
 
 	// RetrieveKey retrieves data for the key identified by the provided ID, that is issued by the user identified by the provided key.
 	RetrieveKey(token, id string) (retrieveKeyRes, error)
+
+	// Keys returns page of keys issued by the user identified by the provided key.
+	Keys(token string, pm KeysPageMetadata) (KeysPage, error)
+
+	// RevokeKeys removes the keys with the provided IDs that are issued by the user identified by the provided key.
+	RevokeKeys(token string, ids []string) error
+
+	// CreateRules registers new rules and returns them.
+	CreateRules(rules []Rule, token string) ([]Rule, error)
+
+	// Rules returns page of rules.
+	Rules(token string, offset, limit uint64) (RulesPage, error)
+
+	// RulesByChannel returns page of rules defined for the specified channel.
+	RulesByChannel(chanID, token string, offset, limit uint64) (RulesPage, error)
+
+	// Rule returns rule data by id.
+	Rule(id, token string) (Rule, error)
+
+	// UpdateRule updates existing rule.
+	UpdateRule(rule Rule, token string) error
+
+	// DeleteRules removes existing rules.
+	DeleteRules(ids []string, token string) error
 }
 
 type mfSDK struct {
@@ -316,6 +378,7 @@ type mfSDK struct {
 	certsURL       string
 	httpAdapterURL string
 	readerURL      string
+	rulesURL       string
 	thingsURL      string
 	usersURL       string
 
@@ -330,6 +393,7 @@ type Config struct {
 	CertsURL       string
 	HTTPAdapterURL string
 	ReaderURL      string
+	RulesURL       string
 	ThingsURL      string
 	UsersURL       string
 
@@ -345,6 +409,7 @@ func NewSDK(conf Config) SDK {
 		certsURL:       conf.CertsURL,
 		httpAdapterURL: conf.HTTPAdapterURL,
 		readerURL:      conf.ReaderURL,
+		rulesURL:       conf.RulesURL,
 		thingsURL:      conf.ThingsURL,
 		usersURL:       conf.UsersURL,
 
@@ -49,7 +49,7 @@ func newThingsService() things.Service {
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
-	groupsRepo := thmocks.NewGroupRepository()
+	groupsRepo := thmocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
@@ -808,7 +808,7 @@ func TestConnectThing(t *testing.T) {
 	err = mainfluxSDK.AssignThing([]string{thingID}, gr, token)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = mainfluxSDK.AssignChannel([]string{chanID1, chanID2}, gr, token)
+	err = mainfluxSDK.AssignChannel([]string{chanID1}, gr, token)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	cases := []struct {
@@ -916,7 +916,7 @@ func TestConnect(t *testing.T) {
 	err = mainfluxSDK.AssignThing([]string{thingID}, gr, token)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = mainfluxSDK.AssignChannel([]string{chanID1, chanID2}, gr, token)
+	err = mainfluxSDK.AssignChannel([]string{chanID1}, gr, token)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	cases := []struct {
@@ -1032,7 +1032,7 @@ func TestDisconnect(t *testing.T) {
 	err = mainfluxSDK.Connect(connIDs, token)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	chanID2, err := mainfluxSDK.CreateChannel(ch2, otherToken)
+	chanID2, err := mainfluxSDK.CreateChannel(ch3, otherToken)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	cases := []struct {
@@ -58,6 +58,8 @@ func (sdk mfSDK) RegisterUser(u User) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	// The verification link sent to the user email points to the users URL.
+	req.Header.Set("Referer", sdk.usersURL)
 
 	resp, err := sdk.sendRequest(req, "", string(CTJSON))
 	if err != nil {
@@ -41,12 +41,13 @@ var (
 func newUserService() users.Service {
 	usersRepo := usmocks.NewUserRepository(usersList)
 	hasher := usmocks.NewHasher()
+	cipher := usmocks.NewCipher()
 	idProvider := uuid.New()
 	admin.ID, _ = idProvider.ID()
 	auth := mocks.NewAuthService(admin.ID, usersList)
 	emailer := usmocks.NewEmailer()
 
-	return users.New(usersRepo, hasher, auth, emailer, idProvider, passRegex)
+	return users.New(usersRepo, hasher, cipher, auth, emailer, idProvider, passRegex, nil, nil)
 }
 
 func newUserServer(svc users.Service) *httptest.Server {
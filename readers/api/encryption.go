@@ -0,0 +1,162 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+var _ readers.MessageRepository = (*encryptionMiddleware)(nil)
+
+type encryptionMiddleware struct {
+	svc  encryption.Service
+	repo readers.MessageRepository
+}
+
+// EncryptionMiddleware decrypts the messages encrypted by the writers, and
+// encrypts the restored messages of the encrypted channels the same way the
+// writers do.
+func EncryptionMiddleware(repo readers.MessageRepository, svc encryption.Service) readers.MessageRepository {
+	return &encryptionMiddleware{
+		svc:  svc,
+		repo: repo,
+	}
+}
+
+// ListChannelMessages fails if the messages of the encrypted channel are
+// filtered by their values, since the database can't compare the encrypted
+// values, so the filter would silently match no messages.
+func (em *encryptionMiddleware) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	if rpm.Value != 0 || rpm.StringValue != "" || rpm.DataValue != "" {
+		encrypted, err := em.svc.Encrypted(context.Background(), chanID)
+		if err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		if encrypted {
+			return readers.MessagesPage{}, readers.ErrEncryptedFilter
+		}
+	}
+
+	page, err := em.repo.ListChannelMessages(chanID, rpm)
+	if err != nil {
+		return page, err
+	}
+
+	return em.decrypt(page)
+}
+
+func (em *encryptionMiddleware) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	page, err := em.repo.ListAllMessages(rpm)
+	if err != nil {
+		return page, err
+	}
+
+	return em.decrypt(page)
+}
+
+func (em *encryptionMiddleware) Backup(rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	page, err := em.repo.Backup(rpm)
+	if err != nil {
+		return page, err
+	}
+
+	return em.decrypt(page)
+}
+
+// Restore encrypts only the messages of the channels which already have a
+// data key, so that restoring a backup doesn't encrypt the channels which
+// were never encrypted.
+func (em *encryptionMiddleware) Restore(ctx context.Context, messages ...senml.Message) error {
+	encrypted := make(map[string]bool)
+	enc := make([]senml.Message, len(messages))
+	for i, msg := range messages {
+		e, ok := encrypted[msg.Channel]
+		if !ok {
+			var err error
+			if e, err = em.svc.Encrypted(ctx, msg.Channel); err != nil {
+				return err
+			}
+			encrypted[msg.Channel] = e
+		}
+		if !e {
+			enc[i] = msg
+			continue
+		}
+
+		var err error
+		if enc[i], err = encryption.EncryptSenML(ctx, em.svc, msg); err != nil {
+			return err
+		}
+	}
+
+	return em.repo.Restore(ctx, enc...)
+}
+
+// AggregateChannelMessages fails for the encrypted channels, since the
+// encrypted values are not readable by the database, so the aggregation
+// would silently skip them.
+func (em *encryptionMiddleware) AggregateChannelMessages(chanID string, rpm readers.PageMetadata) (readers.AggregationPage, error) {
+	encrypted, err := em.svc.Encrypted(context.Background(), chanID)
+	if err != nil {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	if encrypted {
+		return readers.AggregationPage{}, readers.ErrEncryptedAggregation
+	}
+
+	return em.repo.AggregateChannelMessages(chanID, rpm)
+}
+
+func (em *encryptionMiddleware) decrypt(page readers.MessagesPage) (readers.MessagesPage, error) {
+	ctx := context.Background()
+
+	msgs := make([]readers.Message, len(page.Messages))
+	for i, msg := range page.Messages {
+		switch m := msg.(type) {
+		case senml.Message:
+			dec, err := encryption.DecryptSenML(ctx, em.svc, m)
+			if err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			msgs[i] = dec
+		case map[string]interface{}:
+			dec, err := em.decryptJSON(ctx, m)
+			if err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			msgs[i] = dec
+		default:
+			msgs[i] = msg
+		}
+	}
+	page.Messages = msgs
+
+	return page, nil
+}
+
+func (em *encryptionMiddleware) decryptJSON(ctx context.Context, msg map[string]interface{}) (map[string]interface{}, error) {
+	pld, ok := msg["payload"].(map[string]interface{})
+	if !ok {
+		return msg, nil
+	}
+	chanID, _ := msg["channel"].(string)
+
+	dec, err := encryption.DecryptPayload(ctx, em.svc, chanID, pld)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]interface{}, len(msg))
+	for k, v := range msg {
+		ret[k] = v
+	}
+	ret["payload"] = dec
+
+	return ret, nil
+}
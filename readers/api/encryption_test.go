@@ -0,0 +1,147 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/encryption"
+	encmocks "github.com/MainfluxLabs/mainflux/pkg/encryption/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/readers/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var masterKey = encryption.MasterKey{ID: "mk1", Key: bytes.Repeat([]byte{1}, encryption.MasterKeySize)}
+
+// pageRepository returns the same page of messages for every request, and
+// keeps the restored messages.
+type pageRepository struct {
+	readers.MessageRepository
+	page     readers.MessagesPage
+	restored []senml.Message
+}
+
+func (repo *pageRepository) ListChannelMessages(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return repo.page, nil
+}
+
+func (repo *pageRepository) ListAllMessages(rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return repo.page, nil
+}
+
+func (repo *pageRepository) Backup(rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	return repo.page, nil
+}
+
+func (repo *pageRepository) Restore(ctx context.Context, messages ...senml.Message) error {
+	repo.restored = append(repo.restored, messages...)
+	return nil
+}
+
+func (repo *pageRepository) AggregateChannelMessages(chanID string, rpm readers.PageMetadata) (readers.AggregationPage, error) {
+	return readers.AggregationPage{}, nil
+}
+
+func TestEncryptionMiddleware(t *testing.T) {
+	kr, err := encryption.NewKeyring(masterKey)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	keys := encryption.New(encmocks.NewKeyRepository(), kr, uuid.NewMock())
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	senmlMsg := senml.Message{Channel: chanID, Name: msgName, Value: &v, Time: 1}
+	payload := map[string]interface{}{"temperature": v}
+	jsonMsg := map[string]interface{}{"channel": chanID, "payload": payload}
+
+	repo := &pageRepository{}
+	em := api.EncryptionMiddleware(repo, keys)
+
+	_, err = keys.RotateDataKey(context.Background(), chanID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	plainMsg := senml.Message{Channel: "plain", Name: msgName, Value: &v, Time: 1}
+	err = em.Restore(context.Background(), senmlMsg, plainMsg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, repo.restored, 2)
+	assert.Nil(t, repo.restored[0].Value, "expected no plaintext value")
+	require.NotNil(t, repo.restored[0].DataValue, "expected encrypted data value")
+	assert.Equal(t, plainMsg, repo.restored[1], "expected plaintext message of channel without data key")
+	encrypted, err := keys.Encrypted(context.Background(), "plain")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.False(t, encrypted, "expected restore not to encrypt channel without data key")
+
+	encPayload, err := encryption.EncryptPayload(context.Background(), keys, chanID, payload)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	encJSON := map[string]interface{}{"channel": chanID, "payload": encPayload}
+
+	plain := senml.Message{Channel: chanID, Name: msgName, BoolValue: &vb}
+	repo.page = readers.MessagesPage{
+		Total:    3,
+		Messages: []readers.Message{repo.restored[0], encJSON, plain},
+	}
+	expected := []readers.Message{senmlMsg, jsonMsg, plain}
+
+	cases := []struct {
+		desc string
+		list func() (readers.MessagesPage, error)
+	}{
+		{
+			desc: "list channel messages",
+			list: func() (readers.MessagesPage, error) { return em.ListChannelMessages(chanID, readers.PageMetadata{}) },
+		},
+		{
+			desc: "list all messages",
+			list: func() (readers.MessagesPage, error) { return em.ListAllMessages(readers.PageMetadata{}) },
+		},
+		{
+			desc: "backup messages",
+			list: func() (readers.MessagesPage, error) { return em.Backup(readers.PageMetadata{}) },
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := tc.list()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, uint64(3), page.Total, fmt.Sprintf("%s: expected total 3 got %d", tc.desc, page.Total))
+		assert.Equal(t, expected, page.Messages, fmt.Sprintf("%s: expected %v got %v", tc.desc, expected, page.Messages))
+	}
+
+	// The messages of a channel can't be decrypted with the data key of
+	// another channel.
+	forged := repo.restored[0]
+	forged.Channel = "other"
+	repo.page = readers.MessagesPage{Messages: []readers.Message{forged}}
+	_, err = em.ListAllMessages(readers.PageMetadata{})
+	assert.NotNil(t, err, "expected error decrypting message of another channel")
+
+	_, err = em.AggregateChannelMessages(chanID, readers.PageMetadata{})
+	assert.True(t, errors.Contains(err, readers.ErrEncryptedAggregation), fmt.Sprintf("aggregate encrypted channel messages: expected %s got %s", readers.ErrEncryptedAggregation, err))
+	_, err = em.AggregateChannelMessages("plain", readers.PageMetadata{})
+	assert.Nil(t, err, fmt.Sprintf("aggregate plaintext channel messages: unexpected error: %s", err))
+
+	filters := []struct {
+		desc string
+		rpm  readers.PageMetadata
+	}{
+		{desc: "value", rpm: readers.PageMetadata{Value: v}},
+		{desc: "string value", rpm: readers.PageMetadata{StringValue: "value"}},
+		{desc: "data value", rpm: readers.PageMetadata{DataValue: "value"}},
+	}
+	repo.page = readers.MessagesPage{}
+	for _, f := range filters {
+		_, err = em.ListChannelMessages(chanID, f.rpm)
+		assert.True(t, errors.Contains(err, readers.ErrEncryptedFilter), fmt.Sprintf("filter encrypted channel messages by %s: expected %s got %s", f.desc, readers.ErrEncryptedFilter, err))
+		_, err = em.ListChannelMessages("plain", f.rpm)
+		assert.Nil(t, err, fmt.Sprintf("filter plaintext channel messages by %s: unexpected error: %s", f.desc, err))
+	}
+}
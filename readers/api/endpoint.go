@@ -43,6 +43,19 @@ func ListChannelMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoin
 			return nil, errors.Wrap(errors.ErrAuthorization, err)
 		}
 
+		if req.pageMeta.Aggregation != "" {
+			page, err := svc.AggregateChannelMessages(req.chanID, req.pageMeta)
+			if err != nil {
+				return nil, err
+			}
+
+			return aggregateMessagesRes{
+				PageMetadata: page.PageMetadata,
+				Total:        page.Total,
+				Buckets:      page.Buckets,
+			}, nil
+		}
+
 		page, err := svc.ListChannelMessages(req.chanID, req.pageMeta)
 		if err != nil {
 			return nil, err
@@ -52,6 +65,31 @@ func ListChannelMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoin
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
 			Messages:     page.Messages,
+			Next:         page.Next,
+		}, nil
+	}
+}
+
+func exportChannelMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(exportChannelMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := authorize(ctx, req.token, req.key, req.chanID); err != nil {
+			return nil, errors.Wrap(errors.ErrAuthorization, err)
+		}
+
+		// Messages are streamed in chunks using the page cursor.
+		pm := req.pageMeta
+		pm.Limit = exportChunkSize
+
+		return exportRes{
+			svc:        svc,
+			chanID:     req.chanID,
+			pageMeta:   pm,
+			exportType: req.exportType,
 		}, nil
 	}
 }
@@ -77,6 +115,7 @@ func listAllMessagesEndpoint(svc readers.MessageRepository) endpoint.Endpoint {
 			PageMetadata: page.PageMetadata,
 			Total:        page.Total,
 			Messages:     page.Messages,
+			Next:         page.Next,
 		}, nil
 	}
 }
@@ -149,23 +188,7 @@ func generateCSV(page readers.MessagesPage) ([]byte, error) {
 func convertSenMLToCSV(page readers.MessagesPage, writer *csv.Writer) error {
 	for _, msg := range page.Messages {
 		if m, ok := msg.(senml.Message); ok {
-			row := []string{
-				m.Channel,
-				m.Subtopic,
-				m.Publisher,
-				m.Protocol,
-				m.Name,
-				m.Unit,
-				getValue(m.Value, ""),
-				getValue(m.StringValue, ""),
-				getValue(m.BoolValue, ""),
-				getValue(m.DataValue, ""),
-				getValue(m.Sum, ""),
-				fmt.Sprintf("%v", m.Time),
-				fmt.Sprintf("%v", m.UpdateTime),
-			}
-
-			if err := writer.Write(row); err != nil {
+			if err := writer.Write(senmlRow(m)); err != nil {
 				return err
 			}
 		}
@@ -173,6 +196,23 @@ func convertSenMLToCSV(page readers.MessagesPage, writer *csv.Writer) error {
 	return nil
 }
 
+func senmlRow(m senml.Message) []string {
+	return []string{
+		m.Channel,
+		m.Subtopic,
+		m.Publisher,
+		m.Protocol,
+		m.Name,
+		m.Unit,
+		getValue(m.Value, ""),
+		getValue(m.StringValue, ""),
+		getValue(m.BoolValue, ""),
+		getValue(m.DataValue, ""),
+		getValue(m.Sum, ""),
+		fmt.Sprintf("%v", m.Time),
+		fmt.Sprintf("%v", m.UpdateTime),
+	}
+}
 
 func getValue(ptr interface{}, defaultValue string) string {
 	switch v := ptr.(type) {
@@ -4,6 +4,7 @@
 package api_test
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -22,6 +23,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/readers"
 	"github.com/MainfluxLabs/mainflux/readers/api"
 	rmocks "github.com/MainfluxLabs/mainflux/readers/mocks"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
 	"github.com/MainfluxLabs/mainflux/users"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,6 +43,7 @@ const (
 	msgName       = "temperature"
 	validPass     = "password"
 	adminID       = "1"
+	exportChunk   = 1000
 )
 
 var (
@@ -792,6 +795,270 @@ func TestListChannelMessages(t *testing.T) {
 	}
 }
 
+func TestAggregateChannelMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+
+	authSvc := newAuthService()
+	tok, err := authSvc.Issue(context.Background(), &mainflux.IssueReq{Email: user.Email, Type: 0})
+	require.Nil(t, err, fmt.Sprintf("issue token for user got unexpected error: %s", err))
+	userToken := tok.GetValue()
+	identity, err := authSvc.Identify(context.Background(), &mainflux.Token{Value: userToken})
+	require.Nil(t, err, fmt.Sprintf("identify user got unexpected error: %s", err))
+
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{identity.GetId(): chanID}, nil)
+
+	repo := rmocks.NewMessageRepository(chanID, fromSenml(messages))
+	readerstest.TestAggregation(t, repo, chanID, messages)
+
+	ts := newServer(repo, thSvc, authSvc)
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		url      string
+		token    string
+		key      string
+		status   int
+		pageMeta readers.PageMetadata
+	}{
+		{
+			desc:     "aggregate messages by minute as thing",
+			url:      fmt.Sprintf("%s/channels/%s/messages?aggregation=avg&interval=1m", ts.URL, chanID),
+			key:      thingToken,
+			status:   http.StatusOK,
+			pageMeta: readers.PageMetadata{Limit: 10, Aggregation: readers.AggregationAvg, Interval: readers.IntervalMinute},
+		},
+		{
+			desc:     "aggregate messages by hour with offset and limit as user",
+			url:      fmt.Sprintf("%s/channels/%s/messages?aggregation=count&interval=1h&offset=1&limit=2", ts.URL, chanID),
+			token:    userToken,
+			status:   http.StatusOK,
+			pageMeta: readers.PageMetadata{Offset: 1, Limit: 2, Aggregation: readers.AggregationCount, Interval: readers.IntervalHour},
+		},
+		{
+			desc:     "aggregate messages by day with name as user",
+			url:      fmt.Sprintf("%s/channels/%s/messages?aggregation=max&interval=1d&name=%s", ts.URL, chanID, messages[0].Name),
+			token:    userToken,
+			status:   http.StatusOK,
+			pageMeta: readers.PageMetadata{Limit: 10, Name: messages[0].Name, Aggregation: readers.AggregationMax, Interval: readers.IntervalDay},
+		},
+		{
+			desc:   "aggregate messages with invalid aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=%s&interval=1m", ts.URL, chanID, invalid),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "aggregate messages with invalid interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=sum&interval=%s", ts.URL, chanID, invalid),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "aggregate messages without interval",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=sum", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "aggregate messages with interval only",
+			url:    fmt.Sprintf("%s/channels/%s/messages?interval=1m", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "aggregate JSON messages",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=sum&interval=1m&format=json", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "aggregate messages with invalid token",
+			url:    fmt.Sprintf("%s/channels/%s/messages?aggregation=sum&interval=1m", ts.URL, chanID),
+			token:  invalid,
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.token,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var page aggregationPageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+
+		expected, err := repo.AggregateChannelMessages(chanID, tc.pageMeta)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, expected.Total, page.Total, fmt.Sprintf("%s: expected %d got %d", tc.desc, expected.Total, page.Total))
+		assert.Equal(t, expected.Buckets, page.Buckets, fmt.Sprintf("%s: expected buckets %v got %v", tc.desc, expected.Buckets, page.Buckets))
+	}
+}
+
+func TestListChannelMessagesCursor(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{user.ID: chanID}, nil)
+	repo := rmocks.NewMessageRepository(chanID, fromSenml(messages))
+	readerstest.TestCursor(t, repo, chanID, messages)
+
+	ts := newServer(repo, thSvc, newAuthService())
+	defer ts.Close()
+
+	var read []senml.Message
+	url := fmt.Sprintf("%s/channels/%s/messages?limit=25", ts.URL, chanID)
+	for {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    url,
+			key:    thingToken,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		require.Equal(t, http.StatusOK, res.StatusCode, fmt.Sprintf("expected %d got %d", http.StatusOK, res.StatusCode))
+
+		var page pageRes
+		err = json.NewDecoder(res.Body).Decode(&page)
+		require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+		read = append(read, page.Messages...)
+		if page.Next == "" {
+			break
+		}
+		url = fmt.Sprintf("%s/channels/%s/messages?limit=25&cursor=%s", ts.URL, chanID, page.Next)
+	}
+	assert.Equal(t, messages, read, fmt.Sprintf("expected messages %v got %v", messages, read))
+
+	req := testRequest{
+		client: ts.Client(),
+		method: http.MethodGet,
+		url:    fmt.Sprintf("%s/channels/%s/messages?cursor=%s", ts.URL, chanID, invalid),
+		key:    thingToken,
+	}
+	res, err := req.make()
+	require.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode, fmt.Sprintf("invalid cursor: expected %d got %d", http.StatusBadRequest, res.StatusCode))
+}
+
+func TestExportChannelMessages(t *testing.T) {
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	// Export more messages than fit into a single chunk.
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+	for len(messages) <= exportChunk {
+		messages = append(messages, messages...)
+	}
+
+	thSvc := thmocks.NewThingsServiceClient(map[string]string{user.ID: chanID}, nil)
+	repo := rmocks.NewMessageRepository(chanID, fromSenml(messages))
+	ts := newServer(repo, thSvc, newAuthService())
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		url         string
+		key         string
+		status      int
+		contentType string
+		lines       int
+	}{
+		{
+			desc:        "export messages as NDJSON",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			key:         thingToken,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       len(messages),
+		},
+		{
+			desc:        "export messages as CSV",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?type=csv", ts.URL, chanID),
+			key:         thingToken,
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			lines:       len(messages) + 1,
+		},
+		{
+			desc:        "export messages with name",
+			url:         fmt.Sprintf("%s/channels/%s/messages/export?name=%s", ts.URL, chanID, messages[0].Name),
+			key:         thingToken,
+			status:      http.StatusOK,
+			contentType: "application/x-ndjson",
+			lines:       len(messages) / 2,
+		},
+		{
+			desc:   "export messages with invalid type",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?type=%s", ts.URL, chanID, invalid),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with aggregation",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?aggregation=avg&interval=1m", ts.URL, chanID),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages with invalid cursor",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export?cursor=%s", ts.URL, chanID, invalid),
+			key:    thingToken,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "export messages without key",
+			url:    fmt.Sprintf("%s/channels/%s/messages/export", ts.URL, chanID),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			key:    tc.key,
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+		assert.Equal(t, tc.contentType, res.Header.Get("Content-Type"), fmt.Sprintf("%s: expected content type %s got %s", tc.desc, tc.contentType, res.Header.Get("Content-Type")))
+
+		lines := 0
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			lines++
+		}
+		assert.Equal(t, tc.lines, lines, fmt.Sprintf("%s: expected %d lines got %d", tc.desc, tc.lines, lines))
+	}
+}
+
 func TestListAllMessages(t *testing.T) {
 	pubID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
@@ -1202,6 +1469,13 @@ type pageRes struct {
 	readers.PageMetadata
 	Total    uint64          `json:"total"`
 	Messages []senml.Message `json:"messages,omitempty"`
+	Next     string          `json:"next,omitempty"`
+}
+
+type aggregationPageRes struct {
+	readers.PageMetadata
+	Total   uint64           `json:"total"`
+	Buckets []readers.Bucket `json:"buckets"`
 }
 
 func fromSenml(in []senml.Message) []readers.Message {
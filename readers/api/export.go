@@ -0,0 +1,110 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+)
+
+const (
+	ndjsonType        = "ndjson"
+	csvType           = "csv"
+	ndjsonContentType = "application/x-ndjson"
+	csvContentType    = "text/csv"
+	exportChunkSize   = 1000
+)
+
+var jsonHeader = []string{
+	"channel",
+	"subtopic",
+	"publisher",
+	"protocol",
+	"created",
+	"payload",
+}
+
+// exportWriter writes chunks of the exported messages.
+type exportWriter interface {
+	write(msgs []readers.Message) error
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) exportWriter {
+	return ndjsonWriter{enc: json.NewEncoder(w)}
+}
+
+func (nw ndjsonWriter) write(msgs []readers.Message) error {
+	for _, msg := range msgs {
+		if err := nw.enc.Encode(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type csvWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVWriter(w io.Writer) exportWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *csvWriter) write(msgs []readers.Message) error {
+	for _, msg := range msgs {
+		var row []string
+		switch m := msg.(type) {
+		case senml.Message:
+			if err := cw.writeHeader(header); err != nil {
+				return err
+			}
+			row = senmlRow(m)
+		case map[string]interface{}:
+			if err := cw.writeHeader(jsonHeader); err != nil {
+				return err
+			}
+			pld, err := json.Marshal(m["payload"])
+			if err != nil {
+				return err
+			}
+			row = []string{
+				fmt.Sprintf("%v", m["channel"]),
+				fmt.Sprintf("%v", m["subtopic"]),
+				fmt.Sprintf("%v", m["publisher"]),
+				fmt.Sprintf("%v", m["protocol"]),
+				fmt.Sprintf("%v", m["created"]),
+				string(pld),
+			}
+		default:
+			continue
+		}
+
+		if err := cw.w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvWriter) writeHeader(h []string) error {
+	if cw.wroteHeader {
+		return nil
+	}
+	cw.wroteHeader = true
+
+	return cw.w.Write(h)
+}
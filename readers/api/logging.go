@@ -81,3 +81,16 @@ func (lm *loggingMiddleware) Restore(ctx context.Context, messages ...senml.Mess
 
 	return lm.svc.Restore(ctx, messages...)
 }
+
+func (lm *loggingMiddleware) AggregateChannelMessages(chanID string, rpm readers.PageMetadata) (page readers.AggregationPage, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method aggregate_channel_messages for channel %s with query %v took %s to complete", chanID, rpm, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.AggregateChannelMessages(chanID, rpm)
+}
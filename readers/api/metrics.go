@@ -66,3 +66,12 @@ func (mm *metricsMiddleware) Restore(ctx context.Context, messages ...senml.Mess
 
 	return mm.svc.Restore(ctx, messages...)
 }
+
+func (mm *metricsMiddleware) AggregateChannelMessages(chanID string, rpm readers.PageMetadata) (readers.AggregationPage, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "aggregate_channel_messages").Add(1)
+		mm.latency.With("method", "aggregate_channel_messages").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.AggregateChannelMessages(chanID, rpm)
+}
@@ -44,9 +44,42 @@ func (req listChannelMessagesReq) validate() error {
 		return apiutil.ErrInvalidComparator
 	}
 
+	if req.pageMeta.Aggregation == "" {
+		if req.pageMeta.Interval != "" {
+			return apiutil.ErrInvalidAggregation
+		}
+		return nil
+	}
+
+	if !readers.ValidAggregation(req.pageMeta.Aggregation) ||
+		(req.pageMeta.Format != "" && req.pageMeta.Format != defFormat) {
+		return apiutil.ErrInvalidAggregation
+	}
+
+	if readers.IntervalSeconds(req.pageMeta.Interval) == 0 {
+		return apiutil.ErrInvalidInterval
+	}
+
 	return nil
 }
 
+type exportChannelMessagesReq struct {
+	listChannelMessagesReq
+	exportType string
+}
+
+func (req exportChannelMessagesReq) validate() error {
+	if req.exportType != ndjsonType && req.exportType != csvType {
+		return apiutil.ErrInvalidExportType
+	}
+
+	if req.pageMeta.Aggregation != "" || req.pageMeta.Interval != "" {
+		return apiutil.ErrInvalidAggregation
+	}
+
+	return req.listChannelMessagesReq.validate()
+}
+
 type listAllMessagesReq struct {
 	token    string
 	key      string
@@ -12,6 +12,7 @@ import (
 
 var (
 	_ mainflux.Response = (*listMessagesRes)(nil)
+	_ mainflux.Response = (*aggregateMessagesRes)(nil)
 	_ mainflux.Response = (*restoreMessagesRes)(nil)
 )
 
@@ -19,6 +20,7 @@ type listMessagesRes struct {
 	readers.PageMetadata
 	Total    uint64            `json:"total"`
 	Messages []readers.Message `json:"messages,omitempty"`
+	Next     string            `json:"next,omitempty"`
 }
 
 func (res listMessagesRes) Headers() map[string]string {
@@ -33,6 +35,24 @@ func (res listMessagesRes) Empty() bool {
 	return false
 }
 
+type aggregateMessagesRes struct {
+	readers.PageMetadata
+	Total   uint64           `json:"total"`
+	Buckets []readers.Bucket `json:"buckets"`
+}
+
+func (res aggregateMessagesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res aggregateMessagesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res aggregateMessagesRes) Empty() bool {
+	return false
+}
+
 type restoreMessagesRes struct{}
 
 func (res restoreMessagesRes) Code() int {
@@ -62,3 +82,10 @@ func (res backupFileRes) Headers() map[string]string {
 func (res backupFileRes) Empty() bool {
 	return false
 }
+
+type exportRes struct {
+	svc        readers.MessageRepository
+	chanID     string
+	pageMeta   readers.PageMetadata
+	exportType string
+}
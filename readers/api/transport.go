@@ -6,6 +6,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -37,6 +38,10 @@ const (
 	comparatorKey          = "comparator"
 	fromKey                = "from"
 	toKey                  = "to"
+	aggregationKey         = "aggregation"
+	intervalKey            = "interval"
+	cursorKey              = "cursor"
+	typeKey                = "type"
 	defLimit               = 10
 	defOffset              = 0
 	defFormat              = "messages"
@@ -63,6 +68,12 @@ func MakeHandler(svc readers.MessageRepository, tc mainflux.ThingsServiceClient,
 		encodeResponse,
 		opts...,
 	))
+	mux.Get("/channels/:chanID/messages/export", kithttp.NewServer(
+		exportChannelMessagesEndpoint(svc),
+		decodeExportChannelMessages,
+		encodeExportResponse,
+		opts...,
+	))
 	mux.Get("/messages", kithttp.NewServer(
 		listAllMessagesEndpoint(svc),
 		decodeListAllMessages,
@@ -154,6 +165,16 @@ func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{
 		return nil, err
 	}
 
+	aggregation, err := apiutil.ReadStringQuery(r, aggregationKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	interval, err := apiutil.ReadStringQuery(r, intervalKey, "")
+	if err != nil {
+		return nil, err
+	}
+
 	req := listChannelMessagesReq{
 		chanID: bone.GetValue(r, "chanID"),
 		token:  apiutil.ExtractBearerToken(r),
@@ -172,6 +193,8 @@ func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{
 			DataValue:   vd,
 			From:        from,
 			To:          to,
+			Aggregation: aggregation,
+			Interval:    interval,
 		},
 	}
 
@@ -183,9 +206,30 @@ func decodeListChannelMessages(ctx context.Context, r *http.Request) (interface{
 		req.pageMeta.BoolValue = vb
 	}
 
+	if req.pageMeta.Cursor, err = apiutil.ReadStringQuery(r, cursorKey, ""); err != nil {
+		return nil, err
+	}
+
 	return req, nil
 }
 
+func decodeExportChannelMessages(ctx context.Context, r *http.Request) (interface{}, error) {
+	req, err := decodeListChannelMessages(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := apiutil.ReadStringQuery(r, typeKey, ndjsonType)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportChannelMessagesReq{
+		listChannelMessagesReq: req.(listChannelMessagesReq),
+		exportType:             t,
+	}, nil
+}
+
 func decodeListAllMessages(ctx context.Context, r *http.Request) (interface{}, error) {
 	offset, err := apiutil.ReadUintQuery(r, offsetKey, defOffset)
 	if err != nil {
@@ -280,6 +324,10 @@ func decodeListAllMessages(ctx context.Context, r *http.Request) (interface{}, e
 		req.pageMeta.BoolValue = vb
 	}
 
+	if req.pageMeta.Cursor, err = apiutil.ReadStringQuery(r, cursorKey, ""); err != nil {
+		return nil, err
+	}
+
 	return req, nil
 }
 
@@ -334,6 +382,51 @@ func encodeBackupFileResponse(_ context.Context, w http.ResponseWriter, response
 	return nil
 }
 
+func encodeExportResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(exportRes)
+
+	// Retrieve the first page before writing the headers so that
+	// the error can still be reported using the response status.
+	page, err := res.svc.ListChannelMessages(res.chanID, res.pageMeta)
+	if err != nil {
+		return err
+	}
+
+	ct := ndjsonContentType
+	if res.exportType == csvType {
+		ct = csvContentType
+	}
+	w.Header().Set("Content-Type", ct)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.%s", res.chanID, res.exportType))
+	w.WriteHeader(http.StatusOK)
+
+	var ew exportWriter = newNDJSONWriter(w)
+	if res.exportType == csvType {
+		ew = newCSVWriter(w)
+	}
+
+	flusher, _ := w.(http.Flusher)
+	for {
+		if err := ew.write(page.Messages); err != nil {
+			return nil
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		if page.Next == "" {
+			return nil
+		}
+
+		res.pageMeta.Cursor = page.Next
+		if page, err = res.svc.ListChannelMessages(res.chanID, res.pageMeta); err != nil {
+			// The status has already been sent, so the stream is
+			// terminated early as the only way to signal the failure.
+			return nil
+		}
+	}
+}
+
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
 	case errors.Contains(err, nil):
@@ -343,7 +436,11 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrLimitSize,
 		err == apiutil.ErrOffsetSize,
 		err == apiutil.ErrEmptyList,
-		err == apiutil.ErrInvalidComparator:
+		err == apiutil.ErrInvalidComparator,
+		err == apiutil.ErrInvalidAggregation,
+		err == apiutil.ErrInvalidInterval,
+		err == apiutil.ErrInvalidExportType,
+		errors.Contains(err, readers.ErrInvalidCursor):
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrAuthentication),
 		err == apiutil.ErrBearerToken:
@@ -356,7 +453,9 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 	case errors.Contains(err, errors.ErrConflict):
 		w.WriteHeader(http.StatusConflict)
-	case errors.Contains(err, errors.ErrScanMetadata):
+	case errors.Contains(err, errors.ErrScanMetadata),
+		errors.Contains(err, readers.ErrEncryptedAggregation),
+		errors.Contains(err, readers.ErrEncryptedFilter):
 		w.WriteHeader(http.StatusUnprocessableEntity)
 	case errors.Contains(err, readers.ErrReadMessages),
 		errors.Contains(err, errors.ErrCreateEntity):
@@ -382,6 +481,11 @@ func authorize(ctx context.Context, token, key, chanID string) (err error) {
 			return err
 		}
 
+		scopeReq := &mainflux.AuthorizeReq{Token: token, Subject: auth.MessagesSubject, Object: chanID, Action: auth.ReadAction}
+		if _, err := authc.Authorize(ctx, scopeReq); err != nil {
+			return err
+		}
+
 		if err := authorizeAdmin(ctx, auth.RootSubject, token); err == nil {
 			return nil
 		}
@@ -391,7 +495,7 @@ func authorize(ctx context.Context, token, key, chanID string) (err error) {
 		}
 		return nil
 	default:
-		if _, err := thingc.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: key}); err != nil {
+		if _, err := thingc.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: key, ChanID: chanID}); err != nil {
 			return err
 		}
 		return nil
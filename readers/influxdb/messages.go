@@ -26,7 +26,25 @@ const (
 
 var _ readers.MessageRepository = (*influxRepository)(nil)
 
-var errResultTime = errors.New("invalid result time")
+// keyColumn is the column used to sort the messages having the same time.
+const keyColumn = "_key"
+
+// keyExpr builds the key column value from the tags identifying the series.
+var keyExpr = `(if exists r.publisher then r.publisher else "") + "/" + (if exists r.subtopic then r.subtopic else "") + "/" + (if exists r.name then r.name else "")`
+
+var (
+	errResultTime         = errors.New("invalid result time")
+	errResultValue        = errors.New("invalid result value")
+	errInvalidAggregation = errors.New("invalid aggregation")
+)
+
+var aggregations = map[string]string{
+	readers.AggregationMin:   "min",
+	readers.AggregationMax:   "max",
+	readers.AggregationAvg:   "mean",
+	readers.AggregationSum:   "sum",
+	readers.AggregationCount: "count",
+}
 
 type RepoConfig struct {
 	Bucket string
@@ -57,6 +75,66 @@ func (repo *influxRepository) Backup(rpm readers.PageMetadata) (readers.Messages
 	return repo.readAll("", rpm)
 }
 
+func (repo *influxRepository) AggregateChannelMessages(chanID string, rpm readers.PageMetadata) (readers.AggregationPage, error) {
+	fn, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, errInvalidAggregation)
+	}
+
+	condition, timeRange := fmtCondition(chanID, rpm)
+
+	var sb strings.Builder
+	sb.WriteString(`import "influxdata/influxdb/v1"`)
+	sb.WriteString(fmt.Sprintf(`from(bucket: "%s")`, repo.cfg.Bucket))
+	sb.WriteString(timeRange)
+	sb.WriteString(`|> v1.fieldsAsCols()`)
+	sb.WriteString(`|> group()`)
+	sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r._measurement == "%s")`, defMeasurement))
+	sb.WriteString(condition)
+	sb.WriteString(`|> filter(fn: (r) => exists r.value)`)
+	sb.WriteString(fmt.Sprintf(`|> aggregateWindow(every: %s, fn: %s, column: "value", timeSrc: "_start", createEmpty: false)`, rpm.Interval, fn))
+	buckets := sb.String()
+
+	sb.WriteString(`|> sort(columns: ["_time"], desc: true)`)
+	if rpm.Limit != noLimit {
+		sb.WriteString(fmt.Sprintf(`|> limit(n:%d,offset:%d)`, rpm.Limit, rpm.Offset))
+	}
+	sb.WriteString(`|> yield(name: "aggregate")`)
+
+	queryAPI := repo.client.QueryAPI(repo.cfg.Org)
+	resp, err := queryAPI.Query(context.Background(), sb.String())
+	if err != nil {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+
+	page := readers.AggregationPage{
+		PageMetadata: rpm,
+		Buckets:      []readers.Bucket{},
+	}
+	for resp.Next() {
+		b, err := parseBucket(resp.Record().Values())
+		if err != nil {
+			return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Buckets = append(page.Buckets, b)
+	}
+	if resp.Err() != nil {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, resp.Err())
+	}
+
+	resp, err = queryAPI.Query(context.Background(), buckets+`|> count(column: "value")|> yield(name: "count")`)
+	if err != nil {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	if resp.Next() {
+		if total, ok := resp.Record().Values()["value"].(int64); ok {
+			page.Total = uint64(total)
+		}
+	}
+
+	return page, nil
+}
+
 func (repo *influxRepository) Restore(ctx context.Context, messages ...senml.Message) error {
 	pts, err := repo.senmlPoints(messages)
 	if err != nil {
@@ -93,6 +171,13 @@ func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (
 	queryAPI := repo.client.QueryAPI(repo.cfg.Org)
 	var sb strings.Builder
 
+	var pos position
+	if rpm.Cursor != "" {
+		if err := readers.DecodeCursor(rpm.Cursor, &pos); err != nil {
+			return readers.MessagesPage{}, err
+		}
+	}
+
 	condition, timeRange := fmtCondition(chanID, rpm)
 	sb.WriteString(`import "influxdata/influxdb/v1"`)
 	sb.WriteString(fmt.Sprintf(`from(bucket: "%s")`, repo.cfg.Bucket))
@@ -103,8 +188,16 @@ func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (
 	sb.WriteString(`|> group()`)
 	sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r._measurement == "%s")`, format))
 	sb.WriteString(condition)
-	sb.WriteString(`|> sort(columns: ["_time"], desc: true)`)
-	if rpm.Limit != noLimit {
+	sb.WriteString(fmt.Sprintf(`|> map(fn: (r) => ({r with %s: %s}))`, keyColumn, keyExpr))
+	if rpm.Cursor != "" {
+		sb.WriteString(fmt.Sprintf(`|> filter(fn: (r) => r._time < time(v: %d) or (r._time == time(v: %d) and r.%s < %q))`, pos.Time, pos.Time, keyColumn, pos.Key))
+	}
+	sb.WriteString(fmt.Sprintf(`|> sort(columns: ["_time", "%s"], desc: true)`, keyColumn))
+	switch {
+	case rpm.Limit == noLimit:
+	case rpm.Cursor != "":
+		sb.WriteString(fmt.Sprintf(`|> limit(n:%d)`, rpm.Limit))
+	default:
 		sb.WriteString(fmt.Sprintf(`|> limit(n:%d,offset:%d)`, rpm.Limit, rpm.Offset))
 	}
 	sb.WriteString(`|> yield(name: "sort")`)
@@ -124,6 +217,9 @@ func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (
 			return readers.MessagesPage{}, err
 		}
 		messages = append(messages, msg)
+
+		key, _ := valueMap[keyColumn].(string)
+		pos = position{Time: resp.Record().Time().UnixNano(), Key: key}
 	}
 	if resp.Err() != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, resp.Err())
@@ -140,6 +236,12 @@ func (repo *influxRepository) readAll(chanID string, rpm readers.PageMetadata) (
 		Messages:     messages,
 	}
 
+	if rpm.Limit != noLimit && uint64(len(messages)) == rpm.Limit {
+		if page.Next, err = readers.EncodeCursor(pos); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
 	return page, nil
 }
 
@@ -249,6 +351,13 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) (string, string) {
 	return sb.String(), timeRange
 }
 
+// position represents the position of the last message of the page, encoded
+// into the page cursor.
+type position struct {
+	Time int64  `json:"t"`
+	Key  string `json:"k"`
+}
+
 func parseMessage(measurement string, valueMap map[string]interface{}) (interface{}, error) {
 	switch measurement {
 	case defMeasurement:
@@ -301,6 +410,25 @@ func parseSenml(valueMap map[string]interface{}) (interface{}, error) {
 	return senmlMsg, nil
 }
 
+func parseBucket(valueMap map[string]interface{}) (readers.Bucket, error) {
+	t, ok := valueMap["_time"].(time.Time)
+	if !ok {
+		return readers.Bucket{}, errResultTime
+	}
+
+	b := readers.Bucket{Time: float64(t.UnixNano()) / 1e9}
+	switch v := valueMap["value"].(type) {
+	case float64:
+		b.Value = v
+	case int64:
+		b.Value = float64(v)
+	default:
+		return readers.Bucket{}, errResultValue
+	}
+
+	return b, nil
+}
+
 func parseJSON(valueMap map[string]interface{}) (interface{}, error) {
 	ret := make(map[string]interface{})
 	pld := make(map[string]interface{})
@@ -317,7 +445,7 @@ func parseJSON(valueMap map[string]interface{}) (interface{}, error) {
 			v := float64(t.UnixNano()) / 1e9
 			ret[name] = v
 			continue
-		case "table", "_start", "_stop", "result", "_measurement":
+		case "table", "_start", "_stop", "result", "_measurement", keyColumn:
 			break
 		default:
 			v := field
@@ -12,6 +12,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	ireader "github.com/MainfluxLabs/mainflux/readers/influxdb"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -965,3 +966,39 @@ func resetBucket() error {
 
 	return nil
 }
+
+func TestAggregateChannelMessages(t *testing.T) {
+	err := resetBucket()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	writer := iwriter.New(client, repoCfg)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := ireader.New(client, repoCfg)
+	readerstest.TestAggregation(t, reader, chanID, messages)
+}
+
+func TestListChannelMessagesCursor(t *testing.T) {
+	err := resetBucket()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	writer := iwriter.New(client, repoCfg)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := ireader.New(client, repoCfg)
+	readerstest.TestCursor(t, reader, chanID, messages)
+}
@@ -5,6 +5,8 @@ package readers
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -23,13 +25,49 @@ const (
 	GreaterThanEqualKey = "ge"
 )
 
-// ErrReadMessages indicates failure occurred while reading messages from database.
-var ErrReadMessages = errors.New("failed to read messages from database")
+// Aggregations applied to the message values of the time bucket.
+const (
+	AggregationMin   = "min"
+	AggregationMax   = "max"
+	AggregationAvg   = "avg"
+	AggregationSum   = "sum"
+	AggregationCount = "count"
+)
+
+// Intervals used to group messages into time buckets.
+const (
+	IntervalMinute = "1m"
+	IntervalHour   = "1h"
+	IntervalDay    = "1d"
+)
+
+var intervals = map[string]uint64{
+	IntervalMinute: 60,
+	IntervalHour:   60 * 60,
+	IntervalDay:    24 * 60 * 60,
+}
+
+var (
+	// ErrReadMessages indicates failure occurred while reading messages from database.
+	ErrReadMessages = errors.New("failed to read messages from database")
+
+	// ErrInvalidCursor indicates malformed page cursor.
+	ErrInvalidCursor = errors.New("invalid page cursor")
+
+	// ErrEncryptedAggregation indicates that the channel messages can't be
+	// aggregated, since their values are encrypted.
+	ErrEncryptedAggregation = errors.New("aggregation of encrypted messages is not supported")
+
+	// ErrEncryptedFilter indicates that the channel messages can't be
+	// filtered by their values, since the values are encrypted.
+	ErrEncryptedFilter = errors.New("filtering encrypted messages by value is not supported")
+)
 
 // MessageRepository specifies message reader API.
 type MessageRepository interface {
 	// ListChannelMessages skips given number of messages for given channel and returns next
-	// limited number of messages.
+	// limited number of messages. If the page metadata cursor is set, messages following
+	// the cursor are returned instead, regardless of the offset.
 	ListChannelMessages(chanID string, pm PageMetadata) (MessagesPage, error)
 
 	// ListAllMessages retrieves all messages from database.
@@ -40,6 +78,13 @@ type MessageRepository interface {
 
 	// Backup retrieves all messages from database.
 	Backup(rpm PageMetadata) (MessagesPage, error)
+
+	// AggregateChannelMessages groups SenML messages of the given channel that
+	// have a numeric value into the time buckets of the page metadata interval
+	// and applies the page metadata aggregation to the values of each bucket.
+	// Buckets are sorted by time in descending order and paginated using the
+	// page metadata offset and limit.
+	AggregateChannelMessages(chanID string, pm PageMetadata) (AggregationPage, error)
 }
 
 // Message represents any message format.
@@ -51,6 +96,23 @@ type MessagesPage struct {
 	PageMetadata
 	Total    uint64
 	Messages []Message
+	// Next is the cursor of the next page, empty if there are no more messages.
+	Next string
+}
+
+// Bucket represents the aggregated value of the messages received during the
+// interval starting at the bucket time.
+type Bucket struct {
+	Time  float64 `json:"time"`
+	Value float64 `json:"value"`
+}
+
+// AggregationPage contains page related metadata as well as list of time
+// buckets that belong to this page.
+type AggregationPage struct {
+	PageMetadata
+	Total   uint64
+	Buckets []Bucket
 }
 
 // PageMetadata represents the parameters used to create database queries
@@ -69,6 +131,9 @@ type PageMetadata struct {
 	From        float64 `json:"from,omitempty"`
 	To          float64 `json:"to,omitempty"`
 	Format      string  `json:"format,omitempty"`
+	Aggregation string  `json:"aggregation,omitempty"`
+	Interval    string  `json:"interval,omitempty"`
+	Cursor      string  `json:"cursor,omitempty"`
 }
 
 // ParseValueComparator convert comparison operator keys into mathematic anotation
@@ -92,3 +157,45 @@ func ParseValueComparator(query map[string]interface{}) string {
 
 	return comparator
 }
+
+// ValidAggregation checks whether the aggregation is supported.
+func ValidAggregation(aggregation string) bool {
+	switch aggregation {
+	case AggregationMin, AggregationMax, AggregationAvg, AggregationSum, AggregationCount:
+		return true
+	default:
+		return false
+	}
+}
+
+// IntervalSeconds returns the length of the interval in seconds, or zero if
+// the interval is not supported.
+func IntervalSeconds(interval string) uint64 {
+	return intervals[interval]
+}
+
+// EncodeCursor encodes the repository specific position of the last message
+// of the page into an opaque cursor.
+func EncodeCursor(position interface{}) (string, error) {
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor decodes the opaque cursor into the repository specific position
+// of the last message of the previous page.
+func DecodeCursor(cursor string, position interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(data, position); err != nil {
+		return ErrInvalidCursor
+	}
+
+	return nil
+}
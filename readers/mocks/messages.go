@@ -6,6 +6,8 @@ package mocks
 import (
 	"context"
 	"encoding/json"
+	"math"
+	"sort"
 	"sync"
 
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
@@ -56,6 +58,117 @@ func (repo *messageRepositoryMock) readAll(chanID string, rpm readers.PageMetada
 		return readers.MessagesPage{}, nil
 	}
 
+	offset := rpm.Offset
+	if rpm.Cursor != "" {
+		var pos position
+		if err := readers.DecodeCursor(rpm.Cursor, &pos); err != nil {
+			return readers.MessagesPage{}, err
+		}
+		offset = pos.Offset
+	}
+
+	msgs := repo.filter(chanID, rpm)
+	numOfMessages := uint64(len(msgs))
+
+	if offset >= numOfMessages {
+		return readers.MessagesPage{}, nil
+	}
+	if rpm.Limit < 0 {
+		return readers.MessagesPage{}, nil
+	}
+
+	end := offset + rpm.Limit
+	if end > numOfMessages || rpm.Limit == noLimit {
+		end = numOfMessages
+	}
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Total:        uint64(len(msgs)),
+		Messages:     msgs[offset:end],
+	}
+	if rpm.Limit != noLimit && end-offset == rpm.Limit {
+		page.Next, _ = readers.EncodeCursor(position{Offset: end})
+	}
+
+	return page, nil
+}
+
+// position represents the position of the last message of the page, encoded
+// into the page cursor.
+type position struct {
+	Offset uint64 `json:"o"`
+}
+
+func (repo *messageRepositoryMock) AggregateChannelMessages(chanID string, rpm readers.PageMetadata) (readers.AggregationPage, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	interval := float64(readers.IntervalSeconds(rpm.Interval))
+	if !readers.ValidAggregation(rpm.Aggregation) || interval == 0 {
+		return readers.AggregationPage{}, readers.ErrReadMessages
+	}
+
+	values := map[float64][]float64{}
+	for _, m := range repo.filter(chanID, rpm) {
+		msg := m.(senml.Message)
+		if msg.Value == nil {
+			continue
+		}
+		t := math.Floor(msg.Time/interval) * interval
+		values[t] = append(values[t], *msg.Value)
+	}
+
+	buckets := []readers.Bucket{}
+	for t, vals := range values {
+		buckets = append(buckets, readers.Bucket{Time: t, Value: aggregate(rpm.Aggregation, vals)})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Time > buckets[j].Time })
+
+	total := uint64(len(buckets))
+	page := readers.AggregationPage{
+		PageMetadata: rpm,
+		Total:        total,
+		Buckets:      []readers.Bucket{},
+	}
+	if rpm.Offset >= total {
+		return page, nil
+	}
+
+	end := rpm.Offset + rpm.Limit
+	if end > total || rpm.Limit == noLimit {
+		end = total
+	}
+	page.Buckets = buckets[rpm.Offset:end]
+
+	return page, nil
+}
+
+func aggregate(aggregation string, vals []float64) float64 {
+	ret := vals[0]
+	sum := 0.0
+	for _, v := range vals {
+		sum += v
+		switch {
+		case aggregation == readers.AggregationMin && v < ret,
+			aggregation == readers.AggregationMax && v > ret:
+			ret = v
+		}
+	}
+
+	switch aggregation {
+	case readers.AggregationAvg:
+		return sum / float64(len(vals))
+	case readers.AggregationSum:
+		return sum
+	case readers.AggregationCount:
+		return float64(len(vals))
+	default:
+		return ret
+	}
+}
+
+func (repo *messageRepositoryMock) filter(chanID string, rpm readers.PageMetadata) []readers.Message {
 	var query map[string]interface{}
 	meta, _ := json.Marshal(rpm)
 	json.Unmarshal(meta, &query)
@@ -158,23 +271,5 @@ func (repo *messageRepositoryMock) readAll(chanID string, rpm readers.PageMetada
 		}
 	}
 
-	numOfMessages := uint64(len(msgs))
-
-	if rpm.Offset >= numOfMessages {
-		return readers.MessagesPage{}, nil
-	}
-	if rpm.Limit < 0 {
-		return readers.MessagesPage{}, nil
-	}
-
-	end := rpm.Offset + rpm.Limit
-	if end > numOfMessages || rpm.Limit == noLimit {
-		end = numOfMessages
-	}
-
-	return readers.MessagesPage{
-		PageMetadata: rpm,
-		Total:        uint64(len(msgs)),
-		Messages:     msgs[rpm.Offset:end],
-	}, nil
+	return msgs
 }
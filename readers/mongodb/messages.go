@@ -11,6 +11,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/MainfluxLabs/mainflux/readers"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -24,6 +25,16 @@ const (
 
 var _ readers.MessageRepository = (*mongoRepository)(nil)
 
+var errInvalidAggregation = errors.New("invalid aggregation")
+
+var aggregations = map[string]string{
+	readers.AggregationMin:   "$min",
+	readers.AggregationMax:   "$max",
+	readers.AggregationAvg:   "$avg",
+	readers.AggregationSum:   "$sum",
+	readers.AggregationCount: "$sum",
+}
+
 type mongoRepository struct {
 	db *mongo.Database
 }
@@ -47,6 +58,77 @@ func (repo mongoRepository) Backup(rpm readers.PageMetadata) (readers.MessagesPa
 	return repo.readAll("", rpm)
 }
 
+func (repo mongoRepository) AggregateChannelMessages(chanID string, rpm readers.PageMetadata) (readers.AggregationPage, error) {
+	op, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, errInvalidAggregation)
+	}
+
+	var value interface{} = "$value"
+	if rpm.Aggregation == readers.AggregationCount {
+		value = 1
+	}
+
+	interval := float64(readers.IntervalSeconds(rpm.Interval))
+	bucket := bson.M{"$subtract": bson.A{"$time", bson.M{"$mod": bson.A{"$time", interval}}}}
+	buckets := mongo.Pipeline{
+		{{Key: "$match", Value: fmtCondition(chanID, rpm)}},
+		{{Key: "$match", Value: bson.M{"value": bson.M{"$ne": nil}}}},
+		{{Key: "$group", Value: bson.M{"_id": bucket, "value": bson.M{op: value}}}},
+	}
+
+	pipeline := append(mongo.Pipeline{}, buckets...)
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.M{"_id": -1}}})
+	if rpm.Limit != noLimit {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$skip", Value: int64(rpm.Offset)}},
+			bson.D{{Key: "$limit", Value: int64(rpm.Limit)}},
+		)
+	}
+
+	col := repo.db.Collection(defCollection)
+	cursor, err := col.Aggregate(context.Background(), pipeline)
+	if err != nil {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	page := readers.AggregationPage{
+		PageMetadata: rpm,
+		Buckets:      []readers.Bucket{},
+	}
+	for cursor.Next(context.Background()) {
+		var b struct {
+			Time  float64 `bson:"_id"`
+			Value float64 `bson:"value"`
+		}
+		if err := cursor.Decode(&b); err != nil {
+			return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Buckets = append(page.Buckets, readers.Bucket{Time: b.Time, Value: b.Value})
+	}
+
+	count := append(mongo.Pipeline{}, buckets...)
+	count = append(count, bson.D{{Key: "$count", Value: "total"}})
+	cursor, err = col.Aggregate(context.Background(), count)
+	if err != nil {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer cursor.Close(context.Background())
+
+	if cursor.Next(context.Background()) {
+		var res struct {
+			Total int64 `bson:"total"`
+		}
+		if err := cursor.Decode(&res); err != nil {
+			return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Total = uint64(res.Total)
+	}
+
+	return page, nil
+}
+
 func (repo mongoRepository) Restore(ctx context.Context, messages ...senml.Message) error {
 	coll := repo.db.Collection(defCollection)
 	var dbMsgs []interface{}
@@ -72,19 +154,41 @@ func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (re
 
 	col := repo.db.Collection(format)
 
-	sortMap := map[string]interface{}{
-		order: -1,
+	sortMap := bson.D{
+		{Key: order, Value: -1},
+		{Key: "_id", Value: -1},
 	}
 	// Remove format filter and format the rest properly.
 	filter := fmtCondition(chanID, rpm)
-	var cursor *mongo.Cursor
-	var err error
-	switch rpm.Limit {
-	case noLimit:
-		cursor, err = col.Find(context.Background(), filter, options.Find().SetSort(sortMap))
-	default:
-		cursor, err = col.Find(context.Background(), filter, options.Find().SetSort(sortMap).SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset)))
+	opts := options.Find().SetSort(sortMap)
+	if rpm.Limit != noLimit {
+		opts = opts.SetLimit(int64(rpm.Limit)).SetSkip(int64(rpm.Offset))
 	}
+
+	query := filter
+	if rpm.Cursor != "" {
+		var pos position
+		if err := readers.DecodeCursor(rpm.Cursor, &pos); err != nil {
+			return readers.MessagesPage{}, err
+		}
+		id, err := primitive.ObjectIDFromHex(pos.ID)
+		if err != nil {
+			return readers.MessagesPage{}, readers.ErrInvalidCursor
+		}
+
+		var t interface{} = pos.Time
+		if format != defCollection {
+			t = pos.Created
+		}
+		after := bson.E{Key: "$or", Value: bson.A{
+			bson.M{order: bson.M{"$lt": t}},
+			bson.M{order: t, "_id": bson.M{"$lt": id}},
+		}}
+		query = append(append(bson.D{}, filter...), after)
+		opts = opts.SetSkip(0)
+	}
+
+	cursor, err := col.Find(context.Background(), query, opts)
 	if err != nil {
 		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 
@@ -92,15 +196,17 @@ func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (re
 	defer cursor.Close(context.Background())
 
 	var messages []readers.Message
+	var pos position
 	switch format {
 	case defCollection:
 		for cursor.Next(context.Background()) {
-			var m senml.Message
+			var m senmlMessage
 			if err := cursor.Decode(&m); err != nil {
 				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 			}
 
-			messages = append(messages, m)
+			messages = append(messages, m.Message)
+			pos = position{Time: m.Time, ID: m.ID.Hex()}
 		}
 	default:
 		for cursor.Next(context.Background()) {
@@ -110,6 +216,7 @@ func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (re
 			}
 
 			messages = append(messages, m)
+			pos = jsonPosition(m)
 		}
 	}
 
@@ -124,6 +231,12 @@ func (repo mongoRepository) readAll(chanID string, rpm readers.PageMetadata) (re
 		Messages:     messages,
 	}
 
+	if rpm.Limit != noLimit && uint64(len(messages)) == rpm.Limit {
+		if mp.Next, err = readers.EncodeCursor(pos); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
 	return mp, nil
 }
 
@@ -183,3 +296,33 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) bson.D {
 
 	return filter
 }
+
+// position represents the position of the last message of the page, encoded
+// into the page cursor.
+type position struct {
+	Time    float64 `json:"t,omitempty"`
+	Created int64   `json:"c,omitempty"`
+	ID      string  `json:"id"`
+}
+
+type senmlMessage struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	senml.Message `bson:",inline"`
+}
+
+func jsonPosition(m map[string]interface{}) position {
+	var pos position
+	if id, ok := m["_id"].(primitive.ObjectID); ok {
+		pos.ID = id.Hex()
+	}
+	switch created := m["created"].(type) {
+	case int64:
+		pos.Created = created
+	case int32:
+		pos.Created = int64(created)
+	case float64:
+		pos.Created = int64(created)
+	}
+
+	return pos
+}
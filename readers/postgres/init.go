@@ -70,6 +70,23 @@ func migrateDB(db *sqlx.DB) error {
 					"DROP TABLE messages",
 				},
 			},
+			{
+				Id: "messages_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS data_keys (
+                        id            UUID,
+                        channel       UUID NOT NULL,
+                        master_key_id VARCHAR(254) NOT NULL,
+                        wrapped_key   BYTEA NOT NULL,
+                        created_at    TIMESTAMPTZ NOT NULL,
+                        PRIMARY KEY (id)
+                    )`,
+					`CREATE INDEX IF NOT EXISTS data_keys_channel_idx ON data_keys (channel, created_at)`,
+				},
+				Down: []string{
+					"DROP TABLE data_keys",
+				},
+			},
 		},
 	}
 
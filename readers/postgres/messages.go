@@ -30,10 +30,19 @@ const (
 var _ readers.MessageRepository = (*postgresRepository)(nil)
 
 var (
-	errInvalidMessage = errors.New("invalid message representation")
-	errTransRollback  = errors.New("failed to rollback transaction")
+	errInvalidMessage     = errors.New("invalid message representation")
+	errInvalidAggregation = errors.New("invalid aggregation")
+	errTransRollback      = errors.New("failed to rollback transaction")
 )
 
+var aggregations = map[string]string{
+	readers.AggregationMin:   "MIN",
+	readers.AggregationMax:   "MAX",
+	readers.AggregationAvg:   "AVG",
+	readers.AggregationSum:   "SUM",
+	readers.AggregationCount: "COUNT",
+}
+
 type postgresRepository struct {
 	db *sqlx.DB
 }
@@ -57,6 +66,83 @@ func (tr postgresRepository) Backup(rpm readers.PageMetadata) (readers.MessagesP
 	return tr.readAll("", rpm)
 }
 
+func (tr postgresRepository) AggregateChannelMessages(chanID string, rpm readers.PageMetadata) (readers.AggregationPage, error) {
+	fn, ok := aggregations[rpm.Aggregation]
+	if !ok {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, errInvalidAggregation)
+	}
+
+	condition := fmtCondition(chanID, rpm)
+	switch condition {
+	case "":
+		condition = "WHERE value IS NOT NULL"
+	default:
+		condition = fmt.Sprintf("%s AND value IS NOT NULL", condition)
+	}
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if rpm.Limit == noLimit {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT floor(time / :interval) * :interval AS bucket, %s(value) AS value FROM %s %s GROUP BY bucket ORDER BY bucket DESC %s;`, fn, defTable, condition, olq)
+
+	params := map[string]interface{}{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"interval":     readers.IntervalSeconds(rpm.Interval),
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+
+	rows, err := tr.db.NamedQuery(q, params)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == pgerrcode.UndefinedTable {
+				return readers.AggregationPage{PageMetadata: rpm, Buckets: []readers.Bucket{}}, nil
+			}
+		}
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.AggregationPage{
+		PageMetadata: rpm,
+		Buckets:      []readers.Bucket{},
+	}
+	for rows.Next() {
+		var b readers.Bucket
+		if err := rows.Scan(&b.Time, &b.Value); err != nil {
+			return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		page.Buckets = append(page.Buckets, b)
+	}
+
+	q = fmt.Sprintf(`SELECT COUNT(*) FROM (SELECT floor(time / :interval) * :interval AS bucket FROM %s %s GROUP BY bucket) AS buckets;`, defTable, condition)
+	rows, err = tr.db.NamedQuery(q, params)
+	if err != nil {
+		return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		if err := rows.Scan(&page.Total); err != nil {
+			return readers.AggregationPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
+	return page, nil
+}
+
 func (tr postgresRepository) Restore(ctx context.Context, messages ...senml.Message) error {
 	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
           name, unit, value, string_value, bool_value, data_value, sum,
@@ -114,12 +200,31 @@ func (tr postgresRepository) readAll(chanID string, rpm readers.PageMetadata) (r
 		format = rpm.Format
 	}
 
+	var pos position
+	if rpm.Cursor != "" {
+		if err := readers.DecodeCursor(rpm.Cursor, &pos); err != nil {
+			return readers.MessagesPage{}, err
+		}
+	}
+
 	olq := "LIMIT :limit OFFSET :offset"
-	if rpm.Limit == 0 {
+	switch {
+	case rpm.Limit == noLimit:
 		olq = ""
+	case rpm.Cursor != "":
+		olq = "LIMIT :limit"
+	}
+
+	condition := fmtCondition(chanID, rpm)
+	if rpm.Cursor != "" {
+		op := "WHERE"
+		if condition != "" {
+			op = "AND"
+		}
+		condition = fmt.Sprintf(`%s %s (%s, id) < (:cursor_time, :cursor_key)`, condition, op, order)
 	}
 
-	q := fmt.Sprintf(`SELECT * FROM %s %s ORDER BY %s DESC %s;`, format, fmtCondition(chanID, rpm), order, olq)
+	q := fmt.Sprintf(`SELECT * FROM %s %s ORDER BY %s DESC, id DESC %s;`, format, condition, order, olq)
 
 	params := map[string]interface{}{
 		"channel":      chanID,
@@ -135,6 +240,11 @@ func (tr postgresRepository) readAll(chanID string, rpm readers.PageMetadata) (r
 		"data_value":   rpm.DataValue,
 		"from":         rpm.From,
 		"to":           rpm.To,
+		"cursor_time":  pos.Time,
+		"cursor_key":   pos.Key,
+	}
+	if format != defTable {
+		params["cursor_time"] = pos.Created
 	}
 
 	rows, err := tr.db.NamedQuery(q, params)
@@ -161,6 +271,7 @@ func (tr postgresRepository) readAll(chanID string, rpm readers.PageMetadata) (r
 			}
 
 			page.Messages = append(page.Messages, msg.Message)
+			pos = position{Time: msg.Time, Key: msg.ID}
 		}
 	default:
 		for rows.Next() {
@@ -173,10 +284,17 @@ func (tr postgresRepository) readAll(chanID string, rpm readers.PageMetadata) (r
 				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
 			}
 			page.Messages = append(page.Messages, m)
+			pos = position{Created: msg.Created, Key: msg.ID}
 		}
 
 	}
 
+	if rpm.Limit != noLimit && uint64(len(page.Messages)) == rpm.Limit {
+		if page.Next, err = readers.EncodeCursor(pos); err != nil {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+	}
+
 	q = fmt.Sprintf(`SELECT COUNT(*) FROM %s %s;`, format, fmtCondition(chanID, rpm))
 	rows, err = tr.db.NamedQuery(q, params)
 	if err != nil {
@@ -243,6 +361,14 @@ func fmtCondition(chanID string, rpm readers.PageMetadata) string {
 	return condition
 }
 
+// position represents the position of the last message of the page, encoded
+// into the page cursor.
+type position struct {
+	Time    float64 `json:"t,omitempty"`
+	Created int64   `json:"c,omitempty"`
+	Key     string  `json:"k"`
+}
+
 type senmlMessage struct {
 	ID string `db:"id"`
 	senml.Message
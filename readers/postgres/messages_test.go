@@ -14,6 +14,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
 	preader "github.com/MainfluxLabs/mainflux/readers/postgres"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -958,3 +959,35 @@ func toMap(msg json.Message) map[string]interface{} {
 		"payload":   map[string]interface{}(msg.Payload),
 	}
 }
+
+func TestAggregateChannelMessages(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+	readerstest.TestAggregation(t, reader, chanID, messages)
+}
+
+func TestListChannelMessagesCursor(t *testing.T) {
+	writer := pwriter.New(db)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := preader.New(db)
+	readerstest.TestCursor(t, reader, chanID, messages)
+}
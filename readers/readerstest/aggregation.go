@@ -0,0 +1,151 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readerstest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const valueDelta = 1e-9
+
+// TestAggregation checks that the repository aggregates the messages returned
+// by Messages, which have to be saved prior to calling it.
+func TestAggregation(t *testing.T, repo readers.MessageRepository, chanID string, msgs []senml.Message) {
+	cases := []struct {
+		desc     string
+		pageMeta readers.PageMetadata
+	}{
+		{
+			desc:     "aggregate min by minute",
+			pageMeta: readers.PageMetadata{Aggregation: readers.AggregationMin, Interval: readers.IntervalMinute},
+		},
+		{
+			desc:     "aggregate max by minute",
+			pageMeta: readers.PageMetadata{Aggregation: readers.AggregationMax, Interval: readers.IntervalMinute},
+		},
+		{
+			desc:     "aggregate avg by minute",
+			pageMeta: readers.PageMetadata{Aggregation: readers.AggregationAvg, Interval: readers.IntervalMinute},
+		},
+		{
+			desc:     "aggregate sum by minute",
+			pageMeta: readers.PageMetadata{Aggregation: readers.AggregationSum, Interval: readers.IntervalMinute},
+		},
+		{
+			desc:     "aggregate count by minute",
+			pageMeta: readers.PageMetadata{Aggregation: readers.AggregationCount, Interval: readers.IntervalMinute},
+		},
+		{
+			desc:     "aggregate avg by hour",
+			pageMeta: readers.PageMetadata{Aggregation: readers.AggregationAvg, Interval: readers.IntervalHour},
+		},
+		{
+			desc:     "aggregate count by day",
+			pageMeta: readers.PageMetadata{Aggregation: readers.AggregationCount, Interval: readers.IntervalDay},
+		},
+		{
+			desc: "aggregate sum by minute with limit and offset",
+			pageMeta: readers.PageMetadata{
+				Aggregation: readers.AggregationSum,
+				Interval:    readers.IntervalMinute,
+				Offset:      2,
+				Limit:       3,
+			},
+		},
+		{
+			desc: "aggregate max by hour with name",
+			pageMeta: readers.PageMetadata{
+				Aggregation: readers.AggregationMax,
+				Interval:    readers.IntervalHour,
+				Name:        names[0],
+			},
+		},
+		{
+			desc: "aggregate avg by minute with time range",
+			pageMeta: readers.PageMetadata{
+				Aggregation: readers.AggregationAvg,
+				Interval:    readers.IntervalMinute,
+				From:        msgs[40].Time,
+				To:          msgs[10].Time,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.AggregateChannelMessages(chanID, tc.pageMeta)
+		require.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+
+		total, buckets := expected(msgs, tc.pageMeta)
+		assert.Equal(t, total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, total, page.Total))
+		require.Equal(t, len(buckets), len(page.Buckets), fmt.Sprintf("%s: expected %d buckets got %d", tc.desc, len(buckets), len(page.Buckets)))
+		for i, b := range buckets {
+			assert.Equal(t, b.Time, page.Buckets[i].Time, fmt.Sprintf("%s: expected bucket time %v got %v", tc.desc, b.Time, page.Buckets[i].Time))
+			assert.InDelta(t, b.Value, page.Buckets[i].Value, valueDelta, fmt.Sprintf("%s: expected bucket value %v got %v", tc.desc, b.Value, page.Buckets[i].Value))
+		}
+	}
+}
+
+func expected(msgs []senml.Message, pm readers.PageMetadata) (uint64, []readers.Bucket) {
+	interval := float64(readers.IntervalSeconds(pm.Interval))
+
+	values := map[float64][]float64{}
+	for _, msg := range msgs {
+		switch {
+		case msg.Value == nil,
+			pm.Name != "" && msg.Name != pm.Name,
+			pm.From != 0 && msg.Time < pm.From,
+			pm.To != 0 && msg.Time >= pm.To:
+			continue
+		}
+		t := math.Floor(msg.Time/interval) * interval
+		values[t] = append(values[t], *msg.Value)
+	}
+
+	var buckets []readers.Bucket
+	for t, vals := range values {
+		buckets = append(buckets, readers.Bucket{Time: t, Value: aggregate(pm.Aggregation, vals)})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Time > buckets[j].Time })
+
+	total := uint64(len(buckets))
+	if pm.Offset >= total {
+		return total, nil
+	}
+	end := pm.Offset + pm.Limit
+	if end > total || pm.Limit == 0 {
+		end = total
+	}
+
+	return total, buckets[pm.Offset:end]
+}
+
+func aggregate(aggregation string, vals []float64) float64 {
+	min, max, sum := math.Inf(1), math.Inf(-1), 0.0
+	for _, v := range vals {
+		min = math.Min(min, v)
+		max = math.Max(max, v)
+		sum += v
+	}
+
+	switch aggregation {
+	case readers.AggregationMin:
+		return min
+	case readers.AggregationMax:
+		return max
+	case readers.AggregationAvg:
+		return sum / float64(len(vals))
+	case readers.AggregationSum:
+		return sum
+	default:
+		return float64(len(vals))
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readerstest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCursor checks that the repository pages through the messages returned
+// by Messages using the page cursor, which have to be saved prior to calling it.
+func TestCursor(t *testing.T, repo readers.MessageRepository, chanID string, msgs []senml.Message) {
+	cases := []struct {
+		desc     string
+		pageMeta readers.PageMetadata
+		total    int
+	}{
+		{
+			desc:     "page through all messages",
+			pageMeta: readers.PageMetadata{Limit: 7},
+			total:    len(msgs),
+		},
+		{
+			desc:     "page through messages with offset of the first page",
+			pageMeta: readers.PageMetadata{Offset: 10, Limit: 7},
+			total:    len(msgs) - 10,
+		},
+		{
+			desc:     "page through messages with name",
+			pageMeta: readers.PageMetadata{Limit: 4, Name: names[0]},
+			total:    len(msgs) / namesNum,
+		},
+		{
+			desc:     "page through messages with limit dividing total",
+			pageMeta: readers.PageMetadata{Limit: 10},
+			total:    len(msgs),
+		},
+	}
+
+	for _, tc := range cases {
+		var read []senml.Message
+		pm := tc.pageMeta
+		for {
+			page, err := repo.ListChannelMessages(chanID, pm)
+			require.Nil(t, err, fmt.Sprintf("%s: expected no error got %s", tc.desc, err))
+			require.LessOrEqual(t, uint64(len(page.Messages)), pm.Limit, fmt.Sprintf("%s: expected at most %d messages got %d", tc.desc, pm.Limit, len(page.Messages)))
+
+			for _, m := range page.Messages {
+				read = append(read, m.(senml.Message))
+			}
+			if page.Next == "" {
+				break
+			}
+			require.LessOrEqual(t, len(read), tc.total, fmt.Sprintf("%s: expected at most %d messages got %d", tc.desc, tc.total, len(read)))
+			pm.Cursor = page.Next
+		}
+
+		assert.Equal(t, tc.total, len(read), fmt.Sprintf("%s: expected %d messages got %d", tc.desc, tc.total, len(read)))
+		for i := 1; i < len(read); i++ {
+			assert.Greater(t, read[i-1].Time, read[i].Time, fmt.Sprintf("%s: expected messages sorted by time", tc.desc))
+		}
+	}
+
+	_, err := repo.ListChannelMessages(chanID, readers.PageMetadata{Limit: 10, Cursor: "invalid"})
+	assert.ErrorIs(t, err, readers.ErrInvalidCursor, fmt.Sprintf("invalid cursor: expected %s got %s", readers.ErrInvalidCursor, err))
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package readerstest contains the contract tests shared by the message
+// repository implementations.
+package readerstest
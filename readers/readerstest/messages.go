@@ -0,0 +1,52 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package readerstest
+
+import (
+	"math"
+
+	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+)
+
+const (
+	msgsNum     = 60
+	timeStep    = 97
+	valuesNum   = 7
+	namesNum    = 2
+	noValueStep = 5
+)
+
+var names = []string{"temperature", "humidity"}
+
+// Messages returns the SenML messages used by the contract tests. Messages
+// are published over the given channel by the given publisher before the
+// given time in seconds, which is truncated to avoid precision loss in the
+// repositories storing time as an integer.
+func Messages(chanID, pubID string, now float64) []senml.Message {
+	now = math.Floor(now)
+
+	var msgs []senml.Message
+	for i := 0; i < msgsNum; i++ {
+		msg := senml.Message{
+			Channel:   chanID,
+			Publisher: pubID,
+			Protocol:  "mqtt",
+			Name:      names[i%namesNum],
+			Time:      now - float64(i*timeStep),
+		}
+
+		switch i % noValueStep {
+		case 0:
+			vb := true
+			msg.BoolValue = &vb
+		default:
+			v := float64(i % valuesNum)
+			msg.Value = &v
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/readers"
+	"github.com/MainfluxLabs/mainflux/readers/readerstest"
 	treader "github.com/MainfluxLabs/mainflux/readers/timescale"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -929,3 +930,35 @@ func toMap(msg json.Message) map[string]interface{} {
 		"payload":   map[string]interface{}(msg.Payload),
 	}
 }
+
+func TestAggregateChannelMessages(t *testing.T) {
+	writer := twriter.New(db)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+	readerstest.TestAggregation(t, reader, chanID, messages)
+}
+
+func TestListChannelMessagesCursor(t *testing.T) {
+	writer := twriter.New(db)
+
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	pubID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	messages := readerstest.Messages(chanID, pubID, float64(time.Now().Unix()))
+	err = writer.Consume(messages)
+	require.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	reader := treader.New(db)
+	readerstest.TestCursor(t, reader, chanID, messages)
+}
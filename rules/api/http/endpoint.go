@@ -0,0 +1,166 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/rules"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createRulesEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createRulesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rs := []rules.Rule{}
+		for _, rReq := range req.Rules {
+			r := rules.Rule{
+				ChannelID: rReq.ChannelID,
+				Name:      rReq.Name,
+				Condition: rReq.Condition,
+				Actions:   rReq.Actions,
+			}
+			rs = append(rs, r)
+		}
+
+		saved, err := svc.CreateRules(ctx, req.token, rs...)
+		if err != nil {
+			return nil, err
+		}
+
+		res := rulesRes{
+			Rules:   []ruleRes{},
+			created: true,
+		}
+
+		for _, r := range saved {
+			res.Rules = append(res.Rules, buildRuleRes(r))
+		}
+
+		return res, nil
+	}
+}
+
+func listRulesEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRulesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListRules(ctx, req.token, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildRulesPageRes(page), nil
+	}
+}
+
+func listRulesByChannelEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRulesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ListRulesByChannel(ctx, req.token, req.id, req.pageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildRulesPageRes(page), nil
+	}
+}
+
+func viewRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(viewRuleReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule, err := svc.ViewRule(ctx, req.token, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildRuleRes(rule), nil
+	}
+}
+
+func updateRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateRuleReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		rule := rules.Rule{
+			ID:        req.id,
+			Name:      req.Name,
+			Condition: req.Condition,
+			Actions:   req.Actions,
+		}
+
+		if err := svc.UpdateRule(ctx, req.token, rule); err != nil {
+			return nil, err
+		}
+
+		return updateRuleRes{}, nil
+	}
+}
+
+func removeRulesEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(removeRulesReq)
+
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.RemoveRules(ctx, req.token, req.RuleIDs...); err != nil {
+			return nil, err
+		}
+
+		return removeRes{}, nil
+	}
+}
+
+func buildRuleRes(rule rules.Rule) ruleRes {
+	return ruleRes{
+		ID:        rule.ID,
+		Owner:     rule.Owner,
+		ChannelID: rule.ChannelID,
+		Name:      rule.Name,
+		Condition: rule.Condition,
+		Actions:   rule.Actions,
+	}
+}
+
+func buildRulesPageRes(page rules.RulesPage) rulesPageRes {
+	res := rulesPageRes{
+		pageRes: pageRes{
+			Total:  page.Total,
+			Offset: page.Offset,
+			Limit:  page.Limit,
+		},
+		Rules: []ruleRes{},
+	}
+
+	for _, rule := range page.Rules {
+		res.Rules = append(res.Rules, buildRuleRes(rule))
+	}
+
+	return res
+}
@@ -0,0 +1,661 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/logger"
+	authmock "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/rules"
+	httpapi "github.com/MainfluxLabs/mainflux/rules/api/http"
+	"github.com/MainfluxLabs/mainflux/rules/mocks"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	contentType    = "application/json"
+	email          = "user@example.com"
+	otherUserEmail = "other_user@example.com"
+	token          = email
+	otherToken     = otherUserEmail
+	wrongValue     = "wrong_value"
+	password       = "password"
+	chanID         = "5384fb1c-d0ae-4cbe-be52-c54223150fe0"
+	targetChanID   = "d2ebb3f1-ee6f-4b2a-8a4a-3e8f2ec6d1b1"
+	otherChanID    = "b4c7cd8a-8de0-4c79-9c57-0e66d6b1f4ab"
+	n              = 10
+)
+
+var (
+	user      = users.User{ID: "574106f7-030e-4881-8ab0-151195c29f94", Email: email, Password: password}
+	otherUser = users.User{ID: "ecf9e48b-ba3b-41c4-82a9-72e063b17868", Email: otherUserEmail, Password: password}
+	usersList = []users.User{user, otherUser}
+
+	rule = rules.Rule{
+		Name:      "overheating",
+		ChannelID: chanID,
+		Condition: rules.Condition{Field: "temp", Operator: rules.OpGT, Threshold: 40, Consecutive: 3},
+		Actions: []rules.Action{
+			{Type: rules.ActionPublish, ChannelID: targetChanID},
+			{Type: rules.ActionSMTP, Contacts: []string{email}},
+		},
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+	return tr.client.Do(req)
+}
+
+type ruleReq struct {
+	Name      string          `json:"name,omitempty"`
+	ChannelID string          `json:"channel_id,omitempty"`
+	Condition rules.Condition `json:"condition"`
+	Actions   []rules.Action  `json:"actions"`
+}
+
+type ruleRes struct {
+	ID        string          `json:"id"`
+	Owner     string          `json:"owner"`
+	ChannelID string          `json:"channel_id"`
+	Name      string          `json:"name"`
+	Condition rules.Condition `json:"condition"`
+	Actions   []rules.Action  `json:"actions"`
+}
+
+type rulesPageRes struct {
+	Total  uint64    `json:"total"`
+	Offset uint64    `json:"offset"`
+	Limit  uint64    `json:"limit"`
+	Rules  []ruleRes `json:"rules"`
+}
+
+func newService() rules.Service {
+	auth := authmock.NewAuthService("", usersList)
+	things := mocks.NewThingsServiceClient(map[string][]string{
+		user.ID:      {chanID, targetChanID},
+		otherUser.ID: {otherChanID},
+	}, nil)
+
+	return rules.New(auth, things, mocks.NewRuleRepository(), mocks.NewCounterRepository(), mocks.NewPublisher(), uuid.NewMock())
+}
+
+func newServer(svc rules.Service) *httptest.Server {
+	logger := logger.NewMock()
+	mux := httpapi.MakeHandler(mocktracer.New(), svc, logger)
+	return httptest.NewServer(mux)
+}
+
+func toJSON(data interface{}) string {
+	jsonData, _ := json.Marshal(data)
+	return string(jsonData)
+}
+
+func toReq(r rules.Rule) ruleReq {
+	return ruleReq{
+		Name:      r.Name,
+		ChannelID: r.ChannelID,
+		Condition: r.Condition,
+		Actions:   r.Actions,
+	}
+}
+
+func TestCreateRules(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	invalidOp := toReq(rule)
+	invalidOp.Condition.Operator = "~"
+
+	missingField := toReq(rule)
+	missingField.Condition.Field = ""
+
+	noActions := toReq(rule)
+	noActions.Actions = nil
+
+	invalidAction := toReq(rule)
+	invalidAction.Actions = []rules.Action{{Type: wrongValue}}
+
+	invalidURL := toReq(rule)
+	invalidURL.Actions = []rules.Action{{Type: rules.ActionWebhook, URL: "ftp://example.com"}}
+
+	privateURL := toReq(rule)
+	privateURL.Actions = []rules.Action{{Type: rules.ActionWebhook, URL: "http://169.254.169.254/latest/meta-data"}}
+
+	noContacts := toReq(rule)
+	noContacts.Actions = []rules.Action{{Type: rules.ActionSMPP}}
+
+	selfPublish := toReq(rule)
+	selfPublish.Actions = []rules.Action{{Type: rules.ActionPublish, ChannelID: chanID}}
+
+	missingChannel := toReq(rule)
+	missingChannel.ChannelID = ""
+
+	invalidName := toReq(rule)
+	invalidName.Name = strings.Repeat("m", 1025)
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "create valid rules",
+			data:        toJSON([]ruleReq{toReq(rule), toReq(rule)}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "create rules with empty JSON array",
+			data:        "[]",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rules with invalid request format",
+			data:        "}",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid operator",
+			data:        toJSON([]ruleReq{invalidOp}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without condition field",
+			data:        toJSON([]ruleReq{missingField}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without actions",
+			data:        toJSON([]ruleReq{noActions}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid action type",
+			data:        toJSON([]ruleReq{invalidAction}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid webhook URL",
+			data:        toJSON([]ruleReq{invalidURL}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with private webhook URL",
+			data:        toJSON([]ruleReq{privateURL}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with notifier action without contacts",
+			data:        toJSON([]ruleReq{noContacts}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule publishing to its own channel",
+			data:        toJSON([]ruleReq{selfPublish}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule without channel",
+			data:        toJSON([]ruleReq{missingChannel}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule with invalid name",
+			data:        toJSON([]ruleReq{invalidName}),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "create rule for channel owned by other user",
+			data:        toJSON([]ruleReq{toReq(rule)}),
+			contentType: contentType,
+			auth:        otherToken,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "create rules with invalid auth token",
+			data:        toJSON([]ruleReq{toReq(rule)}),
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rules with empty auth token",
+			data:        toJSON([]ruleReq{toReq(rule)}),
+			contentType: contentType,
+			auth:        "",
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "create rules without content type",
+			data:        toJSON([]ruleReq{toReq(rule)}),
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/rules", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	rs, err := svc.CreateRules(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	r := rs[0]
+
+	cases := []struct {
+		desc   string
+		id     string
+		auth   string
+		status int
+		res    ruleRes
+	}{
+		{
+			desc:   "view existing rule",
+			id:     r.ID,
+			auth:   token,
+			status: http.StatusOK,
+			res: ruleRes{
+				ID:        r.ID,
+				Owner:     r.Owner,
+				ChannelID: r.ChannelID,
+				Name:      r.Name,
+				Condition: r.Condition,
+				Actions:   r.Actions,
+			},
+		},
+		{
+			desc:   "view non-existing rule",
+			id:     wrongValue,
+			auth:   token,
+			status: http.StatusNotFound,
+		},
+		{
+			desc:   "view rule owned by other user",
+			id:     r.ID,
+			auth:   otherToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "view rule with invalid auth token",
+			id:     r.ID,
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "view rule with empty auth token",
+			id:     r.ID,
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body ruleRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, body))
+	}
+}
+
+func TestListRules(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	for i := 0; i < n; i++ {
+		_, err := svc.CreateRules(context.Background(), token, rule)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	cases := []struct {
+		desc   string
+		url    string
+		auth   string
+		status int
+		size   int
+	}{
+		{
+			desc:   "list rules",
+			url:    fmt.Sprintf("%s/rules?offset=%d&limit=%d", ts.URL, 0, n),
+			auth:   token,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list rules with default pagination",
+			url:    fmt.Sprintf("%s/rules", ts.URL),
+			auth:   token,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list half of the rules",
+			url:    fmt.Sprintf("%s/rules?offset=%d&limit=%d", ts.URL, n/2, n),
+			auth:   token,
+			status: http.StatusOK,
+			size:   n / 2,
+		},
+		{
+			desc:   "list rules with limit greater than max",
+			url:    fmt.Sprintf("%s/rules?offset=%d&limit=%d", ts.URL, 0, 110),
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list rules with invalid offset",
+			url:    fmt.Sprintf("%s/rules?offset=%s", ts.URL, wrongValue),
+			auth:   token,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "list rules of channel",
+			url:    fmt.Sprintf("%s/channels/%s/rules", ts.URL, chanID),
+			auth:   token,
+			status: http.StatusOK,
+			size:   n,
+		},
+		{
+			desc:   "list rules of channel owned by other user",
+			url:    fmt.Sprintf("%s/channels/%s/rules", ts.URL, chanID),
+			auth:   otherToken,
+			status: http.StatusForbidden,
+		},
+		{
+			desc:   "list rules with invalid auth token",
+			url:    fmt.Sprintf("%s/rules", ts.URL),
+			auth:   wrongValue,
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "list rules with empty auth token",
+			url:    fmt.Sprintf("%s/rules", ts.URL),
+			auth:   "",
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    tc.url,
+			token:  tc.auth,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.status != http.StatusOK {
+			continue
+		}
+
+		var body rulesPageRes
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.size, len(body.Rules), fmt.Sprintf("%s: expected %d rules got %d", tc.desc, tc.size, len(body.Rules)))
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	rs, err := svc.CreateRules(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	r := rs[0]
+
+	updated := toReq(rule)
+	updated.ChannelID = ""
+	updated.Name = "updated"
+
+	foreignTarget := updated
+	foreignTarget.Actions = []rules.Action{{Type: rules.ActionPublish, ChannelID: otherChanID}}
+
+	invalidOp := updated
+	invalidOp.Condition.Operator = ""
+
+	cases := []struct {
+		desc        string
+		id          string
+		data        string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "update existing rule",
+			id:          r.ID,
+			data:        toJSON(updated),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update rule with invalid condition",
+			id:          r.ID,
+			data:        toJSON(invalidOp),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update rule to publish to channel owned by other user",
+			id:          r.ID,
+			data:        toJSON(foreignTarget),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusForbidden,
+		},
+		{
+			desc:        "update non-existing rule",
+			id:          wrongValue,
+			data:        toJSON(updated),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNotFound,
+		},
+		{
+			desc:        "update rule with invalid request format",
+			id:          r.ID,
+			data:        "}",
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "update rule with invalid auth token",
+			id:          r.ID,
+			data:        toJSON(updated),
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "update rule without content type",
+			id:          r.ID,
+			data:        toJSON(updated),
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPut,
+			url:         fmt.Sprintf("%s/rules/%s", ts.URL, tc.id),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
+func TestRemoveRules(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+
+	rs, err := svc.CreateRules(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	r := rs[0]
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		auth        string
+		status      int
+	}{
+		{
+			desc:        "remove rules with invalid auth token",
+			data:        fmt.Sprintf(`{"rule_ids": ["%s"]}`, r.ID),
+			contentType: contentType,
+			auth:        wrongValue,
+			status:      http.StatusUnauthorized,
+		},
+		{
+			desc:        "remove rules with empty list",
+			data:        `{"rule_ids": []}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "remove rules with empty ID",
+			data:        `{"rule_ids": [""]}`,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
+		{
+			desc:        "remove rules without content type",
+			data:        fmt.Sprintf(`{"rule_ids": ["%s"]}`, r.ID),
+			contentType: "",
+			auth:        token,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			desc:        "remove existing rules",
+			data:        fmt.Sprintf(`{"rule_ids": ["%s"]}`, r.ID),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNoContent,
+		},
+		{
+			desc:        "remove removed rules",
+			data:        fmt.Sprintf(`{"rule_ids": ["%s"]}`, r.ID),
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusNoContent,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      ts.Client(),
+			method:      http.MethodPatch,
+			url:         fmt.Sprintf("%s/rules", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.auth,
+			body:        strings.NewReader(tc.data),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
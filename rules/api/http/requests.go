@@ -0,0 +1,187 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"github.com/MainfluxLabs/mainflux/consumers/notifiers/webhook"
+	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/rules"
+)
+
+const (
+	maxLimitSize = 100
+	maxNameSize  = 1024
+)
+
+type ruleReq struct {
+	Name      string          `json:"name,omitempty"`
+	ChannelID string          `json:"channel_id"`
+	Condition rules.Condition `json:"condition"`
+	Actions   []rules.Action  `json:"actions"`
+}
+
+func (req ruleReq) validate() error {
+	if req.ChannelID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	if err := validateCondition(req.Condition); err != nil {
+		return err
+	}
+
+	return validateActions(req.Actions)
+}
+
+type createRulesReq struct {
+	token string
+	Rules []ruleReq
+}
+
+func (req createRulesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.Rules) <= 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, rule := range req.Rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type updateRuleReq struct {
+	token     string
+	id        string
+	Name      string          `json:"name,omitempty"`
+	Condition rules.Condition `json:"condition"`
+	Actions   []rules.Action  `json:"actions"`
+}
+
+func (req updateRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if len(req.Name) > maxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	if err := validateCondition(req.Condition); err != nil {
+		return err
+	}
+
+	return validateActions(req.Actions)
+}
+
+type viewRuleReq struct {
+	token string
+	id    string
+}
+
+func (req viewRuleReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listRulesReq struct {
+	token        string
+	id           string
+	pageMetadata rules.PageMetadata
+}
+
+func (req listRulesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.pageMetadata.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
+
+type removeRulesReq struct {
+	token   string
+	RuleIDs []string `json:"rule_ids,omitempty"`
+}
+
+func (req removeRulesReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if len(req.RuleIDs) < 1 {
+		return apiutil.ErrEmptyList
+	}
+
+	for _, ruleID := range req.RuleIDs {
+		if ruleID == "" {
+			return apiutil.ErrMissingID
+		}
+	}
+
+	return nil
+}
+
+func validateCondition(c rules.Condition) error {
+	if c.Field == "" {
+		return rules.ErrInvalidCondition
+	}
+
+	switch c.Operator {
+	case rules.OpEQ, rules.OpNE, rules.OpGT, rules.OpGE, rules.OpLT, rules.OpLE:
+		return nil
+	default:
+		return rules.ErrInvalidCondition
+	}
+}
+
+func validateActions(actions []rules.Action) error {
+	if len(actions) == 0 {
+		return rules.ErrInvalidAction
+	}
+
+	for _, a := range actions {
+		switch a.Type {
+		case rules.ActionPublish:
+			if a.ChannelID == "" {
+				return rules.ErrInvalidAction
+			}
+		case rules.ActionSMTP, rules.ActionSMPP:
+			if len(a.Contacts) == 0 {
+				return rules.ErrInvalidAction
+			}
+		case rules.ActionWebhook:
+			if err := webhook.ValidateURL(a.URL); err != nil {
+				return rules.ErrInvalidAction
+			}
+		default:
+			return rules.ErrInvalidAction
+		}
+	}
+
+	return nil
+}
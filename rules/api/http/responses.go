@@ -0,0 +1,112 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"net/http"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/rules"
+)
+
+var (
+	_ mainflux.Response = (*rulesRes)(nil)
+	_ mainflux.Response = (*ruleRes)(nil)
+	_ mainflux.Response = (*rulesPageRes)(nil)
+	_ mainflux.Response = (*updateRuleRes)(nil)
+	_ mainflux.Response = (*removeRes)(nil)
+)
+
+type ruleRes struct {
+	ID        string          `json:"id"`
+	Owner     string          `json:"owner,omitempty"`
+	ChannelID string          `json:"channel_id"`
+	Name      string          `json:"name,omitempty"`
+	Condition rules.Condition `json:"condition"`
+	Actions   []rules.Action  `json:"actions"`
+}
+
+func (res ruleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res ruleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res ruleRes) Empty() bool {
+	return false
+}
+
+type rulesRes struct {
+	Rules   []ruleRes `json:"rules"`
+	created bool
+}
+
+func (res rulesRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res rulesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rulesRes) Empty() bool {
+	return false
+}
+
+type pageRes struct {
+	Total  uint64 `json:"total"`
+	Offset uint64 `json:"offset"`
+	Limit  uint64 `json:"limit"`
+}
+
+type rulesPageRes struct {
+	pageRes
+	Rules []ruleRes `json:"rules"`
+}
+
+func (res rulesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rulesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rulesPageRes) Empty() bool {
+	return false
+}
+
+type updateRuleRes struct{}
+
+func (res updateRuleRes) Code() int {
+	return http.StatusOK
+}
+
+func (res updateRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res updateRuleRes) Empty() bool {
+	return true
+}
+
+type removeRes struct{}
+
+func (res removeRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRes) Empty() bool {
+	return true
+}
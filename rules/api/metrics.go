@@ -0,0 +1,94 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/rules"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ rules.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     rules.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc rules.Service, counter metrics.Counter, latency metrics.Histogram) rules.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (ms *metricsMiddleware) CreateRules(ctx context.Context, token string, rs ...rules.Rule) ([]rules.Rule, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "create_rules").Add(1)
+		ms.latency.With("method", "create_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.CreateRules(ctx, token, rs...)
+}
+
+func (ms *metricsMiddleware) ListRules(ctx context.Context, token string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_rules").Add(1)
+		ms.latency.With("method", "list_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListRules(ctx, token, pm)
+}
+
+func (ms *metricsMiddleware) ListRulesByChannel(ctx context.Context, token, chanID string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_rules_by_channel").Add(1)
+		ms.latency.With("method", "list_rules_by_channel").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListRulesByChannel(ctx, token, chanID, pm)
+}
+
+func (ms *metricsMiddleware) ViewRule(ctx context.Context, token, id string) (rules.Rule, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "view_rule").Add(1)
+		ms.latency.With("method", "view_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ViewRule(ctx, token, id)
+}
+
+func (ms *metricsMiddleware) UpdateRule(ctx context.Context, token string, rule rules.Rule) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "update_rule").Add(1)
+		ms.latency.With("method", "update_rule").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UpdateRule(ctx, token, rule)
+}
+
+func (ms *metricsMiddleware) RemoveRules(ctx context.Context, token string, ids ...string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "remove_rules").Add(1)
+		ms.latency.With("method", "remove_rules").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RemoveRules(ctx, token, ids...)
+}
+
+func (ms *metricsMiddleware) Consume(msg interface{}) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "consume").Add(1)
+		ms.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Consume(msg)
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rules contains the domain concept definitions needed to support
+// Mainflux rules engine service functionality.
+package rules
@@ -0,0 +1,58 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/rules"
+)
+
+var _ rules.CounterRepository = (*counterRepositoryMock)(nil)
+
+type counterRepositoryMock struct {
+	mu       sync.Mutex
+	counters map[string]map[string]uint
+}
+
+// NewCounterRepository creates in-memory counter repository.
+func NewCounterRepository() rules.CounterRepository {
+	return &counterRepositoryMock{
+		counters: make(map[string]map[string]uint),
+	}
+}
+
+func (crm *counterRepositoryMock) Increment(_ context.Context, ruleID, publisher string, limit uint) (bool, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	if _, ok := crm.counters[ruleID]; !ok {
+		crm.counters[ruleID] = make(map[string]uint)
+	}
+
+	crm.counters[ruleID][publisher]++
+	if crm.counters[ruleID][publisher] < limit {
+		return false, nil
+	}
+
+	delete(crm.counters[ruleID], publisher)
+	return true, nil
+}
+
+func (crm *counterRepositoryMock) Reset(_ context.Context, ruleID, publisher string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	delete(crm.counters[ruleID], publisher)
+	return nil
+}
+
+func (crm *counterRepositoryMock) Remove(_ context.Context, ruleID string) error {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	delete(crm.counters, ruleID)
+	return nil
+}
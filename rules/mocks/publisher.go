@@ -0,0 +1,48 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+)
+
+// Publisher represents message publisher mock which records published
+// messages.
+type Publisher interface {
+	messaging.Publisher
+
+	// Messages returns the messages published so far.
+	Messages() []messaging.Message
+}
+
+type publisherMock struct {
+	mu       sync.Mutex
+	messages []messaging.Message
+}
+
+// NewPublisher returns mock message publisher.
+func NewPublisher() Publisher {
+	return &publisherMock{}
+}
+
+func (pub *publisherMock) Publish(msg messaging.Message) error {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	pub.messages = append(pub.messages, msg)
+	return nil
+}
+
+func (pub *publisherMock) Messages() []messaging.Message {
+	pub.mu.Lock()
+	defer pub.mu.Unlock()
+
+	return append([]messaging.Message{}, pub.messages...)
+}
+
+func (pub *publisherMock) Close() error {
+	return nil
+}
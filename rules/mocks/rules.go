@@ -0,0 +1,129 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/rules"
+)
+
+var _ rules.RuleRepository = (*ruleRepositoryMock)(nil)
+
+type ruleRepositoryMock struct {
+	mu    sync.Mutex
+	rules map[string]rules.Rule
+}
+
+// NewRuleRepository creates in-memory rule repository.
+func NewRuleRepository() rules.RuleRepository {
+	return &ruleRepositoryMock{
+		rules: make(map[string]rules.Rule),
+	}
+}
+
+func (rrm *ruleRepositoryMock) Save(_ context.Context, rs ...rules.Rule) ([]rules.Rule, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	for _, rule := range rs {
+		if _, ok := rrm.rules[rule.ID]; ok {
+			return []rules.Rule{}, errors.ErrConflict
+		}
+	}
+
+	for _, rule := range rs {
+		rrm.rules[rule.ID] = rule
+	}
+
+	return rs, nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveByID(_ context.Context, id string) (rules.Rule, error) {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rule, ok := rrm.rules[id]
+	if !ok {
+		return rules.Rule{}, errors.ErrNotFound
+	}
+
+	return rule, nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveByOwner(_ context.Context, owner string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	return rrm.retrieve(func(r rules.Rule) bool { return r.Owner == owner }, pm), nil
+}
+
+func (rrm *ruleRepositoryMock) RetrieveByChannel(_ context.Context, chanID string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	return rrm.retrieve(func(r rules.Rule) bool { return r.ChannelID == chanID }, pm), nil
+}
+
+func (rrm *ruleRepositoryMock) Update(_ context.Context, rule rules.Rule) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	r, ok := rrm.rules[rule.ID]
+	if !ok || r.Owner != rule.Owner {
+		return errors.ErrNotFound
+	}
+
+	r.Name = rule.Name
+	r.Condition = rule.Condition
+	r.Actions = rule.Actions
+	rrm.rules[rule.ID] = r
+
+	return nil
+}
+
+func (rrm *ruleRepositoryMock) Remove(_ context.Context, owner string, ids ...string) error {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	for _, id := range ids {
+		if r, ok := rrm.rules[id]; ok && r.Owner == owner {
+			delete(rrm.rules, id)
+		}
+	}
+
+	return nil
+}
+
+func (rrm *ruleRepositoryMock) retrieve(match func(rules.Rule) bool, pm rules.PageMetadata) rules.RulesPage {
+	rrm.mu.Lock()
+	defer rrm.mu.Unlock()
+
+	rs := []rules.Rule{}
+	for _, r := range rrm.rules {
+		if match(r) {
+			rs = append(rs, r)
+		}
+	}
+
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].ID < rs[j].ID
+	})
+
+	total := uint64(len(rs))
+	first := pm.Offset
+	last := first + pm.Limit
+	if last > total || pm.Limit == 0 {
+		last = total
+	}
+	if first > last {
+		first = last
+	}
+
+	return rules.RulesPage{
+		PageMetadata: rules.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+		Rules: rs[first:last],
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+)
+
+var _ mainflux.ThingsServiceClient = (*thingsServiceMock)(nil)
+
+type thingsServiceMock struct {
+	channels map[string][]string
+	profiles map[string]*mainflux.Profile
+}
+
+// NewThingsServiceClient returns mock implementation of things service
+// client, where channels maps owner IDs to the IDs of the channels they own,
+// and profiles maps channel IDs to their profiles. Channels without profile
+// have the empty one.
+func NewThingsServiceClient(channels map[string][]string, profiles map[string]*mainflux.Profile) mainflux.ThingsServiceClient {
+	return &thingsServiceMock{channels, profiles}
+}
+
+func (svc thingsServiceMock) GetConnByKey(context.Context, *mainflux.ConnByKeyReq, ...grpc.CallOption) (*mainflux.ConnByKeyRes, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) IsChannelOwner(_ context.Context, in *mainflux.ChannelOwnerReq, _ ...grpc.CallOption) (*empty.Empty, error) {
+	for _, id := range svc.channels[in.GetOwner()] {
+		if id == in.GetChanID() {
+			return &empty.Empty{}, nil
+		}
+	}
+
+	return nil, errors.ErrAuthorization
+}
+
+func (svc thingsServiceMock) Identify(context.Context, *mainflux.Token, ...grpc.CallOption) (*mainflux.ThingID, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) GetGroupsByIDs(context.Context, *mainflux.GroupsReq, ...grpc.CallOption) (*mainflux.GroupsRes, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) GetRetentionProfiles(context.Context, *empty.Empty, ...grpc.CallOption) (*mainflux.ChannelProfilesRes, error) {
+	panic("not implemented")
+}
+
+func (svc thingsServiceMock) GetChannelProfile(_ context.Context, in *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.Profile, error) {
+	if p, ok := svc.profiles[in.GetValue()]; ok {
+		return p, nil
+	}
+
+	for _, ids := range svc.channels {
+		for _, id := range ids {
+			if id == in.GetValue() {
+				return &mainflux.Profile{}, nil
+			}
+		}
+	}
+
+	return nil, errors.ErrNotFound
+}
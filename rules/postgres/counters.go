@@ -0,0 +1,81 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/rules"
+)
+
+var _ rules.CounterRepository = (*counterRepository)(nil)
+
+type counterRepository struct {
+	db Database
+}
+
+// NewCounterRepository instantiates a PostgreSQL implementation of counter
+// repository.
+func NewCounterRepository(db Database) rules.CounterRepository {
+	return &counterRepository{
+		db: db,
+	}
+}
+
+func (cr counterRepository) Increment(ctx context.Context, ruleID, publisher string, limit uint) (bool, error) {
+	// The counter is reset in the same statement once it reaches the limit,
+	// so that concurrent increments can't trigger the rule more than once.
+	q := `INSERT INTO rule_counters (rule_id, publisher, count)
+		  VALUES (:rule_id, :publisher, CASE WHEN 1 >= :limit THEN 0 ELSE 1 END)
+		  ON CONFLICT (rule_id, publisher) DO UPDATE
+		  SET count = CASE WHEN rule_counters.count + 1 >= :limit THEN 0 ELSE rule_counters.count + 1 END
+		  RETURNING count;`
+
+	params := map[string]interface{}{
+		"rule_id":   ruleID,
+		"publisher": publisher,
+		"limit":     uint64(limit),
+	}
+
+	rows, err := cr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return false, errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	var count uint64
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return false, errors.Wrap(errors.ErrUpdateEntity, err)
+		}
+	}
+
+	return count == 0, nil
+}
+
+func (cr counterRepository) Reset(ctx context.Context, ruleID, publisher string) error {
+	q := `DELETE FROM rule_counters WHERE rule_id = :rule_id AND publisher = :publisher;`
+
+	params := map[string]interface{}{
+		"rule_id":   ruleID,
+		"publisher": publisher,
+	}
+
+	if _, err := cr.db.NamedExecContext(ctx, q, params); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (cr counterRepository) Remove(ctx context.Context, ruleID string) error {
+	q := `DELETE FROM rule_counters WHERE rule_id = :rule_id;`
+
+	if _, err := cr.db.NamedExecContext(ctx, q, map[string]interface{}{"rule_id": ruleID}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/rules/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountersIncrement(t *testing.T) {
+	database := postgres.NewDatabase(db)
+	rulesRepo := postgres.NewRuleRepository(database)
+	repo := postgres.NewCounterRepository(database)
+
+	email := "counters-increment@example.com"
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	publisher, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	rule := newRule(t, email, chanID)
+	_, err = rulesRepo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		reached bool
+	}{
+		{
+			desc:    "increment counter of new publisher",
+			reached: false,
+		},
+		{
+			desc:    "increment counter below limit",
+			reached: false,
+		},
+		{
+			desc:    "increment counter to limit",
+			reached: true,
+		},
+		{
+			desc:    "increment counter after reaching limit",
+			reached: false,
+		},
+	}
+
+	for _, tc := range cases {
+		reached, err := repo.Increment(context.Background(), rule.ID, publisher, 3)
+		assert.Nil(t, err, fmt.Sprintf("%s: got unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.reached, reached, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.reached, reached))
+	}
+
+	err = repo.Reset(context.Background(), rule.ID, publisher)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	reached, err := repo.Increment(context.Background(), rule.ID, publisher, 2)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.False(t, reached, "increment counter after reset: expected limit not to be reached\n")
+
+	err = repo.Remove(context.Background(), rule.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	reached, err = repo.Increment(context.Background(), rule.ID, publisher, 1)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.True(t, reached, "increment counter after remove with limit 1: expected limit to be reached\n")
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	"github.com/jmoiron/sqlx"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Config defines the options that are used when connecting to a PostgreSQL instance
+type Config struct {
+	Host        string
+	Port        string
+	User        string
+	Pass        string
+	Name        string
+	SSLMode     string
+	SSLCert     string
+	SSLKey      string
+	SSLRootCert string
+}
+
+// Connect creates a connection to the PostgreSQL instance and applies any
+// unapplied database migrations. A non-nil error is returned to indicate
+// failure.
+func Connect(cfg Config) (*sqlx.DB, error) {
+	url := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=%s sslcert=%s sslkey=%s sslrootcert=%s", cfg.Host, cfg.Port, cfg.User, cfg.Name, cfg.Pass, cfg.SSLMode, cfg.SSLCert, cfg.SSLKey, cfg.SSLRootCert)
+
+	db, err := sqlx.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateDB(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func migrateDB(db *sqlx.DB) error {
+	migrations := &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "rules_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS rules (
+						id          UUID PRIMARY KEY,
+						owner       VARCHAR(254) NOT NULL,
+						channel_id  UUID NOT NULL,
+						name        VARCHAR(1024),
+						condition   JSONB NOT NULL,
+						actions     JSONB NOT NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS rules_channel_id_idx ON rules (channel_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rules`,
+				},
+			},
+			{
+				Id: "rules_2",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS rule_counters (
+						rule_id     UUID NOT NULL REFERENCES rules (id) ON DELETE CASCADE,
+						publisher   VARCHAR(254) NOT NULL,
+						count       BIGINT NOT NULL,
+						PRIMARY KEY (rule_id, publisher)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rule_counters`,
+				},
+			},
+		},
+	}
+
+	_, err := migrate.Exec(db.DB, "postgres", migrations, migrate.Up)
+	return err
+}
@@ -0,0 +1,270 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/rules"
+	"github.com/gofrs/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var _ rules.RuleRepository = (*ruleRepository)(nil)
+
+type ruleRepository struct {
+	db Database
+}
+
+// NewRuleRepository instantiates a PostgreSQL implementation of rule
+// repository.
+func NewRuleRepository(db Database) rules.RuleRepository {
+	return &ruleRepository{
+		db: db,
+	}
+}
+
+func (rr ruleRepository) Save(ctx context.Context, rs ...rules.Rule) ([]rules.Rule, error) {
+	tx, err := rr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return []rules.Rule{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	q := `INSERT INTO rules (id, owner, channel_id, name, condition, actions)
+		  VALUES (:id, :owner, :channel_id, :name, :condition, :actions);`
+
+	for _, rule := range rs {
+		dbr, err := toDBRule(rule)
+		if err != nil {
+			tx.Rollback()
+			return []rules.Rule{}, errors.Wrap(errors.ErrCreateEntity, err)
+		}
+
+		if _, err := tx.NamedExecContext(ctx, q, dbr); err != nil {
+			tx.Rollback()
+			pgErr, ok := err.(*pgconn.PgError)
+			if ok {
+				switch pgErr.Code {
+				case pgerrcode.InvalidTextRepresentation:
+					return []rules.Rule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+				case pgerrcode.UniqueViolation:
+					return []rules.Rule{}, errors.Wrap(errors.ErrConflict, err)
+				case pgerrcode.StringDataRightTruncationDataException:
+					return []rules.Rule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+				}
+			}
+			return []rules.Rule{}, errors.Wrap(errors.ErrCreateEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return []rules.Rule{}, errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return rs, nil
+}
+
+func (rr ruleRepository) RetrieveByID(ctx context.Context, id string) (rules.Rule, error) {
+	q := `SELECT id, owner, channel_id, name, condition, actions FROM rules WHERE id = $1;`
+
+	var dbr dbRule
+	if err := rr.db.QueryRowxContext(ctx, q, id).StructScan(&dbr); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		//  If there is no result or ID is in an invalid format, return ErrNotFound.
+		if err == sql.ErrNoRows || ok && pgerrcode.InvalidTextRepresentation == pgErr.Code {
+			return rules.Rule{}, errors.ErrNotFound
+		}
+		return rules.Rule{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toRule(dbr)
+}
+
+func (rr ruleRepository) RetrieveByOwner(ctx context.Context, owner string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	if owner == "" {
+		return rules.RulesPage{}, errors.ErrRetrieveEntity
+	}
+
+	return rr.retrieve(ctx, "owner = :owner", map[string]interface{}{"owner": owner}, pm)
+}
+
+func (rr ruleRepository) RetrieveByChannel(ctx context.Context, chanID string, pm rules.PageMetadata) (rules.RulesPage, error) {
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(chanID); err != nil {
+		return rules.RulesPage{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	return rr.retrieve(ctx, "channel_id = :channel_id", map[string]interface{}{"channel_id": chanID}, pm)
+}
+
+func (rr ruleRepository) Update(ctx context.Context, rule rules.Rule) error {
+	q := `UPDATE rules SET name = :name, condition = :condition, actions = :actions WHERE owner = :owner AND id = :id;`
+
+	dbr, err := toDBRule(rule)
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	res, err := rr.db.NamedExecContext(ctx, q, dbr)
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.StringDataRightTruncationDataException:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			}
+		}
+
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	if cnt == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (rr ruleRepository) Remove(ctx context.Context, owner string, ids ...string) error {
+	q := `DELETE FROM rules WHERE id = :id AND owner = :owner;`
+
+	for _, id := range ids {
+		dbr := dbRule{
+			ID:    id,
+			Owner: owner,
+		}
+
+		if _, err := rr.db.NamedExecContext(ctx, q, dbr); err != nil {
+			return errors.Wrap(errors.ErrRemoveEntity, err)
+		}
+	}
+
+	return nil
+}
+
+func (rr ruleRepository) retrieve(ctx context.Context, wq string, params map[string]interface{}, pm rules.PageMetadata) (rules.RulesPage, error) {
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
+	}
+
+	q := fmt.Sprintf(`SELECT id, owner, channel_id, name, condition, actions FROM rules WHERE %s ORDER BY id %s;`, wq, olq)
+	qc := fmt.Sprintf(`SELECT COUNT(*) FROM rules WHERE %s;`, wq)
+
+	params["limit"] = pm.Limit
+	params["offset"] = pm.Offset
+
+	rows, err := rr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return rules.RulesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+	defer rows.Close()
+
+	items := []rules.Rule{}
+	for rows.Next() {
+		var dbr dbRule
+		if err := rows.StructScan(&dbr); err != nil {
+			return rules.RulesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		rule, err := toRule(dbr)
+		if err != nil {
+			return rules.RulesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		}
+
+		items = append(items, rule)
+	}
+
+	total, err := total(ctx, rr.db, qc, params)
+	if err != nil {
+		return rules.RulesPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return rules.RulesPage{
+		Rules: items,
+		PageMetadata: rules.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}, nil
+}
+
+func total(ctx context.Context, db Database, query string, params interface{}) (uint64, error) {
+	rows, err := db.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	total := uint64(0)
+	if rows.Next() {
+		if err := rows.Scan(&total); err != nil {
+			return 0, err
+		}
+	}
+
+	return total, nil
+}
+
+type dbRule struct {
+	ID        string `db:"id"`
+	Owner     string `db:"owner"`
+	ChannelID string `db:"channel_id"`
+	Name      string `db:"name"`
+	Condition []byte `db:"condition"`
+	Actions   []byte `db:"actions"`
+}
+
+func toDBRule(rule rules.Rule) (dbRule, error) {
+	condition, err := json.Marshal(rule.Condition)
+	if err != nil {
+		return dbRule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return dbRule{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+
+	return dbRule{
+		ID:        rule.ID,
+		Owner:     rule.Owner,
+		ChannelID: rule.ChannelID,
+		Name:      rule.Name,
+		Condition: condition,
+		Actions:   actions,
+	}, nil
+}
+
+func toRule(dbr dbRule) (rules.Rule, error) {
+	rule := rules.Rule{
+		ID:        dbr.ID,
+		Owner:     dbr.Owner,
+		ChannelID: dbr.ChannelID,
+		Name:      dbr.Name,
+	}
+
+	if err := json.Unmarshal(dbr.Condition, &rule.Condition); err != nil {
+		return rules.Rule{}, errors.Wrap(errors.ErrScanMetadata, err)
+	}
+
+	if err := json.Unmarshal(dbr.Actions, &rule.Actions); err != nil {
+		return rules.Rule{}, errors.Wrap(errors.ErrScanMetadata, err)
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,305 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/rules"
+	"github.com/MainfluxLabs/mainflux/rules/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var idProvider = uuid.New()
+
+func newRule(t *testing.T, owner, chanID string) rules.Rule {
+	id, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	return rules.Rule{
+		ID:        id,
+		Owner:     owner,
+		ChannelID: chanID,
+		Name:      "rule",
+		Condition: rules.Condition{Field: "temp", Operator: rules.OpGT, Threshold: 40, Consecutive: 3},
+		Actions:   []rules.Action{{Type: rules.ActionSMTP, Contacts: []string{owner}}},
+	}
+}
+
+func TestRulesSave(t *testing.T) {
+	repo := postgres.NewRuleRepository(postgres.NewDatabase(db))
+
+	email := "rules-save@example.com"
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	rs := []rules.Rule{}
+	for i := 0; i < 5; i++ {
+		rs = append(rs, newRule(t, email, chanID))
+	}
+
+	invalidRule := newRule(t, email, "invalid")
+
+	cases := []struct {
+		desc  string
+		rules []rules.Rule
+		err   error
+	}{
+		{
+			desc:  "create new rules",
+			rules: rs,
+			err:   nil,
+		},
+		{
+			desc:  "create rules that already exist",
+			rules: rs,
+			err:   errors.ErrConflict,
+		},
+		{
+			desc:  "create rule with invalid channel ID",
+			rules: []rules.Rule{invalidRule},
+			err:   errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := repo.Save(context.Background(), tc.rules...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRuleRetrieveByID(t *testing.T) {
+	repo := postgres.NewRuleRepository(postgres.NewDatabase(db))
+
+	email := "rule-retrieve@example.com"
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	rule := newRule(t, email, chanID)
+	_, err = repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	nonexistentID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "retrieve existing rule",
+			id:   rule.ID,
+			rule: rule,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing rule",
+			id:   nonexistentID,
+			rule: rules.Rule{},
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "retrieve rule with malformed ID",
+			id:   "invalid",
+			rule: rules.Rule{},
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		r, err := repo.RetrieveByID(context.Background(), tc.id)
+		assert.Equal(t, tc.rule, r, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.rule, r))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRulesRetrieveByOwner(t *testing.T) {
+	repo := postgres.NewRuleRepository(postgres.NewDatabase(db))
+
+	email := "rules-retrieve-owner@example.com"
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		_, err := repo.Save(context.Background(), newRule(t, email, chanID))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		owner string
+		pm    rules.PageMetadata
+		size  uint64
+		err   error
+	}{
+		{
+			desc:  "retrieve all rules of existing owner",
+			owner: email,
+			pm:    rules.PageMetadata{Offset: 0, Limit: n},
+			size:  n,
+		},
+		{
+			desc:  "retrieve subset of rules of existing owner",
+			owner: email,
+			pm:    rules.PageMetadata{Offset: n / 2, Limit: n},
+			size:  n / 2,
+		},
+		{
+			desc:  "retrieve rules of non-existing owner",
+			owner: "nonexistent@example.com",
+			pm:    rules.PageMetadata{Offset: 0, Limit: n},
+			size:  0,
+		},
+		{
+			desc:  "retrieve rules with empty owner",
+			owner: "",
+			pm:    rules.PageMetadata{Offset: 0, Limit: n},
+			size:  0,
+			err:   errors.ErrRetrieveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveByOwner(context.Background(), tc.owner, tc.pm)
+		size := uint64(len(page.Rules))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRulesRetrieveByChannel(t *testing.T) {
+	repo := postgres.NewRuleRepository(postgres.NewDatabase(db))
+
+	email := "rules-retrieve-channel@example.com"
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	otherChanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		_, err := repo.Save(context.Background(), newRule(t, email, chanID))
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	}
+	_, err = repo.Save(context.Background(), newRule(t, email, otherChanID))
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		chanID string
+		pm     rules.PageMetadata
+		size   uint64
+		err    error
+	}{
+		{
+			desc:   "retrieve all channel rules without limit",
+			chanID: chanID,
+			pm:     rules.PageMetadata{},
+			size:   n,
+		},
+		{
+			desc:   "retrieve subset of channel rules",
+			chanID: chanID,
+			pm:     rules.PageMetadata{Offset: 2, Limit: 5},
+			size:   5,
+		},
+		{
+			desc:   "retrieve rules of other channel",
+			chanID: otherChanID,
+			pm:     rules.PageMetadata{},
+			size:   1,
+		},
+		{
+			desc:   "retrieve rules of channel with malformed ID",
+			chanID: "invalid",
+			pm:     rules.PageMetadata{},
+			size:   0,
+			err:    errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := repo.RetrieveByChannel(context.Background(), tc.chanID, tc.pm)
+		size := uint64(len(page.Rules))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, size))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRuleUpdate(t *testing.T) {
+	repo := postgres.NewRuleRepository(postgres.NewDatabase(db))
+
+	email := "rule-update@example.com"
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	rule := newRule(t, email, chanID)
+	_, err = repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	updated := rule
+	updated.Name = "updated"
+	updated.Condition.Threshold = 50
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "update existing rule",
+			rule: updated,
+			err:  nil,
+		},
+		{
+			desc: "update non-existing rule",
+			rule: newRule(t, email, chanID),
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "update rule of other owner",
+			rule: rules.Rule{ID: rule.ID, Owner: "other@example.com"},
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.Update(context.Background(), tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	r, err := repo.RetrieveByID(context.Background(), rule.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	assert.Equal(t, updated, r, fmt.Sprintf("expected %v got %v\n", updated, r))
+}
+
+func TestRulesRemove(t *testing.T) {
+	repo := postgres.NewRuleRepository(postgres.NewDatabase(db))
+
+	email := "rules-remove@example.com"
+	chanID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	rule := newRule(t, email, chanID)
+	_, err = repo.Save(context.Background(), rule)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+
+	err = repo.Remove(context.Background(), "other@example.com", rule.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = repo.RetrieveByID(context.Background(), rule.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove rule of other owner: expected rule to remain got %s\n", err))
+
+	err = repo.Remove(context.Background(), email, rule.ID)
+	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+	_, err = repo.RetrieveByID(context.Background(), rule.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("remove rule: expected %s got %s\n", errors.ErrNotFound, err))
+}
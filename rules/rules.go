@@ -0,0 +1,134 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import "context"
+
+const (
+	// ActionPublish publishes the message that triggered the rule to another channel.
+	ActionPublish = "publish"
+	// ActionSMTP sends the message that triggered the rule to the SMTP notifier.
+	ActionSMTP = "smtp"
+	// ActionSMPP sends the message that triggered the rule to the SMPP notifier.
+	ActionSMPP = "smpp"
+	// ActionWebhook sends the message that triggered the rule to the given URL.
+	ActionWebhook = "webhook"
+)
+
+// Operators supported by the rule condition.
+const (
+	OpEQ = "=="
+	OpNE = "!="
+	OpGT = ">"
+	OpGE = ">="
+	OpLT = "<"
+	OpLE = "<="
+)
+
+// Condition represents a comparison of the message field value with the
+// threshold, which has to be satisfied by the given number of consecutive
+// messages for the rule to be triggered.
+type Condition struct {
+	// Field is the SenML record name or the dot-separated path of the JSON
+	// payload field.
+	Field       string  `json:"field"`
+	Operator    string  `json:"operator"`
+	Threshold   float64 `json:"threshold"`
+	Consecutive uint    `json:"consecutive,omitempty"`
+}
+
+// Satisfied checks whether the given value satisfies the condition.
+func (c Condition) Satisfied(val float64) bool {
+	switch c.Operator {
+	case OpEQ:
+		return val == c.Threshold
+	case OpNE:
+		return val != c.Threshold
+	case OpGT:
+		return val > c.Threshold
+	case OpGE:
+		return val >= c.Threshold
+	case OpLT:
+		return val < c.Threshold
+	case OpLE:
+		return val <= c.Threshold
+	default:
+		return false
+	}
+}
+
+// Action represents an action performed once the rule is triggered.
+type Action struct {
+	Type      string   `json:"type"`
+	ChannelID string   `json:"channel_id,omitempty"`
+	Contacts  []string `json:"contacts,omitempty"`
+	URL       string   `json:"url,omitempty"`
+}
+
+// Rule represents a condition evaluated against the messages received over
+// the channel together with the actions performed when it is satisfied.
+type Rule struct {
+	ID        string
+	Owner     string
+	ChannelID string
+	Name      string
+	Condition Condition
+	Actions   []Action
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Total  uint64
+	Offset uint64
+	Limit  uint64
+}
+
+// RulesPage contains page related metadata as well as a list of rules that
+// belong to this page.
+type RulesPage struct {
+	PageMetadata
+	Rules []Rule
+}
+
+// RuleRepository specifies a rule persistence API.
+type RuleRepository interface {
+	// Save persists multiple rules. Rules are saved using a transaction.
+	// If one rule fails then none will be saved. Successful operation is
+	// indicated by non-nil error response.
+	Save(ctx context.Context, rules ...Rule) ([]Rule, error)
+
+	// RetrieveByID retrieves the rule having the provided identifier.
+	RetrieveByID(ctx context.Context, id string) (Rule, error)
+
+	// RetrieveByOwner retrieves the subset of rules owned by the specified user.
+	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (RulesPage, error)
+
+	// RetrieveByChannel retrieves the subset of rules defined for the specified
+	// channel. If the limit is zero, all the channel rules are retrieved.
+	RetrieveByChannel(ctx context.Context, chanID string, pm PageMetadata) (RulesPage, error)
+
+	// Update performs an update to the existing rule. A non-nil error is
+	// returned to indicate operation failure.
+	Update(ctx context.Context, rule Rule) error
+
+	// Remove removes the rules having the provided identifiers, that is owned
+	// by the specified user.
+	Remove(ctx context.Context, owner string, ids ...string) error
+}
+
+// CounterRepository specifies a persistence API of the numbers of consecutive
+// messages that satisfy the rule conditions, which is shared by the service
+// replicas.
+type CounterRepository interface {
+	// Increment increments the number of consecutive messages of the
+	// publisher that satisfy the rule condition, and reports whether it
+	// reached the limit, in which case the counter is reset.
+	Increment(ctx context.Context, ruleID, publisher string, limit uint) (bool, error)
+
+	// Reset resets the counter of the rule publisher.
+	Reset(ctx context.Context, ruleID, publisher string) error
+
+	// Remove removes the counters of all the publishers of the rule.
+	Remove(ctx context.Context, ruleID string) error
+}
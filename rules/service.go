@@ -0,0 +1,564 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/consumers"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	jsont "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	senmlt "github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/senml"
+)
+
+const (
+	protocol = "rules"
+	fieldSep = "."
+)
+
+var (
+	// ErrMessage indicates an error converting a message to Mainflux message.
+	ErrMessage = errors.New("failed to convert to Mainflux message")
+
+	// ErrAction indicates that performing a rule action failed.
+	ErrAction = errors.New("failed to perform rule action")
+
+	// ErrInvalidCondition indicates malformed rule condition.
+	ErrInvalidCondition = errors.New("invalid rule condition")
+
+	// ErrInvalidAction indicates malformed rule action.
+	ErrInvalidAction = errors.New("invalid rule action")
+)
+
+// Service specifies an API that must be fullfiled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+type Service interface {
+	// CreateRules adds rules to the user identified by the provided token.
+	CreateRules(ctx context.Context, token string, rules ...Rule) ([]Rule, error)
+
+	// ListRules retrieves the rules owned by the user identified by the
+	// provided token.
+	ListRules(ctx context.Context, token string, pm PageMetadata) (RulesPage, error)
+
+	// ListRulesByChannel retrieves the rules defined for the channel
+	// identified by the provided ID.
+	ListRulesByChannel(ctx context.Context, token, chanID string, pm PageMetadata) (RulesPage, error)
+
+	// ViewRule retrieves data about the rule identified by the provided ID.
+	ViewRule(ctx context.Context, token, id string) (Rule, error)
+
+	// UpdateRule updates the rule identified by the provided ID.
+	UpdateRule(ctx context.Context, token string, rule Rule) error
+
+	// RemoveRules removes the rules identified by the provided IDs.
+	RemoveRules(ctx context.Context, token string, ids ...string) error
+
+	consumers.Consumer
+}
+
+var _ Service = (*rulesService)(nil)
+
+type rulesService struct {
+	auth       mainflux.AuthServiceClient
+	things     mainflux.ThingsServiceClient
+	rules      RuleRepository
+	counters   CounterRepository
+	publisher  messaging.Publisher
+	idProvider mainflux.IDProvider
+}
+
+// New instantiates the rules service implementation.
+func New(auth mainflux.AuthServiceClient, things mainflux.ThingsServiceClient, rules RuleRepository, counters CounterRepository, publisher messaging.Publisher, idp mainflux.IDProvider) Service {
+	return &rulesService{
+		auth:       auth,
+		things:     things,
+		rules:      rules,
+		counters:   counters,
+		publisher:  publisher,
+		idProvider: idp,
+	}
+}
+
+func (rs *rulesService) CreateRules(ctx context.Context, token string, rules ...Rule) ([]Rule, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return []Rule{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	targets, err := rs.publishTargets(ctx, res.GetId(), "")
+	if err != nil {
+		return []Rule{}, err
+	}
+
+	for i := range rules {
+		if err := rs.authorizeChannels(ctx, token, res.GetId(), rules[i]); err != nil {
+			return []Rule{}, err
+		}
+
+		if err := addTargets(targets, rules[i]); err != nil {
+			return []Rule{}, err
+		}
+
+		id, err := rs.idProvider.ID()
+		if err != nil {
+			return []Rule{}, err
+		}
+
+		rules[i].ID = id
+		rules[i].Owner = res.GetId()
+	}
+
+	return rs.rules.Save(ctx, rules...)
+}
+
+func (rs *rulesService) ListRules(ctx context.Context, token string, pm PageMetadata) (RulesPage, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return RulesPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := rs.authorizeScope(ctx, token, auth.ReadAction, ""); err != nil {
+		return RulesPage{}, err
+	}
+
+	return rs.rules.RetrieveByOwner(ctx, res.GetId(), pm)
+}
+
+func (rs *rulesService) ListRulesByChannel(ctx context.Context, token, chanID string, pm PageMetadata) (RulesPage, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return RulesPage{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	if err := rs.authorizeScope(ctx, token, auth.ReadAction, chanID); err != nil {
+		return RulesPage{}, err
+	}
+
+	if _, err := rs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: res.GetId(), ChanID: chanID}); err != nil {
+		return RulesPage{}, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return rs.rules.RetrieveByChannel(ctx, chanID, pm)
+}
+
+func (rs *rulesService) ViewRule(ctx context.Context, token, id string) (Rule, error) {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return Rule{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	rule, err := rs.rules.RetrieveByID(ctx, id)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	if rule.Owner != res.GetId() {
+		return Rule{}, errors.ErrAuthorization
+	}
+
+	if err := rs.authorizeScope(ctx, token, auth.ReadAction, rule.ChannelID); err != nil {
+		return Rule{}, err
+	}
+
+	return rule, nil
+}
+
+func (rs *rulesService) UpdateRule(ctx context.Context, token string, rule Rule) error {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	r, err := rs.rules.RetrieveByID(ctx, rule.ID)
+	if err != nil {
+		return err
+	}
+
+	if r.Owner != res.GetId() {
+		return errors.ErrAuthorization
+	}
+
+	rule.Owner = r.Owner
+	rule.ChannelID = r.ChannelID
+	if err := rs.authorizeChannels(ctx, token, res.GetId(), rule); err != nil {
+		return err
+	}
+
+	targets, err := rs.publishTargets(ctx, res.GetId(), rule.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := addTargets(targets, rule); err != nil {
+		return err
+	}
+
+	if err := rs.rules.Update(ctx, rule); err != nil {
+		return err
+	}
+
+	return rs.counters.Remove(ctx, rule.ID)
+}
+
+func (rs *rulesService) RemoveRules(ctx context.Context, token string, ids ...string) error {
+	res, err := rs.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	for _, id := range ids {
+		rule, err := rs.rules.RetrieveByID(ctx, id)
+		if err != nil {
+			if errors.Contains(err, errors.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		if rule.Owner != res.GetId() {
+			continue
+		}
+		if err := rs.authorizeScope(ctx, token, auth.WriteAction, rule.ChannelID); err != nil {
+			return err
+		}
+	}
+
+	return rs.rules.Remove(ctx, res.GetId(), ids...)
+}
+
+func (rs *rulesService) Consume(message interface{}) error {
+	var ins []input
+	switch m := message.(type) {
+	case []senmlt.Message:
+		for _, msg := range m {
+			ins = append(ins, senmlInput(msg))
+		}
+	case jsont.Messages:
+		for _, msg := range m.Data {
+			ins = append(ins, jsonInput(msg, m.Format))
+		}
+	default:
+		return ErrMessage
+	}
+
+	ctx := context.Background()
+	rulesByChan := make(map[string][]Rule)
+	var errs []string
+	for _, in := range ins {
+		rules, ok := rulesByChan[in.channel]
+		if !ok {
+			page, err := rs.rules.RetrieveByChannel(ctx, in.channel, PageMetadata{})
+			if err != nil {
+				return err
+			}
+			rules = page.Rules
+			rulesByChan[in.channel] = rules
+		}
+
+		for _, rule := range rules {
+			triggered, err := rs.triggered(ctx, rule, in)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			if !triggered {
+				continue
+			}
+
+			for _, action := range rule.Actions {
+				if err := rs.perform(ctx, rule, action, in); err != nil {
+					errs = append(errs, err.Error())
+				}
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Wrap(ErrAction, errors.New(strings.Join(errs, "; ")))
+	}
+
+	return nil
+}
+
+// triggered updates the number of consecutive messages sent by the input
+// publisher that satisfy the rule condition, and reports whether it reached
+// the number required by the rule. Messages that do not contain the
+// condition field are ignored. The counters are kept in the repository, so
+// that the consecutive messages are counted regardless of the replica that
+// receives them.
+func (rs *rulesService) triggered(ctx context.Context, rule Rule, in input) (bool, error) {
+	val, ok := in.value(rule.Condition.Field)
+	if !ok {
+		return false, nil
+	}
+
+	if !rule.Condition.Satisfied(val) {
+		return false, rs.counters.Reset(ctx, rule.ID, in.publisher)
+	}
+
+	consecutive := rule.Condition.Consecutive
+	if consecutive == 0 {
+		consecutive = 1
+	}
+
+	return rs.counters.Increment(ctx, rule.ID, in.publisher, consecutive)
+}
+
+func (rs *rulesService) perform(ctx context.Context, rule Rule, action Action, in input) error {
+	msg := messaging.Message{
+		Protocol:  protocol,
+		Channel:   rule.ChannelID,
+		Subtopic:  in.subtopic,
+		Publisher: in.publisher,
+		Payload:   in.payload,
+		Created:   time.Now().UnixNano(),
+		Profile: &messaging.Profile{
+			ContentType: in.contentType,
+			TimeField:   &messaging.TimeField{},
+			Writer:      &messaging.Writer{},
+			Notifier:    &messaging.Notifier{},
+		},
+	}
+
+	switch action.Type {
+	case ActionPublish:
+		return rs.publishTo(ctx, action.ChannelID, in)
+	case ActionSMTP, ActionSMPP:
+		msg.Profile.Notifier = &messaging.Notifier{
+			Protocol:  action.Type,
+			Contacts:  action.Contacts,
+			Subtopics: []string{in.subtopic},
+		}
+		return rs.publisher.Publish(msg)
+	case ActionWebhook:
+		// Webhook requests are sent by the webhook notifier, which signs,
+		// retries and checks the destinations of the requests.
+		msg.Profile.Notifier = &messaging.Notifier{
+			Protocol:  messaging.WebhookProtocol,
+			Contacts:  []string{action.URL},
+			Subtopics: []string{in.subtopic},
+		}
+		return rs.publisher.Publish(msg)
+	default:
+		return ErrInvalidAction
+	}
+}
+
+// publishTo publishes the input message to the channel using the channel
+// profile, the same way the adapters publish the messages of the channel.
+// The message keeps the content type of the message that triggered the rule,
+// since its payload is not converted.
+func (rs *rulesService) publishTo(ctx context.Context, chanID string, in input) error {
+	profile, err := rs.things.GetChannelProfile(ctx, &mainflux.ChannelID{Value: chanID})
+	if err != nil {
+		return err
+	}
+
+	conn := &mainflux.ConnByKeyRes{ChannelID: chanID, ThingID: in.publisher, Profile: profile}
+	msg := messaging.CreateMessage(conn, protocol, in.subtopic, &in.payload)
+	msg.Profile.ContentType = in.contentType
+	if in.contentType != messaging.JsonContentType {
+		msg.Profile.TimeField = &messaging.TimeField{}
+	}
+
+	return rs.publisher.Publish(msg)
+}
+
+// authorizeChannels checks whether the user owns the rule channel as well as
+// the channels the rule publishes to, and whether the token scopes allow
+// modifying them.
+func (rs *rulesService) authorizeChannels(ctx context.Context, token, owner string, rule Rule) error {
+	chanIDs := []string{rule.ChannelID}
+	for _, action := range rule.Actions {
+		if action.Type == ActionPublish {
+			chanIDs = append(chanIDs, action.ChannelID)
+		}
+	}
+
+	for _, chanID := range chanIDs {
+		if err := rs.authorizeScope(ctx, token, auth.WriteAction, chanID); err != nil {
+			return err
+		}
+		if _, err := rs.things.IsChannelOwner(ctx, &mainflux.ChannelOwnerReq{Owner: owner, ChanID: chanID}); err != nil {
+			return errors.Wrap(errors.ErrAuthorization, err)
+		}
+	}
+
+	return nil
+}
+
+// authorizeScope checks whether the token scopes allow the action on the
+// channel, since rules are defined for and publish to channels.
+func (rs *rulesService) authorizeScope(ctx context.Context, token, action, chanID string) error {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: auth.ChannelsSubject,
+		Object:  chanID,
+		Action:  action,
+	}
+
+	if _, err := rs.auth.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return nil
+}
+
+// publishTargets maps the channels of the owner rules to the channels the
+// rules publish to, skipping the rule identified by the provided ID.
+func (rs *rulesService) publishTargets(ctx context.Context, owner, skipID string) (map[string][]string, error) {
+	page, err := rs.rules.RetrieveByOwner(ctx, owner, PageMetadata{})
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string][]string)
+	for _, rule := range page.Rules {
+		if rule.ID == skipID {
+			continue
+		}
+		for _, action := range rule.Actions {
+			if action.Type == ActionPublish {
+				targets[rule.ChannelID] = append(targets[rule.ChannelID], action.ChannelID)
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// addTargets adds the channels the rule publishes to, unless they lead back
+// to the rule channel through the publish actions of the other rules, since
+// the rules would then evaluate their own messages endlessly.
+func addTargets(targets map[string][]string, rule Rule) error {
+	for _, action := range rule.Actions {
+		if action.Type != ActionPublish {
+			continue
+		}
+		if reaches(targets, action.ChannelID, rule.ChannelID, map[string]bool{}) {
+			return ErrInvalidAction
+		}
+	}
+
+	for _, action := range rule.Actions {
+		if action.Type == ActionPublish {
+			targets[rule.ChannelID] = append(targets[rule.ChannelID], action.ChannelID)
+		}
+	}
+
+	return nil
+}
+
+// reaches reports whether the messages published to the from channel end up
+// in the to channel.
+func reaches(targets map[string][]string, from, to string, visited map[string]bool) bool {
+	if from == to {
+		return true
+	}
+	if visited[from] {
+		return false
+	}
+	visited[from] = true
+
+	for _, next := range targets[from] {
+		if reaches(targets, next, to, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// input represents a single message the rules are evaluated against.
+type input struct {
+	channel     string
+	subtopic    string
+	publisher   string
+	contentType string
+	payload     []byte
+	value       func(field string) (float64, bool)
+}
+
+func senmlInput(msg senmlt.Message) input {
+	rec := senml.Record{
+		Name:        msg.Name,
+		Unit:        msg.Unit,
+		Time:        msg.Time,
+		UpdateTime:  msg.UpdateTime,
+		Value:       msg.Value,
+		StringValue: msg.StringValue,
+		DataValue:   msg.DataValue,
+		BoolValue:   msg.BoolValue,
+		Sum:         msg.Sum,
+	}
+	payload, _ := senml.Encode(senml.Pack{Records: []senml.Record{rec}}, senml.JSON)
+
+	return input{
+		channel:     msg.Channel,
+		subtopic:    msg.Subtopic,
+		publisher:   msg.Publisher,
+		contentType: messaging.SenmlContentType,
+		payload:     payload,
+		value: func(field string) (float64, bool) {
+			if msg.Name != field || msg.Value == nil {
+				return 0, false
+			}
+			return *msg.Value, true
+		},
+	}
+}
+
+func jsonInput(msg jsont.Message, format string) input {
+	payload, _ := json.Marshal(msg.Payload)
+
+	subtopic := format
+	if msg.Subtopic != "" {
+		subtopic = fmt.Sprintf("%s%s%s", msg.Subtopic, fieldSep, format)
+	}
+
+	return input{
+		channel:     msg.Channel,
+		subtopic:    subtopic,
+		publisher:   msg.Publisher,
+		contentType: messaging.JsonContentType,
+		payload:     payload,
+		value: func(field string) (float64, bool) {
+			return lookup(msg.Payload, strings.Split(field, fieldSep))
+		},
+	}
+}
+
+// lookup returns the numeric value of the nested JSON field.
+func lookup(payload map[string]interface{}, path []string) (float64, bool) {
+	val, ok := payload[path[0]]
+	if !ok {
+		return 0, false
+	}
+
+	if len(path) > 1 {
+		nested, ok := val.(map[string]interface{})
+		if !ok {
+			return 0, false
+		}
+		return lookup(nested, path[1:])
+	}
+
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,600 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package rules_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	authmock "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	jsont "github.com/MainfluxLabs/mainflux/pkg/transformers/json"
+	senmlt "github.com/MainfluxLabs/mainflux/pkg/transformers/senml"
+	"github.com/MainfluxLabs/mainflux/pkg/uuid"
+	"github.com/MainfluxLabs/mainflux/rules"
+	"github.com/MainfluxLabs/mainflux/rules/mocks"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	userEmail      = "user@example.com"
+	otherUserEmail = "other.user@example.com"
+	token          = userEmail
+	otherToken     = otherUserEmail
+	wrongValue     = "wrong-value"
+	chanID         = "5384fb1c-d0ae-4cbe-be52-c54223150fe0"
+	targetChanID   = "d2ebb3f1-ee6f-4b2a-8a4a-3e8f2ec6d1b1"
+	otherChanID    = "b4c7cd8a-8de0-4c79-9c57-0e66d6b1f4ab"
+	publisherID    = "0ee2aa1a-2ddf-4bb5-91d5-9a1b3c1b4f3e"
+	webhookURL     = "https://example.com/hook"
+	n              = 10
+)
+
+var (
+	user      = users.User{ID: "574106f7-030e-4881-8ab0-151195c29f94", Email: userEmail, Password: "password"}
+	otherUser = users.User{ID: "ecf9e48b-ba3b-41c4-82a9-72e063b17868", Email: otherUserEmail, Password: "password"}
+	usersList = []users.User{user, otherUser}
+
+	targetProfile = &mainflux.Profile{
+		ContentType: messaging.SenmlContentType,
+		Writer:      &mainflux.Writer{Retain: true, Retention: "24h"},
+		Notifier:    &mainflux.Notifier{Protocol: messaging.SMTPProtocol, Contacts: []string{otherUserEmail}, Subtopics: []string{""}},
+		RateLimit:   &mainflux.RateLimit{Channel: &mainflux.Limit{Rate: 10, Burst: 20}},
+	}
+
+	rule = rules.Rule{
+		Name:      "overheating",
+		ChannelID: chanID,
+		Condition: rules.Condition{Field: "temp", Operator: rules.OpGT, Threshold: 40, Consecutive: 3},
+		Actions: []rules.Action{
+			{Type: rules.ActionPublish, ChannelID: targetChanID},
+			{Type: rules.ActionSMTP, Contacts: []string{userEmail}},
+		},
+	}
+)
+
+func newService() (rules.Service, mocks.Publisher) {
+	auth := authmock.NewAuthService("", usersList)
+	things := mocks.NewThingsServiceClient(map[string][]string{
+		user.ID:      {chanID, targetChanID},
+		otherUser.ID: {otherChanID},
+	}, map[string]*mainflux.Profile{targetChanID: targetProfile})
+	pub := mocks.NewPublisher()
+
+	return rules.New(auth, things, mocks.NewRuleRepository(), mocks.NewCounterRepository(), pub, uuid.NewMock()), pub
+}
+
+func TestCreateRules(t *testing.T) {
+	svc, _ := newService()
+
+	foreignTarget := rule
+	foreignTarget.Actions = []rules.Action{{Type: rules.ActionPublish, ChannelID: otherChanID}}
+
+	selfTarget := rule
+	selfTarget.Actions = []rules.Action{{Type: rules.ActionPublish, ChannelID: chanID}}
+
+	cycle := rule
+	cycle.ChannelID = targetChanID
+	cycle.Actions = []rules.Action{{Type: rules.ActionPublish, ChannelID: chanID}}
+
+	cases := []struct {
+		desc  string
+		rules []rules.Rule
+		token string
+		err   error
+	}{
+		{
+			desc:  "create new rules",
+			rules: []rules.Rule{rule, rule},
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "create rule with wrong credentials",
+			rules: []rules.Rule{rule},
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "create rule for channel owned by other user",
+			rules: []rules.Rule{rule},
+			token: otherToken,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create rule publishing to channel owned by other user",
+			rules: []rules.Rule{foreignTarget},
+			token: token,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "create rule publishing to its own channel",
+			rules: []rules.Rule{selfTarget},
+			token: token,
+			err:   rules.ErrInvalidAction,
+		},
+		{
+			desc:  "create rule publishing back to channel of other rule",
+			rules: []rules.Rule{cycle},
+			token: token,
+			err:   rules.ErrInvalidAction,
+		},
+	}
+
+	for _, tc := range cases {
+		rs, err := svc.CreateRules(context.Background(), tc.token, tc.rules...)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			for _, r := range rs {
+				assert.NotEmpty(t, r.ID, fmt.Sprintf("%s: expected non-empty rule ID\n", tc.desc))
+				assert.Equal(t, user.ID, r.Owner, fmt.Sprintf("%s: expected owner %s got %s\n", tc.desc, user.ID, r.Owner))
+			}
+		}
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	svc, _ := newService()
+	rs, err := svc.CreateRules(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	saved := rs[0]
+
+	cases := []struct {
+		desc  string
+		id    string
+		token string
+		err   error
+	}{
+		{
+			desc:  "view existing rule",
+			id:    saved.ID,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "view rule with wrong credentials",
+			id:    saved.ID,
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "view rule owned by other user",
+			id:    saved.ID,
+			token: otherToken,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "view non-existing rule",
+			id:    wrongValue,
+			token: token,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.ViewRule(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListRules(t *testing.T) {
+	svc, _ := newService()
+	for i := 0; i < n; i++ {
+		_, err := svc.CreateRules(context.Background(), token, rule)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		pm    rules.PageMetadata
+		size  int
+		err   error
+	}{
+		{
+			desc:  "list all rules",
+			token: token,
+			pm:    rules.PageMetadata{Offset: 0, Limit: n},
+			size:  n,
+		},
+		{
+			desc:  "list half of the rules",
+			token: token,
+			pm:    rules.PageMetadata{Offset: n / 2, Limit: n},
+			size:  n / 2,
+		},
+		{
+			desc:  "list rules of user without rules",
+			token: otherToken,
+			pm:    rules.PageMetadata{Offset: 0, Limit: n},
+			size:  0,
+		},
+		{
+			desc:  "list rules with wrong credentials",
+			token: wrongValue,
+			pm:    rules.PageMetadata{Offset: 0, Limit: n},
+			size:  0,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListRules(context.Background(), tc.token, tc.pm)
+		assert.Equal(t, tc.size, len(page.Rules), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Rules)))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestListRulesByChannel(t *testing.T) {
+	svc, _ := newService()
+	for i := 0; i < n; i++ {
+		_, err := svc.CreateRules(context.Background(), token, rule)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
+
+	cases := []struct {
+		desc   string
+		token  string
+		chanID string
+		size   int
+		err    error
+	}{
+		{
+			desc:   "list rules of channel",
+			token:  token,
+			chanID: chanID,
+			size:   n,
+		},
+		{
+			desc:   "list rules of channel without rules",
+			token:  token,
+			chanID: targetChanID,
+			size:   0,
+		},
+		{
+			desc:   "list rules of channel owned by other user",
+			token:  otherToken,
+			chanID: chanID,
+			size:   0,
+			err:    errors.ErrAuthorization,
+		},
+		{
+			desc:   "list rules of channel with wrong credentials",
+			token:  wrongValue,
+			chanID: chanID,
+			size:   0,
+			err:    errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		page, err := svc.ListRulesByChannel(context.Background(), tc.token, tc.chanID, rules.PageMetadata{Limit: n})
+		assert.Equal(t, tc.size, len(page.Rules), fmt.Sprintf("%s: expected %d got %d\n", tc.desc, tc.size, len(page.Rules)))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc, _ := newService()
+	rs, err := svc.CreateRules(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	saved := rs[0]
+
+	updated := saved
+	updated.Name = "updated"
+	updated.Condition.Threshold = 50
+
+	foreignTarget := saved
+	foreignTarget.Actions = []rules.Action{{Type: rules.ActionPublish, ChannelID: otherChanID}}
+
+	nonexistent := saved
+	nonexistent.ID = wrongValue
+
+	target := rule
+	target.ChannelID = targetChanID
+	target.Actions = []rules.Action{{Type: rules.ActionSMTP, Contacts: []string{userEmail}}}
+	rs, err = svc.CreateRules(context.Background(), token, target)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cycle := rs[0]
+	cycle.Actions = []rules.Action{{Type: rules.ActionPublish, ChannelID: chanID}}
+
+	cases := []struct {
+		desc  string
+		rule  rules.Rule
+		token string
+		err   error
+	}{
+		{
+			desc:  "update existing rule",
+			rule:  updated,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "update rule with wrong credentials",
+			rule:  updated,
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "update rule owned by other user",
+			rule:  updated,
+			token: otherToken,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update rule to publish to channel owned by other user",
+			rule:  foreignTarget,
+			token: token,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "update rule to publish back to channel of other rule",
+			rule:  cycle,
+			token: token,
+			err:   rules.ErrInvalidAction,
+		},
+		{
+			desc:  "update non-existing rule",
+			rule:  nonexistent,
+			token: token,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UpdateRule(context.Background(), tc.token, tc.rule)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	r, err := svc.ViewRule(context.Background(), token, saved.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	assert.Equal(t, updated, r, fmt.Sprintf("expected %v got %v\n", updated, r))
+}
+
+func TestRemoveRules(t *testing.T) {
+	svc, _ := newService()
+	rs, err := svc.CreateRules(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	saved := rs[0]
+
+	cases := []struct {
+		desc  string
+		id    string
+		token string
+		err   error
+	}{
+		{
+			desc:  "remove rule with wrong credentials",
+			id:    saved.ID,
+			token: wrongValue,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "remove existing rule",
+			id:    saved.ID,
+			token: token,
+			err:   nil,
+		},
+		{
+			desc:  "remove removed rule",
+			id:    saved.ID,
+			token: token,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.RemoveRules(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.ViewRule(context.Background(), token, saved.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view removed rule: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestConsumeSenML(t *testing.T) {
+	svc, pub := newService()
+	_, err := svc.CreateRules(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	msg := func(name string, val float64) []senmlt.Message {
+		return []senmlt.Message{{Channel: chanID, Publisher: publisherID, Name: name, Value: &val}}
+	}
+
+	cases := []struct {
+		desc      string
+		msg       []senmlt.Message
+		published int
+	}{
+		{
+			desc:      "consume first message satisfying condition",
+			msg:       msg("temp", 41),
+			published: 0,
+		},
+		{
+			desc:      "consume second message satisfying condition",
+			msg:       msg("temp", 42),
+			published: 0,
+		},
+		{
+			desc:      "consume message not satisfying condition",
+			msg:       msg("temp", 39),
+			published: 0,
+		},
+		{
+			desc:      "consume message with other field",
+			msg:       msg("humidity", 90),
+			published: 0,
+		},
+		{
+			desc:      "consume first message satisfying condition after reset",
+			msg:       msg("temp", 43),
+			published: 0,
+		},
+		{
+			desc:      "consume second message satisfying condition after reset",
+			msg:       msg("temp", 44),
+			published: 0,
+		},
+		{
+			desc:      "consume third consecutive message satisfying condition",
+			msg:       msg("temp", 45),
+			published: 2,
+		},
+		{
+			desc:      "consume message satisfying condition after rule was triggered",
+			msg:       msg("temp", 46),
+			published: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Consume(tc.msg)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		published := len(pub.Messages())
+		assert.Equal(t, tc.published, published, fmt.Sprintf("%s: expected %d published messages got %d\n", tc.desc, tc.published, published))
+	}
+
+	msgs := pub.Messages()
+	require.Len(t, msgs, 2)
+	assert.Equal(t, targetChanID, msgs[0].Channel, fmt.Sprintf("publish action: expected channel %s got %s\n", targetChanID, msgs[0].Channel))
+	assert.True(t, msgs[0].Profile.Writer.Retain, "publish action: expected message to be retained\n")
+	assert.Equal(t, "24h", msgs[0].Profile.Writer.Retention, "publish action: expected writer of the target channel profile\n")
+	assert.Equal(t, []string{otherUserEmail}, msgs[0].Profile.Notifier.Contacts, "publish action: expected notifier of the target channel profile\n")
+	assert.Equal(t, &messaging.Limit{Rate: 10, Burst: 20}, msgs[0].Profile.RateLimit.Channel, "publish action: expected rate limit of the target channel profile\n")
+	assert.Equal(t, messaging.SenmlContentType, msgs[0].Profile.ContentType, "publish action: expected content type of the consumed message\n")
+	assert.Equal(t, rules.ActionSMTP, msgs[1].Profile.Notifier.Protocol, fmt.Sprintf("smtp action: expected notifier protocol %s got %s\n", rules.ActionSMTP, msgs[1].Profile.Notifier.Protocol))
+	assert.Equal(t, []string{userEmail}, msgs[1].Profile.Notifier.Contacts, fmt.Sprintf("smtp action: expected contacts %v got %v\n", []string{userEmail}, msgs[1].Profile.Notifier.Contacts))
+}
+
+func TestConsumeJSON(t *testing.T) {
+	svc, pub := newService()
+
+	r := rules.Rule{
+		ChannelID: chanID,
+		Condition: rules.Condition{Field: "sensor.temp", Operator: rules.OpGE, Threshold: 40},
+		Actions:   []rules.Action{{Type: rules.ActionWebhook, URL: webhookURL}},
+	}
+	_, err := svc.CreateRules(context.Background(), token, r)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	msg := func(payload map[string]interface{}) jsont.Messages {
+		return jsont.Messages{
+			Format: "reading",
+			Data:   []jsont.Message{{Channel: chanID, Publisher: publisherID, Payload: payload}},
+		}
+	}
+
+	cases := []struct {
+		desc      string
+		msg       interface{}
+		published int
+		err       error
+	}{
+		{
+			desc:      "consume message satisfying condition",
+			msg:       msg(map[string]interface{}{"sensor": map[string]interface{}{"temp": float64(40)}}),
+			published: 1,
+		},
+		{
+			desc:      "consume message not satisfying condition",
+			msg:       msg(map[string]interface{}{"sensor": map[string]interface{}{"temp": float64(20)}}),
+			published: 1,
+		},
+		{
+			desc:      "consume message without condition field",
+			msg:       msg(map[string]interface{}{"temp": float64(50)}),
+			published: 1,
+		},
+		{
+			desc:      "consume message of unsupported type",
+			msg:       wrongValue,
+			published: 1,
+			err:       rules.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Consume(tc.msg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		published := len(pub.Messages())
+		assert.Equal(t, tc.published, published, fmt.Sprintf("%s: expected %d published messages got %d\n", tc.desc, tc.published, published))
+	}
+
+	msgs := pub.Messages()
+	require.Len(t, msgs, 1)
+	assert.False(t, msgs[0].Profile.Writer.Retain, "webhook action: expected message not to be retained\n")
+	assert.Equal(t, messaging.WebhookProtocol, msgs[0].Profile.Notifier.Protocol, fmt.Sprintf("webhook action: expected notifier protocol %s got %s\n", messaging.WebhookProtocol, msgs[0].Profile.Notifier.Protocol))
+	assert.Equal(t, []string{webhookURL}, msgs[0].Profile.Notifier.Contacts, fmt.Sprintf("webhook action: expected contacts %v got %v\n", []string{webhookURL}, msgs[0].Profile.Notifier.Contacts))
+	assert.Equal(t, messaging.WebhookProtocol, messaging.NotifierSubject(msgs[0]), "webhook action: expected message to be forwarded to the webhook notifier\n")
+}
+
+func TestScopedKeyRules(t *testing.T) {
+	readToken := "channels-read"
+	writeToken := "channel-write"
+	keys := map[string]auth.Key{
+		readToken:  {Type: auth.APIKey, Subject: userEmail, Scopes: []auth.Scope{{Resource: auth.ChannelsSubject, Action: auth.ReadScope}}},
+		writeToken: {Type: auth.APIKey, Subject: userEmail, Scopes: []auth.Scope{{Resource: auth.ChannelsSubject, Action: auth.WriteScope, ID: chanID}}},
+	}
+	things := mocks.NewThingsServiceClient(map[string][]string{user.ID: {chanID, targetChanID}}, nil)
+	svc := rules.New(authmock.NewAuthServiceWithScopes("", usersList, nil, keys), things, mocks.NewRuleRepository(), mocks.NewCounterRepository(), mocks.NewPublisher(), uuid.NewMock())
+
+	rs, err := svc.CreateRules(context.Background(), token, rule)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	noTarget := rule
+	noTarget.Actions = []rules.Action{{Type: rules.ActionSMTP, Contacts: []string{userEmail}}}
+
+	cases := []struct {
+		desc string
+		op   func() error
+		err  error
+	}{
+		{
+			desc: "view rule with channels read scope",
+			op:   func() error { _, err := svc.ViewRule(context.Background(), readToken, rs[0].ID); return err },
+			err:  nil,
+		},
+		{
+			desc: "create rule with channels read scope",
+			op:   func() error { _, err := svc.CreateRules(context.Background(), readToken, noTarget); return err },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "remove rule with channels read scope",
+			op:   func() error { return svc.RemoveRules(context.Background(), readToken, rs[0].ID) },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "create rule with rule channel write scope",
+			op:   func() error { _, err := svc.CreateRules(context.Background(), writeToken, noTarget); return err },
+			err:  nil,
+		},
+		{
+			desc: "create rule publishing to channel outside of write scope",
+			op:   func() error { _, err := svc.CreateRules(context.Background(), writeToken, rule); return err },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "list rules with rule channel write scope",
+			op: func() error {
+				_, err := svc.ListRules(context.Background(), writeToken, rules.PageMetadata{})
+				return err
+			},
+			err: errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.op()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
@@ -0,0 +1,422 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/things"
+)
+
+var _ things.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	svc       things.Service
+	auth      mainflux.AuthServiceClient
+	publisher audit.Publisher
+	logger    log.Logger
+}
+
+// AuditMiddleware publishes the audit records of the successful operations
+// which change things, channels and groups.
+func AuditMiddleware(svc things.Service, auth mainflux.AuthServiceClient, publisher audit.Publisher, logger log.Logger) things.Service {
+	return &auditMiddleware{
+		svc:       svc,
+		auth:      auth,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (am *auditMiddleware) CreateThings(ctx context.Context, token string, ths ...things.Thing) ([]things.Thing, error) {
+	saved, err := am.svc.CreateThings(ctx, token, ths...)
+	if err != nil {
+		return saved, err
+	}
+
+	for _, th := range saved {
+		am.publish(ctx, token, audit.ActionCreate, audit.EntityThing, th.ID, "", nil, thingFields(th))
+	}
+
+	return saved, nil
+}
+
+func (am *auditMiddleware) UpdateThing(ctx context.Context, token string, thing things.Thing) error {
+	before, _ := am.svc.ViewThing(ctx, token, thing.ID)
+
+	if err := am.svc.UpdateThing(ctx, token, thing); err != nil {
+		return err
+	}
+
+	// The request contains only the updated fields, so the thing is read
+	// again to record its stored state.
+	after, err := am.svc.ViewThing(ctx, token, thing.ID)
+	if err != nil {
+		after = thing
+	}
+
+	am.publish(ctx, token, audit.ActionUpdate, audit.EntityThing, thing.ID, am.thingOrg(ctx, token, thing.ID), thingFields(before), thingFields(after))
+	return nil
+}
+
+func (am *auditMiddleware) UpdateKey(ctx context.Context, token, id, key string) error {
+	if err := am.svc.UpdateKey(ctx, token, id, key); err != nil {
+		return err
+	}
+
+	am.publish(ctx, token, audit.ActionUpdateKey, audit.EntityThing, id, am.thingOrg(ctx, token, id), nil, nil)
+	return nil
+}
+
+func (am *auditMiddleware) ViewThing(ctx context.Context, token, id string) (things.Thing, error) {
+	return am.svc.ViewThing(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListThings(ctx context.Context, token string, admin bool, pm things.PageMetadata) (things.Page, error) {
+	return am.svc.ListThings(ctx, token, admin, pm)
+}
+
+func (am *auditMiddleware) ListThingsByIDs(ctx context.Context, ids []string) (things.Page, error) {
+	return am.svc.ListThingsByIDs(ctx, ids)
+}
+
+func (am *auditMiddleware) ListThingsByChannel(ctx context.Context, token, chID string, pm things.PageMetadata) (things.Page, error) {
+	return am.svc.ListThingsByChannel(ctx, token, chID, pm)
+}
+
+func (am *auditMiddleware) RemoveThings(ctx context.Context, token string, ids ...string) error {
+	before := map[string]things.Thing{}
+	orgs := map[string]string{}
+	for _, id := range ids {
+		if th, err := am.svc.ViewThing(ctx, token, id); err == nil {
+			before[id] = th
+			orgs[id] = am.thingOrg(ctx, token, id)
+		}
+	}
+
+	if err := am.svc.RemoveThings(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		am.publish(ctx, token, audit.ActionRemove, audit.EntityThing, id, orgs[id], thingFields(before[id]), nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) CreateChannels(ctx context.Context, token string, channels ...things.Channel) ([]things.Channel, error) {
+	saved, err := am.svc.CreateChannels(ctx, token, channels...)
+	if err != nil {
+		return saved, err
+	}
+
+	for _, ch := range saved {
+		am.publish(ctx, token, audit.ActionCreate, audit.EntityChannel, ch.ID, "", nil, channelFields(ch))
+	}
+
+	return saved, nil
+}
+
+func (am *auditMiddleware) UpdateChannel(ctx context.Context, token string, channel things.Channel) error {
+	before, _ := am.svc.ViewChannel(ctx, token, channel.ID)
+
+	if err := am.svc.UpdateChannel(ctx, token, channel); err != nil {
+		return err
+	}
+
+	after, err := am.svc.ViewChannel(ctx, token, channel.ID)
+	if err != nil {
+		after = channel
+	}
+
+	am.publish(ctx, token, audit.ActionUpdate, audit.EntityChannel, channel.ID, am.channelOrg(ctx, token, channel.ID), channelFields(before), channelFields(after))
+	return nil
+}
+
+func (am *auditMiddleware) ViewChannel(ctx context.Context, token, id string) (things.Channel, error) {
+	return am.svc.ViewChannel(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListChannels(ctx context.Context, token string, admin bool, pm things.PageMetadata) (things.ChannelsPage, error) {
+	return am.svc.ListChannels(ctx, token, admin, pm)
+}
+
+func (am *auditMiddleware) ListChannelsByThing(ctx context.Context, token, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	return am.svc.ListChannelsByThing(ctx, token, thID, pm)
+}
+
+func (am *auditMiddleware) RemoveChannels(ctx context.Context, token string, ids ...string) error {
+	before := map[string]things.Channel{}
+	orgs := map[string]string{}
+	for _, id := range ids {
+		if ch, err := am.svc.ViewChannel(ctx, token, id); err == nil {
+			before[id] = ch
+			orgs[id] = am.channelOrg(ctx, token, id)
+		}
+	}
+
+	if err := am.svc.RemoveChannels(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		am.publish(ctx, token, audit.ActionRemove, audit.EntityChannel, id, orgs[id], channelFields(before[id]), nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) ViewChannelProfile(ctx context.Context, chID string) (things.Profile, error) {
+	return am.svc.ViewChannelProfile(ctx, chID)
+}
+
+func (am *auditMiddleware) ListRetentionProfiles(ctx context.Context) (map[string]things.Profile, error) {
+	return am.svc.ListRetentionProfiles(ctx)
+}
+
+func (am *auditMiddleware) Connect(ctx context.Context, token, chID string, thIDs []string) error {
+	if err := am.svc.Connect(ctx, token, chID, thIDs); err != nil {
+		return err
+	}
+
+	am.publish(ctx, token, audit.ActionConnect, audit.EntityChannel, chID, am.channelOrg(ctx, token, chID), nil, map[string]interface{}{"things": thIDs})
+	return nil
+}
+
+func (am *auditMiddleware) Disconnect(ctx context.Context, token, chID string, thIDs []string) error {
+	if err := am.svc.Disconnect(ctx, token, chID, thIDs); err != nil {
+		return err
+	}
+
+	am.publish(ctx, token, audit.ActionDisconnect, audit.EntityChannel, chID, am.channelOrg(ctx, token, chID), map[string]interface{}{"things": thIDs}, nil)
+	return nil
+}
+
+func (am *auditMiddleware) GetConnByKey(ctx context.Context, chanID, key string) (things.Connection, error) {
+	return am.svc.GetConnByKey(ctx, chanID, key)
+}
+
+func (am *auditMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) error {
+	return am.svc.IsChannelOwner(ctx, owner, chanID)
+}
+
+func (am *auditMiddleware) Identify(ctx context.Context, key string) (string, error) {
+	return am.svc.Identify(ctx, key)
+}
+
+func (am *auditMiddleware) Backup(ctx context.Context, token string) (things.Backup, error) {
+	return am.svc.Backup(ctx, token)
+}
+
+func (am *auditMiddleware) Restore(ctx context.Context, token string, backup things.Backup) error {
+	return am.svc.Restore(ctx, token, backup)
+}
+
+func (am *auditMiddleware) CreateGroups(ctx context.Context, token string, grs ...things.Group) ([]things.Group, error) {
+	saved, err := am.svc.CreateGroups(ctx, token, grs...)
+	if err != nil {
+		return saved, err
+	}
+
+	for _, gr := range saved {
+		am.publish(ctx, token, audit.ActionCreate, audit.EntityGroup, gr.ID, "", nil, groupFields(gr))
+	}
+
+	return saved, nil
+}
+
+func (am *auditMiddleware) UpdateGroup(ctx context.Context, token string, group things.Group) (things.Group, error) {
+	before, _ := am.svc.ViewGroup(ctx, token, group.ID)
+
+	updated, err := am.svc.UpdateGroup(ctx, token, group)
+	if err != nil {
+		return updated, err
+	}
+
+	am.publish(ctx, token, audit.ActionUpdate, audit.EntityGroup, group.ID, am.groupOrg(ctx, group.ID), groupFields(before), groupFields(updated))
+	return updated, nil
+}
+
+func (am *auditMiddleware) ViewGroup(ctx context.Context, token, id string) (things.Group, error) {
+	return am.svc.ViewGroup(ctx, token, id)
+}
+
+func (am *auditMiddleware) ListGroups(ctx context.Context, token string, admin bool, pm things.PageMetadata) (things.GroupPage, error) {
+	return am.svc.ListGroups(ctx, token, admin, pm)
+}
+
+func (am *auditMiddleware) ListGroupsByIDs(ctx context.Context, ids []string) ([]things.Group, error) {
+	return am.svc.ListGroupsByIDs(ctx, ids)
+}
+
+func (am *auditMiddleware) ListGroupThings(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupThingsPage, error) {
+	return am.svc.ListGroupThings(ctx, token, groupID, pm)
+}
+
+func (am *auditMiddleware) ListGroupThingsByChannel(ctx context.Context, token, grID, chID string, pm things.PageMetadata) (things.GroupThingsPage, error) {
+	return am.svc.ListGroupThingsByChannel(ctx, token, grID, chID, pm)
+}
+
+func (am *auditMiddleware) ViewThingMembership(ctx context.Context, token, thingID string) (things.Group, error) {
+	return am.svc.ViewThingMembership(ctx, token, thingID)
+}
+
+func (am *auditMiddleware) RemoveGroups(ctx context.Context, token string, ids ...string) error {
+	before := map[string]things.Group{}
+	orgs := map[string]string{}
+	for _, id := range ids {
+		if gr, err := am.svc.ViewGroup(ctx, token, id); err == nil {
+			before[id] = gr
+			orgs[id] = am.groupOrg(ctx, id)
+		}
+	}
+
+	if err := am.svc.RemoveGroups(ctx, token, ids...); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		am.publish(ctx, token, audit.ActionRemove, audit.EntityGroup, id, orgs[id], groupFields(before[id]), nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) AssignThing(ctx context.Context, token, groupID string, thingIDs ...string) error {
+	if err := am.svc.AssignThing(ctx, token, groupID, thingIDs...); err != nil {
+		return err
+	}
+
+	am.publish(ctx, token, audit.ActionAssign, audit.EntityGroup, groupID, am.groupOrg(ctx, groupID), nil, map[string]interface{}{"things": thingIDs})
+	return nil
+}
+
+func (am *auditMiddleware) UnassignThing(ctx context.Context, token, groupID string, thingIDs ...string) error {
+	if err := am.svc.UnassignThing(ctx, token, groupID, thingIDs...); err != nil {
+		return err
+	}
+
+	am.publish(ctx, token, audit.ActionUnassign, audit.EntityGroup, groupID, am.groupOrg(ctx, groupID), map[string]interface{}{"things": thingIDs}, nil)
+	return nil
+}
+
+func (am *auditMiddleware) ListGroupChannels(ctx context.Context, token, groupID string, pm things.PageMetadata) (things.GroupChannelsPage, error) {
+	return am.svc.ListGroupChannels(ctx, token, groupID, pm)
+}
+
+func (am *auditMiddleware) ViewChannelMembership(ctx context.Context, token, channelID string) (things.Group, error) {
+	return am.svc.ViewChannelMembership(ctx, token, channelID)
+}
+
+func (am *auditMiddleware) AssignChannel(ctx context.Context, token, groupID string, channelIDs ...string) error {
+	if err := am.svc.AssignChannel(ctx, token, groupID, channelIDs...); err != nil {
+		return err
+	}
+
+	am.publish(ctx, token, audit.ActionAssign, audit.EntityGroup, groupID, am.groupOrg(ctx, groupID), nil, map[string]interface{}{"channels": channelIDs})
+	return nil
+}
+
+func (am *auditMiddleware) UnassignChannel(ctx context.Context, token, groupID string, channelIDs ...string) error {
+	if err := am.svc.UnassignChannel(ctx, token, groupID, channelIDs...); err != nil {
+		return err
+	}
+
+	am.publish(ctx, token, audit.ActionUnassign, audit.EntityGroup, groupID, am.groupOrg(ctx, groupID), map[string]interface{}{"channels": channelIDs}, nil)
+	return nil
+}
+
+// publish publishes the record of the operation performed by the user
+// identified by the token. The record of the operation within the org is
+// visible to the org owner. Failures are logged, since the operation itself
+// has already succeeded.
+func (am *auditMiddleware) publish(ctx context.Context, token, action, entityType, entityID, orgID string, before, after map[string]interface{}) {
+	res, err := am.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to identify actor of %s %s: %s", entityType, action, err))
+		return
+	}
+
+	record := audit.NewRecord(ctx, res.GetId(), action, entityType, entityID, before, after)
+	record.OrgID = orgID
+	if err := am.publisher.Publish(ctx, record); err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to publish audit record of %s %s: %s", entityType, action, err))
+	}
+}
+
+// thingOrg returns the ID of the org the group of the thing is assigned to,
+// or an empty ID if there is no such org.
+func (am *auditMiddleware) thingOrg(ctx context.Context, token, thingID string) string {
+	gr, err := am.svc.ViewThingMembership(ctx, token, thingID)
+	if err != nil || gr.ID == "" {
+		return ""
+	}
+
+	return am.groupOrg(ctx, gr.ID)
+}
+
+// channelOrg returns the ID of the org the group of the channel is assigned
+// to, or an empty ID if there is no such org.
+func (am *auditMiddleware) channelOrg(ctx context.Context, token, chanID string) string {
+	gr, err := am.svc.ViewChannelMembership(ctx, token, chanID)
+	if err != nil || gr.ID == "" {
+		return ""
+	}
+
+	return am.groupOrg(ctx, gr.ID)
+}
+
+// groupOrg returns the ID of the org the group is assigned to, or an empty
+// ID if there is no such org.
+func (am *auditMiddleware) groupOrg(ctx context.Context, groupID string) string {
+	res, err := am.auth.RetrieveGroupOrg(ctx, &mainflux.GroupOrgReq{GroupID: groupID})
+	if err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to retrieve org of group %s: %s", groupID, err))
+		return ""
+	}
+
+	return res.GetValue()
+}
+
+// Keys of the things are never recorded.
+func thingFields(th things.Thing) map[string]interface{} {
+	if th.ID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"name":     th.Name,
+		"metadata": map[string]interface{}(th.Metadata),
+	}
+}
+
+func channelFields(ch things.Channel) map[string]interface{} {
+	if ch.ID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"name":     ch.Name,
+		"metadata": ch.Metadata,
+	}
+}
+
+func groupFields(gr things.Group) map[string]interface{} {
+	if gr.ID == "" {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"name":        gr.Name,
+		"description": gr.Description,
+		"metadata":    map[string]interface{}(gr.Metadata),
+	}
+}
@@ -19,11 +19,13 @@ import (
 var _ mainflux.ThingsServiceClient = (*grpcClient)(nil)
 
 type grpcClient struct {
-	timeout        time.Duration
-	getConnByKey   endpoint.Endpoint
-	isChannelOwner endpoint.Endpoint
-	identify       endpoint.Endpoint
-	getGroupsByIDs endpoint.Endpoint
+	timeout              time.Duration
+	getConnByKey         endpoint.Endpoint
+	isChannelOwner       endpoint.Endpoint
+	identify             endpoint.Endpoint
+	getGroupsByIDs       endpoint.Endpoint
+	getRetentionProfiles endpoint.Endpoint
+	getChannelProfile    endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -64,6 +66,22 @@ func NewClient(conn *grpc.ClientConn, tracer opentracing.Tracer, timeout time.Du
 			decodeGetGroupsByIDsResponse,
 			mainflux.GroupsRes{},
 		).Endpoint()),
+		getRetentionProfiles: kitot.TraceClient(tracer, "get_retention_profiles")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetRetentionProfiles",
+			encodeGetRetentionProfilesRequest,
+			decodeGetRetentionProfilesResponse,
+			mainflux.ChannelProfilesRes{},
+		).Endpoint()),
+		getChannelProfile: kitot.TraceClient(tracer, "get_channel_profile")(kitgrpc.NewClient(
+			conn,
+			svcName,
+			"GetChannelProfile",
+			encodeGetChannelProfileRequest,
+			decodeGetChannelProfileResponse,
+			mainflux.Profile{},
+		).Endpoint()),
 	}
 }
 
@@ -72,7 +90,8 @@ func (client grpcClient) GetConnByKey(ctx context.Context, req *mainflux.ConnByK
 	defer cancel()
 
 	ar := connByKeyReq{
-		key: req.GetKey(),
+		key:    req.GetKey(),
+		chanID: req.GetChanID(),
 	}
 	res, err := client.getConnByKey(ctx, ar)
 	if err != nil {
@@ -120,9 +139,35 @@ func (client grpcClient) GetGroupsByIDs(ctx context.Context, req *mainflux.Group
 	return &mainflux.GroupsRes{Groups: gr.groups}, nil
 }
 
+func (client grpcClient) GetRetentionProfiles(ctx context.Context, _ *empty.Empty, _ ...grpc.CallOption) (*mainflux.ChannelProfilesRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getRetentionProfiles(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := res.(channelProfilesRes)
+	return &mainflux.ChannelProfilesRes{ChannelProfiles: pr.profiles}, nil
+}
+
+func (client grpcClient) GetChannelProfile(ctx context.Context, req *mainflux.ChannelID, _ ...grpc.CallOption) (*mainflux.Profile, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.getChannelProfile(ctx, channelProfileReq{chanID: req.GetValue()})
+	if err != nil {
+		return nil, err
+	}
+
+	pr := res.(channelProfileRes)
+	return pr.profile, nil
+}
+
 func encodeGetConnByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(connByKeyReq)
-	return &mainflux.ConnByKeyReq{Key: req.key}, nil
+	return &mainflux.ConnByKeyReq{Key: req.key, ChanID: req.chanID}, nil
 }
 
 func encodeIsChannelOwner(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -140,6 +185,15 @@ func encodeGetGroupsByIDsRequest(_ context.Context, grpcReq interface{}) (interf
 	return &mainflux.GroupsReq{Ids: req.ids}, nil
 }
 
+func encodeGetRetentionProfilesRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return &empty.Empty{}, nil
+}
+
+func encodeGetChannelProfileRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(channelProfileReq)
+	return &mainflux.ChannelID{Value: req.chanID}, nil
+}
+
 func decodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(*mainflux.ThingID)
 	return identityRes{id: res.GetValue()}, nil
@@ -158,3 +212,13 @@ func decodeGetGroupsByIDsResponse(_ context.Context, grpcRes interface{}) (inter
 	res := grpcRes.(*mainflux.GroupsRes)
 	return getGroupsByIDsRes{groups: res.GetGroups()}, nil
 }
+
+func decodeGetRetentionProfilesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.ChannelProfilesRes)
+	return channelProfilesRes{profiles: res.GetChannelProfiles()}, nil
+}
+
+func decodeGetChannelProfileResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*mainflux.Profile)
+	return channelProfileRes{profile: res}, nil
+}
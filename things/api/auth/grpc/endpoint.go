@@ -5,6 +5,7 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/things"
@@ -18,7 +19,7 @@ func getConnByKeyEndpoint(svc things.Service) endpoint.Endpoint {
 			return nil, err
 		}
 
-		conn, err := svc.GetConnByKey(ctx, req.key)
+		conn, err := svc.GetConnByKey(ctx, req.chanID, req.key)
 		if err != nil {
 			return connByKeyRes{}, err
 		}
@@ -28,32 +29,105 @@ func getConnByKeyEndpoint(svc things.Service) endpoint.Endpoint {
 			return connByKeyRes{}, err
 		}
 
-		timeField := &mainflux.TimeField{
-			Name:     p.TimeField.Name,
-			Format:   p.TimeField.Format,
-			Location: p.TimeField.Location,
+		profile, err := toProfile(p)
+		if err != nil {
+			return connByKeyRes{}, err
 		}
 
-		notifier := &mainflux.Notifier{
-			Protocol:  p.Notifier.Protocol,
-			Contacts:  p.Notifier.Contacts,
-			Subtopics: p.Notifier.Subtopics,
+		return connByKeyRes{channelOD: conn.ChannelID, thingID: conn.ThingID, profile: profile}, nil
+	}
+}
+
+func getRetentionProfilesEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		profiles, err := svc.ListRetentionProfiles(ctx)
+		if err != nil {
+			return channelProfilesRes{}, err
 		}
 
-		writer := &mainflux.Writer{
-			Retain:    p.Writer.Retain,
-			Subtopics: p.Writer.Subtopics,
+		res := channelProfilesRes{}
+		for chanID, p := range profiles {
+			profile, err := toProfile(p)
+			if err != nil {
+				return channelProfilesRes{}, err
+			}
+			res.profiles = append(res.profiles, &mainflux.ChannelProfile{ChannelID: chanID, Profile: profile})
 		}
 
-		profile := &mainflux.Profile{
-			ContentType: p.ContentType,
-			TimeField:   timeField,
-			Writer:      writer,
-			Notifier:    notifier,
+		return res, nil
+	}
+}
+
+func getChannelProfileEndpoint(svc things.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(channelProfileReq)
+		if err := req.validate(); err != nil {
+			return nil, err
 		}
 
-		return connByKeyRes{channelOD: conn.ChannelID, thingID: conn.ThingID, profile: profile}, nil
+		p, err := svc.ViewChannelProfile(ctx, req.chanID)
+		if err != nil {
+			return channelProfileRes{}, err
+		}
+
+		profile, err := toProfile(p)
+		if err != nil {
+			return channelProfileRes{}, err
+		}
+
+		return channelProfileRes{profile: profile}, nil
+	}
+}
+
+func toProfile(p things.Profile) (*mainflux.Profile, error) {
+	timeField := &mainflux.TimeField{
+		Name:     p.TimeField.Name,
+		Format:   p.TimeField.Format,
+		Location: p.TimeField.Location,
+	}
+
+	notifier := &mainflux.Notifier{
+		Protocol:  p.Notifier.Protocol,
+		Contacts:  p.Notifier.Contacts,
+		Subtopics: p.Notifier.Subtopics,
+	}
+
+	writer := &mainflux.Writer{
+		Retain:    p.Writer.Retain,
+		Subtopics: p.Writer.Subtopics,
+		Retention: p.Writer.Retention,
+	}
+
+	var schema []byte
+	if p.Schema != nil {
+		var err error
+		if schema, err = json.Marshal(p.Schema); err != nil {
+			return nil, err
+		}
+	}
+
+	rateLimit := &mainflux.RateLimit{
+		Thing:   limit(p.RateLimit.Thing),
+		Channel: limit(p.RateLimit.Channel),
+	}
+
+	profile := &mainflux.Profile{
+		ContentType: p.ContentType,
+		TimeField:   timeField,
+		Writer:      writer,
+		Notifier:    notifier,
+		Schema:      schema,
+		RateLimit:   rateLimit,
+	}
+
+	return profile, nil
+}
+
+func limit(l *things.Limit) *mainflux.Limit {
+	if l == nil {
+		return nil
 	}
+	return &mainflux.Limit{Rate: l.Rate, Burst: l.Burst}
 }
 
 func isChannelOwnerEndpoint(svc things.Service) endpoint.Endpoint {
@@ -12,6 +12,7 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/things"
 	grpcapi "github.com/MainfluxLabs/mainflux/things/api/auth/grpc"
+	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -59,25 +60,39 @@ func TestGetConnByKey(t *testing.T) {
 	defer cancel()
 
 	cases := map[string]struct {
-		key  string
-		code codes.Code
+		key    string
+		chanID string
+		code   codes.Code
 	}{
 		"check if connected thing can access existing channel": {
-			key:  th1.Key,
-			code: codes.OK,
+			key:    th1.Key,
+			chanID: ch.ID,
+			code:   codes.OK,
 		},
 		"check if unconnected thing can access existing channel": {
-			key:  th2.Key,
-			code: codes.PermissionDenied,
+			key:    th2.Key,
+			chanID: ch.ID,
+			code:   codes.PermissionDenied,
 		},
 		"check if thing with wrong access key can access existing channel": {
-			key:  wrong,
-			code: codes.NotFound,
+			key:    wrong,
+			chanID: ch.ID,
+			code:   codes.NotFound,
+		},
+		"check if connected thing can access non-existing channel": {
+			key:    th1.Key,
+			chanID: wrong,
+			code:   codes.PermissionDenied,
+		},
+		"check if thing can access channel without channel id": {
+			key:    th1.Key,
+			chanID: wrongID,
+			code:   codes.InvalidArgument,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := cli.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: tc.key})
+		_, err := cli.GetConnByKey(ctx, &mainflux.ConnByKeyReq{Key: tc.key, ChanID: tc.chanID})
 		e, ok := status.FromError(err)
 		assert.True(t, ok, "OK expected to be true")
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
@@ -121,3 +136,73 @@ func TestIdentify(t *testing.T) {
 		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
 	}
 }
+
+func TestGetRetentionProfiles(t *testing.T) {
+	profile := things.Profile{ContentType: "application/json", Writer: things.Writer{Retention: "24h", Subtopics: []string{"temperature"}}}
+	retained := things.Channel{Name: "retained", Metadata: map[string]interface{}{"profile": profile}}
+	chs, err := svc.CreateChannels(context.Background(), token, retained, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	res, err := cli.GetRetentionProfiles(ctx, &empty.Empty{})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	require.Len(t, res.GetChannelProfiles(), 1, "expected profile of the retained channel only")
+
+	cp := res.GetChannelProfiles()[0]
+	assert.Equal(t, ch.ID, cp.GetChannelID(), fmt.Sprintf("expected channel %s got %s", ch.ID, cp.GetChannelID()))
+	assert.Equal(t, "24h", cp.GetProfile().GetWriter().GetRetention(), "expected retention of the channel profile")
+	assert.Equal(t, []string{"temperature"}, cp.GetProfile().GetWriter().GetSubtopics(), "expected writer subtopics of the channel profile")
+}
+
+func TestGetChannelProfile(t *testing.T) {
+	profile := things.Profile{ContentType: "application/senml+json", Writer: things.Writer{Retain: true, Retention: "24h"}}
+	profiled := things.Channel{Name: "profiled", Metadata: map[string]interface{}{"profile": profile}}
+	chs, err := svc.CreateChannels(context.Background(), token, profiled)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	usersAddr := fmt.Sprintf("localhost:%d", port)
+	conn, err := grpc.Dial(usersAddr, grpc.WithInsecure())
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	cli := grpcapi.NewClient(conn, mocktracer.New(), time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	cases := map[string]struct {
+		chanID      string
+		contentType string
+		retention   string
+		code        codes.Code
+	}{
+		"get profile of existing channel": {
+			chanID:      ch.ID,
+			contentType: profile.ContentType,
+			retention:   profile.Writer.Retention,
+			code:        codes.OK,
+		},
+		"get profile of non-existing channel": {
+			chanID: "non-existing",
+			code:   codes.NotFound,
+		},
+		"get profile without channel ID": {
+			chanID: wrongID,
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for desc, tc := range cases {
+		p, err := cli.GetChannelProfile(ctx, &mainflux.ChannelID{Value: tc.chanID})
+		e, ok := status.FromError(err)
+		assert.True(t, ok, "OK expected to be true")
+		assert.Equal(t, tc.code, e.Code(), fmt.Sprintf("%s: expected %s got %s", desc, tc.code, e.Code()))
+		assert.Equal(t, tc.contentType, p.GetContentType(), fmt.Sprintf("%s: expected content type %s got %s", desc, tc.contentType, p.GetContentType()))
+		assert.Equal(t, tc.retention, p.GetWriter().GetRetention(), fmt.Sprintf("%s: expected retention %s got %s", desc, tc.retention, p.GetWriter().GetRetention()))
+	}
+}
@@ -6,7 +6,8 @@ package grpc
 import "github.com/MainfluxLabs/mainflux/internal/apiutil"
 
 type connByKeyReq struct {
-	key string
+	key    string
+	chanID string
 }
 
 func (req connByKeyReq) validate() error {
@@ -14,6 +15,10 @@ func (req connByKeyReq) validate() error {
 		return apiutil.ErrBearerKey
 	}
 
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
 	return nil
 }
 
@@ -55,6 +60,18 @@ func (req identifyReq) validate() error {
 	return nil
 }
 
+type channelProfileReq struct {
+	chanID string
+}
+
+func (req channelProfileReq) validate() error {
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type getGroupsByIDsReq struct {
 	ids []string
 }
@@ -22,3 +22,11 @@ type emptyRes struct {
 type getGroupsByIDsRes struct {
 	groups []*mainflux.Group
 }
+
+type channelProfileRes struct {
+	profile *mainflux.Profile
+}
+
+type channelProfilesRes struct {
+	profiles []*mainflux.ChannelProfile
+}
@@ -21,10 +21,12 @@ import (
 var _ mainflux.ThingsServiceServer = (*grpcServer)(nil)
 
 type grpcServer struct {
-	getConnByKey   kitgrpc.Handler
-	isChannelOwner kitgrpc.Handler
-	identify       kitgrpc.Handler
-	getGroupsByIDs kitgrpc.Handler
+	getConnByKey         kitgrpc.Handler
+	isChannelOwner       kitgrpc.Handler
+	identify             kitgrpc.Handler
+	getGroupsByIDs       kitgrpc.Handler
+	getRetentionProfiles kitgrpc.Handler
+	getChannelProfile    kitgrpc.Handler
 }
 
 // NewServer returns new ThingsServiceServer instance.
@@ -50,6 +52,16 @@ func NewServer(tracer opentracing.Tracer, svc things.Service) mainflux.ThingsSer
 			decodeGetGroupsByIDsRequest,
 			encodeGetGroupsByIDsResponse,
 		),
+		getRetentionProfiles: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_retention_profiles")(getRetentionProfilesEndpoint(svc)),
+			decodeGetRetentionProfilesRequest,
+			encodeGetRetentionProfilesResponse,
+		),
+		getChannelProfile: kitgrpc.NewServer(
+			kitot.TraceServer(tracer, "get_channel_profile")(getChannelProfileEndpoint(svc)),
+			decodeGetChannelProfileRequest,
+			encodeGetChannelProfileResponse,
+		),
 	}
 }
 
@@ -89,9 +101,27 @@ func (gs *grpcServer) GetGroupsByIDs(ctx context.Context, req *mainflux.GroupsRe
 	return res.(*mainflux.GroupsRes), nil
 }
 
+func (gs *grpcServer) GetRetentionProfiles(ctx context.Context, req *empty.Empty) (*mainflux.ChannelProfilesRes, error) {
+	_, res, err := gs.getRetentionProfiles.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.ChannelProfilesRes), nil
+}
+
+func (gs *grpcServer) GetChannelProfile(ctx context.Context, req *mainflux.ChannelID) (*mainflux.Profile, error) {
+	_, res, err := gs.getChannelProfile.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+
+	return res.(*mainflux.Profile), nil
+}
+
 func decodeGetConnByKeyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*mainflux.ConnByKeyReq)
-	return connByKeyReq{key: req.GetKey()}, nil
+	return connByKeyReq{key: req.GetKey(), chanID: req.GetChanID()}, nil
 }
 
 func decodeIsChannelOwnerRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
@@ -109,6 +139,15 @@ func decodeGetGroupsByIDsRequest(_ context.Context, grpcReq interface{}) (interf
 	return getGroupsByIDsReq{ids: req.GetIds()}, nil
 }
 
+func decodeGetRetentionProfilesRequest(_ context.Context, _ interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeGetChannelProfileRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*mainflux.ChannelID)
+	return channelProfileReq{chanID: req.GetValue()}, nil
+}
+
 func encodeIdentityResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	res := grpcRes.(identityRes)
 	return &mainflux.ThingID{Value: res.id}, nil
@@ -129,6 +168,16 @@ func encodeGetGroupsByIDsResponse(_ context.Context, grpcRes interface{}) (inter
 	return &mainflux.GroupsRes{Groups: res.groups}, nil
 }
 
+func encodeGetRetentionProfilesResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelProfilesRes)
+	return &mainflux.ChannelProfilesRes{ChannelProfiles: res.profiles}, nil
+}
+
+func encodeGetChannelProfileResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(channelProfileRes)
+	return res.profile, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case err == nil:
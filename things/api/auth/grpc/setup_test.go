@@ -51,7 +51,7 @@ func newService(tokens map[string]string) things.Service {
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
-	groupsRepo := thmocks.NewGroupRepository()
+	groupsRepo := thmocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
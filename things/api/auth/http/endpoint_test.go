@@ -75,7 +75,7 @@ func newService(tokens map[string]string) things.Service {
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
-	groupsRepo := thmocks.NewGroupRepository()
+	groupsRepo := thmocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
@@ -180,31 +180,49 @@ func TestGetConnByThingKey(t *testing.T) {
 
 	cases := map[string]struct {
 		contentType string
+		chanID      string
 		req         string
 		status      int
 	}{
 		"check access for connected thing and channel": {
 			contentType: contentType,
+			chanID:      ch.ID,
 			req:         data,
 			status:      http.StatusOK,
 		},
+		"check access for thing and non-existing channel": {
+			contentType: contentType,
+			chanID:      wrong,
+			req:         data,
+			status:      http.StatusForbidden,
+		},
+		"check access without channel id": {
+			contentType: contentType,
+			chanID:      "",
+			req:         data,
+			status:      http.StatusBadRequest,
+		},
 		"check access with invalid content type": {
 			contentType: wrong,
+			chanID:      ch.ID,
 			req:         data,
 			status:      http.StatusUnsupportedMediaType,
 		},
 		"check access with empty JSON request": {
 			contentType: contentType,
+			chanID:      ch.ID,
 			req:         "{}",
 			status:      http.StatusUnauthorized,
 		},
 		"check access with invalid JSON request": {
 			contentType: contentType,
+			chanID:      ch.ID,
 			req:         "}",
 			status:      http.StatusBadRequest,
 		},
 		"check access with empty request": {
 			contentType: contentType,
+			chanID:      ch.ID,
 			req:         "",
 			status:      http.StatusBadRequest,
 		},
@@ -214,7 +232,7 @@ func TestGetConnByThingKey(t *testing.T) {
 		req := testRequest{
 			client:      ts.Client(),
 			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/identify/channels/%s/access-by-key", ts.URL, ""),
+			url:         fmt.Sprintf("%s/identify/channels/%s/access-by-key", ts.URL, tc.chanID),
 			contentType: tc.contentType,
 			body:        strings.NewReader(tc.req),
 		}
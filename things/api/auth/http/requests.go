@@ -27,5 +27,9 @@ func (req getConnByKeyReq) validate() error {
 		return apiutil.ErrBearerKey
 	}
 
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
 	return nil
 }
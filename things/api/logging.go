@@ -189,16 +189,16 @@ func (lm *loggingMiddleware) ListChannels(ctx context.Context, token string, adm
 	return lm.svc.ListChannels(ctx, token, admin, pm)
 }
 
-func (lm *loggingMiddleware) ViewChannelByThing(ctx context.Context, token, thID string) (_ things.Channel, err error) {
+func (lm *loggingMiddleware) ListChannelsByThing(ctx context.Context, token, thID string, pm things.PageMetadata) (_ things.ChannelsPage, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method view_channel_by_thing for thing %s took %s to complete", thID, time.Since(begin))
+		message := fmt.Sprintf("Method list_channels_by_thing for thing %s took %s to complete", thID, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s", message, err))
 		}
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.ViewChannelByThing(ctx, token, thID)
+	return lm.svc.ListChannelsByThing(ctx, token, thID, pm)
 }
 
 func (lm *loggingMiddleware) RemoveChannels(ctx context.Context, token string, ids ...string) (err error) {
@@ -227,6 +227,19 @@ func (lm *loggingMiddleware) ViewChannelProfile(ctx context.Context, chID string
 	return lm.svc.ViewChannelProfile(ctx, chID)
 }
 
+func (lm *loggingMiddleware) ListRetentionProfiles(ctx context.Context) (profiles map[string]things.Profile, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method list_retention_profiles took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ListRetentionProfiles(ctx)
+}
+
 func (lm *loggingMiddleware) Connect(ctx context.Context, token, chID string, thIDs []string) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method connect for token %s, channel %s and things %s took %s to complete", token, chID, thIDs, time.Since(begin))
@@ -253,9 +266,9 @@ func (lm *loggingMiddleware) Disconnect(ctx context.Context, token, chID string,
 	return lm.svc.Disconnect(ctx, token, chID, thIDs)
 }
 
-func (lm *loggingMiddleware) GetConnByKey(ctx context.Context, key string) (conn things.Connection, err error) {
+func (lm *loggingMiddleware) GetConnByKey(ctx context.Context, chanID, key string) (conn things.Connection, err error) {
 	defer func(begin time.Time) {
-		message := fmt.Sprintf("Method can_access for thing %s took %s to complete", conn.ThingID, time.Since(begin))
+		message := fmt.Sprintf("Method get_conn_by_key for channel %s and thing %s took %s to complete", chanID, conn.ThingID, time.Since(begin))
 		if err != nil {
 			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
 			return
@@ -263,7 +276,7 @@ func (lm *loggingMiddleware) GetConnByKey(ctx context.Context, key string) (conn
 		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
 	}(time.Now())
 
-	return lm.svc.GetConnByKey(ctx, key)
+	return lm.svc.GetConnByKey(ctx, chanID, key)
 }
 
 func (lm *loggingMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) (err error) {
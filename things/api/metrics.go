@@ -138,13 +138,13 @@ func (ms *metricsMiddleware) ListChannels(ctx context.Context, token string, adm
 	return ms.svc.ListChannels(ctx, token, admin, pm)
 }
 
-func (ms *metricsMiddleware) ViewChannelByThing(ctx context.Context, token, thID string) (things.Channel, error) {
+func (ms *metricsMiddleware) ListChannelsByThing(ctx context.Context, token, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	defer func(begin time.Time) {
-		ms.counter.With("method", "view_channel_by_thing").Add(1)
-		ms.latency.With("method", "view_channel_by_thing").Observe(time.Since(begin).Seconds())
+		ms.counter.With("method", "list_channels_by_thing").Add(1)
+		ms.latency.With("method", "list_channels_by_thing").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.ViewChannelByThing(ctx, token, thID)
+	return ms.svc.ListChannelsByThing(ctx, token, thID, pm)
 }
 
 func (ms *metricsMiddleware) RemoveChannels(ctx context.Context, token string, ids ...string) error {
@@ -165,6 +165,15 @@ func (ms *metricsMiddleware) ViewChannelProfile(ctx context.Context, chID string
 	return ms.svc.ViewChannelProfile(ctx, chID)
 }
 
+func (ms *metricsMiddleware) ListRetentionProfiles(ctx context.Context) (map[string]things.Profile, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_retention_profiles").Add(1)
+		ms.latency.With("method", "list_retention_profiles").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListRetentionProfiles(ctx)
+}
+
 func (ms *metricsMiddleware) Connect(ctx context.Context, token, chID string, thIDs []string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "connect").Add(1)
@@ -183,13 +192,13 @@ func (ms *metricsMiddleware) Disconnect(ctx context.Context, token, chID string,
 	return ms.svc.Disconnect(ctx, token, chID, thIDs)
 }
 
-func (ms *metricsMiddleware) GetConnByKey(ctx context.Context, key string) (things.Connection, error) {
+func (ms *metricsMiddleware) GetConnByKey(ctx context.Context, chanID, key string) (things.Connection, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "get_conn_by_key").Add(1)
 		ms.latency.With("method", "get_conn_by_key").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.GetConnByKey(ctx, key)
+	return ms.svc.GetConnByKey(ctx, chanID, key)
 }
 
 func (ms *metricsMiddleware) IsChannelOwner(ctx context.Context, owner, chanID string) error {
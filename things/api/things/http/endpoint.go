@@ -359,24 +359,38 @@ func listChannelsEndpoint(svc things.Service) endpoint.Endpoint {
 	}
 }
 
-func viewChannelByThingEndpoint(svc things.Service) endpoint.Endpoint {
+func listChannelsByThingEndpoint(svc things.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
-		req := request.(viewResourceReq)
+		req := request.(listByConnectionReq)
 
 		if err := req.validate(); err != nil {
 			return nil, err
 		}
 
-		ch, err := svc.ViewChannelByThing(ctx, req.token, req.id)
+		page, err := svc.ListChannelsByThing(ctx, req.token, req.id, req.pageMetadata)
 		if err != nil {
 			return nil, err
 		}
 
-		res := viewChannelRes{
-			ID:       ch.ID,
-			Owner:    ch.Owner,
-			Name:     ch.Name,
-			Metadata: ch.Metadata,
+		res := channelsPageRes{
+			pageRes: pageRes{
+				Total:  page.Total,
+				Offset: page.Offset,
+				Limit:  page.Limit,
+				Order:  page.Order,
+				Dir:    page.Dir,
+			},
+			Channels: []viewChannelRes{},
+		}
+		for _, channel := range page.Channels {
+			view := viewChannelRes{
+				ID:       channel.ID,
+				Owner:    channel.Owner,
+				Name:     channel.Name,
+				Metadata: channel.Metadata,
+			}
+
+			res.Channels = append(res.Channels, view)
 		}
 
 		return res, nil
@@ -106,7 +106,7 @@ func newService() things.Service {
 	conns := make(chan thmocks.Connection)
 	thingsRepo := thmocks.NewThingRepository(conns)
 	channelsRepo := thmocks.NewChannelRepository(thingsRepo, conns)
-	groupsRepo := thmocks.NewGroupRepository()
+	groupsRepo := thmocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := thmocks.NewChannelCache()
 	thingCache := thmocks.NewThingCache()
 	idProvider := uuid.NewMock()
@@ -1307,6 +1307,13 @@ func TestCreateChannels(t *testing.T) {
 
 	data := `[{"name": "1"}, {"name": "2"}]`
 	invalidData := fmt.Sprintf(`[{"name": "%s"}]`, invalidName)
+	schemaData := `[{"name": "1", "metadata": {"profile": {"schema": {"type": "object", "required": ["temperature"]}}}}]`
+	invalidSchemaData := `[{"name": "1", "metadata": {"profile": {"schema": {"type": "invalid"}}}}]`
+	nonObjectSchemaData := `[{"name": "1", "metadata": {"profile": {"schema": true}}}]`
+	rateLimitData := `[{"name": "1", "metadata": {"profile": {"rate_limit": {"thing": {"rate": 5, "burst": 10}, "channel": {"rate": 50}}}}}]`
+	zeroRateLimitData := `[{"name": "1", "metadata": {"profile": {"rate_limit": {"channel": {"rate": 0}}}}}]`
+	negativeRateLimitData := `[{"name": "1", "metadata": {"profile": {"rate_limit": {"thing": {"rate": -5}}}}}]`
+	negativeBurstLimitData := `[{"name": "1", "metadata": {"profile": {"rate_limit": {"channel": {"rate": 5, "burst": -1}}}}}]`
 
 	cases := []struct {
 		desc        string
@@ -1379,6 +1386,62 @@ func TestCreateChannels(t *testing.T) {
 			status:      http.StatusBadRequest,
 			response:    "",
 		},
+		{
+			desc:        "create channel with profile schema",
+			data:        schemaData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			response:    "",
+		},
+		{
+			desc:        "create channel with invalid profile schema",
+			data:        invalidSchemaData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
+		{
+			desc:        "create channel with non-object profile schema",
+			data:        nonObjectSchemaData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
+		{
+			desc:        "create channel with profile rate limit",
+			data:        rateLimitData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusCreated,
+			response:    "",
+		},
+		{
+			desc:        "create channel with zero profile rate limit",
+			data:        zeroRateLimitData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
+		{
+			desc:        "create channel with negative profile rate limit",
+			data:        negativeRateLimitData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
+		{
+			desc:        "create channel with negative profile burst limit",
+			data:        negativeBurstLimitData,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+			response:    "",
+		},
 	}
 
 	for _, tc := range cases {
@@ -1415,6 +1478,13 @@ func TestUpdateChannel(t *testing.T) {
 	c.Name = invalidName
 	invalidData := toJSON(c)
 
+	c.Name = "updated_channel"
+	c.Metadata = map[string]interface{}{"profile": map[string]interface{}{"schema": map[string]interface{}{"type": "object"}}}
+	schemaData := toJSON(c)
+
+	c.Metadata = map[string]interface{}{"profile": map[string]interface{}{"schema": map[string]interface{}{"type": "invalid"}}}
+	invalidSchemaData := toJSON(c)
+
 	cases := []struct {
 		desc        string
 		req         string
@@ -1502,6 +1572,22 @@ func TestUpdateChannel(t *testing.T) {
 			auth:        token,
 			status:      http.StatusBadRequest,
 		},
+		{
+			desc:        "update channel with profile schema",
+			req:         schemaData,
+			id:          ch.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update channel with invalid profile schema",
+			req:         invalidSchemaData,
+			id:          ch.ID,
+			contentType: contentType,
+			auth:        token,
+			status:      http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1821,7 +1907,7 @@ func TestListChannels(t *testing.T) {
 	}
 }
 
-func TestViewChannelByThing(t *testing.T) {
+func TestListChannelsByThing(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
 	defer ts.Close()
@@ -1837,23 +1923,23 @@ func TestViewChannelByThing(t *testing.T) {
 	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	channel1 := channel
-
-	chs, err := svc.CreateChannels(context.Background(), token, channel1)
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	ch := chs[0]
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var chRes []channelRes
+	for _, ch := range chs {
+		err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	chRes := channelRes{
-		ID:       ch.ID,
-		Name:     ch.Name,
-		Metadata: ch.Metadata,
-	}
+		err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		chRes = append(chRes, channelRes{
+			ID:       ch.ID,
+			Name:     ch.Name,
+			Metadata: ch.Metadata,
+		})
+	}
 
 	channelURL := fmt.Sprintf("%s/things", ts.URL)
 
@@ -1862,42 +1948,56 @@ func TestViewChannelByThing(t *testing.T) {
 		auth   string
 		status int
 		url    string
-		res    channelRes
+		res    []channelRes
 	}{
 		{
-			desc:   "view channel by thing",
+			desc:   "list channels by thing",
 			auth:   token,
 			status: http.StatusOK,
 			url:    fmt.Sprintf("%s/%s/channels", channelURL, th.ID),
 			res:    chRes,
 		},
 		{
-			desc:   "view channel by thing with invalid token",
+			desc:   "list channels by thing with limit",
+			auth:   token,
+			status: http.StatusOK,
+			url:    fmt.Sprintf("%s/%s/channels?offset=%d&limit=%d", channelURL, th.ID, 0, 1),
+			res:    chRes[0:1],
+		},
+		{
+			desc:   "list channels by thing with invalid token",
 			auth:   wrongValue,
 			status: http.StatusUnauthorized,
 			url:    fmt.Sprintf("%s/%s/channels", channelURL, th.ID),
-			res:    channelRes{},
+			res:    nil,
 		},
 		{
-			desc:   "view channel by thing with empty token",
+			desc:   "list channels by thing with empty token",
 			auth:   "",
 			status: http.StatusUnauthorized,
 			url:    fmt.Sprintf("%s/%s/channels", channelURL, th.ID),
-			res:    channelRes{},
+			res:    nil,
 		},
 		{
-			desc:   "view channel by thing without thing id",
+			desc:   "list channels by thing without thing id",
 			auth:   token,
 			status: http.StatusBadRequest,
 			url:    fmt.Sprintf("%s/%s/channels", channelURL, ""),
-			res:    channelRes{},
+			res:    nil,
 		},
 		{
-			desc:   "view channel by thing with wrong thing id",
+			desc:   "list channels by thing with wrong thing id",
 			auth:   token,
 			status: http.StatusNotFound,
 			url:    fmt.Sprintf("%s/%s/channels", channelURL, wrongValue),
-			res:    channelRes{},
+			res:    nil,
+		},
+		{
+			desc:   "list channels by thing with invalid limit",
+			auth:   token,
+			status: http.StatusBadRequest,
+			url:    fmt.Sprintf("%s/%s/channels?offset=%d&limit=%d", channelURL, th.ID, 1, -5),
+			res:    nil,
 		},
 	}
 
@@ -1910,10 +2010,13 @@ func TestViewChannelByThing(t *testing.T) {
 		}
 		res, err := req.make()
 		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		var body channelRes
+		var body channelsPageRes
 		json.NewDecoder(res.Body).Decode(&body)
 		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
-		assert.Equal(t, tc.res, body, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body))
+		assert.Equal(t, len(tc.res), len(body.Channels), fmt.Sprintf("%s: expected %d channels got %d", tc.desc, len(tc.res), len(body.Channels)))
+		if tc.status == http.StatusOK && len(tc.res) == len(chRes) {
+			assert.ElementsMatch(t, tc.res, body.Channels, fmt.Sprintf("%s: expected body %v got %v", tc.desc, tc.res, body.Channels))
+		}
 	}
 }
 
@@ -2111,7 +2214,7 @@ func TestConnect(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
 	err = svc.AssignChannel(context.Background(), token, gr.ID, ch2.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("assign channel of other user: expected %s got %s", errors.ErrAuthorization, err))
 
 	cases := []struct {
 		desc        string
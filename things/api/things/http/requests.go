@@ -4,9 +4,12 @@
 package http
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	"github.com/MainfluxLabs/mainflux/things"
 	"github.com/gofrs/uuid"
 )
@@ -18,6 +21,9 @@ const (
 	idOrder      = "id"
 	ascDir       = "asc"
 	descDir      = "desc"
+	profileKey   = "profile"
+	schemaKey    = "schema"
+	rateLimitKey = "rate_limit"
 )
 
 type createThingReq struct {
@@ -131,6 +137,10 @@ func (req createChannelsReq) validate() error {
 		if len(channel.Name) > maxNameSize {
 			return apiutil.ErrNameSize
 		}
+
+		if err := validateProfile(channel.Metadata); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -156,7 +166,7 @@ func (req updateChannelReq) validate() error {
 		return apiutil.ErrNameSize
 	}
 
-	return nil
+	return validateProfile(req.Metadata)
 }
 
 type removeThingsReq struct {
@@ -571,6 +581,68 @@ func validateUUID(extID string) (err error) {
 	return nil
 }
 
+// validateProfile validates the JSON Schema and the rate limit of the channel
+// profile, if any.
+func validateProfile(metadata map[string]interface{}) error {
+	if err := validateSchema(metadata); err != nil {
+		return err
+	}
+
+	return validateRateLimit(metadata)
+}
+
+// validateRateLimit validates the rate limit of the channel profile, if any.
+// The rates have to be positive, and the bursts can't be negative.
+func validateRateLimit(metadata map[string]interface{}) error {
+	profile, ok := metadata[profileKey].(map[string]interface{})
+	if !ok || profile[rateLimitKey] == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(profile[rateLimitKey])
+	if err != nil {
+		return errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	var rl things.RateLimit
+	if err := json.Unmarshal(data, &rl); err != nil {
+		return errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	for _, l := range []*things.Limit{rl.Thing, rl.Channel} {
+		if l != nil && l.Rate <= 0 {
+			return apiutil.ErrMalformedEntity
+		}
+	}
+
+	return nil
+}
+
+// validateSchema validates the JSON Schema of the channel profile, if any.
+// The schema has to be a JSON object.
+func validateSchema(metadata map[string]interface{}) error {
+	profile, ok := metadata[profileKey].(map[string]interface{})
+	if !ok || profile[schemaKey] == nil {
+		return nil
+	}
+
+	s, ok := profile[schemaKey].(map[string]interface{})
+	if !ok {
+		return apiutil.ErrMalformedEntity
+	}
+
+	schema, err := json.Marshal(s)
+	if err != nil {
+		return errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	if err := messaging.ValidateSchema(schema); err != nil {
+		return errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return nil
+}
+
 type removeGroupsReq struct {
 	token    string
 	GroupIDs []string `json:"group_ids,omitempty"`
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	log "github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -44,6 +45,7 @@ const (
 func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(audit.PopulateSourceIP),
 	}
 
 	r := bone.New()
@@ -91,8 +93,8 @@ func MakeHandler(tracer opentracing.Tracer, svc things.Service, logger log.Logge
 	))
 
 	r.Get("/things/:id/channels", kithttp.NewServer(
-		kitot.TraceServer(tracer, "view_channel_by_thing")(viewChannelByThingEndpoint(svc)),
-		decodeView,
+		kitot.TraceServer(tracer, "list_channels_by_thing")(listChannelsByThingEndpoint(svc)),
+		decodeListByConnection,
 		encodeResponse,
 		opts...,
 	))
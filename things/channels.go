@@ -19,15 +19,32 @@ type Channel struct {
 }
 
 type Profile struct {
-	ContentType string         `json:"content_type"`
-	TimeField   json.TimeField `json:"time_field"`
-	Writer      Writer         `json:"writer"`
-	Notifier    Notifier       `json:"notifier"`
+	ContentType string                 `json:"content_type"`
+	TimeField   json.TimeField         `json:"time_field"`
+	Writer      Writer                 `json:"writer"`
+	Notifier    Notifier               `json:"notifier"`
+	Schema      map[string]interface{} `json:"schema"`
+	RateLimit   RateLimit              `json:"rate_limit"`
 }
 
 type Writer struct {
 	Retain    bool     `json:"retain"`
 	Subtopics []string `json:"subtopics"`
+	Retention string   `json:"retention"`
+}
+
+// RateLimit contains the publish rate limits of the channel and of the things
+// publishing to it, which lower the limits configured by the adapters.
+type RateLimit struct {
+	Thing   *Limit `json:"thing"`
+	Channel *Limit `json:"channel"`
+}
+
+// Limit represents a token bucket refilled with Rate tokens per second and
+// holding at most Burst tokens.
+type Limit struct {
+	Rate  float64 `json:"rate"`
+	Burst uint32  `json:"burst"`
 }
 
 type Notifier struct {
@@ -69,9 +86,13 @@ type ChannelRepository interface {
 	// RetrieveByOwner retrieves the subset of channels owned by the specified user.
 	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (ChannelsPage, error)
 
-	// RetrieveByThing retrieves the channel owned by the specified
-	// user and have specified thing connected to it.
-	RetrieveByThing(ctx context.Context, owner, thID string) (Channel, error)
+	// RetrieveByOwnerOrGroups retrieves the subset of channels owned by the
+	// specified user or assigned to the given groups.
+	RetrieveByOwnerOrGroups(ctx context.Context, owner string, groupIDs []string, pm PageMetadata) (ChannelsPage, error)
+
+	// RetrieveByThing retrieves the subset of channels owned by the specified
+	// user and have specified thing connected to them.
+	RetrieveByThing(ctx context.Context, owner, thID string, pm PageMetadata) (ChannelsPage, error)
 
 	// RetrieveConns retrieves the subset of channels connected to the specified
 	// thing.
@@ -87,8 +108,9 @@ type ChannelRepository interface {
 	// Disconnect disconnects a list of things from a channel.
 	Disconnect(ctx context.Context, owner, chID string, thIDs []string) error
 
-	// RetrieveConnByThingKey retrieves connections IDs by ThingKey
-	RetrieveConnByThingKey(ctx context.Context, key string) (Connection, error)
+	// RetrieveConnByThingKey retrieves the connection between the channel
+	// identified by the provided ID and the thing identified by the provided key.
+	RetrieveConnByThingKey(ctx context.Context, chanID, key string) (Connection, error)
 
 	// RetrieveAll retrieves all channels for all users.
 	RetrieveAll(ctx context.Context) ([]Channel, error)
@@ -31,6 +31,7 @@ type channelRepositoryMock struct {
 	cconns   map[string]map[string]things.Channel // used to track connections
 	conns    map[string]string                    // used to track connections
 	things   things.ThingRepository
+	groups   *groupRepositoryMock
 }
 
 // NewChannelRepository creates in-memory channel repository.
@@ -127,6 +128,47 @@ func (crm *channelRepositoryMock) RetrieveByOwner(_ context.Context, owner strin
 	return page, nil
 }
 
+func (crm *channelRepositoryMock) RetrieveByOwnerOrGroups(_ context.Context, owner string, groupIDs []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	crm.mu.Lock()
+	defer crm.mu.Unlock()
+
+	ids := make(map[string]bool)
+	if crm.groups != nil {
+		for _, id := range crm.groups.groupChannels(groupIDs) {
+			ids[id] = true
+		}
+	}
+
+	var chs []things.Channel
+	prefix := fmt.Sprintf("%s-", owner)
+	for k, v := range crm.channels {
+		if strings.HasPrefix(k, prefix) || ids[v.ID] {
+			chs = append(chs, v)
+		}
+	}
+	chs = sortChannels(pm, chs)
+
+	total := uint64(len(chs))
+	first, last := pm.Offset, pm.Offset+pm.Limit
+	if last > total || pm.Limit == 0 {
+		last = total
+	}
+	if first > last {
+		first = last
+	}
+
+	page := things.ChannelsPage{
+		Channels: chs[first:last],
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
 func (crm *channelRepositoryMock) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.ChannelsPage, error) {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
@@ -156,19 +198,40 @@ func (crm *channelRepositoryMock) RetrieveByAdmin(ctx context.Context, pm things
 	return page, nil
 }
 
-func (crm *channelRepositoryMock) RetrieveByThing(_ context.Context, owner, thID string) (things.Channel, error) {
+func (crm *channelRepositoryMock) RetrieveByThing(_ context.Context, owner, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	crm.mu.Lock()
 	defer crm.mu.Unlock()
 
-	for _, ch := range crm.channels {
-		for _, co := range crm.cconns[thID] {
-			if ch.ID == co.ID {
-				return ch, nil
-			}
+	var chs []things.Channel
+	for _, co := range crm.cconns[thID] {
+		if ch, ok := crm.channels[key(owner, co.ID)]; ok {
+			chs = append(chs, ch)
 		}
 	}
 
-	return things.Channel{}, errors.ErrNotFound
+	// Sort Channels list
+	chs = sortChannels(pm, chs)
+
+	total := uint64(len(chs))
+	first := pm.Offset
+	last := first + pm.Limit
+	if last > total || pm.Limit == 0 {
+		last = total
+	}
+	if first > last {
+		first = last
+	}
+
+	page := things.ChannelsPage{
+		Channels: chs[first:last],
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
 }
 
 func (crm *channelRepositoryMock) RetrieveConns(_ context.Context, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
@@ -205,7 +268,7 @@ func (crm *channelRepositoryMock) Connect(_ context.Context, owner, chID string,
 	}
 
 	for _, thID := range thIDs {
-		if _, ok := crm.cconns[thID]; ok {
+		if _, ok := crm.cconns[thID][chID]; ok {
 			return errors.ErrConflict
 		}
 		th, err := crm.things.RetrieveByID(context.Background(), thID)
@@ -247,7 +310,7 @@ func (crm *channelRepositoryMock) Disconnect(_ context.Context, owner, chID stri
 	return nil
 }
 
-func (crm *channelRepositoryMock) RetrieveConnByThingKey(_ context.Context, token string) (things.Connection, error) {
+func (crm *channelRepositoryMock) RetrieveConnByThingKey(_ context.Context, chanID, token string) (things.Connection, error) {
 	tid, err := crm.things.RetrieveByKey(context.Background(), token)
 	if err != nil {
 		return things.Connection{}, err
@@ -258,15 +321,12 @@ func (crm *channelRepositoryMock) RetrieveConnByThingKey(_ context.Context, toke
 		return things.Connection{}, errors.ErrAuthorization
 	}
 
-	if len(chans) == 0 {
+	ch, ok := chans[chanID]
+	if !ok {
 		return things.Connection{}, errors.ErrAuthorization
 	}
 
-	for _, v := range chans {
-		return things.Connection{ThingID: tid, ChannelID: v.ID}, nil
-	}
-
-	return things.Connection{}, errors.ErrNotFound
+	return things.Connection{ThingID: tid, ChannelID: ch.ID}, nil
 }
 
 func (crm *channelRepositoryMock) HasThingByID(_ context.Context, chanID, thingID string) error {
@@ -317,13 +377,13 @@ func (crm *channelRepositoryMock) RetrieveAllConnections(ctx context.Context) ([
 
 type channelCacheMock struct {
 	mu       sync.Mutex
-	channels map[string]string
+	channels map[string]map[string]bool
 }
 
 // NewChannelCache returns mock cache instance.
 func NewChannelCache() things.ChannelCache {
 	return &channelCacheMock{
-		channels: make(map[string]string),
+		channels: make(map[string]map[string]bool),
 	}
 }
 
@@ -331,7 +391,10 @@ func (ccm *channelCacheMock) Connect(_ context.Context, chanID, thingID string)
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	ccm.channels[chanID] = thingID
+	if _, ok := ccm.channels[chanID]; !ok {
+		ccm.channels[chanID] = make(map[string]bool)
+	}
+	ccm.channels[chanID][thingID] = true
 	return nil
 }
 
@@ -339,14 +402,14 @@ func (ccm *channelCacheMock) HasThing(_ context.Context, chanID, thingID string)
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	return ccm.channels[chanID] == thingID
+	return ccm.channels[chanID][thingID]
 }
 
 func (ccm *channelCacheMock) Disconnect(_ context.Context, chanID, thingID string) error {
 	ccm.mu.Lock()
 	defer ccm.mu.Unlock()
 
-	delete(ccm.channels, chanID)
+	delete(ccm.channels[chanID], thingID)
 	return nil
 }
 
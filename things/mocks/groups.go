@@ -29,15 +29,51 @@ type groupRepositoryMock struct {
 	channels map[string][]string
 }
 
-// NewGroupRepository creates in-memory user repository
-func NewGroupRepository() things.GroupRepository {
-	return &groupRepositoryMock{
+// NewGroupRepository creates in-memory group repository. The thing and the
+// channel repositories use it to retrieve the things and the channels
+// assigned to the groups.
+func NewGroupRepository(thingsRepo things.ThingRepository, channelsRepo things.ChannelRepository) things.GroupRepository {
+	grm := &groupRepositoryMock{
 		groups:            make(map[string]things.Group),
 		thingMembership:   make(map[string]string),
 		things:            make(map[string][]string),
 		channelMembership: make(map[string]string),
 		channels:          make(map[string][]string),
 	}
+	if trm, ok := thingsRepo.(*thingRepositoryMock); ok {
+		trm.groups = grm
+	}
+	if crm, ok := channelsRepo.(*channelRepositoryMock); ok {
+		crm.groups = grm
+	}
+
+	return grm
+}
+
+// groupThings returns the IDs of the things assigned to the groups.
+func (grm *groupRepositoryMock) groupThings(groupIDs []string) []string {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	var ids []string
+	for _, groupID := range groupIDs {
+		ids = append(ids, grm.things[groupID]...)
+	}
+
+	return ids
+}
+
+// groupChannels returns the IDs of the channels assigned to the groups.
+func (grm *groupRepositoryMock) groupChannels(groupIDs []string) []string {
+	grm.mu.Lock()
+	defer grm.mu.Unlock()
+
+	var ids []string
+	for _, groupID := range groupIDs {
+		ids = append(ids, grm.channels[groupID]...)
+	}
+
+	return ids
 }
 
 func (grm *groupRepositoryMock) Save(ctx context.Context, group things.Group) (things.Group, error) {
@@ -195,13 +231,13 @@ func (grm *groupRepositoryMock) RetrieveGroupThings(ctx context.Context, groupID
 	var items []things.Thing
 	ths, ok := grm.things[groupID]
 	if !ok {
-		return things.GroupThingsPage{}, errors.ErrNotFound
+		return things.GroupThingsPage{}, nil
 	}
 
 	first := uint64(pm.Offset)
 	last := first + uint64(pm.Limit)
 
-	if last > uint64(len(ths)) {
+	if last > uint64(len(ths)) || pm.Limit == 0 {
 		last = uint64(len(ths))
 	}
 
@@ -318,7 +354,7 @@ func (grm *groupRepositoryMock) RetrieveGroupChannels(ctx context.Context, group
 	first := uint64(pm.Offset)
 	last := first + uint64(pm.Limit)
 
-	if last > uint64(len(chs)) {
+	if last > uint64(len(chs)) || pm.Limit == 0 {
 		last = uint64(len(chs))
 	}
 
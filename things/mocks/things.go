@@ -21,6 +21,7 @@ type thingRepositoryMock struct {
 	conns   chan Connection
 	tconns  map[string]map[string]things.Thing
 	things  map[string]things.Thing
+	groups  *groupRepositoryMock
 }
 
 // NewThingRepository creates in-memory thing repository.
@@ -157,6 +158,47 @@ func (trm *thingRepositoryMock) RetrieveByOwner(_ context.Context, owner string,
 	return page, nil
 }
 
+func (trm *thingRepositoryMock) RetrieveByOwnerOrGroups(_ context.Context, owner string, groupIDs []string, pm things.PageMetadata) (things.Page, error) {
+	trm.mu.Lock()
+	defer trm.mu.Unlock()
+
+	ids := make(map[string]bool)
+	if trm.groups != nil {
+		for _, id := range trm.groups.groupThings(groupIDs) {
+			ids[id] = true
+		}
+	}
+
+	var ths []things.Thing
+	prefix := fmt.Sprintf("%s-", owner)
+	for k, v := range trm.things {
+		if strings.HasPrefix(k, prefix) || ids[v.ID] {
+			ths = append(ths, v)
+		}
+	}
+	ths = sortThings(pm, ths)
+
+	total := uint64(len(ths))
+	first, last := pm.Offset, pm.Offset+pm.Limit
+	if last > total || pm.Limit == 0 {
+		last = total
+	}
+	if first > last {
+		first = last
+	}
+
+	page := things.Page{
+		Things: ths[first:last],
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+		},
+	}
+
+	return page, nil
+}
+
 func (trm *thingRepositoryMock) RetrieveByIDs(_ context.Context, thingIDs []string, pm things.PageMetadata) (things.Page, error) {
 	trm.mu.Lock()
 	defer trm.mu.Unlock()
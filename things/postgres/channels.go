@@ -134,11 +134,19 @@ func (cr channelRepository) RetrieveByOwner(ctx context.Context, owner string, p
 		return things.ChannelsPage{}, errors.ErrRetrieveEntity
 	}
 
-	return cr.retrieve(ctx, owner, false, pm)
+	return cr.retrieve(ctx, owner, nil, false, pm)
+}
+
+func (cr channelRepository) RetrieveByOwnerOrGroups(ctx context.Context, owner string, groupIDs []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	if owner == "" {
+		return things.ChannelsPage{}, errors.ErrRetrieveEntity
+	}
+
+	return cr.retrieve(ctx, owner, groupIDs, false, pm)
 }
 
 func (cr channelRepository) RetrieveAll(ctx context.Context) ([]things.Channel, error) {
-	chPage, err := cr.retrieve(ctx, "", true, things.PageMetadata{})
+	chPage, err := cr.retrieve(ctx, "", nil, true, things.PageMetadata{})
 	if err != nil {
 		return []things.Channel{}, err
 	}
@@ -147,43 +155,73 @@ func (cr channelRepository) RetrieveAll(ctx context.Context) ([]things.Channel,
 }
 
 func (cr channelRepository) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.ChannelsPage, error) {
-	return cr.retrieve(ctx, "", false, pm)
+	return cr.retrieve(ctx, "", nil, false, pm)
 }
 
-func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thID string) (things.Channel, error) {
+func (cr channelRepository) RetrieveByThing(ctx context.Context, owner, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	oq := getConnOrderQuery(pm.Order, "ch")
+	dq := getDirQuery(pm.Dir)
+
 	// Verify if UUID format is valid to avoid internal Postgres error
 	if _, err := uuid.FromString(thID); err != nil {
-		return things.Channel{}, errors.Wrap(errors.ErrNotFound, err)
+		return things.ChannelsPage{}, errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	olq := "LIMIT :limit OFFSET :offset"
+	if pm.Limit == 0 {
+		olq = ""
 	}
 
-	var q string
-	q = fmt.Sprintf(`SELECT id, name, metadata FROM channels ch
+	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels ch
+		        INNER JOIN connections conn
+		        ON ch.id = conn.channel_id
+		        WHERE ch.owner = :owner AND conn.thing_id = :thing
+		        ORDER BY %s %s %s;`, oq, dq, olq)
+
+	qc := `SELECT COUNT(*)
+		        FROM channels ch
 		        INNER JOIN connections conn
 		        ON ch.id = conn.channel_id
-		        WHERE ch.owner = :owner AND conn.thing_id = :thing;`)
+		        WHERE ch.owner = :owner AND conn.thing_id = :thing;`
 
 	params := map[string]interface{}{
-		"owner": owner,
-		"thing": thID,
+		"owner":  owner,
+		"thing":  thID,
+		"limit":  pm.Limit,
+		"offset": pm.Offset,
 	}
 
 	rows, err := cr.db.NamedQueryContext(ctx, q, params)
 	if err != nil {
-		return things.Channel{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+		return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 	defer rows.Close()
 
-	var item things.Channel
+	items := []things.Channel{}
 	for rows.Next() {
 		dbch := dbChannel{Owner: owner}
 		if err := rows.StructScan(&dbch); err != nil {
-			return things.Channel{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+			return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 		}
 
-		item = toChannel(dbch)
+		items = append(items, toChannel(dbch))
 	}
 
-	return item, nil
+	total, err := total(ctx, cr.db, qc, params)
+	if err != nil {
+		return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return things.ChannelsPage{
+		Channels: items,
+		PageMetadata: things.PageMetadata{
+			Total:  total,
+			Offset: pm.Offset,
+			Limit:  pm.Limit,
+			Order:  pm.Order,
+			Dir:    pm.Dir,
+		},
+	}, nil
 }
 
 func (cr channelRepository) RetrieveConns(ctx context.Context, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
@@ -355,38 +393,33 @@ func (cr channelRepository) Disconnect(ctx context.Context, owner, chID string,
 	return nil
 }
 
-func (cr channelRepository) RetrieveConnByThingKey(ctx context.Context, thingKey string) (things.Connection, error) {
+func (cr channelRepository) RetrieveConnByThingKey(ctx context.Context, chanID, thingKey string) (things.Connection, error) {
 	var thingID string
 	q := `SELECT id FROM things WHERE key = $1`
 	if err := cr.db.QueryRowxContext(ctx, q, thingKey).Scan(&thingID); err != nil {
+		if err == sql.ErrNoRows {
+			return things.Connection{}, errors.ErrNotFound
+		}
 		return things.Connection{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
-	q = fmt.Sprintf(`SELECT thing_id, channel_id FROM connections
-		               WHERE thing_id = :thing;`)
-
-	params := map[string]interface{}{
-		"thing": thingID,
+	// Verify if UUID format is valid to avoid internal Postgres error
+	if _, err := uuid.FromString(chanID); err != nil {
+		return things.Connection{}, errors.Wrap(errors.ErrAuthorization, err)
 	}
 
-	rows, err := cr.db.NamedQueryContext(ctx, q, params)
-	if err != nil {
-		return things.Connection{}, errors.Wrap(errors.ErrRetrieveEntity, err)
-	}
-	defer rows.Close()
+	q = `SELECT channel_id, channel_owner, thing_id, thing_owner FROM connections
+	     WHERE channel_id = $1 AND thing_id = $2;`
 
-	dbch := dbConn{}
-	for rows.Next() {
-		if err := rows.StructScan(&dbch); err != nil {
-			return things.Connection{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	dbco := dbConn{}
+	if err := cr.db.QueryRowxContext(ctx, q, chanID, thingID).StructScan(&dbco); err != nil {
+		if err == sql.ErrNoRows {
+			return things.Connection{}, errors.ErrAuthorization
 		}
-	}
-
-	if dbch.ChannelID == "" {
 		return things.Connection{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 	}
 
-	return things.Connection{ThingID: thingID, ChannelID: dbch.ChannelID}, nil
+	return toConnection(dbco), nil
 }
 
 func (cr channelRepository) RetrieveAllConnections(ctx context.Context) ([]things.Connection, error) {
@@ -411,8 +444,11 @@ func (cr channelRepository) RetrieveAllConnections(ctx context.Context) ([]thing
 	return connections, nil
 }
 
-func (cr channelRepository) retrieve(ctx context.Context, owner string, includeOwner bool, pm things.PageMetadata) (things.ChannelsPage, error) {
+func (cr channelRepository) retrieve(ctx context.Context, owner string, groupIDs []string, includeOwner bool, pm things.PageMetadata) (things.ChannelsPage, error) {
 	ownq := dbutil.GetOwnerQuery(owner, ownerDbId)
+	if ownq != "" && len(groupIDs) > 0 {
+		ownq = fmt.Sprintf("(%s OR id IN (SELECT channel_id FROM group_channels WHERE group_id IN ('%s')))", ownq, strings.Join(groupIDs, "','"))
+	}
 	nq, name := dbutil.GetNameQuery(pm.Name)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
@@ -441,7 +477,7 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, includeO
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, metadata FROM channels %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
+	q := fmt.Sprintf(`SELECT id, name, owner, metadata FROM channels %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
 
 	if includeOwner {
 		q = "SELECT id, name, owner, metadata FROM channels;"
@@ -462,7 +498,7 @@ func (cr channelRepository) retrieve(ctx context.Context, owner string, includeO
 
 	items := []things.Channel{}
 	for rows.Next() {
-		dbch := dbChannel{Owner: owner}
+		dbch := dbChannel{}
 		if err := rows.StructScan(&dbch); err != nil {
 			return things.ChannelsPage{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 		}
@@ -384,59 +384,135 @@ func TestRetrieveByThing(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	thID = th[0].ID
 
-	chID, err := idProvider.ID()
-	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
-	ch := things.Channel{
-		ID:       chID,
-		Owner:    email,
-		Metadata: things.Metadata{},
-	}
+	n := uint64(10)
+	for i := uint64(0); i < n; i++ {
+		chID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		ch := things.Channel{
+			ID:    chID,
+			Name:  fmt.Sprintf("%s-%d", channelName, i),
+			Owner: email,
+		}
 
-	_, err = chanRepo.Save(context.Background(), ch)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		_, err = chanRepo.Save(context.Background(), ch)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = chanRepo.Connect(context.Background(), email, chID, []string{thID})
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		err = chanRepo.Connect(context.Background(), email, chID, []string{thID})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
 
 	nonexistentThingID, err := idProvider.ID()
 	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
 
 	cases := map[string]struct {
-		owner   string
-		thID    string
-		channel things.Channel
-		err     error
+		owner        string
+		thID         string
+		pageMetadata things.PageMetadata
+		size         uint64
+		err          error
 	}{
-		"retrieve channel by thing with existing owner": {
-			owner:   email,
-			thID:    thID,
-			channel: ch,
-			err:     nil,
+		"retrieve all channels by thing with existing owner": {
+			owner: email,
+			thID:  thID,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  n,
+			},
+			size: n,
+			err:  nil,
 		},
-		"retrieve channel by thing with non-existing owner": {
-			owner:   wrongValue,
-			thID:    thID,
-			channel: things.Channel{},
-			err:     nil,
+		"retrieve subset of channels by thing with existing owner": {
+			owner: email,
+			thID:  thID,
+			pageMetadata: things.PageMetadata{
+				Offset: n / 2,
+				Limit:  n,
+				Total:  n,
+			},
+			size: n / 2,
+			err:  nil,
 		},
-		"retrieve channel by non-existent thing": {
-			owner:   email,
-			thID:    nonexistentThingID,
-			channel: things.Channel{},
-			err:     nil,
+		"retrieve channels by thing without limit": {
+			owner: email,
+			thID:  thID,
+			pageMetadata: things.PageMetadata{
+				Limit: 0,
+				Total: n,
+			},
+			size: n,
+			err:  nil,
+		},
+		"retrieve channels by thing with non-existing owner": {
+			owner: wrongValue,
+			thID:  thID,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  0,
+			},
+			size: 0,
+			err:  nil,
+		},
+		"retrieve channels by non-existent thing": {
+			owner: email,
+			thID:  nonexistentThingID,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  0,
+			},
+			size: 0,
+			err:  nil,
 		},
-		"retrieve channel with malformed UUID": {
-			owner:   email,
-			thID:    wrongValue,
-			channel: things.Channel{},
-			err:     errors.ErrNotFound,
+		"retrieve channels with malformed UUID": {
+			owner: email,
+			thID:  wrongValue,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  0,
+			},
+			size: 0,
+			err:  errors.ErrNotFound,
+		},
+		"retrieve channels by thing sorted by name ascendent": {
+			owner: email,
+			thID:  thID,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  n,
+				Order:  "name",
+				Dir:    "asc",
+			},
+			size: n,
+			err:  nil,
+		},
+		"retrieve channels by thing sorted by name descendent": {
+			owner: email,
+			thID:  thID,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  n,
+				Total:  n,
+				Order:  "name",
+				Dir:    "desc",
+			},
+			size: n,
+			err:  nil,
 		},
 	}
 
 	for desc, tc := range cases {
-		ch, err := chanRepo.RetrieveByThing(context.Background(), tc.owner, tc.thID)
-		assert.Equal(t, tc.channel, ch, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.channel, ch))
-		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected no error got %d\n", desc, err))
+		page, err := chanRepo.RetrieveByThing(context.Background(), tc.owner, tc.thID, tc.pageMetadata)
+		size := uint64(len(page.Channels))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected size %d got %d\n", desc, tc.size, size))
+		assert.Equal(t, tc.pageMetadata.Total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", desc, tc.pageMetadata.Total, page.Total))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+
+		// Check if Channels list have been sorted properly
+		testSortChannels(t, tc.pageMetadata, page.Channels)
 	}
 }
 
@@ -683,36 +759,62 @@ func TestRetrieveConnByThingKey(t *testing.T) {
 	thID = ths[0].ID
 
 	chanRepo := postgres.NewChannelRepository(dbMiddleware)
-	chID, err := idProvider.ID()
-	require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
-	chs, err := chanRepo.Save(context.Background(), things.Channel{
-		ID:    chID,
-		Owner: email,
-	})
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	chID = chs[0].ID
-	err = chanRepo.Connect(context.Background(), email, chID, []string{thID})
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	var chIDs []string
+	for i := 0; i < 3; i++ {
+		chID, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("got unexpected error: %s", err))
+		chs, err := chanRepo.Save(context.Background(), things.Channel{
+			ID:    chID,
+			Owner: email,
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		chIDs = append(chIDs, chs[0].ID)
+	}
+
+	for _, chID := range chIDs[:2] {
+		err = chanRepo.Connect(context.Background(), email, chID, []string{thID})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
 
 	cases := map[string]struct {
 		chID      string
 		key       string
 		hasAccess bool
 	}{
-		"access check for thing that has access": {
+		"access check for thing that has access to first channel": {
+			chID:      chIDs[0],
 			key:       th.Key,
 			hasAccess: true,
 		},
+		"access check for thing that has access to second channel": {
+			chID:      chIDs[1],
+			key:       th.Key,
+			hasAccess: true,
+		},
+		"access check for thing not connected to channel": {
+			chID:      chIDs[2],
+			key:       th.Key,
+			hasAccess: false,
+		},
+		"access check for thing with malformed channel ID": {
+			chID:      wrongValue,
+			key:       th.Key,
+			hasAccess: false,
+		},
 		"access check for thing without access": {
+			chID:      chIDs[0],
 			key:       wrongValue,
 			hasAccess: false,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := chanRepo.RetrieveConnByThingKey(context.Background(), tc.key)
+		conn, err := chanRepo.RetrieveConnByThingKey(context.Background(), tc.chID, tc.key)
 		hasAccess := err == nil
 		assert.Equal(t, tc.hasAccess, hasAccess, fmt.Sprintf("%s: expected %t got %t\n", desc, tc.hasAccess, hasAccess))
+		if tc.hasAccess {
+			assert.Equal(t, tc.chID, conn.ChannelID, fmt.Sprintf("%s: expected channel %s got %s\n", desc, tc.chID, conn.ChannelID))
+		}
 	}
 }
 
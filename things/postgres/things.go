@@ -249,11 +249,19 @@ func (tr thingRepository) RetrieveByOwner(ctx context.Context, owner string, pm
 		return things.Page{}, errors.ErrRetrieveEntity
 	}
 
-	return tr.retrieve(ctx, owner, false, pm)
+	return tr.retrieve(ctx, owner, nil, false, pm)
+}
+
+func (tr thingRepository) RetrieveByOwnerOrGroups(ctx context.Context, owner string, groupIDs []string, pm things.PageMetadata) (things.Page, error) {
+	if owner == "" {
+		return things.Page{}, errors.ErrRetrieveEntity
+	}
+
+	return tr.retrieve(ctx, owner, groupIDs, false, pm)
 }
 
 func (tr thingRepository) RetrieveAll(ctx context.Context) ([]things.Thing, error) {
-	thPage, err := tr.retrieve(ctx, "", true, things.PageMetadata{})
+	thPage, err := tr.retrieve(ctx, "", nil, true, things.PageMetadata{})
 	if err != nil {
 		return []things.Thing{}, err
 	}
@@ -262,7 +270,7 @@ func (tr thingRepository) RetrieveAll(ctx context.Context) ([]things.Thing, erro
 }
 
 func (tr thingRepository) RetrieveByAdmin(ctx context.Context, pm things.PageMetadata) (things.Page, error) {
-	return tr.retrieve(ctx, "", false, pm)
+	return tr.retrieve(ctx, "", nil, false, pm)
 }
 
 func (tr thingRepository) RetrieveByChannel(ctx context.Context, owner, chID string, pm things.PageMetadata) (things.Page, error) {
@@ -372,8 +380,11 @@ func (tr thingRepository) Remove(ctx context.Context, owner string, ids ...strin
 	return nil
 }
 
-func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwner bool, pm things.PageMetadata) (things.Page, error) {
+func (tr thingRepository) retrieve(ctx context.Context, owner string, groupIDs []string, includeOwner bool, pm things.PageMetadata) (things.Page, error) {
 	ownq := dbutil.GetOwnerQuery(owner, ownerDbId)
+	if ownq != "" && len(groupIDs) > 0 {
+		ownq = fmt.Sprintf("(%s OR id IN (SELECT thing_id FROM group_things WHERE group_id IN ('%s')))", ownq, strings.Join(groupIDs, "','"))
+	}
 	nq, name := dbutil.GetNameQuery(pm.Name)
 	oq := getOrderQuery(pm.Order)
 	dq := getDirQuery(pm.Dir)
@@ -403,7 +414,7 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 		olq = ""
 	}
 
-	q := fmt.Sprintf(`SELECT id, name, key, metadata FROM things %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
+	q := fmt.Sprintf(`SELECT id, owner, name, key, metadata FROM things %s ORDER BY %s %s %s;`, whereClause, oq, dq, olq)
 
 	if includeOwner {
 		q = "SELECT id, owner, name, key, metadata FROM things;"
@@ -425,7 +436,7 @@ func (tr thingRepository) retrieve(ctx context.Context, owner string, includeOwn
 
 	var items []things.Thing
 	for rows.Next() {
-		dbth := dbThing{Owner: owner}
+		dbth := dbThing{}
 		if err := rows.StructScan(&dbth); err != nil {
 			return things.Page{}, errors.Wrap(errors.ErrRetrieveEntity, err)
 		}
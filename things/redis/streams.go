@@ -178,8 +178,8 @@ func (es eventStore) ListChannels(ctx context.Context, token string, admin bool,
 	return es.svc.ListChannels(ctx, token, admin, pm)
 }
 
-func (es eventStore) ViewChannelByThing(ctx context.Context, token, thID string) (things.Channel, error) {
-	return es.svc.ViewChannelByThing(ctx, token, thID)
+func (es eventStore) ListChannelsByThing(ctx context.Context, token, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	return es.svc.ListChannelsByThing(ctx, token, thID, pm)
 }
 
 func (es eventStore) RemoveChannels(ctx context.Context, token string, ids ...string) error {
@@ -207,6 +207,10 @@ func (es eventStore) ViewChannelProfile(ctx context.Context, chID string) (thing
 	return es.svc.ViewChannelProfile(ctx, chID)
 }
 
+func (es eventStore) ListRetentionProfiles(ctx context.Context) (map[string]things.Profile, error) {
+	return es.svc.ListRetentionProfiles(ctx)
+}
+
 func (es eventStore) Connect(ctx context.Context, token, chID string, thIDs []string) error {
 	if err := es.svc.Connect(ctx, token, chID, thIDs); err != nil {
 		return err
@@ -249,8 +253,8 @@ func (es eventStore) Disconnect(ctx context.Context, token, chID string, thIDs [
 	return nil
 }
 
-func (es eventStore) GetConnByKey(ctx context.Context, key string) (things.Connection, error) {
-	return es.svc.GetConnByKey(ctx, key)
+func (es eventStore) GetConnByKey(ctx context.Context, chanID, key string) (things.Connection, error) {
+	return es.svc.GetConnByKey(ctx, chanID, key)
 }
 
 func (es eventStore) IsChannelOwner(ctx context.Context, owner, chanID string) error {
@@ -64,10 +64,10 @@ type Service interface {
 	// user identified by the provided key.
 	ListChannels(ctx context.Context, token string, admin bool, pm PageMetadata) (ChannelsPage, error)
 
-	// ViewChannelByThing retrieves data about channel that have
-	// specified thing connected or not connected to it and belong to the user identified by
+	// ListChannelsByThing retrieves data about subset of channels that have
+	// specified thing connected to them and belong to the user identified by
 	// the provided key.
-	ViewChannelByThing(ctx context.Context, token, thID string) (Channel, error)
+	ListChannelsByThing(ctx context.Context, token, thID string, pm PageMetadata) (ChannelsPage, error)
 
 	// RemoveChannels removes the things identified by the provided IDs, that
 	// belongs to the user identified by the provided key.
@@ -76,15 +76,20 @@ type Service interface {
 	// ViewChannelProfile retrieves channel profile.
 	ViewChannelProfile(ctx context.Context, chID string) (Profile, error)
 
+	// ListRetentionProfiles retrieves the profiles of the channels which
+	// define the retention period of their messages, mapped by channel ID.
+	ListRetentionProfiles(ctx context.Context) (map[string]Profile, error)
+
 	// Connect connects a list of things to a channel.
 	Connect(ctx context.Context, token, chID string, thIDs []string) error
 
 	// Disconnect disconnects a list of things from a channel.
 	Disconnect(ctx context.Context, token, chID string, thIDs []string) error
 
-	// GetConnByKey determines whether the channel can be accessed using the
-	// provided key and returns thing's id if access is allowed.
-	GetConnByKey(ctx context.Context, key string) (Connection, error)
+	// GetConnByKey determines whether the channel identified by the provided ID
+	// can be accessed using the provided key and returns the connection if
+	// access is allowed.
+	GetConnByKey(ctx context.Context, chanID, key string) (Connection, error)
 
 	// IsChannelOwner determines whether the channel can be accessed by
 	// the given user and returns error if it cannot.
@@ -197,6 +202,10 @@ func (ts *thingsService) CreateThings(ctx context.Context, token string, things
 		return []Thing{}, err
 	}
 
+	if err := ts.authorizeScope(ctx, token, auth.ThingsSubject, auth.WriteAction, ""); err != nil {
+		return []Thing{}, err
+	}
+
 	ths := []Thing{}
 	for _, thing := range things {
 		th, err := ts.createThing(ctx, &thing, res)
@@ -246,11 +255,20 @@ func (ts *thingsService) UpdateThing(ctx context.Context, token string, thing Th
 		return err
 	}
 
-	if err := ts.isThingOwner(ctx, res.GetId(), thing.ID); err != nil {
+	if err := ts.authorizeThingScope(ctx, token, auth.WriteAction, thing.ID); err != nil {
+		return err
+	}
+
+	th, err := ts.things.RetrieveByID(ctx, thing.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.canAccessThing(ctx, token, res.GetId(), auth.WriteAction, th); err != nil {
 		return err
 	}
 
-	thing.Owner = res.GetId()
+	thing.Owner = th.Owner
 
 	return ts.things.Update(ctx, thing)
 }
@@ -261,9 +279,24 @@ func (ts *thingsService) UpdateKey(ctx context.Context, token, id, key string) e
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
-	owner := res.GetId()
+	if err := ts.authorizeThingScope(ctx, token, auth.WriteAction, id); err != nil {
+		return err
+	}
+
+	thing, err := ts.things.RetrieveByID(ctx, id)
+	if err != nil {
+		return err
+	}
 
-	return ts.things.UpdateKey(ctx, owner, id, key)
+	if err := ts.canAccessThing(ctx, token, res.GetId(), auth.WriteAction, thing); err != nil {
+		return err
+	}
+
+	if err := ts.thingCache.Remove(ctx, id); err != nil {
+		return err
+	}
+
+	return ts.things.UpdateKey(ctx, thing.Owner, id, key)
 }
 
 func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing, error) {
@@ -281,20 +314,15 @@ func (ts *thingsService) ViewThing(ctx context.Context, token, id string) (Thing
 		return Thing{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
-	if thing.Owner == res.GetId() {
-		return thing, nil
-	}
-
-	groupID, err := ts.groups.RetrieveThingMembership(ctx, id)
-	if err != nil {
-		return Thing{}, errors.ErrAuthorization
+	if err := ts.authorizeThingScope(ctx, token, auth.ReadAction, id); err != nil {
+		return Thing{}, err
 	}
 
-	if _, err = ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err == nil {
-		return thing, nil
+	if err := ts.canAccessThing(ctx, token, res.GetId(), auth.ReadAction, thing); err != nil {
+		return Thing{}, err
 	}
 
-	return Thing{}, errors.ErrAuthorization
+	return thing, nil
 }
 
 func (ts *thingsService) ListThings(ctx context.Context, token string, admin bool, pm PageMetadata) (Page, error) {
@@ -303,13 +331,25 @@ func (ts *thingsService) ListThings(ctx context.Context, token string, admin boo
 		return Page{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	if err := ts.authorizeScope(ctx, token, auth.ThingsSubject, auth.ReadAction, ""); err != nil {
+		return Page{}, err
+	}
+
 	if admin {
 		if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
 			return ts.things.RetrieveByAdmin(ctx, pm)
 		}
 	}
 
-	return ts.things.RetrieveByOwner(ctx, res.GetId(), pm)
+	groupIDs, err := ts.memberGroups(ctx, token)
+	if err != nil {
+		return Page{}, err
+	}
+	if len(groupIDs) == 0 {
+		return ts.things.RetrieveByOwner(ctx, res.GetId(), pm)
+	}
+
+	return ts.things.RetrieveByOwnerOrGroups(ctx, res.GetId(), groupIDs, pm)
 }
 
 func (ts *thingsService) ListThingsByIDs(ctx context.Context, ids []string) (Page, error) {
@@ -326,7 +366,28 @@ func (ts *thingsService) ListThingsByChannel(ctx context.Context, token, chID st
 		return Page{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
-	return ts.things.RetrieveByChannel(ctx, res.GetId(), chID, pm)
+	if err := ts.authorizeScope(ctx, token, auth.ThingsSubject, auth.ReadAction, ""); err != nil {
+		return Page{}, err
+	}
+
+	if err := ts.authorizeChannelScope(ctx, token, auth.ReadAction, chID); err != nil {
+		return Page{}, err
+	}
+
+	// Listing things by the non-existent channel results in the empty page.
+	owner := res.GetId()
+	channel, err := ts.channels.RetrieveByID(ctx, chID)
+	switch {
+	case err == nil:
+		if err := ts.canAccessChannel(ctx, token, res.GetId(), auth.ReadAction, channel); err != nil {
+			return Page{}, err
+		}
+		owner = channel.Owner
+	case !errors.Contains(err, errors.ErrNotFound):
+		return Page{}, err
+	}
+
+	return ts.things.RetrieveByChannel(ctx, owner, chID, pm)
 }
 
 func (ts *thingsService) RemoveThings(ctx context.Context, token string, ids ...string) error {
@@ -335,14 +396,30 @@ func (ts *thingsService) RemoveThings(ctx context.Context, token string, ids ...
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	owners := make(map[string][]string)
 	for _, id := range ids {
+		if err := ts.authorizeThingScope(ctx, token, auth.WriteAction, id); err != nil {
+			return err
+		}
+
+		thing, err := ts.things.RetrieveByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := ts.canAccessThing(ctx, token, res.GetId(), auth.WriteAction, thing); err != nil {
+			return err
+		}
+		owners[thing.Owner] = append(owners[thing.Owner], id)
+
 		if err := ts.thingCache.Remove(ctx, id); err != nil {
 			return err
 		}
 	}
 
-	if err := ts.things.Remove(ctx, res.GetId(), ids...); err != nil {
-		return err
+	for owner, ids := range owners {
+		if err := ts.things.Remove(ctx, owner, ids...); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -354,6 +431,10 @@ func (ts *thingsService) CreateChannels(ctx context.Context, token string, chann
 		return []Channel{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	if err := ts.authorizeScope(ctx, token, auth.ChannelsSubject, auth.WriteAction, ""); err != nil {
+		return []Channel{}, err
+	}
+
 	chs := []Channel{}
 	for _, channel := range channels {
 		ch, err := ts.createChannel(ctx, &channel, res)
@@ -392,7 +473,20 @@ func (ts *thingsService) UpdateChannel(ctx context.Context, token string, channe
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
-	channel.Owner = res.GetId()
+	if err := ts.authorizeChannelScope(ctx, token, auth.WriteAction, channel.ID); err != nil {
+		return err
+	}
+
+	ch, err := ts.channels.RetrieveByID(ctx, channel.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.canAccessChannel(ctx, token, res.GetId(), auth.WriteAction, ch); err != nil {
+		return err
+	}
+
+	channel.Owner = ch.Owner
 	return ts.channels.Update(ctx, channel)
 }
 
@@ -402,17 +496,17 @@ func (ts *thingsService) ViewChannel(ctx context.Context, token, id string) (Cha
 		return Channel{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
-	channel, err := ts.channels.RetrieveByID(ctx, id)
-	if err != nil {
+	if err := ts.authorizeChannelScope(ctx, token, auth.ReadAction, id); err != nil {
 		return Channel{}, err
 	}
 
-	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
-		return channel, nil
+	channel, err := ts.channels.RetrieveByID(ctx, id)
+	if err != nil {
+		return Channel{}, err
 	}
 
-	if channel.Owner != res.GetId() {
-		return Channel{}, errors.ErrAuthorization
+	if err := ts.canAccessChannel(ctx, token, res.GetId(), auth.ReadAction, channel); err != nil {
+		return Channel{}, err
 	}
 
 	return channel, nil
@@ -424,44 +518,51 @@ func (ts *thingsService) ListChannels(ctx context.Context, token string, admin b
 		return ChannelsPage{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	if err := ts.authorizeScope(ctx, token, auth.ChannelsSubject, auth.ReadAction, ""); err != nil {
+		return ChannelsPage{}, err
+	}
+
 	if admin {
 		if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
 			return ts.channels.RetrieveByAdmin(ctx, pm)
 		}
 	}
 
-	return ts.channels.RetrieveByOwner(ctx, res.GetId(), pm)
-}
-
-func (ts *thingsService) ViewChannelByThing(ctx context.Context, token, thID string) (Channel, error) {
-	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	groupIDs, err := ts.memberGroups(ctx, token)
 	if err != nil {
-		return Channel{}, errors.Wrap(errors.ErrAuthentication, err)
+		return ChannelsPage{}, err
+	}
+	if len(groupIDs) == 0 {
+		return ts.channels.RetrieveByOwner(ctx, res.GetId(), pm)
 	}
 
-	thing, err := ts.things.RetrieveByID(ctx, thID)
+	return ts.channels.RetrieveByOwnerOrGroups(ctx, res.GetId(), groupIDs, pm)
+}
+
+func (ts *thingsService) ListChannelsByThing(ctx context.Context, token, thID string, pm PageMetadata) (ChannelsPage, error) {
+	res, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
 	if err != nil {
-		return Channel{}, err
+		return ChannelsPage{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
-	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
-		return ts.channels.RetrieveByThing(ctx, res.GetId(), thID)
+	if err := ts.authorizeScope(ctx, token, auth.ChannelsSubject, auth.ReadAction, ""); err != nil {
+		return ChannelsPage{}, err
 	}
 
-	if thing.Owner == res.GetId() {
-		return ts.channels.RetrieveByThing(ctx, res.GetId(), thID)
+	if err := ts.authorizeThingScope(ctx, token, auth.ReadAction, thID); err != nil {
+		return ChannelsPage{}, err
 	}
 
-	groupID, err := ts.groups.RetrieveThingMembership(ctx, thID)
+	thing, err := ts.things.RetrieveByID(ctx, thID)
 	if err != nil {
-		return Channel{}, err
+		return ChannelsPage{}, err
 	}
 
-	if _, err = ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err == nil {
-		return ts.channels.RetrieveByThing(ctx, res.GetId(), thID)
+	if err := ts.canAccessThing(ctx, token, res.GetId(), auth.ReadAction, thing); err != nil {
+		return ChannelsPage{}, err
 	}
 
-	return Channel{}, errors.ErrAuthorization
+	return ts.channels.RetrieveByThing(ctx, thing.Owner, thID, pm)
 }
 
 func (ts *thingsService) RemoveChannels(ctx context.Context, token string, ids ...string) error {
@@ -470,13 +571,33 @@ func (ts *thingsService) RemoveChannels(ctx context.Context, token string, ids .
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	owners := make(map[string][]string)
 	for _, id := range ids {
+		if err := ts.authorizeChannelScope(ctx, token, auth.WriteAction, id); err != nil {
+			return err
+		}
+
+		channel, err := ts.channels.RetrieveByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := ts.canAccessChannel(ctx, token, res.GetId(), auth.WriteAction, channel); err != nil {
+			return err
+		}
+		owners[channel.Owner] = append(owners[channel.Owner], id)
+
 		if err := ts.channelCache.Remove(ctx, id); err != nil {
 			return err
 		}
 	}
 
-	return ts.channels.Remove(ctx, res.GetId(), ids...)
+	for owner, ids := range owners {
+		if err := ts.channels.Remove(ctx, owner, ids...); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (ts *thingsService) ViewChannelProfile(ctx context.Context, chID string) (Profile, error) {
@@ -485,6 +606,31 @@ func (ts *thingsService) ViewChannelProfile(ctx context.Context, chID string) (P
 		return Profile{}, err
 	}
 
+	return channelProfile(channel)
+}
+
+func (ts *thingsService) ListRetentionProfiles(ctx context.Context) (map[string]Profile, error) {
+	channels, err := ts.channels.RetrieveAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]Profile)
+	for _, ch := range channels {
+		profile, err := channelProfile(ch)
+		if err != nil {
+			return nil, err
+		}
+		if profile.Writer.Retention == "" {
+			continue
+		}
+		profiles[ch.ID] = profile
+	}
+
+	return profiles, nil
+}
+
+func channelProfile(channel Channel) (Profile, error) {
 	meta, err := json.Marshal(channel.Metadata[profileKey])
 	if err != nil {
 		return Profile{}, err
@@ -504,14 +650,38 @@ func (ts *thingsService) Connect(ctx context.Context, token, chID string, thIDs
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	if err := ts.authorizeChannelScope(ctx, token, auth.WriteAction, chID); err != nil {
+		return err
+	}
+
+	channel, err := ts.channels.RetrieveByID(ctx, chID)
+	if err != nil {
+		return err
+	}
+
+	if err := ts.canAccessChannel(ctx, token, res.GetId(), auth.WriteAction, channel); err != nil {
+		return err
+	}
+
 	for _, thID := range thIDs {
-		if err := ts.isThingOwner(ctx, res.GetId(), thID); err != nil {
+		if err := ts.authorizeThingScope(ctx, token, auth.WriteAction, thID); err != nil {
 			return err
 		}
-	}
 
-	if err := ts.IsChannelOwner(ctx, res.GetId(), chID); err != nil {
-		return err
+		thing, err := ts.things.RetrieveByID(ctx, thID)
+		if err != nil {
+			return err
+		}
+
+		if err := ts.canAccessThing(ctx, token, res.GetId(), auth.WriteAction, thing); err != nil {
+			return err
+		}
+
+		// Connections are stored per owner, so the thing and the channel
+		// must share the owner.
+		if thing.Owner != channel.Owner {
+			return errors.ErrAuthorization
+		}
 	}
 
 	cgrID, err := ts.groups.RetrieveChannelMembership(ctx, chID)
@@ -534,7 +704,7 @@ func (ts *thingsService) Connect(ctx context.Context, token, chID string, thIDs
 		}
 	}
 
-	return ts.channels.Connect(ctx, res.GetId(), chID, thIDs)
+	return ts.channels.Connect(ctx, channel.Owner, chID, thIDs)
 }
 
 func (ts *thingsService) Disconnect(ctx context.Context, token, chID string, thIDs []string) error {
@@ -543,17 +713,42 @@ func (ts *thingsService) Disconnect(ctx context.Context, token, chID string, thI
 		return errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	if err := ts.authorizeChannelScope(ctx, token, auth.WriteAction, chID); err != nil {
+		return err
+	}
+
+	channel, err := ts.channels.RetrieveByID(ctx, chID)
+	if err != nil {
+		return err
+	}
+
+	// The connections of the inaccessible channels aren't disclosed.
+	if err := ts.canAccessChannel(ctx, token, res.GetId(), auth.WriteAction, channel); err != nil {
+		return errors.ErrNotFound
+	}
+
+	for _, thID := range thIDs {
+		if err := ts.authorizeThingScope(ctx, token, auth.WriteAction, thID); err != nil {
+			return err
+		}
+	}
+
 	for _, thID := range thIDs {
 		if err := ts.channelCache.Disconnect(ctx, chID, thID); err != nil {
 			return err
 		}
 	}
 
-	return ts.channels.Disconnect(ctx, res.GetId(), chID, thIDs)
+	return ts.channels.Disconnect(ctx, channel.Owner, chID, thIDs)
 }
 
-func (ts *thingsService) GetConnByKey(ctx context.Context, thingKey string) (Connection, error) {
-	conn, err := ts.channels.RetrieveConnByThingKey(ctx, thingKey)
+func (ts *thingsService) GetConnByKey(ctx context.Context, chanID, thingKey string) (Connection, error) {
+	thID, err := ts.thingCache.ID(ctx, thingKey)
+	if err == nil && ts.channelCache.HasThing(ctx, chanID, thID) {
+		return Connection{ThingID: thID, ChannelID: chanID}, nil
+	}
+
+	conn, err := ts.channels.RetrieveConnByThingKey(ctx, chanID, thingKey)
 	if err != nil {
 		return Connection{}, err
 	}
@@ -688,6 +883,10 @@ func (ts *thingsService) CreateGroups(ctx context.Context, token string, groups
 		return []Group{}, errors.Wrap(errors.ErrAuthentication, err)
 	}
 
+	if err := ts.authorizeScope(ctx, token, auth.GroupsSubject, auth.WriteAction, ""); err != nil {
+		return []Group{}, err
+	}
+
 	owner := user.GetId()
 	timestamp := getTimestmap()
 
@@ -729,6 +928,10 @@ func (ts *thingsService) ListGroups(ctx context.Context, token string, admin boo
 		return GroupPage{}, err
 	}
 
+	if err := ts.authorizeScope(ctx, token, auth.GroupsSubject, auth.ReadAction, ""); err != nil {
+		return GroupPage{}, err
+	}
+
 	if admin {
 		if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
 			return ts.groups.RetrieveByAdmin(ctx, pm)
@@ -754,7 +957,7 @@ func (ts *thingsService) RemoveGroups(ctx context.Context, token string, ids ...
 	}
 
 	for _, id := range ids {
-		if err := ts.isGroupOwner(ctx, user.GetId(), id); err != nil {
+		if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.WriteAction, id); err != nil {
 			return err
 		}
 
@@ -764,7 +967,7 @@ func (ts *thingsService) RemoveGroups(ctx context.Context, token string, ids ...
 		}
 
 		for _, ch := range cp.Channels {
-			tp, err := ts.things.RetrieveByChannel(ctx, user.GetId(), ch.ID, PageMetadata{})
+			tp, err := ts.things.RetrieveByChannel(ctx, ch.Owner, ch.ID, PageMetadata{})
 			if err != nil {
 				return err
 			}
@@ -774,7 +977,7 @@ func (ts *thingsService) RemoveGroups(ctx context.Context, token string, ids ...
 				thingIDs = append(thingIDs, th.ID)
 			}
 
-			if err := ts.channels.Disconnect(ctx, user.GetId(), ch.ID, thingIDs); err != nil {
+			if err := ts.channels.Disconnect(ctx, ch.Owner, ch.ID, thingIDs); err != nil {
 				return err
 			}
 		}
@@ -789,7 +992,7 @@ func (ts *thingsService) UpdateGroup(ctx context.Context, token string, group Gr
 		return Group{}, err
 	}
 
-	if err := ts.isGroupOwner(ctx, user.GetId(), group.ID); err != nil {
+	if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.WriteAction, group.ID); err != nil {
 		return Group{}, err
 	}
 
@@ -804,13 +1007,17 @@ func (ts *thingsService) ViewGroup(ctx context.Context, token, id string) (Group
 		return Group{}, err
 	}
 
+	if err := ts.authorizeScope(ctx, token, auth.GroupsSubject, auth.ReadAction, id); err != nil {
+		return Group{}, err
+	}
+
 	gr, err := ts.groups.RetrieveByID(ctx, id)
 	if err != nil {
 		return Group{}, err
 	}
 
 	if user.GetId() != gr.OwnerID {
-		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: id, Action: auth.ReadAction}); err != nil {
+		if err := ts.authorizeGroup(ctx, token, auth.ReadAction, id); err != nil {
 			return Group{}, err
 		}
 	}
@@ -819,7 +1026,12 @@ func (ts *thingsService) ViewGroup(ctx context.Context, token, id string) (Group
 }
 
 func (ts *thingsService) AssignThing(ctx context.Context, token string, groupID string, thingIDs ...string) error {
-	if _, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token}); err != nil {
+	user, err := ts.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return err
+	}
+
+	if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.WriteAction, groupID); err != nil {
 		return err
 	}
 
@@ -832,6 +1044,14 @@ func (ts *thingsService) AssignThing(ctx context.Context, token string, groupID
 		if thing.ID == "" {
 			return errors.ErrNotFound
 		}
+
+		if err := ts.authorizeThingScope(ctx, token, auth.WriteAction, thingID); err != nil {
+			return err
+		}
+
+		if err := ts.canAccessThing(ctx, token, user.GetId(), auth.WriteAction, thing); err != nil {
+			return err
+		}
 	}
 
 	if err := ts.groups.AssignThing(ctx, groupID, thingIDs...); err != nil {
@@ -847,7 +1067,7 @@ func (ts *thingsService) AssignChannel(ctx context.Context, token string, groupI
 		return err
 	}
 
-	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
+	if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.WriteAction, groupID); err != nil {
 		return err
 	}
 
@@ -860,6 +1080,14 @@ func (ts *thingsService) AssignChannel(ctx context.Context, token string, groupI
 		if ch.ID == "" {
 			return errors.ErrNotFound
 		}
+
+		if err := ts.authorizeChannelScope(ctx, token, auth.WriteAction, channelID); err != nil {
+			return err
+		}
+
+		if err := ts.canAccessChannel(ctx, token, user.GetId(), auth.WriteAction, ch); err != nil {
+			return err
+		}
 	}
 
 	if err := ts.groups.AssignChannel(ctx, groupID, channelIDs...); err != nil {
@@ -875,12 +1103,21 @@ func (ts *thingsService) UnassignChannel(ctx context.Context, token string, grou
 		return err
 	}
 
-	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
+	if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.WriteAction, groupID); err != nil {
 		return err
 	}
 
 	for _, chID := range channelIDs {
-		tp, err := ts.things.RetrieveByChannel(ctx, user.GetId(), chID, PageMetadata{})
+		if err := ts.authorizeChannelScope(ctx, token, auth.WriteAction, chID); err != nil {
+			return err
+		}
+
+		ch, err := ts.channels.RetrieveByID(ctx, chID)
+		if err != nil {
+			return err
+		}
+
+		tp, err := ts.things.RetrieveByChannel(ctx, ch.Owner, chID, PageMetadata{})
 		if err != nil {
 			return err
 		}
@@ -890,7 +1127,7 @@ func (ts *thingsService) UnassignChannel(ctx context.Context, token string, grou
 			thingIDs = append(thingIDs, th.ID)
 		}
 
-		if err := ts.channels.Disconnect(ctx, user.GetId(), chID, thingIDs); err != nil {
+		if err := ts.channels.Disconnect(ctx, ch.Owner, chID, thingIDs); err != nil {
 			return err
 		}
 	}
@@ -908,18 +1145,31 @@ func (ts *thingsService) UnassignThing(ctx context.Context, token string, groupI
 		return err
 	}
 
-	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
+	if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.WriteAction, groupID); err != nil {
 		return err
 	}
 
 	for _, thingID := range thingIDs {
-		ch, err := ts.channels.RetrieveByThing(ctx, user.GetId(), thingID)
+		if err := ts.authorizeThingScope(ctx, token, auth.WriteAction, thingID); err != nil {
+			return err
+		}
+
+		th, err := ts.things.RetrieveByID(ctx, thingID)
+		if err != nil {
+			return err
+		}
+
+		cp, err := ts.channels.RetrieveByThing(ctx, th.Owner, thingID, PageMetadata{})
 		if err != nil {
 			return err
 		}
 
-		if ch.ID != "" {
-			if err := ts.channels.Disconnect(ctx, user.GetId(), ch.ID, []string{thingID}); err != nil {
+		for _, ch := range cp.Channels {
+			if err := ts.channelCache.Disconnect(ctx, ch.ID, thingID); err != nil {
+				return err
+			}
+
+			if err := ts.channels.Disconnect(ctx, th.Owner, ch.ID, []string{thingID}); err != nil {
 				return err
 			}
 		}
@@ -938,10 +1188,8 @@ func (ts *thingsService) ListGroupThings(ctx context.Context, token string, grou
 		return GroupThingsPage{}, err
 	}
 
-	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
-		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err != nil {
-			return GroupThingsPage{}, err
-		}
+	if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.ReadAction, groupID); err != nil {
+		return GroupThingsPage{}, err
 	}
 
 	return ts.groups.RetrieveGroupThings(ctx, groupID, pm)
@@ -957,10 +1205,8 @@ func (ts *thingsService) ListGroupThingsByChannel(ctx context.Context, token, gr
 		return ts.groups.RetrieveGroupThingsByChannel(ctx, grID, chID, pm)
 	}
 
-	if err := ts.isGroupOwner(ctx, user.GetId(), grID); err != nil {
-		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: grID, Action: auth.ReadAction}); err != nil {
-			return GroupThingsPage{}, err
-		}
+	if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.ReadAction, grID); err != nil {
+		return GroupThingsPage{}, err
 	}
 
 	return ts.groups.RetrieveGroupThingsByChannel(ctx, grID, chID, pm)
@@ -972,10 +1218,8 @@ func (ts *thingsService) ListGroupChannels(ctx context.Context, token, groupID s
 		return GroupChannelsPage{}, err
 	}
 
-	if err := ts.isGroupOwner(ctx, user.GetId(), groupID); err != nil {
-		if _, err := ts.auth.Authorize(ctx, &mainflux.AuthorizeReq{Token: token, Subject: auth.GroupSubject, Object: groupID, Action: auth.ReadAction}); err != nil {
-			return GroupChannelsPage{}, err
-		}
+	if err := ts.canAccessGroup(ctx, token, user.GetId(), auth.ReadAction, groupID); err != nil {
+		return GroupChannelsPage{}, err
 	}
 
 	return ts.groups.RetrieveGroupChannels(ctx, groupID, pm)
@@ -986,6 +1230,10 @@ func (ts *thingsService) ViewThingMembership(ctx context.Context, token string,
 		return Group{}, err
 	}
 
+	if err := ts.authorizeThingScope(ctx, token, auth.ReadAction, thingID); err != nil {
+		return Group{}, err
+	}
+
 	groupID, err := ts.groups.RetrieveThingMembership(ctx, thingID)
 	if err != nil {
 		return Group{}, err
@@ -1008,6 +1256,10 @@ func (ts *thingsService) ViewChannelMembership(ctx context.Context, token string
 		return Group{}, err
 	}
 
+	if err := ts.authorizeChannelScope(ctx, token, auth.ReadAction, channelID); err != nil {
+		return Group{}, err
+	}
+
 	groupID, err := ts.groups.RetrieveChannelMembership(ctx, channelID)
 	if err != nil {
 		return Group{}, err
@@ -1038,27 +1290,138 @@ func (ts *thingsService) authorize(ctx context.Context, subject, token string) e
 	return nil
 }
 
-func (ts *thingsService) isThingOwner(ctx context.Context, owner string, thingID string) error {
-	thing, err := ts.things.RetrieveByID(ctx, thingID)
-	if err != nil {
-		return err
+// authorizeScope checks whether the token is allowed to perform the action
+// on the given resource. Tokens which are not restricted by scopes are
+// allowed to perform any action.
+func (ts *thingsService) authorizeScope(ctx context.Context, token, subject, action, object string) error {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: subject,
+		Object:  object,
+		Action:  action,
 	}
 
-	if thing.Owner != owner {
-		return errors.ErrNotFound
+	if _, err := ts.auth.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
 	}
 
 	return nil
 }
 
-func (ts *thingsService) isGroupOwner(ctx context.Context, userID, groupID string) error {
+// authorizeThingScope checks whether the token scopes allow the action on the
+// thing. Besides the scopes limited to the thing itself, the thing is allowed
+// by the things scopes limited to the group the thing is assigned to.
+func (ts *thingsService) authorizeThingScope(ctx context.Context, token, action, thingID string) error {
+	err := ts.authorizeScope(ctx, token, auth.ThingsSubject, action, thingID)
+	if err == nil {
+		return nil
+	}
+
+	groupID, gerr := ts.groups.RetrieveThingMembership(ctx, thingID)
+	if gerr != nil || groupID == "" {
+		return err
+	}
+
+	return ts.authorizeScope(ctx, token, auth.ThingsSubject, action, groupID)
+}
+
+// authorizeChannelScope checks whether the token scopes allow the action on
+// the channel, the same way as authorizeThingScope does for things.
+func (ts *thingsService) authorizeChannelScope(ctx context.Context, token, action, chanID string) error {
+	err := ts.authorizeScope(ctx, token, auth.ChannelsSubject, action, chanID)
+	if err == nil {
+		return nil
+	}
+
+	groupID, gerr := ts.groups.RetrieveChannelMembership(ctx, chanID)
+	if gerr != nil || groupID == "" {
+		return err
+	}
+
+	return ts.authorizeScope(ctx, token, auth.ChannelsSubject, action, groupID)
+}
+
+// canAccessThing verifies that the user can perform the action on the thing.
+// Besides the owner and the root admin, the thing can be accessed by the org
+// members, according to their org roles, if its group is assigned to the org.
+func (ts *thingsService) canAccessThing(ctx context.Context, token, userID, action string, thing Thing) error {
+	if thing.Owner == userID {
+		return nil
+	}
+
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return nil
+	}
+
+	groupID, err := ts.groups.RetrieveThingMembership(ctx, thing.ID)
+	if err != nil || groupID == "" {
+		return errors.ErrAuthorization
+	}
+
+	return ts.authorizeGroup(ctx, token, action, groupID)
+}
+
+// canAccessChannel verifies that the user can perform the action on the
+// channel, the same way as canAccessThing does for things.
+func (ts *thingsService) canAccessChannel(ctx context.Context, token, userID, action string, channel Channel) error {
+	if channel.Owner == userID {
+		return nil
+	}
+
+	if err := ts.authorize(ctx, auth.RootSubject, token); err == nil {
+		return nil
+	}
+
+	groupID, err := ts.groups.RetrieveChannelMembership(ctx, channel.ID)
+	if err != nil || groupID == "" {
+		return errors.ErrAuthorization
+	}
+
+	return ts.authorizeGroup(ctx, token, action, groupID)
+}
+
+// canAccessGroup verifies that the user can perform the action on the group.
+// The group can be accessed by its owner and the members of the org the group
+// is assigned to, according to their org roles.
+func (ts *thingsService) canAccessGroup(ctx context.Context, token, userID, action, groupID string) error {
+	// Scopes apply to the group owner as well, so they're checked first.
+	if err := ts.authorizeScope(ctx, token, auth.GroupsSubject, action, groupID); err != nil {
+		return err
+	}
+
 	group, err := ts.groups.RetrieveByID(ctx, groupID)
 	if err != nil {
 		return err
 	}
 
-	if userID != group.OwnerID {
-		return errors.ErrAuthorization
+	if group.OwnerID == userID {
+		return nil
+	}
+
+	return ts.authorizeGroup(ctx, token, action, groupID)
+}
+
+// memberGroups retrieves the IDs of the groups assigned to the orgs of the
+// user, whose things and channels the user can read by the org role.
+func (ts *thingsService) memberGroups(ctx context.Context, token string) ([]string, error) {
+	res, err := ts.auth.RetrieveMemberGroups(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return nil, errors.Wrap(errors.ErrAuthorization, err)
+	}
+
+	return res.GetGroupIDs(), nil
+}
+
+func (ts *thingsService) authorizeGroup(ctx context.Context, token, action, groupID string) error {
+	req := &mainflux.AuthorizeReq{
+		Token:   token,
+		Subject: auth.GroupSubject,
+		Object:  groupID,
+		Action:  action,
+	}
+
+	if _, err := ts.auth.Authorize(ctx, req); err != nil {
+		return errors.Wrap(errors.ErrAuthorization, err)
 	}
 
 	return nil
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	authmock "github.com/MainfluxLabs/mainflux/pkg/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -52,7 +53,7 @@ func newService() things.Service {
 	conns := make(chan mocks.Connection)
 	thingsRepo := mocks.NewThingRepository(conns)
 	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
-	groupsRepo := mocks.NewGroupRepository()
+	groupsRepo := mocks.NewGroupRepository(thingsRepo, channelsRepo)
 	chanCache := mocks.NewChannelCache()
 	thingCache := mocks.NewThingCache()
 	idProvider := uuid.NewMock()
@@ -902,19 +903,22 @@ func TestListChannels(t *testing.T) {
 	}
 }
 
-func TestViewChannelByThing(t *testing.T) {
+func TestListChannelsByThing(t *testing.T) {
 	svc := newService()
 
 	ths, err := svc.CreateThings(context.Background(), token, thingList[0])
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	th := ths[0]
 
-	c := channel
-	c.Name = "test-channel"
+	var chs []things.Channel
+	for i := 0; i < 3; i++ {
+		c := channel
+		c.Name = fmt.Sprintf("test-channel-%d", i)
+		chs = append(chs, c)
+	}
 
-	chs, err := svc.CreateChannels(context.Background(), token, c)
+	chs, err = svc.CreateChannels(context.Background(), token, chs...)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
-	ch := chs[0]
 
 	grs, err := svc.CreateGroups(context.Background(), token, group)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
@@ -923,56 +927,74 @@ func TestViewChannelByThing(t *testing.T) {
 	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	for _, ch := range chs {
+		err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
 
 	// Wait for things and channels to connect.
 	time.Sleep(time.Second)
 
 	cases := map[string]struct {
-		token   string
-		thID    string
-		channel things.Channel
-		err     error
+		token        string
+		thID         string
+		pageMetadata things.PageMetadata
+		size         uint64
+		err          error
 	}{
-		"view channel by existing thing": {
-			token:   token,
-			thID:    th.ID,
-			channel: ch,
-			err:     nil,
+		"list channels by existing thing": {
+			token: token,
+			thID:  th.ID,
+			size:  3,
+			err:   nil,
 		},
-		"view channel by existing thing as admin": {
-			token:   adminToken,
-			thID:    th.ID,
-			channel: ch,
-			err:     nil,
+		"list channels by existing thing as admin": {
+			token: adminToken,
+			thID:  th.ID,
+			size:  3,
+			err:   nil,
 		},
-		"view channel by existing thing with wrong credentials": {
-			token:   wrongValue,
-			thID:    th.ID,
-			channel: things.Channel{},
-			err:     errors.ErrAuthentication,
+		"list channels by existing thing with limit": {
+			token: token,
+			thID:  th.ID,
+			pageMetadata: things.PageMetadata{
+				Offset: 0,
+				Limit:  2,
+			},
+			size: 2,
+			err:  nil,
 		},
-		"view channel by non-existent thing": {
-			token:   token,
-			thID:    "non-existent",
-			channel: things.Channel{},
-			err:     errors.ErrNotFound,
+		"list channels by existing thing with offset": {
+			token: token,
+			thID:  th.ID,
+			pageMetadata: things.PageMetadata{
+				Offset: 2,
+				Limit:  10,
+			},
+			size: 1,
+			err:  nil,
 		},
-		"view channel by existent thing with invalid token": {
-			token:   wrongValue,
-			thID:    th.ID,
-			channel: things.Channel{},
-			err:     errors.ErrAuthentication,
+		"list channels by existing thing with wrong credentials": {
+			token: wrongValue,
+			thID:  th.ID,
+			size:  0,
+			err:   errors.ErrAuthentication,
+		},
+		"list channels by non-existent thing": {
+			token: token,
+			thID:  "non-existent",
+			size:  0,
+			err:   errors.ErrNotFound,
 		},
 	}
 
 	for desc, tc := range cases {
-		ch, err := svc.ViewChannelByThing(context.Background(), tc.token, tc.thID)
-		assert.Equal(t, tc.channel, ch, fmt.Sprintf("%s: expected %v got %v\n", desc, tc.channel, ch))
+		page, err := svc.ListChannelsByThing(context.Background(), tc.token, tc.thID, tc.pageMetadata)
+		size := uint64(len(page.Channels))
+		assert.Equal(t, tc.size, size, fmt.Sprintf("%s: expected %d got %d\n", desc, tc.size, size))
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
 	}
 }
@@ -1021,6 +1043,21 @@ func TestRemoveChannel(t *testing.T) {
 	}
 }
 
+func TestListRetentionProfiles(t *testing.T) {
+	svc := newService()
+
+	profile := things.Profile{ContentType: "application/json", Writer: things.Writer{Retention: "24h"}}
+	retained := things.Channel{Name: "retained", Metadata: map[string]interface{}{"profile": profile}}
+	unretained := things.Channel{Name: "unretained", Metadata: map[string]interface{}{"profile": things.Profile{ContentType: "application/json"}}}
+	chs, err := svc.CreateChannels(context.Background(), token, retained, unretained, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	profiles, err := svc.ListRetentionProfiles(context.Background())
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	expected := map[string]things.Profile{chs[0].ID: profile}
+	assert.Equal(t, expected, profiles, fmt.Sprintf("expected %v got %v\n", expected, profiles))
+}
+
 func TestConnect(t *testing.T) {
 	svc := newService()
 
@@ -1166,9 +1203,9 @@ func TestGetConnByKey(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
 	th := ths[0]
 
-	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel, channel)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
-	ch := chs[0]
+	ch1, ch2, ch3 := chs[0], chs[1], chs[2]
 
 	grs, err := svc.CreateGroups(context.Background(), token, group)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
@@ -1177,29 +1214,53 @@ func TestGetConnByKey(t *testing.T) {
 	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	for _, ch := range []things.Channel{ch1, ch2} {
+		err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
-	err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
-	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+		err = svc.Connect(context.Background(), token, ch.ID, []string{th.ID})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	}
 
 	cases := map[string]struct {
-		key string
-		err error
+		key    string
+		chanID string
+		err    error
 	}{
-		"allowed access": {
-			key: th.Key,
-			err: nil,
+		"allowed access to first channel": {
+			key:    th.Key,
+			chanID: ch1.ID,
+			err:    nil,
+		},
+		"allowed access to second channel": {
+			key:    th.Key,
+			chanID: ch2.ID,
+			err:    nil,
+		},
+		"access to not connected channel": {
+			key:    th.Key,
+			chanID: ch3.ID,
+			err:    errors.ErrAuthorization,
+		},
+		"access to non-existing channel": {
+			key:    th.Key,
+			chanID: wrongID,
+			err:    errors.ErrAuthorization,
 		},
 		"non-existing thing": {
-			key: wrongValue,
-			err: errors.ErrNotFound,
+			key:    wrongValue,
+			chanID: ch1.ID,
+			err:    errors.ErrNotFound,
 		},
 	}
 
 	for desc, tc := range cases {
-		_, err := svc.GetConnByKey(context.Background(), tc.key)
+		conn, err := svc.GetConnByKey(context.Background(), tc.chanID, tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected '%s' got '%s'\n", desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, tc.chanID, conn.ChannelID, fmt.Sprintf("%s: expected channel %s got %s\n", desc, tc.chanID, conn.ChannelID))
+			assert.Equal(t, th.ID, conn.ThingID, fmt.Sprintf("%s: expected thing %s got %s\n", desc, th.ID, conn.ThingID))
+		}
 	}
 }
 
@@ -1267,6 +1328,163 @@ func TestIdentify(t *testing.T) {
 	}
 }
 
+func TestOrgMemberAccess(t *testing.T) {
+	editor := users.User{ID: "574106f7-030e-4881-8ab0-151195c29f95", Email: "editor@example.com", Password: password}
+	viewer := users.User{ID: "574106f7-030e-4881-8ab0-151195c29f96", Email: "viewer@example.com", Password: password}
+	outsider := users.User{ID: "574106f7-030e-4881-8ab0-151195c29f97", Email: "outsider@example.com", Password: password}
+
+	groups := make(map[string]map[string]string)
+	auth := authmock.NewAuthServiceWithGroups(admin.ID, append(usersList, editor, viewer, outsider), groups)
+	conns := make(chan mocks.Connection)
+	thingsRepo := mocks.NewThingRepository(conns)
+	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	svc := things.New(auth, thingsRepo, channelsRepo, mocks.NewGroupRepository(thingsRepo, channelsRepo), mocks.NewChannelCache(), mocks.NewThingCache(), uuid.NewMock())
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr := grs[0]
+	groups[gr.ID] = map[string]string{editor.ID: "read_write", viewer.ID: "read"}
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th, ungrouped := ths[0], ths[1]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	viewThing := func(token, id string) error {
+		_, err := svc.ViewThing(context.Background(), token, id)
+		return err
+	}
+	updateThing := func(token, id string) error {
+		return svc.UpdateThing(context.Background(), token, things.Thing{ID: id, Name: "updated"})
+	}
+	viewChannel := func(token, id string) error {
+		_, err := svc.ViewChannel(context.Background(), token, id)
+		return err
+	}
+	updateChannel := func(token, id string) error {
+		return svc.UpdateChannel(context.Background(), token, things.Channel{ID: id, Name: "updated"})
+	}
+	connect := func(token, id string) error {
+		return svc.Connect(context.Background(), token, id, []string{th.ID})
+	}
+	listGroupThings := func(token, id string) error {
+		_, err := svc.ListGroupThings(context.Background(), token, id, things.PageMetadata{Limit: n})
+		return err
+	}
+	updateGroup := func(token, id string) error {
+		_, err := svc.UpdateGroup(context.Background(), token, things.Group{ID: id, Name: "updated"})
+		return err
+	}
+
+	cases := []struct {
+		desc  string
+		op    func(token, id string) error
+		token string
+		id    string
+		err   error
+	}{
+		{desc: "view thing as editor", op: viewThing, token: editor.Email, id: th.ID, err: nil},
+		{desc: "view thing as viewer", op: viewThing, token: viewer.Email, id: th.ID, err: nil},
+		{desc: "view thing as outsider", op: viewThing, token: outsider.Email, id: th.ID, err: errors.ErrAuthorization},
+		{desc: "view ungrouped thing as editor", op: viewThing, token: editor.Email, id: ungrouped.ID, err: errors.ErrAuthorization},
+		{desc: "update thing as editor", op: updateThing, token: editor.Email, id: th.ID, err: nil},
+		{desc: "update thing as viewer", op: updateThing, token: viewer.Email, id: th.ID, err: errors.ErrAuthorization},
+		{desc: "view channel as viewer", op: viewChannel, token: viewer.Email, id: ch.ID, err: nil},
+		{desc: "view channel as outsider", op: viewChannel, token: outsider.Email, id: ch.ID, err: errors.ErrAuthorization},
+		{desc: "update channel as editor", op: updateChannel, token: editor.Email, id: ch.ID, err: nil},
+		{desc: "update channel as viewer", op: updateChannel, token: viewer.Email, id: ch.ID, err: errors.ErrAuthorization},
+		{desc: "connect thing as viewer", op: connect, token: viewer.Email, id: ch.ID, err: errors.ErrAuthorization},
+		{desc: "connect thing as editor", op: connect, token: editor.Email, id: ch.ID, err: nil},
+		{desc: "list group things as viewer", op: listGroupThings, token: viewer.Email, id: gr.ID, err: nil},
+		{desc: "list group things as outsider", op: listGroupThings, token: outsider.Email, id: gr.ID, err: errors.ErrAuthorization},
+		{desc: "update group as editor", op: updateGroup, token: editor.Email, id: gr.ID, err: nil},
+		{desc: "update group as viewer", op: updateGroup, token: viewer.Email, id: gr.ID, err: errors.ErrAuthorization},
+	}
+
+	for _, tc := range cases {
+		err := tc.op(tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	foreignThs, err := svc.CreateThings(context.Background(), outsider.Email, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	foreignChs, err := svc.CreateChannels(context.Background(), outsider.Email, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	err = svc.AssignThing(context.Background(), editor.Email, gr.ID, foreignThs[0].ID)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("assign foreign thing as editor: expected %s got %s\n", errors.ErrAuthorization, err))
+	err = svc.AssignThing(context.Background(), token, gr.ID, foreignThs[0].ID)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("assign foreign thing as group owner: expected %s got %s\n", errors.ErrAuthorization, err))
+	err = svc.AssignChannel(context.Background(), editor.Email, gr.ID, foreignChs[0].ID)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("assign foreign channel as editor: expected %s got %s\n", errors.ErrAuthorization, err))
+	err = svc.UpdateKey(context.Background(), editor.Email, foreignThs[0].ID, "new-key")
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("update key of foreign thing as editor: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	err = svc.RemoveThings(context.Background(), editor.Email, th.ID)
+	assert.Nil(t, err, fmt.Sprintf("remove thing as editor: unexpected error: %s\n", err))
+	_, err = svc.ViewThing(context.Background(), token, th.ID)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("view thing removed by editor: expected %s got %s\n", errors.ErrNotFound, err))
+}
+
+func TestListOrgMemberEntities(t *testing.T) {
+	viewer := users.User{ID: "574106f7-030e-4881-8ab0-151195c29f96", Email: "viewer@example.com", Password: password}
+	outsider := users.User{ID: "574106f7-030e-4881-8ab0-151195c29f97", Email: "outsider@example.com", Password: password}
+
+	groups := make(map[string]map[string]string)
+	auth := authmock.NewAuthServiceWithGroups(admin.ID, append(usersList, viewer, outsider), groups)
+	conns := make(chan mocks.Connection)
+	thingsRepo := mocks.NewThingRepository(conns)
+	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	svc := things.New(auth, thingsRepo, channelsRepo, mocks.NewGroupRepository(thingsRepo, channelsRepo), mocks.NewChannelCache(), mocks.NewThingCache(), uuid.NewMock())
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr := grs[0]
+	groups[gr.ID] = map[string]string{viewer.ID: "read"}
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	chs, err := svc.CreateChannels(context.Background(), token, channel, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignThing(context.Background(), token, gr.ID, ths[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignChannel(context.Background(), token, gr.ID, chs[0].ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	_, err = svc.CreateThings(context.Background(), viewer.Email, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	_, err = svc.CreateChannels(context.Background(), viewer.Email, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	cases := []struct {
+		desc     string
+		token    string
+		things   int
+		channels int
+	}{
+		{desc: "list as owner", token: token, things: 2, channels: 2},
+		{desc: "list as org member", token: viewer.Email, things: 2, channels: 2},
+		{desc: "list as outsider", token: outsider.Email, things: 0, channels: 0},
+	}
+
+	for _, tc := range cases {
+		tp, err := svc.ListThings(context.Background(), tc.token, false, things.PageMetadata{Limit: n})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.things, len(tp.Things), fmt.Sprintf("%s: expected %d things got %d\n", tc.desc, tc.things, len(tp.Things)))
+
+		cp, err := svc.ListChannels(context.Background(), tc.token, false, things.PageMetadata{Limit: n})
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.channels, len(cp.Channels), fmt.Sprintf("%s: expected %d channels got %d\n", tc.desc, tc.channels, len(cp.Channels)))
+	}
+}
+
 func TestBackup(t *testing.T) {
 	svc := newService()
 
@@ -1534,3 +1752,108 @@ func testSortChannels(t *testing.T, pm things.PageMetadata, chs []things.Channel
 		break
 	}
 }
+
+func TestScopedKeyAccess(t *testing.T) {
+	keys := make(map[string]auth.Key)
+	authSvc := authmock.NewAuthServiceWithScopes(admin.ID, usersList, nil, keys)
+	conns := make(chan mocks.Connection)
+	thingsRepo := mocks.NewThingRepository(conns)
+	channelsRepo := mocks.NewChannelRepository(thingsRepo, conns)
+	svc := things.New(authSvc, thingsRepo, channelsRepo, mocks.NewGroupRepository(thingsRepo, channelsRepo), mocks.NewChannelCache(), mocks.NewThingCache(), uuid.NewMock())
+
+	grs, err := svc.CreateGroups(context.Background(), token, group)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	gr := grs[0]
+
+	ths, err := svc.CreateThings(context.Background(), token, thing, thing)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	th, ungrouped := ths[0], ths[1]
+	chs, err := svc.CreateChannels(context.Background(), token, channel)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	ch := chs[0]
+
+	err = svc.AssignThing(context.Background(), token, gr.ID, th.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+	err = svc.AssignChannel(context.Background(), token, gr.ID, ch.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s\n", err))
+
+	scoped := func(token string, scopes ...auth.Scope) string {
+		keys[token] = auth.Key{Type: auth.APIKey, Subject: userEmail, Scopes: scopes}
+		return token
+	}
+	thingsRead := scoped("things-read", auth.Scope{Resource: auth.ThingsSubject, Action: auth.ReadScope})
+	groupThingsWrite := scoped("group-things-write", auth.Scope{Resource: auth.ThingsSubject, Action: auth.WriteScope, ID: gr.ID})
+	groupRead := scoped("group-read", auth.Scope{Resource: auth.GroupsSubject, Action: auth.ReadScope, ID: gr.ID})
+
+	cases := []struct {
+		desc string
+		op   func() error
+		err  error
+	}{
+		{
+			desc: "view thing with things read scope",
+			op:   func() error { _, err := svc.ViewThing(context.Background(), thingsRead, th.ID); return err },
+			err:  nil,
+		},
+		{
+			desc: "update thing with things read scope",
+			op:   func() error { return svc.UpdateThing(context.Background(), thingsRead, th) },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "update thing in group with group things write scope",
+			op:   func() error { return svc.UpdateThing(context.Background(), groupThingsWrite, th) },
+			err:  nil,
+		},
+		{
+			desc: "update thing outside group with group things write scope",
+			op:   func() error { return svc.UpdateThing(context.Background(), groupThingsWrite, ungrouped) },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "connect with group things write scope",
+			op:   func() error { return svc.Connect(context.Background(), groupThingsWrite, ch.ID, []string{th.ID}) },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "create group with things read scope",
+			op:   func() error { _, err := svc.CreateGroups(context.Background(), thingsRead, group); return err },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "view group with things read scope",
+			op:   func() error { _, err := svc.ViewGroup(context.Background(), thingsRead, gr.ID); return err },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "view group with group read scope",
+			op:   func() error { _, err := svc.ViewGroup(context.Background(), groupRead, gr.ID); return err },
+			err:  nil,
+		},
+		{
+			desc: "update owned group with group read scope",
+			op:   func() error { _, err := svc.UpdateGroup(context.Background(), groupRead, gr); return err },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "unassign thing with group read scope",
+			op:   func() error { return svc.UnassignThing(context.Background(), groupRead, gr.ID, th.ID) },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "remove owned group with things read scope",
+			op:   func() error { return svc.RemoveGroups(context.Background(), thingsRead, gr.ID) },
+			err:  errors.ErrAuthorization,
+		},
+		{
+			desc: "view thing membership with group read scope",
+			op:   func() error { _, err := svc.ViewThingMembership(context.Background(), groupRead, th.ID); return err },
+			err:  errors.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tc.op()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
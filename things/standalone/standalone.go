@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/golang/protobuf/ptypes/empty"
 	"google.golang.org/grpc"
@@ -45,8 +46,21 @@ func (repo singleUserRepo) Identify(ctx context.Context, token *mainflux.Token,
 	return &mainflux.UserIdentity{Id: repo.email, Email: repo.email}, nil
 }
 
+func (repo singleUserRepo) IdentifyVerification(ctx context.Context, token *mainflux.Token, opts ...grpc.CallOption) (*mainflux.UserIdentity, error) {
+	return &mainflux.UserIdentity{}, errUnsupported
+}
+
 func (repo singleUserRepo) Authorize(ctx context.Context, req *mainflux.AuthorizeReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
-	return &empty.Empty{}, errUnsupported
+	switch req.GetSubject() {
+	case auth.ThingsSubject, auth.ChannelsSubject, auth.MessagesSubject:
+		// The single user token is not restricted by scopes.
+		if repo.token != req.GetToken() {
+			return &empty.Empty{}, errors.ErrAuthentication
+		}
+		return &empty.Empty{}, nil
+	default:
+		return &empty.Empty{}, errUnsupported
+	}
 }
 
 func (repo singleUserRepo) AddPolicy(ctx context.Context, in *mainflux.PolicyReq, opts ...grpc.CallOption) (r *empty.Empty, err error) {
@@ -68,3 +82,18 @@ func (repo singleUserRepo) AssignRole(ctx context.Context, req *mainflux.AssignR
 func (repo singleUserRepo) RetrieveRole(ctx context.Context, req *mainflux.RetrieveRoleReq, _ ...grpc.CallOption) (r *mainflux.RetrieveRoleRes, err error) {
 	return &mainflux.RetrieveRoleRes{}, errUnsupported
 }
+
+func (repo singleUserRepo) JoinOrg(ctx context.Context, req *mainflux.JoinOrgReq, _ ...grpc.CallOption) (r *empty.Empty, err error) {
+	return &empty.Empty{}, errUnsupported
+}
+
+// RetrieveGroupOrg returns no org, since the single user doesn't have any.
+func (repo singleUserRepo) RetrieveGroupOrg(ctx context.Context, req *mainflux.GroupOrgReq, _ ...grpc.CallOption) (r *mainflux.OrgID, err error) {
+	return &mainflux.OrgID{}, nil
+}
+
+// RetrieveMemberGroups returns no groups, since the single user isn't a
+// member of any org.
+func (repo singleUserRepo) RetrieveMemberGroups(ctx context.Context, token *mainflux.Token, _ ...grpc.CallOption) (*mainflux.MemberGroupsRes, error) {
+	return &mainflux.MemberGroupsRes{}, nil
+}
@@ -66,6 +66,10 @@ type ThingRepository interface {
 	// RetrieveByOwner retrieves the subset of things owned by the specified user
 	RetrieveByOwner(ctx context.Context, owner string, pm PageMetadata) (Page, error)
 
+	// RetrieveByOwnerOrGroups retrieves the subset of things owned by the
+	// specified user or assigned to the given groups.
+	RetrieveByOwnerOrGroups(ctx context.Context, owner string, groupIDs []string, pm PageMetadata) (Page, error)
+
 	// RetrieveByIDs retrieves the subset of things specified by given thing ids.
 	RetrieveByIDs(ctx context.Context, thingIDs []string, pm PageMetadata) (Page, error)
 
@@ -11,18 +11,19 @@ import (
 )
 
 const (
-	saveChannelsOp           = "save_channels"
-	updateChannelOp          = "update_channel"
-	retrieveChannelByIDOp    = "retrieve_channel_by_id"
-	retrieveByThingOp        = "retrieve_by_thing"
-	retrieveChannelConnsOp   = "retrieve_channels_conns"
-	removeChannelOp          = "retrieve_channel"
-	connectOp                = "connect"
-	disconnectOp             = "disconnect"
-	hasThingOp               = "has_thing"
-	hasThingByIDOp           = "has_thing_by_id"
-	retrieveAllChannelsOp    = "retrieve_all_channels"
-	retrieveAllConnectionsOp = "retrieve_all_connections"
+	saveChannelsOp            = "save_channels"
+	updateChannelOp           = "update_channel"
+	retrieveChannelByIDOp     = "retrieve_channel_by_id"
+	retrieveByOwnerOrGroupsOp = "retrieve_by_owner_or_groups"
+	retrieveByThingOp         = "retrieve_by_thing"
+	retrieveChannelConnsOp    = "retrieve_channels_conns"
+	removeChannelOp           = "retrieve_channel"
+	connectOp                 = "connect"
+	disconnectOp              = "disconnect"
+	hasThingOp                = "has_thing"
+	hasThingByIDOp            = "has_thing_by_id"
+	retrieveAllChannelsOp     = "retrieve_all_channels"
+	retrieveAllConnectionsOp  = "retrieve_all_connections"
 )
 
 var (
@@ -76,12 +77,20 @@ func (crm channelRepositoryMiddleware) RetrieveByOwner(ctx context.Context, owne
 	return crm.repo.RetrieveByOwner(ctx, owner, pm)
 }
 
-func (crm channelRepositoryMiddleware) RetrieveByThing(ctx context.Context, owner, thID string) (things.Channel, error) {
+func (crm channelRepositoryMiddleware) RetrieveByOwnerOrGroups(ctx context.Context, owner string, groupIDs []string, pm things.PageMetadata) (things.ChannelsPage, error) {
+	span := createSpan(ctx, crm.tracer, retrieveByOwnerOrGroupsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return crm.repo.RetrieveByOwnerOrGroups(ctx, owner, groupIDs, pm)
+}
+
+func (crm channelRepositoryMiddleware) RetrieveByThing(ctx context.Context, owner, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
 	span := createSpan(ctx, crm.tracer, retrieveByThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.RetrieveByThing(ctx, owner, thID)
+	return crm.repo.RetrieveByThing(ctx, owner, thID, pm)
 }
 
 func (crm channelRepositoryMiddleware) RetrieveConns(ctx context.Context, thID string, pm things.PageMetadata) (things.ChannelsPage, error) {
@@ -116,12 +125,12 @@ func (crm channelRepositoryMiddleware) Disconnect(ctx context.Context, owner, ch
 	return crm.repo.Disconnect(ctx, owner, chID, thIDs)
 }
 
-func (crm channelRepositoryMiddleware) RetrieveConnByThingKey(ctx context.Context, key string) (things.Connection, error) {
+func (crm channelRepositoryMiddleware) RetrieveConnByThingKey(ctx context.Context, chanID, key string) (things.Connection, error) {
 	span := createSpan(ctx, crm.tracer, hasThingOp)
 	defer span.Finish()
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
-	return crm.repo.RetrieveConnByThingKey(ctx, key)
+	return crm.repo.RetrieveConnByThingKey(ctx, chanID, key)
 }
 
 func (crm channelRepositoryMiddleware) RetrieveAll(ctx context.Context) ([]things.Channel, error) {
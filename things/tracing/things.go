@@ -11,19 +11,20 @@ import (
 )
 
 const (
-	saveThingOp               = "save_thing"
-	saveThingsOp              = "save_things"
-	updateThingOp             = "update_thing"
-	updateThingKeyOp          = "update_thing_by_key"
-	retrieveThingByIDOp       = "retrieve_thing_by_id"
-	retrieveThingsByIDsOp     = "retrieve_things_by_ids"
-	retrieveThingByKeyOp      = "retrieve_thing_by_key"
-	retrieveThingsByOwnerOp   = "retrieve_things_by_owner"
-	retrieveThingsByChannelOp = "retrieve_things_by_chan"
-	removeThingOp             = "remove_thing"
-	retrieveThingIDByKeyOp    = "retrieve_id_by_key"
-	retrieveAllThingsOp       = "retrieve_all_things"
-	restoreThingsOp           = "restore_things"
+	saveThingOp                     = "save_thing"
+	saveThingsOp                    = "save_things"
+	updateThingOp                   = "update_thing"
+	updateThingKeyOp                = "update_thing_by_key"
+	retrieveThingByIDOp             = "retrieve_thing_by_id"
+	retrieveThingsByIDsOp           = "retrieve_things_by_ids"
+	retrieveThingByKeyOp            = "retrieve_thing_by_key"
+	retrieveThingsByOwnerOp         = "retrieve_things_by_owner"
+	retrieveThingsByOwnerOrGroupsOp = "retrieve_things_by_owner_or_groups"
+	retrieveThingsByChannelOp       = "retrieve_things_by_chan"
+	removeThingOp                   = "remove_thing"
+	retrieveThingIDByKeyOp          = "retrieve_id_by_key"
+	retrieveAllThingsOp             = "retrieve_all_things"
+	restoreThingsOp                 = "restore_things"
 )
 
 var (
@@ -93,6 +94,14 @@ func (trm thingRepositoryMiddleware) RetrieveByOwner(ctx context.Context, owner
 	return trm.repo.RetrieveByOwner(ctx, owner, pm)
 }
 
+func (trm thingRepositoryMiddleware) RetrieveByOwnerOrGroups(ctx context.Context, owner string, groupIDs []string, pm things.PageMetadata) (things.Page, error) {
+	span := createSpan(ctx, trm.tracer, retrieveThingsByOwnerOrGroupsOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return trm.repo.RetrieveByOwnerOrGroups(ctx, owner, groupIDs, pm)
+}
+
 func (trm thingRepositoryMiddleware) RetrieveByIDs(ctx context.Context, thingIDs []string, pm things.PageMetadata) (things.Page, error) {
 	span := createSpan(ctx, trm.tracer, retrieveThingsByIDsOp)
 	defer span.Finish()
@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package aes provides a cipher implementation utilizing AES-GCM.
+package aes
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var (
+	errEncrypt = errors.New("failed to encrypt secret")
+	errDecrypt = errors.New("failed to decrypt secret")
+)
+
+var _ users.Cipher = (*aesCipher)(nil)
+
+type aesCipher struct {
+	aead cipher.AEAD
+}
+
+// New instantiates an AES-256-GCM cipher. The encryption key is derived
+// from the given secret key.
+func New(key string) (users.Cipher, error) {
+	k := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &aesCipher{aead: aead}, nil
+}
+
+func (c *aesCipher) Encrypt(plain string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(errEncrypt, err)
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(plain), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *aesCipher) Decrypt(encrypted string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", errors.Wrap(errDecrypt, err)
+	}
+
+	size := c.aead.NonceSize()
+	if len(sealed) < size {
+		return "", errDecrypt
+	}
+
+	plain, err := c.aead.Open(nil, sealed[:size], sealed[size:], nil)
+	if err != nil {
+		return "", errors.Wrap(errDecrypt, err)
+	}
+
+	return string(plain), nil
+}
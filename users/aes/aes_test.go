@@ -0,0 +1,73 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package aes_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/mainflux/users/aes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestEncryptDecrypt(t *testing.T) {
+	c, err := aes.New("key")
+	require.Nil(t, err, fmt.Sprintf("unexpected cipher creation error: %s", err))
+	other, err := aes.New("other")
+	require.Nil(t, err, fmt.Sprintf("unexpected cipher creation error: %s", err))
+
+	encrypted, err := c.Encrypt(secret)
+	require.Nil(t, err, fmt.Sprintf("unexpected encryption error: %s", err))
+	assert.NotContains(t, encrypted, secret, "expected secret to be encrypted")
+
+	again, err := c.Encrypt(secret)
+	require.Nil(t, err, fmt.Sprintf("unexpected encryption error: %s", err))
+	assert.NotEqual(t, encrypted, again, "expected encryption to be randomized")
+
+	tampered := []byte(encrypted)
+	tampered[len(tampered)/2] ^= 1
+
+	cases := []struct {
+		desc      string
+		cipher    users.Cipher
+		encrypted string
+		plain     string
+		fails     bool
+	}{
+		{
+			desc:      "decrypt encrypted secret",
+			cipher:    c,
+			encrypted: encrypted,
+			plain:     secret,
+		},
+		{
+			desc:      "decrypt secret with different key",
+			cipher:    other,
+			encrypted: encrypted,
+			fails:     true,
+		},
+		{
+			desc:      "decrypt tampered secret",
+			cipher:    c,
+			encrypted: string(tampered),
+			fails:     true,
+		},
+		{
+			desc:      "decrypt malformed secret",
+			cipher:    c,
+			encrypted: "invalid",
+			fails:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		plain, err := tc.cipher.Decrypt(tc.encrypted)
+		assert.Equal(t, tc.fails, err != nil, fmt.Sprintf("%s: expected failure %t got %s\n", tc.desc, tc.fails, err))
+		assert.Equal(t, tc.plain, plain, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.plain, plain))
+	}
+}
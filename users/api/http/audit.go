@@ -0,0 +1,313 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !test
+
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
+	log "github.com/MainfluxLabs/mainflux/logger"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var _ users.Service = (*auditMiddleware)(nil)
+
+type auditMiddleware struct {
+	svc       users.Service
+	auth      mainflux.AuthServiceClient
+	publisher audit.Publisher
+	logger    log.Logger
+}
+
+// AuditMiddleware publishes the audit records of the successful operations
+// which change users.
+func AuditMiddleware(svc users.Service, auth mainflux.AuthServiceClient, publisher audit.Publisher, logger log.Logger) users.Service {
+	return &auditMiddleware{
+		svc:       svc,
+		auth:      auth,
+		publisher: publisher,
+		logger:    logger,
+	}
+}
+
+func (am *auditMiddleware) SelfRegister(ctx context.Context, user users.User, host string) (string, error) {
+	id, err := am.svc.SelfRegister(ctx, user, host)
+	if err != nil {
+		return id, err
+	}
+
+	user.ID = id
+	user.Status = users.PendingStatusKey
+	am.publish(ctx, id, audit.ActionCreate, id, nil, userFields(user))
+
+	return id, nil
+}
+
+func (am *auditMiddleware) VerifyEmail(ctx context.Context, token string) error {
+	if err := am.svc.VerifyEmail(ctx, token); err != nil {
+		return err
+	}
+
+	if id, ok := am.identify(ctx, token); ok {
+		before := map[string]interface{}{"status": users.PendingStatusKey}
+		after := map[string]interface{}{"status": users.EnabledStatusKey}
+		am.publish(ctx, id, audit.ActionUpdate, id, before, after)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) ResendVerification(ctx context.Context, email, host string) error {
+	return am.svc.ResendVerification(ctx, email, host)
+}
+
+func (am *auditMiddleware) Register(ctx context.Context, token string, user users.User) (string, error) {
+	id, err := am.svc.Register(ctx, token, user)
+	if err != nil {
+		return id, err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		user.ID = id
+		am.publish(ctx, actor, audit.ActionCreate, id, nil, userFields(user))
+	}
+
+	return id, nil
+}
+
+func (am *auditMiddleware) RegisterAdmin(ctx context.Context, user users.User) error {
+	return am.svc.RegisterAdmin(ctx, user)
+}
+
+func (am *auditMiddleware) Login(ctx context.Context, user users.User) (string, error) {
+	token, err := am.svc.Login(ctx, user)
+	am.publishLockout(ctx, user.Email, err)
+
+	return token, err
+}
+
+func (am *auditMiddleware) LoginTOTP(ctx context.Context, user users.User, code string) (string, error) {
+	token, err := am.svc.LoginTOTP(ctx, user, code)
+	am.publishLockout(ctx, user.Email, err)
+
+	return token, err
+}
+
+func (am *auditMiddleware) EnrollTOTP(ctx context.Context, token string) (users.TOTPKey, error) {
+	return am.svc.EnrollTOTP(ctx, token)
+}
+
+func (am *auditMiddleware) ActivateTOTP(ctx context.Context, token, code string) ([]string, error) {
+	codes, err := am.svc.ActivateTOTP(ctx, token, code)
+	if err != nil {
+		return codes, err
+	}
+
+	if id, ok := am.identify(ctx, token); ok {
+		am.publish(ctx, id, audit.ActionUpdate, id, map[string]interface{}{"totp": false}, map[string]interface{}{"totp": true})
+	}
+
+	return codes, nil
+}
+
+func (am *auditMiddleware) DeactivateTOTP(ctx context.Context, token, code string) error {
+	if err := am.svc.DeactivateTOTP(ctx, token, code); err != nil {
+		return err
+	}
+
+	if id, ok := am.identify(ctx, token); ok {
+		am.publish(ctx, id, audit.ActionUpdate, id, map[string]interface{}{"totp": true}, map[string]interface{}{"totp": false})
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) OAuthURL(ctx context.Context, provider string, session users.AuthSession) (string, error) {
+	return am.svc.OAuthURL(ctx, provider, session)
+}
+
+func (am *auditMiddleware) OAuthLogin(ctx context.Context, provider, code string, session users.AuthSession) (string, error) {
+	return am.svc.OAuthLogin(ctx, provider, code, session)
+}
+
+func (am *auditMiddleware) ViewUser(ctx context.Context, token, id string) (users.User, error) {
+	return am.svc.ViewUser(ctx, token, id)
+}
+
+func (am *auditMiddleware) ViewProfile(ctx context.Context, token string) (users.User, error) {
+	return am.svc.ViewProfile(ctx, token)
+}
+
+func (am *auditMiddleware) ListUsers(ctx context.Context, token string, pm users.PageMetadata) (users.UserPage, error) {
+	return am.svc.ListUsers(ctx, token, pm)
+}
+
+func (am *auditMiddleware) ListUsersByIDs(ctx context.Context, ids []string) (users.UserPage, error) {
+	return am.svc.ListUsersByIDs(ctx, ids)
+}
+
+func (am *auditMiddleware) ListUsersByEmails(ctx context.Context, emails []string) ([]users.User, error) {
+	return am.svc.ListUsersByEmails(ctx, emails)
+}
+
+func (am *auditMiddleware) UpdateUser(ctx context.Context, token string, user users.User) error {
+	before, _ := am.svc.ViewProfile(ctx, token)
+
+	if err := am.svc.UpdateUser(ctx, token, user); err != nil {
+		return err
+	}
+
+	if id, ok := am.identify(ctx, token); ok {
+		after := before
+		after.Metadata = user.Metadata
+		am.publish(ctx, id, audit.ActionUpdate, id, userFields(before), userFields(after))
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) GenerateResetToken(ctx context.Context, email, host string) error {
+	return am.svc.GenerateResetToken(ctx, email, host)
+}
+
+func (am *auditMiddleware) ChangePassword(ctx context.Context, authToken, password, oldPassword string) error {
+	if err := am.svc.ChangePassword(ctx, authToken, password, oldPassword); err != nil {
+		if errors.Contains(err, users.ErrAccountLocked) && errors.Contains(err, errors.ErrAuthentication) {
+			if id, ok := am.identify(ctx, authToken); ok {
+				am.publish(ctx, id, audit.ActionLock, id, nil, nil)
+			}
+		}
+		return err
+	}
+
+	if id, ok := am.identify(ctx, authToken); ok {
+		am.publish(ctx, id, audit.ActionPassword, id, nil, nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) ResetPassword(ctx context.Context, resetToken, password string) error {
+	if err := am.svc.ResetPassword(ctx, resetToken, password); err != nil {
+		return err
+	}
+
+	if id, ok := am.identify(ctx, resetToken); ok {
+		am.publish(ctx, id, audit.ActionPassword, id, nil, nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) SendPasswordReset(ctx context.Context, host, email, token string) error {
+	return am.svc.SendPasswordReset(ctx, host, email, token)
+}
+
+func (am *auditMiddleware) EnableUser(ctx context.Context, token, id string) error {
+	if err := am.svc.EnableUser(ctx, token, id); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		before := map[string]interface{}{"status": users.DisabledStatusKey}
+		after := map[string]interface{}{"status": users.EnabledStatusKey}
+		am.publish(ctx, actor, audit.ActionEnable, id, before, after)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) DisableUser(ctx context.Context, token, id string) error {
+	if err := am.svc.DisableUser(ctx, token, id); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		before := map[string]interface{}{"status": users.EnabledStatusKey}
+		after := map[string]interface{}{"status": users.DisabledStatusKey}
+		am.publish(ctx, actor, audit.ActionDisable, id, before, after)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) UnlockUser(ctx context.Context, token, id string) error {
+	if err := am.svc.UnlockUser(ctx, token, id); err != nil {
+		return err
+	}
+
+	if actor, ok := am.identify(ctx, token); ok {
+		am.publish(ctx, actor, audit.ActionUnlock, id, nil, nil)
+	}
+
+	return nil
+}
+
+func (am *auditMiddleware) Backup(ctx context.Context, token string) (users.User, []users.User, error) {
+	return am.svc.Backup(ctx, token)
+}
+
+func (am *auditMiddleware) Restore(ctx context.Context, token string, admin users.User, us []users.User) error {
+	return am.svc.Restore(ctx, token, admin, us)
+}
+
+func (am *auditMiddleware) identify(ctx context.Context, token string) (string, bool) {
+	res, err := am.auth.Identify(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to identify actor of user operation: %s", err))
+		return "", false
+	}
+
+	return res.GetId(), true
+}
+
+// publish publishes the record of the operation performed on the user.
+// Failures are logged, since the operation itself has already succeeded.
+func (am *auditMiddleware) publish(ctx context.Context, actor, action, id string, before, after map[string]interface{}) {
+	record := audit.NewRecord(ctx, actor, action, audit.EntityUser, id, before, after)
+	if err := am.publisher.Publish(ctx, record); err != nil {
+		am.logger.Warn(fmt.Sprintf("Failed to publish audit record of user %s: %s", action, err))
+	}
+}
+
+// publishLockout publishes the lockout of the user account. The failed login
+// attempt which locks the account returns ErrAccountLocked wrapping the
+// authentication error, while the attempts of the locked account return
+// ErrAccountLocked only.
+func (am *auditMiddleware) publishLockout(ctx context.Context, email string, err error) {
+	if !errors.Contains(err, users.ErrAccountLocked) || !errors.Contains(err, errors.ErrAuthentication) {
+		return
+	}
+
+	us, e := am.svc.ListUsersByEmails(ctx, []string{email})
+	if e != nil || len(us) == 0 {
+		return
+	}
+	am.publish(ctx, us[0].ID, audit.ActionLock, us[0].ID, nil, nil)
+}
+
+// Passwords of the users are never recorded.
+func userFields(u users.User) map[string]interface{} {
+	if u.ID == "" {
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"email":    u.Email,
+		"metadata": map[string]interface{}(u.Metadata),
+	}
+	if u.Status != "" {
+		fields["status"] = u.Status
+	}
+	if u.Role != "" {
+		fields["role"] = u.Role
+	}
+
+	return fields
+}
@@ -5,6 +5,10 @@ package http
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
 
 	"github.com/MainfluxLabs/mainflux/users"
 	"github.com/go-kit/kit/endpoint"
@@ -16,7 +20,7 @@ func selfRegistrationEndpoint(svc users.Service) endpoint.Endpoint {
 		if err := req.validate(); err != nil {
 			return createUserRes{}, err
 		}
-		uid, err := svc.SelfRegister(ctx, req.user)
+		uid, err := svc.SelfRegister(ctx, req.user, req.host)
 		if err != nil {
 			return createUserRes{}, err
 		}
@@ -29,6 +33,36 @@ func selfRegistrationEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func verifyEmailEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(verifyEmailReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.VerifyEmail(ctx, req.Token); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
+
+func resendVerificationEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(resendVerificationReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.ResendVerification(ctx, req.Email, req.host); err != nil {
+			return nil, err
+		}
+
+		return passwResetReqRes{Msg: VerificationSent}, nil
+	}
+}
+
 func registrationEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(registerUserReq)
@@ -199,6 +233,147 @@ func loginEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func loginTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(loginTOTPReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		user := users.User{
+			Email:    req.Email,
+			Password: req.Password,
+		}
+		token, err := svc.LoginTOTP(ctx, user, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{token}, nil
+	}
+}
+
+func enrollTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(enrollTOTPReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		key, err := svc.EnrollTOTP(ctx, req.token)
+		if err != nil {
+			return nil, err
+		}
+
+		return totpKeyRes{Secret: key.Secret, URI: key.URI}, nil
+	}
+}
+
+func activateTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(totpCodeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		codes, err := svc.ActivateTOTP(ctx, req.token, req.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		return recoveryCodesRes{RecoveryCodes: codes}, nil
+	}
+}
+
+func deactivateTOTPEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(totpCodeReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DeactivateTOTP(ctx, req.token, req.Code); err != nil {
+			return nil, err
+		}
+
+		return deleteRes{}, nil
+	}
+}
+
+func oauthURLEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(oauthURLReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		session, err := newSession()
+		if err != nil {
+			return nil, err
+		}
+
+		url, err := svc.OAuthURL(ctx, req.provider, session)
+		if err != nil {
+			return nil, err
+		}
+
+		// The session is bound to the browser, so the callback is
+		// accepted only if it completes the flow started here.
+		cookie := http.Cookie{
+			Name:     stateCookie,
+			Value:    strings.Join([]string{session.State, session.Nonce, session.Verifier}, sessionSep),
+			MaxAge:   stateCookieAge,
+			Secure:   req.secure,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		}
+
+		return oauthRedirectRes{url: url, cookie: cookie.String()}, nil
+	}
+}
+
+func oauthCallbackEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(oauthCallbackReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+
+		token, err := svc.OAuthLogin(ctx, req.provider, req.code, req.session)
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenRes{token}, nil
+	}
+}
+
+func newSession() (users.AuthSession, error) {
+	state, err := random(stateSize)
+	if err != nil {
+		return users.AuthSession{}, err
+	}
+	nonce, err := random(stateSize)
+	if err != nil {
+		return users.AuthSession{}, err
+	}
+	verifier, err := random(verifierSize)
+	if err != nil {
+		return users.AuthSession{}, err
+	}
+
+	return users.AuthSession{State: state, Nonce: nonce, Verifier: verifier}, nil
+}
+
+func random(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
 func enableUserEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(changeUserStatusReq)
@@ -212,6 +387,19 @@ func enableUserEndpoint(svc users.Service) endpoint.Endpoint {
 	}
 }
 
+func unlockUserEndpoint(svc users.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeUserStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, err
+		}
+		if err := svc.UnlockUser(ctx, req.token, req.id); err != nil {
+			return nil, err
+		}
+		return deleteRes{}, nil
+	}
+}
+
 func disableUserEndpoint(svc users.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(changeUserStatusReq)
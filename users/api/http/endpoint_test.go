@@ -15,6 +15,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
@@ -25,6 +26,7 @@ import (
 	"github.com/MainfluxLabs/mainflux/users"
 	httpapi "github.com/MainfluxLabs/mainflux/users/api/http"
 	usmocks "github.com/MainfluxLabs/mainflux/users/mocks"
+	"github.com/MainfluxLabs/mainflux/users/totp"
 	"github.com/opentracing/opentracing-go/mocktracer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,6 +42,9 @@ const (
 	invalidPass  = "wrong"
 	prefix       = "fe6b4e92-cc98-425e-b0aa-"
 	userNum      = 101
+	provider     = "idp"
+	userCode     = "user-code"
+	stateCookie  = "oauth_state"
 )
 
 var (
@@ -57,6 +62,10 @@ var (
 	missingEmailRes    = toJSON(apiutil.ErrorRes{Err: apiutil.ErrMissingEmail.Error()})
 	missingPassRes     = toJSON(apiutil.ErrorRes{Err: apiutil.ErrMissingPass.Error()})
 	invalidRestPassRes = toJSON(apiutil.ErrorRes{Err: apiutil.ErrInvalidResetPass.Error()})
+	totpRequiredRes    = toJSON(apiutil.ErrorRes{Err: users.ErrTOTPRequired.Error()})
+	totpEnabledRes     = toJSON(apiutil.ErrorRes{Err: users.ErrTOTPEnabled.Error()})
+	missingCodeRes     = toJSON(apiutil.ErrorRes{Err: "missing two-factor authentication code"})
+	emailVerifiedRes   = toJSON(apiutil.ErrorRes{Err: users.ErrEmailVerified.Error()})
 	idProvider         = uuid.New()
 	passRegex          = regexp.MustCompile("^.{8,}$")
 )
@@ -89,9 +98,12 @@ func (tr testRequest) make() (*http.Response, error) {
 func newService() users.Service {
 	usersRepo := usmocks.NewUserRepository(usersList)
 	hasher := usmocks.NewHasher()
+	cipher := usmocks.NewCipher()
 	auth := mocks.NewAuthService(admin.ID, usersList)
 	email := usmocks.NewEmailer()
-	return users.New(usersRepo, hasher, auth, email, idProvider, passRegex)
+	idp := usmocks.NewIdentityProvider(map[string]users.Identity{userCode: {Email: user.Email}})
+	providers := map[string]users.IdentityProvider{provider: idp}
+	return users.New(usersRepo, hasher, cipher, auth, email, idProvider, passRegex, providers, nil)
 }
 
 func newServer(svc users.Service) *httptest.Server {
@@ -148,6 +160,101 @@ func TestSelfRegister(t *testing.T) {
 	}
 }
 
+func TestVerifyEmail(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	_, err := svc.SelfRegister(context.Background(), newUser, "http://localhost")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Verification token of the mocked auth service is the user email.
+	data := toJSON(map[string]string{"token": newUser.Email})
+	invalidData := toJSON(map[string]string{"token": invalidToken})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+		res         string
+	}{
+		{"verify email with invalid token", invalidData, contentType, http.StatusUnauthorized, unauthRes},
+		{"verify email with empty JSON request", "{}", contentType, http.StatusUnauthorized, missingTokRes},
+		{"verify email with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes},
+		{"verify email with missing content type", data, "", http.StatusUnsupportedMediaType, unsupportedRes},
+		{"verify email", data, contentType, http.StatusNoContent, ""},
+		{"verify already verified email", data, contentType, http.StatusConflict, emailVerifiedRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/register/verify", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		resBody := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, resBody, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, resBody))
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	_, err := svc.SelfRegister(context.Background(), newUser, "http://localhost")
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	data := toJSON(map[string]string{"email": newUser.Email})
+	enabledData := toJSON(map[string]string{"email": user.Email})
+	sentRes := toJSON(struct {
+		Msg string `json:"msg"`
+	}{
+		httpapi.VerificationSent,
+	})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		status      int
+		res         string
+	}{
+		{"resend verification to pending user", data, contentType, http.StatusCreated, sentRes},
+		{"resend verification to enabled user", enabledData, contentType, http.StatusNotFound, notFoundRes},
+		{"resend verification with empty JSON request", "{}", contentType, http.StatusBadRequest, missingEmailRes},
+		{"resend verification with invalid request format", "{", contentType, http.StatusBadRequest, malformedRes},
+		{"resend verification with missing content type", data, "", http.StatusUnsupportedMediaType, unsupportedRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/register/resend", ts.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		resBody := strings.Trim(string(body), "\n")
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, resBody, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, resBody))
+	}
+}
+
 func TestRegister(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
@@ -257,6 +364,365 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestOAuthLogin(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	cases := []struct {
+		desc     string
+		provider string
+		status   int
+	}{
+		{"start login", provider, http.StatusFound},
+		{"start login with unknown provider", "unknown", http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/oauth/%s/login", ts.URL, tc.provider),
+		}
+		res, err := req.make()
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusFound {
+			continue
+		}
+
+		var session []string
+		for _, c := range res.Cookies() {
+			if c.Name == stateCookie {
+				session = strings.Split(c.Value, ".")
+				assert.True(t, c.HttpOnly, fmt.Sprintf("%s: expected HttpOnly state cookie", tc.desc))
+			}
+		}
+		require.Len(t, session, 3, fmt.Sprintf("%s: expected state cookie with state, nonce and code verifier", tc.desc))
+		loc := usmocks.AuthURL + "?state=" + session[0]
+		assert.Equal(t, loc, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, loc, res.Header.Get("Location")))
+	}
+}
+
+func TestOAuthCallback(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	const (
+		state   = "state"
+		session = state + ".nonce.verifier"
+	)
+	tokenData := toJSON(map[string]string{"token": user.Email})
+
+	cases := []struct {
+		desc     string
+		provider string
+		query    string
+		cookie   string
+		status   int
+		res      string
+	}{
+		{
+			desc:     "complete login",
+			provider: provider,
+			query:    fmt.Sprintf("code=%s&state=%s", userCode, state),
+			cookie:   session,
+			status:   http.StatusCreated,
+			res:      tokenData,
+		},
+		{
+			desc:     "complete login with wrong state",
+			provider: provider,
+			query:    fmt.Sprintf("code=%s&state=%s", userCode, "wrong"),
+			cookie:   session,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "complete login without state cookie",
+			provider: provider,
+			query:    fmt.Sprintf("code=%s&state=%s", userCode, state),
+			cookie:   "",
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "complete login without nonce and code verifier in cookie",
+			provider: provider,
+			query:    fmt.Sprintf("code=%s&state=%s", userCode, state),
+			cookie:   state,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "complete login denied by provider",
+			provider: provider,
+			query:    fmt.Sprintf("error=access_denied&state=%s", state),
+			cookie:   session,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "complete login without code",
+			provider: provider,
+			query:    fmt.Sprintf("state=%s", state),
+			cookie:   session,
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "complete login with invalid code",
+			provider: provider,
+			query:    fmt.Sprintf("code=%s&state=%s", "invalid", state),
+			cookie:   session,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "complete login with unknown provider",
+			provider: "unknown",
+			query:    fmt.Sprintf("code=%s&state=%s", userCode, state),
+			cookie:   session,
+			status:   http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/oauth/%s/callback?%s", ts.URL, tc.provider, tc.query), nil)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.cookie != "" {
+			req.AddCookie(&http.Cookie{Name: stateCookie, Value: tc.cookie})
+		}
+
+		res, err := client.Do(req)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if tc.res != "" {
+			body, err := ioutil.ReadAll(res.Body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			token := strings.Trim(string(body), "\n")
+			assert.Equal(t, tc.res, token, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, token))
+		}
+	}
+}
+
+func enableTOTP(t *testing.T, svc users.Service, token string) (string, []string) {
+	key, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP enrollment error: %s", err))
+	// Codes are single-use, so the code of the previous period is used
+	// in order to keep the current one valid.
+	code, err := totp.Code(key.Secret, time.Now().Add(-totp.Period))
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+	codes, err := svc.ActivateTOTP(context.Background(), token, code)
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP activation error: %s", err))
+
+	return key.Secret, codes
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	enableTOTP(t, svc, admin.Email)
+
+	cases := []struct {
+		desc   string
+		token  string
+		status int
+	}{
+		{"enroll TOTP", user.Email, http.StatusCreated},
+		{"enroll TOTP with TOTP enabled", admin.Email, http.StatusConflict},
+		{"enroll TOTP with invalid token", invalidToken, http.StatusUnauthorized},
+		{"enroll TOTP with empty token", "", http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client: client,
+			method: http.MethodPost,
+			url:    fmt.Sprintf("%s/users/totp", ts.URL),
+			token:  tc.token,
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		if res.StatusCode != http.StatusCreated {
+			continue
+		}
+
+		var key struct {
+			Secret string `json:"secret"`
+			URI    string `json:"uri"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&key)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.NotEmpty(t, key.Secret, fmt.Sprintf("%s: expected secret to be generated", tc.desc))
+		assert.True(t, strings.HasPrefix(key.URI, "otpauth://totp/"), fmt.Sprintf("%s: expected otpauth URI got %s", tc.desc, key.URI))
+	}
+}
+
+func TestActivateTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	key, err := svc.EnrollTOTP(context.Background(), user.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP enrollment error: %s", err))
+	code, err := totp.Code(key.Secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+
+	data := toJSON(map[string]string{"code": code})
+	invalidData := toJSON(map[string]string{"code": "000000x"})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+		res         string
+	}{
+		{"activate TOTP with invalid code", invalidData, contentType, user.Email, http.StatusUnauthorized, unauthRes},
+		{"activate TOTP with missing code", "{}", contentType, user.Email, http.StatusBadRequest, missingCodeRes},
+		{"activate TOTP with invalid request format", "{", contentType, user.Email, http.StatusBadRequest, malformedRes},
+		{"activate TOTP with missing content type", data, "", user.Email, http.StatusUnsupportedMediaType, unsupportedRes},
+		{"activate TOTP with invalid token", data, contentType, invalidToken, http.StatusUnauthorized, unauthRes},
+		{"activate TOTP with empty token", data, contentType, "", http.StatusUnauthorized, missingTokRes},
+		{"activate TOTP without enrollment", data, contentType, admin.Email, http.StatusNotFound, notFoundRes},
+		{"activate TOTP", data, contentType, user.Email, http.StatusOK, ""},
+		{"activate activated TOTP", data, contentType, user.Email, http.StatusConflict, totpEnabledRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/totp/activate", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+
+		if res.StatusCode == http.StatusOK {
+			var codes struct {
+				RecoveryCodes []string `json:"recovery_codes"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&codes)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Len(t, codes.RecoveryCodes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d", tc.desc, len(codes.RecoveryCodes)))
+			continue
+		}
+
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.res, strings.Trim(string(body), "\n"), fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, body))
+	}
+}
+
+func TestLoginTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	secret, recoveryCodes := enableTOTP(t, svc, user.Email)
+	code, err := totp.Code(secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+
+	tokenData := toJSON(map[string]string{"token": user.Email})
+	credentials := func(email, password, code string) string {
+		return toJSON(map[string]string{"email": email, "password": password, "code": code})
+	}
+
+	cases := []struct {
+		desc        string
+		url         string
+		req         string
+		contentType string
+		status      int
+		res         string
+	}{
+		{"login without TOTP code", "/tokens", toJSON(user), contentType, http.StatusUnauthorized, totpRequiredRes},
+		{"login with TOTP code", "/tokens/totp", credentials(user.Email, validPass, code), contentType, http.StatusCreated, tokenData},
+		{"login with recovery code", "/tokens/totp", credentials(user.Email, validPass, recoveryCodes[0]), contentType, http.StatusCreated, tokenData},
+		{"login with used recovery code", "/tokens/totp", credentials(user.Email, validPass, recoveryCodes[0]), contentType, http.StatusUnauthorized, unauthRes},
+		{"login with invalid TOTP code", "/tokens/totp", credentials(user.Email, validPass, "000000x"), contentType, http.StatusUnauthorized, unauthRes},
+		{"login with invalid password", "/tokens/totp", credentials(user.Email, invalidPass, code), contentType, http.StatusUnauthorized, unauthRes},
+		{"login with missing TOTP code", "/tokens/totp", credentials(user.Email, validPass, ""), contentType, http.StatusBadRequest, missingCodeRes},
+		{"login with invalid email address", "/tokens/totp", credentials(invalidEmail, validPass, code), contentType, http.StatusBadRequest, malformedRes},
+		{"login with TOTP disabled", "/tokens/totp", credentials(admin.Email, validPass, code), contentType, http.StatusUnauthorized, unauthRes},
+		{"login with invalid request format", "/tokens/totp", "{", contentType, http.StatusBadRequest, malformedRes},
+		{"login with missing content type", "/tokens/totp", credentials(user.Email, validPass, code), "", http.StatusUnsupportedMediaType, unsupportedRes},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s%s", ts.URL, tc.url),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		body, err := ioutil.ReadAll(res.Body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		token := strings.Trim(string(body), "\n")
+
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.Equal(t, tc.res, token, fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.res, token))
+	}
+}
+
+func TestDeactivateTOTP(t *testing.T) {
+	svc := newService()
+	ts := newServer(svc)
+	defer ts.Close()
+	client := ts.Client()
+
+	secret, _ := enableTOTP(t, svc, user.Email)
+	code, err := totp.Code(secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+
+	data := toJSON(map[string]string{"code": code})
+	invalidData := toJSON(map[string]string{"code": "000000x"})
+
+	cases := []struct {
+		desc        string
+		req         string
+		contentType string
+		token       string
+		status      int
+	}{
+		{"deactivate TOTP with invalid code", invalidData, contentType, user.Email, http.StatusUnauthorized},
+		{"deactivate TOTP with missing code", "{}", contentType, user.Email, http.StatusBadRequest},
+		{"deactivate TOTP with invalid token", data, contentType, invalidToken, http.StatusUnauthorized},
+		{"deactivate TOTP with missing content type", data, "", user.Email, http.StatusUnsupportedMediaType},
+		{"deactivate TOTP", data, contentType, user.Email, http.StatusNoContent},
+		{"deactivate deactivated TOTP", data, contentType, user.Email, http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			client:      client,
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/totp/deactivate", ts.URL),
+			contentType: tc.contentType,
+			token:       tc.token,
+			body:        strings.NewReader(tc.req),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+	}
+}
+
 func TestUser(t *testing.T) {
 	svc := newService()
 	ts := newServer(svc)
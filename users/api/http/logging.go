@@ -26,7 +26,7 @@ func LoggingMiddleware(svc users.Service, logger log.Logger) users.Service {
 	return &loggingMiddleware{logger, svc}
 }
 
-func (lm *loggingMiddleware) SelfRegister(ctx context.Context, user users.User) (uid string, err error) {
+func (lm *loggingMiddleware) SelfRegister(ctx context.Context, user users.User, host string) (uid string, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method self_register for user %s took %s to complete", user.Email, time.Since(begin))
 		if err != nil {
@@ -37,7 +37,33 @@ func (lm *loggingMiddleware) SelfRegister(ctx context.Context, user users.User)
 
 	}(time.Now())
 
-	return lm.svc.SelfRegister(ctx, user)
+	return lm.svc.SelfRegister(ctx, user, host)
+}
+
+func (lm *loggingMiddleware) VerifyEmail(ctx context.Context, token string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method verify_email took %s to complete", time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.VerifyEmail(ctx, token)
+}
+
+func (lm *loggingMiddleware) ResendVerification(ctx context.Context, email, host string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method resend_verification for user %s took %s to complete", email, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ResendVerification(ctx, email, host)
 }
 
 func (lm *loggingMiddleware) RegisterAdmin(ctx context.Context, user users.User) (err error) {
@@ -81,6 +107,84 @@ func (lm *loggingMiddleware) Login(ctx context.Context, user users.User) (token
 	return lm.svc.Login(ctx, user)
 }
 
+func (lm *loggingMiddleware) LoginTOTP(ctx context.Context, user users.User, code string) (token string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method login_totp for user %s took %s to complete", user.Email, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.LoginTOTP(ctx, user, code)
+}
+
+func (lm *loggingMiddleware) EnrollTOTP(ctx context.Context, token string) (key users.TOTPKey, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method enroll_totp for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.EnrollTOTP(ctx, token)
+}
+
+func (lm *loggingMiddleware) ActivateTOTP(ctx context.Context, token, code string) (codes []string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method activate_totp for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.ActivateTOTP(ctx, token, code)
+}
+
+func (lm *loggingMiddleware) DeactivateTOTP(ctx context.Context, token, code string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method deactivate_totp for token %s took %s to complete", token, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.DeactivateTOTP(ctx, token, code)
+}
+
+func (lm *loggingMiddleware) OAuthURL(ctx context.Context, provider string, session users.AuthSession) (url string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oauth_url for provider %s took %s to complete", provider, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OAuthURL(ctx, provider, session)
+}
+
+func (lm *loggingMiddleware) OAuthLogin(ctx context.Context, provider, code string, session users.AuthSession) (token string, err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method oauth_login for provider %s took %s to complete", provider, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.OAuthLogin(ctx, provider, code, session)
+}
+
 func (lm *loggingMiddleware) ViewUser(ctx context.Context, token, id string) (u users.User, err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method view_user for user %s took %s to complete", u.Email, time.Since(begin))
@@ -224,6 +328,19 @@ func (lm *loggingMiddleware) EnableUser(ctx context.Context, token string, id st
 	return lm.svc.EnableUser(ctx, token, id)
 }
 
+func (lm *loggingMiddleware) UnlockUser(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		message := fmt.Sprintf("Method unlock_user for user %s took %s to complete", id, time.Since(begin))
+		if err != nil {
+			lm.logger.Warn(fmt.Sprintf("%s with error: %s.", message, err))
+			return
+		}
+		lm.logger.Info(fmt.Sprintf("%s without errors.", message))
+	}(time.Now())
+
+	return lm.svc.UnlockUser(ctx, token, id)
+}
+
 func (lm *loggingMiddleware) DisableUser(ctx context.Context, token string, id string) (err error) {
 	defer func(begin time.Time) {
 		message := fmt.Sprintf("Method disable_user for user %s took %s to complete", id, time.Since(begin))
@@ -30,13 +30,31 @@ func MetricsMiddleware(svc users.Service, counter metrics.Counter, latency metri
 	}
 }
 
-func (ms *metricsMiddleware) SelfRegister(ctx context.Context, user users.User) (string, error) {
+func (ms *metricsMiddleware) SelfRegister(ctx context.Context, user users.User, host string) (string, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "self_register").Add(1)
 		ms.latency.With("method", "self_register").Observe(time.Since(begin).Seconds())
 	}(time.Now())
 
-	return ms.svc.SelfRegister(ctx, user)
+	return ms.svc.SelfRegister(ctx, user, host)
+}
+
+func (ms *metricsMiddleware) VerifyEmail(ctx context.Context, token string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_email").Add(1)
+		ms.latency.With("method", "verify_email").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.VerifyEmail(ctx, token)
+}
+
+func (ms *metricsMiddleware) ResendVerification(ctx context.Context, email, host string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "resend_verification").Add(1)
+		ms.latency.With("method", "resend_verification").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ResendVerification(ctx, email, host)
 }
 
 func (ms *metricsMiddleware) RegisterAdmin(ctx context.Context, user users.User) error {
@@ -66,6 +84,60 @@ func (ms *metricsMiddleware) Login(ctx context.Context, user users.User) (string
 	return ms.svc.Login(ctx, user)
 }
 
+func (ms *metricsMiddleware) LoginTOTP(ctx context.Context, user users.User, code string) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "login_totp").Add(1)
+		ms.latency.With("method", "login_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.LoginTOTP(ctx, user, code)
+}
+
+func (ms *metricsMiddleware) EnrollTOTP(ctx context.Context, token string) (users.TOTPKey, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enroll_totp").Add(1)
+		ms.latency.With("method", "enroll_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.EnrollTOTP(ctx, token)
+}
+
+func (ms *metricsMiddleware) ActivateTOTP(ctx context.Context, token, code string) ([]string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "activate_totp").Add(1)
+		ms.latency.With("method", "activate_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ActivateTOTP(ctx, token, code)
+}
+
+func (ms *metricsMiddleware) DeactivateTOTP(ctx context.Context, token, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "deactivate_totp").Add(1)
+		ms.latency.With("method", "deactivate_totp").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.DeactivateTOTP(ctx, token, code)
+}
+
+func (ms *metricsMiddleware) OAuthURL(ctx context.Context, provider string, session users.AuthSession) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oauth_url").Add(1)
+		ms.latency.With("method", "oauth_url").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OAuthURL(ctx, provider, session)
+}
+
+func (ms *metricsMiddleware) OAuthLogin(ctx context.Context, provider, code string, session users.AuthSession) (string, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "oauth_login").Add(1)
+		ms.latency.With("method", "oauth_login").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.OAuthLogin(ctx, provider, code, session)
+}
+
 func (ms *metricsMiddleware) ViewUser(ctx context.Context, token, id string) (users.User, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "view_user").Add(1)
@@ -165,6 +237,15 @@ func (ms *metricsMiddleware) EnableUser(ctx context.Context, token string, id st
 	return ms.svc.EnableUser(ctx, token, id)
 }
 
+func (ms *metricsMiddleware) UnlockUser(ctx context.Context, token, id string) (err error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unlock_user").Add(1)
+		ms.latency.With("method", "unlock_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.UnlockUser(ctx, token, id)
+}
+
 func (ms *metricsMiddleware) DisableUser(ctx context.Context, token string, id string) (err error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "disable_user").Add(1)
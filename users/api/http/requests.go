@@ -14,6 +14,11 @@ const (
 	maxEmailSize = 1024
 )
 
+var (
+	errInvalidState = errors.New("invalid OAuth state")
+	errMissingCode  = errors.New("missing two-factor authentication code")
+)
+
 type userReq struct {
 	user users.User
 }
@@ -22,14 +27,132 @@ func (req userReq) validate() error {
 	return req.user.Validate()
 }
 
+type loginTOTPReq struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+func (req loginTOTPReq) validate() error {
+	if err := (users.User{Email: req.Email}).Validate(); err != nil {
+		return err
+	}
+
+	if req.Code == "" {
+		return errMissingCode
+	}
+
+	return nil
+}
+
+type enrollTOTPReq struct {
+	token string
+}
+
+func (req enrollTOTPReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type totpCodeReq struct {
+	token string
+	Code  string `json:"code"`
+}
+
+func (req totpCodeReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	if req.Code == "" {
+		return errMissingCode
+	}
+
+	return nil
+}
+
+type oauthURLReq struct {
+	provider string
+	secure   bool
+}
+
+func (req oauthURLReq) validate() error {
+	if req.provider == "" {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
+type oauthCallbackReq struct {
+	provider string
+	code     string
+	state    string
+	session  users.AuthSession
+	errCode  string
+}
+
+func (req oauthCallbackReq) validate() error {
+	if req.errCode != "" {
+		return errors.Wrap(errors.ErrAuthentication, errors.New(req.errCode))
+	}
+
+	if req.state == "" || req.state != req.session.State {
+		return errors.Wrap(errors.ErrAuthentication, errInvalidState)
+	}
+
+	if req.provider == "" || req.code == "" {
+		return apiutil.ErrMalformedEntity
+	}
+
+	return nil
+}
+
 type selfRegisterUserReq struct {
 	user users.User
+	host string
 }
 
 func (req selfRegisterUserReq) validate() error {
+	if req.host == "" {
+		return apiutil.ErrMissingHost
+	}
+
 	return req.user.Validate()
 }
 
+type verifyEmailReq struct {
+	Token string `json:"token"`
+}
+
+func (req verifyEmailReq) validate() error {
+	if req.Token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type resendVerificationReq struct {
+	Email string `json:"email"`
+	host  string
+}
+
+func (req resendVerificationReq) validate() error {
+	if req.Email == "" {
+		return apiutil.ErrMissingEmail
+	}
+
+	if req.host == "" {
+		return apiutil.ErrMissingHost
+	}
+
+	return nil
+}
+
 type registerUserReq struct {
 	user  users.User
 	token string
@@ -77,7 +200,8 @@ func (req listUsersReq) validate() error {
 	}
 	if req.status != users.AllStatusKey &&
 		req.status != users.EnabledStatusKey &&
-		req.status != users.DisabledStatusKey {
+		req.status != users.DisabledStatusKey &&
+		req.status != users.PendingStatusKey {
 		return apiutil.ErrInvalidStatus
 	}
 
@@ -16,10 +16,18 @@ var (
 	_ mainflux.Response = (*passwChangeRes)(nil)
 	_ mainflux.Response = (*createUserRes)(nil)
 	_ mainflux.Response = (*deleteRes)(nil)
+	_ mainflux.Response = (*oauthRedirectRes)(nil)
+	_ mainflux.Response = (*totpKeyRes)(nil)
+	_ mainflux.Response = (*recoveryCodesRes)(nil)
 )
 
-// MailSent message response when link is sent
-const MailSent = "Email with reset link is sent"
+const (
+	// MailSent message response when link is sent
+	MailSent = "Email with reset link is sent"
+
+	// VerificationSent message response when verification link is sent
+	VerificationSent = "Email with verification link is sent"
+)
 
 type pageRes struct {
 	Total  uint64 `json:"total"`
@@ -70,6 +78,59 @@ func (res tokenRes) Empty() bool {
 	return res.Token == ""
 }
 
+type totpKeyRes struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+func (res totpKeyRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res totpKeyRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res totpKeyRes) Empty() bool {
+	return false
+}
+
+type recoveryCodesRes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (res recoveryCodesRes) Code() int {
+	return http.StatusOK
+}
+
+func (res recoveryCodesRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res recoveryCodesRes) Empty() bool {
+	return false
+}
+
+type oauthRedirectRes struct {
+	url    string
+	cookie string
+}
+
+func (res oauthRedirectRes) Code() int {
+	return http.StatusFound
+}
+
+func (res oauthRedirectRes) Headers() map[string]string {
+	return map[string]string{
+		"Location":   res.url,
+		"Set-Cookie": res.cookie,
+	}
+}
+
+func (res oauthRedirectRes) Empty() bool {
+	return true
+}
+
 type updateUserRes struct{}
 
 func (res updateUserRes) Code() int {
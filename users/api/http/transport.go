@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/logger"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -29,14 +30,24 @@ const (
 	emailKey    = "email"
 	metadataKey = "metadata"
 	statusKey   = "status"
+	codeKey     = "code"
+	stateKey    = "state"
+	errorKey    = "error"
 	defOffset   = 0
 	defLimit    = 10
+
+	stateCookie    = "oauth_state"
+	stateCookieAge = 600
+	stateSize      = 16
+	verifierSize   = 32
+	sessionSep     = "."
 )
 
 // MakeHandler returns a HTTP handler for API endpoints.
 func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Logger) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, encodeError)),
+		kithttp.ServerBefore(audit.PopulateSourceIP),
 	}
 
 	mux := bone.New()
@@ -55,6 +66,20 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
+	mux.Post("/register/verify", kithttp.NewServer(
+		kitot.TraceServer(tracer, "verify_email")(verifyEmailEndpoint(svc)),
+		decodeVerifyEmail,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/register/resend", kithttp.NewServer(
+		kitot.TraceServer(tracer, "resend_verification")(resendVerificationEndpoint(svc)),
+		decodeResendVerification,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/users/profile", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_profile")(viewProfileEndpoint(svc)),
 		decodeViewProfile,
@@ -62,6 +87,27 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
+	mux.Post("/users/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "enroll_totp")(enrollTOTPEndpoint(svc)),
+		decodeEnrollTOTP,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/totp/activate", kithttp.NewServer(
+		kitot.TraceServer(tracer, "activate_totp")(activateTOTPEndpoint(svc)),
+		decodeTOTPCode,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Post("/users/totp/deactivate", kithttp.NewServer(
+		kitot.TraceServer(tracer, "deactivate_totp")(deactivateTOTPEndpoint(svc)),
+		decodeTOTPCode,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/users/:id", kithttp.NewServer(
 		kitot.TraceServer(tracer, "view_user")(viewUserEndpoint(svc)),
 		decodeViewUser,
@@ -111,6 +157,27 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
+	mux.Post("/tokens/totp", kithttp.NewServer(
+		kitot.TraceServer(tracer, "login_totp")(loginTOTPEndpoint(svc)),
+		decodeTOTPCredentials,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/oauth/:provider/login", kithttp.NewServer(
+		kitot.TraceServer(tracer, "oauth_url")(oauthURLEndpoint(svc)),
+		decodeOAuthURL,
+		encodeResponse,
+		opts...,
+	))
+
+	mux.Get("/oauth/:provider/callback", kithttp.NewServer(
+		kitot.TraceServer(tracer, "oauth_login")(oauthCallbackEndpoint(svc)),
+		decodeOAuthCallback,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Post("/users/:id/enable", kithttp.NewServer(
 		kitot.TraceServer(tracer, "enable_user")(enableUserEndpoint(svc)),
 		decodeChangeUserStatus,
@@ -125,6 +192,13 @@ func MakeHandler(svc users.Service, tracer opentracing.Tracer, logger logger.Log
 		opts...,
 	))
 
+	mux.Post("/users/:id/unlock", kithttp.NewServer(
+		kitot.TraceServer(tracer, "unlock_user")(unlockUserEndpoint(svc)),
+		decodeChangeUserStatus,
+		encodeResponse,
+		opts...,
+	))
+
 	mux.Get("/backup", kithttp.NewServer(
 		kitot.TraceServer(tracer, "backup")(backupEndpoint(svc)),
 		decodeBackup,
@@ -218,6 +292,67 @@ func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error)
 	return userReq{user}, nil
 }
 
+func decodeTOTPCredentials(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req loginTOTPReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+	req.Email = strings.TrimSpace(req.Email)
+
+	return req, nil
+}
+
+func decodeEnrollTOTP(_ context.Context, r *http.Request) (interface{}, error) {
+	req := enrollTOTPReq{token: apiutil.ExtractBearerToken(r)}
+
+	return req, nil
+}
+
+func decodeTOTPCode(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	req := totpCodeReq{token: apiutil.ExtractBearerToken(r)}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeOAuthURL(_ context.Context, r *http.Request) (interface{}, error) {
+	req := oauthURLReq{
+		provider: bone.GetValue(r, "provider"),
+		secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+	}
+
+	return req, nil
+}
+
+func decodeOAuthCallback(_ context.Context, r *http.Request) (interface{}, error) {
+	q := r.URL.Query()
+	req := oauthCallbackReq{
+		provider: bone.GetValue(r, "provider"),
+		code:     q.Get(codeKey),
+		state:    q.Get(stateKey),
+		errCode:  q.Get(errorKey),
+	}
+	// The cookie contains the state, the nonce and the code verifier
+	// of the session.
+	if c, err := r.Cookie(stateCookie); err == nil {
+		if parts := strings.Split(c.Value, sessionSep); len(parts) == 3 {
+			req.session = users.AuthSession{State: parts[0], Nonce: parts[1], Verifier: parts[2]}
+		}
+	}
+
+	return req, nil
+}
+
 func decodeCreateUserReq(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
 		return nil, apiutil.ErrUnsupportedContentType
@@ -247,7 +382,39 @@ func decodeSelfRegisterUserReq(_ context.Context, r *http.Request) (interface{},
 		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
 	}
 
-	return selfRegisterUserReq{user: user}, nil
+	req := selfRegisterUserReq{
+		user: user,
+		host: r.Header.Get("Referer"),
+	}
+
+	return req, nil
+}
+
+func decodeVerifyEmail(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req verifyEmailReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	return req, nil
+}
+
+func decodeResendVerification(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), contentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req resendVerificationReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrMalformedEntity, err)
+	}
+
+	req.host = r.Header.Get("Referer")
+	return req, nil
 }
 
 func decodePasswordResetRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -337,9 +504,12 @@ func encodeResponse(_ context.Context, w http.ResponseWriter, response interface
 
 func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 	switch {
+	case errors.Contains(err, users.ErrAccountLocked):
+		w.WriteHeader(http.StatusTooManyRequests)
 	case errors.Contains(err, apiutil.ErrInvalidQueryParams),
 		errors.Contains(err, apiutil.ErrMalformedEntity),
 		errors.Contains(err, users.ErrPasswordFormat),
+		err == errMissingCode,
 		err == apiutil.ErrMissingEmail,
 		err == apiutil.ErrMissingHost,
 		err == apiutil.ErrMissingPass,
@@ -349,20 +519,24 @@ func encodeError(_ context.Context, err error, w http.ResponseWriter) {
 		err == apiutil.ErrInvalidResetPass:
 		w.WriteHeader(http.StatusBadRequest)
 	case errors.Contains(err, errors.ErrAuthentication),
+		err == users.ErrTOTPRequired,
 		err == apiutil.ErrBearerToken:
 		w.WriteHeader(http.StatusUnauthorized)
 	case errors.Contains(err, errors.ErrAuthorization):
 		w.WriteHeader(http.StatusForbidden)
 	case errors.Contains(err, errors.ErrConflict),
-		errors.Contains(err, errors.ErrConflict):
+		errors.Contains(err, users.ErrTOTPEnabled),
+		err == users.ErrEmailVerified:
 		w.WriteHeader(http.StatusConflict)
 	case errors.Contains(err, apiutil.ErrUnsupportedContentType):
 		w.WriteHeader(http.StatusUnsupportedMediaType)
-	case errors.Contains(err, errors.ErrNotFound):
+	case errors.Contains(err, errors.ErrNotFound),
+		errors.Contains(err, users.ErrUnknownProvider):
 		w.WriteHeader(http.StatusNotFound)
 
 	case errors.Contains(err, uuid.ErrGeneratingID),
-		errors.Contains(err, users.ErrRecoveryToken):
+		errors.Contains(err, users.ErrRecoveryToken),
+		errors.Contains(err, users.ErrVerificationToken):
 		w.WriteHeader(http.StatusInternalServerError)
 
 	case errors.Contains(err, errors.ErrCreateEntity),
@@ -0,0 +1,167 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package argon2 provides a hasher implementation utilizing argon2id.
+package argon2
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/mainflux/users/bcrypt"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	prefix  = "$argon2id$"
+	saltLen = 16
+	keyLen  = 32
+
+	// The parameters are bounded, so that neither the configuration nor the
+	// stored hashes can make the verification exhaust the resources.
+	maxTime    = 32
+	maxMemory  = 1 << 20
+	maxThreads = 64
+	maxKeyLen  = 64
+)
+
+var (
+	// ErrInvalidConfig indicates the argon2id parameters out of bounds.
+	ErrInvalidConfig = errors.New("invalid argon2id parameters")
+
+	errHashPassword    = errors.New("Generate hash from password failed")
+	errComparePassword = errors.New("Compare hash and password failed")
+	errInvalidHash     = errors.New("invalid argon2id hash")
+)
+
+var encoding = base64.RawStdEncoding
+
+// Config contains the argon2id parameters.
+type Config struct {
+	// Time is the number of passes over the memory.
+	Time uint32
+
+	// Memory is the size of the memory in KiB, at least 8 KiB per thread.
+	Memory uint32
+
+	// Threads is the number of the lanes used in parallel.
+	Threads uint8
+}
+
+var _ users.Hasher = (*argon2Hasher)(nil)
+
+type argon2Hasher struct {
+	cfg    Config
+	legacy users.Hasher
+}
+
+// New instantiates an argon2id-based hasher implementation. The hashes are
+// encoded in the PHC string format, along with their parameters, so the
+// hashes generated with different parameters are still verified. The bcrypt
+// hashes are verified as well, and reported as the ones needing rehash.
+func New(cfg Config) (users.Hasher, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return &argon2Hasher{
+		cfg:    cfg,
+		legacy: bcrypt.New(),
+	}, nil
+}
+
+func (cfg Config) validate() error {
+	if cfg.Time < 1 || cfg.Time > maxTime ||
+		cfg.Threads < 1 || cfg.Threads > maxThreads ||
+		cfg.Memory < 8*uint32(cfg.Threads) || cfg.Memory > maxMemory {
+		return ErrInvalidConfig
+	}
+	return nil
+}
+
+func (ah *argon2Hasher) Hash(pwd string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(errHashPassword, err)
+	}
+
+	key := argon2.IDKey([]byte(pwd), salt, ah.cfg.Time, ah.cfg.Memory, ah.cfg.Threads, keyLen)
+	h := hash{cfg: ah.cfg, salt: salt, key: key}
+
+	return h.String(), nil
+}
+
+func (ah *argon2Hasher) Compare(plain, hashed string) error {
+	if !strings.HasPrefix(hashed, prefix) {
+		return ah.legacy.Compare(plain, hashed)
+	}
+
+	h, err := parse(hashed)
+	if err != nil {
+		return errors.Wrap(errComparePassword, err)
+	}
+
+	key := argon2.IDKey([]byte(plain), h.salt, h.cfg.Time, h.cfg.Memory, h.cfg.Threads, uint32(len(h.key)))
+	if subtle.ConstantTimeCompare(key, h.key) != 1 {
+		return errComparePassword
+	}
+
+	return nil
+}
+
+func (ah *argon2Hasher) NeedsRehash(hashed string) bool {
+	h, err := parse(hashed)
+	if err != nil {
+		return true
+	}
+
+	return h.cfg != ah.cfg || len(h.key) != keyLen
+}
+
+type hash struct {
+	cfg  Config
+	salt []byte
+	key  []byte
+}
+
+// String returns the hash in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>
+func (h hash) String() string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", prefix, argon2.Version, h.cfg.Memory, h.cfg.Time, h.cfg.Threads,
+		encoding.EncodeToString(h.salt), encoding.EncodeToString(h.key))
+}
+
+func parse(hashed string) (hash, error) {
+	parts := strings.Split(strings.TrimPrefix(hashed, prefix), "$")
+	if !strings.HasPrefix(hashed, prefix) || len(parts) != 4 {
+		return hash{}, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return hash{}, errInvalidHash
+	}
+
+	var h hash
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &h.cfg.Memory, &h.cfg.Time, &h.cfg.Threads); err != nil {
+		return hash{}, errors.Wrap(errInvalidHash, err)
+	}
+	if err := h.cfg.validate(); err != nil {
+		return hash{}, errors.Wrap(errInvalidHash, err)
+	}
+
+	var err error
+	if h.salt, err = encoding.DecodeString(parts[2]); err != nil {
+		return hash{}, errors.Wrap(errInvalidHash, err)
+	}
+	if h.key, err = encoding.DecodeString(parts[3]); err != nil || len(h.key) == 0 || len(h.key) > maxKeyLen {
+		return hash{}, errInvalidHash
+	}
+
+	return h, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package argon2_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/mainflux/users/argon2"
+	"github.com/MainfluxLabs/mainflux/users/bcrypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const password = "password"
+
+var cfg = argon2.Config{Time: 1, Memory: 1024, Threads: 1}
+
+func newHasher(t *testing.T, cfg argon2.Config) users.Hasher {
+	hasher, err := argon2.New(cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	return hasher
+}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  argon2.Config
+		err  error
+	}{
+		{desc: "create hasher", cfg: cfg, err: nil},
+		{desc: "create hasher without passes", cfg: argon2.Config{Time: 0, Memory: 1024, Threads: 1}, err: argon2.ErrInvalidConfig},
+		{desc: "create hasher without threads", cfg: argon2.Config{Time: 1, Memory: 1024, Threads: 0}, err: argon2.ErrInvalidConfig},
+		{desc: "create hasher with too little memory", cfg: argon2.Config{Time: 1, Memory: 8, Threads: 2}, err: argon2.ErrInvalidConfig},
+		{desc: "create hasher with too much memory", cfg: argon2.Config{Time: 1, Memory: 1<<20 + 1, Threads: 1}, err: argon2.ErrInvalidConfig},
+		{desc: "create hasher with too many passes", cfg: argon2.Config{Time: 33, Memory: 1024, Threads: 1}, err: argon2.ErrInvalidConfig},
+	}
+
+	for _, tc := range cases {
+		_, err := argon2.New(tc.cfg)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestHash(t *testing.T) {
+	hasher := newHasher(t, cfg)
+
+	hash, err := hasher.Hash(password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"), fmt.Sprintf("expected hash to encode algorithm and parameters got %s", hash))
+
+	other, err := hasher.Hash(password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.NotEqual(t, hash, other, "expected hashes of the same password to be salted differently")
+}
+
+func TestCompare(t *testing.T) {
+	hasher := newHasher(t, cfg)
+
+	hash, err := hasher.Hash(password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	weaker, err := newHasher(t, argon2.Config{Time: 1, Memory: 512, Threads: 1}).Hash(password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	legacy, err := bcrypt.New().Hash(password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		plain  string
+		hashed string
+		err    bool
+	}{
+		{desc: "compare valid password", plain: password, hashed: hash, err: false},
+		{desc: "compare wrong password", plain: "wrong", hashed: hash, err: true},
+		{desc: "compare password hashed with different parameters", plain: password, hashed: weaker, err: false},
+		{desc: "compare password hashed with bcrypt", plain: password, hashed: legacy, err: false},
+		{desc: "compare wrong password hashed with bcrypt", plain: "wrong", hashed: legacy, err: true},
+		{desc: "compare malformed hash", plain: password, hashed: "$argon2id$v=19$m=1024,t=1$salt$key", err: true},
+		{desc: "compare hash of unsupported version", plain: password, hashed: strings.Replace(hash, "v=19", "v=16", 1), err: true},
+		{desc: "compare hash without passes", plain: password, hashed: strings.Replace(hash, "t=1", "t=0", 1), err: true},
+		{desc: "compare hash without threads", plain: password, hashed: strings.Replace(hash, "p=1", "p=0", 1), err: true},
+		{desc: "compare hash with too much memory", plain: password, hashed: strings.Replace(hash, "m=1024", "m=4294967295", 1), err: true},
+		{desc: "compare hash with too many passes", plain: password, hashed: strings.Replace(hash, "t=1", "t=4294967295", 1), err: true},
+	}
+
+	for _, tc := range cases {
+		err := hasher.Compare(tc.plain, tc.hashed)
+		assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	hasher := newHasher(t, cfg)
+
+	hash, err := hasher.Hash(password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	weaker, err := newHasher(t, argon2.Config{Time: 1, Memory: 512, Threads: 1}).Hash(password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	legacy, err := bcrypt.New().Hash(password)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		hashed string
+		rehash bool
+	}{
+		{desc: "hash with current parameters", hashed: hash, rehash: false},
+		{desc: "hash with different parameters", hashed: weaker, rehash: true},
+		{desc: "bcrypt hash", hashed: legacy, rehash: true},
+	}
+
+	for _, tc := range cases {
+		rehash := hasher.NeedsRehash(tc.hashed)
+		assert.Equal(t, tc.rehash, rehash, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.rehash, rehash))
+	}
+}
@@ -42,3 +42,8 @@ func (bh *bcryptHasher) Compare(plain, hashed string) error {
 	}
 	return nil
 }
+
+func (bh *bcryptHasher) NeedsRehash(hashed string) bool {
+	c, err := bcrypt.Cost([]byte(hashed))
+	return err != nil || c != cost
+}
@@ -0,0 +1,15 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+// Cipher specifies an API for encrypting the secrets stored in the users
+// repository.
+type Cipher interface {
+	// Encrypt encrypts the plain-text secret.
+	Encrypt(string) (string, error)
+
+	// Decrypt decrypts the secret encrypted by Encrypt. An error should
+	// indicate tampered or otherwise invalid ciphertext.
+	Decrypt(string) (string, error)
+}
@@ -0,0 +1,36 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/logger"
+)
+
+// StartCleanup removes the pending accounts which weren't verified within
+// the given period once per interval, until the context is canceled. This
+// frees the emails of the accounts registered on someone else's behalf.
+func StartCleanup(ctx context.Context, users UserRepository, period, interval time.Duration, logger logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := users.RemoveUnverified(ctx, now.Add(-period))
+			if err != nil {
+				logger.Warn(fmt.Sprintf("Failed to remove unverified users: %s", err))
+				continue
+			}
+			if n > 0 {
+				logger.Info(fmt.Sprintf("Removed %d unverified users", n))
+			}
+		}
+	}
+}
@@ -6,4 +6,6 @@ package users
 // Emailer wrapper around the email
 type Emailer interface {
 	SendPasswordReset(To []string, host, token string) error
+
+	SendVerification(To []string, host, token string) error
 }
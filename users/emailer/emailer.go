@@ -12,17 +12,23 @@ import (
 var _ users.Emailer = (*emailer)(nil)
 
 type emailer struct {
-	resetURL string
-	agent    *email.Agent
+	resetURL  string
+	verifyURL string
+	agent     *email.Agent
 }
 
 // New creates new emailer utility
-func New(url string, c *email.Config) (users.Emailer, error) {
+func New(resetURL, verifyURL string, c *email.Config) (users.Emailer, error) {
 	e, err := email.New(c)
-	return &emailer{resetURL: url, agent: e}, err
+	return &emailer{resetURL: resetURL, verifyURL: verifyURL, agent: e}, err
 }
 
 func (e *emailer) SendPasswordReset(To []string, host string, token string) error {
 	url := fmt.Sprintf("%s%s?token=%s", host, e.resetURL, token)
 	return e.agent.Send(To, "", "Password reset", "", url, "")
 }
+
+func (e *emailer) SendVerification(To []string, host string, token string) error {
+	url := fmt.Sprintf("%s%s?token=%s", host, e.verifyURL, token)
+	return e.agent.Send(To, "", "Email verification", "", url, "")
+}
@@ -12,4 +12,9 @@ type Hasher interface {
 	// Compare compares plain-text version to the hashed one. An error should
 	// indicate failed comparison.
 	Compare(string, string) error
+
+	// NeedsRehash reports whether the hashed string was generated using a
+	// different algorithm or parameters than the ones the hasher uses, so
+	// it should be replaced with a new hash.
+	NeedsRehash(string) bool
 }
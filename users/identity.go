@@ -0,0 +1,46 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import "context"
+
+// Identity represents the user identity asserted by the identity provider.
+type Identity struct {
+	// Email is the verified email of the user.
+	Email string
+
+	// Orgs are IDs of the orgs mapped to the identity provider groups
+	// the user is a member of.
+	Orgs []string
+
+	// MFA indicates that the user is authenticated with multiple factors
+	// by the identity provider.
+	MFA bool
+}
+
+// AuthSession contains the random values binding the authorization code
+// flow to the browser session which started it.
+type AuthSession struct {
+	// State is returned to the callback, which verifies it against the session.
+	State string
+
+	// Nonce is embedded in the ID token by the provider.
+	Nonce string
+
+	// Verifier is the PKCE code verifier, which is sent to the provider in
+	// order to redeem the authorization code.
+	Verifier string
+}
+
+// IdentityProvider specifies an API of the external OpenID Connect
+// identity provider used for the single sign-on.
+type IdentityProvider interface {
+	// AuthCodeURL returns the URL of the provider login page, which redirects
+	// back to the callback with the authorization code and the session state.
+	AuthCodeURL(session AuthSession) string
+
+	// Exchange exchanges the authorization code issued within the session
+	// for the verified user identity.
+	Exchange(ctx context.Context, code string, session AuthSession) (Identity, error)
+}
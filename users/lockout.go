@@ -0,0 +1,127 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import (
+	"context"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/audit"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+)
+
+const (
+	accountPrefix       = "account:"
+	ipPrefix            = "ip:"
+	resetAccountPrefix  = "reset:account:"
+	resetIPPrefix       = "reset:ip:"
+	verifyAccountPrefix = "verify:account:"
+	verifyIPPrefix      = "verify:ip:"
+)
+
+// Lockout specifies the API used for tracking the failed attempts and the
+// lockouts of the accounts and the source IP addresses. The keys identify
+// both the tracked subject and the kind of the attempt.
+type Lockout interface {
+	// Locked returns the remaining lockout period of the key, or zero if
+	// the key isn't locked.
+	Locked(ctx context.Context, key string) (time.Duration, error)
+
+	// Fail records the failed attempt of the key. Once the number of the
+	// failed attempts reaches the limit, the key is locked and the lockout
+	// period is returned. Each subsequent lockout of the key lasts twice
+	// as long as the previous one, up to the maximum period.
+	Fail(ctx context.Context, key string) (time.Duration, error)
+
+	// Reset removes the failed attempts and the lockout of the key.
+	Reset(ctx context.Context, key string) error
+}
+
+// checkLockout returns ErrAccountLocked if any of the keys is locked.
+func (svc usersService) checkLockout(ctx context.Context, keys ...string) error {
+	if svc.lockout == nil {
+		return nil
+	}
+
+	for _, key := range keys {
+		d, err := svc.lockout.Locked(ctx, key)
+		if err != nil {
+			return err
+		}
+		if d > 0 {
+			return ErrAccountLocked
+		}
+	}
+
+	return nil
+}
+
+// fail records the failed attempt of the keys and returns the attempt error.
+// If the attempt locks any of the keys, the error is wrapped with
+// ErrAccountLocked.
+func (svc usersService) fail(ctx context.Context, err error, keys ...string) error {
+	if svc.lockout == nil {
+		return err
+	}
+
+	locked := false
+	for _, key := range keys {
+		d, e := svc.lockout.Fail(ctx, key)
+		if e != nil {
+			return err
+		}
+		locked = locked || d > 0
+	}
+
+	if locked {
+		return errors.Wrap(ErrAccountLocked, err)
+	}
+
+	return err
+}
+
+// resetLockout resets the keys. Failures are ignored, since the keys expire
+// on their own.
+func (svc usersService) resetLockout(ctx context.Context, keys ...string) {
+	if svc.lockout == nil {
+		return
+	}
+
+	for _, key := range keys {
+		_ = svc.lockout.Reset(ctx, key)
+	}
+}
+
+// loginKeys returns the keys of the login attempts of the account and the
+// source IP address of the request.
+func loginKeys(ctx context.Context, email string) []string {
+	keys := []string{accountPrefix + email}
+	if ip := audit.SourceIP(ctx); ip != "" {
+		keys = append(keys, ipPrefix+ip)
+	}
+
+	return keys
+}
+
+// resetKeys returns the keys of the password reset requests of the account
+// and the source IP address of the request.
+func resetKeys(ctx context.Context, email string) []string {
+	keys := []string{resetAccountPrefix + email}
+	if ip := audit.SourceIP(ctx); ip != "" {
+		keys = append(keys, resetIPPrefix+ip)
+	}
+
+	return keys
+}
+
+// verifyKeys returns the keys of the verification resend requests of the
+// account and the source IP address of the request.
+func verifyKeys(ctx context.Context, email string) []string {
+	keys := []string{verifyAccountPrefix + email}
+	if ip := audit.SourceIP(ctx); ip != "" {
+		keys = append(keys, verifyIPPrefix+ip)
+	}
+
+	return keys
+}
@@ -0,0 +1,24 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import "github.com/MainfluxLabs/mainflux/users"
+
+var _ users.Cipher = (*cipherMock)(nil)
+
+type cipherMock struct{}
+
+// NewCipher creates "no-op" cipher for test purposes. This implementation will
+// return secrets without changing them.
+func NewCipher() users.Cipher {
+	return &cipherMock{}
+}
+
+func (cm *cipherMock) Encrypt(plain string) (string, error) {
+	return plain, nil
+}
+
+func (cm *cipherMock) Decrypt(encrypted string) (string, error) {
+	return encrypted, nil
+}
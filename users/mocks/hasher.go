@@ -4,10 +4,16 @@
 package mocks
 
 import (
+	"strings"
+
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/users"
 )
 
+// LegacyPrefix marks the hashes generated by the legacy hasher, which are
+// compared successfully but need rehash.
+const LegacyPrefix = "legacy:"
+
 var _ users.Hasher = (*hasherMock)(nil)
 
 type hasherMock struct{}
@@ -26,9 +32,13 @@ func (hm *hasherMock) Hash(pwd string) (string, error) {
 }
 
 func (hm *hasherMock) Compare(plain, hashed string) error {
-	if plain != hashed {
+	if plain != strings.TrimPrefix(hashed, LegacyPrefix) {
 		return errors.ErrAuthentication
 	}
 
 	return nil
 }
+
+func (hm *hasherMock) NeedsRehash(hashed string) bool {
+	return strings.HasPrefix(hashed, LegacyPrefix)
+}
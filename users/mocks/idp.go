@@ -0,0 +1,40 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+// AuthURL is the login page URL of the mocked identity provider.
+const AuthURL = "https://idp.example.com/authorize"
+
+var _ users.IdentityProvider = (*identityProviderMock)(nil)
+
+type identityProviderMock struct {
+	identities map[string]users.Identity
+}
+
+// NewIdentityProvider creates mock of the identity provider, which
+// exchanges the authorization codes for the given identities.
+func NewIdentityProvider(identities map[string]users.Identity) users.IdentityProvider {
+	return &identityProviderMock{identities: identities}
+}
+
+func (idp *identityProviderMock) AuthCodeURL(session users.AuthSession) string {
+	return AuthURL + "?state=" + url.QueryEscape(session.State)
+}
+
+func (idp *identityProviderMock) Exchange(_ context.Context, code string, session users.AuthSession) (users.Identity, error) {
+	identity, ok := idp.identities[code]
+	if !ok || session.Nonce == "" || session.Verifier == "" {
+		return users.Identity{}, errors.ErrAuthentication
+	}
+
+	return identity, nil
+}
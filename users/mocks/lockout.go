@@ -0,0 +1,75 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package mocks
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/users"
+)
+
+var _ users.Lockout = (*lockoutMock)(nil)
+
+type lockoutMock struct {
+	mu       sync.Mutex
+	attempts uint
+	cooldown time.Duration
+	failed   map[string]uint
+	lockouts map[string]uint
+	locked   map[string]time.Time
+}
+
+// NewLockout returns mock lockout which locks the key after the given number
+// of failed attempts. The lockout period starts from the cooldown and doubles
+// with each subsequent lockout of the key.
+func NewLockout(attempts uint, cooldown time.Duration) users.Lockout {
+	return &lockoutMock{
+		attempts: attempts,
+		cooldown: cooldown,
+		failed:   make(map[string]uint),
+		lockouts: make(map[string]uint),
+		locked:   make(map[string]time.Time),
+	}
+}
+
+func (lm *lockoutMock) Locked(_ context.Context, key string) (time.Duration, error) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	if d := time.Until(lm.locked[key]); d > 0 {
+		return d, nil
+	}
+
+	return 0, nil
+}
+
+func (lm *lockoutMock) Fail(_ context.Context, key string) (time.Duration, error) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	lm.failed[key]++
+	if lm.failed[key] < lm.attempts {
+		return 0, nil
+	}
+
+	delete(lm.failed, key)
+	d := lm.cooldown << lm.lockouts[key]
+	lm.lockouts[key]++
+	lm.locked[key] = time.Now().Add(d)
+
+	return d, nil
+}
+
+func (lm *lockoutMock) Reset(_ context.Context, key string) error {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+
+	delete(lm.failed, key)
+	delete(lm.lockouts, key)
+	delete(lm.locked, key)
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/users"
@@ -18,6 +19,8 @@ type userRepositoryMock struct {
 	mu           sync.Mutex
 	usersByID    map[string]users.User
 	usersByEmail map[string]users.User
+	totps        map[string]users.TOTP
+	createdAt    map[string]time.Time
 }
 
 // NewUserRepository creates in-memory user repository
@@ -33,6 +36,8 @@ func NewUserRepository(us []users.User) users.UserRepository {
 	return &userRepositoryMock{
 		usersByEmail: usersByEmail,
 		usersByID:    usersByID,
+		totps:        make(map[string]users.TOTP),
+		createdAt:    make(map[string]time.Time),
 	}
 }
 
@@ -46,6 +51,7 @@ func (urm *userRepositoryMock) Save(ctx context.Context, u users.User) (string,
 
 	urm.usersByEmail[u.Email] = u
 	urm.usersByID[u.ID] = u
+	urm.createdAt[u.ID] = time.Now()
 	return u.ID, nil
 }
 
@@ -123,7 +129,8 @@ func (urm *userRepositoryMock) RetrieveByIDs(ctx context.Context, ids []string,
 		if i >= pm.Offset && i < pm.Offset+pm.Limit || pm.Limit == 0 {
 			switch pm.Status {
 			case users.DisabledStatusKey,
-				users.EnabledStatusKey:
+				users.EnabledStatusKey,
+				users.PendingStatusKey:
 				if pm.Status == u.Status {
 					up.Users = append(up.Users, u)
 				}
@@ -157,9 +164,15 @@ func (urm *userRepositoryMock) UpdatePassword(_ context.Context, token, password
 	urm.mu.Lock()
 	defer urm.mu.Unlock()
 
-	if _, ok := urm.usersByEmail[token]; !ok {
+	u, ok := urm.usersByEmail[token]
+	if !ok {
 		return errors.ErrNotFound
 	}
+
+	u.Password = password
+	urm.usersByEmail[u.Email] = u
+	urm.usersByID[u.ID] = u
+
 	return nil
 }
 
@@ -177,6 +190,84 @@ func (urm *userRepositoryMock) ChangeStatus(ctx context.Context, id, status stri
 	return nil
 }
 
+func (urm *userRepositoryMock) SaveTOTP(_ context.Context, id string, t users.TOTP) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	if _, ok := urm.usersByID[id]; !ok {
+		return errors.ErrNotFound
+	}
+	if prev, ok := urm.totps[id]; ok && prev.Counter > t.Counter {
+		t.Counter = prev.Counter
+	}
+	urm.totps[id] = t
+	return nil
+}
+
+func (urm *userRepositoryMock) RetrieveTOTP(_ context.Context, id string) (users.TOTP, error) {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	t, ok := urm.totps[id]
+	if !ok {
+		return users.TOTP{}, errors.ErrNotFound
+	}
+	return t, nil
+}
+
+func (urm *userRepositoryMock) RemoveTOTP(_ context.Context, id string) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	delete(urm.totps, id)
+	return nil
+}
+
+func (urm *userRepositoryMock) UpdateTOTPCounter(_ context.Context, id string, counter int64) error {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	t, ok := urm.totps[id]
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if t.Counter >= counter {
+		return errors.ErrConflict
+	}
+	t.Counter = counter
+	urm.totps[id] = t
+	return nil
+}
+
+func (urm *userRepositoryMock) RetrieveUnverified(_ context.Context, email string) (users.User, error) {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	u, ok := urm.usersByEmail[email]
+	if !ok || u.Status != users.PendingStatusKey {
+		return users.User{}, errors.ErrNotFound
+	}
+	return u, nil
+}
+
+func (urm *userRepositoryMock) RemoveUnverified(_ context.Context, before time.Time) (uint64, error) {
+	urm.mu.Lock()
+	defer urm.mu.Unlock()
+
+	var n uint64
+	for id, u := range urm.usersByID {
+		if u.Status != users.PendingStatusKey || !urm.createdAt[id].Before(before) {
+			continue
+		}
+		delete(urm.usersByID, id)
+		delete(urm.usersByEmail, u.Email)
+		delete(urm.createdAt, id)
+		delete(urm.totps, id)
+		n++
+	}
+	return n, nil
+}
+
 func sortUsers(us map[string]users.User) []users.User {
 	users := []users.User{}
 	ids := make([]string, 0, len(us))
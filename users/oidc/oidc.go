@@ -0,0 +1,413 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oidc provides an OpenID Connect identity provider implementation
+// utilizing the authorization code flow.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	discoveryPath  = "/.well-known/openid-configuration"
+	defGroupsClaim = "groups"
+	requestTimeout = 10 * time.Second
+	// Unknown key IDs cause provider keys reload at most once per interval.
+	refreshInterval = time.Minute
+)
+
+var (
+	// ErrDiscovery indicates failure to retrieve the provider configuration.
+	ErrDiscovery = errors.New("failed to discover identity provider")
+
+	// ErrExchange indicates failure to exchange the authorization code.
+	ErrExchange = errors.New("failed to exchange authorization code")
+
+	// ErrInvalidIDToken indicates that the ID token is invalid.
+	ErrInvalidIDToken = errors.New("invalid ID token")
+
+	// ErrUnverifiedEmail indicates that the email is missing or not verified
+	// by the identity provider.
+	ErrUnverifiedEmail = errors.New("email is not verified")
+
+	errUnknownKey     = errors.New("unknown signing key")
+	errUnsupportedKey = errors.New("unsupported signing key")
+)
+
+// Signing algorithms accepted for the ID token.
+var validMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}
+
+// Config contains the OpenID Connect identity provider settings.
+type Config struct {
+	// Issuer is the provider URL, used for discovery and the ID token
+	// issuer verification.
+	Issuer string
+
+	// ClientID and ClientSecret are the client credentials registered
+	// with the provider.
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the URL of the callback endpoint.
+	RedirectURL string
+
+	// Scopes are the requested scopes. The openid scope is always requested.
+	Scopes []string
+
+	// GroupsClaim is the ID token claim containing user groups.
+	GroupsClaim string
+
+	// GroupOrgs maps the provider groups to the IDs of the orgs.
+	GroupOrgs map[string][]string
+
+	// MFA indicates that the provider enforces multi-factor authentication
+	// of all users. Otherwise, only the ID tokens with the mfa
+	// authentication method reference are considered multi-factor.
+	MFA bool
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenRes struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+var _ users.IdentityProvider = (*provider)(nil)
+
+type provider struct {
+	mu        sync.Mutex
+	cfg       Config
+	endpoints discovery
+	client    *http.Client
+	keys      map[string]interface{}
+	refreshed time.Time
+}
+
+// New returns the OpenID Connect identity provider. The provider endpoints
+// are retrieved from its discovery document.
+func New(ctx context.Context, cfg Config) (users.IdentityProvider, error) {
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = defGroupsClaim
+	}
+	cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+
+	p := &provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: requestTimeout},
+		keys:   map[string]interface{}{},
+	}
+
+	if err := p.get(ctx, cfg.Issuer+discoveryPath, &p.endpoints); err != nil {
+		return nil, errors.Wrap(ErrDiscovery, err)
+	}
+	if strings.TrimSuffix(p.endpoints.Issuer, "/") != cfg.Issuer {
+		return nil, errors.Wrap(ErrDiscovery, fmt.Errorf("issuer mismatch: %s", p.endpoints.Issuer))
+	}
+
+	return p, nil
+}
+
+func (p *provider) AuthCodeURL(session users.AuthSession) string {
+	scopes := []string{"openid"}
+	for _, s := range p.cfg.Scopes {
+		if s != "openid" {
+			scopes = append(scopes, s)
+		}
+	}
+
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", p.cfg.ClientID)
+	v.Set("redirect_uri", p.cfg.RedirectURL)
+	v.Set("scope", strings.Join(scopes, " "))
+	v.Set("state", session.State)
+	v.Set("nonce", session.Nonce)
+	v.Set("code_challenge", challenge(session.Verifier))
+	v.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(p.endpoints.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+
+	return p.endpoints.AuthorizationEndpoint + sep + v.Encode()
+}
+
+func (p *provider) Exchange(ctx context.Context, code string, session users.AuthSession) (users.Identity, error) {
+	idToken, err := p.exchange(ctx, code, session.Verifier)
+	if err != nil {
+		return users.Identity{}, errors.Wrap(ErrExchange, err)
+	}
+
+	claims, err := p.verify(ctx, idToken, session.Nonce)
+	if err != nil {
+		return users.Identity{}, errors.Wrap(ErrInvalidIDToken, err)
+	}
+
+	// The identity is linked to the existing account with the same email,
+	// so the email has to be explicitly verified by the provider.
+	email, _ := claims["email"].(string)
+	if verified, _ := claims["email_verified"].(bool); email == "" || !verified {
+		return users.Identity{}, ErrUnverifiedEmail
+	}
+
+	return users.Identity{
+		Email: email,
+		Orgs:  p.orgs(claims[p.cfg.GroupsClaim]),
+		MFA:   p.cfg.MFA || mfa(claims["amr"]),
+	}, nil
+}
+
+func (p *provider) exchange(ctx context.Context, code, verifier string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoints.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var tr tokenRes
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return "", errors.New(resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s %s", resp.Status, tr.Error, tr.ErrorDescription)
+	}
+	if tr.IDToken == "" {
+		return "", errors.New("missing ID token")
+	}
+
+	return tr.IDToken, nil
+}
+
+func (p *provider) verify(ctx context.Context, idToken, nonce string) (jwt.MapClaims, error) {
+	parser := jwt.Parser{ValidMethods: validMethods}
+	token, err := parser.Parse(idToken, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("invalid claims")
+	}
+	// Expiration is verified by the parser only if present.
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("token is expired")
+	}
+	if !claims.VerifyIssuer(p.cfg.Issuer, true) && !claims.VerifyIssuer(p.cfg.Issuer+"/", true) {
+		return nil, errors.New("invalid issuer")
+	}
+	if !claims.VerifyAudience(p.cfg.ClientID, true) {
+		return nil, errors.New("invalid audience")
+	}
+	if n, _ := claims["nonce"].(string); nonce == "" || n != nonce {
+		return nil, errors.New("invalid nonce")
+	}
+
+	return claims, nil
+}
+
+// key returns the provider public key with the given ID. Unknown key IDs
+// cause the keys reload, so the rotated provider keys are picked up.
+func (p *provider) key(ctx context.Context, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if k, ok := p.keys[kid]; ok {
+		return k, nil
+	}
+	if time.Since(p.refreshed) < refreshInterval {
+		return nil, errUnknownKey
+	}
+
+	var set jwks
+	if err := p.get(ctx, p.endpoints.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	p.refreshed = time.Now()
+
+	keys := map[string]interface{}{}
+	for _, k := range set.Keys {
+		pub, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+	p.keys = keys
+
+	if k, ok := p.keys[kid]; ok {
+		return k, nil
+	}
+
+	return nil, errUnknownKey
+}
+
+func (p *provider) orgs(groups interface{}) []string {
+	var names []string
+	switch g := groups.(type) {
+	case string:
+		names = append(names, g)
+	case []interface{}:
+		for _, n := range g {
+			if s, ok := n.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+
+	var orgs []string
+	seen := map[string]bool{}
+	for _, n := range names {
+		for _, id := range p.cfg.GroupOrgs[n] {
+			if !seen[id] {
+				seen[id] = true
+				orgs = append(orgs, id)
+			}
+		}
+	}
+
+	return orgs
+}
+
+func (p *provider) get(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errUnsupportedKey
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, errUnsupportedKey
+	}
+}
+
+// mfa reports whether the authentication method references of the ID token
+// contain the multi-factor authentication.
+func mfa(amr interface{}) bool {
+	methods, _ := amr.([]interface{})
+	for _, m := range methods {
+		if m == "mfa" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// challenge returns the S256 PKCE code challenge of the code verifier.
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,444 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/MainfluxLabs/mainflux/users/oidc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	clientID     = "mainflux"
+	clientSecret = "secret"
+	redirectURL  = "http://localhost/oauth/idp/callback"
+	email        = "user@example.com"
+	rsaKID       = "rsa-key"
+	ecKID        = "ec-key"
+	nonce        = "nonce"
+	verifier     = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	// challenge is the S256 code challenge of the verifier.
+	challenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+var session = users.AuthSession{State: "state", Nonce: nonce, Verifier: verifier}
+
+var groupOrgs = map[string][]string{
+	"engineering": {"org-1", "org-2"},
+	"operations":  {"org-2"},
+}
+
+type grant struct {
+	claims jwt.MapClaims
+	method jwt.SigningMethod
+	kid    string
+	key    interface{}
+}
+
+// mockIdP is a local OpenID Connect identity provider which issues the
+// ID tokens for the registered authorization codes.
+type mockIdP struct {
+	server *httptest.Server
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+	grants map[string]grant
+	issuer string
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected RSA key generation error: %s", err))
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("unexpected EC key generation error: %s", err))
+
+	idp := &mockIdP{rsaKey: rsaKey, ecKey: ecKey, grants: map[string]grant{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/token", idp.token)
+	mux.HandleFunc("/jwks", idp.jwks)
+	idp.server = httptest.NewServer(mux)
+	idp.issuer = idp.server.URL
+
+	return idp
+}
+
+func (idp *mockIdP) claims(code string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            idp.server.URL,
+		"sub":            code,
+		"aud":            clientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"email":          email,
+		"email_verified": true,
+		"nonce":          nonce,
+	}
+}
+
+func (idp *mockIdP) grantRSA(code string, claims jwt.MapClaims) {
+	idp.grants[code] = grant{claims: claims, method: jwt.SigningMethodRS256, kid: rsaKID, key: idp.rsaKey}
+}
+
+func (idp *mockIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]string{
+		"issuer":                 idp.issuer,
+		"authorization_endpoint": idp.server.URL + "/authorize",
+		"token_endpoint":         idp.server.URL + "/token",
+		"jwks_uri":               idp.server.URL + "/jwks",
+	})
+}
+
+func (idp *mockIdP) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != clientID || secret != clientSecret {
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	g, ok := idp.grants[r.FormValue("code")]
+	if !ok || r.FormValue("grant_type") != "authorization_code" || r.FormValue("redirect_uri") != redirectURL || r.FormValue("code_verifier") != verifier {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := jwt.NewWithClaims(g.method, g.claims)
+	token.Header["kid"] = g.kid
+	idToken, err := token.SignedString(g.key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"access_token": "access",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func (idp *mockIdP) jwks(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kid": rsaKID,
+				"kty": "RSA",
+				"n":   encode(idp.rsaKey.N.Bytes()),
+				"e":   encode(big.NewInt(int64(idp.rsaKey.E)).Bytes()),
+			},
+			{
+				"kid": ecKID,
+				"kty": "EC",
+				"crv": "P-256",
+				"x":   encode(idp.ecKey.X.FillBytes(make([]byte, 32))),
+				"y":   encode(idp.ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+		},
+	})
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func newConfig(issuer string) oidc.Config {
+	return oidc.Config{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+		GroupOrgs:    groupOrgs,
+	}
+}
+
+func TestNew(t *testing.T) {
+	idp := newMockIdP(t)
+	defer idp.server.Close()
+
+	other := newMockIdP(t)
+	defer other.server.Close()
+	other.issuer = "https://other.example.com"
+
+	cases := []struct {
+		desc   string
+		issuer string
+		err    error
+	}{
+		{
+			desc:   "create provider",
+			issuer: idp.server.URL,
+			err:    nil,
+		},
+		{
+			desc:   "create provider with trailing slash in issuer",
+			issuer: idp.server.URL + "/",
+			err:    nil,
+		},
+		{
+			desc:   "create provider with mismatched issuer",
+			issuer: other.server.URL,
+			err:    oidc.ErrDiscovery,
+		},
+		{
+			desc:   "create provider with unreachable issuer",
+			issuer: "http://localhost:1",
+			err:    oidc.ErrDiscovery,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := oidc.New(context.Background(), newConfig(tc.issuer))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	idp := newMockIdP(t)
+	defer idp.server.Close()
+
+	p, err := oidc.New(context.Background(), newConfig(idp.server.URL))
+	require.Nil(t, err, fmt.Sprintf("unexpected provider creation error: %s", err))
+
+	u, err := url.Parse(p.AuthCodeURL(session))
+	require.Nil(t, err, fmt.Sprintf("unexpected URL parsing error: %s", err))
+
+	expected := map[string]string{
+		"response_type":         "code",
+		"client_id":             clientID,
+		"redirect_uri":          redirectURL,
+		"scope":                 "openid email profile",
+		"state":                 "state",
+		"nonce":                 nonce,
+		"code_challenge":        challenge,
+		"code_challenge_method": "S256",
+	}
+	assert.Equal(t, idp.server.URL+"/authorize", fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path), "expected authorization endpoint URL")
+	for k, v := range expected {
+		assert.Equal(t, v, u.Query().Get(k), fmt.Sprintf("expected %s %s got %s\n", k, v, u.Query().Get(k)))
+	}
+}
+
+func TestExchange(t *testing.T) {
+	idp := newMockIdP(t)
+	defer idp.server.Close()
+
+	claims := idp.claims("groups")
+	claims["groups"] = []string{"engineering", "operations", "unmapped"}
+	idp.grantRSA("groups", claims)
+
+	idp.grantRSA("no-groups", idp.claims("no-groups"))
+
+	claims = idp.claims("mfa")
+	claims["amr"] = []string{"pwd", "otp", "mfa"}
+	idp.grantRSA("mfa", claims)
+
+	idp.grants["ec"] = grant{claims: idp.claims("ec"), method: jwt.SigningMethodES256, kid: ecKID, key: idp.ecKey}
+
+	claims = idp.claims("audience")
+	claims["aud"] = "other"
+	idp.grantRSA("audience", claims)
+
+	claims = idp.claims("issuer")
+	claims["iss"] = "https://other.example.com"
+	idp.grantRSA("issuer", claims)
+
+	claims = idp.claims("expired")
+	claims["exp"] = time.Now().Add(-time.Minute).Unix()
+	idp.grantRSA("expired", claims)
+
+	claims = idp.claims("unverified")
+	claims["email_verified"] = false
+	idp.grantRSA("unverified", claims)
+
+	claims = idp.claims("unknown-verification")
+	delete(claims, "email_verified")
+	idp.grantRSA("unknown-verification", claims)
+
+	claims = idp.claims("no-email")
+	delete(claims, "email")
+	idp.grantRSA("no-email", claims)
+
+	claims = idp.claims("no-nonce")
+	delete(claims, "nonce")
+	idp.grantRSA("no-nonce", claims)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.Nil(t, err, fmt.Sprintf("unexpected RSA key generation error: %s", err))
+	idp.grants["unknown-key"] = grant{claims: idp.claims("unknown-key"), method: jwt.SigningMethodRS256, kid: "other-key", key: otherKey}
+	idp.grants["forged"] = grant{claims: idp.claims("forged"), method: jwt.SigningMethodRS256, kid: rsaKID, key: otherKey}
+	idp.grants["hmac"] = grant{claims: idp.claims("hmac"), method: jwt.SigningMethodHS256, kid: rsaKID, key: []byte(clientSecret)}
+
+	p, err := oidc.New(context.Background(), newConfig(idp.server.URL))
+	require.Nil(t, err, fmt.Sprintf("unexpected provider creation error: %s", err))
+
+	cases := []struct {
+		desc     string
+		code     string
+		session  users.AuthSession
+		identity users.Identity
+		err      error
+	}{
+		{
+			desc:     "exchange code for identity with groups",
+			code:     "groups",
+			session:  session,
+			identity: users.Identity{Email: email, Orgs: []string{"org-1", "org-2"}},
+			err:      nil,
+		},
+		{
+			desc:     "exchange code for identity without groups",
+			code:     "no-groups",
+			session:  session,
+			identity: users.Identity{Email: email},
+			err:      nil,
+		},
+		{
+			desc:     "exchange code for identity authenticated with multiple factors",
+			code:     "mfa",
+			session:  session,
+			identity: users.Identity{Email: email, MFA: true},
+			err:      nil,
+		},
+		{
+			desc:     "exchange code for identity signed with EC key",
+			code:     "ec",
+			session:  session,
+			identity: users.Identity{Email: email},
+			err:      nil,
+		},
+		{
+			desc:     "exchange invalid code",
+			code:     "invalid",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrExchange,
+		},
+		{
+			desc:     "exchange code for token with invalid audience",
+			code:     "audience",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:     "exchange code for token with invalid issuer",
+			code:     "issuer",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:     "exchange code for expired token",
+			code:     "expired",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:     "exchange code for token signed with unknown key",
+			code:     "unknown-key",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:     "exchange code for forged token",
+			code:     "forged",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:     "exchange code for token signed with shared secret",
+			code:     "hmac",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:     "exchange code for identity with unverified email",
+			code:     "unverified",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc:     "exchange code for identity without email",
+			code:     "no-email",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc:     "exchange code for identity with unknown email verification",
+			code:     "unknown-verification",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrUnverifiedEmail,
+		},
+		{
+			desc:     "exchange code for token without nonce",
+			code:     "no-nonce",
+			session:  session,
+			identity: users.Identity{},
+			err:      oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:     "exchange code for token with other session nonce",
+			code:     "groups",
+			session:  users.AuthSession{State: session.State, Nonce: "other", Verifier: verifier},
+			identity: users.Identity{},
+			err:      oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:     "exchange code with other session code verifier",
+			code:     "groups",
+			session:  users.AuthSession{State: session.State, Nonce: nonce, Verifier: "other"},
+			identity: users.Identity{},
+			err:      oidc.ErrExchange,
+		},
+	}
+
+	for _, tc := range cases {
+		identity, err := p.Exchange(context.Background(), tc.code, tc.session)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.identity, identity, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.identity, identity))
+	}
+
+	cfg := newConfig(idp.server.URL)
+	cfg.MFA = true
+	p, err = oidc.New(context.Background(), cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected provider creation error: %s", err))
+	identity, err := p.Exchange(context.Background(), "no-groups", session)
+	require.Nil(t, err, fmt.Sprintf("unexpected exchange error: %s", err))
+	assert.True(t, identity.MFA, "exchange code with provider enforcing MFA: expected multi-factor identity")
+
+	cfg = newConfig(idp.server.URL)
+	cfg.ClientSecret = "wrong"
+	p, err = oidc.New(context.Background(), cfg)
+	require.Nil(t, err, fmt.Sprintf("unexpected provider creation error: %s", err))
+	_, err = p.Exchange(context.Background(), "groups", session)
+	assert.True(t, errors.Contains(err, oidc.ErrExchange), fmt.Sprintf("exchange code with invalid client secret: expected %s got %s\n", oidc.ErrExchange, err))
+}
@@ -91,6 +91,41 @@ func migrateDB(db *sqlx.DB) error {
 					status USER_STATUS NOT NULL DEFAULT 'enabled'`,
 				},
 			},
+			{
+				Id: "users_6",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS users_totp (
+					 user_id        UUID    PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+					 secret         TEXT    NOT NULL,
+					 enabled        BOOLEAN NOT NULL DEFAULT FALSE,
+					 recovery_codes JSONB
+					)`,
+				},
+				Down: []string{"DROP TABLE users_totp"},
+			},
+			{
+				Id: "users_7",
+				Up: []string{
+					`ALTER TYPE user_status ADD VALUE IF NOT EXISTS 'pending'`,
+					`ALTER TABLE IF EXISTS users ADD COLUMN IF NOT EXISTS
+					created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP`,
+				},
+				// The enum value can't be added within a transaction block.
+				DisableTransactionUp: true,
+			},
+			{
+				Id: "users_8",
+				Up: []string{
+					`ALTER TABLE IF EXISTS users ALTER COLUMN password TYPE TEXT`,
+				},
+			},
+			{
+				Id: "users_9",
+				Up: []string{
+					`ALTER TABLE IF EXISTS users_totp ADD COLUMN IF NOT EXISTS
+					counter BIGINT NOT NULL DEFAULT 0`,
+				},
+			},
 		},
 	}
 
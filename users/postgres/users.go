@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/dbutil"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
@@ -120,7 +121,7 @@ func (ur userRepository) RetrieveByEmail(ctx context.Context, email string) (use
 }
 
 func (ur userRepository) RetrieveByID(ctx context.Context, id string) (users.User, error) {
-	q := `SELECT email, password, metadata FROM users WHERE id = $1`
+	q := `SELECT email, password, metadata, status FROM users WHERE id = $1`
 
 	dbu := dbUser{
 		ID: id,
@@ -282,6 +283,117 @@ func (ur userRepository) ChangeStatus(ctx context.Context, id, status string) er
 	return nil
 }
 
+func (ur userRepository) SaveTOTP(ctx context.Context, id string, t users.TOTP) error {
+	// The counter is never decreased, so the code accepted concurrently
+	// with saving the settings can't be used again.
+	q := `INSERT INTO users_totp (user_id, secret, enabled, recovery_codes, counter) VALUES (:user_id, :secret, :enabled, :recovery_codes, :counter)
+		ON CONFLICT (user_id) DO UPDATE SET secret = :secret, enabled = :enabled, recovery_codes = :recovery_codes,
+		counter = GREATEST(users_totp.counter, :counter)`
+
+	dbt, err := toDBTOTP(id, t)
+	if err != nil {
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	if _, err := ur.db.NamedExecContext(ctx, q, dbt); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok {
+			switch pgErr.Code {
+			case pgerrcode.InvalidTextRepresentation:
+				return errors.Wrap(errors.ErrMalformedEntity, err)
+			case pgerrcode.ForeignKeyViolation:
+				return errors.Wrap(errors.ErrNotFound, err)
+			}
+		}
+		return errors.Wrap(errors.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (ur userRepository) RetrieveTOTP(ctx context.Context, id string) (users.TOTP, error) {
+	q := `SELECT user_id, secret, enabled, recovery_codes, counter FROM users_totp WHERE user_id = $1`
+
+	var dbt dbTOTP
+	if err := ur.db.QueryRowxContext(ctx, q, id).StructScan(&dbt); err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if err == sql.ErrNoRows || ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return users.TOTP{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return users.TOTP{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toTOTP(dbt)
+}
+
+func (ur userRepository) RemoveTOTP(ctx context.Context, id string) error {
+	q := `DELETE FROM users_totp WHERE user_id = :user_id`
+
+	if _, err := ur.db.NamedExecContext(ctx, q, dbTOTP{UserID: id}); err != nil {
+		return errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (ur userRepository) UpdateTOTPCounter(ctx context.Context, id string, counter int64) error {
+	// The counter is updated only if it's advanced, so concurrent requests
+	// can't accept the same code.
+	q := `UPDATE users_totp SET counter = :counter WHERE user_id = :user_id AND counter < :counter`
+
+	res, err := ur.db.NamedExecContext(ctx, q, dbTOTP{UserID: id, Counter: counter})
+	if err != nil {
+		pgErr, ok := err.(*pgconn.PgError)
+		if ok && pgErr.Code == pgerrcode.InvalidTextRepresentation {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrap(errors.ErrUpdateEntity, err)
+	}
+	if n == 0 {
+		return errors.ErrConflict
+	}
+
+	return nil
+}
+
+func (ur userRepository) RetrieveUnverified(ctx context.Context, email string) (users.User, error) {
+	q := `SELECT id, password, metadata, status FROM users WHERE email = $1 AND status = 'pending'`
+
+	dbu := dbUser{
+		Email: email,
+	}
+
+	if err := ur.db.QueryRowxContext(ctx, q, email).StructScan(&dbu); err != nil {
+		if err == sql.ErrNoRows {
+			return users.User{}, errors.Wrap(errors.ErrNotFound, err)
+		}
+		return users.User{}, errors.Wrap(errors.ErrRetrieveEntity, err)
+	}
+
+	return toUser(dbu)
+}
+
+func (ur userRepository) RemoveUnverified(ctx context.Context, before time.Time) (uint64, error) {
+	q := `DELETE FROM users WHERE status = 'pending' AND created_at < :before`
+
+	res, err := ur.db.NamedExecContext(ctx, q, map[string]interface{}{"before": before})
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(errors.ErrRemoveEntity, err)
+	}
+
+	return uint64(n), nil
+}
+
 type dbUser struct {
 	ID       string `db:"id"`
 	Email    string `db:"email"`
@@ -341,6 +453,49 @@ func toUser(dbu dbUser) (users.User, error) {
 	}, nil
 }
 
+type dbTOTP struct {
+	UserID        string `db:"user_id"`
+	Secret        string `db:"secret"`
+	Enabled       bool   `db:"enabled"`
+	RecoveryCodes []byte `db:"recovery_codes"`
+	Counter       int64  `db:"counter"`
+}
+
+func toDBTOTP(id string, t users.TOTP) (dbTOTP, error) {
+	codes := []byte("[]")
+	if len(t.RecoveryCodes) > 0 {
+		b, err := json.Marshal(t.RecoveryCodes)
+		if err != nil {
+			return dbTOTP{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+		codes = b
+	}
+
+	return dbTOTP{
+		UserID:        id,
+		Secret:        t.Secret,
+		Enabled:       t.Enabled,
+		RecoveryCodes: codes,
+		Counter:       t.Counter,
+	}, nil
+}
+
+func toTOTP(dbt dbTOTP) (users.TOTP, error) {
+	var codes []string
+	if dbt.RecoveryCodes != nil {
+		if err := json.Unmarshal(dbt.RecoveryCodes, &codes); err != nil {
+			return users.TOTP{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return users.TOTP{
+		Secret:        dbt.Secret,
+		Enabled:       dbt.Enabled,
+		RecoveryCodes: codes,
+		Counter:       dbt.Counter,
+	}, nil
+}
+
 func createEmailQuery(entity string, email string) (string, string, error) {
 	if email == "" {
 		return "", "", nil
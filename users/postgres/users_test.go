@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
@@ -385,3 +386,257 @@ func TestUpdateUser(t *testing.T) {
 		assert.Nil(t, err, fmt.Sprintf("%s: expected no error got %d\n", desc, err))
 	}
 }
+
+func TestSaveTOTP(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = repo.Save(context.Background(), users.User{ID: uid, Email: "totp-save@example.com", Password: password, Status: users.EnabledStatusKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	nonExistingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		totp users.TOTP
+		err  error
+	}{
+		{
+			desc: "save TOTP",
+			id:   uid,
+			totp: users.TOTP{Secret: "secret"},
+			err:  nil,
+		},
+		{
+			desc: "save existing TOTP",
+			id:   uid,
+			totp: users.TOTP{Secret: "secret", Enabled: true, RecoveryCodes: []string{"code1", "code2"}},
+			err:  nil,
+		},
+		{
+			desc: "save TOTP of non-existing user",
+			id:   nonExistingID,
+			totp: users.TOTP{Secret: "secret"},
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "save TOTP with invalid user ID",
+			id:   "invalid",
+			totp: users.TOTP{Secret: "secret"},
+			err:  errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.SaveTOTP(context.Background(), tc.id, tc.totp)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestRetrieveTOTP(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = repo.Save(context.Background(), users.User{ID: uid, Email: "totp-retrieve@example.com", Password: password, Status: users.EnabledStatusKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	totp := users.TOTP{Secret: "secret", Enabled: true, RecoveryCodes: []string{"code1", "code2"}}
+	err = repo.SaveTOTP(context.Background(), uid, totp)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	nonExistingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		totp users.TOTP
+		err  error
+	}{
+		{
+			desc: "retrieve existing TOTP",
+			id:   uid,
+			totp: totp,
+			err:  nil,
+		},
+		{
+			desc: "retrieve non-existing TOTP",
+			id:   nonExistingID,
+			totp: users.TOTP{},
+			err:  errors.ErrNotFound,
+		},
+		{
+			desc: "retrieve TOTP with invalid user ID",
+			id:   "invalid",
+			totp: users.TOTP{},
+			err:  errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		totp, err := repo.RetrieveTOTP(context.Background(), tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.Equal(t, tc.totp, totp, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.totp, totp))
+	}
+}
+
+func TestRemoveTOTP(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = repo.Save(context.Background(), users.User{ID: uid, Email: "totp-remove@example.com", Password: password, Status: users.EnabledStatusKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = repo.SaveTOTP(context.Background(), uid, users.TOTP{Secret: "secret", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = repo.RemoveTOTP(context.Background(), uid)
+	assert.Nil(t, err, fmt.Sprintf("remove existing TOTP: expected no error got %s\n", err))
+
+	_, err = repo.RetrieveTOTP(context.Background(), uid)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed TOTP: expected %s got %s\n", errors.ErrNotFound, err))
+
+	err = repo.RemoveTOTP(context.Background(), uid)
+	assert.Nil(t, err, fmt.Sprintf("remove removed TOTP: expected no error got %s\n", err))
+}
+
+func TestUpdateTOTPCounter(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	uid, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	_, err = repo.Save(context.Background(), users.User{ID: uid, Email: "totp-counter@example.com", Password: password, Status: users.EnabledStatusKey})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	err = repo.SaveTOTP(context.Background(), uid, users.TOTP{Secret: "secret", Enabled: true, Counter: 10})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	nonExistingID, err := idProvider.ID()
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		id      string
+		counter int64
+		err     error
+	}{
+		{
+			desc:    "advance TOTP counter",
+			id:      uid,
+			counter: 11,
+			err:     nil,
+		},
+		{
+			desc:    "update TOTP counter to the same value",
+			id:      uid,
+			counter: 11,
+			err:     errors.ErrConflict,
+		},
+		{
+			desc:    "update TOTP counter to lower value",
+			id:      uid,
+			counter: 5,
+			err:     errors.ErrConflict,
+		},
+		{
+			desc:    "update TOTP counter of non-existing TOTP",
+			id:      nonExistingID,
+			counter: 12,
+			err:     errors.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		err := repo.UpdateTOTPCounter(context.Background(), tc.id, tc.counter)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = repo.SaveTOTP(context.Background(), uid, users.TOTP{Secret: "secret", Enabled: true})
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	totp, err := repo.RetrieveTOTP(context.Background(), uid)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, int64(11), totp.Counter, fmt.Sprintf("save TOTP with lower counter: expected counter %d got %d\n", 11, totp.Counter))
+}
+
+func TestRetrieveUnverified(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	pending := users.User{Email: "unverified-retrieve@example.com", Password: password, Status: users.PendingStatusKey}
+	enabled := users.User{Email: "verified-retrieve@example.com", Password: password, Status: users.EnabledStatusKey}
+	for _, u := range []*users.User{&pending, &enabled} {
+		uid, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		u.ID = uid
+		_, err = repo.Save(context.Background(), *u)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		email string
+		err   error
+	}{
+		{
+			desc:  "retrieve pending user",
+			email: pending.Email,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve enabled user",
+			email: enabled.Email,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "retrieve non-existing user",
+			email: "non-existing@example.com",
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		u, err := repo.RetrieveUnverified(context.Background(), tc.email)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if tc.err == nil {
+			assert.Equal(t, pending.ID, u.ID, fmt.Sprintf("%s: expected ID %s got %s\n", tc.desc, pending.ID, u.ID))
+		}
+	}
+}
+
+func TestRemoveUnverified(t *testing.T) {
+	dbMiddleware := postgres.NewDatabase(db)
+	repo := postgres.NewUserRepo(dbMiddleware)
+
+	pending := users.User{Email: "unverified-remove@example.com", Password: password, Status: users.PendingStatusKey}
+	enabled := users.User{Email: "verified-remove@example.com", Password: password, Status: users.EnabledStatusKey}
+	for _, u := range []*users.User{&pending, &enabled} {
+		uid, err := idProvider.ID()
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		u.ID = uid
+		_, err = repo.Save(context.Background(), *u)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	n, err := repo.RemoveUnverified(context.Background(), time.Now().Add(-time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("remove users registered before an hour: expected no error got %s\n", err))
+	assert.Equal(t, uint64(0), n, fmt.Sprintf("remove users registered before an hour: expected 0 removed got %d\n", n))
+
+	n, err = repo.RemoveUnverified(context.Background(), time.Now().Add(time.Hour))
+	assert.Nil(t, err, fmt.Sprintf("remove pending users: expected no error got %s\n", err))
+	assert.GreaterOrEqual(t, n, uint64(1), fmt.Sprintf("remove pending users: expected removed users got %d\n", n))
+
+	_, err = repo.RetrieveUnverified(context.Background(), pending.Email)
+	assert.True(t, errors.Contains(err, errors.ErrNotFound), fmt.Sprintf("retrieve removed user: expected %s got %s\n", errors.ErrNotFound, err))
+
+	_, err = repo.RetrieveByID(context.Background(), enabled.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve enabled user: expected no error got %s\n", err))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/users"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	attemptsPrefix = "lockout:attempts"
+	lockoutsPrefix = "lockout:count"
+	lockedPrefix   = "lockout:locked"
+	// Lockout periods stop doubling once the cooldown is shifted this many times.
+	maxShift = 32
+)
+
+// failScript atomically counts the failed attempt of the key and locks the
+// key once the attempts reach the limit. KEYS hold the attempts counter, the
+// lockouts counter and the lock of the key, while ARGV holds the attempts
+// limit, the attempts window, the cooldown and the maximum lockout period,
+// all in milliseconds. It returns the lockout period, or zero if the key
+// isn't locked by the attempt.
+var failScript = redis.NewScript(`
+local attempts = redis.call("INCR", KEYS[1])
+if attempts == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if attempts < tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("DEL", KEYS[1])
+local lockouts = redis.call("INCR", KEYS[2])
+local max = tonumber(ARGV[4])
+local period = math.min(max, tonumber(ARGV[3]) * 2 ^ math.min(lockouts - 1, tonumber(ARGV[5])))
+period = math.floor(period)
+redis.call("PEXPIRE", KEYS[2], period + max)
+redis.call("SET", KEYS[3], 1, "PX", period)
+return period
+`)
+
+var _ users.Lockout = (*lockout)(nil)
+
+type lockout struct {
+	client      *redis.Client
+	attempts    uint
+	window      time.Duration
+	cooldown    time.Duration
+	maxCooldown time.Duration
+}
+
+// NewLockout returns redis lockout implementation, which locks the key once
+// it fails the given number of attempts within the window. The first lockout
+// lasts for the cooldown, and each subsequent one twice as long as the
+// previous, up to the maximum cooldown. The lockouts of the key are counted
+// until the key has no lockouts for the maximum cooldown.
+func NewLockout(client *redis.Client, attempts uint, window, cooldown, maxCooldown time.Duration) users.Lockout {
+	return &lockout{
+		client:      client,
+		attempts:    attempts,
+		window:      window,
+		cooldown:    cooldown,
+		maxCooldown: maxCooldown,
+	}
+}
+
+func (l *lockout) Locked(ctx context.Context, key string) (time.Duration, error) {
+	d, err := l.client.PTTL(ctx, fmt.Sprintf("%s:%s", lockedPrefix, key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// Missing keys are reported with negative TTL.
+	if d < 0 {
+		return 0, nil
+	}
+
+	return d, nil
+}
+
+func (l *lockout) Fail(ctx context.Context, key string) (time.Duration, error) {
+	keys := []string{
+		fmt.Sprintf("%s:%s", attemptsPrefix, key),
+		fmt.Sprintf("%s:%s", lockoutsPrefix, key),
+		fmt.Sprintf("%s:%s", lockedPrefix, key),
+	}
+	ms, err := failScript.Run(ctx, l.client, keys, l.attempts, l.window.Milliseconds(), l.cooldown.Milliseconds(), l.maxCooldown.Milliseconds(), maxShift).Int64()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+func (l *lockout) Reset(ctx context.Context, key string) error {
+	return l.client.Del(ctx,
+		fmt.Sprintf("%s:%s", attemptsPrefix, key),
+		fmt.Sprintf("%s:%s", lockoutsPrefix, key),
+		fmt.Sprintf("%s:%s", lockedPrefix, key),
+	).Err()
+}
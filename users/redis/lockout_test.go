@@ -0,0 +1,79 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/users/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	attempts    = 3
+	window      = time.Minute
+	cooldown    = time.Second
+	maxCooldown = 3 * time.Second
+)
+
+func TestLockoutFail(t *testing.T) {
+	lockout := redis.NewLockout(redisClient, attempts, window, cooldown, maxCooldown)
+	key := "account:fail@example.com"
+
+	for i := 1; i < attempts; i++ {
+		d, err := lockout.Fail(context.Background(), key)
+		require.Nil(t, err, fmt.Sprintf("unexpected failed attempt error: %s", err))
+		assert.Equal(t, time.Duration(0), d, fmt.Sprintf("failed attempt %d: expected no lockout got %s\n", i, d))
+	}
+
+	cases := []struct {
+		desc   string
+		period time.Duration
+	}{
+		{desc: "first lockout", period: cooldown},
+		{desc: "second lockout", period: 2 * cooldown},
+		{desc: "lockout over maximum cooldown", period: maxCooldown},
+	}
+
+	for i, tc := range cases {
+		if i > 0 {
+			for j := 1; j < attempts; j++ {
+				_, err := lockout.Fail(context.Background(), key)
+				require.Nil(t, err, fmt.Sprintf("unexpected failed attempt error: %s", err))
+			}
+		}
+		d, err := lockout.Fail(context.Background(), key)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.period, d, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.period, d))
+
+		locked, err := lockout.Locked(context.Background(), key)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.True(t, locked > 0 && locked <= tc.period, fmt.Sprintf("%s: expected remaining lockout up to %s got %s\n", tc.desc, tc.period, locked))
+	}
+}
+
+func TestLockoutReset(t *testing.T) {
+	lockout := redis.NewLockout(redisClient, attempts, window, cooldown, maxCooldown)
+	key := "account:reset@example.com"
+
+	for i := 0; i < attempts; i++ {
+		_, err := lockout.Fail(context.Background(), key)
+		require.Nil(t, err, fmt.Sprintf("unexpected failed attempt error: %s", err))
+	}
+
+	err := lockout.Reset(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("reset lockout: unexpected error: %s\n", err))
+
+	locked, err := lockout.Locked(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("check reset lockout: unexpected error: %s\n", err))
+	assert.Equal(t, time.Duration(0), locked, fmt.Sprintf("check reset lockout: expected no lockout got %s\n", locked))
+
+	d, err := lockout.Fail(context.Background(), key)
+	assert.Nil(t, err, fmt.Sprintf("fail after reset: unexpected error: %s\n", err))
+	assert.Equal(t, time.Duration(0), d, fmt.Sprintf("fail after reset: expected no lockout got %s\n", d))
+}
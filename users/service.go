@@ -5,19 +5,31 @@ package users
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/auth"
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
+	"github.com/MainfluxLabs/mainflux/users/totp"
 )
 
 const (
 	EnabledStatusKey  = "enabled"
 	DisabledStatusKey = "disabled"
+	PendingStatusKey  = "pending"
 	AllStatusKey      = "all"
 	rootSubject       = "root"
+
+	totpIssuer        = "Mainflux"
+	recoveryCodesNum  = 10
+	recoveryCodeBytes = 10
 )
 
 var (
@@ -39,15 +51,51 @@ var (
 
 	// ErrAlreadyDisabledUser indicates the user is already disabled.
 	ErrAlreadyDisabledUser = errors.New("the user is already disabled")
+
+	// ErrUnknownProvider indicates that the identity provider is not configured.
+	ErrUnknownProvider = errors.New("unknown identity provider")
+
+	// ErrOrgMembership indicates failure to add the user to the org mapped
+	// to the identity provider group.
+	ErrOrgMembership = errors.New("failed to add user to the org")
+
+	// ErrTOTPRequired indicates that the login requires the two-factor
+	// authentication code.
+	ErrTOTPRequired = errors.New("two-factor authentication code required")
+
+	// ErrTOTPEnabled indicates that the two-factor authentication is
+	// already enabled.
+	ErrTOTPEnabled = errors.New("two-factor authentication already enabled")
+
+	// ErrInvalidTOTPCode indicates invalid two-factor authentication code.
+	ErrInvalidTOTPCode = errors.New("invalid two-factor authentication code")
+
+	// ErrVerificationToken indicates error in generating email verification token.
+	ErrVerificationToken = errors.New("failed to generate email verification token")
+
+	// ErrEmailVerified indicates that the user email is already verified.
+	ErrEmailVerified = errors.New("email is already verified")
+
+	// ErrAccountLocked indicates that the account or the source IP address is
+	// temporarily locked due to too many failed attempts.
+	ErrAccountLocked = errors.New("too many failed attempts, try again later")
 )
 
 // Service specifies an API that must be fullfiled by the domain service
 // implementation, and all of its decorators (e.g. logging & metrics).
 type Service interface {
-	// Register creates new user account. In case of the failed registration, a
-	// non-nil error value is returned. The user registration is only allowed
-	// for admin.
-	SelfRegister(ctx context.Context, user User) (string, error)
+	// SelfRegister creates new pending user account and sends the email
+	// verification link to the user email. host is used for generating the
+	// link. The account is enabled once the email is verified.
+	SelfRegister(ctx context.Context, user User, host string) (string, error)
+
+	// VerifyEmail enables the pending account of the user identified by the
+	// verification token sent on the self-registration.
+	VerifyEmail(ctx context.Context, token string) error
+
+	// ResendVerification sends new verification link to the email of the
+	// pending account. host is used for generating the link.
+	ResendVerification(ctx context.Context, email, host string) error
 
 	// Register creates new user account. In case of the failed registration, a
 	// non-nil error value is returned. The user registration is only allowed
@@ -61,9 +109,40 @@ type Service interface {
 
 	// Login authenticates the user given its credentials. Successful
 	// authentication generates new access token. Failed invocations are
-	// identified by the non-nil error values in the response.
+	// identified by the non-nil error values in the response. If the user
+	// has two-factor authentication enabled, ErrTOTPRequired is returned
+	// and the login is completed with LoginTOTP.
 	Login(ctx context.Context, user User) (string, error)
 
+	// LoginTOTP authenticates the user with two-factor authentication enabled,
+	// given its credentials and the TOTP or a recovery code. Successful
+	// authentication generates new access token.
+	LoginTOTP(ctx context.Context, user User, code string) (string, error)
+
+	// EnrollTOTP generates new TOTP secret for the user identified by the
+	// token. Two-factor authentication is enabled once the enrollment is
+	// activated with a valid code.
+	EnrollTOTP(ctx context.Context, token string) (TOTPKey, error)
+
+	// ActivateTOTP enables two-factor authentication for the user identified
+	// by the token, given a valid TOTP code. It returns the recovery codes,
+	// each of which can be used once instead of the TOTP code.
+	ActivateTOTP(ctx context.Context, token, code string) ([]string, error)
+
+	// DeactivateTOTP disables two-factor authentication for the user
+	// identified by the token, given the TOTP or a recovery code.
+	DeactivateTOTP(ctx context.Context, token, code string) error
+
+	// OAuthURL returns the URL of the identity provider login page, which
+	// redirects back to the callback with the authorization code and the state.
+	OAuthURL(ctx context.Context, provider string, session AuthSession) (string, error)
+
+	// OAuthLogin authenticates the user with the authorization code issued by
+	// the identity provider within the session and generates new access token. The user account is
+	// created on the first login, and the user joins the orgs mapped to the
+	// identity provider groups.
+	OAuthLogin(ctx context.Context, provider, code string, session AuthSession) (string, error)
+
 	// ViewUser retrieves user info for a given user ID and an authorized token.
 	ViewUser(ctx context.Context, token, id string) (User, error)
 
@@ -99,9 +178,15 @@ type Service interface {
 	// EnableUser logically enableds the user identified with the provided ID
 	EnableUser(ctx context.Context, token, id string) error
 
-	// DisableUser logically disables the user identified with the provided ID
+	// DisableUser logically disables the user identified with the provided ID.
+	// Two-factor authentication of the user is reset, so the user enrolls
+	// again once enabled.
 	DisableUser(ctx context.Context, token, id string) error
 
+	// UnlockUser removes the lockout and the failed login attempts of the
+	// user identified with the provided ID. Only accessible by admin.
+	UnlockUser(ctx context.Context, token, id string) error
+
 	// Backup returns admin and all users. Only accessible by admin.
 	Backup(ctx context.Context, token string) (User, []User, error)
 
@@ -109,6 +194,13 @@ type Service interface {
 	Restore(ctx context.Context, token string, admin User, users []User) error
 }
 
+// TOTPKey contains the TOTP secret generated on the enrollment.
+type TOTPKey struct {
+	Secret string
+	// URI is the otpauth URI of the secret, used by the authenticator apps.
+	URI string
+}
+
 // PageMetadata contains page metadata that helps navigation.
 type PageMetadata struct {
 	Total    uint64
@@ -130,25 +222,35 @@ var _ Service = (*usersService)(nil)
 type usersService struct {
 	users      UserRepository
 	hasher     Hasher
+	cipher     Cipher
 	email      Emailer
 	auth       mainflux.AuthServiceClient
 	idProvider mainflux.IDProvider
 	passRegex  *regexp.Regexp
+	providers  map[string]IdentityProvider
+	lockout    Lockout
 }
 
-// New instantiates the users service implementation
-func New(users UserRepository, hasher Hasher, auth mainflux.AuthServiceClient, e Emailer, idp mainflux.IDProvider, passRegex *regexp.Regexp) Service {
+// New instantiates the users service implementation. The cipher encrypts the
+// stored TOTP secrets. The providers are the identity providers used for the
+// single sign-on, identified by their names. The lockout throttles the login,
+// the password change and the password reset attempts; nil lockout disables
+// the throttling.
+func New(users UserRepository, hasher Hasher, cipher Cipher, auth mainflux.AuthServiceClient, e Emailer, idp mainflux.IDProvider, passRegex *regexp.Regexp, providers map[string]IdentityProvider, lockout Lockout) Service {
 	return &usersService{
 		users:      users,
 		hasher:     hasher,
+		cipher:     cipher,
 		auth:       auth,
 		email:      e,
 		idProvider: idp,
 		passRegex:  passRegex,
+		providers:  providers,
+		lockout:    lockout,
 	}
 }
 
-func (svc usersService) SelfRegister(ctx context.Context, user User) (string, error) {
+func (svc usersService) SelfRegister(ctx context.Context, user User, host string) (string, error) {
 	if !svc.passRegex.MatchString(user.Password) {
 		return "", ErrPasswordFormat
 	}
@@ -165,15 +267,66 @@ func (svc usersService) SelfRegister(ctx context.Context, user User) (string, er
 	}
 	user.Password = hash
 
-	user.Status = EnabledStatusKey
+	user.Status = PendingStatusKey
 
 	uid, err = svc.users.Save(ctx, user)
 	if err != nil {
 		return "", err
 	}
+
+	if err := svc.sendVerification(ctx, user, host); err != nil {
+		return "", err
+	}
+
 	return uid, nil
 }
 
+func (svc usersService) VerifyEmail(ctx context.Context, token string) error {
+	ir, err := svc.auth.IdentifyVerification(ctx, &mainflux.Token{Value: token})
+	if err != nil {
+		return errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	dbUser, err := svc.users.RetrieveByID(ctx, ir.GetId())
+	if err != nil {
+		return errors.Wrap(errors.ErrNotFound, err)
+	}
+	// Once the account is enabled, the verification link can't be used again.
+	if dbUser.Status != PendingStatusKey {
+		return ErrEmailVerified
+	}
+
+	return svc.users.ChangeStatus(ctx, ir.GetId(), EnabledStatusKey)
+}
+
+func (svc usersService) ResendVerification(ctx context.Context, email, host string) error {
+	// Every resend request counts as a failed attempt, so that the emails
+	// can't be flooded with the verification links.
+	keys := verifyKeys(ctx, email)
+	if err := svc.checkLockout(ctx, keys...); err != nil {
+		return err
+	}
+	if err := svc.fail(ctx, nil, keys...); err != nil {
+		return err
+	}
+
+	user, err := svc.users.RetrieveUnverified(ctx, email)
+	if err != nil || user.Email == "" {
+		return errors.ErrNotFound
+	}
+
+	return svc.sendVerification(ctx, user, host)
+}
+
+func (svc usersService) sendVerification(ctx context.Context, user User, host string) error {
+	t, err := svc.issue(ctx, user.ID, user.Email, auth.VerificationKey)
+	if err != nil {
+		return errors.Wrap(ErrVerificationToken, err)
+	}
+
+	return svc.email.SendVerification([]string{user.Email}, host, t)
+}
+
 func (svc usersService) RegisterAdmin(ctx context.Context, user User) error {
 	if u, err := svc.users.RetrieveByEmail(context.Background(), user.Email); err == nil {
 		role, err := svc.auth.RetrieveRole(ctx, &mainflux.RetrieveRoleReq{Id: u.ID})
@@ -275,16 +428,238 @@ func (svc usersService) Register(ctx context.Context, token string, user User) (
 }
 
 func (svc usersService) Login(ctx context.Context, user User) (string, error) {
-	dbUser, err := svc.users.RetrieveByEmail(ctx, user.Email)
+	keys := loginKeys(ctx, user.Email)
+	if err := svc.checkLockout(ctx, keys...); err != nil {
+		return "", err
+	}
+
+	dbUser, err := svc.authenticate(ctx, user)
 	if err != nil {
-		return "", errors.Wrap(errors.ErrAuthentication, err)
+		return "", svc.fail(ctx, err, keys...)
 	}
-	if err := svc.hasher.Compare(user.Password, dbUser.Password); err != nil {
+
+	// The failed attempts are kept until the second factor is verified as
+	// well, so they keep counting the wrong TOTP codes.
+	t, err := svc.users.RetrieveTOTP(ctx, dbUser.ID)
+	switch {
+	case err == nil && t.Enabled:
+		return "", ErrTOTPRequired
+	case err != nil && !errors.Contains(err, errors.ErrNotFound):
+		return "", err
+	}
+	svc.resetLockout(ctx, accountPrefix+user.Email)
+
+	return svc.issue(ctx, dbUser.ID, dbUser.Email, auth.LoginKey)
+}
+
+func (svc usersService) LoginTOTP(ctx context.Context, user User, code string) (string, error) {
+	keys := loginKeys(ctx, user.Email)
+	if err := svc.checkLockout(ctx, keys...); err != nil {
+		return "", err
+	}
+
+	dbUser, err := svc.authenticate(ctx, user)
+	if err != nil {
+		return "", svc.fail(ctx, err, keys...)
+	}
+
+	t, err := svc.users.RetrieveTOTP(ctx, dbUser.ID)
+	if err != nil {
 		return "", errors.Wrap(errors.ErrAuthentication, err)
 	}
+	if !t.Enabled {
+		return "", errors.ErrAuthentication
+	}
+
+	if err := svc.verifyCode(ctx, dbUser.ID, t, code); err != nil {
+		return "", svc.fail(ctx, err, keys...)
+	}
+	svc.resetLockout(ctx, accountPrefix+user.Email)
+
 	return svc.issue(ctx, dbUser.ID, dbUser.Email, auth.LoginKey)
 }
 
+func (svc usersService) EnrollTOTP(ctx context.Context, token string) (TOTPKey, error) {
+	ir, err := svc.identify(ctx, token)
+	if err != nil {
+		return TOTPKey{}, err
+	}
+
+	t, err := svc.users.RetrieveTOTP(ctx, ir.id)
+	switch {
+	case err == nil && t.Enabled:
+		return TOTPKey{}, ErrTOTPEnabled
+	case err != nil && !errors.Contains(err, errors.ErrNotFound):
+		return TOTPKey{}, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return TOTPKey{}, err
+	}
+	encrypted, err := svc.cipher.Encrypt(secret)
+	if err != nil {
+		return TOTPKey{}, err
+	}
+
+	// Pending enrollment is replaced, so only the latest secret can be activated.
+	if err := svc.users.SaveTOTP(ctx, ir.id, TOTP{Secret: encrypted}); err != nil {
+		return TOTPKey{}, err
+	}
+
+	return TOTPKey{
+		Secret: secret,
+		URI:    totp.URI(totpIssuer, ir.email, secret),
+	}, nil
+}
+
+func (svc usersService) ActivateTOTP(ctx context.Context, token, code string) ([]string, error) {
+	ir, err := svc.identify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := svc.users.RetrieveTOTP(ctx, ir.id)
+	if err != nil {
+		return nil, err
+	}
+	if t.Enabled {
+		return nil, ErrTOTPEnabled
+	}
+
+	if err := svc.verifyCode(ctx, ir.id, t, code); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodesNum)
+	t.RecoveryCodes = make([]string, recoveryCodesNum)
+	for i := range codes {
+		if codes[i], err = recoveryCode(); err != nil {
+			return nil, err
+		}
+		t.RecoveryCodes[i] = hashRecoveryCode(codes[i])
+	}
+	t.Enabled = true
+
+	if err := svc.users.SaveTOTP(ctx, ir.id, t); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (svc usersService) DeactivateTOTP(ctx context.Context, token, code string) error {
+	ir, err := svc.identify(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	t, err := svc.users.RetrieveTOTP(ctx, ir.id)
+	if err != nil {
+		return err
+	}
+	if !t.Enabled {
+		return errors.ErrNotFound
+	}
+
+	if err := svc.verifyCode(ctx, ir.id, t, code); err != nil {
+		return err
+	}
+
+	return svc.users.RemoveTOTP(ctx, ir.id)
+}
+
+func (svc usersService) OAuthURL(_ context.Context, provider string, session AuthSession) (string, error) {
+	idp, ok := svc.providers[provider]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	return idp.AuthCodeURL(session), nil
+}
+
+func (svc usersService) OAuthLogin(ctx context.Context, provider, code string, session AuthSession) (string, error) {
+	idp, ok := svc.providers[provider]
+	if !ok {
+		return "", ErrUnknownProvider
+	}
+
+	identity, err := idp.Exchange(ctx, code, session)
+	if err != nil {
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	user, err := svc.users.RetrieveByEmail(ctx, identity.Email)
+	switch {
+	case errors.Contains(err, errors.ErrNotFound):
+		if user, err = svc.registerIdentity(ctx, identity); err != nil {
+			return "", err
+		}
+	case err != nil:
+		return "", errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	// Two-factor authentication is left to the identity provider only if
+	// the provider asserts that the user is authenticated with multiple
+	// factors, so the enabled TOTP can't be skipped by the single sign-on.
+	if !identity.MFA {
+		t, err := svc.users.RetrieveTOTP(ctx, user.ID)
+		switch {
+		case err == nil && t.Enabled:
+			return "", ErrTOTPRequired
+		case err != nil && !errors.Contains(err, errors.ErrNotFound):
+			return "", err
+		}
+	}
+
+	for _, orgID := range identity.Orgs {
+		if _, err := svc.auth.JoinOrg(ctx, &mainflux.JoinOrgReq{OrgID: orgID, MemberID: user.ID}); err != nil {
+			return "", errors.Wrap(ErrOrgMembership, err)
+		}
+	}
+
+	return svc.issue(ctx, user.ID, user.Email, auth.LoginKey)
+}
+
+// registerIdentity creates the account of the user authenticated by the
+// identity provider. The account password is random, so the user can log
+// in with the password only after resetting it.
+func (svc usersService) registerIdentity(ctx context.Context, identity Identity) (User, error) {
+	user := User{
+		Email:  identity.Email,
+		Status: EnabledStatusKey,
+	}
+	if err := user.Validate(); err != nil {
+		return User{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+
+	uid, err := svc.idProvider.ID()
+	if err != nil {
+		return User{}, err
+	}
+	user.ID = uid
+
+	secret, err := svc.idProvider.ID()
+	if err != nil {
+		return User{}, err
+	}
+	hash, err := svc.hasher.Hash(secret)
+	if err != nil {
+		return User{}, errors.Wrap(errors.ErrMalformedEntity, err)
+	}
+	user.Password = hash
+
+	// Saving fails with conflict if the account exists, but it's disabled.
+	if _, err := svc.users.Save(ctx, user); err != nil {
+		if errors.Contains(err, errors.ErrConflict) {
+			return User{}, errors.Wrap(errors.ErrAuthentication, err)
+		}
+		return User{}, err
+	}
+
+	return user, nil
+}
+
 func (svc usersService) ViewUser(ctx context.Context, token, id string) (User, error) {
 	if _, err := svc.identify(ctx, token); err != nil {
 		return User{}, err
@@ -423,6 +798,16 @@ func (svc usersService) UpdateUser(ctx context.Context, token string, u User) er
 }
 
 func (svc usersService) GenerateResetToken(ctx context.Context, email, host string) error {
+	// Every reset request counts as a failed attempt, so that the emails
+	// can't be flooded with the reset links.
+	keys := resetKeys(ctx, email)
+	if err := svc.checkLockout(ctx, keys...); err != nil {
+		return err
+	}
+	if err := svc.fail(ctx, nil, keys...); err != nil {
+		return err
+	}
+
 	user, err := svc.users.RetrieveByEmail(ctx, email)
 	if err != nil || user.Email == "" {
 		return errors.ErrNotFound
@@ -464,14 +849,21 @@ func (svc usersService) ChangePassword(ctx context.Context, authToken, password,
 	if !svc.passRegex.MatchString(password) {
 		return ErrPasswordFormat
 	}
+	// The old password is checked as a login attempt, so a stolen session
+	// token can't be used to guess it.
+	keys := loginKeys(ctx, ir.email)
+	if err := svc.checkLockout(ctx, keys...); err != nil {
+		return err
+	}
 	u := User{
 		Email:    ir.email,
 		ID:       ir.id,
 		Password: oldPassword,
 	}
-	if _, err := svc.Login(ctx, u); err != nil {
-		return errors.ErrAuthentication
+	if _, err := svc.authenticate(ctx, u); err != nil {
+		return svc.fail(ctx, errors.ErrAuthentication, keys...)
 	}
+	svc.resetLockout(ctx, accountPrefix+ir.email)
 	u, err = svc.users.RetrieveByID(ctx, ir.id)
 	if err != nil || u.Email == "" {
 		return errors.ErrNotFound
@@ -500,11 +892,33 @@ func (svc usersService) DisableUser(ctx context.Context, token, id string) error
 	if err := svc.changeStatus(ctx, token, id, DisabledStatusKey); err != nil {
 		return err
 	}
+	return svc.users.RemoveTOTP(ctx, id)
+}
+
+func (svc usersService) UnlockUser(ctx context.Context, token, id string) error {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
+		return err
+	}
+
+	dbUser, err := svc.users.RetrieveByID(ctx, id)
+	if err != nil {
+		return errors.Wrap(errors.ErrNotFound, err)
+	}
+
+	if svc.lockout == nil {
+		return nil
+	}
+	for _, key := range []string{accountPrefix + dbUser.Email, resetAccountPrefix + dbUser.Email, verifyAccountPrefix + dbUser.Email} {
+		if err := svc.lockout.Reset(ctx, key); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (svc usersService) changeStatus(ctx context.Context, token, id, status string) error {
-	if _, err := svc.identify(ctx, token); err != nil {
+	if err := svc.authorize(ctx, rootSubject, token); err != nil {
 		return err
 	}
 
@@ -522,6 +936,81 @@ func (svc usersService) changeStatus(ctx context.Context, token, id, status stri
 	return svc.users.ChangeStatus(ctx, id, status)
 }
 
+// authenticate verifies the user credentials and returns the stored user.
+func (svc usersService) authenticate(ctx context.Context, user User) (User, error) {
+	dbUser, err := svc.users.RetrieveByEmail(ctx, user.Email)
+	if err != nil {
+		return User{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	if err := svc.hasher.Compare(user.Password, dbUser.Password); err != nil {
+		return User{}, errors.Wrap(errors.ErrAuthentication, err)
+	}
+	svc.rehash(ctx, user.Password, dbUser)
+
+	return dbUser, nil
+}
+
+// rehash replaces the stored password hash generated using an outdated
+// algorithm or parameters with the new one. The plain-text password is
+// available only on successful login, so the hashes are upgraded then.
+// Failures are ignored, since the stored hash remains valid.
+func (svc usersService) rehash(ctx context.Context, password string, dbUser User) {
+	if !svc.hasher.NeedsRehash(dbUser.Password) {
+		return
+	}
+
+	hash, err := svc.hasher.Hash(password)
+	if err != nil {
+		return
+	}
+	_ = svc.users.UpdatePassword(ctx, dbUser.Email, hash)
+}
+
+// verifyCode verifies the TOTP or a recovery code. Both are single-use,
+// so the counter of the used TOTP is stored and the used recovery code
+// is removed.
+func (svc usersService) verifyCode(ctx context.Context, id string, t TOTP, code string) error {
+	secret, err := svc.cipher.Decrypt(t.Secret)
+	if err != nil {
+		return err
+	}
+
+	code = strings.TrimSpace(code)
+	if counter, ok := totp.Verify(secret, code, time.Now(), t.Counter); ok {
+		err := svc.users.UpdateTOTPCounter(ctx, id, counter)
+		if errors.Contains(err, errors.ErrConflict) {
+			return errors.Wrap(errors.ErrAuthentication, ErrInvalidTOTPCode)
+		}
+		return err
+	}
+
+	hashed := []byte(hashRecoveryCode(code))
+	for i, rc := range t.RecoveryCodes {
+		if subtle.ConstantTimeCompare(hashed, []byte(rc)) == 1 {
+			t.RecoveryCodes = append(t.RecoveryCodes[:i:i], t.RecoveryCodes[i+1:]...)
+			return svc.users.SaveTOTP(ctx, id, t)
+		}
+	}
+
+	return errors.Wrap(errors.ErrAuthentication, ErrInvalidTOTPCode)
+}
+
+func recoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// hashRecoveryCode returns the SHA-256 hash of the recovery code. Unlike
+// passwords, recovery codes are random, so they don't need the slow hash.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(code)))
+	return hex.EncodeToString(sum[:])
+}
+
 // Auth helpers
 func (svc usersService) issue(ctx context.Context, id, email string, keyType uint32) (string, error) {
 	key, err := svc.auth.Issue(ctx, &mainflux.IssueReq{Id: id, Email: email, Type: keyType})
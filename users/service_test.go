@@ -8,20 +8,29 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux"
+	"github.com/MainfluxLabs/mainflux/audit"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/mocks"
 	"github.com/MainfluxLabs/mainflux/pkg/uuid"
 	"github.com/MainfluxLabs/mainflux/users"
 	usmocks "github.com/MainfluxLabs/mainflux/users/mocks"
+	"github.com/MainfluxLabs/mainflux/users/totp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const (
-	wrong   = "wrong-value"
-	userNum = 101
+	wrong    = "wrong-value"
+	userNum  = 101
+	provider = "idp"
+	orgID    = "org-1"
+	state    = "state"
+
+	lockoutAttempts = 3
+	lockoutCooldown = time.Minute
 )
 
 var (
@@ -32,7 +41,15 @@ var (
 	user            = users.User{Email: "user@example.com", ID: "574106f7-030e-4881-8ab0-151195c29f96"}
 	nonExistingUser = users.User{Email: "non-ex-user@example.com", Password: "password"}
 	usersList       = []users.User{admin, registerUser, user, unauthUser}
-	host            = "example.com"
+	oauthUser       = users.User{Email: "oauth-user@example.com", ID: "574106f7-030e-4881-8ab0-151195c29f97"}
+	identities      = map[string]users.Identity{
+		"user-code":     {Email: user.Email},
+		"user-mfa-code": {Email: user.Email, MFA: true},
+		"new-code":      {Email: oauthUser.Email, Orgs: []string{orgID}},
+		"invalid-code":  {Email: wrong},
+	}
+	host    = "example.com"
+	session = users.AuthSession{State: state, Nonce: "nonce", Verifier: "verifier"}
 
 	idProvider = uuid.New()
 	passRegex  = regexp.MustCompile("^.{8,}$")
@@ -40,11 +57,14 @@ var (
 
 func newService() users.Service {
 	hasher := usmocks.NewHasher()
+	cipher := usmocks.NewCipher()
 	userRepo := usmocks.NewUserRepository(usersList)
-	authSvc := mocks.NewAuthService(admin.ID, usersList)
+	authSvc := mocks.NewAuthService(admin.ID, append(usersList, oauthUser))
 	e := usmocks.NewEmailer()
+	providers := map[string]users.IdentityProvider{provider: usmocks.NewIdentityProvider(identities)}
+	lockout := usmocks.NewLockout(lockoutAttempts, lockoutCooldown)
 
-	return users.New(userRepo, hasher, authSvc, e, idProvider, passRegex)
+	return users.New(userRepo, hasher, cipher, authSvc, e, idProvider, passRegex, providers, lockout)
 }
 
 func TestSelfRegister(t *testing.T) {
@@ -90,11 +110,114 @@ func TestSelfRegister(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		_, err := svc.SelfRegister(context.Background(), tc.user)
+		_, err := svc.SelfRegister(context.Background(), tc.user, host)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	svc := newService()
+
+	id, err := svc.SelfRegister(context.Background(), selfRegister, host)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	// Verification token of the mocked auth service is the user email.
+	token := selfRegister.Email
+
+	cases := []struct {
+		desc   string
+		token  string
+		status string
+		err    error
+	}{
+		{
+			desc:   "verify email with invalid token",
+			token:  wrong,
+			status: users.PendingStatusKey,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "verify email with login token",
+			token:  user.Email,
+			status: users.PendingStatusKey,
+			err:    errors.ErrAuthentication,
+		},
+		{
+			desc:   "verify email",
+			token:  token,
+			status: users.EnabledStatusKey,
+			err:    nil,
+		},
+		{
+			desc:   "verify already verified email",
+			token:  token,
+			status: users.EnabledStatusKey,
+			err:    users.ErrEmailVerified,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.VerifyEmail(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+
+		u, err := svc.ViewUser(context.Background(), admin.Email, id)
+		require.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+		assert.Equal(t, tc.status, u.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, u.Status))
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	svc := newService()
+
+	_, err := svc.SelfRegister(context.Background(), selfRegister, host)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		email string
+		err   error
+	}{
+		{
+			desc:  "resend verification to pending user",
+			email: selfRegister.Email,
+			err:   nil,
+		},
+		{
+			desc:  "resend verification to enabled user",
+			email: registerUser.Email,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "resend verification to non-existing user",
+			email: nonExistingUser.Email,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ResendVerification(context.Background(), tc.email, host)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
+func TestResendVerificationLockout(t *testing.T) {
+	svc := newService()
+
+	_, err := svc.SelfRegister(context.Background(), selfRegister, host)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	for i := 1; i < lockoutAttempts; i++ {
+		err := svc.ResendVerification(context.Background(), selfRegister.Email, host)
+		require.Nil(t, err, fmt.Sprintf("unexpected resend verification error: %s", err))
+	}
+
+	err = svc.ResendVerification(context.Background(), selfRegister.Email, host)
+	assert.True(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("resend verification over attempts limit: expected %s got %s\n", users.ErrAccountLocked, err))
+
+	err = svc.ResendVerification(context.Background(), selfRegister.Email, host)
+	assert.True(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("resend verification during lockout: expected %s got %s\n", users.ErrAccountLocked, err))
+}
+
 func TestLogin(t *testing.T) {
 	svc := newService()
 
@@ -132,6 +255,551 @@ func TestLogin(t *testing.T) {
 	}
 }
 
+func TestLoginRehash(t *testing.T) {
+	legacyUser := users.User{Email: "legacy-user@example.com", ID: "574106f7-030e-4881-8ab0-151195c29f98", Password: usmocks.LegacyPrefix + "password"}
+	userRepo := usmocks.NewUserRepository(append(usersList, legacyUser))
+	authSvc := mocks.NewAuthService(admin.ID, append(usersList, legacyUser))
+	svc := users.New(userRepo, usmocks.NewHasher(), usmocks.NewCipher(), authSvc, usmocks.NewEmailer(), idProvider, passRegex, nil, nil)
+
+	_, err := svc.Login(context.Background(), users.User{Email: legacyUser.Email, Password: wrong})
+	assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("login with wrong password: expected %s got %s\n", errors.ErrAuthentication, err))
+	dbUser, err := userRepo.RetrieveByEmail(context.Background(), legacyUser.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, legacyUser.Password, dbUser.Password, fmt.Sprintf("login with wrong password: expected hash %s got %s\n", legacyUser.Password, dbUser.Password))
+
+	_, err = svc.Login(context.Background(), users.User{Email: legacyUser.Email, Password: "password"})
+	assert.Nil(t, err, fmt.Sprintf("login with legacy hash: unexpected error: %s", err))
+	dbUser, err = userRepo.RetrieveByEmail(context.Background(), legacyUser.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, "password", dbUser.Password, fmt.Sprintf("login with legacy hash: expected rehashed password got %s\n", dbUser.Password))
+
+	_, err = svc.Login(context.Background(), users.User{Email: legacyUser.Email, Password: "password"})
+	assert.Nil(t, err, fmt.Sprintf("login with rehashed password: unexpected error: %s", err))
+}
+
+func TestLoginLockout(t *testing.T) {
+	svc := newService()
+	wrongPass := users.User{Email: registerUser.Email, Password: wrong}
+
+	for i := 1; i < lockoutAttempts; i++ {
+		_, err := svc.Login(context.Background(), wrongPass)
+		assert.False(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("failed attempt %d: expected account not to be locked\n", i))
+	}
+
+	cases := []struct {
+		desc string
+		user users.User
+		err  error
+	}{
+		{
+			desc: "login with wrong password reaching attempts limit",
+			user: wrongPass,
+			err:  users.ErrAccountLocked,
+		},
+		{
+			desc: "login with good credentials to locked account",
+			user: registerUser,
+			err:  users.ErrAccountLocked,
+		},
+		{
+			desc: "login with good credentials to another account",
+			user: admin,
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Login(context.Background(), tc.user)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err := svc.Login(context.Background(), wrongPass)
+	assert.False(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("login to locked account: expected password not to be verified got %s\n", err))
+}
+
+func TestLoginLockoutBySourceIP(t *testing.T) {
+	svc := newService()
+	ctx := audit.WithSourceIP(context.Background(), "10.0.0.1")
+
+	for i := 0; i < lockoutAttempts; i++ {
+		u := users.User{Email: fmt.Sprintf("unknown%d@example.com", i), Password: wrong}
+		_, err := svc.Login(ctx, u)
+		assert.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("failed attempt %d: expected %s got %s\n", i, errors.ErrAuthentication, err))
+	}
+
+	cases := []struct {
+		desc string
+		ctx  context.Context
+		err  error
+	}{
+		{
+			desc: "login from locked source IP",
+			ctx:  ctx,
+			err:  users.ErrAccountLocked,
+		},
+		{
+			desc: "login from another source IP",
+			ctx:  audit.WithSourceIP(context.Background(), "10.0.0.2"),
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.Login(tc.ctx, registerUser)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestLoginTOTPLockout(t *testing.T) {
+	svc := newService()
+	enableTOTP(t, svc, registerUser.Email)
+
+	var err error
+	for i := 0; i < lockoutAttempts; i++ {
+		_, err = svc.LoginTOTP(context.Background(), registerUser, wrong)
+	}
+	assert.True(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("login with invalid codes: expected %s got %s\n", users.ErrAccountLocked, err))
+
+	_, err = svc.Login(context.Background(), registerUser)
+	assert.True(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("login to locked account: expected %s got %s\n", users.ErrAccountLocked, err))
+}
+
+func TestLoginTOTPLockoutAfterPasswordLogin(t *testing.T) {
+	svc := newService()
+	enableTOTP(t, svc, registerUser.Email)
+
+	// The password step succeeds before each code, so it mustn't reset the failed codes.
+	var err error
+	for i := 0; i < lockoutAttempts; i++ {
+		_, err = svc.Login(context.Background(), registerUser)
+		require.True(t, errors.Contains(err, users.ErrTOTPRequired), fmt.Sprintf("login with TOTP enabled: expected %s got %s\n", users.ErrTOTPRequired, err))
+		_, err = svc.LoginTOTP(context.Background(), registerUser, wrong)
+	}
+	assert.True(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("login with invalid codes: expected %s got %s\n", users.ErrAccountLocked, err))
+}
+
+func TestOAuthURL(t *testing.T) {
+	svc := newService()
+
+	cases := map[string]struct {
+		provider string
+		url      string
+		err      error
+	}{
+		"get login URL": {
+			provider: provider,
+			url:      usmocks.AuthURL + "?state=" + state,
+			err:      nil,
+		},
+		"get login URL of unknown provider": {
+			provider: wrong,
+			url:      "",
+			err:      users.ErrUnknownProvider,
+		},
+	}
+
+	for desc, tc := range cases {
+		url, err := svc.OAuthURL(context.Background(), tc.provider, session)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		assert.Equal(t, tc.url, url, fmt.Sprintf("%s: expected %s got %s\n", desc, tc.url, url))
+	}
+}
+
+func TestOAuthLogin(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc     string
+		provider string
+		code     string
+		err      error
+	}{
+		{
+			desc:     "login existing user",
+			provider: provider,
+			code:     "user-code",
+			err:      nil,
+		},
+		{
+			desc:     "login new user",
+			provider: provider,
+			code:     "new-code",
+			err:      nil,
+		},
+		{
+			desc:     "login new user again",
+			provider: provider,
+			code:     "new-code",
+			err:      nil,
+		},
+		{
+			desc:     "login with invalid email",
+			provider: provider,
+			code:     "invalid-code",
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "login with wrong code",
+			provider: provider,
+			code:     wrong,
+			err:      errors.ErrAuthentication,
+		},
+		{
+			desc:     "login with unknown provider",
+			provider: wrong,
+			code:     "user-code",
+			err:      users.ErrUnknownProvider,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.OAuthLogin(context.Background(), tc.provider, tc.code, session)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	us, err := svc.ListUsersByEmails(context.Background(), []string{oauthUser.Email})
+	require.Nil(t, err, fmt.Sprintf("retrieving created user expected to succeed: %s", err))
+	assert.Len(t, us, 1, fmt.Sprintf("expected one created user got %d\n", len(us)))
+	assert.Equal(t, users.EnabledStatusKey, us[0].Status, fmt.Sprintf("expected status %s got %s\n", users.EnabledStatusKey, us[0].Status))
+}
+
+func enableTOTP(t *testing.T, svc users.Service, token string) (string, []string) {
+	key, err := svc.EnrollTOTP(context.Background(), token)
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP enrollment error: %s", err))
+	// Codes are single-use, so the code of the previous period is used
+	// in order to keep the current one valid.
+	code, err := totp.Code(key.Secret, time.Now().Add(-totp.Period))
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+	codes, err := svc.ActivateTOTP(context.Background(), token, code)
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP activation error: %s", err))
+
+	return key.Secret, codes
+}
+
+func TestOAuthLoginTOTP(t *testing.T) {
+	svc := newService()
+	enableTOTP(t, svc, user.Email)
+
+	cases := []struct {
+		desc string
+		code string
+		err  error
+	}{
+		{
+			desc: "login user with TOTP enabled",
+			code: "user-code",
+			err:  users.ErrTOTPRequired,
+		},
+		{
+			desc: "login user with TOTP enabled authenticated with multiple factors",
+			code: "user-mfa-code",
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.OAuthLogin(context.Background(), provider, tc.code, session)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	svc := newService()
+	enableTOTP(t, svc, user.Email)
+
+	cases := map[string]struct {
+		token string
+		err   error
+	}{
+		"enroll TOTP": {
+			token: registerUser.Email,
+			err:   nil,
+		},
+		"enroll TOTP with pending enrollment": {
+			token: registerUser.Email,
+			err:   nil,
+		},
+		"enroll TOTP with TOTP enabled": {
+			token: user.Email,
+			err:   users.ErrTOTPEnabled,
+		},
+		"enroll TOTP with invalid token": {
+			token: wrong,
+			err:   errors.ErrAuthentication,
+		},
+	}
+
+	for desc, tc := range cases {
+		key, err := svc.EnrollTOTP(context.Background(), tc.token)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", desc, tc.err, err))
+		if err == nil {
+			assert.NotEmpty(t, key.Secret, fmt.Sprintf("%s: expected secret to be generated\n", desc))
+			assert.Contains(t, key.URI, key.Secret, fmt.Sprintf("%s: expected URI to contain the secret\n", desc))
+		}
+	}
+}
+
+func TestActivateTOTP(t *testing.T) {
+	svc := newService()
+
+	key, err := svc.EnrollTOTP(context.Background(), registerUser.Email)
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP enrollment error: %s", err))
+	code, err := totp.Code(key.Secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+	expired, err := totp.Code(key.Secret, time.Now().Add(-3*totp.Period))
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "activate TOTP with invalid code",
+			token: registerUser.Email,
+			code:  wrong,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "activate TOTP with expired code",
+			token: registerUser.Email,
+			code:  expired,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "activate TOTP with invalid token",
+			token: wrong,
+			code:  code,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "activate TOTP without enrollment",
+			token: user.Email,
+			code:  code,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "activate TOTP",
+			token: registerUser.Email,
+			code:  code,
+			err:   nil,
+		},
+		{
+			desc:  "activate activated TOTP",
+			token: registerUser.Email,
+			code:  code,
+			err:   users.ErrTOTPEnabled,
+		},
+	}
+
+	for _, tc := range cases {
+		codes, err := svc.ActivateTOTP(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Len(t, codes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d\n", tc.desc, len(codes)))
+		}
+	}
+}
+
+func TestLoginTOTP(t *testing.T) {
+	svc := newService()
+	secret, recoveryCodes := enableTOTP(t, svc, registerUser.Email)
+
+	_, err := svc.Login(context.Background(), registerUser)
+	assert.Equal(t, users.ErrTOTPRequired, err, fmt.Sprintf("login with TOTP enabled: expected %s got %s\n", users.ErrTOTPRequired, err))
+
+	code, err := totp.Code(secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+
+	cases := []struct {
+		desc string
+		user users.User
+		code string
+		err  error
+	}{
+		{
+			desc: "login with TOTP code",
+			user: registerUser,
+			code: code,
+			err:  nil,
+		},
+		{
+			desc: "login with used TOTP code",
+			user: registerUser,
+			code: code,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login with recovery code",
+			user: registerUser,
+			code: recoveryCodes[0],
+			err:  nil,
+		},
+		{
+			desc: "login with used recovery code",
+			user: registerUser,
+			code: recoveryCodes[0],
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login with another recovery code",
+			user: registerUser,
+			code: recoveryCodes[1],
+			err:  nil,
+		},
+		{
+			desc: "login with invalid code",
+			user: registerUser,
+			code: wrong,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login with wrong password",
+			user: users.User{Email: registerUser.Email, Password: wrong},
+			code: code,
+			err:  errors.ErrAuthentication,
+		},
+		{
+			desc: "login without TOTP enabled",
+			user: admin,
+			code: code,
+			err:  errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		_, err := svc.LoginTOTP(context.Background(), tc.user, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	err = svc.ChangePassword(context.Background(), registerUser.Email, "newpassword", registerUser.Password)
+	assert.Nil(t, err, fmt.Sprintf("change password with TOTP enabled: expected no error got %s\n", err))
+}
+
+func TestDeactivateTOTP(t *testing.T) {
+	svc := newService()
+	secret, _ := enableTOTP(t, svc, registerUser.Email)
+
+	code, err := totp.Code(secret, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected TOTP code generation error: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		code  string
+		err   error
+	}{
+		{
+			desc:  "deactivate TOTP with invalid code",
+			token: registerUser.Email,
+			code:  wrong,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "deactivate TOTP with invalid token",
+			token: wrong,
+			code:  code,
+			err:   errors.ErrAuthentication,
+		},
+		{
+			desc:  "deactivate TOTP",
+			token: registerUser.Email,
+			code:  code,
+			err:   nil,
+		},
+		{
+			desc:  "deactivate deactivated TOTP",
+			token: registerUser.Email,
+			code:  code,
+			err:   errors.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.DeactivateTOTP(context.Background(), tc.token, tc.code)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Login(context.Background(), registerUser)
+	assert.Nil(t, err, fmt.Sprintf("login with TOTP deactivated: expected no error got %s\n", err))
+}
+
+func TestDisableUserResetsTOTP(t *testing.T) {
+	svc := newService()
+	enableTOTP(t, svc, registerUser.Email)
+
+	err := svc.DisableUser(context.Background(), admin.Email, registerUser.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected user disabling error: %s", err))
+	err = svc.EnableUser(context.Background(), admin.Email, registerUser.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected user enabling error: %s", err))
+
+	_, err = svc.Login(context.Background(), registerUser)
+	assert.Nil(t, err, fmt.Sprintf("login after TOTP reset: expected no error got %s\n", err))
+}
+
+func TestDisableUserAsNonAdmin(t *testing.T) {
+	svc := newService()
+	enableTOTP(t, svc, registerUser.Email)
+
+	err := svc.DisableUser(context.Background(), unauthUser.Email, registerUser.ID)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("disable user as non-admin: expected %s got %s\n", errors.ErrAuthorization, err))
+	err = svc.EnableUser(context.Background(), unauthUser.Email, registerUser.ID)
+	assert.True(t, errors.Contains(err, errors.ErrAuthorization), fmt.Sprintf("enable user as non-admin: expected %s got %s\n", errors.ErrAuthorization, err))
+
+	_, err = svc.Login(context.Background(), registerUser)
+	assert.True(t, errors.Contains(err, users.ErrTOTPRequired), fmt.Sprintf("login after rejected TOTP reset: expected %s got %s\n", users.ErrTOTPRequired, err))
+}
+
+func TestUnlockUser(t *testing.T) {
+	svc := newService()
+
+	for i := 0; i < lockoutAttempts; i++ {
+		_, err := svc.Login(context.Background(), users.User{Email: registerUser.Email, Password: wrong})
+		require.True(t, errors.Contains(err, errors.ErrAuthentication), fmt.Sprintf("unexpected login error: %s", err))
+	}
+
+	cases := []struct {
+		desc  string
+		token string
+		id    string
+		err   error
+	}{
+		{
+			desc:  "unlock user as non-admin",
+			token: unauthUser.Email,
+			id:    registerUser.ID,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "unlock user with invalid token",
+			token: wrong,
+			id:    registerUser.ID,
+			err:   errors.ErrAuthorization,
+		},
+		{
+			desc:  "unlock non-existing user",
+			token: admin.Email,
+			id:    wrong,
+			err:   errors.ErrNotFound,
+		},
+		{
+			desc:  "unlock user as admin",
+			token: admin.Email,
+			id:    registerUser.ID,
+			err:   nil,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.UnlockUser(context.Background(), tc.token, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err := svc.Login(context.Background(), registerUser)
+	assert.Nil(t, err, fmt.Sprintf("login to unlocked account: expected no error got %s\n", err))
+}
+
 func TestViewUser(t *testing.T) {
 	svc := newService()
 
@@ -231,7 +899,7 @@ func TestListUsers(t *testing.T) {
 			Email:    email,
 			Password: "passpass",
 		}
-		_, err := svc.SelfRegister(context.Background(), user)
+		_, err := svc.SelfRegister(context.Background(), user, host)
 		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 	}
 	totUser = totUser + nUsers
@@ -356,6 +1024,21 @@ func TestGenerateResetToken(t *testing.T) {
 	}
 }
 
+func TestGenerateResetTokenLockout(t *testing.T) {
+	svc := newService()
+
+	for i := 1; i < lockoutAttempts; i++ {
+		err := svc.GenerateResetToken(context.Background(), registerUser.Email, host)
+		require.Nil(t, err, fmt.Sprintf("unexpected reset token error: %s", err))
+	}
+
+	err := svc.GenerateResetToken(context.Background(), registerUser.Email, host)
+	assert.True(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("reset token over attempts limit: expected %s got %s\n", users.ErrAccountLocked, err))
+
+	_, err = svc.Login(context.Background(), registerUser)
+	assert.Nil(t, err, fmt.Sprintf("login after reset token lockout: expected no error got %s\n", err))
+}
+
 func TestChangePassword(t *testing.T) {
 	svc := newService()
 	token, _ := svc.Login(context.Background(), registerUser)
@@ -378,6 +1061,46 @@ func TestChangePassword(t *testing.T) {
 	}
 }
 
+func TestChangePasswordLockout(t *testing.T) {
+	svc := newService()
+	ctx := audit.WithSourceIP(context.Background(), "10.0.0.1")
+	token, err := svc.Login(context.Background(), registerUser)
+	require.Nil(t, err, fmt.Sprintf("unexpected login error: %s\n", err))
+
+	for i := 1; i < lockoutAttempts; i++ {
+		err := svc.ChangePassword(ctx, token, "newpassword", wrong)
+		assert.False(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("failed attempt %d: expected account not to be locked\n", i))
+	}
+
+	cases := []struct {
+		desc        string
+		ctx         context.Context
+		oldPassword string
+		err         error
+	}{
+		{
+			desc:        "change password with wrong password reaching attempts limit",
+			ctx:         ctx,
+			oldPassword: wrong,
+			err:         users.ErrAccountLocked,
+		},
+		{
+			desc:        "change password with good password of locked account",
+			ctx:         audit.WithSourceIP(context.Background(), "10.0.0.2"),
+			oldPassword: registerUser.Password,
+			err:         users.ErrAccountLocked,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.ChangePassword(tc.ctx, token, "newpassword", tc.oldPassword)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+
+	_, err = svc.Login(ctx, users.User{Email: admin.Email, Password: admin.Password})
+	assert.True(t, errors.Contains(err, users.ErrAccountLocked), fmt.Sprintf("login from locked source IP: expected %s got %s\n", users.ErrAccountLocked, err))
+}
+
 func TestResetPassword(t *testing.T) {
 	svc := newService()
 	authSvc := mocks.NewAuthService("", []users.User{registerUser})
@@ -0,0 +1,122 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+// Package totp provides time-based one-time password generation and
+// validation as specified by RFC 6238, compatible with authenticator apps.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the number of digits of the generated codes.
+	Digits = 6
+
+	// Period is the validity period of the generated codes.
+	Period = 30 * time.Second
+
+	// Codes of the adjacent periods are accepted to tolerate clock skew.
+	skew       = 1
+	secretSize = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32 encoded secret.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(b), nil
+}
+
+// URI returns the otpauth URI of the secret, used to enroll the secret
+// in an authenticator app, usually by scanning it as a QR code.
+func URI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(Digits))
+	v.Set("period", fmt.Sprint(int(Period.Seconds())))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, v.Encode())
+}
+
+// Code returns the code of the secret for the given time.
+func Code(secret string, t time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	return code(key, uint64(t.Unix())/uint64(Period.Seconds())), nil
+}
+
+// Validate reports whether the code is valid for the secret at the given
+// time. Codes of the previous and the next period are valid as well.
+func Validate(secret, code string, t time.Time) bool {
+	_, ok := Verify(secret, code, t, -1)
+	return ok
+}
+
+// Verify validates the code the same way as Validate, accepting only the
+// codes of the periods after the last accepted one. It returns the counter
+// of the code period, which is stored as the last accepted counter, so each
+// code can be used only once.
+func Verify(secret, code string, t time.Time, last int64) (int64, bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+
+	counter := int64(t.Unix()) / int64(Period.Seconds())
+	for i := -skew; i <= skew; i++ {
+		c := counter + int64(i)
+		if c > last && hmac.Equal([]byte(codeAt(key, c)), []byte(code)) {
+			return c, true
+		}
+	}
+
+	return 0, false
+}
+
+func codeAt(key []byte, counter int64) string {
+	if counter < 0 {
+		return ""
+	}
+
+	return code(key, uint64(counter))
+}
+
+// code implements the HOTP algorithm specified by RFC 4226.
+func code(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < Digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%mod)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Mainflux
+// SPDX-License-Identifier: Apache-2.0
+
+package totp_test
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/MainfluxLabs/mainflux/users/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Secret of the RFC 6238 test vectors, "12345678901234567890" base32 encoded.
+const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	cases := []struct {
+		desc string
+		time int64
+		code string
+	}{
+		{desc: "generate code at 59", time: 59, code: "287082"},
+		{desc: "generate code at 1111111109", time: 1111111109, code: "081804"},
+		{desc: "generate code at 1111111111", time: 1111111111, code: "050471"},
+		{desc: "generate code at 1234567890", time: 1234567890, code: "005924"},
+		{desc: "generate code at 2000000000", time: 2000000000, code: "279037"},
+	}
+
+	for _, tc := range cases {
+		code, err := totp.Code(secret, time.Unix(tc.time, 0))
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s\n", tc.desc, err))
+		assert.Equal(t, tc.code, code, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.code, code))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Now()
+	code, err := totp.Code(secret, now)
+	require.Nil(t, err, fmt.Sprintf("unexpected code generation error: %s", err))
+
+	cases := []struct {
+		desc   string
+		secret string
+		code   string
+		time   time.Time
+		valid  bool
+	}{
+		{
+			desc:   "validate current code",
+			secret: secret,
+			code:   code,
+			time:   now,
+			valid:  true,
+		},
+		{
+			desc:   "validate code of the previous period",
+			secret: secret,
+			code:   code,
+			time:   now.Add(totp.Period),
+			valid:  true,
+		},
+		{
+			desc:   "validate code of the next period",
+			secret: secret,
+			code:   code,
+			time:   now.Add(-totp.Period),
+			valid:  true,
+		},
+		{
+			desc:   "validate expired code",
+			secret: secret,
+			code:   code,
+			time:   now.Add(3 * totp.Period),
+			valid:  false,
+		},
+		{
+			desc:   "validate code with lowercase secret",
+			secret: "gezdgnbvgy3tqojqgezdgnbvgy3tqojq",
+			code:   code,
+			time:   now,
+			valid:  true,
+		},
+		{
+			desc:   "validate code with invalid secret",
+			secret: "invalid!",
+			code:   code,
+			time:   now,
+			valid:  false,
+		},
+		{
+			desc:   "validate code with invalid length",
+			secret: secret,
+			code:   code[1:],
+			time:   now,
+			valid:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		valid := totp.Validate(tc.secret, tc.code, tc.time)
+		assert.Equal(t, tc.valid, valid, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.valid, valid))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Code of the RFC 6238 test vector at 1111111109.
+	now := time.Unix(1111111109, 0)
+	code := "081804"
+	counter := now.Unix() / int64(totp.Period.Seconds())
+
+	cases := []struct {
+		desc    string
+		code    string
+		last    int64
+		counter int64
+		valid   bool
+	}{
+		{
+			desc:    "verify unused code",
+			code:    code,
+			last:    counter - 1,
+			counter: counter,
+			valid:   true,
+		},
+		{
+			desc:    "verify used code",
+			code:    code,
+			last:    counter,
+			counter: 0,
+			valid:   false,
+		},
+		{
+			desc:    "verify code older than the last used one",
+			code:    code,
+			last:    counter + 1,
+			counter: 0,
+			valid:   false,
+		},
+		{
+			desc:    "verify invalid code",
+			code:    "000000",
+			last:    counter - 1,
+			counter: 0,
+			valid:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		c, valid := totp.Verify(secret, tc.code, now, tc.last)
+		assert.Equal(t, tc.valid, valid, fmt.Sprintf("%s: expected %t got %t\n", tc.desc, tc.valid, valid))
+		assert.Equal(t, tc.counter, c, fmt.Sprintf("%s: expected counter %d got %d\n", tc.desc, tc.counter, c))
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	s, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected secret generation error: %s", err))
+
+	other, err := totp.GenerateSecret()
+	require.Nil(t, err, fmt.Sprintf("unexpected secret generation error: %s", err))
+	assert.NotEqual(t, s, other, "expected generated secrets to differ")
+
+	code, err := totp.Code(s, time.Now())
+	require.Nil(t, err, fmt.Sprintf("unexpected code generation error: %s", err))
+	assert.True(t, totp.Validate(s, code, time.Now()), "expected code of generated secret to be valid")
+}
+
+func TestURI(t *testing.T) {
+	uri := totp.URI("Mainflux", "user@example.com", secret)
+
+	u, err := url.Parse(uri)
+	require.Nil(t, err, fmt.Sprintf("unexpected URI parsing error: %s", err))
+	assert.Equal(t, "otpauth", u.Scheme, fmt.Sprintf("expected scheme otpauth got %s\n", u.Scheme))
+	assert.Equal(t, "totp", u.Host, fmt.Sprintf("expected type totp got %s\n", u.Host))
+	assert.Equal(t, "/Mainflux:user@example.com", u.Path, fmt.Sprintf("expected label Mainflux:user@example.com got %s\n", u.Path))
+	assert.Equal(t, secret, u.Query().Get("secret"), fmt.Sprintf("expected secret %s got %s\n", secret, u.Query().Get("secret")))
+	assert.Equal(t, "Mainflux", u.Query().Get("issuer"), fmt.Sprintf("expected issuer Mainflux got %s\n", u.Query().Get("issuer")))
+}
@@ -7,20 +7,27 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/users"
 	opentracing "github.com/opentracing/opentracing-go"
 )
 
 const (
-	saveOp            = "save"
-	updateOp          = "update"
-	retrieveByEmailOp = "retrieve_by_email"
-	retrieveByIDOp    = "retrieve_by_id"
-	retrieveByIDsOp   = "retrieve_by_ids"
-	retrieveAllOp     = "retrieve_all"
-	updatePasswordOp  = "update_password"
-	changeStatusOp    = "change_status"
+	saveOp               = "save"
+	updateOp             = "update"
+	retrieveByEmailOp    = "retrieve_by_email"
+	retrieveByIDOp       = "retrieve_by_id"
+	retrieveByIDsOp      = "retrieve_by_ids"
+	retrieveAllOp        = "retrieve_all"
+	updatePasswordOp     = "update_password"
+	changeStatusOp       = "change_status"
+	saveTOTPOp           = "save_totp"
+	retrieveTOTPOp       = "retrieve_totp"
+	removeTOTPOp         = "remove_totp"
+	updateTOTPCounterOp  = "update_totp_counter"
+	retrieveUnverifiedOp = "retrieve_unverified"
+	removeUnverifiedOp   = "remove_unverified"
 )
 
 var _ users.UserRepository = (*userRepositoryMiddleware)(nil)
@@ -103,6 +110,54 @@ func (urm userRepositoryMiddleware) ChangeStatus(ctx context.Context, id, status
 	return urm.repo.ChangeStatus(ctx, id, status)
 }
 
+func (urm userRepositoryMiddleware) SaveTOTP(ctx context.Context, id string, t users.TOTP) error {
+	span := createSpan(ctx, urm.tracer, saveTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.SaveTOTP(ctx, id, t)
+}
+
+func (urm userRepositoryMiddleware) RetrieveTOTP(ctx context.Context, id string) (users.TOTP, error) {
+	span := createSpan(ctx, urm.tracer, retrieveTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RetrieveTOTP(ctx, id)
+}
+
+func (urm userRepositoryMiddleware) RemoveTOTP(ctx context.Context, id string) error {
+	span := createSpan(ctx, urm.tracer, removeTOTPOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RemoveTOTP(ctx, id)
+}
+
+func (urm userRepositoryMiddleware) UpdateTOTPCounter(ctx context.Context, id string, counter int64) error {
+	span := createSpan(ctx, urm.tracer, updateTOTPCounterOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.UpdateTOTPCounter(ctx, id, counter)
+}
+
+func (urm userRepositoryMiddleware) RetrieveUnverified(ctx context.Context, email string) (users.User, error) {
+	span := createSpan(ctx, urm.tracer, retrieveUnverifiedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RetrieveUnverified(ctx, email)
+}
+
+func (urm userRepositoryMiddleware) RemoveUnverified(ctx context.Context, before time.Time) (uint64, error) {
+	span := createSpan(ctx, urm.tracer, removeUnverifiedOp)
+	defer span.Finish()
+	ctx = opentracing.ContextWithSpan(ctx, span)
+
+	return urm.repo.RemoveUnverified(ctx, before)
+}
+
 func createSpan(ctx context.Context, tracer opentracing.Tracer, opName string) opentracing.Span {
 	if parentSpan := opentracing.SpanFromContext(ctx); parentSpan != nil {
 		return tracer.StartSpan(
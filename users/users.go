@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"golang.org/x/net/idna"
@@ -43,6 +44,21 @@ type User struct {
 	Role     string
 }
 
+// TOTP represents the two-factor authentication settings of the user.
+type TOTP struct {
+	// Secret is the encrypted TOTP secret.
+	Secret string
+
+	// Enabled indicates that the enrollment is activated with a valid code.
+	Enabled bool
+
+	// RecoveryCodes are SHA-256 hashes of the unused recovery codes.
+	RecoveryCodes []string
+
+	// Counter is the time step counter of the last accepted code.
+	Counter int64
+}
+
 // Validate returns an error if user representation is invalid.
 func (u User) Validate() error {
 	if !isEmail(u.Email) {
@@ -77,6 +93,30 @@ type UserRepository interface {
 
 	// RetrieveAll retrieves all users.
 	RetrieveAll(ctx context.Context) ([]User, error)
+
+	// SaveTOTP saves the two-factor authentication settings of the user
+	// with given ID, replacing the existing ones.
+	SaveTOTP(ctx context.Context, id string, t TOTP) error
+
+	// RetrieveTOTP retrieves the two-factor authentication settings of the
+	// user with given ID.
+	RetrieveTOTP(ctx context.Context, id string) (TOTP, error)
+
+	// RemoveTOTP removes the two-factor authentication settings of the user
+	// with given ID.
+	RemoveTOTP(ctx context.Context, id string) error
+
+	// UpdateTOTPCounter sets the counter of the last accepted code of the
+	// user with given ID. ErrConflict is returned if the stored counter is
+	// not lower than the given one, i.e. if the code is already used.
+	UpdateTOTPCounter(ctx context.Context, id string, counter int64) error
+
+	// RetrieveUnverified retrieves the pending user by its email.
+	RetrieveUnverified(ctx context.Context, email string) (User, error)
+
+	// RemoveUnverified removes the pending users registered before the given
+	// time and returns the number of removed users.
+	RemoveUnverified(ctx context.Context, before time.Time) (uint64, error)
 }
 
 func isEmail(email string) bool {
@@ -13,6 +13,7 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
 )
 
 const (
@@ -74,7 +75,7 @@ func New(things mainflux.ThingsServiceClient, pubsub messaging.PubSub) Service {
 
 // Publish publishes the message using the broker
 func (svc *adapterService) Publish(ctx context.Context, thingKey string, msg messaging.Message) error {
-	conn, err := svc.authorize(ctx, thingKey)
+	conn, err := svc.authorize(ctx, thingKey, msg.Channel)
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -83,9 +84,16 @@ func (svc *adapterService) Publish(ctx context.Context, thingKey string, msg mes
 		return ErrFailedMessagePublish
 	}
 
+	if err := messaging.ValidatePayload(conn, msg.Payload); err != nil {
+		return err
+	}
+
 	m := messaging.CreateMessage(conn, msg.Protocol, msg.Subtopic, &msg.Payload)
 
 	if err := svc.pubsub.Publish(m); err != nil {
+		if errors.Contains(err, ratelimit.ErrLimitExceeded) {
+			return err
+		}
 		return ErrFailedMessagePublish
 	}
 
@@ -98,7 +106,7 @@ func (svc *adapterService) Subscribe(ctx context.Context, thingKey, chanID, subt
 		return ErrUnauthorizedAccess
 	}
 
-	conn, err := svc.authorize(ctx, thingKey)
+	conn, err := svc.authorize(ctx, thingKey, chanID)
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -123,7 +131,7 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, thingKey, chanID, su
 		return ErrUnauthorizedAccess
 	}
 
-	conn, err := svc.authorize(ctx, thingKey)
+	conn, err := svc.authorize(ctx, thingKey, chanID)
 	if err != nil {
 		return ErrUnauthorizedAccess
 	}
@@ -136,9 +144,10 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, thingKey, chanID, su
 	return svc.pubsub.Unsubscribe(conn.ChannelID, subject)
 }
 
-func (svc *adapterService) authorize(ctx context.Context, thingKey string) (*mainflux.ConnByKeyRes, error) {
+func (svc *adapterService) authorize(ctx context.Context, thingKey, chanID string) (*mainflux.ConnByKeyRes, error) {
 	ar := &mainflux.ConnByKeyReq{
-		Key: thingKey,
+		Key:    thingKey,
+		ChanID: chanID,
 	}
 	conn, err := svc.things.GetConnByKey(ctx, ar)
 	if err != nil {
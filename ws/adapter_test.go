@@ -11,6 +11,8 @@ import (
 	"github.com/MainfluxLabs/mainflux"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
 	thmock "github.com/MainfluxLabs/mainflux/pkg/mocks"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
+	rlmocks "github.com/MainfluxLabs/mainflux/pkg/ratelimit/mocks"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/MainfluxLabs/mainflux/ws/mocks"
 	"github.com/stretchr/testify/assert"
@@ -68,7 +70,7 @@ func TestPublish(t *testing.T) {
 		{
 			desc:     "publish an empty message with valid thingKey",
 			thingKey: thingKey,
-			msg:      messaging.Message{},
+			msg:      messaging.Message{Channel: chanID},
 			err:      ws.ErrFailedMessagePublish,
 		},
 		{
@@ -91,6 +93,31 @@ func TestPublish(t *testing.T) {
 	}
 }
 
+func TestPublishRateLimit(t *testing.T) {
+	thingsClient := thmock.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
+	pubsub := ratelimit.NewPubSub(mocks.NewPubSub(), rlmocks.NewLimiter(1))
+	svc := ws.New(thingsClient, pubsub)
+
+	cases := []struct {
+		desc string
+		err  error
+	}{
+		{
+			desc: "publish a message within limit",
+			err:  nil,
+		},
+		{
+			desc: "publish a message over limit",
+			err:  ratelimit.ErrLimitExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		err := svc.Publish(context.Background(), thingKey, msg)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
+
 func TestSubscribe(t *testing.T) {
 	thingsClient := thmock.NewThingsServiceClient(map[string]string{thingKey: chanID}, nil)
 	svc, pubsub := newService(thingsClient)
@@ -150,7 +150,7 @@ func TestHandshake(t *testing.T) {
 			subtopic: "subtopic",
 			header:   true,
 			thingKey: thingKey,
-			status:   http.StatusSwitchingProtocols,
+			status:   http.StatusBadRequest,
 			msg:      msg,
 		},
 		{
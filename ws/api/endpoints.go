@@ -10,7 +10,9 @@ import (
 	"time"
 
 	"github.com/MainfluxLabs/mainflux/internal/apiutil"
+	"github.com/MainfluxLabs/mainflux/pkg/errors"
 	"github.com/MainfluxLabs/mainflux/pkg/messaging"
+	"github.com/MainfluxLabs/mainflux/pkg/ratelimit"
 	"github.com/MainfluxLabs/mainflux/ws"
 	"github.com/go-zoo/bone"
 	"github.com/gorilla/websocket"
@@ -56,8 +58,14 @@ func decodeRequest(r *http.Request) (getConnByKey, error) {
 		authKey = authKeys[0]
 	}
 
+	chanID := bone.GetValue(r, "id")
+	if chanID == "" {
+		return getConnByKey{}, ws.ErrEmptyID
+	}
+
 	req := getConnByKey{
 		thingKey: authKey,
+		chanID:   chanID,
 	}
 
 	subtopic, err := messaging.ExtractSubtopic(r.RequestURI)
@@ -100,18 +108,40 @@ func listen(conn *websocket.Conn, msgs chan<- []byte) {
 func process(svc ws.Service, req getConnByKey, msgs <-chan []byte) {
 	for msg := range msgs {
 		m := messaging.Message{
+			Channel:  req.chanID,
 			Subtopic: req.subtopic,
 			Protocol: "websocket",
 			Payload:  msg,
 			Created:  time.Now().UnixNano(),
 		}
-		svc.Publish(context.Background(), req.thingKey, m)
+		switch err := svc.Publish(context.Background(), req.thingKey, m); {
+		case errors.Contains(err, ratelimit.ErrLimitExceeded):
+			// Close the connection the same way MQTT adapter disconnects
+			// the client, so that the flooding device has to reconnect.
+			closeConn(req.conn, websocket.ClosePolicyViolation, err)
+		case errors.Contains(err, messaging.ErrSchemaValidation):
+			// Close the connection the same way MQTT adapter disconnects
+			// the client, so that the device is notified about the
+			// rejected message.
+			closeConn(req.conn, websocket.CloseInvalidFramePayloadData, err)
+		}
 	}
 	if err := svc.Unsubscribe(context.Background(), req.thingKey, req.chanID, req.subtopic); err != nil {
 		req.conn.Close()
 	}
 }
 
+// closeConn closes the connection, sending the error as the close reason.
+func closeConn(conn *websocket.Conn, code int, err error) {
+	reason := err.Error()
+	if len(reason) > maxCloseReasonSize {
+		reason = reason[:maxCloseReasonSize]
+	}
+	cm := websocket.FormatCloseMessage(code, reason)
+	conn.WriteControl(websocket.CloseMessage, cm, time.Now().Add(time.Second))
+	conn.Close()
+}
+
 func encodeError(w http.ResponseWriter, err error) {
 	statusCode := http.StatusUnauthorized
 
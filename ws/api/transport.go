@@ -18,6 +18,9 @@ import (
 const (
 	protocol            = "ws"
 	readwriteBufferSize = 1024
+	// maxCloseReasonSize is the maximum size of the close reason, which
+	// together with the close code fits the control frame payload.
+	maxCloseReasonSize = 123
 )
 
 var (
@@ -41,8 +44,6 @@ func MakeHandler(svc ws.Service, l log.Logger) http.Handler {
 	mux := bone.New()
 	mux.GetFunc("/channels/:id/messages", handshake(svc))
 	mux.GetFunc("/channels/:id/messages/*", handshake(svc))
-	mux.GetFunc("/messages", handshake(svc))
-	mux.GetFunc("/messages/*", handshake(svc))
 	mux.GetFunc("/version", mainflux.Health(protocol))
 	mux.Handle("/metrics", promhttp.Handler())
 